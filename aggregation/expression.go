@@ -18,6 +18,8 @@
 package aggregation
 
 import (
+	"sort"
+
 	"github.com/lindb/lindb/aggregation/fields"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/collections"
@@ -148,6 +150,9 @@ func (e *expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []collectio
 
 // funcCall calls the function
 func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
+	if expr.FuncType == function.Histogram || expr.FuncType == function.Quantile {
+		return e.quantileCall(expr)
+	}
 	var params []collections.FloatArray
 	for _, param := range expr.Params {
 		paramValues := e.eval(expr, param)
@@ -163,6 +168,60 @@ func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
 	return []collections.FloatArray{result}
 }
 
+// quantileCall calculates the quantile(params: 0=>quantile, 1=>field) based on histogram buckets,
+// if field is summary, returns the quantile values which calculated by client.
+func (e *expression) quantileCall(expr *stmt.CallExpr) []collections.FloatArray {
+	if len(expr.Params) != 2 {
+		return nil
+	}
+	q, ok := expr.Params[0].(*stmt.NumberLiteral)
+	if !ok {
+		return nil
+	}
+	fieldExpr, ok := expr.Params[1].(*stmt.FieldExpr)
+	if !ok {
+		return nil
+	}
+	type bucket struct {
+		upperBound float64
+		values     collections.FloatArray
+	}
+	var buckets []bucket
+	for fieldName, fieldValues := range e.fieldStore {
+		name, upperBound, ok := field.ParseBucketFieldName(fieldName)
+		if !ok || name != fieldExpr.Name {
+			continue
+		}
+		values := fieldValues.GetValues(expr.FuncType)
+		if len(values) == 0 {
+			continue
+		}
+		buckets = append(buckets, bucket{upperBound: upperBound, values: values[0]})
+	}
+	if len(buckets) == 0 {
+		// summary field
+		fieldValues, ok := e.fieldStore[field.QuantileFieldName(fieldExpr.Name, q.Val)]
+		if !ok {
+			return nil
+		}
+		return fieldValues.GetValues(expr.FuncType)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].upperBound < buckets[j].upperBound
+	})
+	upperBounds := make([]float64, len(buckets))
+	values := make([]collections.FloatArray, len(buckets))
+	for idx, b := range buckets {
+		upperBounds[idx] = b.upperBound
+		values[idx] = b.values
+	}
+	result := function.HistogramQuantile(q.Val, upperBounds, values)
+	if result == nil {
+		return nil
+	}
+	return []collections.FloatArray{result}
+}
+
 // binaryEval evaluates binary operator
 func (e *expression) binaryEval(expr *stmt.BinaryExpr) []collections.FloatArray {
 	binaryOP := expr.Operator
@@ -18,12 +18,14 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
@@ -338,3 +340,60 @@ func TestExpression_NotSupport_Expr(t *testing.T) {
 	resultSet = expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
 }
+
+func TestExpression_FuncCall_Quantile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeries := func(fieldName field.Name, fieldType field.Type, aggType field.AggType, value float64) series.Iterator {
+		it := series.NewMockFieldIterator(ctrl)
+		it.EXPECT().AggType().Return(aggType)
+		it.EXPECT().HasNext().Return(true)
+		it.EXPECT().Next().Return(50, value)
+		it.EXPECT().HasNext().Return(false)
+		timeSeries := series.NewMockIterator(ctrl)
+		timeSeries.EXPECT().FieldType().Return(fieldType)
+		timeSeries.EXPECT().FieldName().Return(fieldName)
+		timeSeries.EXPECT().HasNext().Return(true)
+		timeSeries.EXPECT().Next().Return(familyTime, it)
+		timeSeries.EXPECT().HasNext().Return(false)
+		return timeSeries
+	}
+	eval := func(sqlStr string, fieldSeries ...series.Iterator) map[string]collections.FloatArray {
+		q, _ := sql.Parse(sqlStr)
+		expression := NewExpression(timeutil.TimeRange{
+			Start: now,
+			End:   now + timeutil.OneHour*2,
+		}, timeutil.OneMinute, q.(*stmt.Query).SelectItems)
+		timeSeries := series.NewMockGroupedIterator(ctrl)
+		for _, s := range fieldSeries {
+			timeSeries.EXPECT().HasNext().Return(true)
+			timeSeries.EXPECT().Next().Return(s)
+		}
+		timeSeries.EXPECT().HasNext().Return(false)
+		expression.Eval(timeSeries)
+		return expression.ResultSet()
+	}
+	// case 1: histogram buckets
+	resultSet := eval("select quantile(0.5, latency) as p50, histogram(0.99, latency) from cpu",
+		mockSeries(field.BucketFieldName("latency", math.Inf(1)), field.HistogramField, field.Sum, 40),
+		mockSeries(field.BucketFieldName("latency", 1), field.HistogramField, field.Sum, 10),
+		mockSeries(field.BucketFieldName("latency", 2), field.HistogramField, field.Sum, 20),
+		mockSeries(field.BucketFieldName("latency", 4), field.HistogramField, field.Sum, 40),
+		mockSeries(field.CountFieldName("latency"), field.SumField, field.Sum, 40),
+	)
+	assert.Equal(t, 2, len(resultSet))
+	assert.Equal(t, 2.0, resultSet["p50"].GetValue(50-10))
+	assert.InDelta(t, 3.96, resultSet["histogram(0.99,latency)"].GetValue(50-10), 1e-9)
+	// case 2: summary quantile
+	resultSet = eval("select quantile(0.99, latency) as p99, quantile(0.5, latency) as p50 from cpu",
+		mockSeries(field.QuantileFieldName("latency", 0.99), field.SummaryField, field.Replace, 3.5),
+	)
+	assert.Equal(t, 1, len(resultSet))
+	assert.Equal(t, 3.5, resultSet["p99"].GetValue(50-10))
+	// case 3: bad params
+	resultSet = eval("select quantile(latency), quantile(0.5, 1), quantile(f, latency) from cpu",
+		mockSeries(field.BucketFieldName("latency", math.Inf(1)), field.HistogramField, field.Sum, 40),
+	)
+	assert.Equal(t, 0, len(resultSet))
+}
@@ -22,7 +22,8 @@ import "github.com/lindb/lindb/pkg/collections"
 // FuncCall calls the function calc by function type and params
 func FuncCall(funcType FuncType, params ...collections.FloatArray) collections.FloatArray {
 	switch funcType {
-	case Sum, Min, Max, Count:
+	case Sum, Min, Max, Count, Rate, IRate, Delta, Derivative:
+		// rate/irate/delta/derivative already calculated per series before merging series
 		if len(params) == 0 {
 			return nil
 		}
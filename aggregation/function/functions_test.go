@@ -62,3 +62,10 @@ func TestFuncCall_Avg(t *testing.T) {
 	assert.False(t, result.HasValue(2))
 	assert.False(t, result.HasValue(3))
 }
+
+func TestFuncCall_Rate(t *testing.T) {
+	assert.Nil(t, FuncCall(Rate))
+	array := collections.NewFloatArray(10)
+	assert.Equal(t, array, FuncCall(Rate, array))
+	assert.Equal(t, array, FuncCall(Derivative, array))
+}
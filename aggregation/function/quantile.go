@@ -0,0 +1,100 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// HistogramQuantile calculates the q-quantile(0<=q<=1) from the histogram buckets,
+// upper bounds must be in ascending order and the last one must be +Inf,
+// buckets are the count of observed values which are less than or equal to upper bound(cumulative).
+// The value is linear interpolated within the bucket which the quantile falls into, like prometheus.
+func HistogramQuantile(q float64, upperBounds []float64, buckets []collections.FloatArray) collections.FloatArray {
+	if q < 0 || q > 1 || len(buckets) < 2 || len(upperBounds) != len(buckets) {
+		return nil
+	}
+	if !math.IsInf(upperBounds[len(upperBounds)-1], 1) {
+		return nil
+	}
+	capacity := buckets[0].Capacity()
+	result := collections.NewFloatArray(capacity)
+	bounds := make([]float64, 0, len(buckets))
+	counts := make([]float64, 0, len(buckets))
+	for idx := 0; idx < capacity; idx++ {
+		bounds = bounds[:0]
+		counts = counts[:0]
+		for i, bucket := range buckets {
+			if !bucket.HasValue(idx) {
+				continue
+			}
+			count := bucket.GetValue(idx)
+			if len(counts) > 0 && count < counts[len(counts)-1] {
+				// make sure cumulative count is monotonic, maybe some series lost
+				count = counts[len(counts)-1]
+			}
+			bounds = append(bounds, upperBounds[i])
+			counts = append(counts, count)
+		}
+		if value, ok := bucketQuantile(q, bounds, counts); ok {
+			result.SetValue(idx, value)
+		}
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return result
+}
+
+// bucketQuantile calculates the quantile value for one point
+func bucketQuantile(q float64, bounds, counts []float64) (float64, bool) {
+	last := len(counts) - 1
+	if last < 1 || !math.IsInf(bounds[last], 1) {
+		return 0, false
+	}
+	total := counts[last]
+	if total == 0 {
+		return 0, false
+	}
+	rank := q * total
+	b := 0
+	for b < last && counts[b] < rank {
+		b++
+	}
+	switch {
+	case b == last:
+		// quantile falls into +Inf bucket, returns the upper bound of the second last bucket
+		return bounds[last-1], true
+	case b == 0 && bounds[0] <= 0:
+		return bounds[0], true
+	}
+	bucketStart := 0.0
+	bucketEnd := bounds[b]
+	count := counts[b]
+	if b > 0 {
+		bucketStart = bounds[b-1]
+		count -= counts[b-1]
+		rank -= counts[b-1]
+	}
+	if count == 0 {
+		return bucketStart, true
+	}
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count), true
+}
@@ -0,0 +1,64 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	newBuckets := func(counts ...float64) []collections.FloatArray {
+		var buckets []collections.FloatArray
+		for _, count := range counts {
+			bucket := collections.NewFloatArray(2)
+			bucket.SetValue(0, count)
+			buckets = append(buckets, bucket)
+		}
+		return buckets
+	}
+	bounds := []float64{1, 2, 4, math.Inf(1)}
+	// case 1: invalid params
+	assert.Nil(t, HistogramQuantile(-0.1, bounds, newBuckets(1, 2, 3, 4)))
+	assert.Nil(t, HistogramQuantile(1.1, bounds, newBuckets(1, 2, 3, 4)))
+	assert.Nil(t, HistogramQuantile(0.5, bounds, newBuckets(1, 2)))
+	assert.Nil(t, HistogramQuantile(0.5, []float64{1, 2}, newBuckets(1, 2)))
+	// case 2: no observed values
+	assert.Nil(t, HistogramQuantile(0.5, bounds, newBuckets(0, 0, 0, 0)))
+	// case 3: linear interpolation
+	result := HistogramQuantile(0.5, bounds, newBuckets(10, 20, 40, 40))
+	assert.Equal(t, 2.0, result.GetValue(0))
+	assert.False(t, result.HasValue(1))
+	result = HistogramQuantile(0.75, bounds, newBuckets(10, 20, 40, 40))
+	assert.Equal(t, 3.0, result.GetValue(0))
+	result = HistogramQuantile(0.1, bounds, newBuckets(10, 20, 40, 40))
+	assert.Equal(t, 0.4, result.GetValue(0))
+	// case 4: falls into +Inf bucket
+	result = HistogramQuantile(0.99, bounds, newBuckets(10, 20, 40, 100))
+	assert.Equal(t, 4.0, result.GetValue(0))
+	// case 5: non monotonic count
+	result = HistogramQuantile(0.5, bounds, newBuckets(10, 5, 40, 40))
+	assert.InDelta(t, 2+2.0/3, result.GetValue(0), 1e-9)
+	// case 6: negative bound
+	result = HistogramQuantile(0, []float64{-1, math.Inf(1)}, newBuckets(10, 20))
+	assert.Equal(t, -1.0, result.GetValue(0))
+}
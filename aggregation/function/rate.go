@@ -0,0 +1,120 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+// IsRateFunc checks if the function calculates the change of a series(rate/irate/delta/derivative),
+// these functions need be calculated per series before merging the series of a group.
+func IsRateFunc(funcType FuncType) bool {
+	switch funcType {
+	case Rate, IRate, Delta, Derivative:
+		return true
+	default:
+		return false
+	}
+}
+
+// RateCalculator calculates rate/irate/delta/derivative point by point for one series,
+// the points must be in time order.
+//  1. rate: per-second increase since previous point, handles counter reset;
+//  2. irate: per-second increase between two adjacent points(no missing interval), handles counter reset;
+//  3. delta: difference between current point and previous point(gauge);
+//  4. derivative: per-second difference between current point and previous point(gauge);
+//  5. histogram: increase of histogram bucket/count/sum since previous point, handles counter reset.
+//
+// If values are not cumulative(increments of each interval), the value of point is the increase/difference.
+type RateCalculator struct {
+	funcType   FuncType
+	interval   int64
+	cumulative bool
+
+	hasPrev   bool
+	prevTime  int64
+	prevValue float64
+}
+
+// NewRateCalculator creates a rate calculator, interval is the interval(ms) of points
+func NewRateCalculator(funcType FuncType, interval int64, cumulative bool) *RateCalculator {
+	return &RateCalculator{
+		funcType:   funcType,
+		interval:   interval,
+		cumulative: cumulative,
+	}
+}
+
+// Calc calculates the result of current point(timestamp ms) by previous point,
+// returns false if cannot calculate, like first point of cumulative series, missing previous interval for irate.
+func (c *RateCalculator) Calc(timestamp int64, value float64) (float64, bool) {
+	if !c.cumulative {
+		return c.calcIncrement(value)
+	}
+	defer func() {
+		c.hasPrev = true
+		c.prevTime = timestamp
+		c.prevValue = value
+	}()
+	if !c.hasPrev || timestamp <= c.prevTime {
+		return 0, false
+	}
+	elapsed := timestamp - c.prevTime
+	diff := value - c.prevValue
+	switch c.funcType {
+	case Rate, IRate:
+		if c.funcType == IRate && elapsed != c.interval {
+			return 0, false
+		}
+		if diff < 0 {
+			// counter reset, counter restarts from zero
+			diff = value
+		}
+		return diff * 1000 / float64(elapsed), true
+	case Histogram:
+		if diff < 0 {
+			// counter reset, counter restarts from zero
+			diff = value
+		}
+		return diff, true
+	case Delta:
+		return diff, true
+	case Derivative:
+		return diff * 1000 / float64(elapsed), true
+	default:
+		return 0, false
+	}
+}
+
+// calcIncrement calculates the result of increment value of an interval
+func (c *RateCalculator) calcIncrement(value float64) (float64, bool) {
+	switch c.funcType {
+	case Rate, IRate, Derivative:
+		if c.interval <= 0 {
+			return 0, false
+		}
+		return value * 1000 / float64(c.interval), true
+	case Delta, Histogram:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+// Reset resets the context of calculator for calculating next series
+func (c *RateCalculator) Reset() {
+	c.hasPrev = false
+	c.prevTime = 0
+	c.prevValue = 0
+}
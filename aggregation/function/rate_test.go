@@ -0,0 +1,94 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ratePoint struct {
+	timestamp int64
+	value     float64
+}
+
+func calcRate(c *RateCalculator, points []ratePoint) (result []float64) {
+	for _, p := range points {
+		if v, ok := c.Calc(p.timestamp, p.value); ok {
+			result = append(result, v)
+		}
+	}
+	return
+}
+
+func TestIsRateFunc(t *testing.T) {
+	assert.True(t, IsRateFunc(Rate))
+	assert.True(t, IsRateFunc(IRate))
+	assert.True(t, IsRateFunc(Delta))
+	assert.True(t, IsRateFunc(Derivative))
+	assert.False(t, IsRateFunc(Sum))
+	assert.False(t, IsRateFunc(Quantile))
+}
+
+func TestRateCalculator_Cumulative(t *testing.T) {
+	// 10s interval, missing point at 30s, counter reset at 50s
+	points := []ratePoint{{10000, 100}, {20000, 150}, {40000, 250}, {50000, 20}, {50000, 30}}
+
+	c := NewRateCalculator(Rate, 10000, true)
+	assert.Equal(t, []float64{5, 5, 2}, calcRate(c, points))
+
+	c = NewRateCalculator(IRate, 10000, true)
+	assert.Equal(t, []float64{5, 2}, calcRate(c, points))
+
+	c = NewRateCalculator(Delta, 10000, true)
+	assert.Equal(t, []float64{50, 100, -230}, calcRate(c, points))
+
+	c = NewRateCalculator(Derivative, 10000, true)
+	assert.Equal(t, []float64{5, 5, -23}, calcRate(c, points))
+
+	// reset for next series
+	c.Reset()
+	assert.Equal(t, []float64{1}, calcRate(c, []ratePoint{{10000, 10}, {20000, 20}}))
+
+	c = NewRateCalculator(Histogram, 10000, true)
+	assert.Equal(t, []float64{50, 100, 20}, calcRate(c, points))
+
+	c = NewRateCalculator(Sum, 10000, true)
+	assert.Empty(t, calcRate(c, points))
+}
+
+func TestRateCalculator_Increment(t *testing.T) {
+	points := []ratePoint{{10000, 100}, {20000, 50}}
+
+	c := NewRateCalculator(Rate, 10000, false)
+	assert.Equal(t, []float64{10, 5}, calcRate(c, points))
+	c = NewRateCalculator(IRate, 10000, false)
+	assert.Equal(t, []float64{10, 5}, calcRate(c, points))
+	c = NewRateCalculator(Derivative, 10000, false)
+	assert.Equal(t, []float64{10, 5}, calcRate(c, points))
+	c = NewRateCalculator(Delta, 10000, false)
+	assert.Equal(t, []float64{100, 50}, calcRate(c, points))
+	c = NewRateCalculator(Histogram, 10000, false)
+	assert.Equal(t, []float64{100, 50}, calcRate(c, points))
+
+	c = NewRateCalculator(Rate, 0, false)
+	assert.Empty(t, calcRate(c, points))
+	c = NewRateCalculator(Sum, 10000, false)
+	assert.Empty(t, calcRate(c, points))
+}
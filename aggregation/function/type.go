@@ -29,6 +29,11 @@ const (
 	Replace
 	Histogram
 	Stddev
+	Quantile
+	Rate
+	IRate
+	Delta
+	Derivative
 
 	Unknown
 )
@@ -52,6 +57,16 @@ func (t FuncType) String() string {
 		return "histogram"
 	case Stddev:
 		return "stddev"
+	case Quantile:
+		return "quantile"
+	case Rate:
+		return "rate"
+	case IRate:
+		return "irate"
+	case Delta:
+		return "delta"
+	case Derivative:
+		return "derivative"
 	default:
 		return "unknown"
 	}
@@ -32,5 +32,10 @@ func TestFuncTypeString(t *testing.T) {
 	assert.Equal(t, "replace", Replace.String())
 	assert.Equal(t, "histogram", Histogram.String())
 	assert.Equal(t, "stddev", Stddev.String())
+	assert.Equal(t, "quantile", Quantile.String())
+	assert.Equal(t, "rate", Rate.String())
+	assert.Equal(t, "irate", IRate.String())
+	assert.Equal(t, "delta", Delta.String())
+	assert.Equal(t, "derivative", Derivative.String())
 	assert.Equal(t, "unknown", Unknown.String())
 }
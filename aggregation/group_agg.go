@@ -18,8 +18,10 @@
 package aggregation
 
 import (
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
 )
 
 //go:generate mockgen -source=./group_agg.go -destination=./group_agg_mock.go -package=aggregation
@@ -30,26 +32,45 @@ type GroupingAggregator interface {
 	Aggregate(it series.GroupedIterator)
 	// ResultSet returns the result set of aggregator
 	ResultSet() []series.GroupedIterator
+	// DrainResultSet returns the result set of aggregator batch by batch(at most batchSize series per batch),
+	// removes the returned series from aggregator, so that the caller can handle and release the returned series
+	// before all series are built.
+	DrainResultSet(batchSize int, fn func(seriesList []series.GroupedIterator))
 }
 
 type groupingAggregator struct {
-	aggSpecs   AggregatorSpecs
-	interval   timeutil.Interval
-	timeRange  timeutil.TimeRange
-	aggregates map[string]FieldAggregates // tag values => field aggregates
+	aggSpecs    AggregatorSpecs
+	interval    timeutil.Interval
+	timeRange   timeutil.TimeRange
+	isRawSeries bool                             // aggregates the raw series read from storage, not the merged series of other nodes
+	aggregates  map[string]FieldAggregates       // tag values => field aggregates
+	rateFuncs   map[field.Name]function.FuncType // field name => rate function which calculated per series
 }
 
-// NewGroupingAggregator creates a grouping aggregator
+// NewGroupingAggregator creates a grouping aggregator,
+// rate function and histogram increase are calculated per series only if aggregates the raw series of storage,
+// because the merged series of storage/intermediate node are already calculated.
 func NewGroupingAggregator(
 	interval timeutil.Interval,
 	timeRange timeutil.TimeRange,
+	isRawSeries bool,
 	aggSpecs AggregatorSpecs,
 ) GroupingAggregator {
+	rateFuncs := make(map[field.Name]function.FuncType)
+	for _, aggSpec := range aggSpecs {
+		for funcType := range aggSpec.Functions() {
+			if function.IsRateFunc(funcType) {
+				rateFuncs[aggSpec.FieldName()] = funcType
+			}
+		}
+	}
 	return &groupingAggregator{
-		aggSpecs:   aggSpecs,
-		interval:   interval,
-		timeRange:  timeRange,
-		aggregates: make(map[string]FieldAggregates),
+		aggSpecs:    aggSpecs,
+		interval:    interval,
+		timeRange:   timeRange,
+		isRawSeries: isRawSeries,
+		aggregates:  make(map[string]FieldAggregates),
+		rateFuncs:   rateFuncs,
 	}
 }
 
@@ -71,10 +92,16 @@ func (ga *groupingAggregator) Aggregate(it series.GroupedIterator) {
 			}
 		}
 		if sAgg == nil {
-			continue
+			// histogram/summary field is stored as multiple fields, query only with base field name
+			sAgg = ga.getHistogramAggregator(tags, fieldName)
+			if sAgg == nil {
+				continue
+			}
+			seriesAgg = ga.aggregates[tags]
 		}
 		// set field type for aggregate
 		sAgg.SetFieldType(fieldType)
+		seriesIt = ga.seriesIterator(seriesIt)
 		// 2. merge the field series data
 		for seriesIt.HasNext() {
 			startTime, fieldIt := seriesIt.Next()
@@ -90,6 +117,24 @@ func (ga *groupingAggregator) Aggregate(it series.GroupedIterator) {
 	}
 }
 
+// seriesIterator returns the iterator which calculates rate function/histogram increase per series
+// before merging series, if aggregates the raw series of storage, else returns the series directly.
+func (ga *groupingAggregator) seriesIterator(seriesIt series.Iterator) series.Iterator {
+	if !ga.isRawSeries {
+		return seriesIt
+	}
+	if funcType, ok := ga.rateFuncs[seriesIt.FieldName()]; ok {
+		// calculates rate function per series before merging series
+		return newRateIterator(seriesIt, funcType, ga.interval.Int64())
+	}
+	if seriesIt.FieldType() == field.HistogramField {
+		// histogram bucket/count/sum are stored as cumulative value,
+		// calculates the increase per series before merging series
+		return newRateIterator(seriesIt, function.Histogram, ga.interval.Int64())
+	}
+	return seriesIt
+}
+
 // ResultSet returns the result set of aggregator
 func (ga *groupingAggregator) ResultSet() []series.GroupedIterator {
 	length := len(ga.aggregates)
@@ -105,6 +150,27 @@ func (ga *groupingAggregator) ResultSet() []series.GroupedIterator {
 	return seriesList
 }
 
+// DrainResultSet returns the result set of aggregator batch by batch(at most batchSize series per batch),
+// removes the returned series from aggregator, so that the caller can handle and release the returned series
+// before all series are built.
+func (ga *groupingAggregator) DrainResultSet(batchSize int, fn func(seriesList []series.GroupedIterator)) {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	var seriesList []series.GroupedIterator
+	for tags, aggregator := range ga.aggregates {
+		seriesList = append(seriesList, aggregator.ResultSet(tags))
+		delete(ga.aggregates, tags)
+		if len(seriesList) == batchSize {
+			fn(seriesList)
+			seriesList = nil
+		}
+	}
+	if len(seriesList) > 0 {
+		fn(seriesList)
+	}
+}
+
 // getAggregator returns the time series aggregator by time series's tags
 func (ga *groupingAggregator) getAggregator(tags string) (agg FieldAggregates) {
 	// 2. get series aggregator
@@ -115,3 +181,24 @@ func (ga *groupingAggregator) getAggregator(tags string) (agg FieldAggregates) {
 	}
 	return
 }
+
+// getHistogramAggregator returns the aggregator of histogram bucket/summary quantile field,
+// if base field name of it in aggregator specs, creates a new one and adds it into the time series aggregator.
+func (ga *groupingAggregator) getHistogramAggregator(tags string, fieldName field.Name) SeriesAggregator {
+	name, _, ok := field.ParseBucketFieldName(fieldName)
+	if !ok {
+		name, _, ok = field.ParseQuantileFieldName(fieldName)
+	}
+	if !ok {
+		return nil
+	}
+	for _, aggSpec := range ga.aggSpecs {
+		if string(aggSpec.FieldName()) != name {
+			continue
+		}
+		sAgg := NewSeriesAggregator(ga.interval, 1, ga.timeRange, false, NewAggregatorSpec(fieldName))
+		ga.aggregates[tags] = append(ga.aggregates[tags], sAgg)
+		return sAgg
+	}
+	return nil
+}
@@ -17,6 +17,18 @@
 
 package aggregation
 
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
 //TODO need impl
 //func TestGroupByAggregator_Aggregate(t *testing.T) {
 //	ctrl := gomock.NewController(t)
@@ -101,3 +113,102 @@ package aggregation
 //	rs = agg.ResultSet()
 //	assert.Nil(t, rs)
 //}
+
+func TestGroupingAggregator_getHistogramAggregator(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
+	agg := NewGroupingAggregator(
+		timeutil.Interval(timeutil.OneSecond),
+		timeutil.TimeRange{
+			Start: now,
+			End:   now + 3*timeutil.OneHour,
+		},
+		true,
+		AggregatorSpecs{NewAggregatorSpec("latency")})
+	gAgg := agg.(*groupingAggregator)
+	_ = gAgg.getAggregator("1.1.1.1")
+	assert.Nil(t, gAgg.getHistogramAggregator("1.1.1.1", "latency"))
+	assert.Nil(t, gAgg.getHistogramAggregator("1.1.1.1", field.BucketFieldName("rt", 1)))
+	sAgg := gAgg.getHistogramAggregator("1.1.1.1", field.BucketFieldName("latency", 1))
+	assert.NotNil(t, sAgg)
+	assert.Equal(t, field.Name("latency__bucket_1"), sAgg.FieldName())
+	sAgg = gAgg.getHistogramAggregator("1.1.1.1", field.QuantileFieldName("latency", 0.99))
+	assert.NotNil(t, sAgg)
+	assert.Len(t, gAgg.aggregates["1.1.1.1"], 3)
+}
+
+func TestGroupingAggregator_rateFuncs(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
+	rateSpec := NewDownSamplingSpec("requests", field.SumField)
+	rateSpec.AddFunctionType(function.Rate)
+	sumSpec := NewDownSamplingSpec("bytes", field.SumField)
+	sumSpec.AddFunctionType(function.Sum)
+	agg := NewGroupingAggregator(
+		timeutil.Interval(timeutil.OneSecond),
+		timeutil.TimeRange{
+			Start: now,
+			End:   now + 3*timeutil.OneHour,
+		},
+		true,
+		AggregatorSpecs{rateSpec, sumSpec})
+	gAgg := agg.(*groupingAggregator)
+	assert.Len(t, gAgg.rateFuncs, 1)
+	assert.Equal(t, function.Rate, gAgg.rateFuncs["requests"])
+}
+
+func TestGroupingAggregator_histogram_roundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interval := timeutil.Interval(10 * timeutil.OneSecond)
+	timeRange := timeutil.TimeRange{Start: 0, End: timeutil.OneHour}
+	specs := AggregatorSpecs{NewAggregatorSpec("latency")}
+	newSeries := func(values map[int]float64) series.Iterator {
+		sIt := series.NewMockIterator(ctrl)
+		sIt.EXPECT().FieldName().Return(field.Name("latency")).AnyTimes()
+		sIt.EXPECT().FieldType().Return(field.HistogramField).AnyTimes()
+		gomock.InOrder(
+			sIt.EXPECT().HasNext().Return(true),
+			sIt.EXPECT().Next().Return(int64(0), newTestFieldIterator(0, values)),
+			sIt.EXPECT().HasNext().Return(false),
+		)
+		return sIt
+	}
+	// storage side calculates the increase of cumulative histogram field
+	storageAgg := NewGroupingAggregator(interval, timeRange, true, specs).(*groupingAggregator)
+	increase := readRateIterator(storageAgg.seriesIterator(newSeries(map[int]float64{0: 100, 1: 150, 2: 250, 3: 20})))
+	assert.Equal(t, []float64{50, 100, 20}, increase)
+
+	// broker side merges the increase of storage nodes directly
+	brokerAgg := NewGroupingAggregator(interval, timeRange, false, specs).(*groupingAggregator)
+	it := brokerAgg.seriesIterator(newSeries(map[int]float64{0: 50, 1: 100, 2: 20}))
+	assert.Equal(t, increase, readRateIterator(it))
+}
+
+func TestGroupingAggregator_DrainResultSet(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
+	agg := NewGroupingAggregator(
+		timeutil.Interval(timeutil.OneSecond),
+		timeutil.TimeRange{
+			Start: now,
+			End:   now + 3*timeutil.OneHour,
+		},
+		false,
+		AggregatorSpecs{NewAggregatorSpec("latency")})
+	gAgg := agg.(*groupingAggregator)
+	for _, tags := range []string{"1.1.1.1", "1.1.1.2", "1.1.1.3"} {
+		_ = gAgg.getAggregator(tags)
+	}
+	var batches []int
+	tags := make(map[string]struct{})
+	agg.DrainResultSet(2, func(seriesList []series.GroupedIterator) {
+		batches = append(batches, len(seriesList))
+		for _, it := range seriesList {
+			tags[it.Tags()] = struct{}{}
+		}
+	})
+	assert.Equal(t, []int{2, 1}, batches)
+	assert.Len(t, tags, 3)
+	// drained series are removed from aggregator
+	assert.Empty(t, gAgg.aggregates)
+	assert.Nil(t, agg.ResultSet())
+}
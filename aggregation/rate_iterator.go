@@ -0,0 +1,130 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// rateIterator implements series.Iterator, calculates rate/irate/delta/derivative of one series
+// before merging the series of a group, the results are summed when merging series.
+type rateIterator struct {
+	series.Iterator
+	funcType    function.FuncType
+	interval    int64
+	cumulative  bool
+	calculators map[field.AggType]*function.RateCalculator
+}
+
+// newRateIterator creates an iterator which calculates rate function for the series,
+// the value of increase field is the increment of each interval, others are cumulative values.
+func newRateIterator(it series.Iterator, funcType function.FuncType, interval int64) series.Iterator {
+	return &rateIterator{
+		Iterator:    it,
+		funcType:    funcType,
+		interval:    interval,
+		cumulative:  it.FieldType() != field.IncreaseField,
+		calculators: make(map[field.AggType]*function.RateCalculator),
+	}
+}
+
+// Next returns the field's iterator which calculates rate function and segment start time,
+// keeps the calculate context between segments.
+func (it *rateIterator) Next() (startTime int64, fieldIt series.FieldIterator) {
+	startTime, fieldIt = it.Iterator.Next()
+	if fieldIt == nil {
+		return
+	}
+	calc, ok := it.calculators[fieldIt.AggType()]
+	if !ok {
+		calc = function.NewRateCalculator(it.funcType, it.interval, it.cumulative)
+		it.calculators[fieldIt.AggType()] = calc
+	}
+	return startTime, &rateFieldIterator{
+		startTime: startTime,
+		interval:  it.interval,
+		it:        fieldIt,
+		calc:      calc,
+	}
+}
+
+// MarshalBinary marshals the data
+func (it *rateIterator) MarshalBinary() ([]byte, error) {
+	return series.MarshalIterator(it)
+}
+
+// rateFieldIterator implements series.FieldIterator, skips the points which cannot calculate rate
+type rateFieldIterator struct {
+	startTime int64
+	interval  int64
+	it        series.FieldIterator
+	calc      *function.RateCalculator
+
+	slot  int
+	value float64
+}
+
+// AggType returns sum agg type, because the rate of series are summed when merging series.
+func (it *rateFieldIterator) AggType() field.AggType {
+	return field.Sum
+}
+
+// HasNext returns if the iteration has more point which can calculate rate
+func (it *rateFieldIterator) HasNext() bool {
+	for it.it.HasNext() {
+		slot, value := it.it.Next()
+		if slot < 0 {
+			continue
+		}
+		result, ok := it.calc.Calc(it.startTime+int64(slot)*it.interval, value)
+		if ok {
+			it.slot = slot
+			it.value = result
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the data point in the iteration
+func (it *rateFieldIterator) Next() (timeSlot int, value float64) {
+	return it.slot, it.value
+}
+
+// MarshalBinary marshals the data
+func (it *rateFieldIterator) MarshalBinary() ([]byte, error) {
+	var slots []int
+	var values []float64
+	for it.HasNext() {
+		slot, value := it.Next()
+		slots = append(slots, slot)
+		values = append(values, value)
+	}
+	if len(slots) == 0 {
+		return nil, nil
+	}
+	startSlot := slots[0]
+	array := collections.NewFloatArray(slots[len(slots)-1] - startSlot + 1)
+	for idx, slot := range slots {
+		array.SetValue(slot-startSlot, values[idx])
+	}
+	return newFieldIterator(startSlot, field.Sum, array).MarshalBinary()
+}
@@ -0,0 +1,123 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func newTestFieldIterator(startSlot int, values map[int]float64) series.FieldIterator {
+	array := collections.NewFloatArray(10)
+	for idx, value := range values {
+		array.SetValue(idx, value)
+	}
+	return newFieldIterator(startSlot, field.Max, array)
+}
+
+func readRateIterator(it series.Iterator) (result []float64) {
+	for it.HasNext() {
+		_, fIt := it.Next()
+		if fIt == nil {
+			continue
+		}
+		for fIt.HasNext() {
+			_, value := fIt.Next()
+			result = append(result, value)
+		}
+	}
+	return
+}
+
+func TestRateIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interval := 10 * timeutil.OneSecond
+	sIt := series.NewMockIterator(ctrl)
+	sIt.EXPECT().FieldType().Return(field.MaxField)
+	gomock.InOrder(
+		sIt.EXPECT().HasNext().Return(true),
+		sIt.EXPECT().Next().Return(int64(0), newTestFieldIterator(0, map[int]float64{0: 100, 1: 150, 3: 250})),
+		sIt.EXPECT().HasNext().Return(true),
+		sIt.EXPECT().Next().Return(int64(0), nil),
+		sIt.EXPECT().HasNext().Return(true),
+		// counter reset in next segment
+		sIt.EXPECT().Next().Return(4*interval, newTestFieldIterator(0, map[int]float64{0: 20})),
+		sIt.EXPECT().HasNext().Return(false),
+	)
+	it := newRateIterator(sIt, function.Rate, interval)
+	assert.Equal(t, []float64{5, 5, 2}, readRateIterator(it))
+
+	// increase field
+	sIt.EXPECT().FieldType().Return(field.IncreaseField)
+	gomock.InOrder(
+		sIt.EXPECT().HasNext().Return(true),
+		sIt.EXPECT().Next().Return(int64(0), newTestFieldIterator(0, map[int]float64{0: 100, 1: 150})),
+		sIt.EXPECT().HasNext().Return(false),
+	)
+	it = newRateIterator(sIt, function.Delta, interval)
+	assert.Equal(t, []float64{100, 150}, readRateIterator(it))
+}
+
+func TestRateIterator_MarshalBinary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interval := 10 * timeutil.OneSecond
+	sIt := series.NewMockIterator(ctrl)
+	sIt.EXPECT().FieldType().Return(field.SumField).AnyTimes()
+	gomock.InOrder(
+		sIt.EXPECT().HasNext().Return(true),
+		sIt.EXPECT().Next().Return(int64(0), newTestFieldIterator(0, map[int]float64{0: 100, 1: 150, 3: 250})),
+		sIt.EXPECT().HasNext().Return(false),
+	)
+	data, err := newRateIterator(sIt, function.Rate, interval).MarshalBinary()
+	assert.NoError(t, err)
+
+	expect := collections.NewFloatArray(10)
+	expect.SetValue(0, 5)
+	expect.SetValue(2, 5)
+	gomock.InOrder(
+		sIt.EXPECT().HasNext().Return(true),
+		sIt.EXPECT().Next().Return(int64(0), newFieldIterator(1, field.Sum, expect)),
+		sIt.EXPECT().HasNext().Return(false),
+	)
+	expectData, err := series.MarshalIterator(sIt)
+	assert.NoError(t, err)
+	assert.Equal(t, expectData, data)
+
+	// no point can calculate rate
+	fIt := &rateFieldIterator{
+		interval: interval,
+		it:       newTestFieldIterator(0, map[int]float64{0: 100}),
+		calc:     function.NewRateCalculator(function.Rate, interval, true),
+	}
+	data, err = fIt.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	assert.Equal(t, field.Sum, fIt.AggType())
+}
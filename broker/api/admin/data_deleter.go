@@ -0,0 +1,125 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// DataDeleterAPI represents the data delete api, supports drop database/metric and delete series by sql
+type DataDeleterAPI struct {
+	master          coordinator.Master
+	databaseService service.DatabaseService
+}
+
+// NewDataDeleterAPI creates data deleter api
+func NewDataDeleterAPI(master coordinator.Master, databaseService service.DatabaseService) *DataDeleterAPI {
+	return &DataDeleterAPI{
+		master:          master,
+		databaseService: databaseService,
+	}
+}
+
+// Delete submits the task which drops database/metric or deletes series based on the sql,
+// 1) drop database db(need db param which is same as dropped database)
+// 2) drop metric on ns cpu(need db param)
+// 3) delete series on ns from cpu where host='1.1.1.1'(need db param)
+func (d *DataDeleterAPI) Delete(w http.ResponseWriter, r *http.Request) {
+	ql, err := api.GetParamsFromRequest("sql", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	statement, err := sql.Parse(ql)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if s, ok := statement.(*stmt.DropDatabase); ok {
+		// the permission is authorized by db param, so the dropped database must be same as db param
+		databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+		if err != nil {
+			api.Error(w, err)
+			return
+		}
+		if databaseName != s.Database {
+			api.Error(w, fmt.Errorf("dropped database[%s] not match db param[%s]", s.Database, databaseName))
+			return
+		}
+	}
+	if !d.master.IsMaster() {
+		// if current node is not master, need forward to master node
+		if err := forwardToMaster(d.master, r); err != nil {
+			api.Error(w, err)
+			return
+		}
+		api.OK(w, "success")
+		return
+	}
+	switch s := statement.(type) {
+	case *stmt.DropDatabase:
+		err = d.dropDatabase(s.Database)
+	case *stmt.DropMetric:
+		err = d.deleteMetricData(r, func(cluster, databaseName string) error {
+			return d.master.DropMetric(cluster, databaseName, s.Namespace, s.MetricName)
+		})
+	case *stmt.DeleteSeries:
+		err = d.deleteMetricData(r, func(cluster, databaseName string) error {
+			return d.master.DeleteSeries(cluster, databaseName, s.Namespace, s.MetricName, s.Condition)
+		})
+	default:
+		err = fmt.Errorf("not drop/delete statement")
+	}
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, "success")
+}
+
+// dropDatabase drops the data of database in storage cluster, then removes database config
+func (d *DataDeleterAPI) dropDatabase(databaseName string) error {
+	database, err := d.databaseService.Get(databaseName)
+	if err != nil {
+		return err
+	}
+	if err := d.master.DropDatabase(database.Cluster, databaseName); err != nil {
+		return err
+	}
+	return d.databaseService.Delete(databaseName)
+}
+
+// deleteMetricData deletes the data of metric in storage cluster which database belongs to
+func (d *DataDeleterAPI) deleteMetricData(r *http.Request, deleteFn func(cluster, databaseName string) error) error {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		return err
+	}
+	database, err := d.databaseService.Get(databaseName)
+	if err != nil {
+		return err
+	}
+	return deleteFn(database.Cluster, databaseName)
+}
@@ -0,0 +1,104 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
+)
+
+func TestDataDeleterAPI_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	databaseService := service.NewMockDatabaseService(ctrl)
+	deleteAPI := NewDataDeleterAPI(master, databaseService)
+	doRequest := func(ql, db string, expectHTTPCode int) {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodDelete,
+			URL:            fmt.Sprintf("/database/data?sql=%s&db=%s", url.QueryEscape(ql), db),
+			HandlerFunc:    deleteAPI.Delete,
+			ExpectHTTPCode: expectHTTPCode,
+		})
+	}
+	// case 1: no sql
+	doRequest("", "", http.StatusInternalServerError)
+	// case 2: parse sql err
+	doRequest("drop xx", "", http.StatusInternalServerError)
+	// case 3: not delete statement
+	master.EXPECT().IsMaster().Return(true)
+	doRequest("show databases", "", http.StatusInternalServerError)
+	// case 4: drop database without db param or other database than db param
+	doRequest("drop database db", "", http.StatusInternalServerError)
+	doRequest("drop database db2", "db", http.StatusInternalServerError)
+	// case 5: drop database
+	master.EXPECT().IsMaster().Return(true).Times(4)
+	databaseService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	doRequest("drop database db", "db", http.StatusInternalServerError)
+	databaseService.EXPECT().Get("db").Return(&models.Database{Cluster: "test"}, nil).Times(3)
+	master.EXPECT().DropDatabase("test", "db").Return(fmt.Errorf("err"))
+	doRequest("drop database db", "db", http.StatusInternalServerError)
+	master.EXPECT().DropDatabase("test", "db").Return(nil).Times(2)
+	databaseService.EXPECT().Delete("db").Return(fmt.Errorf("err"))
+	doRequest("drop database db", "db", http.StatusInternalServerError)
+	databaseService.EXPECT().Delete("db").Return(nil)
+	doRequest("drop database db", "db", http.StatusOK)
+	// case 6: drop metric
+	master.EXPECT().IsMaster().Return(true).Times(3)
+	doRequest("drop metric cpu", "", http.StatusInternalServerError)
+	databaseService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	doRequest("drop metric cpu", "db", http.StatusInternalServerError)
+	databaseService.EXPECT().Get("db").Return(&models.Database{Cluster: "test"}, nil)
+	master.EXPECT().DropMetric("test", "db", "default-ns", "cpu").Return(nil)
+	doRequest("drop metric cpu", "db", http.StatusOK)
+	// case 7: delete series
+	master.EXPECT().IsMaster().Return(true)
+	databaseService.EXPECT().Get("db").Return(&models.Database{Cluster: "test"}, nil)
+	master.EXPECT().DeleteSeries("test", "db", "ns", "cpu", gomock.Any()).Return(nil)
+	doRequest("delete series on ns from cpu where host='1.1.1.1'", "db", http.StatusOK)
+	// case 8: forward to master
+	master.EXPECT().IsMaster().Return(false).Times(3)
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).Times(3)
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	doRequest("drop metric cpu", "db", http.StatusInternalServerError)
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: &mockIOReader{}}, nil
+	}
+	doRequest("drop metric cpu", "db", http.StatusInternalServerError)
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	doRequest("drop metric cpu", "db", http.StatusOK)
+}
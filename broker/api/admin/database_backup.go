@@ -0,0 +1,84 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb/backup"
+)
+
+// DatabaseBackupAPI represents the backup of database by manual,
+// all storage nodes of database backup their shards and metadata into backup target.
+type DatabaseBackupAPI struct {
+	master          coordinator.Master
+	databaseService service.DatabaseService
+}
+
+// NewDatabaseBackupAPI creates database backup api
+func NewDatabaseBackupAPI(master coordinator.Master, databaseService service.DatabaseService) *DatabaseBackupAPI {
+	return &DatabaseBackupAPI{
+		master:          master,
+		databaseService: databaseService,
+	}
+}
+
+// SubmitBackupTask submits the task which backups the database into backup target(uri) with backup name
+func (dba *DatabaseBackupAPI) SubmitBackupTask(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	target, err := api.GetParamsFromRequest("target", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := backup.ValidateName(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !dba.master.IsMaster() {
+		// if current node is not master, need forward to master node
+		if err := forwardToMaster(dba.master, r); err != nil {
+			api.Error(w, err)
+			return
+		}
+		api.OK(w, "success")
+		return
+	}
+	database, err := dba.databaseService.Get(databaseName)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := dba.master.BackupDatabase(database.Cluster, databaseName, target, name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, "success")
+}
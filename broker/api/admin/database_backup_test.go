@@ -0,0 +1,84 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
+)
+
+func TestDatabaseBackupAPI_SubmitBackupTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	databaseService := service.NewMockDatabaseService(ctrl)
+	backupAPI := NewDatabaseBackupAPI(master, databaseService)
+	doRequest := func(query string, expectHTTPCode int) {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodPut,
+			URL:            "/database/backup?" + query,
+			HandlerFunc:    backupAPI.SubmitBackupTask,
+			ExpectHTTPCode: expectHTTPCode,
+		})
+	}
+	// case 1: no db/target/name
+	doRequest("target=/tmp/backup&name=b1", http.StatusInternalServerError)
+	doRequest("db=db&name=b1", http.StatusInternalServerError)
+	doRequest("db=db&target=/tmp/backup", http.StatusInternalServerError)
+	// case 2: traversal backup name
+	for _, name := range []string{"..", "../../..", "a%2F..%2F..%2Fb", "%2Fetc"} {
+		doRequest("db=db&target=/tmp/backup&name="+name, http.StatusInternalServerError)
+	}
+	// case 3: get database err
+	query := "db=db&target=/tmp/backup&name=b1"
+	master.EXPECT().IsMaster().Return(true).Times(3)
+	databaseService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	doRequest(query, http.StatusInternalServerError)
+	// case 4: submit backup task err
+	databaseService.EXPECT().Get("db").Return(&models.Database{Cluster: "test"}, nil).Times(2)
+	master.EXPECT().BackupDatabase("test", "db", "/tmp/backup", "b1").Return(fmt.Errorf("err"))
+	doRequest(query, http.StatusInternalServerError)
+	// case 5: submit backup task
+	master.EXPECT().BackupDatabase("test", "db", "/tmp/backup", "b1").Return(nil)
+	doRequest(query, http.StatusOK)
+	// case 6: forward to master
+	master.EXPECT().IsMaster().Return(false).Times(2)
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).Times(2)
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	doRequest(query, http.StatusInternalServerError)
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	doRequest(query, http.StatusOK)
+}
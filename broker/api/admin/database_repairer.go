@@ -0,0 +1,69 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/service"
+)
+
+// DatabaseRepairerAPI represents the anti-entropy repair of database by manual,
+// the replicas of shard pull the missing data from each other.
+type DatabaseRepairerAPI struct {
+	master          coordinator.Master
+	databaseService service.DatabaseService
+}
+
+// NewDatabaseRepairerAPI creates database repairer api
+func NewDatabaseRepairerAPI(master coordinator.Master, databaseService service.DatabaseService) *DatabaseRepairerAPI {
+	return &DatabaseRepairerAPI{
+		master:          master,
+		databaseService: databaseService,
+	}
+}
+
+// SubmitRepairTask submits the task which repairs the shards of database from the peer replicas
+func (dr *DatabaseRepairerAPI) SubmitRepairTask(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !dr.master.IsMaster() {
+		// if current node is not master, need forward to master node
+		if err := forwardToMaster(dr.master, r); err != nil {
+			api.Error(w, err)
+			return
+		}
+		api.OK(w, "success")
+		return
+	}
+	database, err := dr.databaseService.Get(databaseName)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := dr.master.RepairDatabase(database.Cluster, databaseName); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, "success")
+}
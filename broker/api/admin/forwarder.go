@@ -0,0 +1,112 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/lindb/lindb/broker/middleware"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/tlsutil"
+)
+
+// forwardHeaders represents the request headers which need be copied when forwarding,
+// includes the credentials for authentication of master node.
+var forwardHeaders = []string{"Authorization", middleware.APIKeyHeader, "Content-Type"}
+
+var (
+	forwardLock   sync.RWMutex
+	forwardScheme = "http"
+	httpDo        = http.DefaultClient.Do
+)
+
+// SetForwardClient sets the http client which forwards request to master node,
+// requests with timeout, and by https if tls enable(presents the certificate for mutual tls).
+func SetForwardClient(cfg config.HTTP) error {
+	scheme, client, err := tlsutil.NewHTTPClient(cfg.TLS, cfg.ForwardTimeout.Duration())
+	if err != nil {
+		return err
+	}
+	forwardLock.Lock()
+	forwardScheme = scheme
+	httpDo = client.Do
+	forwardLock.Unlock()
+	return nil
+}
+
+// forwardClient returns the scheme and the do function of forward http client
+func forwardClient() (scheme string, do func(req *http.Request) (*http.Response, error)) {
+	forwardLock.RLock()
+	defer forwardLock.RUnlock()
+	return forwardScheme, httpDo
+}
+
+// forwardToMaster forwards the request(with body and credential headers) to master node
+func forwardToMaster(master coordinator.Master, r *http.Request) error {
+	body, err := readForwardBody(r)
+	if err != nil {
+		return err
+	}
+	masterNode := master.GetMaster().Node
+	scheme, do := forwardClient()
+	req, err := http.NewRequest(r.Method,
+		fmt.Sprintf("%s://%s:%d%s", scheme, masterNode.IP, masterNode.Port, r.RequestURI), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for _, header := range forwardHeaders {
+		if value := r.Header.Get(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+	resp, err := do(req)
+	if err != nil {
+		return err
+	}
+	if resp.Body != nil {
+		if err := resp.Body.Close(); err != nil {
+			adminLogger.Error("close http response body", logger.Error(err))
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("master handle error after forward")
+	}
+	return nil
+}
+
+// readForwardBody reads the request body, if form body already parsed, encodes the post form as body
+func readForwardBody(r *http.Request) ([]byte, error) {
+	var body []byte
+	if r.Body != nil {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+	if len(body) == 0 && len(r.PostForm) > 0 {
+		body = []byte(r.PostForm.Encode())
+	}
+	return body, nil
+}
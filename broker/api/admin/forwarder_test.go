@@ -0,0 +1,117 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/broker/middleware"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+func TestSetForwardClient(t *testing.T) {
+	defer func() {
+		forwardScheme = "http"
+		httpDo = http.DefaultClient.Do
+	}()
+	assert.NoError(t, SetForwardClient(config.HTTP{ForwardTimeout: ltoml.Duration(time.Second)}))
+	scheme, _ := forwardClient()
+	assert.Equal(t, "http", scheme)
+
+	// certificate files not exist
+	assert.Error(t, SetForwardClient(config.HTTP{TLS: config.TLS{Enable: true, CertFile: "not-exist.crt", KeyFile: "not-exist.key"}}))
+	scheme, _ = forwardClient()
+	assert.Equal(t, "http", scheme)
+
+	// timeout when master node not responds
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	assert.NoError(t, SetForwardClient(config.HTTP{ForwardTimeout: ltoml.Duration(10 * time.Millisecond)}))
+	_, do := forwardClient()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := do(req)
+	assert.Error(t, err)
+}
+
+func TestForwardToMaster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).AnyTimes()
+
+	var forwarded *http.Request
+	var forwardedBody string
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		forwarded = req
+		body, _ := ioutil.ReadAll(req.Body)
+		forwardedBody = string(body)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+	// case 1: copy body and credential headers
+	r := httptest.NewRequest(http.MethodPut, "/database/repair?db=test", strings.NewReader("body"))
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set(middleware.APIKeyHeader, "api-key")
+	r.Header.Set("Cookie", "c=1")
+	assert.NoError(t, forwardToMaster(master, r))
+	assert.Equal(t, "http://127.0.0.1:12345/database/repair?db=test", forwarded.URL.String())
+	assert.Equal(t, http.MethodPut, forwarded.Method)
+	assert.Equal(t, "body", forwardedBody)
+	assert.Equal(t, "Bearer token", forwarded.Header.Get("Authorization"))
+	assert.Equal(t, "api-key", forwarded.Header.Get(middleware.APIKeyHeader))
+	assert.Empty(t, forwarded.Header.Get("Cookie"))
+
+	// case 2: form body already parsed
+	r = httptest.NewRequest(http.MethodPost, "/database/repair", strings.NewReader("db=test"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.NoError(t, r.ParseForm())
+	assert.NoError(t, forwardToMaster(master, r))
+	assert.Equal(t, url.Values{"db": []string{"test"}}.Encode(), forwardedBody)
+	assert.Equal(t, "application/x-www-form-urlencoded", forwarded.Header.Get("Content-Type"))
+
+	// case 3: read body failure
+	r = httptest.NewRequest(http.MethodPut, "/database/repair", &mockIOReader{})
+	assert.Error(t, forwardToMaster(master, r))
+
+	// case 4: forward failure
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	r = httptest.NewRequest(http.MethodPut, "/database/repair", nil)
+	assert.Error(t, forwardToMaster(master, r))
+}
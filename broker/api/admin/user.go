@@ -0,0 +1,192 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/broker/middleware"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
+)
+
+// errNotAllDatabasesAdmin represents the request user isn't the admin of all databases
+var errNotAllDatabasesAdmin = errors.New("permission denied, need admin permission of all databases")
+
+// apiKeyParam represents the param of creating api key
+type apiKeyParam struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+// UserAPI represents user/role/api key admin rest api
+type UserAPI struct {
+	userService service.UserService
+}
+
+// NewUserAPI creates user api instance
+func NewUserAPI(userService service.UserService) *UserAPI {
+	return &UserAPI{
+		userService: userService,
+	}
+}
+
+// SaveUser creates the user if not exist, otherwise update the password/roles of user,
+// keeps the password if password is empty when update user
+func (u *UserAPI) SaveUser(w http.ResponseWriter, r *http.Request) {
+	if !allowAllDatabasesAdmin(w, r) {
+		return
+	}
+	user := &models.User{}
+	if err := api.GetJSONBodyFromRequest(r, user); err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.SaveUser(user); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// DeleteUser deletes the user by name
+func (u *UserAPI) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.DeleteUser(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// ListUsers returns all users without password hash
+func (u *UserAPI) ListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := u.userService.ListUsers()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	for _, user := range users {
+		user.PasswordHash = ""
+		user.Salt = ""
+	}
+	api.OK(w, users)
+}
+
+// SaveRole creates or updates the role
+func (u *UserAPI) SaveRole(w http.ResponseWriter, r *http.Request) {
+	if !allowAllDatabasesAdmin(w, r) {
+		return
+	}
+	role := &models.Role{}
+	if err := api.GetJSONBodyFromRequest(r, role); err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.SaveRole(role); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// DeleteRole deletes the role by name
+func (u *UserAPI) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.DeleteRole(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// ListRoles returns all roles
+func (u *UserAPI) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := u.userService.ListRoles()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, roles)
+}
+
+// CreateAPIKey creates the api key for ingestion agent,
+// the key only responses once when created
+func (u *UserAPI) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !allowAllDatabasesAdmin(w, r) {
+		return
+	}
+	param := &apiKeyParam{}
+	if err := api.GetJSONBodyFromRequest(r, param); err != nil {
+		api.Error(w, err)
+		return
+	}
+	apiKey, err := u.userService.CreateAPIKey(param.Name, param.Roles)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, apiKey)
+}
+
+// DeleteAPIKey deletes the api key by name
+func (u *UserAPI) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.DeleteAPIKey(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// ListAPIKeys returns all api keys without key hash
+func (u *UserAPI) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := u.userService.ListAPIKeys()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	for _, apiKey := range apiKeys {
+		apiKey.KeyHash = ""
+	}
+	api.OK(w, apiKeys)
+}
+
+// allowAllDatabasesAdmin checks if the request user has the admin permission of all databases,
+// because users/roles/api keys can grant any permission of any database, responses 403 if not allowed.
+func allowAllDatabasesAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if !middleware.GetGrants(r.Context()).AllowAllDatabases(models.AdminPermission) {
+		api.Forbidden(w, errNotAllDatabasesAdmin)
+		return false
+	}
+	return true
+}
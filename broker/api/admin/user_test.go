@@ -0,0 +1,290 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/broker/middleware"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
+)
+
+var (
+	allDatabasesAdmin = models.Grants{{Database: models.AllDatabases, Permission: models.AdminPermission}}
+	databaseAdmin     = models.Grants{{Database: "db1", Permission: models.AdminPermission}}
+)
+
+// withGrants returns the handler which serves the request with grants, like authorize middleware
+func withGrants(grants models.Grants, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r.WithContext(middleware.WithGrants(r.Context(), grants)))
+	}
+}
+
+func TestUserAPI_AllDatabasesAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// userService expects nothing, admin of single database cannot grant permissions
+	api := NewUserAPI(service.NewMockUserService(ctrl))
+	for _, grants := range []models.Grants{nil, databaseAdmin} {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodPost,
+			URL:            "/user",
+			RequestBody:    models.User{UserName: "test", Password: "pwd", Roles: []string{"admin"}},
+			HandlerFunc:    withGrants(grants, api.SaveUser),
+			ExpectHTTPCode: http.StatusForbidden,
+		})
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method: http.MethodPost,
+			URL:    "/role",
+			RequestBody: models.Role{Name: "admin", Grants: models.Grants{
+				{Database: models.AllDatabases, Permission: models.AdminPermission}}},
+			HandlerFunc:    withGrants(grants, api.SaveRole),
+			ExpectHTTPCode: http.StatusForbidden,
+		})
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodPost,
+			URL:            "/apikey",
+			RequestBody:    apiKeyParam{Name: "agent", Roles: []string{"admin"}},
+			HandlerFunc:    withGrants(grants, api.CreateAPIKey),
+			ExpectHTTPCode: http.StatusForbidden,
+		})
+	}
+}
+
+func TestUserAPI_User(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	api := NewUserAPI(userService)
+
+	// get request error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    []byte{1, 3, 4},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.SaveUser),
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().SaveUser(gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    models.User{UserName: "test"},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.SaveUser),
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().SaveUser(gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    models.User{UserName: "test", Password: "pwd"},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.SaveUser),
+		ExpectHTTPCode: 204,
+	})
+
+	userService.EXPECT().ListUsers().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user/list",
+		HandlerFunc:    api.ListUsers,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().ListUsers().Return([]*models.User{
+		{UserName: "test", PasswordHash: "hash", Salt: "salt", Roles: []string{"reader"}}}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user/list",
+		HandlerFunc:    api.ListUsers,
+		ExpectHTTPCode: 200,
+		ExpectResponse: []*models.User{{UserName: "test", Roles: []string{"reader"}}},
+	})
+
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/user",
+		HandlerFunc:    api.DeleteUser,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().DeleteUser("test").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/user?name=test",
+		HandlerFunc:    api.DeleteUser,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().DeleteUser("test").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/user?name=test",
+		HandlerFunc:    api.DeleteUser,
+		ExpectHTTPCode: 204,
+	})
+}
+
+func TestUserAPI_Role(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	api := NewUserAPI(userService)
+	role := models.Role{
+		Name:   "reader",
+		Grants: models.Grants{{Database: "db", Permission: models.ReadPermission}},
+	}
+
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/role",
+		RequestBody:    []byte{1, 3, 4},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.SaveRole),
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().SaveRole(gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/role",
+		RequestBody:    role,
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.SaveRole),
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().SaveRole(gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/role",
+		RequestBody:    role,
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.SaveRole),
+		ExpectHTTPCode: 204,
+	})
+
+	userService.EXPECT().ListRoles().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role/list",
+		HandlerFunc:    api.ListRoles,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().ListRoles().Return([]*models.Role{&role}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role/list",
+		HandlerFunc:    api.ListRoles,
+		ExpectHTTPCode: 200,
+		ExpectResponse: []*models.Role{&role},
+	})
+
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/role",
+		HandlerFunc:    api.DeleteRole,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().DeleteRole("reader").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/role?name=reader",
+		HandlerFunc:    api.DeleteRole,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().DeleteRole("reader").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/role?name=reader",
+		HandlerFunc:    api.DeleteRole,
+		ExpectHTTPCode: 204,
+	})
+}
+
+func TestUserAPI_APIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	api := NewUserAPI(userService)
+
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/apikey",
+		RequestBody:    []byte{1, 3, 4},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.CreateAPIKey),
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().CreateAPIKey("agent", []string{"writer"}).Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/apikey",
+		RequestBody:    apiKeyParam{Name: "agent", Roles: []string{"writer"}},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.CreateAPIKey),
+		ExpectHTTPCode: 500,
+	})
+	apiKey := &models.APIKey{Name: "agent", Key: "agent.secret", Roles: []string{"writer"}}
+	userService.EXPECT().CreateAPIKey("agent", []string{"writer"}).Return(apiKey, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/apikey",
+		RequestBody:    apiKeyParam{Name: "agent", Roles: []string{"writer"}},
+		HandlerFunc:    withGrants(allDatabasesAdmin, api.CreateAPIKey),
+		ExpectHTTPCode: 200,
+		ExpectResponse: apiKey,
+	})
+
+	userService.EXPECT().ListAPIKeys().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/apikey/list",
+		HandlerFunc:    api.ListAPIKeys,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().ListAPIKeys().Return([]*models.APIKey{{Name: "agent", KeyHash: "hash"}}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/apikey/list",
+		HandlerFunc:    api.ListAPIKeys,
+		ExpectHTTPCode: 200,
+		ExpectResponse: []*models.APIKey{{Name: "agent"}},
+	})
+
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/apikey",
+		HandlerFunc:    api.DeleteAPIKey,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().DeleteAPIKey("agent").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/apikey?name=agent",
+		HandlerFunc:    api.DeleteAPIKey,
+		ExpectHTTPCode: 500,
+	})
+	userService.EXPECT().DeleteAPIKey("agent").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/apikey?name=agent",
+		HandlerFunc:    api.DeleteAPIKey,
+		ExpectHTTPCode: 204,
+	})
+}
@@ -23,21 +23,24 @@ import (
 	"github.com/lindb/lindb/broker/middleware"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
 )
 
 var log = logger.GetLogger("broker", "api")
 
 // LoginAPI represents login param
 type LoginAPI struct {
-	user config.User
-	auth middleware.Authentication
+	user        config.User
+	auth        middleware.Authentication
+	userService service.UserService
 }
 
 // NewLoginAPI creates login api instance
-func NewLoginAPI(user config.User, auth middleware.Authentication) *LoginAPI {
+func NewLoginAPI(user config.User, auth middleware.Authentication, userService service.UserService) *LoginAPI {
 	return &LoginAPI{
-		user: user,
-		auth: auth,
+		user:        user,
+		auth:        auth,
+		userService: userService,
 	}
 }
 
@@ -65,15 +68,16 @@ func (l *LoginAPI) Login(w http.ResponseWriter, r *http.Request) {
 		OK(w, "")
 		return
 	}
-	// user name is error
-	if l.user.UserName != user.UserName {
-		log.Error("username is invalid")
-		OK(w, "")
-		return
-	}
-	// password is error
-	if l.user.Password != user.Password {
-		log.Error("password is invalid")
+	if l.user.UserName == user.UserName {
+		// password of admin user is error
+		if l.user.Password != user.Password {
+			log.Error("password is invalid")
+			OK(w, "")
+			return
+		}
+	} else if _, err := l.userService.Authenticate(user.UserName, user.Password); err != nil {
+		// user name or password is error
+		log.Error("username or password is invalid", logger.String("user", user.UserName))
 		OK(w, "")
 		return
 	}
@@ -85,8 +89,17 @@ func (l *LoginAPI) Login(w http.ResponseWriter, r *http.Request) {
 	OK(w, token)
 }
 
+// Logout revokes the token of request header Authorization
+func (l *LoginAPI) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := l.auth.RevokeToken(r.Header.Get("Authorization")); err != nil {
+		Error(w, err)
+		return
+	}
+	OK(w, "success")
+}
+
 // Check responses use msg
 // this method use for test
 func (l *LoginAPI) Check(w http.ResponseWriter, r *http.Request) {
-	OK(w, l.user)
+	OK(w, config.User{UserName: l.user.UserName})
 }
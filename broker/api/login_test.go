@@ -29,6 +29,8 @@ import (
 	"github.com/lindb/lindb/broker/middleware"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
 )
 
 var tokenStr = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ1c2VybmFtZSI6ImFkbWluIiwicGFzc3dvc" +
@@ -39,9 +41,10 @@ func TestLogin(t *testing.T) {
 	defer ctrl.Finish()
 
 	auth := middleware.NewMockAuthentication(ctrl)
+	userService := service.NewMockUserService(ctrl)
 
 	user := config.User{UserName: "admin", Password: "admin123"}
-	api := NewLoginAPI(user, auth)
+	api := NewLoginAPI(user, auth, userService)
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
 		URL:            "/user",
@@ -83,6 +86,7 @@ func TestLogin(t *testing.T) {
 	})
 
 	//user failure error user name
+	userService.EXPECT().Authenticate("123", "admin123").Return(nil, fmt.Errorf("err"))
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
 		URL:            "/user",
@@ -122,12 +126,48 @@ func TestLogin(t *testing.T) {
 		ExpectResponse: "",
 	})
 
+	// user stored in repo
+	userService.EXPECT().Authenticate("test", "pwd").Return(&models.User{UserName: "test"}, nil)
+	auth.EXPECT().CreateToken(config.User{UserName: "test", Password: "pwd"}).Return(tokenStr, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    config.User{UserName: "test", Password: "pwd"},
+		HandlerFunc:    api.Login,
+		ExpectHTTPCode: 200,
+		ExpectResponse: tokenStr,
+	})
+
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
 		URL:            "/check",
 		HandlerFunc:    api.Check,
 		ExpectHTTPCode: 200,
-		ExpectResponse: user,
+		ExpectResponse: config.User{UserName: "admin"},
+	})
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := middleware.NewMockAuthentication(ctrl)
+	api := NewLoginAPI(config.User{UserName: "admin", Password: "admin123"}, auth, service.NewMockUserService(ctrl))
+
+	auth.EXPECT().RevokeToken(gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/logout",
+		HandlerFunc:    api.Logout,
+		ExpectHTTPCode: 500,
+	})
+	auth.EXPECT().RevokeToken(gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/logout",
+		HandlerFunc:    api.Logout,
+		ExpectHTTPCode: 200,
+		ExpectResponse: "success",
 	})
 }
 
@@ -135,18 +175,17 @@ func Test_JWT(t *testing.T) {
 	user := config.User{UserName: "admin", Password: "admin123"}
 	claims := middleware.CustomClaims{
 		UserName: user.UserName,
-		Password: user.Password,
 	}
+	claims.ExpiresAt = 4102444800
 	cid := middleware.Md5Encrypt(user)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
 	tokenString, _ := token.SignedString([]byte(cid))
 
-	assert.Equal(t, tokenStr, tokenString)
-
 	mapClaims := middleware.CustomClaims{}
-	_, _ = jwt.ParseWithClaims(tokenString, &mapClaims, func(token *jwt.Token) (i interface{}, e error) {
-		return cid, nil
+	_, err := jwt.ParseWithClaims(tokenString, &mapClaims, func(token *jwt.Token) (i interface{}, e error) {
+		return []byte(cid), nil
 	})
-	assert.Equal(t, user.Password, mapClaims.Password)
+	assert.NoError(t, err)
 	assert.Equal(t, user.UserName, mapClaims.UserName)
+	assert.Equal(t, int64(4102444800), mapClaims.ExpiresAt)
 }
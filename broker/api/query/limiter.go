@@ -0,0 +1,97 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+)
+
+// queryRetryAfter is the Retry-After of the query which is rejected by the limit of concurrent queries
+const queryRetryAfter = time.Second
+
+// QueryLimiter limits the number of concurrent queries for each database
+type QueryLimiter struct {
+	maxConcurrent int // 0 means no limit
+	running       map[string]int
+	mutex         sync.Mutex
+}
+
+// NewQueryLimiter creates the query limiter with max concurrent queries of each database
+func NewQueryLimiter(maxConcurrent int) *QueryLimiter {
+	return &QueryLimiter{
+		maxConcurrent: maxConcurrent,
+		running:       make(map[string]int),
+	}
+}
+
+// Acquire acquires a query slot of database, returns ErrTooManyConcurrentQueries if no slot available
+func (l *QueryLimiter) Acquire(db string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	running := l.running[db]
+	if l.maxConcurrent > 0 && running >= l.maxConcurrent {
+		return fmt.Errorf("%s of database: %s, limit: %d", constants.ErrTooManyConcurrentQueries, db, l.maxConcurrent)
+	}
+	l.running[db] = running + 1
+	return nil
+}
+
+// Release releases the query slot of database
+func (l *QueryLimiter) Release(db string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	running := l.running[db] - 1
+	if running <= 0 {
+		delete(l.running, db)
+		return
+	}
+	l.running[db] = running
+}
+
+// tooManyQueries responses 429 Too Many Requests with Retry-After if no query slot available
+func tooManyQueries(w http.ResponseWriter, err error) {
+	api.TooManyRequests(w, queryRetryAfter, err)
+}
+
+// getQueryTimeout returns the query timeout from request param, uses default timeout if not set,
+// the timeout of request cannot exceed the configured(default) timeout.
+func getQueryTimeout(r *http.Request, defaultTimeout time.Duration) (time.Duration, error) {
+	timeoutStr, err := api.GetParamsFromRequest("timeout", r, "", false)
+	if err != nil {
+		return 0, err
+	}
+	if timeoutStr == "" {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid query timeout: %s", timeoutStr)
+	}
+	if defaultTimeout > 0 && timeout > defaultTimeout {
+		return defaultTimeout, nil
+	}
+	return timeout, nil
+}
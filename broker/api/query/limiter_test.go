@@ -0,0 +1,67 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLimiter(t *testing.T) {
+	l := NewQueryLimiter(2)
+	assert.NoError(t, l.Acquire("db"))
+	assert.NoError(t, l.Acquire("db"))
+	assert.Error(t, l.Acquire("db"))
+	assert.NoError(t, l.Acquire("db2"))
+	l.Release("db")
+	assert.NoError(t, l.Acquire("db"))
+	l.Release("db")
+	l.Release("db")
+	assert.Empty(t, l.running["db"])
+	l.Release("db")
+	assert.NotContains(t, l.running, "db")
+
+	// no limit
+	l = NewQueryLimiter(0)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, l.Acquire("db"))
+	}
+}
+
+func TestGetQueryTimeout(t *testing.T) {
+	timeout, err := getQueryTimeout(httptest.NewRequest(http.MethodGet, "/query/metric", nil), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, timeout)
+	timeout, err = getQueryTimeout(httptest.NewRequest(http.MethodGet, "/query/metric?timeout=10s", nil), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, timeout)
+	// cannot exceed the configured timeout
+	timeout, err = getQueryTimeout(httptest.NewRequest(http.MethodGet, "/query/metric?timeout=100h", nil), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, timeout)
+	_, err = getQueryTimeout(httptest.NewRequest(http.MethodGet, "/query/metric?timeout=abc", nil), time.Minute)
+	assert.Error(t, err)
+	_, err = getQueryTimeout(httptest.NewRequest(http.MethodGet, "/query/metric?timeout=-1s", nil), time.Minute)
+	assert.Error(t, err)
+	_, err = getQueryTimeout(httptest.NewRequest(http.MethodPatch, "/query/metric", nil), time.Minute)
+	assert.Error(t, err)
+}
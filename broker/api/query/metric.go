@@ -23,9 +23,11 @@ import (
 	"time"
 
 	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
 )
 
@@ -36,18 +38,23 @@ type MetricAPI struct {
 	databaseStateMachine database.DBStateMachine
 	executorFactory      parallel.ExecutorFactory
 	jobManager           parallel.JobManager
+	limiter              *QueryLimiter
+	timeout              time.Duration
 }
 
 // NewMetricAPI creates the metric query api
 func NewMetricAPI(replicaStateMachine replica.StatusStateMachine,
 	nodeStateMachine broker.NodeStateMachine, databaseStateMachine database.DBStateMachine,
-	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager) *MetricAPI {
+	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager,
+	cfg config.Query, limiter *QueryLimiter) *MetricAPI {
 	return &MetricAPI{
 		replicaStateMachine:  replicaStateMachine,
 		nodeStateMachine:     nodeStateMachine,
 		databaseStateMachine: databaseStateMachine,
 		executorFactory:      executorFactory,
 		jobManager:           jobManager,
+		limiter:              limiter,
+		timeout:              cfg.Timeout.Duration(),
 	}
 }
 
@@ -63,8 +70,23 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
-	//FIXME add timeout cfg
-	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	stream, err := api.GetParamsFromRequest("stream", r, "false", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	timeout, err := getQueryTimeout(r, m.timeout)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := m.limiter.Acquire(db); err != nil {
+		tooManyQueries(w, err)
+		return
+	}
+	defer m.limiter.Release(db)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
 	defer cancel()
 
 	exec := m.executorFactory.NewBrokerExecutor(ctx, db, sql,
@@ -74,8 +96,12 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 
 	brokerExecutor := exec.(parallel.BrokerExecutor)
 	exeCtx := brokerExecutor.ExecuteContext()
+	if stream == "true" {
+		m.stream(w, exeCtx)
+		return
+	}
 
-	//FIXME timeout logic use select
+	// result chan is closed when query completed, timeout or killed
 	resultCh := exeCtx.ResultCh()
 	for result := range resultCh {
 		exeCtx.Emit(result)
@@ -88,3 +114,42 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 	}
 	api.OK(w, resultSet)
 }
+
+// stream writes the time series to client as NDJSON as soon as it's evaluated,
+// the summary with stats/error of query is the last line.
+func (m *MetricAPI) stream(w http.ResponseWriter, exeCtx parallel.BrokerExecuteContext) {
+	writer := api.NewStreamWriter(w)
+	seriesCount := 0
+	exeCtx.Stream(func(series *models.ColumnSeries) error {
+		if err := writer.Write(&models.StreamEvent{Series: series}); err != nil {
+			return err
+		}
+		seriesCount++
+		return nil
+	})
+
+	// result chan is closed when query completed, timeout or killed
+	resultCh := exeCtx.ResultCh()
+	for result := range resultCh {
+		exeCtx.Emit(result)
+	}
+
+	resultSet, err := exeCtx.ResultSet()
+	if err != nil && !writer.Started() {
+		api.Error(w, err)
+		return
+	}
+	summary := &models.StreamSummary{
+		MetricName:  resultSet.MetricName,
+		StartTime:   resultSet.StartTime,
+		EndTime:     resultSet.EndTime,
+		Interval:    resultSet.Interval,
+		SeriesCount: seriesCount,
+		Stats:       resultSet.Stats,
+		Warnings:    resultSet.Warnings,
+	}
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	_ = writer.Write(&models.StreamEvent{Summary: summary})
+}
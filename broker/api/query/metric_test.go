@@ -18,17 +18,28 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/mock"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestMetricAPI_Search(t *testing.T) {
@@ -45,7 +56,7 @@ func TestMetricAPI_Search(t *testing.T) {
 		gomock.Any(), gomock.Any(),
 		gomock.Any(), gomock.Any()).Return(brokerExecutor)
 
-	api := NewMetricAPI(nil, nil, nil, executorFactory, nil)
+	api := NewMetricAPI(nil, nil, nil, executorFactory, nil, *config.NewDefaultQuery(), NewQueryLimiter(0))
 
 	ch := make(chan *series.TimeSeriesEvent)
 
@@ -71,7 +82,7 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 	defer ctrl.Finish()
 
 	executorFactory := parallel.NewMockExecutorFactory(ctrl)
-	api := NewMetricAPI(nil, nil, nil, executorFactory, nil)
+	api := NewMetricAPI(nil, nil, nil, executorFactory, nil, *config.NewDefaultQuery(), NewQueryLimiter(0))
 
 	// param error
 	mock.DoRequest(t, &mock.HTTPHandler{
@@ -89,6 +100,25 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 		ExpectHTTPCode: 500,
 	})
 
+	// invalid timeout
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select+f+from+cpu&timeout=abc",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
+	// too many concurrent queries
+	api.limiter = NewQueryLimiter(1)
+	assert.NoError(t, api.limiter.Acquire("test"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select+f+from+cpu",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 429,
+	})
+	api.limiter.Release("test")
+
 	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
 	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
 	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
@@ -113,3 +143,124 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 		ExpectHTTPCode: 500,
 	})
 }
+
+func TestMetricAPI_Search_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx).AnyTimes()
+	brokerExecutor.EXPECT().Execute().AnyTimes()
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(brokerExecutor).AnyTimes()
+
+	api := NewMetricAPI(nil, nil, nil, executorFactory, nil, *config.NewDefaultQuery(), NewQueryLimiter(0))
+
+	// stream series, then summary
+	var handler parallel.SeriesHandler
+	executeCtx.EXPECT().Stream(gomock.Any()).DoAndReturn(func(h parallel.SeriesHandler) {
+		handler = h
+	})
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	ch <- &series.TimeSeriesEvent{}
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	executeCtx.EXPECT().Emit(gomock.Any()).Do(func(_ *series.TimeSeriesEvent) {
+		s := models.NewColumnSeries(map[string]string{"host": "1"})
+		s.Timestamps = []int64{10}
+		s.Fields["f"] = models.FloatValues{1}
+		assert.NoError(t, handler(s))
+	})
+	executeCtx.EXPECT().ResultSet().Return(&models.ResultSet{MetricName: "cpu"}, fmt.Errorf("err"))
+	resp := doStreamRequest(api)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"series":{"tags":{"host":"1"},"timestamps":[10],"fields":{"f":[1]}}}`+"\n"+
+		`{"summary":{"metricName":"cpu","seriesCount":1,"error":"err"}}`+"\n", resp.Body.String())
+
+	// query failure before any series streamed
+	ch = make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().Stream(gomock.Any())
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	executeCtx.EXPECT().ResultSet().Return(&models.ResultSet{}, fmt.Errorf("err"))
+	resp = doStreamRequest(api)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	// empty result
+	ch = make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().Stream(gomock.Any())
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	executeCtx.EXPECT().ResultSet().Return(&models.ResultSet{}, nil)
+	resp = doStreamRequest(api)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"summary":{"seriesCount":0}}`+"\n", resp.Body.String())
+}
+
+func doStreamRequest(api *MetricAPI) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/query/metric?db=test&sql=select+f+from+cpu&stream=true", nil)
+	resp := httptest.NewRecorder()
+	api.Search(resp, req)
+	return resp
+}
+
+func TestMetricAPI_Search_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := parallel.NewMockTaskManager(ctrl)
+	taskSeq := atomic.NewInt32(0)
+	taskManager.EXPECT().AllocTaskID().DoAndReturn(func() string {
+		return fmt.Sprintf("task-%d", taskSeq.Inc())
+	}).AnyTimes()
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().Complete(gomock.Any()).AnyTimes()
+	// storage node receives the request, but never responds
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	jobManager := parallel.NewJobManager(taskManager)
+
+	q, err := sql.Parse("select f from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	plan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.1:8000", NumOfTask: 1})
+	plan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{Parent: "1.1.1.1:8000", Indicator: "1.1.1.2:9000"},
+		ShardIDs: []int32{1},
+	})
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	var executeCtx parallel.BrokerExecuteContext
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, databaseName, sqlText string,
+			_ replica.StatusStateMachine, _ broker.NodeStateMachine, _ database.DBStateMachine,
+			jobManager parallel.JobManager,
+		) parallel.BrokerExecutor {
+			brokerExecutor.EXPECT().Execute().Do(func() {
+				executeCtx = parallel.NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
+				jobCtx := parallel.NewJobContext(ctx, executeCtx.ResultCh(), plan, query, sqlText)
+				jobCtx.SetFailover(&parallel.FailoverOption{Replicas: map[int32][]string{1: {"1.1.1.2:9000"}}})
+				assert.NoError(t, jobManager.SubmitJob(jobCtx))
+			})
+			return brokerExecutor
+		})
+	brokerExecutor.EXPECT().ExecuteContext().DoAndReturn(func() parallel.BrokerExecuteContext {
+		return executeCtx
+	})
+
+	api := NewMetricAPI(nil, nil, nil, executorFactory, jobManager, *config.NewDefaultQuery(), NewQueryLimiter(0))
+	api.timeout = 50 * time.Millisecond
+
+	// range loop over result chan ends when query timeout
+	start := time.Now()
+	resp := httptest.NewRecorder()
+	api.Search(resp, httptest.NewRequest(http.MethodGet, "/query/metric?db=test&sql=select+f+from+cpu", nil))
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, resp.Body.String(), "context deadline exceeded")
+}
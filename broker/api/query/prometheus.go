@@ -0,0 +1,178 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/broker/api/write"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/protocol/prompb"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// for testing
+var (
+	readBodyFunc      = write.ReadSnappyBody
+	promReadParseFunc = protocol.PromRemoteReadParse
+	promEncodeFunc    = protocol.PromRemoteReadEncode
+)
+
+// PrometheusReadAPI represents the prometheus remote read api
+type PrometheusReadAPI struct {
+	replicaStateMachine  replica.StatusStateMachine
+	nodeStateMachine     broker.NodeStateMachine
+	databaseStateMachine database.DBStateMachine
+	executorFactory      parallel.ExecutorFactory
+	jobManager           parallel.JobManager
+	limiter              *QueryLimiter
+	timeout              time.Duration
+}
+
+// NewPrometheusReadAPI creates the prometheus remote read api
+func NewPrometheusReadAPI(replicaStateMachine replica.StatusStateMachine,
+	nodeStateMachine broker.NodeStateMachine, databaseStateMachine database.DBStateMachine,
+	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager,
+	cfg config.Query, limiter *QueryLimiter) *PrometheusReadAPI {
+	return &PrometheusReadAPI{
+		replicaStateMachine:  replicaStateMachine,
+		nodeStateMachine:     nodeStateMachine,
+		databaseStateMachine: databaseStateMachine,
+		executorFactory:      executorFactory,
+		jobManager:           jobManager,
+		limiter:              limiter,
+		timeout:              cfg.Timeout.Duration(),
+	}
+}
+
+// Read parses the snappy-compressed protobuf ReadRequest of prometheus remote read,
+// translates each query's label matchers into LinDB query, then responses the matched time series.
+func (m *PrometheusReadAPI) Read(w http.ResponseWriter, r *http.Request) {
+	db, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	timeout, err := getQueryTimeout(r, m.timeout)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	// body is compressed by snappy, limits the size of body as remote write does
+	data, err := readBodyFunc(w, r)
+	if err != nil {
+		api.BadRequest(w, err)
+		return
+	}
+	req, err := promReadParseFunc(data)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := m.limiter.Acquire(db); err != nil {
+		tooManyQueries(w, err)
+		return
+	}
+	defer m.limiter.Release(db)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+	defer cancel()
+
+	resp := &prompb.ReadResponse{}
+	for _, promQuery := range req.Queries {
+		result, err := m.query(ctx, db, promQuery)
+		if err != nil {
+			api.Error(w, err)
+			return
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	body, err := promEncodeFunc(resp)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// query executes the query of prometheus remote read
+func (m *PrometheusReadAPI) query(ctx context.Context, db string, promQuery *prompb.Query) (*prompb.QueryResult, error) {
+	query, err := protocol.PromQueryToStmt(promQuery)
+	if err != nil {
+		return nil, err
+	}
+	// group by all tag keys of metric for returning raw time series
+	groupBy, err := m.tagKeys(ctx, db, query.MetricName)
+	if err != nil {
+		return nil, err
+	}
+	query.GroupBy = groupBy
+
+	exec := m.executorFactory.NewBrokerQueryExecutor(ctx, db, query,
+		m.replicaStateMachine, m.nodeStateMachine, m.databaseStateMachine,
+		m.jobManager)
+	exec.Execute()
+
+	exeCtx := exec.ExecuteContext()
+	for result := range exeCtx.ResultCh() {
+		exeCtx.Emit(result)
+	}
+	resultSet, err := exeCtx.ResultSet()
+	if err != nil {
+		return nil, err
+	}
+	return protocol.PromQueryResult(resultSet), nil
+}
+
+// tagKeys returns the sorted tag keys of metric
+func (m *PrometheusReadAPI) tagKeys(ctx context.Context, db, metricName string) ([]string, error) {
+	exec := m.executorFactory.NewMetadataBrokerExecutor(ctx, db, &stmt.Metadata{
+		Type:       stmt.TagKey,
+		MetricName: metricName,
+		Limit:      constants.MaxSuggestions,
+	}, m.replicaStateMachine, m.nodeStateMachine, m.jobManager)
+	values, err := exec.Execute()
+	if err != nil {
+		return nil, err
+	}
+	// tag keys maybe duplicate, because they come from multi storage nodes
+	keys := make(map[string]struct{}, len(values))
+	var tagKeys []string
+	for _, value := range values {
+		if _, ok := keys[value]; ok {
+			continue
+		}
+		keys[value] = struct{}{}
+		tagKeys = append(tagKeys, value)
+	}
+	sort.Strings(tagKeys)
+	return tagKeys, nil
+}
@@ -0,0 +1,239 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/broker/api/write"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/protocol/prompb"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestPrometheusReadAPI_Read(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		promReadParseFunc = protocol.PromRemoteReadParse
+		ctrl.Finish()
+	}()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	metadataExecutor := parallel.NewMockMetadataExecutor(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	api := NewPrometheusReadAPI(nil, nil, nil, executorFactory, nil, *config.NewDefaultQuery(), NewQueryLimiter(0))
+
+	promReadParseFunc = func(data []byte) (*prompb.ReadRequest, error) {
+		return &prompb.ReadRequest{Queries: []*prompb.Query{{
+			StartTimestampMs: 10,
+			EndTimestampMs:   20,
+			Matchers:         []*prompb.LabelMatcher{{Type: prompb.MatchEqual, Name: "__name__", Value: "cpu"}},
+		}}}, nil
+	}
+	executorFactory.EXPECT().NewMetadataBrokerExecutor(gomock.Any(), "test", gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, request, _, _, _ interface{}) parallel.MetadataExecutor {
+			metadata := request.(*stmt.Metadata)
+			assert.Equal(t, stmt.TagKey, metadata.Type)
+			assert.Equal(t, "cpu", metadata.MetricName)
+			return metadataExecutor
+		})
+	metadataExecutor.EXPECT().Execute().Return([]string{"zone", "host", "zone"}, nil)
+	executorFactory.EXPECT().NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, query, _, _, _, _ interface{}) parallel.BrokerExecutor {
+			assert.Equal(t, []string{"host", "zone"}, query.(*stmt.Query).GroupBy)
+			return brokerExecutor
+		})
+	brokerExecutor.EXPECT().Execute()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
+	ch := make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	rs := models.NewResultSet()
+	rs.MetricName = "cpu"
+	s := models.NewSeries(map[string]string{"host": "a", "zone": "sh"})
+	s.Fields[protocol.PromFieldName] = map[int64]float64{10: 1}
+	rs.AddSeries(s)
+	executeCtx.EXPECT().ResultSet().Return(rs, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/prometheus/read?db=test", nil)
+	rr := httptest.NewRecorder()
+	api.Read(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "snappy", rr.Header().Get("Content-Encoding"))
+	resp := &prompb.ReadResponse{}
+	data, err := ioutil.ReadAll(rr.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, decodeReadResponse(data, resp))
+	assert.Len(t, resp.Results, 1)
+	assert.Len(t, resp.Results[0].Timeseries, 1)
+	assert.Equal(t, []*prompb.Sample{{Value: 1, Timestamp: 10}}, resp.Results[0].Timeseries[0].Samples)
+}
+
+func TestPrometheusReadAPI_Read_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readBodyFunc = write.ReadSnappyBody
+		promReadParseFunc = protocol.PromRemoteReadParse
+		promEncodeFunc = protocol.PromRemoteReadEncode
+		ctrl.Finish()
+	}()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	metadataExecutor := parallel.NewMockMetadataExecutor(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	api := NewPrometheusReadAPI(nil, nil, nil, executorFactory, nil, *config.NewDefaultQuery(), NewQueryLimiter(0))
+	// param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// invalid timeout
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test&timeout=abc",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// read body error
+	readBodyFunc = func(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: http.StatusBadRequest,
+	})
+	readBodyFunc = write.ReadSnappyBody
+	// parse request error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	promReadParseFunc = func(data []byte) (*prompb.ReadRequest, error) {
+		return &prompb.ReadRequest{}, nil
+	}
+	// too many concurrent queries
+	api.limiter = NewQueryLimiter(1)
+	assert.NoError(t, api.limiter.Acquire("test"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 429,
+	})
+	api.limiter.Release("test")
+	// no metric name
+	promReadParseFunc = func(data []byte) (*prompb.ReadRequest, error) {
+		return &prompb.ReadRequest{Queries: []*prompb.Query{{}}}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	promReadParseFunc = func(data []byte) (*prompb.ReadRequest, error) {
+		return &prompb.ReadRequest{Queries: []*prompb.Query{{
+			Matchers: []*prompb.LabelMatcher{{Type: prompb.MatchEqual, Name: "__name__", Value: "cpu"}},
+		}}}, nil
+	}
+	// get tag keys error
+	executorFactory.EXPECT().NewMetadataBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).Return(metadataExecutor).AnyTimes()
+	metadataExecutor.EXPECT().Execute().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// query error
+	metadataExecutor.EXPECT().Execute().Return(nil, nil).AnyTimes()
+	executorFactory.EXPECT().NewBrokerQueryExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(brokerExecutor).AnyTimes()
+	brokerExecutor.EXPECT().Execute().AnyTimes()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx).AnyTimes()
+	ch := make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch).AnyTimes()
+	executeCtx.EXPECT().ResultSet().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// encode response error
+	executeCtx.EXPECT().ResultSet().Return(models.NewResultSet(), nil)
+	promEncodeFunc = func(resp *prompb.ReadResponse) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+}
+
+func TestPrometheusReadAPI_Read_bodyTooLarge(t *testing.T) {
+	defer write.SetMaxBodySize(0)
+
+	api := NewPrometheusReadAPI(nil, nil, nil, nil, nil, *config.NewDefaultQuery(), NewQueryLimiter(0))
+	write.SetMaxBodySize(64)
+	// decompressed body too large, though compressed body is small
+	data := snappy.Encode(nil, make([]byte, 1024))
+	assert.True(t, len(data) <= 64)
+	r := httptest.NewRequest(http.MethodPost, "/prometheus/read?db=test", bytes.NewReader(data))
+	rr := httptest.NewRecorder()
+	api.Read(rr, r)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "request body too large")
+}
+
+func decodeReadResponse(data []byte, resp *prompb.ReadResponse) error {
+	decoded, err := snappy.Decode(nil, data)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(decoded, resp)
+}
@@ -0,0 +1,84 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+var errUnknownQueryManageStmt = errors.New("unknown show queries/kill query statement")
+
+// RunningQueryAPI represents the running query manage api of current broker
+type RunningQueryAPI struct {
+	jobManager parallel.JobManager
+}
+
+// NewRunningQueryAPI creates running query api instance
+func NewRunningQueryAPI(jobManager parallel.JobManager) *RunningQueryAPI {
+	return &RunningQueryAPI{
+		jobManager: jobManager,
+	}
+}
+
+// Show handles the show queries statement, lists the running queries
+func (q *RunningQueryAPI) Show(w http.ResponseWriter, r *http.Request) {
+	statement, err := parseRunningQueryStmt(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if _, ok := statement.(*stmt.ShowQueries); !ok {
+		api.Error(w, errUnknownQueryManageStmt)
+		return
+	}
+	api.OK(w, q.jobManager.GetJobs())
+}
+
+// Kill handles the kill query jobID statement, kills the running query
+func (q *RunningQueryAPI) Kill(w http.ResponseWriter, r *http.Request) {
+	statement, err := parseRunningQueryStmt(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	s, ok := statement.(*stmt.KillQuery)
+	if !ok {
+		api.Error(w, errUnknownQueryManageStmt)
+		return
+	}
+	if err := q.jobManager.KillJob(s.JobID); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, "success")
+}
+
+// parseRunningQueryStmt parses the running query manage statement of request
+func parseRunningQueryStmt(r *http.Request) (stmt.Statement, error) {
+	ql, err := api.GetParamsFromRequest("sql", r, "", true)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Parse(ql)
+}
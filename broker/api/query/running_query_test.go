@@ -0,0 +1,86 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+)
+
+func TestRunningQueryAPI_Show(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := parallel.NewMockJobManager(ctrl)
+	queryAPI := NewRunningQueryAPI(jobManager)
+	doRequest := func(ql string, expectHTTPCode int) {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodGet,
+			URL:            "/query/running?sql=" + url.QueryEscape(ql),
+			HandlerFunc:    queryAPI.Show,
+			ExpectHTTPCode: expectHTTPCode,
+		})
+	}
+	// case 1: no sql
+	doRequest("", http.StatusInternalServerError)
+	// case 2: parse sql err
+	doRequest("show abc", http.StatusInternalServerError)
+	// case 3: unknown statement
+	doRequest("show databases", http.StatusInternalServerError)
+	// case 4: kill query cannot be executed by show api
+	doRequest("kill query 1", http.StatusInternalServerError)
+	// case 5: show queries
+	jobManager.EXPECT().GetJobs().Return([]models.RunningQuery{{JobID: 1, SQL: "select f from cpu"}})
+	doRequest("show queries", http.StatusOK)
+}
+
+func TestRunningQueryAPI_Kill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := parallel.NewMockJobManager(ctrl)
+	queryAPI := NewRunningQueryAPI(jobManager)
+	doRequest := func(ql string, expectHTTPCode int) {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodDelete,
+			URL:            "/query/running?sql=" + url.QueryEscape(ql),
+			HandlerFunc:    queryAPI.Kill,
+			ExpectHTTPCode: expectHTTPCode,
+		})
+	}
+	// case 1: no sql
+	doRequest("", http.StatusInternalServerError)
+	// case 2: parse sql err
+	doRequest("kill query abc", http.StatusInternalServerError)
+	// case 3: show queries cannot be executed by kill api
+	doRequest("show queries", http.StatusInternalServerError)
+	// case 4: kill query failure
+	jobManager.EXPECT().KillJob(int64(1)).Return(fmt.Errorf("err"))
+	doRequest("kill query 1", http.StatusInternalServerError)
+	// case 5: kill query
+	jobManager.EXPECT().KillJob(int64(1)).Return(nil)
+	doRequest("kill query 1", http.StatusOK)
+}
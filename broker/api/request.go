@@ -31,6 +31,7 @@ func GetJSONBodyFromRequest(r *http.Request, t interface{}) error {
 
 // GetParamsFromRequest gets parameter value from the request。
 // If the request method is neither GET or POST,returns an error.
+// If the request method is POST and the value isn't in the form, gets the value from the url.
 // If there are multiple parameters with the same paramsName,returns the first value.
 // If there does not have the value and required is false, returns an error, otherwise returns the defaultValue
 func GetParamsFromRequest(paramsName string, r *http.Request, defaultValue string, required bool) (string, error) {
@@ -47,6 +48,10 @@ func GetParamsFromRequest(paramsName string, r *http.Request, defaultValue strin
 			return "", err
 		}
 		values := r.PostForm[paramsName]
+		if len(values) == 0 {
+			// the request body maybe isn't form(e.g. protobuf), try to get value from the url
+			values = r.URL.Query()[paramsName]
+		}
 		if len(values) > 0 {
 			value = values[0]
 		}
@@ -61,6 +61,11 @@ func TestGetJSONBodyFromRequest(t *testing.T) {
 	value, err = GetParamsFromRequest("key", req2, "defaultValue", true)
 	assert.NoError(t, err)
 	assert.Equal(t, "value", value)
+
+	req2, _ = http.NewRequest("POST", "/database?key=value2", bytes.NewReader([]byte("body")))
+	value, err = GetParamsFromRequest("key", req2, "defaultValue", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "value2", value)
 }
 
 func TestGetParamsFromRequest(t *testing.T) {
@@ -19,7 +19,10 @@ package api
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // OK responses with content and set the http status code 200
@@ -44,6 +47,25 @@ func Error(w http.ResponseWriter, err error) {
 	response(w, http.StatusInternalServerError, b)
 }
 
+// BadRequest responses error message and set the http status code 400
+func BadRequest(w http.ResponseWriter, err error) {
+	b, _ := json.Marshal(err.Error())
+	response(w, http.StatusBadRequest, b)
+}
+
+// Forbidden responses error message and set the http status code 403
+func Forbidden(w http.ResponseWriter, err error) {
+	b, _ := json.Marshal(err.Error())
+	response(w, http.StatusForbidden, b)
+}
+
+// TooManyRequests responses error message with Retry-After(seconds) header and set the http status code 429
+func TooManyRequests(w http.ResponseWriter, retryAfter time.Duration, err error) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	b, _ := json.Marshal(err.Error())
+	response(w, http.StatusTooManyRequests, b)
+}
+
 // response responses json body for http restful api
 func response(w http.ResponseWriter, httpCode int, content []byte) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -55,3 +77,40 @@ func response(w http.ResponseWriter, httpCode int, content []byte) {
 		}
 	}
 }
+
+// StreamWriter writes json objects as NDJSON(newline delimited json) stream with chunked transfer encoding,
+// the http status code 200 is written when the first object written.
+type StreamWriter struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+	started bool
+}
+
+// NewStreamWriter creates a NDJSON stream writer
+func NewStreamWriter(w http.ResponseWriter) *StreamWriter {
+	return &StreamWriter{
+		w:       w,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// Write writes the object as one line, then flushes it to client
+func (s *StreamWriter) Write(a interface{}) error {
+	if !s.started {
+		s.w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	if err := s.encoder.Encode(a); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// Started returns if the stream has been started(response header written)
+func (s *StreamWriter) Started() bool {
+	return s.started
+}
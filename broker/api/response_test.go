@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +54,39 @@ func TestError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	assert.Equal(t, `"err"`, resp.Body.String())
 }
+
+func TestBadRequest(t *testing.T) {
+	resp := httptest.NewRecorder()
+	BadRequest(resp, fmt.Errorf("err"))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, `"err"`, resp.Body.String())
+}
+
+func TestForbidden(t *testing.T) {
+	resp := httptest.NewRecorder()
+	Forbidden(resp, fmt.Errorf("err"))
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Equal(t, `"err"`, resp.Body.String())
+}
+
+func TestTooManyRequests(t *testing.T) {
+	resp := httptest.NewRecorder()
+	TooManyRequests(resp, 1500*time.Millisecond, fmt.Errorf("err"))
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("Retry-After"))
+	assert.Equal(t, `"err"`, resp.Body.String())
+}
+
+func TestStreamWriter(t *testing.T) {
+	resp := httptest.NewRecorder()
+	w := NewStreamWriter(resp)
+	assert.False(t, w.Started())
+	assert.NoError(t, w.Write("a"))
+	assert.NoError(t, w.Write(1))
+	assert.True(t, w.Started())
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.True(t, resp.Flushed)
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "\"a\"\n1\n", resp.Body.String())
+	assert.Error(t, w.Write(make(chan int)))
+}
@@ -62,14 +62,10 @@ func NewRouter() *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
 	for _, route := range routes {
 		mds := getMiddleware(route.pattern)
-		var handler http.Handler
-		// this route.pattern set middleware
-		if len(mds) > 0 {
-			for _, md := range mds {
-				handler = md.Middleware(route.handler)
-			}
-		} else {
-			handler = route.handler
+		var handler http.Handler = route.handler
+		// chain all middleware of this route.pattern, the first added middleware is outermost
+		for i := len(mds) - 1; i >= 0; i-- {
+			handler = mds[i].Middleware(handler)
 		}
 		router.
 			Methods([]string{route.method, http.MethodOptions}...).
@@ -97,7 +93,7 @@ func NewRouter() *mux.Router {
 
 				w.Header().Set("Access-Control-Allow-Headers",
 					"Origin, X-Requested-With, X-HTTP-Method-Override,accept-charset,accept-encoding "+
-						", Content-Type, Accept, Authorization")
+						", Content-Type, Accept, Authorization, X-Api-Key")
 
 				if r.Method == http.MethodOptions {
 					return
@@ -110,3 +110,24 @@ func TestRouteHandle(t *testing.T) {
 	resp = httptest.NewRecorder()
 	r.ServeHTTP(resp, req)
 }
+
+func TestNewRouter_MiddlewareChain(t *testing.T) {
+	var calls []string
+	newMiddleware := func(name string) func(next http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	AddMiddleware(newMiddleware("first"), regexp.MustCompile("^/chain/"))
+	AddMiddleware(newMiddleware("second"), regexp.MustCompile("^/chain/route$"))
+	AddRoute("chain", http.MethodGet, "/chain/route", func(writer http.ResponseWriter, request *http.Request) {
+		calls = append(calls, "handler")
+	})
+	r := NewRouter()
+	req, _ := http.NewRequest(http.MethodGet, "/chain/route", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
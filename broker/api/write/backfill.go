@@ -0,0 +1,254 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cespare/xxhash"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/tlsutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/point"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/service"
+)
+
+var (
+	backfillLock   sync.RWMutex
+	backfillScheme = "http"
+	backfillDo     = http.DefaultClient.Do
+)
+
+// SetBackfillClient sets the http client which sends metrics to the backfill api of storage nodes,
+// requests with timeout, and by https if tls enable.
+func SetBackfillClient(cfg config.StorageHTTP) error {
+	scheme, client, err := tlsutil.NewHTTPClient(cfg.TLS, cfg.Timeout.Duration())
+	if err != nil {
+		return err
+	}
+	backfillLock.Lock()
+	backfillScheme = scheme
+	backfillDo = client.Do
+	backfillLock.Unlock()
+	return nil
+}
+
+// backfillClient returns the scheme and the do function of backfill http client
+func backfillClient() (scheme string, do func(req *http.Request) (*http.Response, error)) {
+	backfillLock.RLock()
+	defer backfillLock.RUnlock()
+	return backfillScheme, backfillDo
+}
+
+// MetricBackfill represents the backfill of historical data, routes the metrics by the shard assignment of database
+// as broker write does, then writes the metrics of each shard into all replicas by the backfill api of storage nodes.
+type MetricBackfill struct {
+	shardAssignService service.ShardAssignService
+	namespaceResolver  *namespaceResolver
+}
+
+// NewMetricBackfill creates metric backfill
+func NewMetricBackfill(shardAssignService service.ShardAssignService,
+	databaseStateMachine database.DBStateMachine,
+) *MetricBackfill {
+	return &MetricBackfill{
+		shardAssignService: shardAssignService,
+		namespaceResolver:  &namespaceResolver{databaseStateMachine: databaseStateMachine},
+	}
+}
+
+// Backfill parses line protocol(default) or json format(Content-Type: application/json) like write api,
+// routes the metrics by the num. of shard and routing epochs of database, then writes the metrics of each shard
+// into all replicas by building sst files directly, bypasses the time window(behind/ahead), wal and replication.
+// Responses error with the failed shards/replicas if the backfill of any replica fails,
+// NOTICE: backfill isn't idempotent, only retries the failed replicas by the backfill api of storage node.
+func (m *MetricBackfill) Backfill(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := getNamespace(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	shardAssign, err := m.shardAssignService.Get(databaseName)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	data, err := readBody(w, r)
+	if err != nil {
+		api.BadRequest(w, err)
+		return
+	}
+	parse := lineParseFunc
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		parse = jsonParseFunc
+	}
+	metricList, err := parse(data)
+	result := &writeResult{}
+	if err != nil {
+		parseErrors, ok := err.(point.ParseErrors)
+		if !ok || metricList == nil || len(metricList.Metrics) == 0 {
+			api.Error(w, err)
+			return
+		}
+		result.Failed = len(parseErrors)
+		for _, parseErr := range parseErrors {
+			result.Errors = append(result.Errors, parseErr.Error())
+		}
+	}
+	rejected, rejectedErrs, err := m.namespaceResolver.resolve(databaseName, namespace, metricList)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	result.Rejected = rejected
+	result.Errors = append(result.Errors, rejectedErrs...)
+	var (
+		failures []string
+		mutex    sync.Mutex
+		wait     sync.WaitGroup
+	)
+	// backfills the shards concurrently
+	for shardID, metrics := range routeBackfillMetrics(shardAssign, metricList) {
+		wait.Add(1)
+		go func(shardID int, metrics *pb.MetricList) {
+			defer wait.Done()
+			if err := backfillShard(shardAssign, shardID, metrics); err != nil {
+				mutex.Lock()
+				failures = append(failures, err.Error())
+				mutex.Unlock()
+			}
+		}(shardID, metrics)
+	}
+	wait.Wait()
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		api.Error(w, fmt.Errorf("backfill database[%s] failure: %s", databaseName, strings.Join(failures, "; ")))
+		return
+	}
+	result.Written = len(metricList.Metrics)
+	api.OK(w, result)
+}
+
+// routeBackfillMetrics routes the metrics to shards as database channel does,
+// uses the routing epoch which contains metric's timestamp, make sure history series is written into right shard.
+func routeBackfillMetrics(shardAssign *models.ShardAssignment, metricList *pb.MetricList) map[int]*pb.MetricList {
+	defaultNumOfShard := uint64(len(shardAssign.Shards))
+	shardMetrics := make(map[int]*pb.MetricList)
+	for _, metric := range metricList.Metrics {
+		hash := xxhash.Sum64String(tag.Concat(metric.Tags))
+		metric.TagsHash = hash
+		numOfShard := defaultNumOfShard
+		if n := shardAssign.Epochs.NumOfShard(metric.Timestamp); n > 0 {
+			numOfShard = uint64(n)
+		}
+		shardID := int(hash % numOfShard)
+		metrics, ok := shardMetrics[shardID]
+		if !ok {
+			metrics = &pb.MetricList{}
+			shardMetrics[shardID] = metrics
+		}
+		metrics.Metrics = append(metrics.Metrics, metric)
+	}
+	return shardMetrics
+}
+
+// backfillShard writes the metrics of shard into all replicas concurrently by the backfill api of storage nodes,
+// returns error with the failed replicas.
+func backfillShard(shardAssign *models.ShardAssignment, shardID int, metricList *pb.MetricList) error {
+	replica, ok := shardAssign.Shards[shardID]
+	if !ok || len(replica.Replicas) == 0 {
+		return fmt.Errorf("replicas of shard[%d] not found", shardID)
+	}
+	data, err := metricList.Marshal()
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("db", shardAssign.Name)
+	params.Set("shard", strconv.Itoa(shardID))
+	var (
+		failures []string
+		mutex    sync.Mutex
+		wait     sync.WaitGroup
+	)
+	for _, replicaID := range replica.Replicas {
+		node, ok := shardAssign.Nodes[replicaID]
+		if !ok {
+			mutex.Lock()
+			failures = append(failures, fmt.Sprintf("replica[%d] not found", replicaID))
+			mutex.Unlock()
+			continue
+		}
+		wait.Add(1)
+		go func(node *models.Node) {
+			defer wait.Done()
+			if err := doBackfill(node, params, data); err != nil {
+				mutex.Lock()
+				failures = append(failures, fmt.Sprintf("replica[%s] error:%s", node.Indicator(), err))
+				mutex.Unlock()
+			}
+		}(node)
+	}
+	wait.Wait()
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("backfill shard[%d] failure: %s", shardID, strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// doBackfill sends the protobuf metrics to the backfill api of storage node
+func doBackfill(node *models.Node, params url.Values, data []byte) error {
+	scheme, do := backfillClient()
+	reqURL := fmt.Sprintf("%s://%s:%d/backfill?%s", scheme, node.IP, node.HTTPPort, params.Encode())
+	req, err := http.NewRequest(http.MethodPut, reqURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("status: %d, response: %s", resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/service"
+)
+
+func TestMetricBackfill_Backfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		backfillDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	api := NewMetricBackfill(shardAssignService, newMockDatabaseSM(ctrl))
+	line := []byte("cpu,host=1.1.1.1 usage_SUM=1 1577000000000\ncpu,host=1.1.1.2 usage_SUM=1 1577000000000")
+
+	// case 1: db param not exist
+	rr := doWriteRequest(api.Backfill, "/metric/backfill", line, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	// case 2: get shard assignment err
+	shardAssignService.EXPECT().Get("dal").Return(nil, fmt.Errorf("err"))
+	rr = doWriteRequest(api.Backfill, "/metric/backfill?db=dal", line, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	shardAssign := models.NewShardAssignment("dal")
+	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", HTTPPort: 2892}
+	shardAssign.Nodes[2] = &models.Node{IP: "1.1.1.2", HTTPPort: 2892}
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(0, 2)
+	shardAssignService.EXPECT().Get("dal").Return(shardAssign, nil).AnyTimes()
+	// case 3: parse err
+	rr = doWriteRequest(api.Backfill, "/metric/backfill?db=dal", []byte("cpu"), nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	// case 4: backfill all replicas concurrently
+	var (
+		targets []string
+		mutex   sync.Mutex
+	)
+	backfillDo = func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		targets = append(targets, req.URL.Host)
+		mutex.Unlock()
+		assert.Equal(t, "0", req.URL.Query().Get("shard"))
+		data, _ := ioutil.ReadAll(req.Body)
+		metricList := &pb.MetricList{}
+		assert.NoError(t, metricList.Unmarshal(data))
+		assert.Len(t, metricList.Metrics, 2)
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+	}
+	rr = doWriteRequest(api.Backfill, "/metric/backfill?db=dal", line, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":2}`, rr.Body.String())
+	sort.Strings(targets)
+	assert.Equal(t, []string{"1.1.1.1:2892", "1.1.1.2:2892"}, targets)
+	// case 5: backfill replica failure
+	backfillDo = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "1.1.1.2:2892" {
+			return nil, fmt.Errorf("err")
+		}
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: ioutil.NopCloser(strings.NewReader("err"))}, nil
+	}
+	rr = doWriteRequest(api.Backfill, "/metric/backfill?db=dal", line, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Contains(t, rr.Body.String(), "1.1.1.1:0")
+	// case 6: replica node not found
+	shardAssign.AddReplica(0, 3)
+	rr = doWriteRequest(api.Backfill, "/metric/backfill?db=dal", line, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestSetBackfillClient(t *testing.T) {
+	defer func() {
+		backfillScheme = "http"
+		backfillDo = http.DefaultClient.Do
+	}()
+	assert.Error(t, SetBackfillClient(config.StorageHTTP{TLS: config.TLS{Enable: true}}))
+	assert.NoError(t, SetBackfillClient(config.StorageHTTP{Timeout: ltoml.Duration(time.Second)}))
+	scheme, _ := backfillClient()
+	assert.Equal(t, "http", scheme)
+
+	backfillScheme = "https"
+	var reqURL string
+	backfillDo = func(req *http.Request) (*http.Response, error) {
+		reqURL = req.URL.String()
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+	}
+	assert.NoError(t, doBackfill(&models.Node{IP: "1.1.1.1", HTTPPort: 2892}, url.Values{"db": []string{"dal"}}, nil))
+	assert.Equal(t, "https://1.1.1.1:2892/backfill?db=dal", reqURL)
+}
+
+func TestRouteBackfillMetrics(t *testing.T) {
+	shardAssign := models.NewShardAssignment("dal")
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddReplica(2, 1)
+	shardAssign.AddRoutingEpoch(1, 3, 1000)
+	metricList := &pb.MetricList{Metrics: []*pb.Metric{
+		{Name: "cpu", Timestamp: 10, Tags: map[string]string{"host": "1.1.1.1"}},
+		{Name: "cpu", Timestamp: 10, Tags: map[string]string{"host": "1.1.1.2"}},
+	}}
+	// history data is routed by the epoch of original num. of shard
+	shardMetrics := routeBackfillMetrics(shardAssign, metricList)
+	assert.Len(t, shardMetrics, 1)
+	assert.Len(t, shardMetrics[0].Metrics, 2)
+	assert.NotZero(t, shardMetrics[0].Metrics[0].TagsHash)
+	// replicas of shard not found
+	assert.Error(t, backfillShard(shardAssign, 5, metricList))
+}
@@ -0,0 +1,46 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/pkg/option"
+)
+
+// consistencyResolver resolves the write consistency(durability level of acknowledgement) for all write endpoints,
+// uses the consistency param of request(buffered/wal/replicated:N) if set, else uses the option of database.
+type consistencyResolver struct {
+	databaseStateMachine database.DBStateMachine
+}
+
+// resolve returns the write consistency of request, returns error if consistency is invalid
+func (cr *consistencyResolver) resolve(databaseName string, r *http.Request) (option.WriteConsistency, error) {
+	consistency, err := api.GetParamsFromRequest("consistency", r, "", false)
+	if err != nil {
+		return option.WriteConsistency{}, err
+	}
+	if consistency == "" {
+		if databaseCfg, ok := cr.databaseStateMachine.GetDatabaseCfg(databaseName); ok {
+			consistency = databaseCfg.Option.WriteConsistency
+		}
+	}
+	return option.ParseWriteConsistency(consistency)
+}
@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/replication"
+)
+
+func TestConsistencyResolver_resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	resolver := &consistencyResolver{databaseStateMachine: databaseSM}
+
+	// case 1: database not found, default buffered
+	databaseSM.EXPECT().GetDatabaseCfg("db").Return(models.Database{}, false)
+	consistency, err := resolver.resolve("db", httptest.NewRequest(http.MethodPut, "/write?db=db", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, option.WriteConsistency{Level: option.ConsistencyBuffered}, consistency)
+	// case 2: use database option
+	databaseSM.EXPECT().GetDatabaseCfg("db").
+		Return(models.Database{Option: option.DatabaseOption{WriteConsistency: "replicated:2"}}, true)
+	consistency, err = resolver.resolve("db", httptest.NewRequest(http.MethodPut, "/write?db=db", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, option.WriteConsistency{Level: option.ConsistencyReplicated, Replicas: 2}, consistency)
+	// case 3: use request param
+	consistency, err = resolver.resolve("db", httptest.NewRequest(http.MethodPut, "/write?db=db&consistency=wal", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, option.WriteConsistency{Level: option.ConsistencyWAL}, consistency)
+	// case 4: invalid consistency
+	_, err = resolver.resolve("db", httptest.NewRequest(http.MethodPut, "/write?db=db&consistency=abc", nil))
+	assert.Error(t, err)
+	// case 5: unsupported method
+	_, err = resolver.resolve("db", httptest.NewRequest(http.MethodPatch, "/write?db=db", nil))
+	assert.Error(t, err)
+}
+
+func TestPrometheusWrite_invalidConsistency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewPrometheusWrite(replication.NewMockChannelManager(ctrl), newMockDatabaseSM(ctrl))
+	rr := doWriteRequest(api.Write, "/metric/prometheus?db=dal&consistency=abc", nil, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	rr = doWriteRequest(api.RemoteWrite, "/prometheus/write?db=dal&consistency=abc", nil, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
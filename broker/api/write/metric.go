@@ -0,0 +1,219 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/replication"
+	"github.com/lindb/lindb/series/point"
+)
+
+// for testing
+var (
+	lineParseFunc = protocol.LineParse
+	jsonParseFunc = protocol.JSONParse
+)
+
+// defaultMaxBodySize represents the default max size of write request body after decompression
+const defaultMaxBodySize = 32 * 1024 * 1024
+
+var errBodyTooLarge = errors.New("request body too large")
+
+var (
+	maxBodySizeLock sync.RWMutex
+	maxBodySize     int64 = defaultMaxBodySize
+)
+
+// SetMaxBodySize sets the max size(bytes) of write request body after decompression
+func SetMaxBodySize(size int64) {
+	if size <= 0 {
+		size = defaultMaxBodySize
+	}
+	maxBodySizeLock.Lock()
+	maxBodySize = size
+	maxBodySizeLock.Unlock()
+}
+
+// getMaxBodySize returns the max size(bytes) of write request body after decompression
+func getMaxBodySize() int64 {
+	maxBodySizeLock.RLock()
+	defer maxBodySizeLock.RUnlock()
+	return maxBodySize
+}
+
+// writeResult represents the result of write request
+type writeResult struct {
+	Written  int      `json:"written"`
+	Failed   int      `json:"failed,omitempty"`   // number of invalid lines
+	Rejected int      `json:"rejected,omitempty"` // number of metrics out of time window or not allowed namespace
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// MetricWrite represents support InfluxDB-style line protocol and json format
+type MetricWrite struct {
+	cm                  replication.ChannelManager
+	namespaceResolver   *namespaceResolver
+	consistencyResolver *consistencyResolver
+	timeWindowFilter    *timeWindowFilter
+}
+
+// NewMetricWrite creates metric write
+func NewMetricWrite(cm replication.ChannelManager, databaseStateMachine database.DBStateMachine) *MetricWrite {
+	return &MetricWrite{
+		cm:                  cm,
+		namespaceResolver:   &namespaceResolver{databaseStateMachine: databaseStateMachine},
+		consistencyResolver: &consistencyResolver{databaseStateMachine: databaseStateMachine},
+		timeWindowFilter:    &timeWindowFilter{databaseStateMachine: databaseStateMachine},
+	}
+}
+
+// Write parses line protocol(default) or json format(Content-Type: application/json),
+// the metrics without namespace are written into the namespace of ns param(default namespace if not set),
+// then writes data into wal, the request body can be compressed by gzip(Content-Encoding: gzip).
+// If some lines are invalid, writes the valid lines and responses the parse errors of invalid lines,
+// responses 400 if request body is too large or no metric is accepted,
+// the metrics out of time window(behind/ahead) are dropped/rejected based on database option.
+// Responses after the data is acknowledged based on write consistency(consistency param or database option).
+func (m *MetricWrite) Write(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := getNamespace(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	consistency, err := m.consistencyResolver.resolve(databaseName, r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	data, err := readBody(w, r)
+	if err != nil {
+		api.BadRequest(w, err)
+		return
+	}
+	parse := lineParseFunc
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		parse = jsonParseFunc
+	}
+	metricList, err := parse(data)
+	result := &writeResult{}
+	if err != nil {
+		parseErrors, ok := err.(point.ParseErrors)
+		if !ok || metricList == nil || len(metricList.Metrics) == 0 {
+			api.BadRequest(w, err)
+			return
+		}
+		result.Failed = len(parseErrors)
+		for _, parseErr := range parseErrors {
+			result.Errors = append(result.Errors, parseErr.Error())
+		}
+	}
+	rejected, rejectedErrs, err := m.namespaceResolver.resolve(databaseName, namespace, metricList)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	result.Rejected = rejected
+	result.Errors = append(result.Errors, rejectedErrs...)
+	rejected, rejectedErrs = m.timeWindowFilter.filter(databaseName, metricList)
+	result.Rejected += rejected
+	result.Errors = append(result.Errors, rejectedErrs...)
+	if len(metricList.Metrics) == 0 && len(result.Errors) > 0 {
+		// no metric accepted, all lines are invalid or rejected
+		api.BadRequest(w, fmt.Errorf("no metric accepted: %s", strings.Join(result.Errors, "; ")))
+		return
+	}
+	if len(metricList.Metrics) > 0 {
+		if err := m.cm.Write(r.Context(), databaseName, metricList, consistency); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	result.Written = len(metricList.Metrics)
+	api.OK(w, result)
+}
+
+// writeError responses 429 Too Many Requests with Retry-After if the writes are throttled by backpressure,
+// else responses 500.
+func writeError(w http.ResponseWriter, err error) {
+	if overloaded, ok := err.(*replication.OverloadedError); ok {
+		api.TooManyRequests(w, overloaded.RetryAfter, err)
+		return
+	}
+	api.Error(w, err)
+}
+
+// readBody reads the request body, decompresses the body if it is compressed by gzip,
+// returns errBodyTooLarge if the size of body(after decompression) is greater than max body size.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	limit := getMaxBodySize()
+	var reader io.Reader = http.MaxBytesReader(w, r.Body, limit)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = gzipReader.Close()
+		}()
+		reader = gzipReader
+	}
+	// reads one more byte to check if body is too large
+	data, err := readAllFunc(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errBodyTooLarge
+	}
+	return data, nil
+}
+
+// ReadSnappyBody reads the snappy-compressed request body without decompression,
+// returns errBodyTooLarge if the size of body(compressed or decompressed) is greater than max body size,
+// used by prometheus remote write/read.
+func ReadSnappyBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	limit := getMaxBodySize()
+	data, err := readAllFunc(io.LimitReader(http.MaxBytesReader(w, r.Body, limit), limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errBodyTooLarge
+	}
+	if n, err := snappy.DecodedLen(data); err == nil && int64(n) > limit {
+		return nil, errBodyTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,184 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func doWriteRequest(handler http.HandlerFunc, url string, body []byte, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMetricWrite_Write(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewMetricWrite(cm, newMockDatabaseSM(ctrl))
+	// case 1: param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/write",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: write line protocol success
+	line := []byte("cpu,host=1.1.1.1 usage_SUM=1 1577000000000\nmemory used_MAX=1 1577000000000")
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, db string, metricList *pb.MetricList, _ option.WriteConsistency) error {
+			assert.Len(t, metricList.Metrics, 2)
+			return nil
+		})
+	rr := doWriteRequest(api.Write, "/metric/write?db=dal", line, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":2}`, rr.Body.String())
+	// case 3: write wal err
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("err"))
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", line, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	// case 4: write json format
+	jsonData := []byte(`{"metrics":[{"name":"cpu","fields":[{"name":"usage","type":"sum","value":1}]}]}`)
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).Return(nil)
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", jsonData,
+		map[string]string{"Content-Type": "application/json"})
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":1}`, rr.Body.String())
+	// case 5: all lines invalid
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", []byte("cpu usage=1"), nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", []byte("abc"),
+		map[string]string{"Content-Type": "application/json"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	// case 6: partial lines invalid
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).Return(nil)
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal",
+		[]byte("cpu usage_SUM=1 1577000000000\ncpu,host=a\ncpu usage_SUM=2 1577000000000"), nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":2,"failed":1,"errors":["line 2: unable to parse 'cpu,host=a': fields is missing"]}`,
+		rr.Body.String())
+	// case 7: empty body
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", nil, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":0}`, rr.Body.String())
+	// case 8: invalid write consistency
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal&consistency=abc", line, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	// case 9: write with consistency
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), option.WriteConsistency{Level: option.ConsistencyWAL}).Return(nil)
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal&consistency=wal", line, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	// case 10: writes throttled by backpressure
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).
+		Return(&replication.OverloadedError{Database: "dal", RetryAfter: 5 * time.Second})
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", line, nil)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "5", rr.Header().Get("Retry-After"))
+}
+
+func TestMetricWrite_Write_gzip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		lineParseFunc = protocol.LineParse
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewMetricWrite(cm, newMockDatabaseSM(ctrl))
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, _ = writer.Write([]byte("cpu,host=1.1.1.1 usage_SUM=1 1577000000000"))
+	_ = writer.Close()
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).Return(nil)
+	rr := doWriteRequest(api.Write, "/metric/write?db=dal", buf.Bytes(),
+		map[string]string{"Content-Encoding": "gzip"})
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":1}`, rr.Body.String())
+	// invalid gzip body
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", []byte("abc"),
+		map[string]string{"Content-Encoding": "gzip"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	// read body err
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+	}()
+	readAllFunc = func(r io.Reader) ([]byte, error) {
+		return nil, errors.New("err")
+	}
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", buf.Bytes(), nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestMetricWrite_Write_bodyTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		SetMaxBodySize(0)
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewMetricWrite(cm, newMockDatabaseSM(ctrl))
+	SetMaxBodySize(64)
+	assert.Equal(t, int64(64), getMaxBodySize())
+	line := []byte("cpu,host=1.1.1.1 usage_SUM=1 1577000000000")
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).Return(nil)
+	rr := doWriteRequest(api.Write, "/metric/write?db=dal", line, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	// body too large
+	large := bytes.Repeat(append(line, '\n'), 10)
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", large, nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	// decompressed body too large, though compressed body is small
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, _ = writer.Write(large)
+	_ = writer.Close()
+	assert.True(t, buf.Len() <= 64)
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", buf.Bytes(),
+		map[string]string{"Content-Encoding": "gzip"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errBodyTooLarge.Error())
+	// reset default max body size
+	SetMaxBodySize(0)
+	assert.Equal(t, int64(defaultMaxBodySize), getMaxBodySize())
+}
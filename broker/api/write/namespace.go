@@ -0,0 +1,86 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/pkg/option"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+// namespaceResolver resolves the namespace of metrics for all write endpoints,
+// 1. sets the namespace(ns param of request) for the metrics which don't specify namespace
+// 2. rejects the metrics if the namespace is not allowed based on database option
+type namespaceResolver struct {
+	databaseStateMachine database.DBStateMachine
+}
+
+// resolve sets the namespace for metrics which don't specify namespace, then drops the metrics whose namespace
+// is not allowed, returns the number of rejected metrics and the reject reasons.
+func (nr *namespaceResolver) resolve(databaseName, namespace string,
+	metricList *pb.MetricList) (rejected int, errs []string, err error) {
+	if metricList == nil || len(metricList.Metrics) == 0 {
+		return
+	}
+	databaseCfg, ok := nr.databaseStateMachine.GetDatabaseCfg(databaseName)
+	if !ok {
+		err = fmt.Errorf("database [%s] not found", databaseName)
+		return
+	}
+	metrics := metricList.Metrics[:0]
+	for _, metric := range metricList.Metrics {
+		if metric.Namespace == "" {
+			metric.Namespace = namespace
+		}
+		if allowNamespace(databaseCfg.Option, metric.Namespace) {
+			metrics = append(metrics, metric)
+			continue
+		}
+		rejected++
+		errs = append(errs, fmt.Sprintf("metric [%s] namespace [%s] not exist in database [%s], "+
+			"please enable auto create namespace or add it into pre-defined namespaces",
+			metric.Name, metric.Namespace, databaseName))
+	}
+	metricList.Metrics = metrics
+	return
+}
+
+// allowNamespace checks if the namespace is allowed to write,
+// namespace check is enabled only if auto create namespace is disabled and pre-defined namespaces are configured,
+// then only default namespace and pre-defined namespaces are allowed.
+func allowNamespace(databaseOption option.DatabaseOption, namespace string) bool {
+	if databaseOption.AutoCreateNS || len(databaseOption.Namespaces) == 0 || namespace == constants.DefaultNamespace {
+		return true
+	}
+	for _, ns := range databaseOption.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// getNamespace returns the namespace from ns param of request, if not set returns default namespace
+func getNamespace(r *http.Request) (string, error) {
+	return api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+}
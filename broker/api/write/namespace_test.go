@@ -0,0 +1,164 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func newMockDatabaseSM(ctrl *gomock.Controller) database.DBStateMachine {
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	databaseSM.EXPECT().GetDatabaseCfg(gomock.Any()).
+		Return(models.Database{Option: option.DatabaseOption{AutoCreateNS: true}}, true).AnyTimes()
+	return databaseSM
+}
+
+func TestNamespaceResolver_resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	resolver := &namespaceResolver{databaseStateMachine: databaseSM}
+	// empty metric list
+	rejected, errs, err := resolver.resolve("db", "ns", nil)
+	assert.NoError(t, err)
+	assert.Zero(t, rejected)
+	assert.Empty(t, errs)
+	_, _, err = resolver.resolve("db", "ns", &pb.MetricList{})
+	assert.NoError(t, err)
+	// database not exist
+	databaseSM.EXPECT().GetDatabaseCfg("db").Return(models.Database{}, false)
+	_, _, err = resolver.resolve("db", "ns", &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu"}}})
+	assert.Error(t, err)
+	// auto create namespace
+	databaseSM.EXPECT().GetDatabaseCfg("db").
+		Return(models.Database{Option: option.DatabaseOption{AutoCreateNS: true}}, true)
+	metricList := &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu"}, {Name: "cpu", Namespace: "ns2"}}}
+	rejected, _, err = resolver.resolve("db", "ns", metricList)
+	assert.NoError(t, err)
+	assert.Zero(t, rejected)
+	assert.Equal(t, "ns", metricList.Metrics[0].Namespace)
+	assert.Equal(t, "ns2", metricList.Metrics[1].Namespace)
+	// no pre-defined namespaces, all namespaces allowed
+	databaseSM.EXPECT().GetDatabaseCfg("db").Return(models.Database{}, true)
+	metricList = &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu"}, {Name: "cpu", Namespace: "ns2"}}}
+	rejected, _, err = resolver.resolve("db", "ns", metricList)
+	assert.NoError(t, err)
+	assert.Zero(t, rejected)
+	assert.Len(t, metricList.Metrics, 2)
+	// namespace not allowed, only rejects the offending metrics
+	databaseSM.EXPECT().GetDatabaseCfg("db").
+		Return(models.Database{Option: option.DatabaseOption{Namespaces: []string{"ns"}}}, true).AnyTimes()
+	metricList = &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu"}, {Name: "mem", Namespace: "ns2"}}}
+	rejected, errs, err = resolver.resolve("db", "ns", metricList)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rejected)
+	assert.Len(t, errs, 1)
+	assert.Len(t, metricList.Metrics, 1)
+	assert.Equal(t, "cpu", metricList.Metrics[0].Name)
+	metricList = &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu"}, {Name: "cpu", Namespace: constants.DefaultNamespace}}}
+	rejected, _, err = resolver.resolve("db", "ns", metricList)
+	assert.NoError(t, err)
+	assert.Zero(t, rejected)
+	assert.Len(t, metricList.Metrics, 2)
+}
+
+func TestAllowNamespace(t *testing.T) {
+	assert.True(t, allowNamespace(option.DatabaseOption{AutoCreateNS: true}, "ns"))
+	assert.True(t, allowNamespace(option.DatabaseOption{}, constants.DefaultNamespace))
+	assert.True(t, allowNamespace(option.DatabaseOption{}, "ns"))
+	assert.False(t, allowNamespace(option.DatabaseOption{Namespaces: []string{"ns1"}}, "ns"))
+	assert.True(t, allowNamespace(option.DatabaseOption{Namespaces: []string{"ns1", "ns"}}, "ns"))
+}
+
+func TestMetricWrite_Write_namespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	databaseSM.EXPECT().GetDatabaseCfg("dal").
+		Return(models.Database{Option: option.DatabaseOption{Namespaces: []string{"ns1"}}}, true).AnyTimes()
+	api := NewMetricWrite(cm, databaseSM)
+	// namespace not allowed, metric rejected, no metric accepted
+	rr := doWriteRequest(api.Write, "/metric/write?db=dal&ns=ns", []byte("cpu usage_SUM=1 1577000000000"), nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "no metric accepted")
+	// default namespace
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, db string, metricList *pb.MetricList, _ option.WriteConsistency) error {
+			assert.Equal(t, constants.DefaultNamespace, metricList.Metrics[0].Namespace)
+			return nil
+		})
+	rr = doWriteRequest(api.Write, "/metric/write?db=dal", []byte("cpu usage_SUM=1 1577000000000"), nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestPrometheusWrite_Write_namespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewPrometheusWrite(cm, newMockDatabaseSM(ctrl))
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, db string, metricList *pb.MetricList, _ option.WriteConsistency) error {
+			for _, m := range metricList.Metrics {
+				assert.Equal(t, "ns", m.Namespace)
+			}
+			return nil
+		})
+	req := httptest.NewRequest(http.MethodPut, "/metric/prometheus?db=dal&ns=ns",
+		strings.NewReader("# TYPE cpu gauge\ncpu{host=\"a\"} 1\n"))
+	rr := httptest.NewRecorder()
+	api.Write(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// namespace not allowed, metrics dropped
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	databaseSM.EXPECT().GetDatabaseCfg("dal").
+		Return(models.Database{Option: option.DatabaseOption{Namespaces: []string{"ns1"}}}, true).AnyTimes()
+	api = NewPrometheusWrite(cm, databaseSM)
+	req = httptest.NewRequest(http.MethodPut, "/metric/prometheus?db=dal&ns=ns",
+		strings.NewReader("# TYPE cpu gauge\ncpu{host=\"a\"} 1\n"))
+	rr = httptest.NewRecorder()
+	api.Write(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	// database not found
+	databaseSM = database.NewMockDBStateMachine(ctrl)
+	databaseSM.EXPECT().GetDatabaseCfg("dal").Return(models.Database{}, false).AnyTimes()
+	api = NewPrometheusWrite(cm, databaseSM)
+	req = httptest.NewRequest(http.MethodPut, "/metric/prometheus?db=dal&ns=ns",
+		strings.NewReader("# TYPE cpu gauge\ncpu{host=\"a\"} 1\n"))
+	rr = httptest.NewRecorder()
+	api.Write(rr, req)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
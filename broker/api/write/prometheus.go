@@ -22,25 +22,32 @@ import (
 	"net/http"
 
 	"github.com/lindb/lindb/broker/api"
-	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/protocol"
 	"github.com/lindb/lindb/replication"
 )
 
 // for testing
 var (
-	readAllFunc = ioutil.ReadAll
+	readAllFunc          = ioutil.ReadAll
+	promRemoteWriteParse = protocol.PromRemoteWriteParse
 )
 
 // PrometheusWrite represents support prometheus text protocol
 type PrometheusWrite struct {
-	cm replication.ChannelManager
+	cm                  replication.ChannelManager
+	namespaceResolver   *namespaceResolver
+	consistencyResolver *consistencyResolver
+	timeWindowFilter    *timeWindowFilter
 }
 
 // NewPrometheusWrite creates prometheus write
-func NewPrometheusWrite(cm replication.ChannelManager) *PrometheusWrite {
+func NewPrometheusWrite(cm replication.ChannelManager, databaseStateMachine database.DBStateMachine) *PrometheusWrite {
 	return &PrometheusWrite{
-		cm: cm,
+		cm:                  cm,
+		namespaceResolver:   &namespaceResolver{databaseStateMachine: databaseStateMachine},
+		consistencyResolver: &consistencyResolver{databaseStateMachine: databaseStateMachine},
+		timeWindowFilter:    &timeWindowFilter{databaseStateMachine: databaseStateMachine},
 	}
 }
 
@@ -51,22 +58,83 @@ func (m *PrometheusWrite) Write(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
-	_, _ = api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
-	s, err := readAllFunc(r.Body)
+	namespace, err := getNamespace(r)
 	if err != nil {
 		api.Error(w, err)
 		return
 	}
+	consistency, err := m.consistencyResolver.resolve(databaseName, r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	s, err := readBody(w, r)
+	if err != nil {
+		api.BadRequest(w, err)
+		return
+	}
 
 	metricList, err := protocol.PromParse(s)
 	if err != nil {
 		api.Error(w, err)
 		return
 	}
-
-	if err := m.cm.Write(databaseName, metricList); err != nil {
+	// prometheus protocol cannot report the rejected metrics, only drops the metrics of not allowed namespace
+	// and records the metric of out-of-window writes
+	if _, _, err := m.namespaceResolver.resolve(databaseName, namespace, metricList); err != nil {
 		api.Error(w, err)
 		return
 	}
+	_, _ = m.timeWindowFilter.filter(databaseName, metricList)
+	if len(metricList.Metrics) > 0 {
+		if err := m.cm.Write(r.Context(), databaseName, metricList, consistency); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
 	api.OK(w, "success")
 }
+
+// RemoteWrite parses snappy-compressed protobuf WriteRequest of prometheus remote write then writes data into wal
+func (m *PrometheusWrite) RemoteWrite(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := getNamespace(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	consistency, err := m.consistencyResolver.resolve(databaseName, r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	// body is compressed by snappy(Content-Encoding: snappy), decompress it when parsing
+	data, err := ReadSnappyBody(w, r)
+	if err != nil {
+		api.BadRequest(w, err)
+		return
+	}
+	metricList, err := promRemoteWriteParse(data)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	// prometheus protocol cannot report the rejected metrics, only drops the metrics of not allowed namespace
+	// and records the metric of out-of-window writes
+	if _, _, err := m.namespaceResolver.resolve(databaseName, namespace, metricList); err != nil {
+		api.Error(w, err)
+		return
+	}
+	_, _ = m.timeWindowFilter.filter(databaseName, metricList)
+	if len(metricList.Metrics) > 0 {
+		if err := m.cm.Write(r.Context(), databaseName, metricList, consistency); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	api.NoContent(w)
+}
@@ -24,11 +24,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/protocol"
 	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
 )
 
 func TestPrometheusWrite_Write(t *testing.T) {
@@ -39,7 +44,7 @@ func TestPrometheusWrite_Write(t *testing.T) {
 	}()
 
 	cm := replication.NewMockChannelManager(ctrl)
-	api := NewPrometheusWrite(cm)
+	api := NewPrometheusWrite(cm, newMockDatabaseSM(ctrl))
 	// case 1: param error
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPut,
@@ -55,7 +60,7 @@ func TestPrometheusWrite_Write(t *testing.T) {
 		Method:         http.MethodPut,
 		URL:            "/metric/prometheus?db=dal",
 		HandlerFunc:    api.Write,
-		ExpectHTTPCode: 500,
+		ExpectHTTPCode: 400,
 	})
 	// case 3: write wal err
 	input := `# HELP go_gc_duration_seconds A summary of the GC invocation durations.
@@ -67,7 +72,7 @@ go_gc_duration_seconds_sum 90
 	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
 		return []byte(input), nil
 	}
-	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(errors.New("err"))
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("err"))
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPut,
 		URL:            "/metric/prometheus?db=dal&cluster=dal&c=1",
@@ -75,7 +80,7 @@ go_gc_duration_seconds_sum 90
 		ExpectHTTPCode: 500,
 	})
 	// case 4: write wal success
-	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	cm.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPut,
 		URL:            "/metric/prometheus?db=dal&cluster=dal&c=1",
@@ -94,3 +99,103 @@ go_gc_duration_seconds_sum 90
 		ExpectHTTPCode: 500,
 	})
 }
+
+func TestPrometheusWrite_RemoteWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+		promRemoteWriteParse = protocol.PromRemoteWriteParse
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewPrometheusWrite(cm, newMockDatabaseSM(ctrl))
+	// case 1: param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/write",
+		HandlerFunc:    api.RemoteWrite,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: read request body err
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/write?db=dal",
+		HandlerFunc:    api.RemoteWrite,
+		ExpectHTTPCode: 400,
+	})
+	readAllFunc = ioutil.ReadAll
+	// case 3: parse err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/write?db=dal",
+		HandlerFunc:    api.RemoteWrite,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: write wal err
+	promRemoteWriteParse = func(data []byte) (*pb.MetricList, error) {
+		return &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu"}}}, nil
+	}
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).Return(errors.New("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/write?db=dal",
+		HandlerFunc:    api.RemoteWrite,
+		ExpectHTTPCode: 500,
+	})
+	// case 5: write wal success
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/write?db=dal",
+		HandlerFunc:    api.RemoteWrite,
+		ExpectHTTPCode: 204,
+	})
+	// case 6: empty metric list
+	promRemoteWriteParse = func(data []byte) (*pb.MetricList, error) {
+		return &pb.MetricList{}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/prometheus/write?db=dal",
+		HandlerFunc:    api.RemoteWrite,
+		ExpectHTTPCode: 204,
+	})
+}
+
+func TestPrometheusWrite_throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewPrometheusWrite(cm, newMockDatabaseSM(ctrl))
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).
+		Return(&replication.OverloadedError{Database: "dal", RetryAfter: time.Second})
+	rr := doWriteRequest(api.Write, "/metric/prometheus?db=dal", []byte("# TYPE cpu gauge\ncpu{host=\"a\"} 1\n"), nil)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+}
+
+func TestPrometheusWrite_RemoteWrite_bodyTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		SetMaxBodySize(0)
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewPrometheusWrite(cm, newMockDatabaseSM(ctrl))
+	SetMaxBodySize(64)
+	// compressed body too large
+	rr := doWriteRequest(api.RemoteWrite, "/prometheus/write?db=dal", make([]byte, 128), nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	// decompressed body too large, though compressed body is small
+	data := snappy.Encode(nil, make([]byte, 1024))
+	assert.True(t, len(data) <= 64)
+	rr = doWriteRequest(api.RemoteWrite, "/prometheus/write?db=dal", data, nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errBodyTooLarge.Error())
+}
@@ -0,0 +1,84 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+var (
+	outOfWindowCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "broker_write_out_of_window",
+			Help: "The number of dropped/rejected writes which timestamp is out of time window(behind/ahead).",
+		},
+		[]string{"db", "metric", "reason"},
+	)
+)
+
+func init() {
+	monitoring.BrokerRegistry.MustRegister(outOfWindowCounter)
+}
+
+// timeWindowFilter filters the metrics which timestamp is out of time window(behind/ahead) based on database option,
+// the late data is kept if the out of window policy is backfill.
+type timeWindowFilter struct {
+	databaseStateMachine database.DBStateMachine
+}
+
+// filter removes the out-of-window metrics from metric list, returns the number of removed metrics,
+// and the errors of removed metrics if the out of window policy is reject.
+func (f *timeWindowFilter) filter(databaseName string, metricList *pb.MetricList) (rejected int, errs []string) {
+	if metricList == nil || len(metricList.Metrics) == 0 {
+		return
+	}
+	databaseCfg, ok := f.databaseStateMachine.GetDatabaseCfg(databaseName)
+	if !ok {
+		return
+	}
+	databaseOption := databaseCfg.Option
+	behind, ahead := databaseOption.TimeWindow()
+	if behind <= 0 && ahead <= 0 {
+		return
+	}
+	now := timeutil.Now()
+	metrics := metricList.Metrics[:0]
+	for _, metric := range metricList.Metrics {
+		reason := option.CheckTimeWindow(metric.Timestamp, now, behind, ahead)
+		if reason == "" || databaseOption.IsBackfill(reason) {
+			metrics = append(metrics, metric)
+			continue
+		}
+		outOfWindowCounter.WithLabelValues(databaseName, metric.Name, reason).Inc()
+		rejected++
+		if databaseOption.OutOfWindow == option.OutOfWindowReject {
+			errs = append(errs, fmt.Sprintf("metric [%s] timestamp [%d] out of time window(%s)",
+				metric.Name, metric.Timestamp, reason))
+		}
+	}
+	metricList.Metrics = metrics
+	return
+}
@@ -0,0 +1,114 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package write
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func newTestMetricList(now int64) *pb.MetricList {
+	return &pb.MetricList{Metrics: []*pb.Metric{
+		{Name: "cpu", Timestamp: now},
+		{Name: "cpu", Timestamp: now - 2*timeutil.OneMinute},
+		{Name: "cpu", Timestamp: now + 2*timeutil.OneMinute},
+	}}
+}
+
+func TestTimeWindowFilter_filter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	f := &timeWindowFilter{databaseStateMachine: databaseSM}
+	now := timeutil.Now()
+	// case 1: empty metric list
+	rejected, errs := f.filter("db", nil)
+	assert.Zero(t, rejected)
+	assert.Empty(t, errs)
+	// case 2: database not found
+	databaseSM.EXPECT().GetDatabaseCfg("db").Return(models.Database{}, false)
+	rejected, _ = f.filter("db", newTestMetricList(now))
+	assert.Zero(t, rejected)
+	// case 3: no time window
+	databaseSM.EXPECT().GetDatabaseCfg("db").Return(models.Database{}, true)
+	metricList := newTestMetricList(now)
+	rejected, _ = f.filter("db", metricList)
+	assert.Zero(t, rejected)
+	assert.Len(t, metricList.Metrics, 3)
+	// case 4: drop
+	databaseSM.EXPECT().GetDatabaseCfg("db").
+		Return(models.Database{Option: option.DatabaseOption{Behind: "1m", Ahead: "1m"}}, true)
+	metricList = newTestMetricList(now)
+	rejected, errs = f.filter("db", metricList)
+	assert.Equal(t, 2, rejected)
+	assert.Empty(t, errs)
+	assert.Len(t, metricList.Metrics, 1)
+	// case 5: reject
+	databaseSM.EXPECT().GetDatabaseCfg("db").
+		Return(models.Database{Option: option.DatabaseOption{Behind: "1m", Ahead: "1m",
+			OutOfWindow: option.OutOfWindowReject}}, true)
+	metricList = newTestMetricList(now)
+	rejected, errs = f.filter("db", metricList)
+	assert.Equal(t, 2, rejected)
+	assert.Len(t, errs, 2)
+	assert.Len(t, metricList.Metrics, 1)
+	// case 6: backfill
+	databaseSM.EXPECT().GetDatabaseCfg("db").
+		Return(models.Database{Option: option.DatabaseOption{Behind: "1m", Ahead: "1m",
+			OutOfWindow: option.OutOfWindowBackfill}}, true)
+	metricList = newTestMetricList(now)
+	rejected, errs = f.filter("db", metricList)
+	assert.Equal(t, 1, rejected)
+	assert.Empty(t, errs)
+	assert.Len(t, metricList.Metrics, 2)
+}
+
+func TestMetricWrite_Write_OutOfWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	databaseSM := database.NewMockDBStateMachine(ctrl)
+	databaseSM.EXPECT().GetDatabaseCfg(gomock.Any()).
+		Return(models.Database{Option: option.DatabaseOption{AutoCreateNS: true, Behind: "1m",
+			OutOfWindow: option.OutOfWindowReject}}, true).AnyTimes()
+	api := NewMetricWrite(cm, databaseSM)
+	cm.EXPECT().Write(gomock.Any(), "dal", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, db string, metricList *pb.MetricList, _ option.WriteConsistency) error {
+			assert.Len(t, metricList.Metrics, 1)
+			return nil
+		})
+	rr := doWriteRequest(api.Write, "/metric/write?db=dal",
+		[]byte(fmt.Sprintf("cpu usage_SUM=1 %d\ncpu usage_SUM=1 1577000000000", timeutil.Now())), nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"written":1,"rejected":1,"errors":["metric [cpu] timestamp [1577000000000] `+
+		`out of time window(too_late)"]}`, rr.Body.String())
+}
@@ -18,92 +18,242 @@
 package middleware
 
 import (
+	"context"
 	/* #nosec */
 	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-
-	"github.com/lindb/lindb/config"
+	"strings"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/service"
 )
 
 //go:generate mockgen -source=./authentication.go -destination=./authentication_mock.go -package=middleware
 
+// for testing
+var (
+	randRead = rand.Read
+)
+
+const (
+	// APIKeyHeader represents the request header of api key for ingestion agents
+	APIKeyHeader = "X-Api-Key"
+	// defaultTokenExpire represents the default expire duration of token
+	defaultTokenExpire = 24 * time.Hour
+)
+
+var (
+	errTokenInvalid   = errors.New("authorization token invalid")
+	errTokenNoExpire  = errors.New("authorization token without expire time")
+	errTokenRevoked   = errors.New("authorization token revoked")
+	errNoPermission   = errors.New("permission denied")
+	superUserGrants   = models.Grants{{Database: models.AllDatabases, Permission: models.AdminPermission}}
+	bearerTokenPrefix = "Bearer "
+)
+
 type Authentication interface {
-	// CreateLToken returns the authentication token
+	// CreateToken returns the authentication token which expires after token expire duration
 	CreateToken(user config.User) (string, error)
-	// Validate validates the token
+	// RevokeToken revokes the token, the token cannot be used even if not expired
+	RevokeToken(token string) error
+	// Validate validates the token or api key
 	Validate(next http.Handler) http.Handler
+	// Authorize returns the middleware which validates the token or api key,
+	// then checks if has the permission of request database(db param)
+	Authorize(permission models.Permission) mux.MiddlewareFunc
+	// AuthorizeAllDatabases returns the middleware which validates the token or api key,
+	// then checks if has the permission of all databases, used by global resources
+	AuthorizeAllDatabases(permission models.Permission) mux.MiddlewareFunc
 }
 
-// userAuthentication represents user authentication using jwt
+// grantsKey represents the context key of request grants
+type grantsKey struct{}
+
+// WithGrants returns the context which carries the grants of request user
+func WithGrants(ctx context.Context, grants models.Grants) context.Context {
+	return context.WithValue(ctx, grantsKey{}, grants)
+}
+
+// GetGrants returns the grants of request user which is stored into context by authorize middleware
+func GetGrants(ctx context.Context) models.Grants {
+	grants, _ := ctx.Value(grantsKey{}).(models.Grants)
+	return grants
+}
+
+// userAuthentication represents user authentication using jwt,
+// the admin user of config is super user, other users/roles/api keys are stored in state's repo
 type userAuthentication struct {
-	user config.User
+	user        config.User
+	userService service.UserService
+	tokenExpire time.Duration
 }
 
 // CustomClaims represents jwt custom claims param
-// need username and password and some standard claims
+// need username and some standard claims(token id/issued at/expires at)
 type CustomClaims struct {
 	jwt.StandardClaims
 	UserName string `json:"username"`
-	Password string `json:"password"`
 }
 
-// Valid rewrites jwt.Claims valid method return nil
-func (*CustomClaims) Valid() error {
-	return nil
+// Valid validates the standard claims, the token must have expire time
+func (c *CustomClaims) Valid() error {
+	if c.ExpiresAt == 0 {
+		return errTokenNoExpire
+	}
+	return c.StandardClaims.Valid()
 }
 
 // NewAuthentication creates authentication api instance
-func NewAuthentication(user config.User) Authentication {
+func NewAuthentication(user config.User, userService service.UserService) Authentication {
+	tokenExpire := user.TokenExpire.Duration()
+	if tokenExpire <= 0 {
+		tokenExpire = defaultTokenExpire
+	}
 	return &userAuthentication{
-		user: user,
+		user:        user,
+		userService: userService,
+		tokenExpire: tokenExpire,
 	}
 }
 
-// Validate creates middleware for user permissions validation by request header Authorization
+// Validate creates middleware for user validation by request header Authorization or api key,
 // if not authorization throw error
 // else perform the next action
 func (u *userAuthentication) Validate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := r.Header.Get("Authorization")
-		if len(token) > 0 {
-			claims := parseToken(token, u.user)
-			if claims.UserName == u.user.UserName && claims.Password == u.user.Password {
-				next.ServeHTTP(w, r)
+		if _, err := u.authenticate(r); err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Authorize returns the middleware which validates the token or api key,
+// then checks if has the permission of request database,
+// if request without database, only the grant of all databases is allowed.
+func (u *userAuthentication) Authorize(permission models.Permission) mux.MiddlewareFunc {
+	return u.authorize(func(r *http.Request, grants models.Grants) bool {
+		return grants.Allow(getDatabase(r), permission)
+	})
+}
+
+// AuthorizeAllDatabases returns the middleware which validates the token or api key,
+// then checks if has the permission of all databases, the request database is ignored.
+func (u *userAuthentication) AuthorizeAllDatabases(permission models.Permission) mux.MiddlewareFunc {
+	return u.authorize(func(_ *http.Request, grants models.Grants) bool {
+		return grants.AllowAllDatabases(permission)
+	})
+}
+
+// authorize returns the middleware which validates the token or api key, then checks the grants by allow func,
+// the grants are stored into request context for handlers.
+func (u *userAuthentication) authorize(allow func(r *http.Request, grants models.Grants) bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			grants, err := u.authenticate(r)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, err)
 				return
 			}
+			if !allow(r, grants) {
+				writeError(w, http.StatusForbidden, errNoPermission)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithGrants(r.Context(), grants)))
+		})
+	}
+}
+
+// authenticate authenticates the request by api key or token, returns the grants of request user
+func (u *userAuthentication) authenticate(r *http.Request) (models.Grants, error) {
+	if key := r.Header.Get(APIKeyHeader); len(key) > 0 {
+		apiKey, err := u.userService.AuthenticateAPIKey(key)
+		if err != nil {
+			return nil, err
 		}
-		err := errors.New("authorization token invalid")
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(http.StatusUnauthorized)
-		b, _ := json.Marshal(err.Error())
-		_, _ = w.Write(b)
-	})
+		return u.userService.GetGrants(apiKey.Roles)
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), bearerTokenPrefix)
+	if len(token) == 0 {
+		return nil, errTokenInvalid
+	}
+	claims, err := parseToken(token, u.user)
+	if err != nil {
+		return nil, errTokenInvalid
+	}
+	revoked, err := u.userService.IsTokenRevoked(claims.Id)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errTokenRevoked
+	}
+	if claims.UserName == u.user.UserName {
+		return superUserGrants, nil
+	}
+	// user maybe deleted after token created
+	user, err := u.userService.GetUser(claims.UserName)
+	if err != nil {
+		return nil, errTokenInvalid
+	}
+	return u.userService.GetGrants(user.Roles)
 }
 
-// ParseToken returns jwt claims by token
-// get secret key use Md5Encrypt method with username and password
-// then jwt parse token by secret key
-func parseToken(tokenString string, user config.User) *CustomClaims {
-	claims := CustomClaims{}
+// RevokeToken revokes the token before token expired
+func (u *userAuthentication) RevokeToken(token string) error {
+	claims, err := parseToken(strings.TrimPrefix(token, bearerTokenPrefix), u.user)
+	if err != nil {
+		return errTokenInvalid
+	}
+	return u.userService.RevokeToken(claims.Id, claims.ExpiresAt)
+}
+
+// parseToken returns jwt claims by token
+// get secret key use Md5Encrypt method with username and password of admin user
+// then jwt parse token by secret key, and validates the claims
+func parseToken(tokenString string, user config.User) (*CustomClaims, error) {
+	claims := &CustomClaims{}
 	cid := Md5Encrypt(user)
-	_, _ = jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
-		return cid, nil
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(cid), nil
 	})
-	return &claims
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
 }
 
-// CreateLToken returns token use jwt with custom claims
+// CreateToken returns token use jwt with custom claims, the token doesn't include password
 func (u *userAuthentication) CreateToken(user config.User) (string, error) {
+	tokenID := make([]byte, 16)
+	if _, err := randRead(tokenID); err != nil {
+		return "", err
+	}
+	now := timeutil.Now() / 1000
 	claims := CustomClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        hex.EncodeToString(tokenID),
+			IssuedAt:  now,
+			ExpiresAt: now + int64(u.tokenExpire/time.Second),
+		},
 		UserName: user.UserName,
-		Password: user.Password,
 	}
-	cid := Md5Encrypt(user)
+	cid := Md5Encrypt(u.user)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
 	return token.SignedString([]byte(cid))
 }
@@ -117,3 +267,23 @@ func Md5Encrypt(user config.User) string {
 	cipher := md5Encrypt.Sum(nil)
 	return string(cipher)
 }
+
+// getDatabase returns the database param of request, same as api.GetParamsFromRequest
+func getDatabase(r *http.Request) string {
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err == nil {
+			if db := r.PostForm.Get("db"); len(db) > 0 {
+				return db
+			}
+		}
+	}
+	return r.URL.Query().Get("db")
+}
+
+// writeError writes the error message with http status code
+func writeError(w http.ResponseWriter, httpCode int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpCode)
+	b, _ := json.Marshal(err.Error())
+	_, _ = w.Write(b)
+}
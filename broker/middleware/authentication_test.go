@@ -18,63 +18,256 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/lindb/lindb/config"
-
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/service"
 )
 
-var tokenStr = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ1c2VybmFtZSI6ImFkbWluIiwicGF" +
-	"zc3dvcmQiOiJhZG1pbjEyMyJ9.YbNGN0V-U5Y3xOIGNXcgbQkK2VV30UDDEZV19FN62hk"
+var admin = config.User{UserName: "admin", Password: "admin123"}
 
-func Test_ParseToken(t *testing.T) {
-	user := config.User{UserName: "admin", Password: "admin123"}
-	claim := parseToken(tokenStr, user)
-	assert.Equal(t, user.UserName, claim.UserName)
-	assert.Equal(t, user.Password, claim.Password)
+func newTestHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "ok")
+	})
 }
 
 func Test_CreateToken(t *testing.T) {
-	user := config.User{UserName: "admin", Password: "admin123"}
-	u := NewAuthentication(user)
-	token, err := u.CreateToken(user)
-	assert.Equal(t, true, err == nil)
-	assert.Equal(t, tokenStr, token)
+	ctrl := gomock.NewController(t)
+	defer func() {
+		randRead = rand.Read
+		ctrl.Finish()
+	}()
+
+	u := NewAuthentication(admin, service.NewMockUserService(ctrl))
+	token, err := u.CreateToken(config.User{UserName: "test", Password: "pwd"})
+	assert.NoError(t, err)
+	claims, err := parseToken(token, admin)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", claims.UserName)
+	assert.NotEmpty(t, claims.Id)
+	assert.True(t, claims.ExpiresAt > time.Now().Unix())
+	// token doesn't include password
+	assert.False(t, strings.Contains(token, "cHdk"))
+	// signed by other key
+	_, err = parseToken(token, config.User{UserName: "admin", Password: "other"})
+	assert.Error(t, err)
+
+	randRead = func(b []byte) (n int, err error) {
+		return 0, fmt.Errorf("err")
+	}
+	_, err = u.CreateToken(admin)
+	assert.Error(t, err)
+}
+
+func Test_TokenExpire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u := NewAuthentication(config.User{
+		UserName:    "admin",
+		Password:    "admin123",
+		TokenExpire: ltoml.Duration(time.Hour),
+	}, service.NewMockUserService(ctrl))
+	token, err := u.CreateToken(admin)
+	assert.NoError(t, err)
+	claims, err := parseToken(token, admin)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3600), claims.ExpiresAt-claims.IssuedAt)
+}
+
+func TestCustomClaims_Valid(t *testing.T) {
+	claims := &CustomClaims{UserName: "admin"}
+	assert.Equal(t, errTokenNoExpire, claims.Valid())
+	claims.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	assert.Error(t, claims.Valid())
+	claims.ExpiresAt = time.Now().Add(time.Minute).Unix()
+	assert.NoError(t, claims.Valid())
+
+	// token without expire time
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &CustomClaims{UserName: "admin"}).
+		SignedString([]byte(Md5Encrypt(admin)))
+	assert.NoError(t, err)
+	_, err = parseToken(token, admin)
+	assert.Error(t, err)
 }
 
 func TestUserAuthentication_Validate(t *testing.T) {
-	req, err := http.NewRequest("GET", "/health-check", nil)
-	if err != nil {
-		t.Fatal(err)
-	}
-	req.Header.Set("Authorization", "Bearer abc123")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
+	userService := service.NewMockUserService(ctrl)
+	auth := NewAuthentication(admin, userService)
+	authHandler := auth.Validate(newTestHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/check/1", nil)
 	rr := httptest.NewRecorder()
-	user := config.User{UserName: "admin", Password: "admin123"}
-	auth := NewAuthentication(user)
+	authHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = io.WriteString(w, "ok")
-	})
-	authHandler := auth.Validate(handler)
+	req.Header.Set("Authorization", "Bearer abc123")
+	rr = httptest.NewRecorder()
+	authHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 
+	token, _ := auth.CreateToken(admin)
+	req.Header.Set("Authorization", token)
+	userService.EXPECT().IsTokenRevoked(gomock.Any()).Return(false, nil)
+	rr = httptest.NewRecorder()
 	authHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "ok", rr.Body.String())
 
-	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	req.Header.Set("Authorization", "Bearer "+token)
+	userService.EXPECT().IsTokenRevoked(gomock.Any()).Return(false, nil)
+	rr = httptest.NewRecorder()
+	authHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
 
-	req, err = http.NewRequest("GET", "/health-check", nil)
-	assert.NoError(t, err)
-	req.Header.Set("Authorization", tokenStr)
+	userService.EXPECT().IsTokenRevoked(gomock.Any()).Return(true, nil)
 	rr = httptest.NewRecorder()
+	authHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 
+	userService.EXPECT().IsTokenRevoked(gomock.Any()).Return(false, fmt.Errorf("err"))
+	rr = httptest.NewRecorder()
 	authHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestUserAuthentication_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
+	userService := service.NewMockUserService(ctrl)
+	auth := NewAuthentication(admin, userService)
+	readHandler := auth.Authorize(models.ReadPermission)(newTestHandler())
+	writeHandler := auth.Authorize(models.WritePermission)(newTestHandler())
+	userService.EXPECT().IsTokenRevoked(gomock.Any()).Return(false, nil).AnyTimes()
+
+	// super user
+	token, _ := auth.CreateToken(admin)
+	req := httptest.NewRequest(http.MethodGet, "/query/metric?db=db1", nil)
+	req.Header.Set("Authorization", token)
+	rr := httptest.NewRecorder()
+	writeHandler.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusOK, rr.Code)
-	assert.Equal(t, "ok", rr.Body.String())
+
+	// user with grants
+	token, _ = auth.CreateToken(config.User{UserName: "test"})
+	user := &models.User{UserName: "test", Roles: []string{"reader"}}
+	grants := models.Grants{{Database: "db1", Permission: models.ReadPermission}}
+	userService.EXPECT().GetUser("test").Return(user, nil).Times(2)
+	userService.EXPECT().GetGrants([]string{"reader"}).Return(grants, nil).Times(2)
+	req.Header.Set("Authorization", token)
+	rr = httptest.NewRecorder()
+	readHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = httptest.NewRecorder()
+	writeHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// user deleted
+	userService.EXPECT().GetUser("test").Return(nil, fmt.Errorf("err"))
+	rr = httptest.NewRecorder()
+	readHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// api key with write grant, database from post form
+	form := url.Values{}
+	form.Set("db", "db2")
+	req = httptest.NewRequest(http.MethodPost, "/metric/influx", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(APIKeyHeader, "agent.secret")
+	userService.EXPECT().AuthenticateAPIKey("agent.secret").
+		Return(&models.APIKey{Name: "agent", Roles: []string{"writer"}}, nil).Times(2)
+	userService.EXPECT().GetGrants([]string{"writer"}).
+		Return(models.Grants{{Database: "db2", Permission: models.WritePermission}}, nil).Times(2)
+	rr = httptest.NewRecorder()
+	writeHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	req = httptest.NewRequest(http.MethodPost, "/metric/influx?db=db3", nil)
+	req.Header.Set(APIKeyHeader, "agent.secret")
+	rr = httptest.NewRecorder()
+	writeHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// invalid api key
+	userService.EXPECT().AuthenticateAPIKey("agent.secret").Return(nil, fmt.Errorf("err"))
+	rr = httptest.NewRecorder()
+	writeHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestUserAuthentication_Authorize_databaseAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	auth := NewAuthentication(admin, userService)
+	databaseHandler := auth.Authorize(models.AdminPermission)(newTestHandler())
+	globalHandler := auth.AuthorizeAllDatabases(models.AdminPermission)(newTestHandler())
+	userService.EXPECT().AuthenticateAPIKey("admin.secret").
+		Return(&models.APIKey{Name: "admin", Roles: []string{"db1-admin"}}, nil).AnyTimes()
+	userService.EXPECT().GetGrants([]string{"db1-admin"}).
+		Return(models.Grants{{Database: "db1", Permission: models.AdminPermission}}, nil).AnyTimes()
+	serve := func(handler http.Handler, target string) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set(APIKeyHeader, "admin.secret")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	// admin of db1
+	assert.Equal(t, http.StatusOK, serve(databaseHandler, "/database/flush?db=db1"))
+	// request without database or other database
+	assert.Equal(t, http.StatusForbidden, serve(databaseHandler, "/database/flush"))
+	assert.Equal(t, http.StatusForbidden, serve(databaseHandler, "/database/flush?db=db2"))
+	assert.Equal(t, http.StatusForbidden, serve(databaseHandler, "/database/flush?db=*"))
+	// global routes ignore database of request
+	for _, target := range []string{"/user", "/role?db=db1", "/apikey?db=db1", "/cluster/master?db=db1",
+		"/query/running?db=db1"} {
+		assert.Equal(t, http.StatusForbidden, serve(globalHandler, target), target)
+	}
+
+	// super user
+	token, _ := auth.CreateToken(admin)
+	userService.EXPECT().IsTokenRevoked(gomock.Any()).Return(false, nil)
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", token)
+	rr := httptest.NewRecorder()
+	auth.AuthorizeAllDatabases(models.AdminPermission)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, GetGrants(r.Context()).AllowAllDatabases(models.AdminPermission))
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestUserAuthentication_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	auth := NewAuthentication(admin, userService)
+	assert.Error(t, auth.RevokeToken("abc"))
+
+	token, _ := auth.CreateToken(admin)
+	claims, _ := parseToken(token, admin)
+	userService.EXPECT().RevokeToken(claims.Id, claims.ExpiresAt).Return(nil)
+	assert.NoError(t, auth.RevokeToken("Bearer "+token))
 }
@@ -19,6 +19,7 @@ package broker
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
@@ -33,7 +34,6 @@ import (
 	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/broker/api/admin"
 	masterAPI "github.com/lindb/lindb/broker/api/cluster"
-	writeAPI "github.com/lindb/lindb/broker/api/metric"
 	queryAPI "github.com/lindb/lindb/broker/api/query"
 	stateAPI "github.com/lindb/lindb/broker/api/state"
 	"github.com/lindb/lindb/broker/api/write"
@@ -52,6 +52,7 @@ import (
 	"github.com/lindb/lindb/pkg/server"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/pkg/tlsutil"
 	"github.com/lindb/lindb/query"
 	"github.com/lindb/lindb/replication"
 	"github.com/lindb/lindb/rpc"
@@ -69,6 +70,7 @@ type srv struct {
 	storageStateService   service.StorageStateService
 	shardAssignService    service.ShardAssignService
 	databaseService       service.DatabaseService
+	userService           service.UserService
 	replicatorStateReport replication.ReplicatorStateReport
 	channelManager        replication.ChannelManager
 	taskManager           parallel.TaskManager
@@ -83,17 +85,24 @@ type factory struct {
 
 // apiHandler represents all api handlers for broker
 type apiHandler struct {
-	storageClusterAPI  *admin.StorageClusterAPI
-	databaseAPI        *admin.DatabaseAPI
-	databaseFlusherAPI *admin.DatabaseFlusherAPI
-	loginAPI           *api.LoginAPI
-	storageStateAPI    *stateAPI.StorageAPI
-	brokerStateAPI     *stateAPI.BrokerAPI
-	masterAPI          *masterAPI.MasterAPI
-	metricAPI          *queryAPI.MetricAPI
-	metadataAPI        *queryAPI.MetadataAPI
-	writeAPI           *writeAPI.WriteAPI
-	prometheusWriter   *write.PrometheusWrite
+	storageClusterAPI   *admin.StorageClusterAPI
+	databaseAPI         *admin.DatabaseAPI
+	databaseFlusherAPI  *admin.DatabaseFlusherAPI
+	dataDeleterAPI      *admin.DataDeleterAPI
+	databaseRepairerAPI *admin.DatabaseRepairerAPI
+	databaseBackupAPI   *admin.DatabaseBackupAPI
+	userAPI             *admin.UserAPI
+	loginAPI            *api.LoginAPI
+	storageStateAPI     *stateAPI.StorageAPI
+	brokerStateAPI      *stateAPI.BrokerAPI
+	masterAPI           *masterAPI.MasterAPI
+	metricAPI           *queryAPI.MetricAPI
+	metadataAPI         *queryAPI.MetadataAPI
+	runningQueryAPI     *queryAPI.RunningQueryAPI
+	metricWriter        *write.MetricWrite
+	metricBackfill      *write.MetricBackfill
+	prometheusWriter    *write.PrometheusWrite
+	prometheusReader    *queryAPI.PrometheusReadAPI
 }
 
 type rpcHandler struct {
@@ -178,6 +187,24 @@ func (r *runtime) Run() error {
 		return err
 	}
 
+	// set tls config of grpc client connections before creating any connection
+	if err := rpc.SetClientTLS(r.config.BrokerBase.GRPC.TLS); err != nil {
+		r.state = server.Failed
+		return fmt.Errorf("set grpc client tls config error:%s", err)
+	}
+	// set http client which forwards admin request to master node
+	if err := admin.SetForwardClient(r.config.BrokerBase.HTTP); err != nil {
+		r.state = server.Failed
+		return fmt.Errorf("set http forward client error:%s", err)
+	}
+	// set max size of write/prometheus remote read request body
+	write.SetMaxBodySize(r.config.BrokerBase.HTTP.GetMaxBodySize())
+	// set http client which sends metrics to the backfill api of storage nodes
+	if err := write.SetBackfillClient(r.config.BrokerBase.StorageHTTP); err != nil {
+		r.state = server.Failed
+		return fmt.Errorf("set http backfill client error:%s", err)
+	}
+
 	r.factory = factory{
 		taskClient: rpc.NewTaskClientFactory(r.node),
 		taskServer: rpc.NewTaskServerFactory(),
@@ -219,7 +246,10 @@ func (r *runtime) Run() error {
 	r.buildMiddlewareDependency()
 	r.buildAPIDependency()
 	// start tcp server
-	r.startGRPCServer()
+	if err := r.startGRPCServer(); err != nil {
+		r.state = server.Failed
+		return err
+	}
 
 	// register broker node info
 	//TODO TTL default value???
@@ -230,7 +260,10 @@ func (r *runtime) Run() error {
 	r.master.Start()
 
 	// start http server
-	r.startHTTPServer()
+	if err := r.startHTTPServer(); err != nil {
+		r.state = server.Failed
+		return err
+	}
 
 	// start stat monitoring
 	r.monitoring()
@@ -293,9 +326,18 @@ func (r *runtime) Stop() error {
 	return nil
 }
 
-// startHTTPServer starts http server for api rpcHandler
-func (r *runtime) startHTTPServer() {
+// startHTTPServer starts http server for api rpcHandler, serves https if tls enable
+func (r *runtime) startHTTPServer() error {
 	port := r.config.BrokerBase.HTTP.Port
+	tlsCfg := r.config.BrokerBase.HTTP.TLS
+	var tlsConfig *tls.Config
+	if tlsCfg.Enable {
+		reloader, err := tlsutil.NewCertReloader(tlsCfg)
+		if err != nil {
+			return fmt.Errorf("load http server tls certificate error:%s", err)
+		}
+		tlsConfig = reloader.ServerConfig()
+	}
 
 	r.log.Info("starting http server", logger.Uint16("port", port))
 	router := api.NewRouter()
@@ -310,13 +352,22 @@ func (r *runtime) startHTTPServer() {
 		ReadTimeout:  time.Second * 15,
 		IdleTimeout:  time.Second * 60,
 		Handler:      router,
+		TLSConfig:    tlsConfig,
 	}
 	go func() {
-		if err := r.httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		var err error
+		if tlsConfig != nil {
+			// certificates are loaded by tls config
+			err = r.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = r.httpServer.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
 			panic(fmt.Sprintf("start http server with error: %s", err))
 		}
 		r.log.Info("http server stopped successfully")
 	}()
+	return nil
 }
 
 // startStateRepo starts state repository
@@ -350,6 +401,7 @@ func (r *runtime) buildServiceDependency() {
 		databaseService:       service.NewDatabaseService(r.repo),
 		storageStateService:   service.NewStorageStateService(r.repo),
 		shardAssignService:    service.NewShardAssignService(r.repo),
+		userService:           service.NewUserService(r.repo),
 		replicatorStateReport: replicatorStateReport,
 		channelManager:        cm,
 		taskManager:           taskManager,
@@ -360,25 +412,49 @@ func (r *runtime) buildServiceDependency() {
 
 // buildAPIDependency builds broker api dependency
 func (r *runtime) buildAPIDependency() {
+	queryCfg := r.config.BrokerBase.Query
+	executorFactory := query.NewExecutorFactory(queryCfg)
+	queryLimiter := queryAPI.NewQueryLimiter(queryCfg.MaxConcurrentQueries)
 	handlers := apiHandler{
-		storageClusterAPI:  admin.NewStorageClusterAPI(r.srv.storageClusterService),
-		databaseAPI:        admin.NewDatabaseAPI(r.srv.databaseService),
-		databaseFlusherAPI: admin.NewDatabaseFlusherAPI(r.master),
-		loginAPI:           api.NewLoginAPI(r.config.BrokerBase.User, r.middleware.authentication),
-		storageStateAPI:    stateAPI.NewStorageAPI(r.ctx, r.repo, r.stateMachines.StorageSM, r.srv.shardAssignService, r.srv.databaseService),
-		brokerStateAPI:     stateAPI.NewBrokerAPI(r.ctx, r.repo, r.stateMachines.NodeSM),
-		masterAPI:          masterAPI.NewMasterAPI(r.master),
+		storageClusterAPI:   admin.NewStorageClusterAPI(r.srv.storageClusterService),
+		databaseAPI:         admin.NewDatabaseAPI(r.srv.databaseService),
+		databaseFlusherAPI:  admin.NewDatabaseFlusherAPI(r.master),
+		dataDeleterAPI:      admin.NewDataDeleterAPI(r.master, r.srv.databaseService),
+		databaseRepairerAPI: admin.NewDatabaseRepairerAPI(r.master, r.srv.databaseService),
+		databaseBackupAPI:   admin.NewDatabaseBackupAPI(r.master, r.srv.databaseService),
+		userAPI:             admin.NewUserAPI(r.srv.userService),
+		loginAPI:            api.NewLoginAPI(r.config.BrokerBase.User, r.middleware.authentication, r.srv.userService),
+		storageStateAPI:     stateAPI.NewStorageAPI(r.ctx, r.repo, r.stateMachines.StorageSM, r.srv.shardAssignService, r.srv.databaseService),
+		brokerStateAPI:      stateAPI.NewBrokerAPI(r.ctx, r.repo, r.stateMachines.NodeSM),
+		masterAPI:           masterAPI.NewMasterAPI(r.master),
 		metricAPI: queryAPI.NewMetricAPI(r.stateMachines.ReplicaStatusSM,
-			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, query.NewExecutorFactory(), r.srv.jobManager),
+			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, executorFactory, r.srv.jobManager,
+			queryCfg, queryLimiter),
 		metadataAPI: queryAPI.NewMetadataAPI(r.srv.databaseService, r.stateMachines.ReplicaStatusSM,
-			r.stateMachines.NodeSM, query.NewExecutorFactory(), r.srv.jobManager),
-		writeAPI:         writeAPI.NewWriteAPI(r.srv.channelManager),
-		prometheusWriter: write.NewPrometheusWrite(r.srv.channelManager),
+			r.stateMachines.NodeSM, executorFactory, r.srv.jobManager),
+		runningQueryAPI:  queryAPI.NewRunningQueryAPI(r.srv.jobManager),
+		metricWriter:     write.NewMetricWrite(r.srv.channelManager, r.stateMachines.DatabaseSM),
+		metricBackfill:   write.NewMetricBackfill(r.srv.shardAssignService, r.stateMachines.DatabaseSM),
+		prometheusWriter: write.NewPrometheusWrite(r.srv.channelManager, r.stateMachines.DatabaseSM),
+		prometheusReader: queryAPI.NewPrometheusReadAPI(r.stateMachines.ReplicaStatusSM,
+			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, executorFactory, r.srv.jobManager,
+			queryCfg, queryLimiter),
 	}
 
 	api.AddRoute("Login", http.MethodPost, "/login", handlers.loginAPI.Login)
+	api.AddRoute("Logout", http.MethodPost, "/logout", handlers.loginAPI.Logout)
 	api.AddRoute("Check", http.MethodGet, "/check/1", handlers.loginAPI.Check)
 
+	api.AddRoute("SaveUser", http.MethodPost, "/user", handlers.userAPI.SaveUser)
+	api.AddRoute("DeleteUser", http.MethodDelete, "/user", handlers.userAPI.DeleteUser)
+	api.AddRoute("ListUsers", http.MethodGet, "/user/list", handlers.userAPI.ListUsers)
+	api.AddRoute("SaveRole", http.MethodPost, "/role", handlers.userAPI.SaveRole)
+	api.AddRoute("DeleteRole", http.MethodDelete, "/role", handlers.userAPI.DeleteRole)
+	api.AddRoute("ListRoles", http.MethodGet, "/role/list", handlers.userAPI.ListRoles)
+	api.AddRoute("CreateAPIKey", http.MethodPost, "/apikey", handlers.userAPI.CreateAPIKey)
+	api.AddRoute("DeleteAPIKey", http.MethodDelete, "/apikey", handlers.userAPI.DeleteAPIKey)
+	api.AddRoute("ListAPIKeys", http.MethodGet, "/apikey/list", handlers.userAPI.ListAPIKeys)
+
 	api.AddRoute("SaveStorageCluster", http.MethodPost, "/storage/cluster", handlers.storageClusterAPI.Create)
 	api.AddRoute("GetStorageCluster", http.MethodGet, "/storage/cluster", handlers.storageClusterAPI.GetByName)
 	api.AddRoute("DeleteStorageCluster", http.MethodDelete, "/storage/cluster", handlers.storageClusterAPI.DeleteByName)
@@ -388,6 +464,9 @@ func (r *runtime) buildAPIDependency() {
 	api.AddRoute("GetDatabase", http.MethodGet, "/database", handlers.databaseAPI.GetByName)
 	api.AddRoute("ListDatabase", http.MethodGet, "/database/list", handlers.databaseAPI.List)
 	api.AddRoute("FLushDatabase", http.MethodGet, "/database/flush", handlers.databaseFlusherAPI.SubmitFlushTask)
+	api.AddRoute("DeleteData", http.MethodDelete, "/database/data", handlers.dataDeleterAPI.Delete)
+	api.AddRoute("RepairDatabase", http.MethodPut, "/database/repair", handlers.databaseRepairerAPI.SubmitRepairTask)
+	api.AddRoute("BackupDatabase", http.MethodPut, "/database/backup", handlers.databaseBackupAPI.SubmitBackupTask)
 
 	api.AddRoute("ListStorageClusterNodesState", http.MethodGet, "/storage/cluster/state", handlers.storageStateAPI.GetStorageClusterState)
 	api.AddRoute("ListStorageClusterState", http.MethodGet, "/storage/cluster/state/list", handlers.storageStateAPI.ListStorageClusterState)
@@ -397,30 +476,66 @@ func (r *runtime) buildAPIDependency() {
 
 	api.AddRoute("QueryMetric", http.MethodGet, "/query/metric", handlers.metricAPI.Search)
 	api.AddRoute("QueryMetadata", http.MethodGet, "/query/metadata", handlers.metadataAPI.Handle)
+	api.AddRoute("ShowRunningQuery", http.MethodGet, "/query/running", handlers.runningQueryAPI.Show)
+	api.AddRoute("KillRunningQuery", http.MethodDelete, "/query/running", handlers.runningQueryAPI.Kill)
 
-	api.AddRoute("WriteSumMetric", http.MethodPut, "/metric/sum", handlers.writeAPI.Sum)
+	api.AddRoute("MetricWriter", http.MethodPut, "/metric/write", handlers.metricWriter.Write)
+	api.AddRoute("MetricBackfill", http.MethodPut, "/metric/backfill", handlers.metricBackfill.Backfill)
 	api.AddRoute("PrometheusWriter", http.MethodPut, "/metric/prometheus", handlers.prometheusWriter.Write)
+	api.AddRoute("PrometheusRemoteWrite", http.MethodPost, "/prometheus/write", handlers.prometheusWriter.RemoteWrite)
+	api.AddRoute("PrometheusRemoteRead", http.MethodPost, "/prometheus/read", handlers.prometheusReader.Read)
 }
 
+// patterns of admin routes
+const (
+	databaseAdminRoutes     = "^/database/(flush|data|repair|backup)$"
+	allDatabasesAdminRoutes = "^/(query/running$|user|role|apikey|database(/list)?$|storage/|broker/|cluster/)"
+)
+
 // buildMiddlewareDependency builds middleware dependency
 // pattern support regexp matching
 func (r *runtime) buildMiddlewareDependency() {
 	r.middleware = &middlewareHandler{
-		authentication: middleware.NewAuthentication(r.config.BrokerBase.User),
+		authentication: middleware.NewAuthentication(r.config.BrokerBase.User, r.srv.userService),
 	}
 	httpAPI, err := regexp.Compile("/*")
 	if err == nil {
 		api.AddMiddleware(middleware.AccessLogMiddleware, httpAPI)
 	}
-	validate, err := regexp.Compile("/check/*")
+	validate, err := regexp.Compile("^/(check/|logout$)")
 	if err == nil {
 		api.AddMiddleware(r.middleware.authentication.Validate, validate)
 	}
+	// write routes need write permission of request database
+	writeAPI, err := regexp.Compile("^/(metric/|prometheus/write$)")
+	if err == nil {
+		api.AddMiddleware(r.middleware.authentication.Authorize(models.WritePermission), writeAPI)
+	}
+	// query routes need read permission of request database
+	readAPI, err := regexp.Compile("^/(query/(metric|metadata)|prometheus/read)$")
+	if err == nil {
+		api.AddMiddleware(r.middleware.authentication.Authorize(models.ReadPermission), readAPI)
+	}
+	// data management routes of database need admin permission of request database
+	databaseAdminAPI, err := regexp.Compile(databaseAdminRoutes)
+	if err == nil {
+		api.AddMiddleware(r.middleware.authentication.Authorize(models.AdminPermission), databaseAdminAPI)
+	}
+	// running queries of all databases, user management and cluster/database management routes
+	// need admin permission of all databases
+	allDatabasesAdminAPI, err := regexp.Compile(allDatabasesAdminRoutes)
+	if err == nil {
+		api.AddMiddleware(r.middleware.authentication.AuthorizeAllDatabases(models.AdminPermission), allDatabasesAdminAPI)
+	}
 }
 
-// startGRPCServer starts the GRPC server
-func (r *runtime) startGRPCServer() {
-	r.grpcServer = rpc.NewGRPCServer(fmt.Sprintf(":%d", r.config.BrokerBase.GRPC.Port))
+// startGRPCServer starts the GRPC server, enables tls if tls config enable
+func (r *runtime) startGRPCServer() error {
+	opts, err := rpc.NewServerTLSOptions(r.config.BrokerBase.GRPC.TLS)
+	if err != nil {
+		return fmt.Errorf("load grpc server tls certificate error:%s", err)
+	}
+	r.grpcServer = rpc.NewGRPCServer(fmt.Sprintf(":%d", r.config.BrokerBase.GRPC.Port), opts...)
 
 	// bind grpc handlers
 	r.bindGRPCHandlers()
@@ -430,6 +545,7 @@ func (r *runtime) startGRPCServer() {
 			panic(err)
 		}
 	}()
+	return nil
 }
 
 // bindGRPCHandlers binds rpc handlers, registers rpcHandler into grpc server
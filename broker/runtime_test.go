@@ -19,15 +19,21 @@ package broker
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	check "gopkg.in/check.v1"
 
+	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/mock"
 	"github.com/lindb/lindb/pkg/hostutil"
@@ -146,3 +152,68 @@ func (ts *testBrokerRuntimeSuite) TestBroker_Run_Err(c *check.C) {
 	registry.EXPECT().Close().Return(fmt.Errorf("err"))
 	_ = broker.Stop()
 }
+
+func (ts *testBrokerRuntimeSuite) TestBrokerRun_TLS_Err(c *check.C) {
+	tlsCfg := cfg
+	tlsCfg.BrokerBase.Coordinator.Endpoints = ts.Cluster.Endpoints
+	tlsCfg.BrokerBase.GRPC.TLS = config.TLS{Enable: true, CertFile: "not_exist", KeyFile: "not_exist"}
+
+	broker := NewBrokerRuntime("test-version", tlsCfg)
+	err := broker.Run()
+	c.Assert(err, check.NotNil)
+	c.Assert(server.Failed, check.Equals, broker.State())
+	_ = broker.Stop()
+}
+
+func TestBrokerRuntime_routes_unauthorized(t *testing.T) {
+	r := NewBrokerRuntime("test-version", cfg).(*runtime)
+	r.stateMachines = &coordinator.BrokerStateMachines{}
+	r.buildMiddlewareDependency()
+	r.buildAPIDependency()
+
+	router := api.NewRouter()
+	count := 0
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil || path == "/login" {
+			return nil
+		}
+		for _, method := range methods {
+			if method == http.MethodOptions {
+				continue
+			}
+			count++
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+			assert.Equal(t, http.StatusUnauthorized, rr.Code, "%s %s", method, path)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, count > 0)
+}
+
+func TestBrokerRuntime_adminRoutes(t *testing.T) {
+	databaseAdminAPI := regexp.MustCompile(databaseAdminRoutes)
+	allDatabasesAdminAPI := regexp.MustCompile(allDatabasesAdminRoutes)
+	// global routes need admin permission of all databases
+	for _, path := range []string{"/user", "/user/list", "/role", "/role/list", "/apikey", "/apikey/list",
+		"/database", "/database/list", "/storage/cluster", "/storage/cluster/state/list",
+		"/broker/cluster/state", "/cluster/master", "/query/running"} {
+		assert.True(t, allDatabasesAdminAPI.MatchString(path), path)
+		assert.False(t, databaseAdminAPI.MatchString(path), path)
+	}
+	// data management routes need admin permission of request database
+	for _, path := range []string{"/database/flush", "/database/data", "/database/repair", "/database/backup"} {
+		assert.True(t, databaseAdminAPI.MatchString(path), path)
+		assert.False(t, allDatabasesAdminAPI.MatchString(path), path)
+	}
+	for _, path := range []string{"/query/metric", "/metric/write", "/login", "/check/1"} {
+		assert.False(t, databaseAdminAPI.MatchString(path), path)
+		assert.False(t, allDatabasesAdminAPI.MatchString(path), path)
+	}
+}
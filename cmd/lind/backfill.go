@@ -0,0 +1,144 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lind
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/broker/middleware"
+)
+
+var (
+	backfillURL    string
+	backfillAPIKey string
+	backfillDB     string
+	backfillNS     string
+	backfillFile   string
+	backfillBatch  int
+)
+
+// backfillCmd writes the historical data of line protocol file by the backfill api of broker,
+// broker routes the metrics by the shard assignment of database and writes them into all replicas of shards,
+// bypasses the time window(behind/ahead) of database.
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Backfill historical data of line protocol file into all replicas of database by broker",
+	RunE:  backfill,
+}
+
+func init() {
+	backfillCmd.Flags().StringVar(&backfillURL, "url", "", "http address of broker, e.g. http://127.0.0.1:9000")
+	backfillCmd.Flags().StringVar(&backfillAPIKey, "api-key", "", "api key which has write permission of database")
+	backfillCmd.Flags().StringVar(&backfillDB, "db", "", "database name")
+	backfillCmd.Flags().StringVar(&backfillNS, "ns", "", "namespace of metrics without namespace")
+	backfillCmd.Flags().StringVar(&backfillFile, "file", "", "line protocol file path")
+	backfillCmd.Flags().IntVar(&backfillBatch, "batch", 10000, "number of lines for each backfill request")
+}
+
+func backfill(cmd *cobra.Command, args []string) error {
+	if len(backfillURL) == 0 || len(backfillDB) == 0 || len(backfillFile) == 0 {
+		return fmt.Errorf("url, db and file are required")
+	}
+	if backfillBatch <= 0 {
+		return fmt.Errorf("batch must be positive")
+	}
+	f, err := os.Open(backfillFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	params := url.Values{}
+	params.Set("db", backfillDB)
+	if len(backfillNS) > 0 {
+		params.Set("ns", backfillNS)
+	}
+	address := backfillURL + "/metric/backfill?" + params.Encode()
+	client := &http.Client{Timeout: time.Minute}
+
+	var batch bytes.Buffer
+	lines := 0
+	flush := func() error {
+		if lines == 0 {
+			return nil
+		}
+		if err := doBackfill(client, address, batch.Bytes()); err != nil {
+			return err
+		}
+		batch.Reset()
+		lines = 0
+		return nil
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		batch.Write(line)
+		batch.WriteByte('\n')
+		lines++
+		if lines >= backfillBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// doBackfill sends the backfill request to broker, prints the result
+func doBackfill(client *http.Client, address string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, address, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if len(backfillAPIKey) > 0 {
+		req.Header.Set(middleware.APIKeyHeader, backfillAPIKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("backfill %s failure, status: %d, response: %s", address, resp.StatusCode, body)
+	}
+	fmt.Printf("backfill %s, result: %s\n", address, body)
+	return nil
+}
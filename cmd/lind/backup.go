@@ -0,0 +1,153 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lind
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/backup"
+)
+
+var (
+	backupURLs    []string
+	backupDB      string
+	backupTarget  string
+	backupName    string
+	restorePrefix string
+	restoreShards []int
+)
+
+// backupCmd backups the database of storage nodes into backup target by backup api,
+// each storage node backups its shards and metadata under its own prefix.
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup the database of storage nodes into backup target",
+	RunE:  backupDatabase,
+}
+
+// restoreCmd restores the database of storage node from backup target into data dir of storage config,
+// the storage node must be stopped, if the database exists in data dir, only the given shards are restored.
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the database of stopped storage node from backup target",
+	Long: `Restore the database of stopped storage node from backup target.
+If the database not exists in data dir, restores the whole database(metadata and shards).
+If the database exists, --shard is required, the given shards are replaced by backup and the metadata
+of existing database is kept, so the backup must be taken from this database of the same storage node,
+because the metric/tag ids are node-local.
+The storage node must be stopped during restoring, because the shard files are replaced.`,
+	RunE: restoreDatabase,
+}
+
+func init() {
+	backupCmd.Flags().StringSliceVar(&backupURLs, "url", nil,
+		"http addresses of storage nodes, e.g. http://127.0.0.1:2892")
+	backupCmd.Flags().StringVar(&backupDB, "db", "", "database name")
+	backupCmd.Flags().StringVar(&backupTarget, "target", "",
+		"backup target, local directory(/data/backup or file:///data/backup) or registered object store(scheme://bucket/path)")
+	backupCmd.Flags().StringVar(&backupName, "name", "", "backup name, default is current time")
+
+	restoreCmd.Flags().StringVar(&cfg, "config", "",
+		fmt.Sprintf("storage config file path, default is %s", defaultStorageCfgFile))
+	restoreCmd.Flags().StringVar(&backupDB, "db", "", "database name")
+	restoreCmd.Flags().StringVar(&backupTarget, "target", "", "backup target")
+	restoreCmd.Flags().StringVar(&restorePrefix, "prefix", "",
+		"prefix of backup in target which is returned by backup, e.g. <name>/<node>/<db>")
+	restoreCmd.Flags().IntSliceVar(&restoreShards, "shard", nil, "restores these shards only, default is all shards of backup, required if database exists")
+}
+
+func backupDatabase(cmd *cobra.Command, args []string) error {
+	if len(backupURLs) == 0 || len(backupDB) == 0 || len(backupTarget) == 0 {
+		return fmt.Errorf("url, db and target are required")
+	}
+	name := backupName
+	if len(name) == 0 {
+		name = timeutil.FormatTimestamp(timeutil.Now(), "20060102150405")
+	}
+	params := url.Values{}
+	params.Set("db", backupDB)
+	params.Set("target", backupTarget)
+	params.Set("name", name)
+	// backup uploads all files of database, so waits longer than other requests
+	client := &http.Client{Timeout: time.Hour}
+	for _, address := range backupURLs {
+		if err := doBackup(client, address+"/backup?"+params.Encode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// doBackup sends the backup request to storage node, prints the prefix of backup
+func doBackup(client *http.Client, address string) error {
+	req, err := http.NewRequest(http.MethodPut, address, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("backup %s failure, status: %d, response: %s", address, resp.StatusCode, body)
+	}
+	fmt.Printf("backup %s, prefix: %s\n", address, body)
+	return nil
+}
+
+func restoreDatabase(cmd *cobra.Command, args []string) error {
+	if len(backupDB) == 0 || len(backupTarget) == 0 || len(restorePrefix) == 0 {
+		return fmt.Errorf("db, target and prefix are required")
+	}
+	storageCfg := config.Storage{}
+	if err := ltoml.LoadConfig(cfg, defaultStorageCfgFile, &storageCfg); err != nil {
+		return fmt.Errorf("decode config file error: %s", err)
+	}
+	target, err := backup.NewTarget(backupTarget)
+	if err != nil {
+		return err
+	}
+	var shardIDs []int32
+	for _, shardID := range restoreShards {
+		shardIDs = append(shardIDs, int32(shardID))
+	}
+	databasePath := filepath.Join(storageCfg.StorageBase.TSDB.Dir, backupDB)
+	if err := tsdb.RestoreDatabase(target, restorePrefix, databasePath, shardIDs); err != nil {
+		return err
+	}
+	fmt.Printf("restore database[%s] from %s into %s\n", backupDB, restorePrefix, databasePath)
+	return nil
+}
@@ -54,6 +54,9 @@ func newStorageCmd() *cobra.Command {
 		runStorageCmd,
 		initializeStorageConfigCmd,
 		databaseCmd,
+		backfillCmd,
+		backupCmd,
+		restoreCmd,
 	)
 	return storageCmd
 }
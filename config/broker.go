@@ -27,7 +27,18 @@ import (
 
 // HTTP represents a HTTP level configuration of broker.
 type HTTP struct {
-	Port uint16 `toml:"port"`
+	Port           uint16         `toml:"port"`
+	TLS            TLS            `toml:"tls"`
+	MaxBodySize    int64          `toml:"max-body-size"`   // max size(megabytes) of write request body after decompression
+	ForwardTimeout ltoml.Duration `toml:"forward-timeout"` // timeout of admin requests forwarded to master node
+}
+
+// GetMaxBodySize returns the max size(bytes) of write request body after decompression
+func (h *HTTP) GetMaxBodySize() int64 {
+	if h.MaxBodySize <= 0 {
+		return 32 * 1024 * 1024 // 32MB
+	}
+	return h.MaxBodySize * 1024 * 1024
 }
 
 func (h *HTTP) TOML() string {
@@ -35,24 +46,38 @@ func (h *HTTP) TOML() string {
 	## Controls how HTTP endpoints are configured.
     ##
     ## which port broker's HTTP Server is listening on 
-    port = %d`,
+    port = %d
+
+    ## max size in megabytes of write/prometheus remote read request body after decompression,
+    ## the request is rejected(400 Bad Request) if body is too large
+    max-body-size = %d
+
+    ## timeout of admin requests which are forwarded to master node
+    forward-timeout = "%s"`,
 		h.Port,
+		h.MaxBodySize,
+		h.ForwardTimeout.String(),
 	)
 }
 
 // User represents user model
 type User struct {
-	UserName string `toml:"username" json:"username"`
-	Password string `toml:"password" json:"password"`
+	UserName    string         `toml:"username" json:"username"`
+	Password    string         `toml:"password" json:"password"`
+	TokenExpire ltoml.Duration `toml:"token-expire" json:"-"`
 }
 
 func (u *User) TOML() string {
 	return fmt.Sprintf(`
     ## admin user setting
     username = "%s"
-    password = "%s"`,
+    password = "%s"
+
+    ## expire duration of the login token, the token cannot be used after expired
+    token-expire = "%s"`,
 		u.UserName,
-		u.Password)
+		u.Password,
+		u.TokenExpire.String())
 }
 
 type TCP struct {
@@ -74,6 +99,11 @@ type ReplicationChannel struct {
 	CheckFlushInterval ltoml.Duration `toml:"check-flush-interval"`
 	FlushInterval      ltoml.Duration `toml:"flush-interval"`
 	BufferSize         int            `toml:"buffer-size"`
+	AckTimeout         ltoml.Duration `toml:"ack-timeout"` // max wait time of write acknowledgement(wal/replicated)
+	// backpressure of writes, throttles writes if replication queue of any target shard is overloaded
+	MaxPending    int64          `toml:"max-pending"`     // max pending messages of one replicator, 0 means no limit
+	MaxQueueUsage float64        `toml:"max-queue-usage"` // max usage ratio of queue data size limit, 0 means no limit
+	RetryAfter    ltoml.Duration `toml:"retry-after"`     // retry after of throttled writes
 }
 
 func (rc *ReplicationChannel) GetDataSizeLimit() int64 {
@@ -112,7 +142,19 @@ func (rc *ReplicationChannel) TOML() string {
     flush-interval = "%s"
 
     ## will flush if this size of data in kegabytes get buffered
-    buffer-size = %d`,
+    buffer-size = %d
+
+    ## max wait time of write acknowledgement when write consistency is wal or replicated:N,
+    ## the write fails if the data isn't persisted/acked by replicas in time
+    ack-timeout = "%s"
+
+    ## writes are throttled(429 Too Many Requests) if the replication queue of any target shard is overloaded:
+    ## max-pending is the max number of pending messages of one replica, 0 means no limit
+    max-pending = %d
+    ## max-queue-usage is the max usage ratio(0~1) of queue data size limit, 0 means no limit
+    max-queue-usage = %g
+    ## retry-after is the time which client should wait before retrying the throttled writes
+    retry-after = "%s"`,
 		rc.Dir,
 		rc.DataSizeLimit,
 		rc.RemoveTaskInterval.String(),
@@ -120,6 +162,10 @@ func (rc *ReplicationChannel) TOML() string {
 		rc.CheckFlushInterval.String(),
 		rc.FlushInterval.String(),
 		rc.BufferSize,
+		rc.AckTimeout.String(),
+		rc.MaxPending,
+		rc.MaxQueueUsage,
+		rc.RetryAfter.String(),
 	)
 }
 
@@ -128,6 +174,7 @@ type BrokerBase struct {
 	Coordinator        RepoState          `toml:"coordinator"`
 	Query              Query              `toml:"query"`
 	HTTP               HTTP               `toml:"http"`
+	StorageHTTP        StorageHTTP        `toml:"storage_http"`
 	User               User               `toml:"user"`
 	GRPC               GRPC               `toml:"grpc"`
 	ReplicationChannel ReplicationChannel `toml:"replication_channel"`
@@ -141,17 +188,30 @@ func (bb *BrokerBase) TOML() string {
   [broker.query]%s
 
   [broker.http]%s
+
+  [broker.http.tls]%s
+
+  [broker.storage_http]%s
+
+  ## tls of http requests sent to storage nodes, need to match the http tls of storage
+  [broker.storage_http.tls]%s
 	
   [broker.user]%s
 
   [broker.grpc]%s
 
+  [broker.grpc.tls]%s
+
   [broker.replication_channel]%s`,
 		bb.Coordinator.TOML(),
 		bb.Query.TOML(),
 		bb.HTTP.TOML(),
+		bb.HTTP.TLS.TOML(),
+		bb.StorageHTTP.TOML(),
+		bb.StorageHTTP.TLS.TOML(),
 		bb.User.TOML(),
 		bb.GRPC.TOML(),
+		bb.GRPC.TLS.TOML(),
 		bb.ReplicationChannel.TOML(),
 	)
 }
@@ -159,10 +219,18 @@ func (bb *BrokerBase) TOML() string {
 func NewDefaultBrokerBase() *BrokerBase {
 	return &BrokerBase{
 		HTTP: HTTP{
-			Port: 9000,
+			Port:           9000,
+			TLS:            *NewDefaultTLS(),
+			MaxBodySize:    32,
+			ForwardTimeout: ltoml.Duration(30 * time.Second),
+		},
+		StorageHTTP: StorageHTTP{
+			Timeout: ltoml.Duration(time.Minute),
+			TLS:     *NewDefaultTLS(),
 		},
 		GRPC: GRPC{
 			Port: 9001,
+			TLS:  *NewDefaultTLS(),
 		},
 		Coordinator: RepoState{
 			Namespace:   "/lindb/broker",
@@ -170,8 +238,9 @@ func NewDefaultBrokerBase() *BrokerBase {
 			DialTimeout: ltoml.Duration(time.Second * 5),
 		},
 		User: User{
-			UserName: "admin",
-			Password: "admin123",
+			UserName:    "admin",
+			Password:    "admin123",
+			TokenExpire: ltoml.Duration(24 * time.Hour),
 		},
 		ReplicationChannel: ReplicationChannel{
 			Dir:                filepath.Join(defaultParentDir, "broker/replication"),
@@ -180,6 +249,10 @@ func NewDefaultBrokerBase() *BrokerBase {
 			CheckFlushInterval: ltoml.Duration(time.Second),
 			FlushInterval:      ltoml.Duration(5 * time.Second),
 			BufferSize:         128,
+			AckTimeout:         ltoml.Duration(10 * time.Second),
+			MaxPending:         10000,
+			MaxQueueUsage:      0.9,
+			RetryAfter:         ltoml.Duration(5 * time.Second),
 		},
 		Query: *NewDefaultQuery(),
 	}
@@ -80,3 +80,10 @@ func Test_ReplicationChannel_SegmentFileSizeInBytes(t *testing.T) {
 	rc.DataSizeLimit = 10000
 	assert.Equal(t, int64(1024*1024*1024), rc.GetDataSizeLimit())
 }
+
+func Test_HTTP_MaxBodySize(t *testing.T) {
+	var h HTTP
+	assert.Equal(t, int64(32*1024*1024), h.GetMaxBodySize())
+	h.MaxBodySize = 10
+	assert.Equal(t, int64(10*1024*1024), h.GetMaxBodySize())
+}
@@ -49,10 +49,54 @@ func (rs *RepoState) TOML() string {
 	)
 }
 
+// TLS represents tls config of server and client,
+// if ca file is set, server requires and verifies the client certificate(mutual tls)
+type TLS struct {
+	Enable         bool           `toml:"enable"`
+	CertFile       string         `toml:"cert-file"`
+	KeyFile        string         `toml:"key-file"`
+	CAFile         string         `toml:"ca-file"`
+	ServerName     string         `toml:"server-name"`
+	ReloadInterval ltoml.Duration `toml:"reload-interval"`
+}
+
+// TOML returns TLS's toml config string
+func (t *TLS) TOML() string {
+	return fmt.Sprintf(`
+    ## enable tls
+    enable = %t
+    ## certificate and private key file(PEM encoded)
+    cert-file = "%s"
+    key-file = "%s"
+    ## ca certificate file(PEM encoded), enables mutual tls if set,
+    ## server verifies client certificate and client verifies server certificate by this ca
+    ca-file = "%s"
+    ## server name used by client to verify the server certificate,
+    ## uses the ip of target node if not set
+    server-name = "%s"
+    ## interval of checking certificate files modified, reloads certificates without restart
+    reload-interval = "%s"`,
+		t.Enable,
+		t.CertFile,
+		t.KeyFile,
+		t.CAFile,
+		t.ServerName,
+		t.ReloadInterval.String(),
+	)
+}
+
+// NewDefaultTLS returns a new default tls config(disabled)
+func NewDefaultTLS() *TLS {
+	return &TLS{
+		ReloadInterval: ltoml.Duration(10 * time.Second),
+	}
+}
+
 // GRPC represents grpc server config
 type GRPC struct {
 	Port uint16         `toml:"port"`
 	TTL  ltoml.Duration `toml:"ttl"`
+	TLS  TLS            `toml:"tls"`
 }
 
 func (g *GRPC) TOML() string {
@@ -70,11 +114,18 @@ type StorageCluster struct {
 	Config RepoState `json:"config"`
 }
 
-// Query represents query rpc config
+// Query represents query rpc config and resource limits of query
 type Query struct {
 	MaxWorkers  int            `toml:"max-workers"`
 	IdleTimeout ltoml.Duration `toml:"idle-timeout"`
 	Timeout     ltoml.Duration `toml:"timeout"`
+
+	MaxSeries            int `toml:"max-series"`
+	MaxPoints            int `toml:"max-points"`
+	MaxConcurrentQueries int `toml:"max-concurrent-queries"`
+
+	RetryTimeout ltoml.Duration `toml:"retry-timeout"`
+	HedgeDelay   ltoml.Duration `toml:"hedge-delay"`
 }
 
 func (q *Query) TOML() string {
@@ -87,18 +138,42 @@ func (q *Query) TOML() string {
     ## idle worker will be canceled in this duration
 	idle-timeout = "%s"
 
-    ## maximum timeout threshold for the task performed
-    timeout = "%s"`,
+    ## maximum timeout threshold for the task performed,
+    ## also the default timeout of query in broker, which can be overridden by request param
+    timeout = "%s"
+
+    ## maximum number of series scanned by one query in storage node, 0 means no limit
+    max-series = %d
+    ## maximum number of points returned by one query in broker, 0 means no limit
+    max-points = %d
+    ## maximum number of concurrent queries for each database in broker, 0 means no limit
+    max-concurrent-queries = %d
+
+    ## retries the shards of leaf task on other replica if the leaf task doesn't respond in this duration,
+    ## 0 means never retry slow leaf task(failed leaf task is always retried)
+    retry-timeout = "%s"
+    ## sends hedged request to other replica if the leaf task doesn't respond in this duration,
+    ## the first response wins, 0 means disable hedged request
+    hedge-delay = "%s"`,
 		q.MaxWorkers,
 		q.IdleTimeout,
 		q.Timeout,
+		q.MaxSeries,
+		q.MaxPoints,
+		q.MaxConcurrentQueries,
+		q.RetryTimeout,
+		q.HedgeDelay,
 	)
 }
 
 func NewDefaultQuery() *Query {
 	return &Query{
-		MaxWorkers:  30,
-		IdleTimeout: ltoml.Duration(5 * time.Second),
-		Timeout:     ltoml.Duration(30 * time.Second),
+		MaxWorkers:           30,
+		IdleTimeout:          ltoml.Duration(5 * time.Second),
+		Timeout:              ltoml.Duration(30 * time.Second),
+		MaxSeries:            1000000,
+		MaxPoints:            10000000,
+		MaxConcurrentQueries: 64,
+		RetryTimeout:         ltoml.Duration(10 * time.Second),
 	}
 }
@@ -27,23 +27,53 @@ import (
 
 // TSDB represents the tsdb configuration
 type TSDB struct {
-	Dir string `toml:"dir"`
+	Dir                     string `toml:"dir"`
+	MaxCachedTableFiles     int    `toml:"max-cached-table-files"`
+	MaxCachedTableIndexSize int64  `toml:"max-cached-table-index-size"`
+	BackupDir               string `toml:"backup-dir"`
 }
 
 func (t *TSDB) TOML() string {
 	return fmt.Sprintf(`
     ## where the tsdb data is stored
-    dir = "%s"`,
+    dir = "%s"
+
+    ## max num. of opened sst table readers cached in storage node
+    max-cached-table-files = %d
+
+    ## max memory size(bytes) of index block(keys/offsets) of sst table readers cached in storage node
+    max-cached-table-index-size = %d
+
+    ## local backup target of database must be under this dir, backup into object store is not restricted
+    backup-dir = "%s"`,
 		t.Dir,
+		t.MaxCachedTableFiles,
+		t.MaxCachedTableIndexSize,
+		t.BackupDir,
+	)
+}
+
+// StorageHTTP represents the http configuration of storage, http server listens on the port of grpc + 1
+type StorageHTTP struct {
+	Timeout ltoml.Duration `toml:"timeout"`
+	TLS     TLS            `toml:"tls"`
+}
+
+func (h *StorageHTTP) TOML() string {
+	return fmt.Sprintf(`
+    ## timeout of http requests sent to storage nodes, such as backfill and repairing shard from peer replicas
+    timeout = "%s"`,
+		h.Timeout.String(),
 	)
 }
 
 // StorageBase represents a storage configuration
 type StorageBase struct {
-	Coordinator RepoState `toml:"coordinator"`
-	GRPC        GRPC      `toml:"grpc"`
-	TSDB        TSDB      `toml:"tsdb"`
-	Query       Query     `toml:"query"`
+	Coordinator RepoState   `toml:"coordinator"`
+	GRPC        GRPC        `toml:"grpc"`
+	HTTP        StorageHTTP `toml:"http"`
+	TSDB        TSDB        `toml:"tsdb"`
+	Query       Query       `toml:"query"`
 }
 
 // TOML returns StorageBase's toml config string
@@ -56,11 +86,20 @@ func (s *StorageBase) TOML() string {
   
   [storage.grpc]%s
 
+  [storage.grpc.tls]%s
+
+  [storage.http]%s
+
+  [storage.http.tls]%s
+
   [storage.tsdb]%s
 `,
 		s.Coordinator.TOML(),
 		s.Query.TOML(),
 		s.GRPC.TOML(),
+		s.GRPC.TLS.TOML(),
+		s.HTTP.TOML(),
+		s.HTTP.TLS.TOML(),
 		s.TSDB.TOML(),
 	)
 }
@@ -81,9 +120,17 @@ func NewDefaultStorageBase() *StorageBase {
 			DialTimeout: ltoml.Duration(time.Second * 5)},
 		GRPC: GRPC{
 			Port: 2891,
-			TTL:  ltoml.Duration(time.Second)},
+			TTL:  ltoml.Duration(time.Second),
+			TLS:  *NewDefaultTLS()},
+		HTTP: StorageHTTP{
+			Timeout: ltoml.Duration(time.Minute),
+			TLS:     *NewDefaultTLS()},
 		TSDB: TSDB{
-			Dir: filepath.Join(defaultParentDir, "storage/data")},
+			Dir:                     filepath.Join(defaultParentDir, "storage/data"),
+			MaxCachedTableFiles:     1024,
+			MaxCachedTableIndexSize: 512 * 1024 * 1024,
+			BackupDir:               filepath.Join(defaultParentDir, "storage/backup"),
+		},
 		Query: *NewDefaultQuery(),
 	}
 }
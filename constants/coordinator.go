@@ -51,6 +51,15 @@ const (
 	ReplicaStatePath = "/state/replica"
 	// StorageClusterStatPath represents storage cluster's node monitoring stat
 	StorageClusterStatPath = "/state/storage/stat/cluster"
+
+	// UserPath represents user config path
+	UserPath = "/auth/user"
+	// RolePath represents role config path
+	RolePath = "/auth/role"
+	// APIKeyPath represents api key config path
+	APIKeyPath = "/auth/apikey"
+	// RevokedTokenPath represents revoked token path
+	RevokedTokenPath = "/auth/revoked/token"
 )
 
 // defines all task kinds
@@ -59,6 +68,16 @@ const (
 	CreateShard task.Kind = "create-shard"
 	// FlushDatabase represents task kind which is flush memory database for storage node
 	FlushDatabase task.Kind = "flush-database"
+	// DropDatabase represents task kind which is drop database for storage node
+	DropDatabase task.Kind = "drop-database"
+	// DropMetric represents task kind which is drop metric of database for storage node
+	DropMetric task.Kind = "drop-metric"
+	// DeleteSeries represents task kind which is delete series of metric for storage node
+	DeleteSeries task.Kind = "delete-series"
+	// RepairShard represents task kind which is repair the data of shard from other replicas for storage node
+	RepairShard task.Kind = "repair-shard"
+	// BackupDatabase represents task kind which is backup the database into backup target for storage node
+	BackupDatabase task.Kind = "backup-database"
 )
 
 // GetStorageClusterConfigPath returns path which storing config of storage cluster
@@ -81,6 +100,26 @@ func GetDatabaseConfigPath(name string) string {
 	return fmt.Sprintf("%s/%s", DatabaseConfigPath, name)
 }
 
+// GetUserPath returns path which storing config of user
+func GetUserPath(name string) string {
+	return fmt.Sprintf("%s/%s", UserPath, name)
+}
+
+// GetRolePath returns path which storing config of role
+func GetRolePath(name string) string {
+	return fmt.Sprintf("%s/%s", RolePath, name)
+}
+
+// GetAPIKeyPath returns path which storing config of api key
+func GetAPIKeyPath(name string) string {
+	return fmt.Sprintf("%s/%s", APIKeyPath, name)
+}
+
+// GetRevokedTokenPath returns path which storing revoked token by token id
+func GetRevokedTokenPath(tokenID string) string {
+	return fmt.Sprintf("%s/%s", RevokedTokenPath, tokenID)
+}
+
 // GetDatabaseAssignPath returns path which storing shard assignment of database
 func GetDatabaseAssignPath(name string) string {
 	return fmt.Sprintf("%s/%s", DatabaseAssignPath, name)
@@ -54,3 +54,10 @@ func TestGetReplicaStatePath(t *testing.T) {
 func TestGetNodeMonitoringStatPath(t *testing.T) {
 	assert.Equal(t, StateNodesPath+"/1.1.1.1:port", GetNodeMonitoringStatPath("1.1.1.1:port"))
 }
+
+func TestGetAuthPath(t *testing.T) {
+	assert.Equal(t, UserPath+"/admin", GetUserPath("admin"))
+	assert.Equal(t, RolePath+"/reader", GetRolePath("reader"))
+	assert.Equal(t, APIKeyPath+"/agent", GetAPIKeyPath("agent"))
+	assert.Equal(t, RevokedTokenPath+"/id", GetRevokedTokenPath("id"))
+}
@@ -33,6 +33,22 @@ var (
 	// ErrEmptyField represents field is empty when write data
 	ErrEmptyField = errors.New("field is empty")
 
+	// ErrTooManyMetrics represents the number of metrics under namespace exceeds the limit when write data
+	ErrTooManyMetrics = errors.New("too many metrics under namespace")
+	// ErrTooManySeries represents the number of series under metric exceeds the limit when write data
+	ErrTooManySeries = errors.New("too many series under metric")
+	// ErrTooManyTagValues represents the number of tag values under tag key exceeds the limit when write data
+	ErrTooManyTagValues = errors.New("too many tag values under tag key")
+	// ErrOutOfTimeWindow represents the timestamp of metric is out of acceptable time range(behind/ahead) when write data
+	ErrOutOfTimeWindow = errors.New("timestamp out of time window")
+
+	// ErrQueryTooManySeries represents the number of series scanned by query exceeds the limit
+	ErrQueryTooManySeries = errors.New("query scans too many series")
+	// ErrQueryTooManyPoints represents the number of points returned by query exceeds the limit
+	ErrQueryTooManyPoints = errors.New("query returns too many points")
+	// ErrTooManyConcurrentQueries represents the number of running queries of database exceeds the limit
+	ErrTooManyConcurrentQueries = errors.New("too many concurrent queries")
+
 	// ErrDataFileCorruption represents data in tsdb's file is corrupted
 	ErrDataFileCorruption = errors.New("data corruption")
 )
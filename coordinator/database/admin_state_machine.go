@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
@@ -30,10 +31,15 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source=./admin_state_machine.go -destination=./admin_state_machine_mock.go -package=database
 
+// routingEpochDelay is the delay of new routing epoch taking effect,
+// makes sure all brokers and storage nodes receive the new shard assignment before routing metric to new shards.
+const routingEpochDelay = time.Minute
+
 // AdminStateMachine is database config controller,
 // creates shard assignment based on config and active nodes related storage cluster.
 // runtime watches database change event, maintain shard assignment and create related coordinator task.
@@ -186,11 +192,26 @@ func (sm *adminStateMachine) modifyShardAssignment(databaseName string, shardAss
 			nodeIDs = append(nodeIDs, idx)
 		}
 
+		originalNumOfShard := len(shardAssign.Shards)
+		// modifies the copy of shard assignment, keeps the original one unchanged if save failure
+		newShardAssign := copyShardAssignment(shardAssign)
 		// generate shard assignment based on node ids and config
-		err := ModifyShardAssignment(nodeIDs, cfg, shardAssign, -1, len(shardAssign.Shards))
+		err := ModifyShardAssignment(nodeIDs, cfg, newShardAssign, -1, originalNumOfShard)
 		if err != nil {
 			return err
 		}
+		// record new routing epoch, metric routes to new shards after epoch start time,
+		// the series of history data keep in original shards, query reads all shards then merges the results.
+		newShardAssign.AddRoutingEpoch(originalNumOfShard, cfg.NumOfShard, timeutil.Now()+routingEpochDelay.Milliseconds())
+		// save shard assignment into related storage cluster
+		if err := cluster.SaveShardAssign(databaseName, newShardAssign, cfg.Option); err != nil {
+			return err
+		}
+		*shardAssign = *newShardAssign
+		sm.log.Info("num. of shard increased, add new routing epoch",
+			logger.String("database", databaseName),
+			logger.Any("epoch", shardAssign.Epochs[len(shardAssign.Epochs)-1]))
+		return nil
 	}
 	// save shard assignment into related storage cluster
 	if err := cluster.SaveShardAssign(databaseName, shardAssign, cfg.Option); err != nil {
@@ -198,3 +219,14 @@ func (sm *adminStateMachine) modifyShardAssignment(databaseName string, shardAss
 	}
 	return nil
 }
+
+// copyShardAssignment copies the shards and routing epochs of shard assignment for modifying
+func copyShardAssignment(shardAssign *models.ShardAssignment) *models.ShardAssignment {
+	newShardAssign := *shardAssign
+	newShardAssign.Shards = make(map[int]*models.Replica, len(shardAssign.Shards))
+	for shardID, replica := range shardAssign.Shards {
+		newShardAssign.Shards[shardID] = replica
+	}
+	newShardAssign.Epochs = append(models.RoutingEpochs(nil), shardAssign.Epochs...)
+	return &newShardAssign
+}
@@ -29,7 +29,10 @@ import (
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestAdminStateMachine(t *testing.T) {
@@ -101,6 +104,49 @@ func TestAdminStateMachine(t *testing.T) {
 	_ = stateMachine.Close()
 }
 
+func TestAdminStateMachine_modifyShardAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sm := &adminStateMachine{log: logger.GetLogger("coordinator", "AdminStateMachine")}
+	cluster := storage.NewMockCluster(ctrl)
+	cfg := &models.Database{Name: "db1", NumOfShard: 3, ReplicaFactor: 1}
+	shardAssign, err := ShardAssignment([]int{0, 1}, cfg, -1, -1)
+	assert.NoError(t, err)
+	// case 1: active node not found
+	cfg.NumOfShard = 5
+	cluster.EXPECT().GetActiveNodes().Return(nil)
+	err = sm.modifyShardAssignment("db1", shardAssign, cluster, cfg)
+	assert.Error(t, err)
+	// case 2: increase num. of shard, add routing epoch
+	cluster.EXPECT().GetActiveNodes().Return(prepareStorageCluster())
+	cluster.EXPECT().SaveShardAssign("db1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ string, saved *models.ShardAssignment, _ option.DatabaseOption) error {
+			// saves the modified copy, original one is unchanged before saved
+			assert.Len(t, saved.Epochs, 2)
+			assert.Len(t, shardAssign.Epochs, 0)
+			return nil
+		})
+	now := timeutil.Now()
+	err = sm.modifyShardAssignment("db1", shardAssign, cluster, cfg)
+	assert.NoError(t, err)
+	assert.Len(t, shardAssign.Shards, 5)
+	assert.Len(t, shardAssign.Epochs, 2)
+	assert.Equal(t, models.RoutingEpoch{NumOfShard: 3}, shardAssign.Epochs[0])
+	assert.Equal(t, 1, shardAssign.Epochs[1].Epoch)
+	assert.Equal(t, 5, shardAssign.Epochs[1].NumOfShard)
+	assert.True(t, shardAssign.Epochs[1].StartTime >= now+routingEpochDelay.Milliseconds())
+	// case 3: save shard assignment err
+	cfg.NumOfShard = 6
+	cluster.EXPECT().GetActiveNodes().Return(prepareStorageCluster())
+	cluster.EXPECT().SaveShardAssign("db1", gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	err = sm.modifyShardAssignment("db1", shardAssign, cluster, cfg)
+	assert.Error(t, err)
+	// shard assignment is unchanged if save failure
+	assert.Len(t, shardAssign.Shards, 5)
+	assert.Len(t, shardAssign.Epochs, 2)
+}
+
 func prepareStorageCluster() []*models.ActiveNode {
 	return []*models.ActiveNode{
 		{Node: models.Node{IP: "127.0.0.1", Port: 2080}},
@@ -33,6 +33,7 @@ import (
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 //go:generate mockgen -source=./master.go -destination=./master_mock.go -package=coordinator
@@ -79,6 +80,16 @@ type Master interface {
 	Stop()
 	// FlushDatabase submits the coordinator task for flushing memory database by cluster and database name
 	FlushDatabase(cluster string, databaseName string) error
+	// DropDatabase submits the coordinator task for dropping database by cluster and database name
+	DropDatabase(cluster string, databaseName string) error
+	// DropMetric submits the coordinator task for dropping metric of database
+	DropMetric(cluster string, databaseName, namespace, metricName string) error
+	// DeleteSeries submits the coordinator task for deleting series which match the tag filter condition
+	DeleteSeries(cluster string, databaseName, namespace, metricName string, condition stmt.Expr) error
+	// RepairDatabase submits the coordinator task for repairing the shards of database from other replicas
+	RepairDatabase(cluster string, databaseName string) error
+	// BackupDatabase submits the coordinator task for backing up the database into target
+	BackupDatabase(cluster string, databaseName, target, name string) error
 }
 
 // master implements master interface
@@ -189,3 +200,73 @@ func (m *master) FlushDatabase(cluster string, databaseName string) error {
 	}
 	return nil
 }
+
+// DropDatabase submits the coordinator task for dropping database by cluster and database name
+func (m *master) DropDatabase(cluster string, databaseName string) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return errNoCluster
+		}
+		return cluster.DropDatabase(databaseName)
+	}
+	return nil
+}
+
+// DropMetric submits the coordinator task for dropping metric of database
+func (m *master) DropMetric(cluster string, databaseName, namespace, metricName string) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return errNoCluster
+		}
+		return cluster.DropMetric(databaseName, namespace, metricName)
+	}
+	return nil
+}
+
+// DeleteSeries submits the coordinator task for deleting series which match the tag filter condition
+func (m *master) DeleteSeries(cluster string, databaseName, namespace, metricName string, condition stmt.Expr) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return errNoCluster
+		}
+		return cluster.DeleteSeries(databaseName, namespace, metricName, condition)
+	}
+	return nil
+}
+
+// RepairDatabase submits the coordinator task for repairing the shards of database from other replicas
+func (m *master) RepairDatabase(cluster string, databaseName string) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return errNoCluster
+		}
+		return cluster.RepairDatabase(databaseName)
+	}
+	return nil
+}
+
+// BackupDatabase submits the coordinator task for backing up the database into target
+func (m *master) BackupDatabase(cluster string, databaseName, target, name string) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return errNoCluster
+		}
+		return cluster.BackupDatabase(databaseName, target, name)
+	}
+	return nil
+}
@@ -211,6 +211,43 @@ func TestMaster_FlushDatabase(t *testing.T) {
 	cluster1.EXPECT().FlushDatabase(gomock.Any()).Return(nil)
 	err = master1.FlushDatabase("test", "test")
 	assert.NoError(t, err)
+
+	// drop/delete data
+	clusterSM.EXPECT().GetCluster(gomock.Any()).Return(nil).Times(3)
+	err = master1.DropDatabase("test", "test")
+	assert.Equal(t, errNoCluster, err)
+	err = master1.DropMetric("test", "test", "ns", "cpu")
+	assert.Equal(t, errNoCluster, err)
+	err = master1.DeleteSeries("test", "test", "ns", "cpu", nil)
+	assert.Equal(t, errNoCluster, err)
+	clusterSM.EXPECT().GetCluster(gomock.Any()).Return(cluster1).Times(3)
+	cluster1.EXPECT().DropDatabase("test").Return(nil)
+	err = master1.DropDatabase("test", "test")
+	assert.NoError(t, err)
+	cluster1.EXPECT().DropMetric("test", "ns", "cpu").Return(nil)
+	err = master1.DropMetric("test", "test", "ns", "cpu")
+	assert.NoError(t, err)
+	cluster1.EXPECT().DeleteSeries("test", "ns", "cpu", nil).Return(nil)
+	err = master1.DeleteSeries("test", "test", "ns", "cpu", nil)
+	assert.NoError(t, err)
+
+	// repair database
+	clusterSM.EXPECT().GetCluster(gomock.Any()).Return(nil)
+	err = master1.RepairDatabase("test", "test")
+	assert.Equal(t, errNoCluster, err)
+	clusterSM.EXPECT().GetCluster(gomock.Any()).Return(cluster1)
+	cluster1.EXPECT().RepairDatabase("test").Return(nil)
+	err = master1.RepairDatabase("test", "test")
+	assert.NoError(t, err)
+
+	// backup database
+	clusterSM.EXPECT().GetCluster(gomock.Any()).Return(nil)
+	err = master1.BackupDatabase("test", "test", "/tmp/backup", "b1")
+	assert.Equal(t, errNoCluster, err)
+	clusterSM.EXPECT().GetCluster(gomock.Any()).Return(cluster1)
+	cluster1.EXPECT().BackupDatabase("test", "/tmp/backup", "b1").Return(nil)
+	err = master1.BackupDatabase("test", "test", "/tmp/backup", "b1")
+	assert.NoError(t, err)
 }
 
 func sendEvent(eventCh chan *state.Event, event *state.Event) {
@@ -127,6 +127,12 @@ func (sm *replicatorStateMachine) buildShardAssign(shardAssign *models.ShardAssi
 	for shardID := range shards {
 		sm.createReplicaChannel(numOfShard, shardID, shardAssign)
 	}
+	if len(shardAssign.Epochs) > 0 {
+		// num. of shard changed, routes metric by routing epochs
+		if err := sm.cm.SetRoutingEpochs(shardAssign.Name, shardAssign.Epochs); err != nil {
+			sm.log.Error("set routing epochs", logger.String("db", shardAssign.Name), logger.Error(err))
+		}
+	}
 }
 
 // createReplicaChannel creates wal replica channel for spec database's shard
@@ -28,6 +28,7 @@ import (
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/replication"
 	"github.com/lindb/lindb/service"
 )
@@ -100,3 +101,26 @@ func TestReplicatorStateMachine(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestReplicatorStateMachine_buildShardAssign_RoutingEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	sm := &replicatorStateMachine{
+		cm:           cm,
+		shardAssigns: make(map[string]*models.ShardAssignment),
+		log:          logger.GetLogger("coordinator", "ReplicatorStateMachine"),
+	}
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddRoutingEpoch(1, 2, 100)
+	ch := replication.NewMockChannel(ctrl)
+	cm.EXPECT().CreateChannel("test", int32(2), gomock.Any()).Return(ch, nil).Times(2)
+	cm.EXPECT().SetRoutingEpochs("test", shardAssign.Epochs).Return(fmt.Errorf("err"))
+	sm.buildShardAssign(shardAssign)
+	cm.EXPECT().CreateChannel("test", int32(2), gomock.Any()).Return(ch, nil).Times(2)
+	cm.EXPECT().SetRoutingEpochs("test", shardAssign.Epochs).Return(nil)
+	sm.buildShardAssign(shardAssign)
+}
@@ -41,6 +41,10 @@ type StatusStateMachine interface {
 	// and chooses the fastest replica if the shard has multi-replica.
 	// returns storage node => shard id list
 	GetQueryableReplicas(database string) map[string][]int32
+	// GetShardReplicas returns all queryable replicas of each shard for failover,
+	// the storage nodes are ordered by pending msg, the fastest is the first.
+	// returns shard id => storage node list
+	GetShardReplicas(database string) map[int32][]string
 	// GetReplicas returns the replica state list under this broker by broker's indicator
 	GetReplicas(broker string) models.BrokerReplicaState
 	// Close closes state machine, stops watch change event
@@ -92,39 +96,69 @@ func NewStatusStateMachine(ctx context.Context, factory discovery.Factory) (Stat
 // GetQueryableReplicas returns the queryable replicas
 // returns storage node => shard id list
 func (sm *statusStateMachine) GetQueryableReplicas(database string) map[string][]int32 {
-	// 1. find shards by given database's name
-	shards := make(map[string][]models.ReplicaState)
+	shards := sm.getShardReplicas(database)
+	if len(shards) == 0 {
+		return nil
+	}
+
+	result := make(map[string][]int32)
+	for shardID, replicaList := range shards {
+		// chooses the fastest replica
+		nodeID := replicaList[0].Target.Indicator()
+		result[nodeID] = append(result[nodeID], shardID)
+	}
+
+	return result
+}
+
+// GetShardReplicas returns all queryable replicas of each shard, ordered by pending msg
+// returns shard id => storage node list
+func (sm *statusStateMachine) GetShardReplicas(database string) map[int32][]string {
+	shards := sm.getShardReplicas(database)
+	if len(shards) == 0 {
+		return nil
+	}
+
+	result := make(map[int32][]string)
+	for shardID, replicaList := range shards {
+		nodes := make(map[string]struct{})
+		for _, replica := range replicaList {
+			nodeID := replica.Target.Indicator()
+			// same replica maybe reported by multi-broker
+			if _, ok := nodes[nodeID]; ok {
+				continue
+			}
+			nodes[nodeID] = struct{}{}
+			result[shardID] = append(result[shardID], nodeID)
+		}
+	}
+	return result
+}
+
+// getShardReplicas returns the replica list of each shard by given database's name,
+// the replicas are sorted based on pending msg.
+func (sm *statusStateMachine) getShardReplicas(database string) map[int32][]models.ReplicaState {
+	shards := make(map[int32][]models.ReplicaState)
 	sm.mutex.RLock()
 	for _, brokerReplicaState := range sm.brokers {
 		for _, replica := range brokerReplicaState.Replicas {
 			if replica.Database != database {
 				continue
 			}
-			shardID := replica.ShardIndicator()
-			shards[shardID] = append(shards[shardID], replica)
+			shards[replica.ShardID] = append(shards[replica.ShardID], replica)
 		}
 	}
 	sm.mutex.RUnlock()
 
-	if len(shards) == 0 {
-		return nil
-	}
-
-	result := make(map[string][]int32)
-	for _, replicas := range shards {
-		replicaList := replicas
+	for _, replicaList := range shards {
 		if len(replicaList) > 1 {
-			// has multi-replica, chooses the fastest
-			// sort replicas based pending msg
-			sort.Slice(replicaList, func(i, j int) bool {
+			// has multi-replica, sort replicas based pending msg
+			sort.SliceStable(replicaList, func(i, j int) bool {
 				return replicaList[i].Pending < replicaList[j].Pending
 			})
 		}
-		nodeID := replicaList[0].Target.Indicator()
-		result[nodeID] = append(result[nodeID], replicaList[0].ShardID)
 	}
-
-	return result
+	return shards
 }
 
 // GetReplicas returns the replica state list under this broker by broker's indicator
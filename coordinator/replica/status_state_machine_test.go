@@ -146,6 +146,11 @@ func TestStatusStateMachine(t *testing.T) {
 	r = sm.GetQueryableReplicas("test_db_not_exist")
 	assert.Nil(t, r)
 
+	replicas := sm.GetShardReplicas("test_db")
+	assert.Equal(t, 2, len(replicas))
+	assert.Equal(t, []string{"1.1.1.3:2090", "1.1.1.2:2090"}, replicas[2])
+	assert.Nil(t, sm.GetShardReplicas("test_db_not_exist"))
+
 	discovery1.EXPECT().Close()
 	err = sm.Close()
 	if err != nil {
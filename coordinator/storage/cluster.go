@@ -33,6 +33,8 @@ import (
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
 )
 
 //go:generate mockgen -source=./cluster.go -destination=./cluster_mock.go -package=storage
@@ -93,6 +95,21 @@ type Cluster interface {
 	// FLushDatabase submits the coordinator task for flushing memory database by name
 	FlushDatabase(databaseName string) error
 
+	// DropDatabase submits the coordinator task for dropping database, then removes the shard assignment
+	DropDatabase(databaseName string) error
+
+	// DropMetric submits the coordinator task for dropping metric of database
+	DropMetric(databaseName, namespace, metricName string) error
+
+	// DeleteSeries submits the coordinator task for deleting series which match the tag filter condition
+	DeleteSeries(databaseName, namespace, metricName string, condition stmt.Expr) error
+
+	// RepairDatabase submits the coordinator task for repairing the shards of database from other replicas
+	RepairDatabase(databaseName string) error
+
+	// BackupDatabase submits the coordinator task for backing up the database of all active nodes into target
+	BackupDatabase(databaseName, target, name string) error
+
 	// SaveShardAssign saves shard assignment
 	SaveShardAssign(
 		databaseName string,
@@ -211,21 +228,163 @@ func (c *cluster) CollectStat() (*models.StorageClusterStat, error) {
 
 // FLushDatabase submits the coordinator task for flushing memory database by name
 func (c *cluster) FlushDatabase(databaseName string) error {
+	return c.submitTaskToActiveNodes(constants.FlushDatabase, databaseName,
+		&models.DatabaseFlushTask{DatabaseName: databaseName})
+}
+
+// DropDatabase submits the coordinator task for dropping database, then removes the shard assignment
+func (c *cluster) DropDatabase(databaseName string) error {
+	if err := c.submitTaskToDatabaseNodes(constants.DropDatabase, databaseName,
+		&models.DropDatabaseTask{DatabaseName: databaseName}); err != nil {
+		return err
+	}
+	return c.cfg.shardAssignService.Delete(databaseName)
+}
+
+// DropMetric submits the coordinator task for dropping metric of database
+func (c *cluster) DropMetric(databaseName, namespace, metricName string) error {
+	return c.submitTaskToDatabaseNodes(constants.DropMetric, databaseName,
+		&models.DropMetricTask{
+			DatabaseName: databaseName,
+			Namespace:    namespace,
+			MetricName:   metricName,
+		})
+}
+
+// DeleteSeries submits the coordinator task for deleting series which match the tag filter condition
+func (c *cluster) DeleteSeries(databaseName, namespace, metricName string, condition stmt.Expr) error {
+	return c.submitTaskToDatabaseNodes(constants.DeleteSeries, databaseName,
+		&models.DeleteSeriesTask{
+			DatabaseName: databaseName,
+			Namespace:    namespace,
+			MetricName:   metricName,
+			Condition:    stmt.Marshal(condition),
+		})
+}
+
+// RepairDatabase submits the coordinator task for repairing the shards of database from other replicas,
+// each active replica pulls the missing data from the other active replicas of same shard.
+func (c *cluster) RepairDatabase(databaseName string) error {
+	shardAssign, err := c.GetShardAssign(databaseName)
+	if err != nil {
+		return err
+	}
+	var tasks = make(map[int]*models.ShardRepairTask)
+	c.mutex.RLock()
+	for shardID, shard := range shardAssign.Shards {
+		// active replicas of shard, key: replica id, value: active node
+		replicas := make(map[int]models.Node)
+		for _, replicaID := range shard.Replicas {
+			node, ok := shardAssign.Nodes[replicaID]
+			if !ok {
+				continue
+			}
+			if activeNode, ok := c.clusterState.ActiveNodes[node.Indicator()]; ok {
+				replicas[replicaID] = activeNode.Node
+			}
+		}
+		for replicaID := range replicas {
+			var peers []models.Node
+			for peerID, peer := range replicas {
+				if peerID != replicaID {
+					peers = append(peers, peer)
+				}
+			}
+			if len(peers) == 0 {
+				continue
+			}
+			taskParam, ok := tasks[replicaID]
+			if !ok {
+				taskParam = &models.ShardRepairTask{DatabaseName: databaseName}
+				tasks[replicaID] = taskParam
+			}
+			taskParam.Shards = append(taskParam.Shards, models.ShardRepair{ShardID: int32(shardID), Peers: peers})
+		}
+	}
+	c.mutex.RUnlock()
+	if len(tasks) == 0 {
+		return fmt.Errorf("no shard of database[%s] has active peer replicas", databaseName)
+	}
 	var params []task.ControllerTaskParam
-	taskParam := &models.DatabaseFlushTask{DatabaseName: databaseName}
+	for nodeID, taskParam := range tasks {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: shardAssign.Nodes[nodeID].Indicator(),
+			Params: taskParam,
+		})
+	}
+	return c.SubmitTask(constants.RepairShard, databaseName, params)
+}
+
+// BackupDatabase submits the coordinator task for backing up the database of all active nodes into target,
+// the metric/tag/series ids are node-local, so each node backups its shards and metadata under node's prefix.
+func (c *cluster) BackupDatabase(databaseName, target, name string) error {
+	shardAssign, err := c.GetShardAssign(databaseName)
+	if err != nil {
+		return err
+	}
+	var params []task.ControllerTaskParam
+	c.mutex.RLock()
+	for _, node := range shardAssign.Nodes {
+		nodeID := node.Indicator()
+		if _, ok := c.clusterState.ActiveNodes[nodeID]; !ok {
+			continue
+		}
+		params = append(params, task.ControllerTaskParam{
+			NodeID: nodeID,
+			Params: &models.DatabaseBackupTask{
+				DatabaseName: databaseName,
+				Target:       target,
+				Name:         name,
+				Prefix:       tsdb.BackupPrefix(name, nodeID, databaseName),
+			},
+		})
+	}
+	c.mutex.RUnlock()
+	if len(params) == 0 {
+		return fmt.Errorf("no active node of database[%s]", databaseName)
+	}
+	return c.SubmitTask(constants.BackupDatabase, databaseName+"-"+name, params)
+}
+
+// submitTaskToActiveNodes submits the coordinator task for all active nodes
+func (c *cluster) submitTaskToActiveNodes(kind task.Kind, name string, taskParam task.ToBytes) error {
+	var params []task.ControllerTaskParam
+	c.mutex.RLock()
 	for _, node := range c.clusterState.ActiveNodes {
 		params = append(params, task.ControllerTaskParam{
 			NodeID: node.Node.Indicator(),
 			Params: taskParam,
 		})
 	}
-	// create create shard coordinator tasks
-	if err := c.SubmitTask(constants.FlushDatabase, databaseName, params); err != nil {
+	c.mutex.RUnlock()
+	if err := c.SubmitTask(kind, name, params); err != nil {
 		return err
 	}
 	return nil
 }
 
+// submitTaskToDatabaseNodes submits the coordinator task for all nodes which the shards of database assigned to,
+// includes inactive nodes, the task is persisted in state repo and executed when inactive node comes back,
+// so that the dropped/deleted data of database doesn't come back with the offline replica.
+func (c *cluster) submitTaskToDatabaseNodes(kind task.Kind, databaseName string, taskParam task.ToBytes) error {
+	shardAssign, err := c.GetShardAssign(databaseName)
+	if err == state.ErrNotExist {
+		// no shard assigned, nothing stored in storage nodes
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var params []task.ControllerTaskParam
+	for _, node := range shardAssign.Nodes {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	return c.SubmitTask(kind, databaseName, params)
+}
+
 // GetShardAssign returns shard assignment by database name, return not exist err if it not exist
 func (c *cluster) GetShardAssign(databaseName string) (*models.ShardAssignment, error) {
 	return c.cfg.shardAssignService.Get(databaseName)
@@ -26,6 +26,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/coordinator/task"
 	"github.com/lindb/lindb/models"
@@ -33,6 +34,7 @@ import (
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestStorageCluster(t *testing.T) {
@@ -256,3 +258,226 @@ func TestCluster_FlushDatabase(t *testing.T) {
 	err = cluster1.FlushDatabase("test")
 	assert.Error(t, err)
 }
+
+func TestCluster_DeleteData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	factory := NewClusterFactory()
+	storage := config.StorageCluster{
+		Config: config.RepoState{Namespace: "storage"},
+	}
+	discoveryFactory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
+
+	storageService := service.NewMockStorageStateService(ctrl)
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	repo := state.NewMockRepository(ctrl)
+	discovery1.EXPECT().Discovery().Return(nil)
+
+	storageService.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	controller := task.NewMockController(ctrl)
+	controllerFactory := task.NewMockControllerFactory(ctrl)
+	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
+	cfg := clusterCfg{
+		storageStateService: storageService,
+		shardAssignService:  shardAssignService,
+		cfg:                 storage,
+		repo:                repo,
+		factory:             discoveryFactory,
+		controllerFactory:   controllerFactory,
+	}
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+	cluster1, err := factory.newCluster(cfg)
+	assert.NoError(t, err)
+	cluster2 := cluster1.(*cluster)
+	cluster2.mutex.Lock()
+	cluster2.clusterState.AddActiveNode(&models.ActiveNode{
+		Node: models.Node{IP: "1.1.1.1", Port: 9000},
+	})
+	cluster2.mutex.Unlock()
+
+	// database shards are assigned to active node and inactive node
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(0, 2)
+	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", Port: 9000}
+	shardAssign.Nodes[2] = &models.Node{IP: "1.1.1.2", Port: 9000}
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil).AnyTimes()
+	shardAssignService.EXPECT().Get("not_assigned").Return(nil, state.ErrNotExist).AnyTimes()
+	shardAssignService.EXPECT().Get("err").Return(nil, fmt.Errorf("err")).AnyTimes()
+
+	// drop database
+	err = cluster1.DropDatabase("err")
+	assert.Error(t, err)
+	controller.EXPECT().Submit(constants.DropDatabase, "test", gomock.Any()).Return(fmt.Errorf("err"))
+	err = cluster1.DropDatabase("test")
+	assert.Error(t, err)
+	// task is submitted to inactive node too, the node executes it when it comes back
+	controller.EXPECT().Submit(constants.DropDatabase, "test", gomock.Any()).
+		DoAndReturn(func(_ task.Kind, _ string, params []task.ControllerTaskParam) error {
+			var nodes []string
+			for _, param := range params {
+				nodes = append(nodes, param.NodeID)
+			}
+			assert.ElementsMatch(t, []string{"1.1.1.1:9000", "1.1.1.2:9000"}, nodes)
+			return nil
+		})
+	shardAssignService.EXPECT().Delete("test").Return(nil)
+	err = cluster1.DropDatabase("test")
+	assert.NoError(t, err)
+	// database without shard assignment
+	shardAssignService.EXPECT().Delete("not_assigned").Return(nil)
+	err = cluster1.DropDatabase("not_assigned")
+	assert.NoError(t, err)
+	// drop metric
+	controller.EXPECT().Submit(constants.DropMetric, "test", gomock.Any()).Return(nil)
+	err = cluster1.DropMetric("test", "ns", "cpu")
+	assert.NoError(t, err)
+	// delete series
+	controller.EXPECT().Submit(constants.DeleteSeries, "test", gomock.Any()).Return(nil)
+	err = cluster1.DeleteSeries("test", "ns", "cpu", &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"})
+	assert.NoError(t, err)
+}
+
+func TestCluster_RepairDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	factory := NewClusterFactory()
+	storage := config.StorageCluster{
+		Config: config.RepoState{Namespace: "storage"},
+	}
+	discoveryFactory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
+
+	storageService := service.NewMockStorageStateService(ctrl)
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	repo := state.NewMockRepository(ctrl)
+	discovery1.EXPECT().Discovery().Return(nil)
+
+	storageService.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	controller := task.NewMockController(ctrl)
+	controllerFactory := task.NewMockControllerFactory(ctrl)
+	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
+	cfg := clusterCfg{
+		storageStateService: storageService,
+		shardAssignService:  shardAssignService,
+		cfg:                 storage,
+		repo:                repo,
+		factory:             discoveryFactory,
+		controllerFactory:   controllerFactory,
+	}
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+	cluster1, err := factory.newCluster(cfg)
+	assert.NoError(t, err)
+	cluster2 := cluster1.(*cluster)
+	cluster2.mutex.Lock()
+	cluster2.clusterState.AddActiveNode(&models.ActiveNode{
+		Node: models.Node{IP: "1.1.1.1", Port: 9000, HTTPPort: 2891},
+	})
+	cluster2.clusterState.AddActiveNode(&models.ActiveNode{
+		Node: models.Node{IP: "1.1.1.2", Port: 9000, HTTPPort: 2891},
+	})
+	cluster2.mutex.Unlock()
+
+	// case 1: get shard assign err
+	shardAssignService.EXPECT().Get("test").Return(nil, fmt.Errorf("err"))
+	err = cluster1.RepairDatabase("test")
+	assert.Error(t, err)
+	// case 2: no active peer replicas
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddReplica(1, 3)
+	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", Port: 9000}
+	shardAssign.Nodes[3] = &models.Node{IP: "1.1.1.3", Port: 9000}
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil)
+	err = cluster1.RepairDatabase("test")
+	assert.Error(t, err)
+	// case 3: submit task to active replicas
+	shardAssign.AddReplica(1, 2)
+	shardAssign.AddReplica(2, 1)
+	shardAssign.Nodes[2] = &models.Node{IP: "1.1.1.2", Port: 9000}
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil)
+	controller.EXPECT().Submit(constants.RepairShard, "test", gomock.Any()).
+		DoAndReturn(func(_ task.Kind, _ string, params []task.ControllerTaskParam) error {
+			assert.Len(t, params, 2)
+			for _, param := range params {
+				repairTask := param.Params.(*models.ShardRepairTask)
+				assert.Equal(t, "test", repairTask.DatabaseName)
+				assert.Equal(t, []models.ShardRepair{{ShardID: 1, Peers: repairTask.Shards[0].Peers}}, repairTask.Shards)
+				assert.Len(t, repairTask.Shards[0].Peers, 1)
+				assert.NotEqual(t, param.NodeID, repairTask.Shards[0].Peers[0].Indicator())
+				assert.Equal(t, uint16(2891), repairTask.Shards[0].Peers[0].HTTPPort)
+			}
+			return nil
+		})
+	err = cluster1.RepairDatabase("test")
+	assert.NoError(t, err)
+}
+
+func TestCluster_BackupDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	factory := NewClusterFactory()
+	storage := config.StorageCluster{
+		Config: config.RepoState{Namespace: "storage"},
+	}
+	discoveryFactory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
+
+	storageService := service.NewMockStorageStateService(ctrl)
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	repo := state.NewMockRepository(ctrl)
+	discovery1.EXPECT().Discovery().Return(nil)
+
+	storageService.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	controller := task.NewMockController(ctrl)
+	controllerFactory := task.NewMockControllerFactory(ctrl)
+	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
+	cfg := clusterCfg{
+		storageStateService: storageService,
+		shardAssignService:  shardAssignService,
+		cfg:                 storage,
+		repo:                repo,
+		factory:             discoveryFactory,
+		controllerFactory:   controllerFactory,
+	}
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+	cluster1, err := factory.newCluster(cfg)
+	assert.NoError(t, err)
+	cluster2 := cluster1.(*cluster)
+	cluster2.mutex.Lock()
+	cluster2.clusterState.AddActiveNode(&models.ActiveNode{
+		Node: models.Node{IP: "1.1.1.1", Port: 9000, HTTPPort: 2891},
+	})
+	cluster2.mutex.Unlock()
+
+	// case 1: get shard assign err
+	shardAssignService.EXPECT().Get("test").Return(nil, fmt.Errorf("err"))
+	err = cluster1.BackupDatabase("test", "/tmp/backup", "b1")
+	assert.Error(t, err)
+	// case 2: no active node of database
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(1, 2)
+	shardAssign.Nodes[2] = &models.Node{IP: "1.1.1.2", Port: 9000}
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil)
+	err = cluster1.BackupDatabase("test", "/tmp/backup", "b1")
+	assert.Error(t, err)
+	// case 3: submit task to active nodes
+	shardAssign.AddReplica(1, 1)
+	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", Port: 9000}
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil)
+	controller.EXPECT().Submit(constants.BackupDatabase, "test-b1", []task.ControllerTaskParam{{
+		NodeID: "1.1.1.1:9000",
+		Params: &models.DatabaseBackupTask{
+			DatabaseName: "test",
+			Target:       "/tmp/backup",
+			Name:         "b1",
+			Prefix:       "b1/1.1.1.1_9000/test",
+		},
+	}}).Return(nil)
+	err = cluster1.BackupDatabase("test", "/tmp/backup", "b1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,63 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+)
+
+// databaseBackupProcessor represents backup the database of storage node into backup target
+type databaseBackupProcessor struct {
+	storageService service.StorageService
+}
+
+// newDatabaseBackupProcessor returns backup database processor instance
+func newDatabaseBackupProcessor(storageService service.StorageService) task.Processor {
+	return &databaseBackupProcessor{
+		storageService: storageService,
+	}
+}
+
+func (p *databaseBackupProcessor) Kind() task.Kind             { return constants.BackupDatabase }
+func (p *databaseBackupProcessor) RetryCount() int             { return 0 }
+func (p *databaseBackupProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *databaseBackupProcessor) Concurrency() int            { return 1 }
+
+// Process backups the database into backup target
+func (p *databaseBackupProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DatabaseBackupTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.storageService.BackupDatabase(param.DatabaseName, param.Target, param.Name, param.Prefix); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageBackupDBProcessor").
+		Info("process backup database task",
+			logger.String("params", string(task.Params)),
+		)
+	return nil
+}
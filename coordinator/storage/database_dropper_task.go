@@ -0,0 +1,63 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+)
+
+// databaseDropProcessor represents drop database and remove all data of database
+type databaseDropProcessor struct {
+	storageService service.StorageService
+}
+
+// newDatabaseDropProcessor returns drop database processor instance
+func newDatabaseDropProcessor(storageService service.StorageService) task.Processor {
+	return &databaseDropProcessor{
+		storageService: storageService,
+	}
+}
+
+func (p *databaseDropProcessor) Kind() task.Kind             { return constants.DropDatabase }
+func (p *databaseDropProcessor) RetryCount() int             { return 0 }
+func (p *databaseDropProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *databaseDropProcessor) Concurrency() int            { return 1 }
+
+// Process drops database and removes all data of database
+func (p *databaseDropProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DropDatabaseTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.storageService.DropDatabase(param.DatabaseName); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageDropDBProcessor").
+		Info("process drop database task",
+			logger.String("params", string(task.Params)),
+		)
+	return nil
+}
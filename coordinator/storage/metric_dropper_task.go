@@ -0,0 +1,63 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+)
+
+// metricDropProcessor represents drop metric of database
+type metricDropProcessor struct {
+	storageService service.StorageService
+}
+
+// newMetricDropProcessor returns drop metric processor instance
+func newMetricDropProcessor(storageService service.StorageService) task.Processor {
+	return &metricDropProcessor{
+		storageService: storageService,
+	}
+}
+
+func (p *metricDropProcessor) Kind() task.Kind             { return constants.DropMetric }
+func (p *metricDropProcessor) RetryCount() int             { return 0 }
+func (p *metricDropProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *metricDropProcessor) Concurrency() int            { return 1 }
+
+// Process drops metric of database
+func (p *metricDropProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DropMetricTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.storageService.DropMetric(param.DatabaseName, param.Namespace, param.MetricName); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageDropMetricProcessor").
+		Info("process drop metric task",
+			logger.String("params", string(task.Params)),
+		)
+	return nil
+}
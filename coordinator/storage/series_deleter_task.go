@@ -0,0 +1,68 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// seriesDeleteProcessor represents delete series which match the tag filter condition
+type seriesDeleteProcessor struct {
+	storageService service.StorageService
+}
+
+// newSeriesDeleteProcessor returns delete series processor instance
+func newSeriesDeleteProcessor(storageService service.StorageService) task.Processor {
+	return &seriesDeleteProcessor{
+		storageService: storageService,
+	}
+}
+
+func (p *seriesDeleteProcessor) Kind() task.Kind             { return constants.DeleteSeries }
+func (p *seriesDeleteProcessor) RetryCount() int             { return 0 }
+func (p *seriesDeleteProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *seriesDeleteProcessor) Concurrency() int            { return 1 }
+
+// Process deletes series which match the tag filter condition
+func (p *seriesDeleteProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DeleteSeriesTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	condition, err := stmt.Unmarshal(param.Condition)
+	if err != nil {
+		return err
+	}
+	if err := p.storageService.DeleteSeries(param.DatabaseName, param.Namespace, param.MetricName, condition); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageDeleteSeriesProcessor").
+		Info("process delete series task",
+			logger.String("params", string(task.Params)),
+		)
+	return nil
+}
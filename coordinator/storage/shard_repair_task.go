@@ -0,0 +1,214 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+var (
+	repairDivergenceCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_repair_divergent_metrics",
+			Help: "The number of metrics which diverge from the peer replicas, kind: index/family.",
+		},
+		[]string{"db", "kind"},
+	)
+	repairMetricsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_repair_metrics",
+			Help: "The number of metrics repaired from the peer replicas.",
+		},
+		[]string{"db"},
+	)
+	repairFailCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_repair_failures",
+			Help: "The number of failures when repairing shard from the peer replicas.",
+		},
+		[]string{"db"},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(repairDivergenceCounter)
+	monitoring.StorageRegistry.MustRegister(repairMetricsCounter)
+	monitoring.StorageRegistry.MustRegister(repairFailCounter)
+}
+
+// shardRepairProcessor represents repair the data of shards from the peer replicas(anti-entropy),
+// compares the digest of index and persisted data families with peer, then pulls the missing series from peer.
+type shardRepairProcessor struct {
+	storageService service.StorageService
+	scheme         string                                          // scheme of peer's http api, https if tls enable
+	httpDo         func(req *http.Request) (*http.Response, error) // do func of http client with timeout
+	logger         *logger.Logger
+}
+
+// newShardRepairProcessor returns repair shard processor instance,
+// requests the repair api of peer by the http client with timeout and tls config.
+func newShardRepairProcessor(storageService service.StorageService, scheme string, client *http.Client) task.Processor {
+	return &shardRepairProcessor{
+		storageService: storageService,
+		scheme:         scheme,
+		httpDo:         client.Do,
+		logger:         logger.GetLogger("coordinator", "StorageShardRepairProcessor"),
+	}
+}
+
+func (p *shardRepairProcessor) Kind() task.Kind             { return constants.RepairShard }
+func (p *shardRepairProcessor) RetryCount() int             { return 0 }
+func (p *shardRepairProcessor) RetryBackOff() time.Duration { return 0 }
+func (p *shardRepairProcessor) Concurrency() int            { return 1 }
+
+// Process repairs the shards of database from the peer replicas
+func (p *shardRepairProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.ShardRepairTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	for _, shardRepair := range param.Shards {
+		shard, ok := p.storageService.GetShard(param.DatabaseName, shardRepair.ShardID)
+		if !ok {
+			return fmt.Errorf("shard[%d] of database[%s] not exist", shardRepair.ShardID, param.DatabaseName)
+		}
+		for idx := range shardRepair.Peers {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			peer := shardRepair.Peers[idx]
+			if err := p.repairFromPeer(param.DatabaseName, shard, &peer); err != nil {
+				repairFailCounter.WithLabelValues(param.DatabaseName).Inc()
+				return fmt.Errorf("repair shard[%d] of database[%s] from peer[%s] failure: %s",
+					shardRepair.ShardID, param.DatabaseName, peer.Indicator(), err)
+			}
+		}
+	}
+	p.logger.Info("process repair shard task",
+		logger.String("params", string(task.Params)),
+	)
+	return nil
+}
+
+// repairFromPeer compares the digest of shard with peer, then pulls the data points of series which are missing in local from peer,
+// the index divergence is only reported, because the series without data don't need to be repaired.
+func (p *shardRepairProcessor) repairFromPeer(databaseName string, shard tsdb.Shard, peer *models.Node) error {
+	digest, err := shard.Digest()
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("db", databaseName)
+	params.Set("shard", strconv.Itoa(int(shard.ShardID())))
+	peerDigest := &tsdb.ShardDigest{}
+	if err := p.doRequest(http.MethodGet, peer, "/repair/digest", params, nil, peerDigest); err != nil {
+		return err
+	}
+	index, families := digest.Diff(peerDigest)
+	repairDivergenceCounter.WithLabelValues(databaseName, "index").Add(float64(len(index)))
+	repaired := 0
+	for _, family := range families {
+		repairDivergenceCounter.WithLabelValues(databaseName, "family").Add(float64(len(family.Metrics)))
+		for _, metric := range family.Metrics {
+			excludes, err := shard.SeriesSlots(family.FamilyTime, metric.Namespace, metric.MetricName)
+			if err != nil {
+				return err
+			}
+			body, err := json.Marshal(excludes)
+			if err != nil {
+				return err
+			}
+			metricParams := url.Values{}
+			for k, v := range params {
+				metricParams[k] = v
+			}
+			metricParams.Set("familyTime", strconv.FormatInt(family.FamilyTime, 10))
+			metricParams.Set("ns", metric.Namespace)
+			metricParams.Set("metric", metric.MetricName)
+			metricList := &pb.MetricList{}
+			if err := p.doRequest(http.MethodPost, peer, "/repair/series", metricParams, body, metricList); err != nil {
+				return err
+			}
+			if len(metricList.Metrics) == 0 {
+				continue
+			}
+			if err := shard.Backfill(metricList.Metrics); err != nil {
+				return err
+			}
+			repaired += len(metricList.Metrics)
+			repairMetricsCounter.WithLabelValues(databaseName).Add(float64(len(metricList.Metrics)))
+		}
+	}
+	p.logger.Info("repair shard from peer",
+		logger.String("db", databaseName),
+		logger.Int32("shard", shard.ShardID()),
+		logger.String("peer", peer.Indicator()),
+		logger.Int64("divergentIndex", int64(len(index))),
+		logger.Int64("divergentFamilies", int64(len(families))),
+		logger.Int64("repairedMetrics", int64(repaired)),
+	)
+	return nil
+}
+
+// doRequest does the http request to the repair api of peer, then decodes the response into result
+func (p *shardRepairProcessor) doRequest(method string, peer *models.Node, path string,
+	params url.Values, body []byte, result interface{},
+) error {
+	reqURL := fmt.Sprintf("%s://%s:%d%s?%s", p.scheme, peer.IP, peer.HTTPPort, path, params.Encode())
+	req, err := http.NewRequest(method, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := p.httpDo(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.logger.Error("close http response body", logger.Error(err))
+		}
+	}()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request peer[%s] failure, status: %d, body: %s", reqURL, resp.StatusCode, string(data))
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,162 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestShardRepairProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().ShardID().Return(int32(1)).AnyTimes()
+	processor := newShardRepairProcessor(storageService, "https", http.DefaultClient)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, time.Duration(0), processor.RetryBackOff())
+	assert.Equal(t, 0, processor.RetryCount())
+	assert.Equal(t, constants.RepairShard, processor.Kind())
+
+	// case 1: unmarshal param err
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.Error(t, err)
+	param := encoding.JSONMarshal(&models.ShardRepairTask{
+		DatabaseName: "db",
+		Shards: []models.ShardRepair{{
+			ShardID: 1,
+			Peers:   []models.Node{{IP: "1.1.1.2", Port: 9000, HTTPPort: 2891}},
+		}},
+	})
+	// case 2: shard not exist
+	storageService.EXPECT().GetShard("db", int32(1)).Return(nil, false)
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	storageService.EXPECT().GetShard("db", int32(1)).Return(shard, true).AnyTimes()
+	// case 3: local digest err
+	shard.EXPECT().Digest().Return(nil, fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+
+	localDigest := &tsdb.ShardDigest{
+		Families: []tsdb.FamilyDigest{{
+			FamilyTime: 10,
+			Metrics:    []tsdb.MetricDigest{{Namespace: "ns", MetricName: "cpu", Series: tsdb.SeriesDigest{Count: 1, Checksum: 5}}},
+		}},
+	}
+	peerDigest := &tsdb.ShardDigest{
+		Index: []tsdb.MetricDigest{{Namespace: "ns", MetricName: "cpu", Series: tsdb.SeriesDigest{Count: 2, Checksum: 12}}},
+		Families: []tsdb.FamilyDigest{{
+			FamilyTime: 10,
+			Metrics:    []tsdb.MetricDigest{{Namespace: "ns", MetricName: "cpu", Series: tsdb.SeriesDigest{Count: 2, Checksum: 12}}},
+		}},
+	}
+	shard.EXPECT().Digest().Return(localDigest, nil).AnyTimes()
+	newResponse := func(code int, body interface{}) *http.Response {
+		data, _ := json.Marshal(body)
+		return &http.Response{StatusCode: code, Body: ioutil.NopCloser(bytes.NewReader(data))}
+	}
+	// case 4: request peer digest err
+	processor.(*shardRepairProcessor).httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://1.1.1.2:2891/repair/digest", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+		return nil, fmt.Errorf("err")
+	}
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 5: peer digest response err
+	processor.(*shardRepairProcessor).httpDo = func(req *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusInternalServerError, "err"), nil
+	}
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 6: get local series slots err
+	processor.(*shardRepairProcessor).httpDo = func(req *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, peerDigest), nil
+	}
+	shard.EXPECT().SeriesSlots(int64(10), "ns", "cpu").Return(nil, fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 7: export series from peer err
+	shard.EXPECT().SeriesSlots(int64(10), "ns", "cpu").Return([]tsdb.SeriesSlots{{TagsHash: 5, Slots: []uint16{1}}}, nil).AnyTimes()
+	exported := &pb.MetricList{Metrics: []*pb.Metric{{
+		Namespace: "ns",
+		Name:      "cpu",
+		Timestamp: 10,
+		TagsHash:  7,
+		Tags:      map[string]string{"host": "1.1.1.2"},
+		Fields:    []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: 1}},
+	}}}
+	processor.(*shardRepairProcessor).httpDo = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/repair/digest" {
+			assert.Equal(t, "1.1.1.2:2891", req.URL.Host)
+			assert.Equal(t, "db", req.URL.Query().Get("db"))
+			assert.Equal(t, "1", req.URL.Query().Get("shard"))
+			return newResponse(http.StatusOK, peerDigest), nil
+		}
+		assert.Equal(t, "/repair/series", req.URL.Path)
+		assert.Equal(t, "10", req.URL.Query().Get("familyTime"))
+		assert.Equal(t, "ns", req.URL.Query().Get("ns"))
+		assert.Equal(t, "cpu", req.URL.Query().Get("metric"))
+		body, _ := ioutil.ReadAll(req.Body)
+		assert.Equal(t, `[{"tagsHash":5,"slots":[1]}]`, string(body))
+		return newResponse(http.StatusOK, exported), nil
+	}
+	shard.EXPECT().Backfill(gomock.Any()).Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.Error(t, err)
+	// case 8: repair shard
+	shard.EXPECT().Backfill(gomock.Any()).DoAndReturn(func(metrics []*pb.Metric) error {
+		assert.Equal(t, exported.Metrics, metrics)
+		return nil
+	})
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.NoError(t, err)
+	// case 9: nothing to repair
+	processor.(*shardRepairProcessor).httpDo = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/repair/digest" {
+			return newResponse(http.StatusOK, peerDigest), nil
+		}
+		return newResponse(http.StatusOK, &pb.MetricList{}), nil
+	}
+	err = processor.Process(context.TODO(), task.Task{Params: param})
+	assert.NoError(t, err)
+	// case 10: context canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err = processor.Process(ctx, task.Task{Params: param})
+	assert.Error(t, err)
+}
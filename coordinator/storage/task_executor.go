@@ -20,10 +20,12 @@ package storage
 import (
 	"context"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/task"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/tlsutil"
 	"github.com/lindb/lindb/service"
 )
 
@@ -38,23 +40,35 @@ type TaskExecutor struct {
 	log *logger.Logger
 }
 
-// NewTaskExecutor creates task executor
+// NewTaskExecutor creates task executor,
+// the http config is used by the processors which request the http api of other storage nodes.
 func NewTaskExecutor(ctx context.Context,
 	node *models.Node,
 	repo state.Repository,
-	storageService service.StorageService) *TaskExecutor {
+	storageService service.StorageService,
+	httpCfg config.StorageHTTP,
+) (*TaskExecutor, error) {
+	scheme, httpClient, err := tlsutil.NewHTTPClient(httpCfg.TLS, httpCfg.Timeout.Duration())
+	if err != nil {
+		return nil, err
+	}
 	executor := task.NewExecutor(ctx, node, repo)
 
 	// register task processor
 	executor.Register(newCreateShardProcessor(storageService))
 	executor.Register(newDatabaseFlushProcessor(storageService))
+	executor.Register(newDatabaseDropProcessor(storageService))
+	executor.Register(newMetricDropProcessor(storageService))
+	executor.Register(newSeriesDeleteProcessor(storageService))
+	executor.Register(newShardRepairProcessor(storageService, scheme, httpClient))
+	executor.Register(newDatabaseBackupProcessor(storageService))
 	return &TaskExecutor{
 		ctx:            ctx,
 		repo:           repo,
 		executor:       executor,
 		storageService: storageService,
 		log:            logger.GetLogger("coordinator", "StorageTaskExecutor"),
-	}
+	}, nil
 }
 
 // Run runs task executor, watches task assign and runs task process based on task kind in background
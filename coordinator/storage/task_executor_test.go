@@ -25,6 +25,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/service"
@@ -36,13 +37,18 @@ func TestTaskExecutor(t *testing.T) {
 
 	storageService := service.NewMockStorageService(ctrl)
 	repo := state.NewMockRepository(ctrl)
-	exec := NewTaskExecutor(context.TODO(), &models.Node{IP: "1.1.1.1", Port: 5000}, repo, storageService)
+	_, err := NewTaskExecutor(context.TODO(), &models.Node{IP: "1.1.1.1", Port: 5000}, repo, storageService,
+		config.StorageHTTP{TLS: config.TLS{Enable: true}})
+	assert.Error(t, err)
+	exec, err := NewTaskExecutor(context.TODO(), &models.Node{IP: "1.1.1.1", Port: 5000}, repo, storageService,
+		config.StorageHTTP{})
+	assert.NoError(t, err)
 	assert.NotNil(t, exec)
 
 	repo.EXPECT().WatchPrefix(gomock.Any(), gomock.Any(), true).Return(nil)
 	exec.Run()
 	time.Sleep(100 * time.Millisecond)
-	err := exec.Close()
+	err = exec.Close()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -20,6 +20,7 @@ package flow
 import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/pkg/concurrent"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source=./query_flow.go -destination=./query_flow_mock.go -package=flow
@@ -42,6 +43,8 @@ type StorageQueryFlow interface {
 	GetAggregator(highKey uint16) (agg aggregation.ContainerAggregator)
 	// Complete completes the query flow with error
 	Complete(err error)
+	// StorageInterval returns the interval of storage which query data from
+	StorageInterval() timeutil.Interval
 }
 
 // QueryTask represents query task for data search flow
@@ -35,19 +35,23 @@ type CompactJob interface {
 
 // compactJob represents the compaction job, merges input files
 type compactJob struct {
-	family Family
-	state  *compactionState
-	merger NewMerger
-	rollup Rollup
+	family    Family
+	state     *compactionState
+	merger    NewMerger
+	rollup    Rollup
+	tombstone Tombstone
+
+	corruptedFiles []table.FileNumber // corrupted input files which need quarantine
 }
 
 // newCompactJob creates a compaction job
 func newCompactJob(family Family, state *compactionState, rollup Rollup) CompactJob {
 	return &compactJob{
-		family: family,
-		merger: family.getNewMerger(),
-		state:  state,
-		rollup: rollup,
+		family:    family,
+		merger:    family.getNewMerger(),
+		state:     state,
+		rollup:    rollup,
+		tombstone: family.getTombstone(),
 	}
 }
 
@@ -55,7 +59,7 @@ func newCompactJob(family Family, state *compactionState, rollup Rollup) Compact
 func (c *compactJob) Run() error {
 	compaction := c.state.compaction
 	switch {
-	case compaction.IsTrivialMove():
+	case compaction.IsTrivialMove() && c.rollup == nil:
 		c.moveCompaction()
 	default:
 		if err := c.mergeCompaction(); err != nil {
@@ -93,6 +97,8 @@ func (c *compactJob) mergeCompaction() (err error) {
 	}
 	// if merge success install compaction results into manifest
 	c.installCompactionResults()
+	// corrupted input files are removed from manifest, then moves them into quarantine
+	c.quarantineCorruptedFiles()
 	return nil
 }
 
@@ -103,8 +109,15 @@ func (c *compactJob) doMerge() error {
 		return err
 	}
 	merger := c.merger()
+	params := make(map[string]interface{})
 	if c.rollup != nil {
-		merger.Init(map[string]interface{}{RollupContext: c.rollup})
+		params[RollupContext] = c.rollup
+	}
+	if c.tombstone != nil {
+		params[TombstoneContext] = c.tombstone
+	}
+	if len(params) > 0 {
+		merger.Init(params)
 	}
 
 	var needMerge [][]byte
@@ -122,7 +135,7 @@ func (c *compactJob) doMerge() error {
 			//FIXME stone1100 merge data maybe is one block
 
 			// 1. if new key != previous key do merge logic based on user define
-			mergedValue, err := merger.Merge(previousKey, needMerge)
+			mergedValue, err := c.merge(merger, previousKey, needMerge)
 			if err != nil {
 				return err
 			}
@@ -142,7 +155,7 @@ func (c *compactJob) doMerge() error {
 
 	// if has pending merge values after iterator, need do merge
 	if len(needMerge) > 0 {
-		mergedValue, err := merger.Merge(previousKey, needMerge)
+		mergedValue, err := c.merge(merger, previousKey, needMerge)
 		if err != nil {
 			return err
 		}
@@ -159,17 +172,32 @@ func (c *compactJob) doMerge() error {
 	return nil
 }
 
+// merge merges values for same key, purges all values if the key is deleted in tombstone
+func (c *compactJob) merge(merger Merger, key uint32, values [][]byte) ([]byte, error) {
+	if c.tombstone != nil && c.tombstone.IsDeleted(key) {
+		return nil, nil
+	}
+	return merger.Merge(key, values)
+}
+
 // installCompactionResults installs compactions results.
 // 1. mark input files is deletion which compaction job picked.
 // 2. add output files to up level.
 // 3. commit edit log for manifest.
+// NOTICE: if rollup job, input files belong to source family, so cannot mark input files for deletion,
+// and output files need rollup if target store has rollup relation.
 func (c *compactJob) installCompactionResults() {
-	// marks compaction input files for deletion
-	c.state.compaction.MarkInputDeletes()
+	if c.rollup == nil {
+		// marks compaction input files for deletion
+		c.state.compaction.MarkInputDeletes()
+	}
 	// adds compaction outputs
 	level := c.state.compaction.GetLevel()
 	for _, output := range c.state.outputs {
 		c.state.compaction.AddFile(level+1, output)
+		if c.rollup != nil {
+			c.family.addRollupFiles(c.state.compaction.GetEditLog(), output.GetFileNumber())
+		}
 	}
 	c.family.commitEditLog(c.state.compaction.GetEditLog())
 }
@@ -207,8 +235,21 @@ func (c *compactJob) makeInputIterator() (table.Iterator, error) {
 		files := c.state.compaction.GetInputs()[which]
 		if len(files) > 0 {
 			for _, fileMeta := range files {
-				reader, err := c.state.snapshot.GetReader(fileMeta.GetFileNumber())
+				fileNumber := fileMeta.GetFileNumber()
+				reader, err := c.state.snapshot.GetReader(fileNumber)
+				if err == nil {
+					// verify checksum of input file, make sure not merge corrupted data into new file
+					err = reader.Verify()
+				}
 				if err != nil {
+					// rollup job's input files belong to source family, cannot quarantine them in target family
+					if c.rollup == nil && table.IsCorrupted(err) {
+						kvLogger.Error("skip corrupted file when do compaction job",
+							logger.String("family", c.family.familyInfo()),
+							logger.Int64("file", fileNumber.Int64()), logger.Error(err))
+						c.corruptedFiles = append(c.corruptedFiles, fileNumber)
+						continue
+					}
 					return nil, err
 				}
 				its = append(its, reader.Iterator())
@@ -218,6 +259,21 @@ func (c *compactJob) makeInputIterator() (table.Iterator, error) {
 	return table.NewMergedIterator(its), nil
 }
 
+// quarantineCorruptedFiles moves the corrupted input files into quarantine dir
+func (c *compactJob) quarantineCorruptedFiles() {
+	for _, fileNumber := range c.corruptedFiles {
+		if err := c.family.quarantineFile(fileNumber); err != nil {
+			kvLogger.Error("quarantine corrupted file fail",
+				logger.String("family", c.family.familyInfo()),
+				logger.Int64("file", fileNumber.Int64()), logger.Error(err))
+			continue
+		}
+		kvLogger.Warn("quarantine corrupted file successfully",
+			logger.String("family", c.family.familyInfo()),
+			logger.Int64("file", fileNumber.Int64()))
+	}
+}
+
 // openCompactionOutputFile opens a new compaction store build, and adds the file number into pending output
 func (c *compactJob) openCompactionOutputFile() error {
 	//TODO add lock
@@ -19,6 +19,8 @@ package kv
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -27,6 +29,7 @@ import (
 
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
 )
 
 type mockAppendMerger struct {
@@ -102,6 +105,7 @@ func TestCompactJob_merge_compact_merge_fail(t *testing.T) {
 
 	snapshot := version.NewMockSnapshot(ctrl)
 	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Verify().Return(nil).AnyTimes()
 	gomock.InOrder(
 		reader.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
 			1: []byte("value1"),
@@ -142,7 +146,9 @@ func TestCompactJob_merge_doMerge_fail(t *testing.T) {
 
 	snapshot := version.NewMockSnapshot(ctrl)
 	reader1 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Verify().Return(nil).AnyTimes()
 	reader2 := table.NewMockReader(ctrl)
+	reader2.EXPECT().Verify().Return(nil).AnyTimes()
 	merge := NewMockMerger(ctrl)
 
 	// test new store build fail
@@ -287,7 +293,9 @@ func TestCompactJob_output_fail(t *testing.T) {
 
 	snapshot := version.NewMockSnapshot(ctrl)
 	reader1 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Verify().Return(nil).AnyTimes()
 	reader2 := table.NewMockReader(ctrl)
+	reader2.EXPECT().Verify().Return(nil).AnyTimes()
 	merge := NewMockMerger(ctrl)
 
 	// test store build is empty
@@ -373,6 +381,7 @@ func TestCompactJob_finish_output_fail(t *testing.T) {
 	snapshot := version.NewMockSnapshot(ctrl)
 	family := NewMockFamily(ctrl)
 	family.EXPECT().getNewMerger().Return(nil)
+	family.EXPECT().getTombstone().Return(nil)
 	compaction := version.NewCompaction(1, 0, nil, nil)
 	state := newCompactionState(1000, snapshot, compaction)
 	compact := newCompactJob(family, state, nil)
@@ -387,9 +396,13 @@ func TestCompactJob_merge_compact(t *testing.T) {
 
 	snapshot := version.NewMockSnapshot(ctrl)
 	reader1 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Verify().Return(nil).AnyTimes()
 	reader2 := table.NewMockReader(ctrl)
+	reader2.EXPECT().Verify().Return(nil).AnyTimes()
 	reader3 := table.NewMockReader(ctrl)
+	reader3.EXPECT().Verify().Return(nil).AnyTimes()
 	reader4 := table.NewMockReader(ctrl)
+	reader4.EXPECT().Verify().Return(nil).AnyTimes()
 	reader1.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
 		1:  []byte("value1"),
 		3:  []byte("value3"),
@@ -447,24 +460,150 @@ func TestCompactJob_merge_compact(t *testing.T) {
 		builder.EXPECT().Size().Return(int32(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(5)),
 	)
+	family.EXPECT().addRollupFiles(gomock.Any(), table.FileNumber(5))
 	err := compactJob.Run()
 	assert.NoError(t, err)
 	newFile := version.NewFileMeta(table.FileNumber(5), uint32(1), uint32(100), int32(10))
 	assert.Equal(t, 1, len(state.outputs))
 	assert.Equal(t, *newFile, *(state.outputs[0]))
+	// rollup job cannot delete input files of source family
 	editLog := state.compaction.GetEditLog()
 	logs := editLog.GetLogs()
-	assert.Equal(t, 5, len(logs))
-	assert.Equal(t, version.NewDeleteFile(0, 1), logs[0])
-	assert.Equal(t, version.NewDeleteFile(0, 2), logs[1])
-	assert.Equal(t, version.NewDeleteFile(1, 3), logs[2])
-	assert.Equal(t, version.NewDeleteFile(1, 4), logs[3])
-	assert.Equal(t, version.CreateNewFile(1, newFile), logs[4])
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, version.CreateNewFile(1, newFile), logs[0])
+}
+
+func TestCompactJob_merge_compact_tombstone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	reader1 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Verify().Return(nil).AnyTimes()
+	reader2 := table.NewMockReader(ctrl)
+	reader2.EXPECT().Verify().Return(nil).AnyTimes()
+	reader1.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
+		1:  []byte("value1"),
+		10: []byte("value10"),
+	}))
+	reader2.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
+		10: []byte("value10"),
+		30: []byte("value30"),
+	}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(2)).Return(reader2, nil)
+	tombstone := NewMockTombstone(ctrl)
+	tombstone.EXPECT().IsDeleted(uint32(1)).Return(false)
+	tombstone.EXPECT().IsDeleted(uint32(10)).Return(true)
+	tombstone.EXPECT().IsDeleted(uint32(30)).Return(false)
+	merger := NewMockMerger(ctrl)
+	merger.EXPECT().Init(map[string]interface{}{TombstoneContext: tombstone})
+	merger.EXPECT().Merge(uint32(1), gomock.Any()).Return([]byte("value1"), nil)
+	merger.EXPECT().Merge(uint32(30), gomock.Any()).Return([]byte("value30"), nil)
+	family := NewMockFamily(ctrl)
+	family.EXPECT().getNewMerger().Return(func() Merger { return merger })
+	family.EXPECT().getTombstone().Return(tombstone)
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	family.EXPECT().commitEditLog(gomock.Any()).Return(true)
+	f1 := version.NewFileMeta(1, 1, 10, 100)
+	f2 := version.NewFileMeta(2, 10, 30, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, []*version.FileMeta{f2})
+	state := newCompactionState(10000000, snapshot, compaction)
+	compactJob := newCompactJob(family, state, nil)
+	builder := table.NewMockBuilder(ctrl)
+	gomock.InOrder(
+		family.EXPECT().newTableBuilder().Return(builder, nil),
+		builder.EXPECT().FileNumber().Return(table.FileNumber(5)),
+		family.EXPECT().addPendingOutput(table.FileNumber(5)),
+		builder.EXPECT().Add(uint32(1), []byte("value1")).Return(nil),
+		builder.EXPECT().Size().Return(int32(10)),
+		// key 10 is deleted, purge it
+		builder.EXPECT().Add(uint32(30), []byte("value30")).Return(nil),
+		builder.EXPECT().Size().Return(int32(10)),
+		builder.EXPECT().Count().Return(uint64(2)),
+		builder.EXPECT().Close().Return(nil),
+		builder.EXPECT().FileNumber().Return(table.FileNumber(5)),
+		builder.EXPECT().MinKey().Return(uint32(1)),
+		builder.EXPECT().MaxKey().Return(uint32(30)),
+		builder.EXPECT().Size().Return(int32(10)),
+		family.EXPECT().removePendingOutput(table.FileNumber(5)),
+	)
+	err := compactJob.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(state.outputs))
+}
+
+func TestCompactJob_merge_compact_corrupted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	// generate corrupted error by reading an invalid sst file
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testKVPath, "000003.sst"), []byte("corrupted"), 0644))
+	_, corruptedErr := table.NewCache(testKVPath).GetReader("", "000003.sst")
+	assert.True(t, table.IsCorrupted(corruptedErr))
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	reader1 := table.NewMockReader(ctrl)
+	reader1.EXPECT().Verify().Return(nil).AnyTimes()
+	reader1.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{
+		1: []byte("value1"),
+	}))
+	reader2 := table.NewMockReader(ctrl)
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(2)).Return(reader2, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(3)).Return(nil, corruptedErr)
+	// checksum mismatch when verify file
+	reader2.EXPECT().Verify().Return(corruptedErr)
+	family := generateMockFamily(ctrl, newMockAppendMerger)
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	family.EXPECT().quarantineFile(table.FileNumber(2)).Return(nil)
+	family.EXPECT().quarantineFile(table.FileNumber(3)).Return(fmt.Errorf("err"))
+	f1 := version.NewFileMeta(1, 1, 10, 100)
+	f2 := version.NewFileMeta(2, 10, 30, 100)
+	f3 := version.NewFileMeta(3, 10, 30, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1, f2}, []*version.FileMeta{f3})
+	state := newCompactionState(10000000, snapshot, compaction)
+	compactJob := newCompactJob(family, state, nil)
+	builder := table.NewMockBuilder(ctrl)
+	gomock.InOrder(
+		family.EXPECT().newTableBuilder().Return(builder, nil),
+		builder.EXPECT().FileNumber().Return(table.FileNumber(5)),
+		family.EXPECT().addPendingOutput(table.FileNumber(5)),
+		builder.EXPECT().Add(uint32(1), []byte("value1")).Return(nil),
+		builder.EXPECT().Size().Return(int32(10)),
+		builder.EXPECT().Count().Return(uint64(1)),
+		builder.EXPECT().Close().Return(nil),
+		builder.EXPECT().FileNumber().Return(table.FileNumber(5)),
+		builder.EXPECT().MinKey().Return(uint32(1)),
+		builder.EXPECT().MaxKey().Return(uint32(1)),
+		builder.EXPECT().Size().Return(int32(10)),
+		family.EXPECT().removePendingOutput(table.FileNumber(5)),
+	)
+	err := compactJob.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(state.outputs))
+	// corrupted files removed from manifest
+	logs := state.compaction.GetEditLog().GetLogs()
+	assert.Contains(t, logs, version.NewDeleteFile(0, 2))
+	assert.Contains(t, logs, version.NewDeleteFile(1, 3))
+
+	// case 2: rollup job cannot skip corrupted file of source family
+	reader2.EXPECT().Verify().Return(corruptedErr)
+	snapshot.EXPECT().GetReader(table.FileNumber(2)).Return(reader2, nil)
+	compaction = version.NewCompaction(1, 0, []*version.FileMeta{f2}, nil)
+	state = newCompactionState(10000000, snapshot, compaction)
+	compactJob = newCompactJob(family, state, NewMockRollup(ctrl))
+	err = compactJob.Run()
+	assert.Error(t, err)
 }
 
 func generateMockFamily(ctrl *gomock.Controller, merger NewMerger) *MockFamily {
 	family := NewMockFamily(ctrl)
 	family.EXPECT().getNewMerger().Return(merger).AnyTimes()
+	family.EXPECT().getTombstone().Return(nil).AnyTimes()
 	family.EXPECT().Name().Return("test-family").AnyTimes()
 	family.EXPECT().commitEditLog(gomock.Any()).Return(true).AnyTimes()
 	return family
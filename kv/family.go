@@ -19,6 +19,7 @@ package kv
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
 
@@ -36,6 +37,7 @@ import (
 var (
 	newCompactJobFunc = newCompactJob
 	removeDirFunc     = fileutil.RemoveDir
+	renameFunc        = os.Rename
 )
 
 // Family implements column family for data isolation each family.
@@ -48,6 +50,8 @@ type Family interface {
 	NewFlusher() Flusher
 	// GetSnapshot returns current version's snapshot
 	GetSnapshot() version.Snapshot
+	// HasRollupFiles returns if the family has files which are not rolled up into the rollup target yet
+	HasRollupFiles() bool
 	// familyInfo return family info
 	familyInfo() string
 
@@ -67,8 +71,19 @@ type Family interface {
 	addPendingOutput(fileNumber table.FileNumber)
 	// removePendingOutput removes pending output file after compact or flush
 	removePendingOutput(fileNumber table.FileNumber)
+	// needRollup returns if need rollup source family data
+	needRollup() bool
+	// rollup does rollup in source family, need trigger target family does rollup compact job
+	rollup()
 	// doRollupWork does rollup job, merge source family data to target family
 	doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []table.FileNumber) (err error)
+	// addRollupFiles adds the new files into rollup files if store has rollup relation
+	addRollupFiles(editLog version.EditLog, fileNumbers ...table.FileNumber)
+	// getTombstone returns the tombstone of store which family belongs to
+	getTombstone() Tombstone
+
+	// quarantineFile moves the corrupted file into quarantine dir of family
+	quarantineFile(fileNumber table.FileNumber) error
 
 	// deleteObsoleteFiles deletes obsolete files
 	deleteObsoleteFiles()
@@ -249,6 +264,18 @@ func (f *family) deleteSST(fileNumber table.FileNumber) error {
 	return nil
 }
 
+// quarantineFile moves the corrupted file into quarantine dir of family,
+// keeps the file for manual recovery instead of deleting it.
+func (f *family) quarantineFile(fileNumber table.FileNumber) error {
+	f.store.evictFamilyFile(f.name, fileNumber)
+	quarantinePath := filepath.Join(f.familyPath, quarantineDir)
+	if err := mkDirFunc(quarantinePath); err != nil {
+		return err
+	}
+	fileName := version.Table(fileNumber)
+	return renameFunc(filepath.Join(f.familyPath, fileName), filepath.Join(quarantinePath, fileName))
+}
+
 // getFamilyVersion returns the family version
 func (f *family) getFamilyVersion() version.FamilyVersion {
 	return f.familyVersion
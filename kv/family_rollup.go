@@ -38,6 +38,27 @@ type Rollup interface {
 	GetTargetFamily(sourceFamilyName string) Family
 }
 
+// RollupRelation represents the rollup relation of store(source store => target store),
+// builds the rollup context for each source family.
+type RollupRelation interface {
+	// NewRollup creates the rollup context based on source family name
+	NewRollup(sourceFamilyName string) (Rollup, error)
+}
+
+// addRollupFiles adds the new files into rollup files if store has rollup relation
+func (f *family) addRollupFiles(editLog version.EditLog, fileNumbers ...table.FileNumber) {
+	for _, interval := range f.store.getRollupIntervals() {
+		for _, fileNumber := range fileNumbers {
+			editLog.Add(version.CreateNewRollupFile(fileNumber, interval))
+		}
+	}
+}
+
+// HasRollupFiles returns if the family has files which are not rolled up into the rollup target yet
+func (f *family) HasRollupFiles() bool {
+	return len(f.familyVersion.GetLiveRollupFiles()) > 0
+}
+
 // needRollup returns if need rollup source family data
 func (f *family) needRollup() bool {
 	if f.rolluping.Load() {
@@ -86,13 +107,20 @@ func (f *family) rollup() {
 		}
 
 		// do rollup job in target family
-		rollup, ok := f.store.getRollup(interval)
+		relation, ok := f.store.getRollup(interval)
 		if !ok {
 			kvLogger.Warn("skip rollup because cannot get target rollup",
 				logger.String("family", f.familyInfo()),
 				logger.Int64("interval", interval.Int64()))
 			return
 		}
+		rollup, err := relation.NewRollup(f.name)
+		if err != nil {
+			kvLogger.Error("create rollup context fail",
+				logger.String("family", f.familyInfo()),
+				logger.Int64("interval", interval.Int64()), logger.Error(err))
+			return
+		}
 		editLog := version.NewEditLog(f.ID())
 		targetFamily := rollup.GetTargetFamily(f.name)
 
@@ -100,7 +128,7 @@ func (f *family) rollup() {
 			kvLogger.Error("do rollup work fail",
 				logger.String("family", f.familyInfo()),
 				logger.Int64("interval", interval.Int64()),
-				logger.Any("files", sourceFiles))
+				logger.Any("files", sourceFiles), logger.Error(err))
 			return
 		}
 
@@ -150,7 +178,18 @@ func (f *family) doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []
 	defer func() {
 		snapshot.Close()
 	}()
-	compaction := version.NewCompaction(f.ID(), -1, nil, nil)
+	// source files maybe not alive in source family current version, because compaction job,
+	// but those files cannot be deleted before rollup job completed.
+	var inputs []*version.FileMeta
+	for file := range targetFiles {
+		inputs = append(inputs, version.NewFileMeta(file, 0, 0, 0))
+	}
+	// rollup output files add into level0 of target family
+	compaction := version.NewCompaction(f.ID(), -1, inputs, nil)
+	// add reference files, make sure source files cannot be rollup again
+	for file := range targetFiles {
+		compaction.GetEditLog().Add(version.CreateNewReferenceFile(sourceFamily.ID(), file))
+	}
 
 	compactionState := newCompactionState(f.maxFileSize, snapshot, compaction)
 	compactJob := newCompactJobFunc(f, compactionState, rollup)
@@ -61,6 +61,11 @@ func TestFamily_needRollup(t *testing.T) {
 	// case 4: need rollup
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10, 11: 10, 12: 10, 13: 10})
 	assert.True(t, f2.needRollup())
+
+	fv.EXPECT().GetLiveRollupFiles().Return(nil)
+	assert.False(t, f2.HasRollupFiles())
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10})
+	assert.True(t, f2.HasRollupFiles())
 }
 
 func TestFamily_rollup(t *testing.T) {
@@ -93,15 +98,21 @@ func TestFamily_rollup(t *testing.T) {
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	store.EXPECT().getRollup(timeutil.Interval(10)).Return(nil, false)
 	f2.rollup()
-	// case 4: do rollup err
+	// case 4: new rollup context err
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
+	relation := NewMockRollupRelation(ctrl)
 	rollup := NewMockRollup(ctrl)
 	tf := NewMockFamily(ctrl)
 	rollup.EXPECT().GetTargetFamily(gomock.Any()).Return(tf).AnyTimes()
-	store.EXPECT().getRollup(timeutil.Interval(10)).Return(rollup, true).AnyTimes()
+	store.EXPECT().getRollup(timeutil.Interval(10)).Return(relation, true).AnyTimes()
+	relation.EXPECT().NewRollup(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	f2.rollup()
+	// case 5: do rollup err
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
+	relation.EXPECT().NewRollup(gomock.Any()).Return(rollup, nil).AnyTimes()
 	tf.EXPECT().doRollupWork(f2, rollup, []table.FileNumber{10}).Return(fmt.Errorf("err"))
 	f2.rollup()
-	// case 5: rollup success
+	// case 6: rollup success
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	tf.EXPECT().doRollupWork(f2, rollup, []table.FileNumber{10}).Return(nil)
 	store.EXPECT().commitFamilyEditLog(gomock.Any(), gomock.Any()).Return(nil)
@@ -137,7 +148,22 @@ func TestFamily_doRollupWork(t *testing.T) {
 	snapshot := version.NewMockSnapshot(ctrl)
 	snapshot.EXPECT().Close().AnyTimes()
 	sf.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
-	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
+	rollup := NewMockRollup(ctrl)
+	store.EXPECT().getTombstone().Return(nil)
+	newCompactJobFunc = func(family Family, state *compactionState, rollup Rollup) CompactJob {
+		compaction := state.compaction
+		// rollup output files add into level0, and add reference file
+		assert.Equal(t, -1, compaction.GetLevel())
+		assert.Equal(t, []*version.FileMeta{version.NewFileMeta(20, 0, 0, 0)}, compaction.GetLevelFiles())
+		assert.Equal(t, version.CreateNewReferenceFile(10, 20), compaction.GetEditLog().GetLogs()[0])
+		return newCompactJob(family, state, rollup)
+	}
+	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Verify().Return(nil).AnyTimes()
+	snapshot.EXPECT().GetReader(table.FileNumber(20)).Return(reader, nil)
+	reader.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{}))
+	store.EXPECT().commitFamilyEditLog(gomock.Any(), gomock.Any()).Return(nil)
+	err = f2.doRollupWork(sf, rollup, []table.FileNumber{10, 20, 30})
 	assert.NoError(t, err)
 	// case 4: rollup job err
 	compactJob := NewMockCompactJob(ctrl)
@@ -145,6 +171,6 @@ func TestFamily_doRollupWork(t *testing.T) {
 		return compactJob
 	}
 	compactJob.EXPECT().Run().Return(fmt.Errorf("err"))
-	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
+	err = f2.doRollupWork(sf, rollup, []table.FileNumber{10, 20, 30})
 	assert.Error(t, err)
 }
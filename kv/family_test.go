@@ -19,6 +19,9 @@ package kv
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -276,3 +279,38 @@ func TestFamily_deleteObsoleteFiles(t *testing.T) {
 	}
 	f1.deleteObsoleteFiles()
 }
+
+func TestFamily_quarantineFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		mkDirFunc = fileutil.MkDir
+		renameFunc = os.Rename
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	store := NewMockStore(ctrl)
+	store.EXPECT().Option().Return(DefaultStoreOption(testKVPath)).AnyTimes()
+	store.EXPECT().createFamilyVersion(gomock.Any(), gomock.Any()).Return(version.NewMockFamilyVersion(ctrl))
+	store.EXPECT().evictFamilyFile(gomock.Any(), table.FileNumber(1)).AnyTimes()
+	f, err := newFamily(store, FamilyOption{Name: "f", Merger: "mockMerger"})
+	assert.NoError(t, err)
+	f1 := f.(*family)
+	fileName := version.Table(1)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(f1.familyPath, fileName), []byte("corrupted"), 0644))
+	// case 1: make quarantine dir err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, f1.quarantineFile(1))
+	mkDirFunc = fileutil.MkDir
+	// case 2: rename err
+	renameFunc = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, f1.quarantineFile(1))
+	renameFunc = os.Rename
+	// case 3: move file into quarantine dir
+	assert.NoError(t, f1.quarantineFile(1))
+	assert.False(t, fileutil.Exist(filepath.Join(f1.familyPath, fileName)))
+	assert.True(t, fileutil.Exist(filepath.Join(f1.familyPath, quarantineDir, fileName)))
+}
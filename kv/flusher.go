@@ -83,6 +83,8 @@ func (sf *storeFlusher) Commit() (err error) {
 
 		fileMeta := version.NewFileMeta(builder.FileNumber(), builder.MinKey(), builder.MaxKey(), builder.Size())
 		sf.editLog.Add(version.CreateNewFile(0, fileMeta))
+		// new file need rollup if store has rollup relation
+		sf.family.addRollupFiles(sf.editLog, fileMeta.GetFileNumber())
 	}
 
 	if flag := sf.family.commitEditLog(sf.editLog); !flag {
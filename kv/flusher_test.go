@@ -114,6 +114,7 @@ func TestStoreFlusher_Commit(t *testing.T) {
 		builder.EXPECT().MinKey().Return(uint32(1)),
 		builder.EXPECT().MaxKey().Return(uint32(10)),
 		builder.EXPECT().Size().Return(int32(100)),
+		family.EXPECT().addRollupFiles(gomock.Any(), table.FileNumber(10)),
 		family.EXPECT().commitEditLog(gomock.Any()).Return(false),
 		builder.EXPECT().FileNumber().Return(table.FileNumber(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(10)),
@@ -131,6 +132,7 @@ func TestStoreFlusher_Commit(t *testing.T) {
 		builder.EXPECT().MinKey().Return(uint32(1)),
 		builder.EXPECT().MaxKey().Return(uint32(10)),
 		builder.EXPECT().Size().Return(int32(100)),
+		family.EXPECT().addRollupFiles(gomock.Any(), table.FileNumber(10)),
 		family.EXPECT().commitEditLog(gomock.Any()).Return(true),
 		builder.EXPECT().FileNumber().Return(table.FileNumber(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(10)),
@@ -47,6 +47,7 @@ var (
 	mkDirFunc         = fileutil.MkDir
 	removeFunc        = os.Remove
 	newFileLockFunc   = lockers.NewFileLock
+	linkOrCopyFunc    = fileutil.LinkOrCopyFile
 )
 
 // Store is kv store, supporting column family, but is different from other LSM implementation.
@@ -58,10 +59,18 @@ type Store interface {
 	GetFamily(familyName string) Family
 	// ListFamilyNames returns the all family's name
 	ListFamilyNames() []string
+	// DropFamily drops family and removes all family's data files, does nothing if family not exist.
+	DropFamily(familyName string) error
 	// Option returns the store configuration options
 	Option() StoreOption
-	// RegisterRollup registers the rollup source/target relation
-	RegisterRollup(interval timeutil.Interval, rollup Rollup)
+	// RegisterRollup registers the rollup source/target relation,
+	// NOTICE: only supports one target interval for each new file.
+	RegisterRollup(interval timeutil.Interval, relation RollupRelation)
+	// RegisterTombstone registers the tombstone of store, compaction job purges deleted data based on it
+	RegisterTombstone(tombstone Tombstone)
+	// Checkpoint creates a consistent checkpoint of store into the path which not exist,
+	// the checkpoint can be opened as a kv store directly.
+	Checkpoint(path string) error
 	// Close closes store, then release some resource
 	Close() error
 
@@ -75,7 +84,11 @@ type Store interface {
 	// evictFamilyFile evicts family file reader from cache
 	evictFamilyFile(name string, fileNumber table.FileNumber)
 	// getRollup returns the rollup relation by interval
-	getRollup(interval timeutil.Interval) (Rollup, bool)
+	getRollup(interval timeutil.Interval) (RollupRelation, bool)
+	// getRollupIntervals returns the target intervals of all rollup relations
+	getRollupIntervals() []timeutil.Interval
+	// getTombstone returns the tombstone of store, returns nil if not register
+	getTombstone() Tombstone
 }
 
 // store implements Store interface
@@ -94,7 +107,8 @@ type store struct {
 	storeInfo *storeInfo
 	cache     table.Cache
 
-	rollupRelations map[timeutil.Interval]Rollup // save target kv store for rollup job
+	rollupRelations map[timeutil.Interval]RollupRelation // save target kv store for rollup job
+	tombstone       Tombstone                            // deleted data of store
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -240,18 +254,55 @@ func (s *store) ListFamilyNames() []string {
 	return result
 }
 
+// DropFamily drops family and removes all family's data files, does nothing if family not exist.
+func (s *store) DropFamily(familyName string) error {
+	s.rwMutex.Lock()
+	defer s.rwMutex.Unlock()
+
+	family, ok := s.families[familyName]
+	if !ok {
+		return nil
+	}
+	files := family.getFamilyVersion().GetAllActiveFiles()
+	// 1. drop family version from version set, make sure manifest file not include dropped family
+	if err := s.versions.DropFamilyVersion(familyName); err != nil {
+		return fmt.Errorf("drop family version for family[%s] error:%s", familyName, err)
+	}
+	// 2. remove family option from store info
+	familyOption := s.storeInfo.Families[familyName]
+	delete(s.storeInfo.Families, familyName)
+	if err := s.dumpStoreInfo(); err != nil {
+		s.storeInfo.Families[familyName] = familyOption
+		return err
+	}
+	delete(s.families, familyName)
+	// 3. evict file readers from cache, then remove family's data files
+	for _, file := range files {
+		s.evictFamilyFile(familyName, file.GetFileNumber())
+	}
+	if err := removeDirFunc(filepath.Join(s.option.Path, familyName)); err != nil {
+		kvLogger.Error("remove dropped family path error",
+			logger.String("store", s.option.Path), logger.String("family", familyName), logger.Error(err))
+	}
+	// 4. delete old manifest file
+	s.deleteObsoleteFiles()
+	kvLogger.Info("drop family successfully",
+		logger.String("store", s.option.Path), logger.String("family", familyName))
+	return nil
+}
+
 // Option returns the store configuration options
 func (s *store) Option() StoreOption {
 	return s.option
 }
 
 // RegisterRollup registers the rollup source/target relation
-func (s *store) RegisterRollup(interval timeutil.Interval, rollup Rollup) {
+func (s *store) RegisterRollup(interval timeutil.Interval, relation RollupRelation) {
 	s.rwMutex.Lock()
 	defer s.rwMutex.Unlock()
 
 	if s.rollupRelations == nil {
-		s.rollupRelations = make(map[timeutil.Interval]Rollup)
+		s.rollupRelations = make(map[timeutil.Interval]RollupRelation)
 	}
 	_, ok := s.rollupRelations[interval]
 	if ok {
@@ -260,7 +311,63 @@ func (s *store) RegisterRollup(interval timeutil.Interval, rollup Rollup) {
 			logger.Any("interval", interval))
 		return
 	}
-	s.rollupRelations[interval] = rollup
+	s.rollupRelations[interval] = relation
+}
+
+// RegisterTombstone registers the tombstone of store, compaction job purges deleted data based on it
+func (s *store) RegisterTombstone(tombstone Tombstone) {
+	s.rwMutex.Lock()
+	defer s.rwMutex.Unlock()
+
+	s.tombstone = tombstone
+}
+
+// Checkpoint creates a consistent checkpoint of store into the path which not exist,
+// includes store info(OPTIONS), the manifest of current versions and the sst files referenced by the manifest,
+// the sst files are hard linked into checkpoint(copied if hard link fails), so it's cheap on same device.
+// The checkpoint can be opened as a kv store directly.
+func (s *store) Checkpoint(path string) error {
+	if fileutil.Exist(path) {
+		return fmt.Errorf("checkpoint path[%s] already exist", path)
+	}
+	if err := mkDirFunc(path); err != nil {
+		return fmt.Errorf("create checkpoint path error:%s", err)
+	}
+	// hold read lock, make sure no family created/dropped between manifest and store info
+	s.rwMutex.RLock()
+	snapshots, err := s.versions.Checkpoint(path)
+	if err == nil {
+		err = encodeTomlFunc(filepath.Join(path, version.Options), s.storeInfo)
+	}
+	s.rwMutex.RUnlock()
+	if err != nil {
+		for _, snapshot := range snapshots {
+			snapshot.Close()
+		}
+		return err
+	}
+	defer func() {
+		for _, snapshot := range snapshots {
+			snapshot.Close()
+		}
+	}()
+	for familyName, snapshot := range snapshots {
+		familyPath := filepath.Join(path, familyName)
+		if err := mkDirFunc(familyPath); err != nil {
+			return fmt.Errorf("create checkpoint path of family[%s] error:%s", familyName, err)
+		}
+		for _, file := range snapshot.GetCurrent().GetAllFiles() {
+			fileName := version.Table(file.GetFileNumber())
+			if err := linkOrCopyFunc(
+				filepath.Join(s.option.Path, familyName, fileName),
+				filepath.Join(familyPath, fileName)); err != nil {
+				return fmt.Errorf("checkpoint file[%s] of family[%s] error:%s", fileName, familyName, err)
+			}
+		}
+	}
+	kvLogger.Info("create checkpoint of store successfully",
+		logger.String("store", s.option.Path), logger.String("checkpoint", path))
+	return nil
 }
 
 // Close closes store, then release some resource
@@ -283,9 +390,32 @@ func (s *store) evictFamilyFile(name string, fileNumber table.FileNumber) {
 }
 
 // getRollup returns the rollup relation by interval
-func (s *store) getRollup(interval timeutil.Interval) (Rollup, bool) {
-	rollup, ok := s.rollupRelations[interval]
-	return rollup, ok
+func (s *store) getRollup(interval timeutil.Interval) (RollupRelation, bool) {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	relation, ok := s.rollupRelations[interval]
+	return relation, ok
+}
+
+// getRollupIntervals returns the target intervals of all rollup relations
+func (s *store) getRollupIntervals() []timeutil.Interval {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	var intervals []timeutil.Interval
+	for interval := range s.rollupRelations {
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+// getTombstone returns the tombstone of store, returns nil if not register
+func (s *store) getTombstone() Tombstone {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	return s.tombstone
 }
 
 // createFamilyVersion creates family version using family name and family id,
@@ -345,6 +475,10 @@ func (s *store) compact() {
 	}
 	s.rwMutex.RUnlock()
 	for _, family := range families {
+		// do rollup job first, because compaction job maybe removes the files which need rollup
+		if family.needRollup() {
+			family.rollup()
+		}
 		if family.needCompat() {
 			family.compact()
 		}
@@ -33,6 +33,7 @@ import (
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/lockers"
 	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var testKVPath = "./test_data"
@@ -188,6 +189,63 @@ func TestStore_CreateFamily(t *testing.T) {
 	assert.Equal(t, "f", names[0])
 }
 
+func TestStore_DropFamily(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	defer func() {
+		encodeTomlFunc = ltoml.EncodeToml
+		removeDirFunc = fileutil.RemoveDir
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+	_, err = kv.CreateFamily("f2", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	// case 1: family not exist
+	err = kv.DropFamily("f3")
+	assert.NoError(t, err)
+	// case 2: dump store info err
+	encodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	err = kv.DropFamily("f2")
+	assert.Error(t, err)
+	assert.NotNil(t, kv.GetFamily("f2"))
+	encodeTomlFunc = ltoml.EncodeToml
+	// case 3: remove family dir err
+	removeDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	err = kv.DropFamily("f2")
+	assert.NoError(t, err)
+	assert.Nil(t, kv.GetFamily("f2"))
+	removeDirFunc = fileutil.RemoveDir
+	// case 4: drop family
+	err = kv.DropFamily("f")
+	assert.NoError(t, err)
+	assert.Nil(t, kv.GetFamily("f"))
+	assert.False(t, fileutil.Exist(filepath.Join(testKVPath, "f")))
+	err = kv.Close()
+	assert.NoError(t, err)
+	// case 5: reopen store without dropped family
+	kv, err = NewStore("test_kv", option)
+	assert.NoError(t, err)
+	assert.Empty(t, kv.ListFamilyNames())
+	// case 6: create family again after drop
+	f, err = kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	snapshot := f.GetSnapshot()
+	assert.Empty(t, snapshot.GetCurrent().GetAllFiles())
+	snapshot.Close()
+	err = kv.Close()
+	assert.NoError(t, err)
+}
+
 func TestStore_deleteObsoleteFiles(t *testing.T) {
 	option := DefaultStoreOption(testKVPath)
 	defer func() {
@@ -289,10 +347,88 @@ func TestStore_RegisterRollup(t *testing.T) {
 
 	kv, err := NewStore("test_kv", option)
 	assert.NoError(t, err)
-	rollup := NewMockRollup(ctrl)
-	kv.RegisterRollup(10, rollup)
-	kv.RegisterRollup(10, rollup) // reject
-	rollup2, ok := kv.getRollup(10)
+	relation := NewMockRollupRelation(ctrl)
+	kv.RegisterRollup(10, relation)
+	kv.RegisterRollup(10, relation) // reject
+	relation2, ok := kv.getRollup(10)
 	assert.True(t, ok)
-	assert.Equal(t, rollup, rollup2)
+	assert.Equal(t, relation, relation2)
+	assert.Equal(t, []timeutil.Interval{10}, kv.getRollupIntervals())
+	_ = kv.Close()
+}
+
+func TestStore_Checkpoint(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	checkpointPath := "./test_checkpoint"
+	defer func() {
+		encodeTomlFunc = ltoml.EncodeToml
+		mkDirFunc = fileutil.MkDir
+		linkOrCopyFunc = fileutil.LinkOrCopyFile
+		_ = fileutil.RemoveDir(testKVPath)
+		_ = fileutil.RemoveDir(checkpointPath)
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+	_, err = kv.CreateFamily("f2", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+
+	// case 1: checkpoint path exist
+	err = kv.Checkpoint(testKVPath)
+	assert.Error(t, err)
+	// case 2: make checkpoint dir err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	err = kv.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	mkDirFunc = fileutil.MkDir
+	// case 3: dump store info err
+	encodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	err = kv.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	encodeTomlFunc = ltoml.EncodeToml
+	_ = fileutil.RemoveDir(checkpointPath)
+	// case 4: link file err
+	linkOrCopyFunc = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	err = kv.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	linkOrCopyFunc = fileutil.LinkOrCopyFile
+	_ = fileutil.RemoveDir(checkpointPath)
+	// case 5: create checkpoint
+	err = kv.Checkpoint(checkpointPath)
+	assert.NoError(t, err)
+	// write new data after checkpoint
+	flusher = f.NewFlusher()
+	_ = flusher.Add(2, []byte("test2"))
+	assert.NoError(t, flusher.Commit())
+	err = kv.Close()
+	assert.NoError(t, err)
+
+	// case 6: open checkpoint as kv store
+	kv, err = NewStore("test_checkpoint", DefaultStoreOption(checkpointPath))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"f", "f2"}, kv.ListFamilyNames())
+	snapshot := kv.GetFamily("f").GetSnapshot()
+	readers, err := snapshot.FindReaders(1)
+	assert.NoError(t, err)
+	assert.Len(t, readers, 1)
+	value, err := readers[0].Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
+	readers, err = snapshot.FindReaders(2)
+	assert.NoError(t, err)
+	assert.Empty(t, readers)
+	snapshot.Close()
+	err = kv.Close()
+	assert.NoError(t, err)
 }
@@ -20,6 +20,7 @@ package table
 import (
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 
 	"github.com/lindb/roaring"
 
@@ -76,7 +77,8 @@ type storeBuilder struct {
 	minKey uint32
 	maxKey uint32
 
-	first bool
+	first   bool
+	scratch []byte // buffer for block data + checksum
 }
 
 // NewStoreBuilder creates store builder instance for building store file
@@ -111,7 +113,7 @@ func (b *storeBuilder) Add(key uint32, value []byte) error {
 
 	// get write offset
 	offset := b.writer.Size()
-	if _, err := b.writer.Write(value); err != nil {
+	if err := b.writeBlock(value); err != nil {
 		return fmt.Errorf("write data into store file error:%s", err)
 	}
 	// add offset into offset buffer
@@ -161,7 +163,7 @@ func (b *storeBuilder) Close() error {
 	}
 	posOfOffset := b.writer.Size()
 	offset := b.offset.MarshalBinary()
-	if _, err := b.writer.Write(offset); err != nil {
+	if err := b.writeBlock(offset); err != nil {
 		return err
 	}
 
@@ -171,7 +173,7 @@ func (b *storeBuilder) Close() error {
 		return err
 	}
 	posOfKeys := b.writer.Size()
-	if _, err = b.writer.Write(keys); err != nil {
+	if err = b.writeBlock(keys); err != nil {
 		return err
 	}
 
@@ -179,10 +181,20 @@ func (b *storeBuilder) Close() error {
 	var buf [17]byte
 	binary.LittleEndian.PutUint32(buf[:4], uint32(posOfOffset))
 	binary.LittleEndian.PutUint32(buf[4:8], uint32(posOfKeys))
-	buf[8] = version0
+	buf[8] = currentVersion
 	binary.LittleEndian.PutUint64(buf[9:], magicNumberOffsetFile)
 	if _, err = b.writer.Write(buf[:]); err != nil {
 		return err
 	}
 	return b.writer.Close()
 }
+
+// writeBlock writes block data with crc32c checksum(data+checksum(4))
+func (b *storeBuilder) writeBlock(data []byte) error {
+	b.scratch = append(b.scratch[:0], data...)
+	var checksum [checksumSize]byte
+	binary.LittleEndian.PutUint32(checksum[:], crc32.Checksum(data, crc32cTable))
+	b.scratch = append(b.scratch, checksum[:]...)
+	_, err := b.writer.Write(b.scratch)
+	return err
+}
@@ -100,7 +100,7 @@ func TestStoreBuilder_Build_Err(t *testing.T) {
 	err = builder.Close()
 	assert.Equal(t, ErrEmptyKeys, err)
 	// case 3: close write offset err
-	writer.EXPECT().Write([]byte{1, 2, 3}).Return(10, nil)
+	writer.EXPECT().Write(gomock.Any()).Return(10, nil) // write value with checksum
 	writer.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("err"))
 	err = builder.Add(10, []byte{1, 2, 3})
 	assert.NoError(t, err)
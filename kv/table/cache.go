@@ -18,14 +18,17 @@
 package table
 
 import (
+	"container/list"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/monitoring"
 	"github.com/lindb/lindb/pkg/logger"
 )
 
-//FIXME store100 using lru cache?????
-
 //go:generate mockgen -source ./cache.go -destination=./cache_mock.go -package table
 
 // for test
@@ -33,77 +36,328 @@ var (
 	newMMapStoreReaderFunc = newMMapStoreReader
 )
 
+const (
+	defaultMaxCachedFiles     = 1024
+	defaultMaxCachedIndexSize = 512 * 1024 * 1024 // 512MB
+)
+
+var (
+	cacheHitCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_cache_hit",
+			Help: "Get table reader hit from cache.",
+		},
+	)
+	cacheMissCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_cache_miss",
+			Help: "Get table reader miss from cache, need open new reader.",
+		},
+	)
+	cacheEvictCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_cache_evict",
+			Help: "Evict table reader from cache.",
+		},
+	)
+	cacheFilesGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kv_table_cache_files",
+			Help: "Num. of table readers in cache.",
+		},
+	)
+	cacheIndexSizeGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kv_table_cache_index_size",
+			Help: "Memory size of index block of table readers in cache.",
+		},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(cacheHitCounter)
+	monitoring.StorageRegistry.MustRegister(cacheMissCounter)
+	monitoring.StorageRegistry.MustRegister(cacheEvictCounter)
+	monitoring.StorageRegistry.MustRegister(cacheFilesGauge)
+	monitoring.StorageRegistry.MustRegister(cacheIndexSizeGauge)
+}
+
+// readerCache is the table reader cache shared by all kv stores of current node
+var readerCache = newLRUCache(CacheOption{})
+
+// CacheOption represents the capacity of table reader cache,
+// use default value if option item <= 0.
+type CacheOption struct {
+	MaxFiles     int   // max num. of table readers in cache
+	MaxIndexSize int64 // max memory size of index block(keys/offsets) in cache
+}
+
+// SetCacheOption sets the capacity of table reader cache shared by all kv stores
+func SetCacheOption(option CacheOption) {
+	readerCache.setOption(option)
+}
+
 // Cache caches table readers
 type Cache interface {
 	// GetReader returns store reader from cache, create new reader if not exist.
+	// NOTICE: reader is retained after getting, must release it after reading.
 	GetReader(family string, fileName string) (Reader, error)
+	// Release releases the reader which get from cache,
+	// closes the reader if it is evicted and not used by others.
+	Release(reader Reader)
 	// Evict evicts file reader from cache
 	Evict(family string, fileName string)
 	// Close cleans cache data after closing reader resource firstly
 	Close() error
 }
 
-// Cache caches table readers based on map
-type mapCache struct {
+// storeCache caches table readers of kv store based on shared lru cache
+type storeCache struct {
 	storePath string
-	readers   map[string]Reader
-	mutex     sync.Mutex
+	cache     *lruCache
 }
 
 // NewCache creates cache for store readers
 func NewCache(storePath string) Cache {
-	return &mapCache{
+	return &storeCache{
 		storePath: storePath,
-		readers:   make(map[string]Reader),
+		cache:     readerCache,
 	}
 }
 
+// GetReader returns store reader from cache, create new reader if not exist
+func (c *storeCache) GetReader(family string, fileName string) (Reader, error) {
+	return c.cache.acquire(filepath.Join(c.storePath, family, fileName))
+}
+
+// Release releases the reader which get from cache
+func (c *storeCache) Release(reader Reader) {
+	c.cache.release(reader)
+}
+
 // Evict evicts file reader from cache
-func (c *mapCache) Evict(family string, fileName string) {
-	filePath := filepath.Join(family, fileName)
+func (c *storeCache) Evict(family string, fileName string) {
+	c.cache.evict(filepath.Join(c.storePath, family, fileName))
+}
+
+// Close evicts all readers of kv store from cache.
+func (c *storeCache) Close() error {
+	c.cache.evictPrefix(c.storePath + string(filepath.Separator))
+	return nil
+}
+
+// readerEntry represents the cached reader with reference count
+type readerEntry struct {
+	path    string
+	reader  Reader
+	size    int64 // memory size of index block
+	ref     int32
+	evicted bool
+	elem    *list.Element
+}
+
+// pendingEntry represents the reader which is opening, others wait it done instead of opening the same file.
+type pendingEntry struct {
+	done    chan struct{}
+	err     error
+	evicted bool // evicted when opening, don't put the reader into cache
+}
+
+// lruCache caches table readers with lru eviction, bounded by num. of files and memory size of index block.
+// Reader is closed after evicting only if it isn't used by others, else closes it when last user releases it.
+type lruCache struct {
+	option    CacheOption
+	entries   map[string]*readerEntry  // path => cached reader entry
+	pending   map[string]*pendingEntry // path => reader which is opening
+	readers   map[Reader]*readerEntry  // reader => entry, include evicted entry which still used
+	lru       *list.List               // front is the most recently used entry
+	indexSize int64
+	mutex     sync.Mutex
+}
+
+// newLRUCache creates the lru cache for table readers
+func newLRUCache(option CacheOption) *lruCache {
+	c := &lruCache{
+		entries: make(map[string]*readerEntry),
+		pending: make(map[string]*pendingEntry),
+		readers: make(map[Reader]*readerEntry),
+		lru:     list.New(),
+	}
+	c.setOption(option)
+	return c
+}
+
+// setOption sets the capacity of cache, evicts readers if cache is full
+func (c *lruCache) setOption(option CacheOption) {
+	if option.MaxFiles <= 0 {
+		option.MaxFiles = defaultMaxCachedFiles
+	}
+	if option.MaxIndexSize <= 0 {
+		option.MaxIndexSize = defaultMaxCachedIndexSize
+	}
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	reader, ok := c.readers[filePath]
-	if ok {
-		if err := reader.Close(); err != nil {
-			tableLogger.Error("close store reader error",
-				logger.String("path", c.storePath),
-				logger.String("file", filePath), logger.Error(err))
+
+	c.option = option
+	c.evictIfFull()
+	c.updateGauge()
+}
+
+// acquire returns the reader by file path and retains it, creates new reader if not exist.
+// Opens the reader without holding the lock, so that opening a file doesn't block others.
+func (c *lruCache) acquire(path string) (Reader, error) {
+	for {
+		c.mutex.Lock()
+		// find from cache
+		entry, ok := c.entries[path]
+		if ok {
+			cacheHitCounter.Inc()
+			entry.ref++
+			c.lru.MoveToFront(entry.elem)
+			c.mutex.Unlock()
+			return entry.reader, nil
 		}
-		delete(c.readers, filePath)
+		// wait the reader which is opening by others, then find from cache again
+		if p, ok := c.pending[path]; ok {
+			c.mutex.Unlock()
+			<-p.done
+			if p.err != nil {
+				return nil, p.err
+			}
+			continue
+		}
+		cacheMissCounter.Inc()
+		p := &pendingEntry{done: make(chan struct{})}
+		c.pending[path] = p
+		c.mutex.Unlock()
+
+		return c.open(path, p)
 	}
 }
 
-// GetReader returns store reader from cache, create new reader if not exist
-func (c *mapCache) GetReader(family string, fileName string) (Reader, error) {
-	filePath := filepath.Join(family, fileName)
+// open creates new reader without lock, then puts it into cache if the file isn't evicted when opening
+func (c *lruCache) open(path string, p *pendingEntry) (Reader, error) {
+	reader, err := newMMapStoreReaderFunc(path)
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	// find from cache
-	reader, ok := c.readers[filePath]
-	if ok {
-		return reader, nil
+	if c.pending[path] == p {
+		delete(c.pending, path)
 	}
-
-	// create new reader
-	path := filepath.Join(c.storePath, filePath)
-	newReader, err := newMMapStoreReaderFunc(path)
+	p.err = err
+	close(p.done)
 	if err != nil {
 		return nil, err
 	}
-	c.readers[filePath] = newReader
-	return newReader, nil
+	// re-check after opening, file may be evicted by others when opening,
+	// so don't put the reader into cache, closes it after released.
+	if p.evicted {
+		c.readers[reader] = &readerEntry{path: path, reader: reader, ref: 1, evicted: true}
+		return reader, nil
+	}
+	entry := &readerEntry{
+		path:   path,
+		reader: reader,
+		size:   reader.MemSize(),
+		ref:    1,
+	}
+	entry.elem = c.lru.PushFront(entry)
+	c.entries[path] = entry
+	c.readers[reader] = entry
+	c.indexSize += entry.size
+	c.evictIfFull()
+	c.updateGauge()
+	return reader, nil
+}
+
+// release releases the reader, closes it if evicted and not used by others
+func (c *lruCache) release(reader Reader) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.readers[reader]
+	if !ok {
+		return
+	}
+	if entry.ref > 0 {
+		entry.ref--
+	}
+	if entry.evicted && entry.ref == 0 {
+		c.closeReader(entry)
+	}
+}
+
+// evict evicts the reader by file path
+func (c *lruCache) evict(path string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, ok := c.entries[path]; ok {
+		c.removeEntry(entry)
+		c.updateGauge()
+	}
+	c.evictPending(path)
 }
 
-// Close closes reader resource and cleans cache data.
-func (c *mapCache) Close() error {
-	for k, v := range c.readers {
-		if err := v.Close(); err != nil {
-			tableLogger.Error("close store reader error",
-				logger.String("path", c.storePath),
-				logger.String("file", k), logger.Error(err))
+// evictPrefix evicts all readers which file path has the prefix
+func (c *lruCache) evictPrefix(prefix string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for path, entry := range c.entries {
+		if strings.HasPrefix(path, prefix) {
+			c.removeEntry(entry)
 		}
 	}
-	return nil
+	for path := range c.pending {
+		if strings.HasPrefix(path, prefix) {
+			c.evictPending(path)
+		}
+	}
+	c.updateGauge()
+}
+
+// evictPending marks the reader which is opening as evicted, the reader will not be put into cache
+func (c *lruCache) evictPending(path string) {
+	if p, ok := c.pending[path]; ok {
+		p.evicted = true
+		delete(c.pending, path)
+	}
+}
+
+// evictIfFull evicts the least recently used readers until cache isn't full,
+// keeps the most recently used reader at least.
+func (c *lruCache) evictIfFull() {
+	for c.lru.Len() > 1 && (c.lru.Len() > c.option.MaxFiles || c.indexSize > c.option.MaxIndexSize) {
+		entry := c.lru.Back().Value.(*readerEntry)
+		c.removeEntry(entry)
+	}
+}
+
+// removeEntry removes the entry from cache, closes the reader if not used
+func (c *lruCache) removeEntry(entry *readerEntry) {
+	cacheEvictCounter.Inc()
+	c.lru.Remove(entry.elem)
+	delete(c.entries, entry.path)
+	c.indexSize -= entry.size
+	entry.evicted = true
+	if entry.ref == 0 {
+		c.closeReader(entry)
+	}
+}
+
+// closeReader closes the reader of evicted entry
+func (c *lruCache) closeReader(entry *readerEntry) {
+	delete(c.readers, entry.reader)
+	if err := entry.reader.Close(); err != nil {
+		tableLogger.Error("close store reader error",
+			logger.String("file", entry.path), logger.Error(err))
+	}
+}
+
+// updateGauge updates the gauge metric of cache
+func (c *lruCache) updateGauge() {
+	cacheFilesGauge.Set(float64(c.lru.Len()))
+	cacheIndexSizeGauge.Set(float64(c.indexSize))
 }
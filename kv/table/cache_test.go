@@ -20,14 +20,16 @@ package table
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/pkg/fileutil"
 )
 
-func TestMapCache_GetReader(t *testing.T) {
+func TestStoreCache_GetReader(t *testing.T) {
 	_ = fileutil.MkDirIfNotExist(testKVPath)
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -45,6 +47,7 @@ func TestMapCache_GetReader(t *testing.T) {
 	assert.Nil(t, r)
 	// case 2: get reader success
 	mockReader := NewMockReader(ctrl)
+	mockReader.EXPECT().MemSize().Return(int64(100)).AnyTimes()
 	newMMapStoreReaderFunc = func(path string) (reader Reader, err error) {
 		return mockReader, nil
 	}
@@ -58,13 +61,212 @@ func TestMapCache_GetReader(t *testing.T) {
 	// case 4: evict not exist
 	cache.Evict("f", "200000.sst")
 	cache.Evict("f1", "100000.sst")
-	// case 5: evict reader err
-	mockReader.EXPECT().Close().Return(fmt.Errorf("err"))
+	// case 5: evict reader which is used, close it after released
 	cache.Evict("f", "100000.sst")
-	// case 6: close err
-	mockReader.EXPECT().Close().Return(fmt.Errorf("err")).MaxTimes(2)
-	_, _ = cache.GetReader("f", "100000.sst")
-	_, _ = cache.GetReader("f", "200000.sst")
+	cache.Release(r)
+	mockReader.EXPECT().Close().Return(fmt.Errorf("err"))
+	cache.Release(r)
+	// release not exist reader
+	cache.Release(r)
+	// case 6: close store cache
+	mockReader2 := NewMockReader(ctrl)
+	mockReader2.EXPECT().MemSize().Return(int64(100)).AnyTimes()
+	newMMapStoreReaderFunc = func(path string) (reader Reader, err error) {
+		return mockReader2, nil
+	}
+	r, _ = cache.GetReader("f", "100000.sst")
+	cache.Release(r)
+	mockReader2.EXPECT().Close().Return(nil)
 	err = cache.Close()
 	assert.NoError(t, err)
 }
+
+func TestLRUCache_evict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		ctrl.Finish()
+	}()
+	readers := make(map[string]*MockReader)
+	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+		reader := NewMockReader(ctrl)
+		reader.EXPECT().MemSize().Return(int64(100)).AnyTimes()
+		readers[path] = reader
+		return reader, nil
+	}
+	// case 1: evict by num. of files
+	cache := newLRUCache(CacheOption{MaxFiles: 2})
+	r1, _ := cache.acquire("1.sst")
+	cache.release(r1)
+	r2, _ := cache.acquire("2.sst")
+	cache.release(r2)
+	// 1.sst is most recently used
+	_, _ = cache.acquire("1.sst")
+	cache.release(r1)
+	readers["2.sst"].EXPECT().Close().Return(nil)
+	r3, _ := cache.acquire("3.sst")
+	assert.Equal(t, 2, cache.lru.Len())
+	assert.Equal(t, int64(200), cache.indexSize)
+	// case 2: evict by index size, reader in used cannot be closed
+	readers["1.sst"].EXPECT().Close().Return(nil)
+	cache.setOption(CacheOption{MaxFiles: 10, MaxIndexSize: 50})
+	assert.Equal(t, 1, cache.lru.Len())
+	// keep the most recently used reader at least
+	_, ok := cache.entries["3.sst"]
+	assert.True(t, ok)
+	r4, _ := cache.acquire("4.sst")
+	assert.Equal(t, 1, cache.lru.Len())
+	readers["3.sst"].EXPECT().Close().Return(nil)
+	cache.release(r3)
+	// case 3: default option
+	cache.setOption(CacheOption{})
+	assert.Equal(t, CacheOption{MaxFiles: defaultMaxCachedFiles, MaxIndexSize: defaultMaxCachedIndexSize}, cache.option)
+	readers["4.sst"].EXPECT().Close().Return(nil)
+	cache.release(r4)
+	cache.evict("4.sst")
+	assert.Equal(t, 0, cache.lru.Len())
+	assert.Empty(t, cache.readers)
+
+	SetCacheOption(CacheOption{MaxFiles: 100})
+	assert.Equal(t, 100, readerCache.option.MaxFiles)
+	SetCacheOption(CacheOption{})
+}
+
+func TestLRUCache_acquire_concurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		ctrl.Finish()
+	}()
+	opening := make(chan struct{})
+	opened := make(chan struct{})
+	var openCount atomic.Int32
+	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+		openCount.Inc()
+		opening <- struct{}{}
+		<-opened
+		reader := NewMockReader(ctrl)
+		reader.EXPECT().MemSize().Return(int64(100)).AnyTimes()
+		return reader, nil
+	}
+	cache := newLRUCache(CacheOption{})
+	acquire := func(ch chan Reader) {
+		r, err := cache.acquire("1.sst")
+		assert.NoError(t, err)
+		ch <- r
+	}
+	// case 1: open same file only once, others wait for it
+	ch1 := make(chan Reader)
+	ch2 := make(chan Reader)
+	go acquire(ch1)
+	<-opening
+	// cache isn't locked when opening
+	cache.mutex.Lock()
+	_, ok := cache.pending["1.sst"]
+	cache.mutex.Unlock()
+	assert.True(t, ok)
+	go acquire(ch2)
+	close(opened)
+	r1 := <-ch1
+	r2 := <-ch2
+	assert.Equal(t, r1, r2)
+	assert.Equal(t, int32(1), openCount.Load())
+	assert.Equal(t, int32(2), cache.entries["1.sst"].ref)
+	assert.Empty(t, cache.pending)
+}
+
+func TestLRUCache_acquire_evict_when_opening(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		ctrl.Finish()
+	}()
+	cache := newLRUCache(CacheOption{})
+	opening := make(chan struct{})
+	opened := make(chan struct{})
+	blockReader := NewMockReader(ctrl)
+	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+		opening <- struct{}{}
+		<-opened
+		return blockReader, nil
+	}
+	ch := make(chan Reader)
+	go func() {
+		r, err := cache.acquire("1.sst")
+		assert.NoError(t, err)
+		ch <- r
+	}()
+	<-opening
+	// case 1: evict reader which is opening
+	cache.evict("1.sst")
+	// case 2: other one opens reader again and puts it into cache
+	cachedReader := NewMockReader(ctrl)
+	cachedReader.EXPECT().MemSize().Return(int64(100)).AnyTimes()
+	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+		return cachedReader, nil
+	}
+	r, err := cache.acquire("1.sst")
+	assert.NoError(t, err)
+	assert.Equal(t, cachedReader, r)
+	// evicted reader isn't put into cache, closes it after released
+	close(opened)
+	r = <-ch
+	assert.Equal(t, blockReader, r)
+	assert.Equal(t, 1, cache.lru.Len())
+	blockReader.EXPECT().Close().Return(nil)
+	cache.release(r)
+	// case 3: evict readers by prefix when opening
+	opening = make(chan struct{})
+	opened = make(chan struct{})
+	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+		opening <- struct{}{}
+		<-opened
+		return blockReader, nil
+	}
+	go func() {
+		r, err := cache.acquire("2.sst")
+		assert.NoError(t, err)
+		ch <- r
+	}()
+	<-opening
+	cache.evictPrefix("2")
+	close(opened)
+	r = <-ch
+	assert.Equal(t, blockReader, r)
+	_, ok := cache.entries["2.sst"]
+	assert.False(t, ok)
+	blockReader.EXPECT().Close().Return(fmt.Errorf("err"))
+	cache.release(r)
+	assert.Empty(t, cache.pending)
+}
+
+func TestLRUCache_acquire_wait_err(t *testing.T) {
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+	}()
+	opening := make(chan struct{})
+	opened := make(chan struct{})
+	var openCount atomic.Int32
+	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+		if openCount.Inc() == 1 {
+			opening <- struct{}{}
+			<-opened
+		}
+		return nil, fmt.Errorf("err")
+	}
+	cache := newLRUCache(CacheOption{})
+	ch := make(chan error)
+	acquire := func() {
+		_, err := cache.acquire("1.sst")
+		ch <- err
+	}
+	go acquire()
+	<-opening
+	go acquire()
+	// wait the second one waiting for pending reader
+	time.Sleep(10 * time.Millisecond)
+	close(opened)
+	assert.Error(t, <-ch)
+	assert.Error(t, <-ch)
+	assert.Empty(t, cache.pending)
+}
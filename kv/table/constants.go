@@ -19,19 +19,33 @@ package table
 
 import (
 	"errors"
+	"fmt"
+	"hash/crc32"
 
 	"github.com/lindb/lindb/pkg/logger"
 )
 
 var (
 	ErrEmptyKeys = errors.New("empty keys under store builder")
+	// ErrKeyNotFound represents the key not exist in store file
+	ErrKeyNotFound = errors.New("key not found in store file")
 )
 
+// crc32cTable is the castagnoli crc32 table for checksum of sst file's blocks
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
 const (
 	// magic-number in the footer of sst file
 	magicNumberOffsetFile uint64 = 0x69632d656d656c65
-	// current file layout version
+	// file layout version without checksum
 	version0 = 0
+	// file layout version with crc32c checksum for each value/offsets/keys block,
+	// checksum(4) is appended after block data.
+	version1 = 1
+	// current file layout version
+	currentVersion = version1
+
+	checksumSize = 4
 
 	sstFileFooterSize = 1 + // entry length wrote by bufioutil
 		4 + // posOfOffset(4)
@@ -43,3 +57,25 @@ const (
 )
 
 var tableLogger = logger.GetLogger("kv", "table")
+
+// corruptedError represents the sst file is corrupted(checksum mismatch/invalid block etc.)
+type corruptedError struct {
+	path   string
+	reason string
+}
+
+// Error returns the error message
+func (e *corruptedError) Error() string {
+	return fmt.Sprintf("sstfile:%s is corrupted, %s", e.path, e.reason)
+}
+
+// newCorruptedError creates the corrupted error for sst file
+func newCorruptedError(path, reason string) error {
+	return &corruptedError{path: path, reason: reason}
+}
+
+// IsCorrupted returns if the error is caused by corrupted sst file
+func IsCorrupted(err error) bool {
+	_, ok := err.(*corruptedError)
+	return ok
+}
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 
 	"github.com/lindb/roaring"
 
@@ -44,10 +45,15 @@ var (
 type Reader interface {
 	// Path returns the file path
 	Path() string
-	// Get returns value for giving key, if key not exist, return nil, false
-	Get(key uint32) ([]byte, bool)
+	// Get returns value for giving key, if key not exist, returns ErrKeyNotFound,
+	// returns corrupted error if checksum of value mismatch.
+	Get(key uint32) ([]byte, error)
 	// Iterator iterates over a store's key/value pairs in key order.
 	Iterator() Iterator
+	// MemSize returns the memory size of index block(keys/offsets) cached in reader
+	MemSize() int64
+	// Verify verifies the checksum of all values in store file, returns corrupted error if checksum mismatch
+	Verify() error
 	// Close closes reader, release related resources
 	Close() error
 }
@@ -57,6 +63,8 @@ type storeMMapReader struct {
 	path    string                       // path of sst-file
 	data    []byte                       // mmaped file content
 	len     int                          // length of the file
+	version byte                         // file layout version
+	size    int64                        // memory size of index block
 	keys    *roaring.Bitmap              // bitmap of keys
 	offsets *encoding.FixedOffsetDecoder // offset of values
 }
@@ -77,7 +85,7 @@ func newMMapStoreReader(path string) (r Reader, err error) {
 		return
 	}
 	if len(data) < sstFileMinLength {
-		err = fmt.Errorf("length of sstfile:%s length is too short", path)
+		err = newCorruptedError(path, "length is too short")
 		return
 	}
 	reader := &storeMMapReader{
@@ -98,22 +106,34 @@ func newMMapStoreReader(path string) (r Reader, err error) {
 func (r *storeMMapReader) initialize() error {
 	buf := r.readBytes(r.len - sstFileFooterSize)
 	if (len(buf)) != sstFileFooterSize-1 {
-		return fmt.Errorf("read sstfile:%s footer error", r.path)
+		return newCorruptedError(r.path, "read footer error")
 	}
 	// validate magic-number
 	if uint64Func(buf[9:]) != magicNumberOffsetFile {
-		return fmt.Errorf("verify magic-number of sstfile:%s failure", r.path)
+		return newCorruptedError(r.path, "verify magic-number failure")
+	}
+	r.version = buf[8]
+	if r.version != version0 && r.version != version1 {
+		return newCorruptedError(r.path, fmt.Sprintf("unknown version:%d", r.version))
 	}
 	posOfOffset := int(binary.LittleEndian.Uint32(buf[:4]))
 	posOfKeys := int(binary.LittleEndian.Uint32(buf[4:8]))
-	if err := encoding.BitmapUnmarshal(r.keys, r.readBytes(posOfKeys)); err != nil {
-		return fmt.Errorf("unmarshal keys data from file[%s] error:%s", r.path, err)
+	keys, err := r.readBlock(posOfKeys)
+	if err != nil {
+		return err
+	}
+	if err := encoding.BitmapUnmarshal(r.keys, keys); err != nil {
+		return newCorruptedError(r.path, fmt.Sprintf("unmarshal keys data error:%s", err))
+	}
+	offset, err := r.readBlock(posOfOffset)
+	if err != nil {
+		return err
 	}
-	offset := r.readBytes(posOfOffset)
 	r.offsets = encoding.NewFixedOffsetDecoder(offset)
+	r.size = int64(r.keys.GetSizeInBytes()) + int64(len(offset))
 
 	if r.offsets.Size() != int(r.keys.GetCardinality()) {
-		return fmt.Errorf("num. of keys != num. of offsets in file[%s]", r.path)
+		return newCorruptedError(r.path, "num. of keys != num. of offsets")
 	}
 	return nil
 }
@@ -123,15 +143,21 @@ func (r *storeMMapReader) Path() string {
 	return r.path
 }
 
-// Get return value for key, if not exist return nil,false
-func (r *storeMMapReader) Get(key uint32) ([]byte, bool) {
+// Get return value for key, if not exist returns ErrKeyNotFound, returns corrupted error if checksum mismatch
+func (r *storeMMapReader) Get(key uint32) ([]byte, error) {
 	if !r.keys.Contains(key) {
-		return nil, false
+		return nil, ErrKeyNotFound
 	}
 	// bitmap data's index from 1, so idx= get index - 1
 	idx := r.keys.Rank(key)
 	offset, _ := r.offsets.Get(int(idx) - 1)
-	return r.readBytes(offset), true
+	value, err := r.readBlock(offset)
+	if err != nil {
+		tableLogger.Error("read value from sst file fail",
+			logger.String("path", r.path), logger.Uint32("key", key), logger.Error(err))
+		return nil, err
+	}
+	return value, nil
 }
 
 // Iterator iterates over a store's key/value pairs in key order.
@@ -139,6 +165,29 @@ func (r *storeMMapReader) Iterator() Iterator {
 	return newMMapIterator(r)
 }
 
+// MemSize returns the memory size of index block(keys/offsets) cached in reader
+func (r *storeMMapReader) MemSize() int64 {
+	return r.size
+}
+
+// Verify verifies the checksum of all values in store file, returns corrupted error if checksum mismatch
+func (r *storeMMapReader) Verify() error {
+	if r.version == version0 {
+		// old file layout hasn't checksum
+		return nil
+	}
+	for idx := 0; idx < r.offsets.Size(); idx++ {
+		offset, ok := r.offsets.Get(idx)
+		if !ok {
+			return newCorruptedError(r.path, fmt.Sprintf("offset of value:%d not found", idx))
+		}
+		if _, err := r.readBlock(offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // close store reader, release resource
 func (r *storeMMapReader) Close() error {
 	return fileutil.Unmap(r.data)
@@ -146,6 +195,9 @@ func (r *storeMMapReader) Close() error {
 
 // readBytes reads bytes from buffer, read length+data format
 func (r *storeMMapReader) readBytes(offset int) []byte {
+	if offset < 0 || offset >= len(r.data) {
+		return nil
+	}
 	length, err := uvarintFunc(bytes.NewReader(r.data[offset:]))
 	if err != nil {
 		return nil
@@ -159,6 +211,23 @@ func (r *storeMMapReader) readBytes(offset int) []byte {
 	return r.data[start:end]
 }
 
+// readBlock reads block data, verifies the checksum of block if file layout version is version1
+func (r *storeMMapReader) readBlock(offset int) ([]byte, error) {
+	block := r.readBytes(offset)
+	if r.version == version0 {
+		return block, nil
+	}
+	if len(block) < checksumSize {
+		return nil, newCorruptedError(r.path, fmt.Sprintf("block of offset:%d is too short", offset))
+	}
+	pos := len(block) - checksumSize
+	data := block[:pos]
+	if crc32.Checksum(data, crc32cTable) != binary.LittleEndian.Uint32(block[pos:]) {
+		return nil, newCorruptedError(r.path, fmt.Sprintf("checksum mismatch of block, offset:%d", offset))
+	}
+	return data, nil
+}
+
 // storeMMapIterator iterates k/v pair using mmap store reader
 type storeMMapIterator struct {
 	reader *storeMMapReader
@@ -191,5 +260,11 @@ func (it *storeMMapIterator) Key() uint32 {
 func (it *storeMMapIterator) Value() []byte {
 	offset, _ := it.reader.offsets.Get(it.idx)
 	it.idx++
-	return it.reader.readBytes(offset)
+	value, err := it.reader.readBlock(offset)
+	if err != nil {
+		tableLogger.Error("read value from sst file fail",
+			logger.String("path", it.reader.path), logger.Error(err))
+		return nil
+	}
+	return value
 }
@@ -21,12 +21,14 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"testing"
 
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/pkg/bufioutil"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fileutil"
 )
@@ -150,8 +152,8 @@ func TestReader(t *testing.T) {
 	defer func() {
 		_ = reader.Close()
 	}()
-	value, ok := reader.Get(100)
-	assert.False(t, ok)
+	value, err := reader.Get(100)
+	assert.Equal(t, ErrKeyNotFound, err)
 	assert.Nil(t, value)
 
 	value, _ = reader.Get(1)
@@ -196,3 +198,110 @@ func TestStoreIterator(t *testing.T) {
 
 	assert.False(t, it.HasNext())
 }
+
+func TestStoreMMapReader_checksum(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		_ = os.RemoveAll(testKVPath)
+	}()
+	path := testKVPath + "/000010.sst"
+	builder, err := NewStoreBuilder(10, path)
+	assert.NoError(t, err)
+	_ = builder.Add(1, []byte("test"))
+	_ = builder.Add(10, []byte("test10"))
+	assert.NoError(t, builder.Close())
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(version1), data[len(data)-9])
+
+	// case 1: verify successfully
+	r, err := newMMapStoreReader(path)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Verify())
+	_ = r.Close()
+
+	// case 2: value corrupted
+	corrupted := append([]byte{}, data...)
+	corrupted[2] = 'x' // test => txst
+	assert.NoError(t, ioutil.WriteFile(path, corrupted, 0644))
+	r, err = newMMapStoreReader(path)
+	assert.NoError(t, err)
+	value, err := r.Get(1)
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, value)
+	value, err = r.Get(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test10"), value)
+	it := r.Iterator()
+	assert.True(t, it.HasNext())
+	assert.Equal(t, uint32(1), it.Key())
+	assert.Nil(t, it.Value())
+	err = r.Verify()
+	assert.Error(t, err)
+	assert.True(t, IsCorrupted(err))
+	_ = r.Close()
+
+	// case 3: keys block corrupted
+	corrupted = append([]byte{}, data...)
+	corrupted[len(corrupted)-sstFileFooterSize-1]++
+	assert.NoError(t, ioutil.WriteFile(path, corrupted, 0644))
+	r, err = newMMapStoreReader(path)
+	assert.Error(t, err)
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, r)
+
+	// case 4: unknown version
+	corrupted = append([]byte{}, data...)
+	corrupted[len(corrupted)-9] = 100
+	assert.NoError(t, ioutil.WriteFile(path, corrupted, 0644))
+	r, err = newMMapStoreReader(path)
+	assert.Error(t, err)
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, r)
+	assert.False(t, IsCorrupted(fmt.Errorf("err")))
+}
+
+func TestStoreMMapReader_version0(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		_ = os.RemoveAll(testKVPath)
+	}()
+	path := testKVPath + "/000010.sst"
+	// build file with version0 layout(no checksum)
+	writer, err := bufioutil.NewBufioWriter(path)
+	assert.NoError(t, err)
+	offsets := encoding.NewFixedOffsetEncoder()
+	keys := roaring.New()
+	offsets.Add(int(writer.Size()))
+	keys.Add(1)
+	_, _ = writer.Write([]byte("test"))
+	offsets.Add(int(writer.Size()))
+	keys.Add(10)
+	_, _ = writer.Write([]byte("test10"))
+	posOfOffset := writer.Size()
+	_, _ = writer.Write(offsets.MarshalBinary())
+	posOfKeys := writer.Size()
+	keysData, _ := encoding.BitmapMarshal(keys)
+	_, _ = writer.Write(keysData)
+	var buf [17]byte
+	binary.LittleEndian.PutUint32(buf[:4], uint32(posOfOffset))
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(posOfKeys))
+	buf[8] = version0
+	binary.LittleEndian.PutUint64(buf[9:], magicNumberOffsetFile)
+	_, _ = writer.Write(buf[:])
+	assert.NoError(t, writer.Close())
+
+	r, err := newMMapStoreReader(path)
+	assert.NoError(t, err)
+	defer func() {
+		_ = r.Close()
+	}()
+	assert.NoError(t, r.Verify())
+	value, err := r.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
+	value, err = r.Get(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test10"), value)
+}
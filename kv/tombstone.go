@@ -0,0 +1,38 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kv
+
+import (
+	"github.com/lindb/roaring"
+)
+
+//go:generate mockgen -source ./tombstone.go -destination=./tombstone_mock.go -package kv
+
+// Tombstone represents the deleted data of store(like dropped metric/deleted series),
+// compaction job purges the deleted data based on it.
+type Tombstone interface {
+	// IsDeleted returns if all values of the key are deleted
+	IsDeleted(key uint32) bool
+	// DeletedValues returns the deleted value ids(like series ids) under the key, returns nil if not exist
+	DeletedValues(key uint32) *roaring.Bitmap
+}
+
+// getTombstone returns the tombstone of store which family belongs to
+func (f *family) getTombstone() Tombstone {
+	return f.store.getTombstone()
+}
@@ -18,6 +18,8 @@
 package version
 
 import (
+	"sync"
+
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/kv/table"
@@ -26,7 +28,7 @@ import (
 //go:generate mockgen -source ./snapshot.go -destination=./snapshot_mock.go -package version
 
 // Snapshot represents a current family version for reading data.
-// NOTICE: current version will retain like ref count, so snapshot must close.
+// NOTICE: current version and readers will retain like ref count, so snapshot must close.
 type Snapshot interface {
 	// GetCurrent returns current mutable version
 	GetCurrent() Version
@@ -44,7 +46,9 @@ type snapshot struct {
 	cache      table.Cache
 
 	version Version
+	readers []table.Reader // readers retained by snapshot, release them when close
 	closed  atomic.Bool
+	mutex   sync.Mutex
 }
 
 // newSnapshot new snapshot instance
@@ -75,6 +79,7 @@ func (s *snapshot) FindReaders(key uint32) ([]table.Reader, error) {
 			return nil, err
 		}
 		if reader != nil {
+			s.retain(reader)
 			readers = append(readers, reader)
 		}
 	}
@@ -83,7 +88,19 @@ func (s *snapshot) FindReaders(key uint32) ([]table.Reader, error) {
 
 // GetReader returns the file reader
 func (s *snapshot) GetReader(fileNumber table.FileNumber) (table.Reader, error) {
-	return s.cache.GetReader(s.familyName, Table(fileNumber))
+	reader, err := s.cache.GetReader(s.familyName, Table(fileNumber))
+	if err != nil {
+		return nil, err
+	}
+	s.retain(reader)
+	return reader, nil
+}
+
+// retain records the readers retained by snapshot
+func (s *snapshot) retain(reader table.Reader) {
+	s.mutex.Lock()
+	s.readers = append(s.readers, reader)
+	s.mutex.Unlock()
 }
 
 // Close releases related resources
@@ -91,5 +108,12 @@ func (s *snapshot) Close() {
 	// atomic set closed status, make sure only release once
 	if s.closed.CAS(false, true) {
 		s.version.Release()
+
+		s.mutex.Lock()
+		for _, reader := range s.readers {
+			s.cache.Release(reader)
+		}
+		s.readers = nil
+		s.mutex.Unlock()
 	}
 }
@@ -67,8 +67,9 @@ func TestSnapshot_FindReaders(t *testing.T) {
 	readers, err = snapshot.FindReaders(uint32(80))
 	assert.Error(t, err)
 	assert.Nil(t, readers)
-	// case 7: close snapshot
+	// case 7: close snapshot, release retained readers
 	v.EXPECT().Release()
+	cache.EXPECT().Release(gomock.Any()).Times(2)
 	snapshot.Close()
 	snapshot.Close() // test version release only once
 }
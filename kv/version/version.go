@@ -218,6 +218,15 @@ func (v *version) Clone() Version {
 			newVersion.AddFile(level, file)
 		}
 	}
+	// copy rollup/reference files for rollup job
+	for fileNumber, interval := range v.rollup.rollupFiles {
+		newVersion.AddRollupFile(fileNumber, interval)
+	}
+	for familyID, files := range v.rollup.referenceFiles {
+		for _, file := range files {
+			newVersion.AddReferenceFile(familyID, file)
+		}
+	}
 	return newVersion
 }
 
@@ -61,6 +61,12 @@ type StoreVersionSet interface {
 	CreateFamilyVersion(family string, familyID FamilyID) FamilyVersion
 	// GetFamilyVersion returns family version if exist, else return nil
 	GetFamilyVersion(family string) FamilyVersion
+	// DropFamilyVersion drops family version, then rolls a new manifest file without the dropped family
+	DropFamilyVersion(family string) error
+	// Checkpoint writes the manifest of current versions into the checkpoint path,
+	// returns the snapshots of all families which pin the files referenced by the manifest,
+	// NOTICE: invoker must close the snapshots after using.
+	Checkpoint(path string) (map[string]Snapshot, error)
 
 	// newVersionID generates new version id
 	newVersionID() int64
@@ -198,6 +204,87 @@ func (vs *storeVersionSet) GetFamilyVersion(family string) FamilyVersion {
 	return nil
 }
 
+// DropFamilyVersion drops family version, then rolls a new manifest file without the dropped family,
+// because recovering fails when manifest file includes the edit logs of unknown family.
+// NOTICE: old manifest file will be deleted by kv store as obsolete file.
+func (vs *storeVersionSet) DropFamilyVersion(family string) error {
+	vs.mutex.Lock()
+	defer vs.mutex.Unlock()
+
+	familyVersion, ok := vs.familyVersions[family]
+	if !ok {
+		return nil
+	}
+	familyID := familyVersion.GetID()
+	delete(vs.familyVersions, family)
+	delete(vs.familyIDs, familyID)
+
+	oldManifest := vs.manifest
+	oldManifestFileNumber := vs.manifestFileNumber.Load()
+	// use next file number as new manifest file number, same as recovering
+	vs.setNextFileNumberWithoutLock(table.FileNumber(vs.nextFileNumber.Load()))
+	vs.manifest = nil
+	if err := vs.initJournal(); err != nil {
+		// rollback family version and manifest writer
+		vs.familyVersions[family] = familyVersion
+		vs.familyIDs[familyID] = family
+		vs.manifest = oldManifest
+		vs.manifestFileNumber.Store(oldManifestFileNumber)
+		return err
+	}
+	if oldManifest != nil {
+		if err := oldManifest.Close(); err != nil {
+			versionLogger.Warn("close old manifest writer error when drop family version",
+				logger.String("path", vs.storePath), logger.Error(err))
+		}
+	}
+	versionLogger.Info("drop family version",
+		logger.String("path", vs.storePath), logger.String("family", family))
+	return nil
+}
+
+// Checkpoint writes the manifest of current versions into the checkpoint path,
+// returns the snapshots of all families which pin the files referenced by the manifest,
+// the snapshots are taken under lock, so that the manifest is consistent with the pinned files.
+// NOTICE: invoker must close the snapshots after using.
+func (vs *storeVersionSet) Checkpoint(path string) (map[string]Snapshot, error) {
+	vs.mutex.Lock()
+	defer vs.mutex.Unlock()
+
+	snapshots := make(map[string]Snapshot, len(vs.familyVersions))
+	closeSnapshots := func() {
+		for _, snapshot := range snapshots {
+			snapshot.Close()
+		}
+	}
+	var editLogs []EditLog
+	for familyID, familyName := range vs.familyIDs {
+		snapshot := vs.familyVersions[familyName].GetSnapshot()
+		snapshots[familyName] = snapshot
+		editLogs = append(editLogs, vs.createVersionEditLog(familyID, snapshot.GetCurrent()))
+	}
+	editLogs = append(editLogs, vs.createStoreSnapshot())
+
+	manifestFileName := ManifestFileName(table.FileNumber(vs.manifestFileNumber.Load()))
+	writer, err := newBufferWriterFunc(filepath.Join(path, manifestFileName))
+	if err != nil {
+		closeSnapshots()
+		return nil, err
+	}
+	err = vs.persistEditLogs(writer, editLogs)
+	if closeErr := writer.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = writeFileFunc(filepath.Join(path, current()), []byte(manifestFileName), 0666)
+	}
+	if err != nil {
+		closeSnapshots()
+		return nil, fmt.Errorf("write checkpoint manifest into path[%s] error:%s", path, err)
+	}
+	return snapshots, nil
+}
+
 // Recover recover version set if exist, recover been invoked when kv store init.
 // Initialize if version file not exists, else recover old data then init journal writer.
 func (vs *storeVersionSet) Recover() error {
@@ -376,11 +463,16 @@ func (vs *storeVersionSet) createSnapshot() (editLogs []EditLog) {
 
 // createFamilySnapshot creates snapshot of edit log for family level
 func (vs *storeVersionSet) createFamilySnapshot(familyID FamilyID, familyVersion FamilyVersion) EditLog {
-	editLog := NewEditLog(familyID)
 	// save current version all active files
 	snapshot := familyVersion.GetSnapshot()
 	defer snapshot.Close()
-	levels := snapshot.GetCurrent().Levels()
+	return vs.createVersionEditLog(familyID, snapshot.GetCurrent())
+}
+
+// createVersionEditLog creates edit log which includes all active/rollup/reference files of the version
+func (vs *storeVersionSet) createVersionEditLog(familyID FamilyID, current Version) EditLog {
+	editLog := NewEditLog(familyID)
+	levels := current.Levels()
 	for numOfLevel, level := range levels {
 		files := level.getFiles()
 		for _, file := range files {
@@ -389,6 +481,15 @@ func (vs *storeVersionSet) createFamilySnapshot(familyID FamilyID, familyVersion
 			editLog.Add(newFile)
 		}
 	}
+	// save rollup/reference files for rollup job
+	for fileNumber, interval := range current.GetRollupFiles() {
+		editLog.Add(CreateNewRollupFile(fileNumber, interval))
+	}
+	for familyID, files := range current.GetReferenceFiles() {
+		for _, file := range files {
+			editLog.Add(CreateNewReferenceFile(familyID, file))
+		}
+	}
 	return editLog
 }
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -29,6 +30,7 @@ import (
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/bufioutil"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var vsTestPath = "test_data"
@@ -206,6 +208,8 @@ func TestCommitFamilyEditLog(t *testing.T) {
 	nf := nFile.(*newFile)
 	editLog.Add(nFile)
 	editLog.Add(NewDeleteFile(1, 123))
+	editLog.Add(CreateNewRollupFile(12, 3))
+	editLog.Add(CreateNewReferenceFile(10, 100))
 	err = vs.CommitFamilyEditLog("f", editLog)
 	assert.Nil(t, err, "commit family edit log error")
 
@@ -222,6 +226,8 @@ func TestCommitFamilyEditLog(t *testing.T) {
 		snapshot := familyVersion.GetSnapshot()
 		vs1 := vs.(*storeVersionSet)
 		assert.Equal(t, nf.file, snapshot.GetCurrent().GetAllFiles()[0], "cannot recover family version data")
+		assert.Equal(t, map[table.FileNumber]timeutil.Interval{12: 3}, snapshot.GetCurrent().GetRollupFiles())
+		assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100}}, snapshot.GetCurrent().GetReferenceFiles())
 		assert.Equal(t, int64(3+i), vs1.nextFileNumber.Load(), "recover file number error")
 		snapshot.Close()
 
@@ -281,6 +287,53 @@ func TestCreateFamily(t *testing.T) {
 	assert.Equal(t, familyVersion, familyVersion2, "get diff family version")
 }
 
+func TestStoreVersionSet_DropFamilyVersion(t *testing.T) {
+	initVersionSetTestData()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newBufferWriterFunc = bufioutil.NewBufioWriter
+		destroyVersionTestData()
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f1", 1)
+	vs.CreateFamilyVersion("f2", 2)
+	err := vs.Recover()
+	assert.NoError(t, err)
+	editLog := NewEditLog(1)
+	editLog.Add(CreateNewFile(0, NewFileMeta(12, 1, 100, 2014)))
+	err = vs.CommitFamilyEditLog("f1", editLog)
+	assert.NoError(t, err)
+	// case 1: family not exist
+	err = vs.DropFamilyVersion("f3")
+	assert.NoError(t, err)
+	// case 2: new manifest writer err
+	newBufferWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
+		return nil, fmt.Errorf("err")
+	}
+	manifestFileNumber := vs.ManifestFileNumber()
+	err = vs.DropFamilyVersion("f1")
+	assert.Error(t, err)
+	assert.NotNil(t, vs.GetFamilyVersion("f1"))
+	assert.Equal(t, manifestFileNumber, vs.ManifestFileNumber())
+	newBufferWriterFunc = bufioutil.NewBufioWriter
+	// case 3: drop family version
+	err = vs.DropFamilyVersion("f1")
+	assert.NoError(t, err)
+	assert.Nil(t, vs.GetFamilyVersion("f1"))
+	assert.NotEqual(t, manifestFileNumber, vs.ManifestFileNumber())
+	_ = vs.Destroy()
+	// case 4: recover without dropped family
+	vs = NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f2", 2)
+	err = vs.Recover()
+	assert.NoError(t, err)
+	assert.Nil(t, vs.GetFamilyVersion("f1"))
+	_ = vs.Destroy()
+}
+
 func TestStoreVersionSet_Destroy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -306,3 +359,64 @@ func destroyVersionTestData() {
 		fmt.Println("delete test path error")
 	}
 }
+
+func TestStoreVersionSet_Checkpoint(t *testing.T) {
+	initVersionSetTestData()
+	checkpointPath := filepath.Join(vsTestPath, "checkpoint")
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newBufferWriterFunc = bufioutil.NewBufioWriter
+		writeFileFunc = ioutil.WriteFile
+		destroyVersionTestData()
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f1", 1)
+	err := vs.Recover()
+	assert.NoError(t, err)
+	editLog := NewEditLog(1)
+	editLog.Add(CreateNewFile(0, NewFileMeta(12, 1, 100, 2014)))
+	err = vs.CommitFamilyEditLog("f1", editLog)
+	assert.NoError(t, err)
+	assert.NoError(t, fileutil.MkDirIfNotExist(checkpointPath))
+	// case 1: new manifest writer err
+	newBufferWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
+		return nil, fmt.Errorf("err")
+	}
+	snapshots, err := vs.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	assert.Nil(t, snapshots)
+	newBufferWriterFunc = bufioutil.NewBufioWriter
+	// case 2: write current file err
+	writeFileFunc = func(filename string, data []byte, perm os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	snapshots, err = vs.Checkpoint(checkpointPath)
+	assert.Error(t, err)
+	assert.Nil(t, snapshots)
+	writeFileFunc = ioutil.WriteFile
+	// case 3: checkpoint pins current version
+	snapshots, err = vs.Checkpoint(checkpointPath)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	editLog = NewEditLog(1)
+	editLog.Add(NewDeleteFile(0, 12))
+	err = vs.CommitFamilyEditLog("f1", editLog)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots["f1"].GetCurrent().GetAllFiles(), 1)
+	for _, snapshot := range snapshots {
+		snapshot.Close()
+	}
+	_ = vs.Destroy()
+	// case 4: recover from checkpoint
+	vs = NewStoreVersionSet(checkpointPath, cache, 2)
+	vs.CreateFamilyVersion("f1", 1)
+	err = vs.Recover()
+	assert.NoError(t, err)
+	snapshot := vs.GetFamilyVersion("f1").GetSnapshot()
+	assert.Equal(t, []*FileMeta{NewFileMeta(12, 1, 100, 2014)}, snapshot.GetCurrent().GetAllFiles())
+	snapshot.Close()
+	_ = vs.Destroy()
+}
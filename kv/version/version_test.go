@@ -188,6 +188,7 @@ func TestVersion_RollupJob(t *testing.T) {
 	fv.EXPECT().GetVersionSet().Return(vs).AnyTimes()
 	fv.EXPECT().GetID().Return(FamilyID(1)).AnyTimes()
 	vs.EXPECT().numberOfLevels().Return(2).AnyTimes()
+	vs.EXPECT().newVersionID().Return(int64(2))
 	v := newVersion(1, fv)
 	v.AddRollupFile(10, 3)
 	v.DeleteRollupFile(10)
@@ -196,4 +197,9 @@ func TestVersion_RollupJob(t *testing.T) {
 	v.AddReferenceFile(10, 10)
 	v.DeleteReferenceFile(10, 10)
 	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100}}, v.GetReferenceFiles())
+	// clone keeps rollup/reference files
+	v.AddRollupFile(20, 3)
+	v2 := v.Clone()
+	assert.Equal(t, v.GetRollupFiles(), v2.GetRollupFiles())
+	assert.Equal(t, v.GetReferenceFiles(), v2.GetReferenceFiles())
 }
@@ -46,11 +46,34 @@ type Replica struct {
 	Replicas []int `json:"replicas"`
 }
 
+// RoutingEpoch defines the metric routing rule(num. of shard) which takes effect from start time.
+type RoutingEpoch struct {
+	Epoch      int   `json:"epoch"`
+	NumOfShard int   `json:"numOfShard"`
+	StartTime  int64 `json:"startTime"` // timestamp(ms) of metric which epoch takes effect from
+}
+
+// RoutingEpochs represents the routing epoch list, sorted by start time asc.
+// Metric is routed by the epoch which contains the metric's timestamp,
+// so the series of history data never moves after num. of shard increased.
+type RoutingEpochs []RoutingEpoch
+
+// NumOfShard returns the num. of shard for routing metric by timestamp, returns 0 if not match
+func (e RoutingEpochs) NumOfShard(timestamp int64) int {
+	for i := len(e) - 1; i >= 0; i-- {
+		if timestamp >= e[i].StartTime {
+			return e[i].NumOfShard
+		}
+	}
+	return 0
+}
+
 // ShardAssignment defines shard assignment for database
 type ShardAssignment struct {
 	Name   string           `json:"name"` // database's name
 	Nodes  map[int]*Node    `json:"nodes"`
 	Shards map[int]*Replica `json:"shards"`
+	Epochs RoutingEpochs    `json:"epochs,omitempty"` // routing epochs, empty if num. of shard never changed
 }
 
 // NewShardAssignment returns empty shard assignment instance
@@ -62,6 +85,23 @@ func NewShardAssignment(name string) *ShardAssignment {
 	}
 }
 
+// AddRoutingEpoch records a new routing epoch when num. of shard increased, new num. of shard takes effect from start time,
+// adds the initial epoch(from 0) for original num. of shard if no epoch recorded.
+func (s *ShardAssignment) AddRoutingEpoch(originalNumOfShard, numOfShard int, startTime int64) {
+	if len(s.Epochs) == 0 {
+		s.Epochs = append(s.Epochs, RoutingEpoch{NumOfShard: originalNumOfShard})
+	}
+	last := s.Epochs[len(s.Epochs)-1]
+	if startTime <= last.StartTime {
+		startTime = last.StartTime + 1
+	}
+	s.Epochs = append(s.Epochs, RoutingEpoch{
+		Epoch:      last.Epoch + 1,
+		NumOfShard: numOfShard,
+		StartTime:  startTime,
+	})
+}
+
 // AddReplica adds replica id to replica list of spec shard
 func (s *ShardAssignment) AddReplica(shardID int, replicaID int) {
 	replica, ok := s.Shards[shardID]
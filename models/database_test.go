@@ -44,3 +44,23 @@ func TestDatabase_String(t *testing.T) {
 	}
 	assert.Equal(t, "create database test with shard 10, replica 1, interval 10s", database.String())
 }
+
+func TestShardAssignment_AddRoutingEpoch(t *testing.T) {
+	shardAssign := NewShardAssignment("test")
+	assert.Equal(t, 0, shardAssign.Epochs.NumOfShard(100))
+	shardAssign.AddRoutingEpoch(3, 5, 100)
+	assert.Equal(t, RoutingEpochs{
+		{Epoch: 0, NumOfShard: 3, StartTime: 0},
+		{Epoch: 1, NumOfShard: 5, StartTime: 100},
+	}, shardAssign.Epochs)
+	// start time must be after previous epoch
+	shardAssign.AddRoutingEpoch(5, 8, 50)
+	assert.Equal(t, RoutingEpoch{Epoch: 2, NumOfShard: 8, StartTime: 101}, shardAssign.Epochs[2])
+
+	assert.Equal(t, 3, shardAssign.Epochs.NumOfShard(10))
+	assert.Equal(t, 3, shardAssign.Epochs.NumOfShard(99))
+	assert.Equal(t, 5, shardAssign.Epochs.NumOfShard(100))
+	assert.Equal(t, 8, shardAssign.Epochs.NumOfShard(101))
+	assert.Equal(t, 8, shardAssign.Epochs.NumOfShard(1000))
+	assert.Equal(t, 0, shardAssign.Epochs.NumOfShard(-1))
+}
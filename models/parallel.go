@@ -65,3 +65,14 @@ type Leaf struct {
 	Receivers []Node
 	ShardIDs  []int32
 }
+
+// RunningQuery represents the running distribution query job of broker
+type RunningQuery struct {
+	JobID          int64    `json:"jobID"`          // job id
+	SQL            string   `json:"sql"`            // sql of query
+	Database       string   `json:"database"`       // database name
+	StartTime      int64    `json:"startTime"`      // start time of job
+	StorageNodes   []string `json:"storageNodes"`   // indicators of storage nodes which execute the query
+	CompletedTasks int32    `json:"completedTasks"` // num. of completed sub tasks
+	TotalTasks     int32    `json:"totalTasks"`     // num. of total sub tasks
+}
@@ -17,6 +17,12 @@
 
 package models
 
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
 // SuggestResult represents the suggest result set
 type SuggestResult struct {
 	Values []string `json:"values"`
@@ -30,6 +36,8 @@ type ResultSet struct {
 	Interval   int64       `json:"interval,omitempty"`
 	Series     []*Series   `json:"series,omitempty"`
 	Stats      *QueryStats `json:"stats,omitempty"`
+	// Warnings represents the shards which are not queried, the result set is partial if not empty
+	Warnings []ShardWarning `json:"warnings,omitempty"`
 }
 
 // NewResultSet creates a new result set
@@ -42,6 +50,22 @@ func (rs *ResultSet) AddSeries(series *Series) {
 	rs.Series = append(rs.Series, series)
 }
 
+// AddWarning adds a warning of the shard which is not queried
+func (rs *ResultSet) AddWarning(warning ShardWarning) {
+	rs.Warnings = append(rs.Warnings, warning)
+}
+
+// Partial returns if the result set is partial, some shards are not queried
+func (rs *ResultSet) Partial() bool {
+	return len(rs.Warnings) > 0
+}
+
+// ShardWarning represents the warning of the shard which no replica can serve the query
+type ShardWarning struct {
+	ShardID int32  `json:"shardID"`
+	Message string `json:"message"`
+}
+
 // Series represents one time series for metric
 type Series struct {
 	Tags   map[string]string            `json:"tags,omitempty"`
@@ -79,3 +103,74 @@ func NewPoints() *Points {
 func (p *Points) AddPoint(timestamp int64, value float64) {
 	p.Points[timestamp] = value
 }
+
+// ColumnSeries represents one time series with columnar format, all fields share the same timestamps,
+// the missing value of field is NaN, which is encoded as null in json.
+type ColumnSeries struct {
+	Tags       map[string]string      `json:"tags,omitempty"`
+	Timestamps []int64                `json:"timestamps"`
+	Fields     map[string]FloatValues `json:"fields"`
+}
+
+// NewColumnSeries creates a new columnar time series
+func NewColumnSeries(tags map[string]string) *ColumnSeries {
+	return &ColumnSeries{Tags: tags, Fields: make(map[string]FloatValues)}
+}
+
+// FloatValues represents the values of field which are aligned with timestamps
+type FloatValues []float64
+
+// MarshalJSON encodes the values as json array, NaN/Inf(no value) is encoded as null
+func (v FloatValues) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 2+len(v)*8)
+	buf = append(buf, '[')
+	for idx, value := range v {
+		if idx > 0 {
+			buf = append(buf, ',')
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			buf = append(buf, "null"...)
+			continue
+		}
+		buf = strconv.AppendFloat(buf, value, 'g', -1, 64)
+	}
+	buf = append(buf, ']')
+	return buf, nil
+}
+
+// UnmarshalJSON decodes the json array, null is decoded as NaN
+func (v *FloatValues) UnmarshalJSON(data []byte) error {
+	var values []*float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	result := make(FloatValues, len(values))
+	for idx, value := range values {
+		if value == nil {
+			result[idx] = math.NaN()
+			continue
+		}
+		result[idx] = *value
+	}
+	*v = result
+	return nil
+}
+
+// StreamSummary represents the summary of streaming query result, which is the last line of result stream
+type StreamSummary struct {
+	MetricName  string      `json:"metricName,omitempty"`
+	StartTime   int64       `json:"startTime,omitempty"`
+	EndTime     int64       `json:"endTime,omitempty"`
+	Interval    int64       `json:"interval,omitempty"`
+	SeriesCount int         `json:"seriesCount"`
+	Stats       *QueryStats `json:"stats,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	// Warnings represents the shards which are not queried
+	Warnings []ShardWarning `json:"warnings,omitempty"`
+}
+
+// StreamEvent represents one line of streaming query result, which is either a time series or the summary
+type StreamEvent struct {
+	Series  *ColumnSeries  `json:"series,omitempty"`
+	Summary *StreamSummary `json:"summary,omitempty"`
+}
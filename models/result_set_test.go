@@ -18,6 +18,8 @@
 package models
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,4 +43,34 @@ func TestResultSet(t *testing.T) {
 		int64(10): 10.0,
 		int64(20): 10.0},
 		s.Fields["f1"])
+
+	assert.False(t, rs.Partial())
+	rs.AddWarning(ShardWarning{ShardID: 1, Message: "no available replica"})
+	assert.True(t, rs.Partial())
+	assert.Equal(t, []ShardWarning{{ShardID: 1, Message: "no available replica"}}, rs.Warnings)
+}
+
+func TestColumnSeries_JSON(t *testing.T) {
+	series := NewColumnSeries(map[string]string{"key": "value"})
+	series.Timestamps = []int64{10, 20, 30}
+	series.Fields["f1"] = FloatValues{1, math.NaN(), 2.5}
+	series.Fields["f2"] = FloatValues{math.Inf(1), 3, 4}
+	data, err := json.Marshal(series)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":{"key":"value"},"timestamps":[10,20,30],"fields":{"f1":[1,null,2.5],"f2":[null,3,4]}}`,
+		string(data))
+
+	series2 := &ColumnSeries{}
+	err = json.Unmarshal(data, series2)
+	assert.NoError(t, err)
+	assert.Equal(t, series.Timestamps, series2.Timestamps)
+	assert.Equal(t, 1.0, series2.Fields["f1"][0])
+	assert.True(t, math.IsNaN(series2.Fields["f1"][1]))
+	assert.Equal(t, 2.5, series2.Fields["f1"][2])
+
+	var values FloatValues
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), &values))
+	data, err = json.Marshal(FloatValues{})
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
 }
@@ -43,3 +43,68 @@ type DatabaseFlushTask struct {
 func (t DatabaseFlushTask) Bytes() []byte {
 	return encoding.JSONMarshal(t)
 }
+
+// DropDatabaseTask represents the drop database task's param
+type DropDatabaseTask struct {
+	DatabaseName string `json:"databaseName"` // database's name
+}
+
+// Bytes returns the drop database task's binary data using json
+func (t DropDatabaseTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// DropMetricTask represents the drop metric task's param
+type DropMetricTask struct {
+	DatabaseName string `json:"databaseName"` // database's name
+	Namespace    string `json:"namespace"`    // metric's namespace
+	MetricName   string `json:"metricName"`   // metric's name
+}
+
+// Bytes returns the drop metric task's binary data using json
+func (t DropMetricTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// DeleteSeriesTask represents the delete series task's param
+type DeleteSeriesTask struct {
+	DatabaseName string `json:"databaseName"` // database's name
+	Namespace    string `json:"namespace"`    // metric's namespace
+	MetricName   string `json:"metricName"`   // metric's name
+	Condition    []byte `json:"condition"`    // tag filter condition(marshaled stmt.Expr)
+}
+
+// Bytes returns the delete series task's binary data using json
+func (t DeleteSeriesTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// ShardRepair represents the shard which need to be repaired from the peer replicas
+type ShardRepair struct {
+	ShardID int32  `json:"shardId"` // shard's id
+	Peers   []Node `json:"peers"`   // other replicas of shard
+}
+
+// ShardRepairTask represents the repair shard task's param
+type ShardRepairTask struct {
+	DatabaseName string        `json:"databaseName"` // database's name
+	Shards       []ShardRepair `json:"shards"`       // shards of database on storage node
+}
+
+// Bytes returns the repair shard task's binary data using json
+func (t ShardRepairTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// DatabaseBackupTask represents the backup database task's param
+type DatabaseBackupTask struct {
+	DatabaseName string `json:"databaseName"` // database's name
+	Target       string `json:"target"`       // uri of backup target
+	Name         string `json:"name"`         // backup's name
+	Prefix       string `json:"prefix"`       // prefix of backup in target for storage node
+}
+
+// Bytes returns the backup database task's binary data using json
+func (t DatabaseBackupTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
@@ -0,0 +1,107 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+// AllDatabases represents the grant which takes effect on all databases
+const AllDatabases = "*"
+
+// Permission represents the permission of database, admin includes write, write includes read
+type Permission string
+
+// Defines all permissions of database
+const (
+	ReadPermission  Permission = "read"
+	WritePermission Permission = "write"
+	AdminPermission Permission = "admin"
+)
+
+// level returns the level of permission, returns 0 if permission is unknown
+func (p Permission) level() int {
+	switch p {
+	case ReadPermission:
+		return 1
+	case WritePermission:
+		return 2
+	case AdminPermission:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IsValid checks if the permission is a known permission
+func (p Permission) IsValid() bool {
+	return p.level() > 0
+}
+
+// Includes checks if the permission includes the target permission
+func (p Permission) Includes(target Permission) bool {
+	return target.IsValid() && p.level() >= target.level()
+}
+
+// Grant represents the permission of database
+type Grant struct {
+	Database   string     `json:"database"`   // database name, * means all databases
+	Permission Permission `json:"permission"` // permission of database
+}
+
+// Grants represents the grant list
+type Grants []Grant
+
+// Allow checks if has the permission of database,
+// if database is empty, only the grant of all databases is allowed.
+func (gs Grants) Allow(database string, permission Permission) bool {
+	for _, g := range gs {
+		if !g.Permission.Includes(permission) {
+			continue
+		}
+		if g.Database == AllDatabases || (database != "" && g.Database == database) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowAllDatabases checks if has the permission of all databases,
+// which is required by global resources like users/roles/api keys and cluster.
+func (gs Grants) AllowAllDatabases(permission Permission) bool {
+	return gs.Allow(AllDatabases, permission)
+}
+
+// Role represents the role which defines the grants of databases
+type Role struct {
+	Name   string `json:"name"`   // role name
+	Grants Grants `json:"grants"` // grants of databases
+}
+
+// User represents the user which can login and access databases by roles
+type User struct {
+	UserName     string   `json:"username"`               // user name
+	Password     string   `json:"password,omitempty"`     // plain password, only used when create/update user
+	PasswordHash string   `json:"passwordHash,omitempty"` // hash of password with salt
+	Salt         string   `json:"salt,omitempty"`         // salt of password hash
+	Roles        []string `json:"roles"`                  // role names
+}
+
+// APIKey represents the api key for ingestion agents which access databases by roles
+type APIKey struct {
+	Name    string   `json:"name"`              // api key name
+	Key     string   `json:"key,omitempty"`     // plain key, only returned when create api key
+	KeyHash string   `json:"keyHash,omitempty"` // hash of key secret
+	Roles   []string `json:"roles"`             // role names
+}
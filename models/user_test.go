@@ -0,0 +1,62 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermission_Includes(t *testing.T) {
+	assert.True(t, AdminPermission.Includes(WritePermission))
+	assert.True(t, AdminPermission.Includes(ReadPermission))
+	assert.True(t, WritePermission.Includes(ReadPermission))
+	assert.True(t, ReadPermission.Includes(ReadPermission))
+	assert.False(t, ReadPermission.Includes(WritePermission))
+	assert.False(t, WritePermission.Includes(AdminPermission))
+	assert.False(t, AdminPermission.Includes("unknown"))
+	assert.False(t, Permission("unknown").IsValid())
+}
+
+func TestGrants_Allow(t *testing.T) {
+	grants := Grants{
+		{Database: "db1", Permission: ReadPermission},
+		{Database: "db2", Permission: WritePermission},
+	}
+	assert.True(t, grants.Allow("db1", ReadPermission))
+	assert.False(t, grants.Allow("db1", WritePermission))
+	assert.True(t, grants.Allow("db2", ReadPermission))
+	assert.True(t, grants.Allow("db2", WritePermission))
+	assert.False(t, grants.Allow("db3", ReadPermission))
+	assert.False(t, grants.Allow("", ReadPermission))
+	assert.False(t, grants.Allow(AllDatabases, ReadPermission))
+	assert.False(t, grants.AllowAllDatabases(ReadPermission))
+
+	// admin of single database
+	grants = Grants{{Database: "db1", Permission: AdminPermission}}
+	assert.True(t, grants.Allow("db1", AdminPermission))
+	assert.False(t, grants.Allow("", AdminPermission))
+	assert.False(t, grants.AllowAllDatabases(AdminPermission))
+	assert.False(t, grants.AllowAllDatabases(ReadPermission))
+
+	grants = Grants{{Database: AllDatabases, Permission: AdminPermission}}
+	assert.True(t, grants.Allow("db3", AdminPermission))
+	assert.True(t, grants.Allow("", ReadPermission))
+	assert.True(t, grants.AllowAllDatabases(AdminPermission))
+}
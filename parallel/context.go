@@ -20,6 +20,7 @@ package parallel
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"go.uber.org/atomic"
 
@@ -54,6 +55,8 @@ type ExecuteContext interface {
 type StorageExecuteContext interface {
 	// QueryStats returns the storage query stats
 	QueryStats() *models.StorageStats
+	// Release releases the resources(like data families) retained by storage query
+	Release()
 }
 
 // BrokerExecuteContext represents the broker execute context
@@ -64,6 +67,10 @@ type BrokerExecuteContext interface {
 	ResultCh() chan *series.TimeSeriesEvent
 	// ResultSet returns the final result set
 	ResultSet() (*models.ResultSet, error)
+	// Stream sets the handler which handles the final time series with columnar format as soon as it's evaluated,
+	// if query has order by clause, handles the time series after all time series sorted when gets the result set,
+	// the handled time series are not kept in the result set.
+	Stream(handler SeriesHandler)
 }
 
 type brokerExecuteContext struct {
@@ -71,13 +78,16 @@ type brokerExecuteContext struct {
 	err        error
 	query      *stmt.Query
 	expression aggregation.Expression
+	processor  *seriesProcessor
 	resultSet  *models.ResultSet
 
 	stats     *models.QueryStats
 	startTime int64
 }
 
-func NewBrokerExecuteContext(startTime int64, query *stmt.Query) BrokerExecuteContext {
+// NewBrokerExecuteContext creates the broker execute context,
+// maxPoints is the maximum number of points evaluated by query, 0 means no limit.
+func NewBrokerExecuteContext(startTime int64, query *stmt.Query, maxPoints int) BrokerExecuteContext {
 	ctx := &brokerExecuteContext{
 		startTime: startTime,
 		resultCh:  make(chan *series.TimeSeriesEvent),
@@ -85,12 +95,23 @@ func NewBrokerExecuteContext(startTime int64, query *stmt.Query) BrokerExecuteCo
 		query:     query,
 	}
 	if query != nil {
-		ctx.expression = aggregation.NewExpression(query.TimeRange, query.Interval.Int64(), query.SelectItems)
+		ctx.processor = newSeriesProcessor(query)
+		ctx.processor.maxPoints = maxPoints
+		ctx.expression = aggregation.NewExpression(query.TimeRange, query.Interval.Int64(), ctx.processor.selectItems())
 	}
 	return ctx
 }
 
 func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
+	if len(event.Warnings) > 0 {
+		// partial result, some shards are not queried
+		for _, warning := range event.Warnings {
+			c.resultSet.AddWarning(warning)
+		}
+		if event.Err == nil && len(event.SeriesList) == 0 {
+			return
+		}
+	}
 	//TODO merge stats for cross idc query?
 	c.stats = event.Stats
 	if event.Err != nil {
@@ -114,22 +135,9 @@ func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
 				tags[tagKey] = tagValues[idx]
 			}
 		}
-		timeSeries := models.NewSeries(tags)
-		c.resultSet.AddSeries(timeSeries)
 		c.expression.Eval(ts)
-		rs := c.expression.ResultSet()
-		for fieldName, values := range rs {
-			if values == nil {
-				continue
-			}
-			points := models.NewPoints()
-			it := values.Iterator()
-			for it.HasNext() {
-				slot, val := it.Next()
-				points.AddPoint(int64(slot)*c.query.Interval.Int64()+c.query.TimeRange.Start, val)
-			}
-			timeSeries.AddField(fieldName, points)
-		}
+		// filter/fill/build time series with having clause and fill policy
+		c.processor.process(tags, c.expression.ResultSet())
 		c.expression.Reset()
 	}
 	if c.stats != nil {
@@ -148,13 +156,32 @@ func (c *brokerExecuteContext) ResultCh() chan *series.TimeSeriesEvent {
 	return c.resultCh
 }
 
+func (c *brokerExecuteContext) Stream(handler SeriesHandler) {
+	if c.processor != nil {
+		c.processor.handler = handler
+	}
+}
+
 func (c *brokerExecuteContext) ResultSet() (*models.ResultSet, error) {
+	if c.processor != nil {
+		if c.processor.handler != nil {
+			// handle the pending time series for streaming
+			_ = c.processor.flush()
+		}
+		if c.processor.err != nil && c.err == nil {
+			c.err = c.processor.err
+		}
+	}
 	if c.err == nil {
 		c.resultSet.MetricName = c.query.MetricName
 		c.resultSet.StartTime = c.query.TimeRange.Start
 		c.resultSet.EndTime = c.query.TimeRange.End
 		c.resultSet.Interval = c.query.Interval.Int64()
 	}
+	if c.processor != nil && c.processor.handler == nil {
+		// sort time series by order by clause, then cut by limit
+		c.resultSet.Series = c.processor.resultSeries()
+	}
 	if c.stats != nil {
 		c.stats.Cost = timeutil.NowNano() - c.startTime
 	}
@@ -166,31 +193,53 @@ func (c *brokerExecuteContext) ResultSet() (*models.ResultSet, error) {
 type JobContext interface {
 	Plan() *models.PhysicalPlan
 	Query() *stmt.Query
+	// SQL returns the sql text of query
+	SQL() string
+	// StartTime returns the start time of job
+	StartTime() int64
 	Emit(event *series.TimeSeriesEvent)
 	Complete()
+	// Cancel cancels the job context, completes the job with err if job not completed
+	Cancel(err error)
 	ResultSet() chan *series.TimeSeriesEvent
 	Context() context.Context
 	Completed() bool
+	// Failover returns the replica failover option of leaf tasks, nil means no failover
+	Failover() *FailoverOption
+	// SetFailover sets the replica failover option of leaf tasks
+	SetFailover(option *FailoverOption)
 }
 
 type jobContext struct {
 	resultSet chan *series.TimeSeriesEvent
 	plan      *models.PhysicalPlan
 	query     *stmt.Query
+	sql       string
+	startTime int64
 	ctx       context.Context
 	cancel    context.CancelFunc
+	failover  *FailoverOption
 
-	completed atomic.Bool
+	// mutex guards the result set channel, so that late events are dropped after job completed
+	mutex      sync.RWMutex
+	completed  atomic.Bool
+	cancelOnce sync.Once
+	canceled   chan struct{}
 }
 
-func NewJobContext(ctx context.Context, resultSet chan *series.TimeSeriesEvent, plan *models.PhysicalPlan, query *stmt.Query) JobContext {
+func NewJobContext(ctx context.Context, resultSet chan *series.TimeSeriesEvent,
+	plan *models.PhysicalPlan, query *stmt.Query, sql string,
+) JobContext {
 	c, cancel := context.WithCancel(ctx)
 	return &jobContext{
 		resultSet: resultSet,
 		plan:      plan,
 		query:     query,
+		sql:       sql,
+		startTime: timeutil.Now(),
 		ctx:       c,
 		cancel:    cancel,
+		canceled:  make(chan struct{}),
 	}
 }
 
@@ -201,13 +250,42 @@ func (c *jobContext) Plan() *models.PhysicalPlan {
 func (c *jobContext) Query() *stmt.Query {
 	return c.query
 }
+
+// SQL returns the sql text of query
+func (c *jobContext) SQL() string {
+	return c.sql
+}
+
+// StartTime returns the start time of job
+func (c *jobContext) StartTime() int64 {
+	return c.startTime
+}
+
+// Cancel cancels the job context, completes the job with err if job not completed
+func (c *jobContext) Cancel(err error) {
+	c.cancel()
+	// unblocks the pending emitters which hold the read lock
+	c.cancelOnce.Do(func() {
+		close(c.canceled)
+	})
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.completed.CAS(false, true) {
+		c.resultSet <- &series.TimeSeriesEvent{Err: err}
+		close(c.resultSet)
+	}
+}
 func (c *jobContext) ResultSet() chan *series.TimeSeriesEvent {
 	return c.resultSet
 }
 
 func (c *jobContext) Complete() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.completed.CAS(false, true) {
-		//TODO send result
 		close(c.resultSet)
 	}
 }
@@ -215,14 +293,34 @@ func (c *jobContext) Completed() bool {
 	return c.completed.Load()
 }
 
+// Emit emits the event into result set, drops it if job completed or canceled
 func (c *jobContext) Emit(event *series.TimeSeriesEvent) {
-	c.resultSet <- event
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.completed.Load() {
+		return
+	}
+	select {
+	case c.resultSet <- event:
+	case <-c.canceled:
+	}
 }
 
 func (c *jobContext) Context() context.Context {
 	return c.ctx
 }
 
+// Failover returns the replica failover option of leaf tasks, nil means no failover
+func (c *jobContext) Failover() *FailoverOption {
+	return c.failover
+}
+
+// SetFailover sets the replica failover option of leaf tasks
+func (c *jobContext) SetFailover(option *FailoverOption) {
+	c.failover = option
+}
+
 // TaskContext represents the task context for distribution query and computing
 type TaskContext interface {
 	// TaskID returns the task id under current node
@@ -237,6 +335,8 @@ type TaskContext interface {
 	ReceiveResult(resp *pb.TaskResponse)
 	// Completed returns if the task is completes
 	Completed() bool
+	// PendingResults returns the num. of pending sub task results
+	PendingResults() int32
 	// Error returns task's error
 	Error() error
 }
@@ -322,3 +422,8 @@ func (c *taskContext) Error() error {
 func (c *taskContext) Completed() bool {
 	return c.expectResults.Load() == 0
 }
+
+// PendingResults returns the num. of pending sub task results
+func (c *taskContext) PendingResults() int32 {
+	return c.expectResults.Load()
+}
@@ -18,8 +18,11 @@
 package parallel
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +47,7 @@ func TestBrokerExecuteContext(t *testing.T) {
 	assert.NoError(t, err)
 	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
 
-	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
 	brokerCtx := ctx.(*brokerExecuteContext)
 	brokerCtx.expression = expression
 	assert.NotNil(t, brokerCtx.expression)
@@ -81,7 +84,7 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.NoError(t, err)
 	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
 
-	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
 	brokerCtx := ctx.(*brokerExecuteContext)
 	brokerCtx.expression = expression
 	assert.NotNil(t, brokerCtx.expression)
@@ -107,7 +110,7 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotNil(t, rs.Series[0].Fields["f"])
 
-	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
 	brokerCtx = ctx.(*brokerExecuteContext)
 	brokerCtx.expression = expression
 	assert.NotNil(t, brokerCtx.expression)
@@ -123,10 +126,165 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.Len(t, rs.Series, 0)
 }
 
+func TestBrokerExecuteContext_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+
+	q, err := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+	assert.NoError(t, err)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
+	var handled []*models.ColumnSeries
+	ctx.Stream(func(series *models.ColumnSeries) error {
+		handled = append(handled, series)
+		return fmt.Errorf("err")
+	})
+	brokerCtx := ctx.(*brokerExecuteContext)
+	brokerCtx.expression = expression
+	it := series.NewMockGroupedIterator(ctrl)
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{
+		SeriesList: []series.GroupedIterator{it},
+	})
+	rs, err := ctx.ResultSet()
+	ctx.Complete(nil)
+	assert.Error(t, err)
+	assert.Empty(t, rs.Series)
+	assert.Len(t, handled, 1)
+	assert.Equal(t, models.FloatValues{10}, handled[0].Fields["f"])
+	assert.Equal(t, []int64{query.TimeRange.Start + query.Interval.Int64()}, handled[0].Timestamps)
+
+	// nil processor
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), nil, 0)
+	ctx.Stream(func(series *models.ColumnSeries) error {
+		return nil
+	})
+}
+
+func TestBrokerExecuteContext_maxPoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+
+	q, err := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+	assert.NoError(t, err)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, 1)
+	brokerCtx := ctx.(*brokerExecuteContext)
+	brokerCtx.expression = expression
+	it := series.NewMockGroupedIterator(ctrl)
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	values.SetValue(2, 10.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{
+		SeriesList: []series.GroupedIterator{it},
+	})
+	rs, err := ctx.ResultSet()
+	assert.Error(t, err)
+	assert.Empty(t, rs.Series)
+}
+
 func TestBrokerExecuteContext_ResultSet(t *testing.T) {
-	ctx := NewBrokerExecuteContext(timeutil.NowNano(), nil)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), nil, 0)
 	ctx.Complete(fmt.Errorf("err"))
 	rs, err := ctx.ResultSet()
 	assert.Error(t, err)
 	assert.NotNil(t, rs)
 }
+
+func TestBrokerExecuteContext_Warnings(t *testing.T) {
+	q, err := sql.Parse("select f from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
+	stats := &models.QueryStats{}
+	ctx.Emit(&series.TimeSeriesEvent{Stats: stats})
+	ctx.Emit(&series.TimeSeriesEvent{
+		Warnings: []models.ShardWarning{{ShardID: 1, Message: "no available replica"}},
+	})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.True(t, rs.Partial())
+	assert.Equal(t, []models.ShardWarning{{ShardID: 1, Message: "no available replica"}}, rs.Warnings)
+	// warnings event doesn't overwrite the stats
+	assert.Equal(t, stats, rs.Stats)
+}
+
+func TestJobContext_Failover(t *testing.T) {
+	jobCtx := NewJobContext(context.TODO(), nil, nil, nil, "select f from cpu")
+	assert.Nil(t, jobCtx.Failover())
+	option := &FailoverOption{RetryTimeout: time.Second}
+	jobCtx.SetFailover(option)
+	assert.Equal(t, option, jobCtx.Failover())
+}
+
+func TestJobContext_Cancel(t *testing.T) {
+	ch := make(chan *series.TimeSeriesEvent)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "select f from cpu")
+	assert.Equal(t, "select f from cpu", jobCtx.SQL())
+	assert.True(t, jobCtx.StartTime() > 0)
+	go func() {
+		jobCtx.Cancel(errJobKilled)
+	}()
+	event := <-ch
+	assert.Equal(t, errJobKilled, event.Err)
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.True(t, jobCtx.Completed())
+	assert.Equal(t, context.Canceled, jobCtx.Context().Err())
+	// cancel completed job
+	jobCtx.Cancel(errJobKilled)
+}
+
+func TestJobContext_Emit_after_Cancel(t *testing.T) {
+	ch := make(chan *series.TimeSeriesEvent)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "select f from cpu")
+	go func() {
+		jobCtx.Cancel(errJobKilled)
+	}()
+	for range ch {
+	}
+	// late response after job canceled, drop it
+	assert.NotPanics(t, func() {
+		jobCtx.Emit(&series.TimeSeriesEvent{})
+		jobCtx.Complete()
+	})
+}
+
+func TestJobContext_Emit_blocked_Cancel(t *testing.T) {
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "select f from cpu")
+	jobCtx.Emit(&series.TimeSeriesEvent{})
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		// blocked until job canceled
+		jobCtx.Emit(&series.TimeSeriesEvent{})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	go func() {
+		jobCtx.Cancel(errJobKilled)
+	}()
+	wait.Wait()
+	<-ch
+	event := <-ch
+	assert.Equal(t, errJobKilled, event.Err)
+	_, ok := <-ch
+	assert.False(t, ok)
+}
@@ -21,6 +21,26 @@ import (
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
+// getStorageInterval returns the storage interval which query data from,
+// picks the largest storage interval which query interval is a multiple of,
+// if not match returns the smallest storage interval(write interval).
+// NOTICE: storage intervals must be sorted by interval asc.
+func getStorageInterval(queryInterval timeutil.Interval, storageIntervals []timeutil.Interval) timeutil.Interval {
+	if len(storageIntervals) == 0 {
+		return 0
+	}
+	interval := storageIntervals[0]
+	if queryInterval <= 0 {
+		return interval
+	}
+	for _, storageInterval := range storageIntervals[1:] {
+		if storageInterval <= queryInterval && queryInterval.Int64()%storageInterval.Int64() == 0 {
+			interval = storageInterval
+		}
+	}
+	return interval
+}
+
 // downSamplingTimeRange returns down sampling time range and interval ratio
 func downSamplingTimeRange(queryInterval,
 	storageInterval timeutil.Interval,
@@ -40,3 +40,22 @@ func Test_downSamplingTimeRange(t *testing.T) {
 		End:   60 * timeutil.OneSecond,
 	}, timeRange)
 }
+
+func Test_getStorageInterval(t *testing.T) {
+	assert.Equal(t, timeutil.Interval(0), getStorageInterval(timeutil.Interval(timeutil.OneMinute), nil))
+	intervals := []timeutil.Interval{
+		timeutil.Interval(10 * timeutil.OneSecond),
+		timeutil.Interval(5 * timeutil.OneMinute),
+		timeutil.Interval(timeutil.OneHour),
+	}
+	// use write interval if query interval not set
+	assert.Equal(t, intervals[0], getStorageInterval(0, intervals))
+	// query interval < rollup interval
+	assert.Equal(t, intervals[0], getStorageInterval(timeutil.Interval(timeutil.OneMinute), intervals))
+	// query interval not a multiple of rollup interval
+	assert.Equal(t, intervals[0], getStorageInterval(timeutil.Interval(7*timeutil.OneMinute), intervals))
+	assert.Equal(t, intervals[1], getStorageInterval(timeutil.Interval(10*timeutil.OneMinute), intervals))
+	assert.Equal(t, intervals[1], getStorageInterval(timeutil.Interval(90*timeutil.OneMinute), intervals))
+	// pick the largest rollup interval
+	assert.Equal(t, intervals[2], getStorageInterval(timeutil.Interval(timeutil.OneDay), intervals))
+}
@@ -57,6 +57,17 @@ type ExecutorFactory interface {
 		jobManager JobManager,
 	) BrokerExecutor
 
+	// NewBrokerQueryExecutor creates the broker executor based on parsed query statement
+	NewBrokerQueryExecutor(
+		ctx context.Context,
+		databaseName string,
+		query *stmt.Query,
+		replicaStateMachine replica.StatusStateMachine,
+		nodeStateMachine broker.NodeStateMachine,
+		databaseStateMachine database.DBStateMachine,
+		jobManager JobManager,
+	) BrokerExecutor
+
 	// NewMetadataBrokerExecutor creates the metadata executor in broker side
 	NewMetadataBrokerExecutor(
 		ctx context.Context,
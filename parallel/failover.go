@@ -0,0 +1,530 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parallel
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/series"
+)
+
+var failoverLogger = logger.GetLogger("parallel", "Failover")
+
+var (
+	errNoAvailableReplica = errors.New("no available replica")
+	errLeafTaskTimeout    = errors.New("leaf task timeout")
+)
+
+// FailoverOption represents the replica failover option of the leaf tasks for distribution query
+type FailoverOption struct {
+	// Replicas represents the queryable replicas of each shard, shard id => storage nodes ordered by priority
+	Replicas map[int32][]string
+	// RetryTimeout retries the shards of leaf task on other replica if it doesn't respond in time, 0 means no timeout
+	RetryTimeout time.Duration
+	// HedgeDelay sends hedged request to other replica if leaf task doesn't respond in time, 0 means disable
+	HedgeDelay time.Duration
+}
+
+// bypassIntermediates makes the leaf tasks of physical plan respond to root directly,
+// because root must track the leaf tasks for retrying the shards of failed or slow leaf task on other replica,
+// the results of leaf tasks are merged by root instead of intermediate nodes.
+func bypassIntermediates(plan *models.PhysicalPlan) {
+	if len(plan.Intermediates) == 0 {
+		return
+	}
+	var receivers []models.Node
+	if root, err := models.ParseNode(plan.Root.Indicator); err == nil {
+		receivers = []models.Node{*root}
+	}
+	plan.Intermediates = nil
+	plan.Root.NumOfTask = int32(len(plan.Leafs))
+	for idx := range plan.Leafs {
+		plan.Leafs[idx].Parent = plan.Root.Indicator
+		plan.Leafs[idx].Receivers = receivers
+	}
+}
+
+// shardTask represents the query state of the shard
+type shardTask struct {
+	leaf     int      // index of leaf node in physical plan
+	replicas []string // candidate storage nodes
+	next     int      // index of next candidate storage node
+	inflight int      // num. of in-flight leaf tasks which query this shard
+	done     bool
+	err      error // the last error of leaf task which queries this shard
+}
+
+// nextReplica returns the next candidate storage node, returns empty if no available replica
+func (s *shardTask) nextReplica() string {
+	if s.next >= len(s.replicas) {
+		return ""
+	}
+	node := s.replicas[s.next]
+	s.next++
+	return node
+}
+
+// leafAttempt represents the in-flight leaf task which queries some shards on storage node
+type leafAttempt struct {
+	taskID   string
+	node     string
+	shardIDs []int32
+	timers   []*time.Timer
+}
+
+// leafTaskContext represents the leaf task context which is tracked by failover task context,
+// each leaf task has its own task id for identifying which replica responds.
+type leafTaskContext struct {
+	TaskContext
+	taskID string
+}
+
+// TaskID returns the task id of leaf task
+func (c *leafTaskContext) TaskID() string {
+	return c.taskID
+}
+
+// failoverTaskContext represents the root task context which sends leaf tasks to storage nodes directly,
+// 1. tracks the leaf tasks by shard, retries the shards of failed or slow leaf task on other replica
+// 2. sends hedged request to other replica if leaf task doesn't respond in hedge delay, the first response wins
+// 3. completes the job with partial result and shard warnings if some shards have no available replica
+type failoverTaskContext struct {
+	*taskContext
+
+	jobCtx      JobContext
+	req         *pb.TaskRequest
+	option      *FailoverOption
+	taskManager TaskManager
+	removeJob   func()
+
+	mutex     sync.Mutex
+	shards    map[int32]*shardTask
+	attempts  map[string]*leafAttempt
+	warnings  []models.ShardWarning
+	lastErr   error
+	succeeded bool
+	finished  bool
+	closed    chan struct{}
+}
+
+// newFailoverTaskContext creates the root task context which supports replica failover of leaf tasks
+func newFailoverTaskContext(taskID string, jobCtx JobContext, req *pb.TaskRequest, option *FailoverOption,
+	merger ResultMerger, taskManager TaskManager, removeJob func(),
+) *failoverTaskContext {
+	shards := make(map[int32]*shardTask)
+	for idx, leaf := range jobCtx.Plan().Leafs {
+		for _, shardID := range leaf.ShardIDs {
+			// the storage node of physical plan is the first choice
+			replicas := []string{leaf.Indicator}
+			for _, node := range option.Replicas[shardID] {
+				if node != leaf.Indicator {
+					replicas = append(replicas, node)
+				}
+			}
+			shards[shardID] = &shardTask{leaf: idx, replicas: replicas, next: 1}
+		}
+	}
+	return &failoverTaskContext{
+		taskContext: newTaskContext(taskID, RootTask, "", "", int32(len(shards)), merger).(*taskContext),
+		jobCtx:      jobCtx,
+		req:         req,
+		option:      option,
+		taskManager: taskManager,
+		removeJob:   removeJob,
+		shards:      shards,
+		attempts:    make(map[string]*leafAttempt),
+		closed:      make(chan struct{}),
+	}
+}
+
+// start sends the leaf tasks of physical plan, fails over to other replica if send fail,
+// returns err if no shard can be queried.
+func (c *failoverTaskContext) start() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, leaf := range c.jobCtx.Plan().Leafs {
+		if len(leaf.ShardIDs) == 0 {
+			continue
+		}
+		if err := c.send(leaf.Indicator, leaf.ShardIDs, false); err != nil {
+			c.failover(leaf.ShardIDs, err)
+		}
+	}
+	if c.Completed() {
+		// all shards fail before any response, the consumer of job isn't started, so returns err directly
+		c.release()
+		c.merger.close()
+		if c.lastErr == nil {
+			return errNoAvailableReplica
+		}
+		return c.lastErr
+	}
+	go c.watch()
+	return nil
+}
+
+// ReceiveResult receives the response of leaf task, merges the result or fails over the shards to other replica
+func (c *failoverTaskContext) ReceiveResult(resp *pb.TaskResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.finished {
+		return
+	}
+	attempt, ok := c.attempts[resp.TaskID]
+	if !ok {
+		// leaf task is timeout or canceled, ignore the late response
+		return
+	}
+	c.removeAttempt(attempt)
+
+	switch {
+	case len(resp.ErrMsg) > 0:
+		failoverLogger.Warn("leaf task fail, retry on other replica",
+			logger.String("target", attempt.node), logger.Any("shards", attempt.shardIDs),
+			logger.String("err", resp.ErrMsg))
+		c.failover(attempt.shardIDs, errors.New(resp.ErrMsg))
+	case c.acceptable(attempt):
+		c.merger.merge(resp)
+		c.succeeded = true
+		for _, shardID := range attempt.shardIDs {
+			c.shards[shardID].done = true
+			c.expectResults.Dec()
+		}
+		// cancels the other in-flight leaf tasks(hedged request) of completed shards
+		c.cancelRedundant(attempt.shardIDs)
+	default:
+		// some shards are completed by other replica, discards the response for avoiding duplicate data
+		c.failover(attempt.shardIDs, nil)
+	}
+
+	if c.Completed() {
+		c.finish()
+	}
+}
+
+// PendingResults returns the num. of pending leaf tasks of physical plan
+func (c *failoverTaskContext) PendingResults() int32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	leafs := make(map[int]struct{})
+	for _, shard := range c.shards {
+		if !shard.done {
+			leafs[shard.leaf] = struct{}{}
+		}
+	}
+	return int32(len(leafs))
+}
+
+// acceptable checks if all shards of leaf task are not completed
+func (c *failoverTaskContext) acceptable(attempt *leafAttempt) bool {
+	for _, shardID := range attempt.shardIDs {
+		if c.shards[shardID].done {
+			return false
+		}
+	}
+	return true
+}
+
+// send sends the leaf task which queries the shards to storage node, returns err if send fail
+func (c *failoverTaskContext) send(node string, shardIDs []int32, hedged bool) error {
+	plan := *c.jobCtx.Plan()
+	// only keeps the leaf node of this leaf task
+	plan.Leafs = []models.Leaf{{
+		BaseNode: models.BaseNode{
+			Parent:    plan.Root.Indicator,
+			Indicator: node,
+		},
+		Receivers: plan.Leafs[c.shards[shardIDs[0]].leaf].Receivers,
+		ShardIDs:  shardIDs,
+	}}
+	taskID := c.taskManager.AllocTaskID()
+	req := &pb.TaskRequest{
+		JobID:        c.req.JobID,
+		ParentTaskID: taskID,
+		PhysicalPlan: encoding.JSONMarshal(&plan),
+		Payload:      c.req.Payload,
+	}
+	// submits task before sending request, because response maybe received before sending returns
+	c.taskManager.Submit(&leafTaskContext{TaskContext: c, taskID: taskID})
+	if err := c.taskManager.SendRequest(node, req); err != nil {
+		c.taskManager.Complete(taskID)
+		failoverLogger.Warn("send leaf task fail", logger.String("target", node),
+			logger.Any("shards", shardIDs), logger.Error(err))
+		return err
+	}
+
+	attempt := &leafAttempt{
+		taskID:   taskID,
+		node:     node,
+		shardIDs: shardIDs,
+	}
+	for _, shardID := range shardIDs {
+		c.shards[shardID].inflight++
+	}
+	if c.option.RetryTimeout > 0 {
+		attempt.timers = append(attempt.timers, time.AfterFunc(c.option.RetryTimeout, func() {
+			c.onTimeout(taskID)
+		}))
+	}
+	if !hedged && c.option.HedgeDelay > 0 {
+		attempt.timers = append(attempt.timers, time.AfterFunc(c.option.HedgeDelay, func() {
+			c.onHedge(taskID)
+		}))
+	}
+	c.attempts[taskID] = attempt
+	return nil
+}
+
+// failover retries the shards which are not completed and not queried by other leaf task on other replica
+func (c *failoverTaskContext) failover(shardIDs []int32, err error) {
+	var retryShardIDs []int32
+	for _, shardID := range shardIDs {
+		shard := c.shards[shardID]
+		if err != nil {
+			shard.err = err
+			c.lastErr = err
+		}
+		if shard.done || shard.inflight > 0 {
+			continue
+		}
+		retryShardIDs = append(retryShardIDs, shardID)
+	}
+	c.dispatch(retryShardIDs, false)
+}
+
+// dispatch sends the shards to next replica, groups the shards by storage node,
+// if no available replica, marks the shard failure except hedged request.
+func (c *failoverTaskContext) dispatch(shardIDs []int32, hedged bool) {
+	pending := shardIDs
+	for len(pending) > 0 {
+		targets := make(map[string][]int32)
+		for _, shardID := range pending {
+			node := c.shards[shardID].nextReplica()
+			if node == "" {
+				if !hedged {
+					c.fail(shardID)
+				}
+				continue
+			}
+			targets[node] = append(targets[node], shardID)
+		}
+		pending = nil
+		for node, ids := range targets {
+			if err := c.send(node, ids, hedged); err != nil {
+				for _, shardID := range ids {
+					c.shards[shardID].err = err
+				}
+				c.lastErr = err
+				pending = append(pending, ids...)
+			}
+		}
+	}
+}
+
+// fail marks the shard failure because no available replica
+func (c *failoverTaskContext) fail(shardID int32) {
+	shard := c.shards[shardID]
+	shard.done = true
+	c.expectResults.Dec()
+	msg := errNoAvailableReplica.Error()
+	if shard.err != nil {
+		msg = fmt.Sprintf("%s, last error: %s", msg, shard.err)
+	}
+	if c.lastErr == nil {
+		c.lastErr = errNoAvailableReplica
+	}
+	c.warnings = append(c.warnings, models.ShardWarning{ShardID: shardID, Message: msg})
+}
+
+// onTimeout retries the shards of leaf task on other replica if leaf task doesn't respond in retry timeout
+func (c *failoverTaskContext) onTimeout(taskID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	attempt, ok := c.attempts[taskID]
+	if c.finished || !ok {
+		return
+	}
+	failoverLogger.Warn("leaf task timeout, retry on other replica",
+		logger.String("target", attempt.node), logger.Any("shards", attempt.shardIDs))
+	c.removeAttempt(attempt)
+	c.cancel(attempt)
+	c.failover(attempt.shardIDs, errLeafTaskTimeout)
+
+	if c.Completed() {
+		c.finish()
+	}
+}
+
+// onHedge sends hedged request to other replica if leaf task doesn't respond in hedge delay
+func (c *failoverTaskContext) onHedge(taskID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	attempt, ok := c.attempts[taskID]
+	if c.finished || !ok {
+		return
+	}
+	var shardIDs []int32
+	for _, shardID := range attempt.shardIDs {
+		if !c.shards[shardID].done {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	c.dispatch(shardIDs, true)
+}
+
+// cancelRedundant cancels the in-flight leaf tasks which query the completed shards,
+// retries the other shards of these leaf tasks if need.
+func (c *failoverTaskContext) cancelRedundant(shardIDs []int32) {
+	completed := make(map[int32]struct{}, len(shardIDs))
+	for _, shardID := range shardIDs {
+		completed[shardID] = struct{}{}
+	}
+	var redundant []*leafAttempt
+	for _, attempt := range c.attempts {
+		for _, shardID := range attempt.shardIDs {
+			if _, ok := completed[shardID]; ok {
+				redundant = append(redundant, attempt)
+				break
+			}
+		}
+	}
+	for _, attempt := range redundant {
+		c.removeAttempt(attempt)
+		c.cancel(attempt)
+	}
+	for _, attempt := range redundant {
+		c.failover(attempt.shardIDs, nil)
+	}
+}
+
+// removeAttempt removes the in-flight leaf task, the late response of it will be ignored
+func (c *failoverTaskContext) removeAttempt(attempt *leafAttempt) {
+	delete(c.attempts, attempt.taskID)
+	c.taskManager.Complete(attempt.taskID)
+	for _, timer := range attempt.timers {
+		timer.Stop()
+	}
+	for _, shardID := range attempt.shardIDs {
+		c.shards[shardID].inflight--
+	}
+}
+
+// cancel sends cancel request to storage node for stopping the leaf task
+func (c *failoverTaskContext) cancel(attempt *leafAttempt) {
+	if err := c.taskManager.SendRequest(attempt.node, &pb.TaskRequest{
+		JobID:        c.req.JobID,
+		ParentTaskID: attempt.taskID,
+		RequestType:  pb.RequestType_Cancel,
+	}); err != nil {
+		// storage node stops scanning when query timeout, so just log it
+		failoverLogger.Error("send cancel request", logger.String("target", attempt.node),
+			logger.String("taskID", attempt.taskID), logger.Error(err))
+	}
+}
+
+// release cancels all in-flight leaf tasks and removes the root task
+func (c *failoverTaskContext) release() {
+	c.finished = true
+	for _, attempt := range c.attempts {
+		c.removeAttempt(attempt)
+		c.cancel(attempt)
+	}
+	c.taskManager.Complete(c.taskID)
+}
+
+// kill cancels all in-flight leaf tasks by their own task id when job is killed
+func (c *failoverTaskContext) kill() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.finished {
+		return
+	}
+	c.release()
+}
+
+// finish completes the job, sends the partial result with shard warnings,
+// if all shards fail, completes the job with the last error.
+func (c *failoverTaskContext) finish() {
+	c.release()
+	close(c.closed)
+
+	c.merger.close()
+	if !c.jobCtx.Completed() {
+		switch {
+		case !c.succeeded:
+			c.err = c.lastErr
+			c.jobCtx.Emit(&series.TimeSeriesEvent{Err: c.err})
+		case len(c.warnings) > 0:
+			sort.Slice(c.warnings, func(i, j int) bool {
+				return c.warnings[i].ShardID < c.warnings[j].ShardID
+			})
+			c.jobCtx.Emit(&series.TimeSeriesEvent{Warnings: c.warnings})
+		}
+		c.jobCtx.Complete()
+	}
+	c.removeJob()
+}
+
+// watch completes the job with partial result when query timeout,
+// or cancels the in-flight leaf tasks when job is killed.
+func (c *failoverTaskContext) watch() {
+	select {
+	case <-c.jobCtx.Context().Done():
+		c.expire()
+	case <-c.closed:
+	}
+}
+
+// expire handles the job context done, completes the job with the shards which are not completed as warnings
+func (c *failoverTaskContext) expire() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.finished {
+		return
+	}
+	if c.jobCtx.Completed() {
+		// job is killed, the result set is closed
+		c.release()
+		return
+	}
+	err := c.jobCtx.Context().Err()
+	for shardID, shard := range c.shards {
+		if shard.done {
+			continue
+		}
+		shard.err = err
+		c.lastErr = err
+		c.fail(shardID)
+	}
+	c.finish()
+}
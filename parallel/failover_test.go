@@ -0,0 +1,383 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/series"
+)
+
+const (
+	failoverNodeA = "1.1.1.1:9000"
+	failoverNodeB = "1.1.1.2:9000"
+	failoverNodeC = "1.1.1.4:9000"
+)
+
+// sentRequest represents the task request sent to storage node
+type sentRequest struct {
+	node string
+	req  *pb.TaskRequest
+}
+
+// failoverSender records the task requests, mocks send failure of storage node
+type failoverSender struct {
+	mutex sync.Mutex
+	seq   int
+	reqs  []sentRequest
+	fail  map[string]bool
+}
+
+func (s *failoverSender) alloc() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.seq++
+	return fmt.Sprintf("task-%d", s.seq)
+}
+
+func (s *failoverSender) send(node string, req *pb.TaskRequest) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.fail[node] {
+		return errTaskSend
+	}
+	s.reqs = append(s.reqs, sentRequest{node: node, req: req})
+	return nil
+}
+
+// find returns the task id and shards of the last request sent to storage node by request type
+func (s *failoverSender) find(node string, requestType pb.RequestType) (taskID string, shardIDs []int32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, r := range s.reqs {
+		if r.node != node || r.req.RequestType != requestType {
+			continue
+		}
+		taskID = r.req.ParentTaskID
+		if requestType == pb.RequestType_Data {
+			plan := &models.PhysicalPlan{}
+			_ = encoding.JSONUnmarshal(r.req.PhysicalPlan, plan)
+			shardIDs = plan.Leafs[0].ShardIDs
+		}
+	}
+	return
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	for i := 0; i < 500; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	t.Fatal("wait condition timeout")
+}
+
+type failoverJob struct {
+	taskCtx *failoverTaskContext
+	jobCtx  JobContext
+	sender  *failoverSender
+	merger  *MockResultMerger
+	removed bool
+	events  chan []*series.TimeSeriesEvent
+}
+
+// newFailoverJob creates the failover job, leaf A queries shard 1/2, leaf B queries shard 3,
+// storage node C has the replicas of all shards.
+func newFailoverJob(ctx context.Context, ctrl *gomock.Controller, option *FailoverOption) *failoverJob {
+	job := &failoverJob{
+		sender: &failoverSender{fail: make(map[string]bool)},
+		merger: NewMockResultMerger(ctrl),
+		events: make(chan []*series.TimeSeriesEvent, 1),
+	}
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().AllocTaskID().DoAndReturn(job.sender.alloc).AnyTimes()
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().Complete(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).DoAndReturn(job.sender.send).AnyTimes()
+
+	plan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	plan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: failoverNodeA},
+		ShardIDs: []int32{1, 2},
+	})
+	plan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: failoverNodeB},
+		ShardIDs: []int32{3},
+	})
+	option.Replicas = map[int32][]string{
+		1: {failoverNodeC, failoverNodeA},
+		2: {failoverNodeA, failoverNodeC},
+		3: {failoverNodeB, failoverNodeC},
+	}
+	ch := make(chan *series.TimeSeriesEvent)
+	go func() {
+		var events []*series.TimeSeriesEvent
+		for event := range ch {
+			events = append(events, event)
+		}
+		job.events <- events
+	}()
+	job.jobCtx = NewJobContext(ctx, ch, plan, nil, "select f from cpu")
+	job.taskCtx = newFailoverTaskContext("root", job.jobCtx, &pb.TaskRequest{JobID: 1}, option,
+		job.merger, taskManager, func() {
+			job.removed = true
+		})
+	return job
+}
+
+func TestFailoverTaskContext_ReceiveResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	assert.Nil(t, job.taskCtx.start())
+	assert.Equal(t, int32(2), job.taskCtx.PendingResults())
+
+	taskA, shardIDs := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	assert.Equal(t, []int32{1, 2}, shardIDs)
+	taskB, shardIDs := job.sender.find(failoverNodeB, pb.RequestType_Data)
+	assert.Equal(t, []int32{3}, shardIDs)
+
+	job.merger.EXPECT().merge(gomock.Any()).Times(2)
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+	assert.False(t, job.taskCtx.Completed())
+	assert.Equal(t, int32(1), job.taskCtx.PendingResults())
+	// unknown leaf task
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: "unknown", Completed: true})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	assert.True(t, job.taskCtx.Completed())
+	assert.Empty(t, <-job.events)
+	assert.True(t, job.removed)
+	assert.Nil(t, job.taskCtx.Error())
+	// late response
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+}
+
+func TestFailoverTaskContext_LeafTaskFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	// leaf task of node A fail, retry shard 1/2 on node C
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, ErrMsg: "not found database"})
+	taskC, shardIDs := job.sender.find(failoverNodeC, pb.RequestType_Data)
+	assert.Equal(t, []int32{1, 2}, shardIDs)
+
+	job.merger.EXPECT().merge(gomock.Any()).Times(2)
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskC, Completed: true})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	assert.Empty(t, <-job.events)
+}
+
+func TestFailoverTaskContext_PartialResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	job.sender.fail[failoverNodeC] = true
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	// leaf task of node A fail, node C cannot be sent
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, ErrMsg: "not found database"})
+	assert.False(t, job.taskCtx.Completed())
+
+	job.merger.EXPECT().merge(gomock.Any())
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	events := <-job.events
+	assert.Len(t, events, 1)
+	assert.Nil(t, events[0].Err)
+	assert.Len(t, events[0].Warnings, 2)
+	assert.Equal(t, int32(1), events[0].Warnings[0].ShardID)
+	assert.Equal(t, int32(2), events[0].Warnings[1].ShardID)
+	assert.Equal(t, "no available replica, last error: send task request error", events[0].Warnings[0].Message)
+}
+
+func TestFailoverTaskContext_AllFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// all leaf tasks fail
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	job.sender.fail[failoverNodeC] = true
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, ErrMsg: "err"})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, ErrMsg: "err"})
+	events := <-job.events
+	assert.Len(t, events, 1)
+	assert.Equal(t, errTaskSend, events[0].Err)
+	assert.Equal(t, errTaskSend, job.taskCtx.Error())
+
+	// all storage nodes cannot be sent
+	job = newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	job.sender.fail[failoverNodeA] = true
+	job.sender.fail[failoverNodeB] = true
+	job.sender.fail[failoverNodeC] = true
+	job.merger.EXPECT().close()
+	assert.Equal(t, errTaskSend, job.taskCtx.start())
+}
+
+func TestFailoverTaskContext_RetryTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{RetryTimeout: 20 * time.Millisecond})
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	job.merger.EXPECT().merge(gomock.Any()).Times(2)
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	// leaf task of node A is slow, retry shard 1/2 on node C after timeout
+	var taskC string
+	waitFor(t, func() bool {
+		taskC, _ = job.sender.find(failoverNodeC, pb.RequestType_Data)
+		return taskC != ""
+	})
+	cancelTask, _ := job.sender.find(failoverNodeA, pb.RequestType_Cancel)
+	assert.Equal(t, taskA, cancelTask)
+	// late response of slow leaf task
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskC, Completed: true})
+	assert.Empty(t, <-job.events)
+}
+
+func TestFailoverTaskContext_Hedge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{HedgeDelay: 20 * time.Millisecond})
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	job.merger.EXPECT().merge(gomock.Any()).Times(2)
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	// leaf task of node A is slow, send hedged request to node C, the first response wins
+	var taskC string
+	waitFor(t, func() bool {
+		taskC, _ = job.sender.find(failoverNodeC, pb.RequestType_Data)
+		return taskC != ""
+	})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskC, Completed: true})
+	cancelTask, _ := job.sender.find(failoverNodeA, pb.RequestType_Cancel)
+	assert.Equal(t, taskA, cancelTask)
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+	assert.Empty(t, <-job.events)
+}
+
+func TestFailoverTaskContext_Hedge_Overlap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	job.taskCtx.mutex.Lock()
+	// hedged request of shard 3 on node C
+	job.taskCtx.dispatch([]int32{3}, true)
+	job.taskCtx.mutex.Unlock()
+	taskC, shardIDs := job.sender.find(failoverNodeC, pb.RequestType_Data)
+	assert.Equal(t, []int32{3}, shardIDs)
+
+	job.merger.EXPECT().merge(gomock.Any()).Times(2)
+	job.merger.EXPECT().close()
+	// hedged request fail, original leaf task still in-flight
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskC, ErrMsg: "err"})
+	assert.False(t, job.taskCtx.Completed())
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+	assert.Empty(t, <-job.events)
+}
+
+func TestFailoverTaskContext_QueryTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+	job := newFailoverJob(ctx, ctrl, &FailoverOption{})
+	assert.Nil(t, job.taskCtx.start())
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	job.merger.EXPECT().merge(gomock.Any())
+	job.merger.EXPECT().close()
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskB, Completed: true})
+	// leaf task of node A doesn't respond until query timeout
+	events := <-job.events
+	assert.Len(t, events, 1)
+	assert.Len(t, events[0].Warnings, 2)
+	assert.Equal(t, "no available replica, last error: context deadline exceeded", events[0].Warnings[0].Message)
+	assert.True(t, job.taskCtx.Completed())
+	cancelTask, _ := job.sender.find(failoverNodeA, pb.RequestType_Cancel)
+	assert.NotEmpty(t, cancelTask)
+}
+
+func TestFailoverTaskContext_Killed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+
+	job.jobCtx.Cancel(errJobKilled)
+	events := <-job.events
+	assert.Len(t, events, 1)
+	assert.Equal(t, errJobKilled, events[0].Err)
+	// cancels in-flight leaf tasks
+	waitFor(t, func() bool {
+		cancelTask, _ := job.sender.find(failoverNodeA, pb.RequestType_Cancel)
+		return cancelTask == taskA
+	})
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+	assert.False(t, job.removed)
+}
+
+func TestLeafTaskContext(t *testing.T) {
+	taskCtx := newTaskContext("root", RootTask, "", "", 1, nil)
+	leafTaskCtx := &leafTaskContext{TaskContext: taskCtx, taskID: "leaf"}
+	assert.Equal(t, "leaf", leafTaskCtx.TaskID())
+	assert.Equal(t, RootTask, leafTaskCtx.TaskType())
+}
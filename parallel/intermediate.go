@@ -53,6 +53,10 @@ func (p *intermediateTask) Process(ctx context.Context, req *pb.TaskRequest) err
 	if err := encoding.JSONUnmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
 		return errUnmarshalPlan
 	}
+	if req.RequestType == pb.RequestType_Cancel {
+		// propagate the cancel request to the related leaf nodes
+		return p.sendLeafTasks(physicalPlan, req)
+	}
 	payload := req.Payload
 	query := &stmt.Query{}
 	if err := encoding.JSONUnmarshal(payload, query); err != nil {
@@ -61,6 +65,7 @@ func (p *intermediateTask) Process(ctx context.Context, req *pb.TaskRequest) err
 	groupAgg := aggregation.NewGroupingAggregator(
 		query.Interval,
 		query.TimeRange,
+		false,
 		buildAggregatorSpecs(query.FieldNames))
 	taskSubmitted := false
 	for _, intermediate := range physicalPlan.Intermediates {
@@ -75,6 +75,11 @@ func TestIntermediate_Process(t *testing.T) {
 	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan2, Payload: data})
 	assert.NoError(t, err)
 
+	// cancel request, propagate to leaf nodes
+	taskManager.EXPECT().SendRequest("1.1.1.5:8000", gomock.Any()).Return(nil)
+	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan2, RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
+
 	// normal
 	plan, _ = json.Marshal(&models.PhysicalPlan{
 		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
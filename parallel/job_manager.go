@@ -19,6 +19,9 @@ package parallel
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
 
 	"go.uber.org/atomic"
@@ -26,6 +29,7 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/sql/stmt"
@@ -33,6 +37,10 @@ import (
 
 //go:generate mockgen -source=./job_manager.go -destination=./job_manager_mock.go -package=parallel
 
+var jobLogger = logger.GetLogger("parallel", "JobManager")
+
+var errJobKilled = errors.New("query is killed")
+
 // JobManager represents the job manager for the root broker node
 type JobManager interface {
 	// SubmitJob submits the distribution query job based on physical plan
@@ -43,6 +51,12 @@ type JobManager interface {
 	) (err error)
 	// GetJob returns job context by job id
 	GetJob(jobID int64) JobContext
+	// GetJobs returns all running query jobs
+	GetJobs() []models.RunningQuery
+	// KillJob kills the running query job, cancels the job context and the sub tasks of intermediate/leaf nodes
+	KillJob(jobID int64) error
+	// RemoveJob removes the completed job
+	RemoveJob(jobID int64)
 	// GetTaskManager return the task manager
 	GetTaskManager() TaskManager
 }
@@ -55,6 +69,12 @@ type jobManager struct {
 	jobs sync.Map
 }
 
+// runningJob represents the running query job with the root task id
+type runningJob struct {
+	jobCtx JobContext
+	taskID string
+}
+
 // NewJobManager creates the job manager
 func NewJobManager(taskManger TaskManager) JobManager {
 	return &jobManager{
@@ -65,33 +85,135 @@ func NewJobManager(taskManger TaskManager) JobManager {
 
 // GetJob return the job context by job id
 func (j *jobManager) GetJob(jobID int64) JobContext {
-	job, ok := j.jobs.Load(jobID)
+	job, ok := j.getRunningJob(jobID)
 	if !ok {
 		return nil
 	}
-	jobCtx, ok := job.(JobContext)
+	return job.jobCtx
+}
+
+// GetJobs returns all running query jobs, ordered by job id
+func (j *jobManager) GetJobs() (queries []models.RunningQuery) {
+	j.jobs.Range(func(key, value interface{}) bool {
+		job, ok := value.(*runningJob)
+		if !ok || job.jobCtx.Completed() {
+			return true
+		}
+		jobCtx := job.jobCtx
+		plan := jobCtx.Plan()
+		query := models.RunningQuery{
+			JobID:      key.(int64),
+			SQL:        jobCtx.SQL(),
+			Database:   plan.Database,
+			StartTime:  jobCtx.StartTime(),
+			TotalTasks: plan.Root.NumOfTask,
+		}
+		for _, leaf := range plan.Leafs {
+			query.StorageNodes = append(query.StorageNodes, leaf.Indicator)
+		}
+		query.CompletedTasks = query.TotalTasks
+		if taskCtx := j.taskManager.Get(job.taskID); taskCtx != nil {
+			query.CompletedTasks -= taskCtx.PendingResults()
+		}
+		queries = append(queries, query)
+		return true
+	})
+	sort.Slice(queries, func(i, k int) bool {
+		return queries[i].JobID < queries[k].JobID
+	})
+	return queries
+}
+
+// KillJob kills the running query job,
+// 1. if root task tracks the leaf tasks for failover, sends cancel request with the task id of each in-flight leaf task
+// 2. else sends cancel request to intermediate nodes if has, else sends to the leaf nodes directly
+// 3. removes the root task, ignores the sub task results
+// 4. cancels the job context and completes the job with killed error
+func (j *jobManager) KillJob(jobID int64) error {
+	job, ok := j.getRunningJob(jobID)
 	if !ok {
-		return nil
+		return fmt.Errorf("query job not found, job id: %d", jobID)
+	}
+	defer j.RemoveJob(jobID)
+
+	if taskCtx, ok := j.taskManager.Get(job.taskID).(*failoverTaskContext); ok {
+		// leaf tasks are sent with their own task id, cancels them by failover task context
+		taskCtx.kill()
+	} else {
+		j.cancelSubTasks(jobID, job)
+	}
+	j.taskManager.Complete(job.taskID)
+	job.jobCtx.Cancel(errJobKilled)
+	return nil
+}
+
+// cancelSubTasks sends cancel request with root task id to intermediate nodes if has, else sends to the leaf nodes
+func (j *jobManager) cancelSubTasks(jobID int64, job *runningJob) {
+	plan := job.jobCtx.Plan()
+	req := &pb.TaskRequest{
+		JobID:        jobID,
+		ParentTaskID: job.taskID,
+		RequestType:  pb.RequestType_Cancel,
+		PhysicalPlan: encoding.JSONMarshal(plan),
 	}
-	return jobCtx
+	var targets []string
+	if len(plan.Intermediates) > 0 {
+		for _, intermediate := range plan.Intermediates {
+			targets = append(targets, intermediate.Indicator)
+		}
+	} else {
+		for _, leaf := range plan.Leafs {
+			targets = append(targets, leaf.Indicator)
+		}
+	}
+	for _, target := range targets {
+		if err := j.taskManager.SendRequest(target, req); err != nil {
+			// storage node stops scanning when query timeout, so just log it
+			jobLogger.Error("send cancel request", logger.String("target", target),
+				logger.Int64("jobID", jobID), logger.Error(err))
+		}
+	}
+}
+
+// RemoveJob removes the completed job
+func (j *jobManager) RemoveJob(jobID int64) {
+	j.jobs.Delete(jobID)
+}
+
+// getRunningJob returns the running query job by job id
+func (j *jobManager) getRunningJob(jobID int64) (*runningJob, bool) {
+	job, ok := j.jobs.Load(jobID)
+	if !ok {
+		return nil, false
+	}
+	running, ok := job.(*runningJob)
+	return running, ok
 }
 
 // SubmitJob submits the distribution query job based on physical plan,
 // 1. if has intermediate nodes, sends the request to the intermediate nodes
-// 2. else sends the request to the leaf node directly
+// 2. else sends the request to the leaf node directly,
+// if job has failover option, sends the leaf task of each storage node with its own task id for replica failover,
+// the leaf tasks respond to root directly even if plan has intermediate nodes.
 func (j *jobManager) SubmitJob(ctx JobContext) (err error) {
 	plan := ctx.Plan()
+	failover := ctx.Failover() != nil && len(plan.Leafs) > 0
+	if failover {
+		// root tracks the leaf tasks for retrying the shards, so bypasses the intermediate nodes
+		bypassIntermediates(plan)
+	}
 	planPayload := encoding.JSONMarshal(plan)
 	jobID := j.seq.Inc()
+	taskID := j.taskManager.AllocTaskID()
 
+	// stores job before sending request, because job maybe completed before submit returns
+	j.jobs.Store(jobID, &runningJob{jobCtx: ctx, taskID: taskID})
 	defer func() {
-		if err == nil {
-			j.jobs.Store(jobID, ctx)
+		if err != nil {
+			j.RemoveJob(jobID)
 		}
 	}()
 
-	taskID := j.taskManager.AllocTaskID()
-
 	// TODO need add param
 	req := &pb.TaskRequest{
 		JobID:        jobID,
@@ -101,9 +223,17 @@ func (j *jobManager) SubmitJob(ctx JobContext) (err error) {
 	}
 	query := ctx.Query()
 
-	groupAgg := aggregation.NewGroupingAggregator(query.Interval, query.TimeRange, buildAggregatorSpecs(query.FieldNames))
-	taskCtx := newTaskContext(taskID, RootTask, "", "", plan.Root.NumOfTask,
-		newResultMerger(ctx.Context(), groupAgg, ctx.ResultSet()))
+	groupAgg := aggregation.NewGroupingAggregator(query.Interval, query.TimeRange, false, buildAggregatorSpecs(query.FieldNames))
+	merger := newResultMerger(ctx.Context(), groupAgg, ctx.Emit)
+	if failover {
+		// sends leaf tasks directly, retries the shards on other replica if leaf task fail or slow
+		taskCtx := newFailoverTaskContext(taskID, ctx, req, ctx.Failover(), merger, j.taskManager, func() {
+			j.RemoveJob(jobID)
+		})
+		j.taskManager.Submit(taskCtx)
+		return taskCtx.start()
+	}
+	taskCtx := newTaskContext(taskID, RootTask, "", "", plan.Root.NumOfTask, merger)
 	j.taskManager.Submit(taskCtx)
 
 	if len(plan.Intermediates) > 0 {
@@ -26,6 +26,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -50,16 +53,92 @@ func TestJobManager_SubmitJob(t *testing.T) {
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
 	q, _ := sql.Parse("select f from cpu where host='1.1.1.1' and time>'20190729 11:00:00' and time<'20190729 12:00:00'")
 	query := q.(*stmt.Query)
-	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu"))
 	assert.NotNil(t, err)
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
-	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu"))
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestJobManager_SubmitJob_Failover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().Complete(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+
+	jobManager := NewJobManager(taskManager)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.3:8000",
+			Indicator: "1.1.1.1:9000",
+		},
+		ShardIDs: []int32{1, 2, 4},
+	})
+	q, _ := sql.Parse("select f from cpu where host='1.1.1.1' and time>'20190729 11:00:00' and time<'20190729 12:00:00'")
+	query := q.(*stmt.Query)
+
+	// send fail, retry on other replica
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(nil)
+	jobCtx := NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu")
+	jobCtx.SetFailover(&FailoverOption{Replicas: map[int32][]string{
+		1: {"1.1.1.1:9000", "1.1.1.2:9000"},
+		2: {"1.1.1.2:9000"},
+		4: {"1.1.1.2:9000"},
+	}})
+	err := jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, jobCtx, jobManager.GetJob(1))
+
+	// no available replica
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	jobCtx = NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu")
+	jobCtx.SetFailover(&FailoverOption{})
+	err = jobManager.SubmitJob(jobCtx)
+	assert.Error(t, err)
+	assert.Nil(t, jobManager.GetJob(2))
+
+	// bypass intermediate nodes, send leaf task directly
+	physicalPlan = models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddIntermediate(models.Intermediate{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.3:8000",
+			Indicator: "1.1.1.5:8000",
+		},
+		NumOfTask: 1,
+	})
+	physicalPlan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.5:8000",
+			Indicator: "1.1.1.1:9000",
+		},
+		Receivers: []models.Node{{IP: "1.1.1.5", Port: 8000}},
+		ShardIDs:  []int32{1},
+	})
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			plan := &models.PhysicalPlan{}
+			_ = encoding.JSONUnmarshal(req.PhysicalPlan, plan)
+			assert.Empty(t, plan.Intermediates)
+			assert.Equal(t, "1.1.1.3:8000", plan.Leafs[0].Parent)
+			assert.Equal(t, []models.Node{{IP: "1.1.1.3", Port: 8000}}, plan.Leafs[0].Receivers)
+			return nil
+		})
+	jobCtx = NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu")
+	jobCtx.SetFailover(&FailoverOption{})
+	err = jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	assert.Empty(t, physicalPlan.Intermediates)
+	assert.Equal(t, int32(1), physicalPlan.Root.NumOfTask)
+}
+
 func TestJobManager_SubmitJob_2(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -80,11 +159,11 @@ func TestJobManager_SubmitJob_2(t *testing.T) {
 	q, _ := sql.Parse("select f from cpu where host='1.1.1.1' and time>'20190729 11:00:00' and time<'20190729 12:00:00'")
 	query := q.(*stmt.Query)
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
-	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu"))
 	assert.NotNil(t, err)
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
-	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "select f from cpu"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -98,7 +177,7 @@ func TestJobManager_GetTaskManager(t *testing.T) {
 	taskManager := NewMockTaskManager(ctrl)
 	jobManager1 := NewJobManager(taskManager)
 	manager := jobManager1.(*jobManager)
-	manager.jobs.Store(int64(1), &jobContext{})
+	manager.jobs.Store(int64(1), &runningJob{jobCtx: &jobContext{}})
 	job := jobManager1.GetJob(1)
 	assert.NotNil(t, job)
 	job = jobManager1.GetJob(2)
@@ -141,3 +220,119 @@ func TestJobManager_SubmitMetadataJob(t *testing.T) {
 		}, &stmt.Metadata{}, nil)
 	assert.NoError(t, err)
 }
+
+func TestJobManager_KillJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+	jobManager := NewJobManager(taskManager)
+
+	// job not exist
+	err := jobManager.KillJob(1)
+	assert.Error(t, err)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.Database = "db"
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"}})
+	q, _ := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+	ch := make(chan *series.TimeSeriesEvent)
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	jobCtx := NewJobContext(context.TODO(), ch, physicalPlan, query, "select f from cpu")
+	err = jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+
+	// show running queries
+	taskCtx := newTaskContext("TaskID", RootTask, "", "", 2, nil)
+	taskCtx.(*taskContext).expectResults.Dec()
+	taskManager.EXPECT().Get("TaskID").Return(taskCtx)
+	queries := jobManager.GetJobs()
+	assert.Len(t, queries, 1)
+	assert.Equal(t, int64(1), queries[0].JobID)
+	assert.Equal(t, "select f from cpu", queries[0].SQL)
+	assert.Equal(t, "db", queries[0].Database)
+	assert.Equal(t, []string{"1.1.1.1:9000", "1.1.1.2:9000"}, queries[0].StorageNodes)
+	assert.Equal(t, int32(1), queries[0].CompletedTasks)
+	assert.Equal(t, int32(2), queries[0].TotalTasks)
+
+	// kill query, send cancel request to leaf nodes
+	taskManager.EXPECT().Get("TaskID").Return(taskCtx)
+	taskManager.EXPECT().Complete("TaskID")
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			assert.Equal(t, "TaskID", req.ParentTaskID)
+			return nil
+		})
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	errCh := make(chan error)
+	go func() {
+		errCh <- jobManager.KillJob(1)
+	}()
+	event := <-ch
+	assert.Equal(t, errJobKilled, event.Err)
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.Equal(t, context.Canceled, jobCtx.Context().Err())
+	assert.NoError(t, <-errCh)
+	assert.Nil(t, jobManager.GetJob(1))
+	assert.Empty(t, jobManager.GetJobs())
+}
+
+func TestJobManager_KillJob_Intermediate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	jobManager1 := NewJobManager(taskManager)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddIntermediate(models.Intermediate{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.4:8000"}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.1:9000"}})
+	jobCtx := NewMockJobContext(ctrl)
+	jobCtx.EXPECT().Plan().Return(physicalPlan).AnyTimes()
+	manager := jobManager1.(*jobManager)
+	manager.jobs.Store(int64(1), &runningJob{jobCtx: jobCtx, taskID: "TaskID"})
+
+	taskManager.EXPECT().Get("TaskID").Return(nil)
+	taskManager.EXPECT().Complete("TaskID")
+	taskManager.EXPECT().SendRequest("1.1.1.4:8000", gomock.Any()).Return(nil)
+	jobCtx.EXPECT().Cancel(errJobKilled)
+	err := jobManager1.KillJob(1)
+	assert.NoError(t, err)
+	assert.Nil(t, jobManager1.GetJob(1))
+}
+
+func TestJobManager_KillJob_Failover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newFailoverJob(context.TODO(), ctrl, &FailoverOption{})
+	assert.Nil(t, job.taskCtx.start())
+	taskA, _ := job.sender.find(failoverNodeA, pb.RequestType_Data)
+	taskB, _ := job.sender.find(failoverNodeB, pb.RequestType_Data)
+
+	taskManager := NewMockTaskManager(ctrl)
+	jobManager1 := NewJobManager(taskManager)
+	manager := jobManager1.(*jobManager)
+	manager.jobs.Store(int64(1), &runningJob{jobCtx: job.jobCtx, taskID: "root"})
+
+	taskManager.EXPECT().Get("root").Return(job.taskCtx)
+	taskManager.EXPECT().Complete("root")
+	assert.NoError(t, jobManager1.KillJob(1))
+	// leaf tasks are canceled by the task id of each attempt
+	cancelTask, _ := job.sender.find(failoverNodeA, pb.RequestType_Cancel)
+	assert.Equal(t, taskA, cancelTask)
+	cancelTask, _ = job.sender.find(failoverNodeB, pb.RequestType_Cancel)
+	assert.Equal(t, taskB, cancelTask)
+	events := <-job.events
+	assert.Len(t, events, 1)
+	assert.Equal(t, errJobKilled, events[0].Err)
+	assert.Nil(t, jobManager1.GetJob(1))
+	// late response of canceled leaf task is ignored
+	job.taskCtx.ReceiveResult(&pb.TaskResponse{TaskID: taskA, Completed: true})
+	assert.False(t, job.removed)
+}
@@ -20,11 +20,11 @@ package parallel
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
-	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/service"
@@ -40,6 +40,8 @@ type leafTask struct {
 	storageService    service.StorageService
 	executorFactory   ExecutorFactory
 	taskServerFactory rpc.TaskServerFactory
+
+	queries sync.Map // parent task id => cancel func of running data search
 }
 
 // newLeafTask creates the leaf task
@@ -59,6 +61,10 @@ func newLeafTask(
 
 // Process processes the task request, searches the metric's data from time series engine
 func (p *leafTask) Process(ctx context.Context, req *pb.TaskRequest) error {
+	if req.RequestType == pb.RequestType_Cancel {
+		p.cancelDataSearch(req)
+		return nil
+	}
 	physicalPlan := models.PhysicalPlan{}
 	if err := json.Unmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
 		return errUnmarshalPlan
@@ -133,14 +139,59 @@ func (p *leafTask) processDataSearch(ctx context.Context, db tsdb.Database, shar
 	}
 
 	option := db.GetOption()
-	var interval timeutil.Interval
-	_ = interval.ValueOf(option.Interval)
-	//TODO need get storage interval by query time if has rollup config
+	// pick the storage interval(write interval or rollup interval) based on query interval
+	interval := getStorageInterval(query.Interval, option.GetStorageIntervals())
 	timeRange, intervalRatio, queryInterval := downSamplingTimeRange(query.Interval, interval, query.TimeRange)
 	// execute leaf task
 	storageExecuteCtx := p.executorFactory.NewStorageExecuteContext(shardIDs, &query)
-	queryFlow := NewStorageQueryFlow(ctx, storageExecuteCtx, &query, req, stream, db.ExecutorPool(), timeRange, queryInterval, intervalRatio)
+	// storage query flow is async, so cannot use the dispatch context which cancels after dispatch returns,
+	// creates the query context which keeps the deadline and can be canceled by kill query.
+	queryCtx, cancel := newQueryContext(ctx)
+	p.queries.Store(req.ParentTaskID, cancel)
+	stream = &queryStream{
+		TaskService_HandleServer: stream,
+		done: func() {
+			p.queries.Delete(req.ParentTaskID)
+			cancel()
+		},
+	}
+	queryFlow := NewStorageQueryFlow(queryCtx, storageExecuteCtx, &query, req, stream, db.ExecutorPool(),
+		timeRange, interval, queryInterval, intervalRatio)
 	exec := p.executorFactory.NewStorageExecutor(queryFlow, db, storageExecuteCtx)
 	exec.Execute()
 	return nil
 }
+
+// cancelDataSearch cancels the running data search by parent task id
+func (p *leafTask) cancelDataSearch(req *pb.TaskRequest) {
+	cancel, ok := p.queries.Load(req.ParentTaskID)
+	if !ok {
+		return
+	}
+	p.queries.Delete(req.ParentTaskID)
+	cancel.(context.CancelFunc)()
+}
+
+// newQueryContext creates the cancelable query context which keeps the deadline of parent context
+func newQueryContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if deadline, ok := parent.Deadline(); ok {
+		return context.WithDeadline(context.Background(), deadline)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// queryStream wraps the task stream, invokes done func after sending the completed response
+type queryStream struct {
+	pb.TaskService_HandleServer
+
+	done func()
+}
+
+// Send sends the task response, if response is completed, invokes done func
+func (s *queryStream) Send(resp *pb.TaskResponse) error {
+	err := s.TaskService_HandleServer.Send(resp)
+	if resp.Completed {
+		s.done()
+	}
+	return err
+}
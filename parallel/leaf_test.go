@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/option"
@@ -193,3 +194,69 @@ func TestLeafTask_Suggest_Process(t *testing.T) {
 		Payload:      data})
 	assert.NoError(t, err)
 }
+
+func TestLeafTask_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	storageService := service.NewMockStorageService(ctrl)
+	executorFactory := NewMockExecutorFactory(ctrl)
+
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory)
+	leaf := processor.(*leafTask)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
+	})
+	data := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu"})
+
+	mockDatabase.EXPECT().GetOption().Return(option.DatabaseOption{Interval: "10s"})
+	mockDatabase.EXPECT().ExecutorPool().Return(&tsdb.ExecutorPool{})
+	storageService.EXPECT().GetDatabase(gomock.Any()).Return(mockDatabase, true)
+	serverStream := commonmock.NewMockTaskService_HandleServer(ctrl)
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream)
+	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any()).Return(nil)
+	exec := NewMockExecutor(ctrl)
+	exec.EXPECT().Execute()
+	var queryCtx context.Context
+	var queryStream pb.TaskService_HandleServer
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(queryFlow flow.StorageQueryFlow, _ tsdb.Database, _ StorageExecuteContext) Executor {
+			qf := queryFlow.(*storageQueryFlow)
+			queryCtx = qf.ctx
+			queryStream = qf.stream
+			return exec
+		})
+	ctx, cancel := context.WithCancel(context.TODO())
+	err := processor.Process(ctx, &pb.TaskRequest{ParentTaskID: "task-1", PhysicalPlan: plan, Payload: data})
+	assert.NoError(t, err)
+	// cancel dispatch context, query context still alive
+	cancel()
+	assert.NoError(t, queryCtx.Err())
+	_, ok := leaf.queries.Load("task-1")
+	assert.True(t, ok)
+
+	// cancel not exist query
+	err = processor.Process(context.TODO(), &pb.TaskRequest{ParentTaskID: "task-2", RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
+	assert.NoError(t, queryCtx.Err())
+	// cancel running query
+	err = processor.Process(context.TODO(), &pb.TaskRequest{ParentTaskID: "task-1", RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
+	assert.Equal(t, context.Canceled, queryCtx.Err())
+	_, ok = leaf.queries.Load("task-1")
+	assert.False(t, ok)
+
+	// completed response deregisters query
+	leaf.queries.Store("task-1", context.CancelFunc(func() {}))
+	serverStream.EXPECT().Send(gomock.Any()).Return(nil).Times(2)
+	assert.NoError(t, queryStream.Send(&pb.TaskResponse{}))
+	_, ok = leaf.queries.Load("task-1")
+	assert.True(t, ok)
+	assert.NoError(t, queryStream.Send(&pb.TaskResponse{Completed: true}))
+	_, ok = leaf.queries.Load("task-1")
+	assert.False(t, ok)
+}
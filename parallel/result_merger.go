@@ -34,6 +34,9 @@ import (
 
 var mergeLogger = logger.GetLogger("parallel", "merger")
 
+// emitBatchSize represents the max number of grouped series in an event emitted by merger
+const emitBatchSize = 100
+
 // ResultMerger represents a merger which merges the task response and aggregates the result
 type ResultMerger interface {
 	// merge merges the task response and aggregates the result
@@ -45,7 +48,7 @@ type ResultMerger interface {
 
 // resultMerger implements ResultMerger interface
 type resultMerger struct {
-	resultSet chan *series.TimeSeriesEvent
+	emit func(event *series.TimeSeriesEvent)
 
 	groupAgg aggregation.GroupingAggregator
 
@@ -58,14 +61,14 @@ type resultMerger struct {
 	err   error
 }
 
-// newResultMerger create a result merger
-func newResultMerger(ctx context.Context, groupAgg aggregation.GroupingAggregator, resultSet chan *series.TimeSeriesEvent) ResultMerger {
+// newResultMerger create a result merger, emit sends the merged result set(nil means no output)
+func newResultMerger(ctx context.Context, groupAgg aggregation.GroupingAggregator, emit func(event *series.TimeSeriesEvent)) ResultMerger {
 	merger := &resultMerger{
-		resultSet: resultSet,
-		groupAgg:  groupAgg,
-		events:    make(chan *pb.TaskResponse),
-		closed:    make(chan struct{}),
-		ctx:       ctx,
+		emit:     emit,
+		groupAgg: groupAgg,
+		events:   make(chan *pb.TaskResponse),
+		closed:   make(chan struct{}),
+		ctx:      ctx,
 	}
 	go func() {
 		defer close(merger.closed)
@@ -76,7 +79,11 @@ func newResultMerger(ctx context.Context, groupAgg aggregation.GroupingAggregato
 
 // merge merges and aggregates the result
 func (m *resultMerger) merge(resp *pb.TaskResponse) {
-	m.events <- resp
+	select {
+	case m.events <- resp:
+	case <-m.ctx.Done():
+		// process loop exits when query timeout or killed
+	}
 }
 
 // close closes merger
@@ -84,18 +91,23 @@ func (m *resultMerger) close() {
 	close(m.events)
 	// waiting process completed
 	<-m.closed
+	if m.emit == nil {
+		return
+	}
 	// send result set
 	if m.err != nil {
-		m.resultSet <- &series.TimeSeriesEvent{Err: m.err, Stats: m.stats}
+		m.emit(&series.TimeSeriesEvent{Err: m.err, Stats: m.stats})
 	} else {
-		// send all series data
-		resultSet := m.groupAgg.ResultSet()
-		if len(resultSet) > 0 {
-			m.resultSet <- &series.TimeSeriesEvent{
-				SeriesList: resultSet,
+		// send series data batch by batch, so that the grouped series can be evaluated/streamed
+		// and released before all grouped series are built.
+		// NOTICE: the aggregates of all groups are still kept until all task responses are merged,
+		// because any task response maybe contains the data of any group.
+		m.groupAgg.DrainResultSet(emitBatchSize, func(seriesList []series.GroupedIterator) {
+			m.emit(&series.TimeSeriesEvent{
+				SeriesList: seriesList,
 				Stats:      m.stats,
-			}
-		}
+			})
+		})
 	}
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/series"
 )
@@ -39,9 +40,12 @@ func TestResultMerger_Merge(t *testing.T) {
 	defer ctrl.Finish()
 
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
-	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+	groupAgg.EXPECT().DrainResultSet(emitBatchSize, gomock.Any()).
+		Do(func(_ int, fn func(seriesList []series.GroupedIterator)) {
+			fn([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+		})
 	ch := make(chan *series.TimeSeriesEvent)
-	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger := newResultMerger(context.TODO(), groupAgg, func(event *series.TimeSeriesEvent) { ch <- event })
 	c := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -56,14 +60,35 @@ func TestResultMerger_Merge(t *testing.T) {
 	assert.Equal(t, int32(1), c.Load())
 }
 
+func TestResultMerger_EmitBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	groupAgg.EXPECT().DrainResultSet(emitBatchSize, gomock.Any()).
+		Do(func(_ int, fn func(seriesList []series.GroupedIterator)) {
+			fn([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl), series.NewMockGroupedIterator(ctrl)})
+			fn([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+		})
+	var events []*series.TimeSeriesEvent
+	merger := newResultMerger(context.TODO(), groupAgg, func(event *series.TimeSeriesEvent) {
+		events = append(events, event)
+	})
+	merger.close()
+	// grouped series are emitted batch by batch
+	assert.Len(t, events, 2)
+	assert.Len(t, events[0].SeriesList, 2)
+	assert.Len(t, events[1].SeriesList, 1)
+}
+
 func TestResultMerger_Cancel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
-	groupAgg.EXPECT().ResultSet().Return(nil)
+	groupAgg.EXPECT().DrainResultSet(emitBatchSize, gomock.Any())
 	ch := make(chan *series.TimeSeriesEvent)
 	ctx, cancel := context.WithCancel(context.TODO())
-	merger := newResultMerger(ctx, groupAgg, ch)
+	merger := newResultMerger(ctx, groupAgg, func(event *series.TimeSeriesEvent) { ch <- event })
 	var wait sync.WaitGroup
 	wait.Add(1)
 	go func() {
@@ -80,7 +105,7 @@ func TestResultMerger_Err(t *testing.T) {
 	defer ctrl.Finish()
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
 	ch := make(chan *series.TimeSeriesEvent)
-	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger := newResultMerger(context.TODO(), groupAgg, func(event *series.TimeSeriesEvent) { ch <- event })
 	c := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -103,9 +128,12 @@ func TestResultMerger_GroupBy(t *testing.T) {
 	defer ctrl.Finish()
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
 	groupAgg.EXPECT().Aggregate(gomock.Any()).AnyTimes()
-	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+	groupAgg.EXPECT().DrainResultSet(emitBatchSize, gomock.Any()).
+		Do(func(_ int, fn func(seriesList []series.GroupedIterator)) {
+			fn([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
+		})
 	ch := make(chan *series.TimeSeriesEvent)
-	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger := newResultMerger(context.TODO(), groupAgg, func(event *series.TimeSeriesEvent) { ch <- event })
 	c := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -142,6 +170,46 @@ func TestResultMerger_GroupBy(t *testing.T) {
 	assert.Equal(t, int32(1), c.Load())
 }
 
+func TestResultMerger_crossRoutingEpoch(t *testing.T) {
+	now, _ := timeutil.ParseTimestamp("20190702 19:10:00", "20060102 15:04:05")
+	epochStart := now + timeutil.OneHour
+	// query time range spans the routing epoch boundary(num. of shard increased from 1 to 2)
+	groupAgg := aggregation.NewGroupingAggregator(
+		timeutil.Interval(timeutil.OneSecond),
+		timeutil.TimeRange{Start: now, End: epochStart + timeutil.OneHour},
+		false,
+		aggregation.AggregatorSpecs{aggregation.NewAggregatorSpec("f1")})
+	var seriesList []series.GroupedIterator
+	merger := newResultMerger(context.TODO(), groupAgg, func(event *series.TimeSeriesEvent) {
+		assert.NoError(t, event.Err)
+		seriesList = append(seriesList, event.SeriesList...)
+	})
+	newResponse := func(taskID string, tags ...string) *pb.TaskResponse {
+		tsList := pb.TimeSeriesList{}
+		for _, tagValues := range tags {
+			tsList.TimeSeriesList = append(tsList.TimeSeriesList, &pb.TimeSeries{
+				Tags:   tagValues,
+				Fields: map[string][]byte{"f1": {}},
+			})
+		}
+		data, _ := tsList.Marshal()
+		return &pb.TaskResponse{TaskID: taskID, Payload: data}
+	}
+	// history data of series routes by original num. of shard, stays in shard 0
+	merger.merge(newResponse("shard-0", "1.1.1.1", "1.1.1.2"))
+	// new data of series 1.1.1.1 routes to new shard after epoch start time
+	merger.merge(newResponse("shard-1", "1.1.1.1"))
+	merger.close()
+
+	// the same series read from shards of different epochs is merged into one series
+	assert.Len(t, seriesList, 2)
+	tags := make(map[string]struct{})
+	for _, it := range seriesList {
+		tags[it.Tags()] = struct{}{}
+	}
+	assert.Equal(t, map[string]struct{}{"1.1.1.1": {}, "1.1.1.2": {}}, tags)
+}
+
 func TestSuggestMerge_merge(t *testing.T) {
 	ch := make(chan []string)
 	merger := newSuggestResultMerger(ch)
@@ -0,0 +1,402 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parallel
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// SeriesHandler handles the final time series with columnar format one by one
+type SeriesHandler func(series *models.ColumnSeries) error
+
+// processedSeries represents the time series which passes the having clause with its order by values
+type processedSeries struct {
+	series        *models.Series
+	columnSeries  *models.ColumnSeries // columnar time series for streaming
+	orderByValues []float64
+}
+
+// seriesProcessor processes the evaluated time series of broker's final result,
+// 1. filters the time series by having clause
+// 2. fills the missing points based on fill policy
+// 3. sorts the time series by order by clause, then cuts the series list by limit
+type seriesProcessor struct {
+	query       *stmt.Query
+	hiddenItems map[string]stmt.Expr // expressions which having/order by clause depends on, but not in select list
+	aliases     map[string]stmt.Expr // alias => expression of select item
+	seriesList  []*processedSeries
+
+	handler SeriesHandler // handles time series as soon as it's processed if set, instead of keeping it in memory
+	handled int           // the number of handled time series
+	err     error         // the error of processing/handling time series
+
+	maxPoints int // maximum number of points evaluated by query, 0 means no limit
+	points    int // the number of points evaluated
+}
+
+// newSeriesProcessor creates the series processor for query
+func newSeriesProcessor(query *stmt.Query) *seriesProcessor {
+	p := &seriesProcessor{
+		query:       query,
+		hiddenItems: make(map[string]stmt.Expr),
+		aliases:     make(map[string]stmt.Expr),
+	}
+	for _, item := range query.SelectItems {
+		if selectItem, ok := item.(*stmt.SelectItem); ok && len(selectItem.Alias) > 0 {
+			p.aliases[selectItem.Alias] = selectItem.Expr
+		}
+	}
+	for _, item := range query.HiddenItems() {
+		p.hiddenItems[item.Rewrite()] = item
+	}
+	return p
+}
+
+// selectItems returns the select items which need to be evaluated, includes hidden items
+func (p *seriesProcessor) selectItems() []stmt.Expr {
+	return p.query.AllSelectItems()
+}
+
+// process processes the evaluated result of one time series,
+// drops the time series if it doesn't match having clause.
+func (p *seriesProcessor) process(tags map[string]string, rs map[string]collections.FloatArray) {
+	if p.err != nil {
+		return
+	}
+	if p.query.Having != nil && !p.having(p.query.Having, rs) {
+		return
+	}
+	s := &processedSeries{}
+	for _, orderBy := range p.query.OrderBy {
+		orderByExpr, ok := orderBy.(*stmt.OrderByExpr)
+		if !ok {
+			continue
+		}
+		value, ok := p.value(orderByExpr.Expr, rs)
+		if !ok {
+			value = math.NaN()
+		}
+		s.orderByValues = append(s.orderByValues, value)
+	}
+	if p.handler != nil {
+		s.columnSeries = p.columnSeries(tags, rs)
+		if !p.addPoints(len(s.columnSeries.Timestamps) * len(s.columnSeries.Fields)) {
+			return
+		}
+		if !p.query.HasOrderBy() {
+			// handle time series directly if no need to sort
+			p.handle(s.columnSeries)
+			return
+		}
+		p.seriesList = append(p.seriesList, s)
+		return
+	}
+	s.series = models.NewSeries(tags)
+	interval := p.query.Interval.Int64()
+	startTime := p.query.TimeRange.Start
+	numOfPoints := 0
+	for fieldName, values := range rs {
+		if !p.isResultField(fieldName, values) {
+			continue
+		}
+		points := models.NewPoints()
+		p.fill(values, func(slot int, value float64) {
+			points.AddPoint(int64(slot)*interval+startTime, value)
+		})
+		s.series.AddField(fieldName, points)
+		numOfPoints += len(points.Points)
+	}
+	if !p.addPoints(numOfPoints) {
+		return
+	}
+	p.seriesList = append(p.seriesList, s)
+}
+
+// addPoints accumulates the number of points evaluated by query,
+// returns false and sets the error if total number exceeds the limit.
+func (p *seriesProcessor) addPoints(numOfPoints int) bool {
+	p.points += numOfPoints
+	if p.maxPoints > 0 && p.points > p.maxPoints {
+		p.err = fmt.Errorf("%s, limit: %d", constants.ErrQueryTooManyPoints, p.maxPoints)
+		return false
+	}
+	return true
+}
+
+// resultSeries returns the time series list after sorting and cutting by limit
+func (p *seriesProcessor) resultSeries() []*models.Series {
+	length := p.sortSeries()
+	result := make([]*models.Series, length)
+	for idx := 0; idx < length; idx++ {
+		result[idx] = p.seriesList[idx].series
+	}
+	return result
+}
+
+// flush handles the pending time series after sorting and cutting by limit for streaming,
+// returns the error of handling time series.
+func (p *seriesProcessor) flush() error {
+	length := p.sortSeries()
+	for idx := 0; idx < length; idx++ {
+		p.handle(p.seriesList[idx].columnSeries)
+	}
+	p.seriesList = nil
+	return p.err
+}
+
+// sortSeries sorts the time series by order by clause, returns the length of time series after cutting by limit
+func (p *seriesProcessor) sortSeries() int {
+	if p.query.HasOrderBy() {
+		sort.SliceStable(p.seriesList, func(i, j int) bool {
+			return p.less(p.seriesList[i], p.seriesList[j])
+		})
+	}
+	length := len(p.seriesList)
+	if p.query.Limit > 0 && length > p.query.Limit {
+		length = p.query.Limit
+	}
+	return length
+}
+
+// handle handles the time series by handler if not reach the limit
+func (p *seriesProcessor) handle(series *models.ColumnSeries) {
+	if p.err != nil || (p.query.Limit > 0 && p.handled >= p.query.Limit) {
+		return
+	}
+	if err := p.handler(series); err != nil {
+		p.err = err
+		return
+	}
+	p.handled++
+}
+
+// columnSeries builds the columnar time series with fill policy, all fields share the same timestamps
+func (p *seriesProcessor) columnSeries(tags map[string]string, rs map[string]collections.FloatArray) *models.ColumnSeries {
+	slots := 0
+	for fieldName, values := range rs {
+		if p.isResultField(fieldName, values) && values.Capacity() > slots {
+			slots = values.Capacity()
+		}
+	}
+	hasValue := make([]bool, slots)
+	columns := make(map[string][]float64)
+	for fieldName, values := range rs {
+		if !p.isResultField(fieldName, values) {
+			continue
+		}
+		column := make([]float64, slots)
+		for idx := range column {
+			column[idx] = math.NaN()
+		}
+		p.fill(values, func(slot int, value float64) {
+			column[slot] = value
+			hasValue[slot] = true
+		})
+		columns[fieldName] = column
+	}
+	series := models.NewColumnSeries(tags)
+	interval := p.query.Interval.Int64()
+	startTime := p.query.TimeRange.Start
+	for slot, ok := range hasValue {
+		if ok {
+			series.Timestamps = append(series.Timestamps, int64(slot)*interval+startTime)
+		}
+	}
+	for fieldName, column := range columns {
+		values := make(models.FloatValues, 0, len(series.Timestamps))
+		for slot, ok := range hasValue {
+			if ok {
+				values = append(values, column[slot])
+			}
+		}
+		series.Fields[fieldName] = values
+	}
+	return series
+}
+
+// isResultField checks if the field need to be returned, hidden items are not returned
+func (p *seriesProcessor) isResultField(fieldName string, values collections.FloatArray) bool {
+	if values == nil {
+		return false
+	}
+	_, hidden := p.hiddenItems[fieldName]
+	return !hidden
+}
+
+// less compares two time series by order by values, NaN(no value) is always last
+func (p *seriesProcessor) less(s1, s2 *processedSeries) bool {
+	for idx, orderBy := range p.query.OrderBy {
+		v1 := s1.orderByValues[idx]
+		v2 := s2.orderByValues[idx]
+		nan1, nan2 := math.IsNaN(v1), math.IsNaN(v2)
+		switch {
+		case nan1 && nan2, v1 == v2:
+			continue
+		case nan1:
+			return false
+		case nan2:
+			return true
+		}
+		if orderByExpr, ok := orderBy.(*stmt.OrderByExpr); ok && orderByExpr.Desc {
+			return v1 > v2
+		}
+		return v1 < v2
+	}
+	return false
+}
+
+// fill iterates the values with fill policy, calls the callback for each point
+func (p *seriesProcessor) fill(values collections.FloatArray, fn func(slot int, value float64)) {
+	if p.query.Fill != stmt.FillPrevious && p.query.Fill != stmt.FillValue {
+		it := values.Iterator()
+		for it.HasNext() {
+			fn(it.Next())
+		}
+		return
+	}
+	interval := p.query.Interval.Int64()
+	startTime := p.query.TimeRange.Start
+	hasPrevious := false
+	previous := 0.0
+	for slot := 0; slot < values.Capacity(); slot++ {
+		if int64(slot)*interval+startTime > p.query.TimeRange.End {
+			return
+		}
+		if values.HasValue(slot) {
+			previous = values.GetValue(slot)
+			hasPrevious = true
+			fn(slot, previous)
+			continue
+		}
+		switch {
+		case p.query.Fill == stmt.FillValue:
+			fn(slot, p.query.FillValue)
+		case hasPrevious:
+			fn(slot, previous)
+		}
+	}
+}
+
+// having checks if the time series matches the having condition
+func (p *seriesProcessor) having(expr stmt.Expr, rs map[string]collections.FloatArray) bool {
+	switch e := expr.(type) {
+	case *stmt.ParenExpr:
+		return p.having(e.Expr, rs)
+	case *stmt.BinaryExpr:
+		switch e.Operator {
+		case stmt.AND:
+			return p.having(e.Left, rs) && p.having(e.Right, rs)
+		case stmt.OR:
+			return p.having(e.Left, rs) || p.having(e.Right, rs)
+		}
+		left, ok := p.value(e.Left, rs)
+		if !ok {
+			return false
+		}
+		right, ok := p.value(e.Right, rs)
+		if !ok {
+			return false
+		}
+		return compare(e.Operator, left, right)
+	default:
+		return false
+	}
+}
+
+// value returns the single value of the expression for one time series
+func (p *seriesProcessor) value(expr stmt.Expr, rs map[string]collections.FloatArray) (float64, bool) {
+	if number, ok := expr.(*stmt.NumberLiteral); ok {
+		return number.Val, true
+	}
+	values, ok := rs[expr.Rewrite()]
+	if !ok || values == nil {
+		return 0, false
+	}
+	// alias of select item is reduced by the function of its expression
+	if fieldExpr, ok := expr.(*stmt.FieldExpr); ok {
+		if aliasExpr, ok := p.aliases[fieldExpr.Name]; ok {
+			expr = aliasExpr
+		}
+	}
+	return reduceValues(expr, values)
+}
+
+// reduceValues reduces the points of time series to single value based on the function type of expression,
+// sum/count => sum of all points, min => min point, max => max point, others => avg of all points.
+func reduceValues(expr stmt.Expr, values collections.FloatArray) (float64, bool) {
+	funcType := function.Avg
+	if parenExpr, ok := expr.(*stmt.ParenExpr); ok {
+		return reduceValues(parenExpr.Expr, values)
+	}
+	if callExpr, ok := expr.(*stmt.CallExpr); ok {
+		funcType = callExpr.FuncType
+	}
+	count := 0
+	result := 0.0
+	it := values.Iterator()
+	for it.HasNext() {
+		_, value := it.Next()
+		switch {
+		case count == 0:
+			result = value
+		case funcType == function.Min:
+			result = math.Min(result, value)
+		case funcType == function.Max:
+			result = math.Max(result, value)
+		default:
+			result += value
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	if funcType != function.Sum && funcType != function.Count &&
+		funcType != function.Min && funcType != function.Max {
+		result /= float64(count)
+	}
+	return result, true
+}
+
+// compare compares the values based on the compare operator
+func compare(operator stmt.BinaryOP, left, right float64) bool {
+	switch operator {
+	case stmt.EQUAL:
+		return left == right
+	case stmt.NOTEQUAL:
+		return left != right
+	case stmt.LESS:
+		return left < right
+	case stmt.LESSEQUAL:
+		return left <= right
+	case stmt.GREATER:
+		return left > right
+	case stmt.GREATEREQUAL:
+		return left >= right
+	default:
+		return false
+	}
+}
@@ -0,0 +1,258 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parallel
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func newTestProcessor(t *testing.T, sqlStr string) *seriesProcessor {
+	q, err := sql.Parse(sqlStr)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.TimeRange = timeutil.TimeRange{Start: 0, End: 40}
+	query.Interval = 10
+	return newSeriesProcessor(query)
+}
+
+func newTestValues(values ...float64) collections.FloatArray {
+	arr := collections.NewFloatArray(5)
+	for idx, v := range values {
+		if v >= 0 {
+			arr.SetValue(idx, v)
+		}
+	}
+	return arr
+}
+
+func TestSeriesProcessor_selectItems(t *testing.T) {
+	p := newTestProcessor(t, "select f from cpu")
+	assert.Len(t, p.selectItems(), 1)
+
+	p = newTestProcessor(t, "select avg(f) as a,max(f) from cpu group by host "+
+		"having max(f) > 90 and min(f)>1 order by a desc, sum(g)")
+	assert.Len(t, p.hiddenItems, 2)
+	selectItems := p.selectItems()
+	assert.Len(t, selectItems, 4)
+	assert.Equal(t, "min(f)", selectItems[2].Rewrite())
+	assert.Equal(t, "sum(g)", selectItems[3].Rewrite())
+}
+
+func TestSeriesProcessor_having(t *testing.T) {
+	p := newTestProcessor(t, "select avg(f) as a from cpu group by host having max(f) > 90 or (a>=1 and a<2)")
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{
+		"a":      newTestValues(1, 2),
+		"max(f)": newTestValues(91, 95),
+	})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{
+		"a":      newTestValues(1, 1.5),
+		"max(f)": newTestValues(10, 20),
+	})
+	p.process(map[string]string{"host": "3"}, map[string]collections.FloatArray{
+		"a":      newTestValues(10, 20),
+		"max(f)": newTestValues(10, 20),
+	})
+	p.process(map[string]string{"host": "4"}, map[string]collections.FloatArray{
+		"a": newTestValues(10, 20),
+	})
+	rs := p.resultSeries()
+	assert.Len(t, rs, 2)
+	assert.Equal(t, "1", rs[0].Tags["host"])
+	assert.Equal(t, "2", rs[1].Tags["host"])
+	// hidden item not in result
+	assert.Len(t, rs[0].Fields, 1)
+	assert.Equal(t, map[int64]float64{0: 1, 10: 2}, rs[0].Fields["a"])
+
+	p = newTestProcessor(t, "select f from cpu group by host having f = 1 or f != 2 or f<=1")
+	p.process(nil, map[string]collections.FloatArray{"f": newTestValues(2)})
+	assert.Len(t, p.resultSeries(), 0)
+	assert.False(t, p.having(&stmt.FieldExpr{Name: "f"}, nil))
+	assert.False(t, compare(stmt.ADD, 1, 1))
+}
+
+func TestSeriesProcessor_orderBy(t *testing.T) {
+	p := newTestProcessor(t, "select avg(f) from cpu group by host order by avg(f) desc, host limit 3")
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{"avg(f)": newTestValues(1, 3)})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{"avg(f)": newTestValues(5, 7)})
+	p.process(map[string]string{"host": "3"}, map[string]collections.FloatArray{"avg(f)": newTestValues()})
+	p.process(map[string]string{"host": "4"}, map[string]collections.FloatArray{"avg(f)": newTestValues(3)})
+	p.process(map[string]string{"host": "5"}, map[string]collections.FloatArray{"avg(f)": newTestValues(8)})
+	rs := p.resultSeries()
+	assert.Len(t, rs, 3)
+	assert.Equal(t, "5", rs[0].Tags["host"])
+	assert.Equal(t, "2", rs[1].Tags["host"])
+	assert.Equal(t, "4", rs[2].Tags["host"])
+
+	p = newTestProcessor(t, "select f from cpu group by host order by max(f)")
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{"max(f)": newTestValues(1, 10)})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{})
+	p.process(map[string]string{"host": "3"}, map[string]collections.FloatArray{"max(f)": newTestValues(5, 3)})
+	rs = p.resultSeries()
+	assert.Len(t, rs, 3)
+	assert.Equal(t, "3", rs[0].Tags["host"])
+	assert.Equal(t, "1", rs[1].Tags["host"])
+	assert.Equal(t, "2", rs[2].Tags["host"])
+}
+
+func TestSeriesProcessor_fill(t *testing.T) {
+	p := newTestProcessor(t, "select f from cpu group by host")
+	p.process(nil, map[string]collections.FloatArray{"f": newTestValues(-1, 2, -1, 4)})
+	assert.Equal(t, map[int64]float64{10: 2, 30: 4}, p.resultSeries()[0].Fields["f"])
+
+	p = newTestProcessor(t, "select f from cpu group by host fill(previous)")
+	p.process(nil, map[string]collections.FloatArray{"f": newTestValues(-1, 2, -1, 4)})
+	assert.Equal(t, map[int64]float64{10: 2, 20: 2, 30: 4, 40: 4}, p.resultSeries()[0].Fields["f"])
+
+	p = newTestProcessor(t, "select f from cpu group by host fill(0)")
+	p.process(nil, map[string]collections.FloatArray{"f": newTestValues(-1, 2, -1, 4)})
+	assert.Equal(t, map[int64]float64{0: 0, 10: 2, 20: 0, 30: 4, 40: 0}, p.resultSeries()[0].Fields["f"])
+}
+
+func TestSeriesProcessor_stream(t *testing.T) {
+	var handled []*models.ColumnSeries
+	handler := func(series *models.ColumnSeries) error {
+		handled = append(handled, series)
+		return nil
+	}
+	p := newTestProcessor(t, "select f,g from cpu group by host limit 2")
+	p.handler = handler
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{
+		"f": newTestValues(-1, 2, -1, 4),
+		"g": newTestValues(1),
+	})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{"f": newTestValues(3)})
+	p.process(map[string]string{"host": "3"}, map[string]collections.FloatArray{"f": newTestValues(5)})
+	assert.Len(t, handled, 2)
+	assert.Empty(t, p.seriesList)
+	assert.Equal(t, "1", handled[0].Tags["host"])
+	assert.Equal(t, []int64{0, 10, 30}, handled[0].Timestamps)
+	assert.Equal(t, models.FloatValues{2, 4}, handled[0].Fields["f"][1:])
+	assert.True(t, math.IsNaN(handled[0].Fields["f"][0]))
+	assert.Equal(t, models.FloatValues{1}, handled[0].Fields["g"][:1])
+	assert.True(t, math.IsNaN(handled[0].Fields["g"][1]))
+	assert.NoError(t, p.flush())
+
+	// order by, handles time series when flush
+	handled = nil
+	p = newTestProcessor(t, "select avg(f) from cpu group by host order by max(f) desc limit 2")
+	p.handler = handler
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{
+		"avg(f)": newTestValues(1), "max(f)": newTestValues(1)})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{
+		"avg(f)": newTestValues(5), "max(f)": newTestValues(5)})
+	p.process(map[string]string{"host": "3"}, map[string]collections.FloatArray{
+		"avg(f)": newTestValues(3), "max(f)": newTestValues(3)})
+	assert.Empty(t, handled)
+	assert.NoError(t, p.flush())
+	assert.Len(t, handled, 2)
+	assert.Equal(t, "2", handled[0].Tags["host"])
+	assert.Equal(t, "3", handled[1].Tags["host"])
+	// hidden item not in result
+	assert.Len(t, handled[0].Fields, 1)
+	assert.Equal(t, models.FloatValues{5}, handled[0].Fields["avg(f)"])
+	assert.Empty(t, p.seriesList)
+
+	// handle failure
+	count := 0
+	p = newTestProcessor(t, "select f from cpu group by host")
+	p.handler = func(series *models.ColumnSeries) error {
+		count++
+		return fmt.Errorf("err")
+	}
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{"f": newTestValues(1)})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{"f": newTestValues(1)})
+	assert.Equal(t, 1, count)
+	assert.Error(t, p.flush())
+}
+
+func TestSeriesProcessor_maxPoints(t *testing.T) {
+	p := newTestProcessor(t, "select f from cpu group by host")
+	p.maxPoints = 3
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{"f": newTestValues(1, 2)})
+	assert.NoError(t, p.err)
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{"f": newTestValues(1, 2)})
+	assert.Error(t, p.err)
+	// ignore time series after failure
+	p.process(map[string]string{"host": "3"}, map[string]collections.FloatArray{"f": newTestValues(1)})
+	assert.Len(t, p.resultSeries(), 1)
+
+	// streaming
+	handled := 0
+	p = newTestProcessor(t, "select f,g from cpu group by host")
+	p.maxPoints = 4
+	p.handler = func(series *models.ColumnSeries) error {
+		handled++
+		return nil
+	}
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{
+		"f": newTestValues(1, 2), "g": newTestValues(1)})
+	assert.NoError(t, p.err)
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{"f": newTestValues(1)})
+	assert.Error(t, p.flush())
+	assert.Equal(t, 1, handled)
+}
+
+func TestReduceValues(t *testing.T) {
+	values := newTestValues(1, 4, -1, 7)
+	cases := []struct {
+		funcType function.FuncType
+		expect   float64
+	}{
+		{funcType: function.Sum, expect: 12},
+		{funcType: function.Count, expect: 12},
+		{funcType: function.Min, expect: 1},
+		{funcType: function.Max, expect: 7},
+		{funcType: function.Avg, expect: 4},
+	}
+	for _, c := range cases {
+		v, ok := reduceValues(&stmt.CallExpr{FuncType: c.funcType}, values)
+		assert.True(t, ok)
+		assert.Equal(t, c.expect, v)
+	}
+	v, ok := reduceValues(&stmt.FieldExpr{Name: "f"}, values)
+	assert.True(t, ok)
+	assert.Equal(t, 4.0, v)
+	_, ok = reduceValues(&stmt.FieldExpr{Name: "f"}, newTestValues())
+	assert.False(t, ok)
+}
+
+func TestSeriesProcessor_having_alias(t *testing.T) {
+	// alias is reduced by sum, avg(=4) would be filtered
+	p := newTestProcessor(t, "select sum(f) as s from cpu group by host having s > 5")
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{"s": newTestValues(1, 4, -1, 7)})
+	rs := p.resultSeries()
+	assert.Len(t, rs, 1)
+
+	p = newTestProcessor(t, "select max(f) as m from cpu group by host order by m desc")
+	p.process(map[string]string{"host": "1"}, map[string]collections.FloatArray{"m": newTestValues(1, 9)})
+	p.process(map[string]string{"host": "2"}, map[string]collections.FloatArray{"m": newTestValues(6, 6)})
+	rs = p.resultSeries()
+	assert.Equal(t, "1", rs[0].Tags["host"])
+	assert.Equal(t, "2", rs[1].Tags["host"])
+}
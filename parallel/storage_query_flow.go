@@ -60,6 +60,7 @@ type storageQueryFlow struct {
 	allocAgg          allocAgg
 
 	queryTimeRange     timeutil.TimeRange
+	storageInterval    timeutil.Interval
 	queryInterval      timeutil.Interval
 	queryIntervalRatio int
 	downSamplingSpecs  aggregation.AggregatorSpecs
@@ -80,6 +81,7 @@ func NewStorageQueryFlow(ctx context.Context,
 	stream pb.TaskService_HandleServer,
 	executorPool *tsdb.ExecutorPool,
 	queryTimeRange timeutil.TimeRange,
+	storageInterval timeutil.Interval,
 	queryInterval timeutil.Interval,
 	queryIntervalRatio int,
 ) flow.StorageQueryFlow {
@@ -92,13 +94,19 @@ func NewStorageQueryFlow(ctx context.Context,
 		executorPool:       executorPool,
 		pendingTasks:       make(map[int32]Stage),
 		queryTimeRange:     queryTimeRange,
+		storageInterval:    storageInterval,
 		queryInterval:      queryInterval,
 		queryIntervalRatio: queryIntervalRatio,
 	}
 }
 
+// StorageInterval returns the interval of storage which query data from
+func (qf *storageQueryFlow) StorageInterval() timeutil.Interval {
+	return qf.storageInterval
+}
+
 func (qf *storageQueryFlow) Prepare(downSamplingSpecs aggregation.AggregatorSpecs) {
-	qf.reduceAgg = aggregation.NewGroupingAggregator(qf.queryInterval, qf.queryTimeRange, downSamplingSpecs)
+	qf.reduceAgg = aggregation.NewGroupingAggregator(qf.queryInterval, qf.queryTimeRange, true, downSamplingSpecs)
 	qf.aggPool = make(chan aggregation.ContainerAggregator, 64)
 	qf.downSamplingSpecs = downSamplingSpecs
 	qf.allocAgg = func(aggSpecs aggregation.AggregatorSpecs) aggregation.ContainerAggregator {
@@ -227,6 +235,11 @@ func (qf *storageQueryFlow) completeTask(taskID int32) {
 	completed = len(qf.pendingTasks) == 0
 	qf.mux.Unlock()
 
+	if completed {
+		// all tasks completed, no one reads the data families retained by query
+		qf.storageExecuteCtx.Release()
+	}
+
 	if completed && qf.completed.CAS(false, true) {
 		// if all tasks of all stages completed
 		var data []byte
@@ -296,6 +309,11 @@ func (qf *storageQueryFlow) execute(stage Stage, task concurrent.Task) {
 		// query flow is completed, reject new task execute
 		return
 	}
+	if err := qf.ctx.Err(); err != nil {
+		// query is killed or timeout, stop scanning
+		qf.Complete(err)
+		return
+	}
 	var executePool concurrent.Pool
 	switch stage {
 	case Filtering:
@@ -333,7 +351,11 @@ func (qf *storageQueryFlow) execute(stage Stage, task concurrent.Task) {
 				}
 			}()
 
-			// 2. handle task logic in background goroutine
+			// 2. handle task logic in background goroutine, if query is killed or timeout, skip it
+			if err := qf.ctx.Err(); err != nil {
+				qf.Complete(err)
+				return
+			}
 			task()
 		})
 	}
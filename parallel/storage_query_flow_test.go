@@ -28,6 +28,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
@@ -63,7 +64,7 @@ func TestStorageQueryFlow_GetAggregator(t *testing.T) {
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	queryFlow := NewStorageQueryFlow(context.TODO(), nil, &stmt.Query{GroupBy: []string{"host"}},
 		&pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Prepare(nil)
 
 	agg := queryFlow.GetAggregator(1)
@@ -87,10 +88,11 @@ func TestStorageQueryFlow_Execute(t *testing.T) {
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
 	storageExecuteCtx.EXPECT().QueryStats().Return(models.NewStorageStats()).AnyTimes()
+	storageExecuteCtx.EXPECT().Release().AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
 	reduceAgg := aggregation.NewMockGroupingAggregator(ctrl)
@@ -137,11 +139,12 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
 	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
+	storageExecuteCtx.EXPECT().Release().AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{},
 		&pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
 	// case 1: test execute task after completed
@@ -152,7 +155,7 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 
 	// case 2: test reduce result send
 	queryFlow = NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{GroupBy: []string{"host"}}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Prepare(nil)
 	qf = queryFlow.(*storageQueryFlow)
 	reduceAgg := aggregation.NewMockGroupingAggregator(ctrl)
@@ -187,7 +190,7 @@ func TestStorageQueryFlow_completeTask(t *testing.T) {
 func TestStorageQueryFlow_getValues(t *testing.T) {
 	queryFlow := NewStorageQueryFlow(context.TODO(), nil, &stmt.Query{},
 		&pb.TaskRequest{}, nil, nil,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Prepare(nil)
 	qf := queryFlow.(*storageQueryFlow)
 	qf.tagValues = make([]string, 2)
@@ -210,10 +213,11 @@ func TestStorageQueryFlow_Task_panic(t *testing.T) {
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
 	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
+	storageExecuteCtx.EXPECT().Release().AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Prepare(nil)
 	var wait sync.WaitGroup
 	wait.Add(3)
@@ -239,11 +243,42 @@ func TestStorageQueryFlow_Complete(t *testing.T) {
 
 	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
 	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
+	storageExecuteCtx.EXPECT().Release().AnyTimes()
 	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
 	queryFlow := NewStorageQueryFlow(context.TODO(), storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
-		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
 	queryFlow.Complete(nil) // err is nil, need not send err result
 	streamHandler.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
 	queryFlow.Complete(fmt.Errorf("err")) // send err result
 	queryFlow.Complete(fmt.Errorf("err")) // no send err result
 }
+
+func TestStorageQueryFlow_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	storageExecuteCtx.EXPECT().QueryStats().Return(nil).AnyTimes()
+	storageExecuteCtx.EXPECT().Release().AnyTimes()
+	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
+	ctx, cancel := context.WithCancel(context.TODO())
+	queryFlow := NewStorageQueryFlow(ctx, storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), timeutil.Interval(timeutil.OneSecond), 1)
+	queryFlow.Prepare(nil)
+	cancel()
+	// query is killed, send err result and reject task
+	streamHandler.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.True(t, resp.Completed)
+		assert.Equal(t, context.Canceled.Error(), resp.ErrMsg)
+		return nil
+	})
+	executed := atomic.NewBool(false)
+	queryFlow.Filtering(func() {
+		executed.Store(true)
+	})
+	queryFlow.Load(func() {
+		executed.Store(true)
+	})
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, executed.Load())
+}
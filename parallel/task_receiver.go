@@ -56,6 +56,7 @@ func (r *taskReceiver) Receive(resp *pb.TaskResponse) error {
 				}
 				jobCtx.Complete()
 			}
+			r.jobManager.RemoveJob(resp.JobID)
 		}
 	}
 	return nil
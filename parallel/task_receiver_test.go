@@ -53,8 +53,9 @@ func TestTaskReceiver_Receive(t *testing.T) {
 	taskManager.EXPECT().Complete("taskID")
 	taskManager.EXPECT().Get("taskID").Return(taskCtx)
 	ch := make(chan *series.TimeSeriesEvent)
-	jobCtx := NewJobContext(context.TODO(), ch, nil, nil)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "")
 	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+	jobManager.EXPECT().RemoveJob(gomock.Any())
 	a := atomic.NewInt32(0)
 
 	var wait sync.WaitGroup
@@ -89,8 +90,9 @@ func TestTaskReceiver_Receive_Err(t *testing.T) {
 	taskManager.EXPECT().Complete("taskID").MaxTimes(2)
 	taskManager.EXPECT().Get("taskID").Return(taskCtx).MaxTimes(2)
 	ch := make(chan *series.TimeSeriesEvent)
-	jobCtx := NewJobContext(context.TODO(), ch, nil, nil)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "")
 	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx).MaxTimes(2)
+	jobManager.EXPECT().RemoveJob(gomock.Any()).MaxTimes(2)
 	a := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -18,15 +18,18 @@
 package fileutil
 
 import (
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 var (
 	mkdirAllFunc  = os.MkdirAll
 	removeAllFunc = os.RemoveAll
 	removeFunc    = os.Remove
+	linkFunc      = os.Link
 )
 
 // MkDirIfNotExist creates given dir if it not exist
@@ -80,6 +83,21 @@ func ListDir(path string) ([]string, error) {
 	return result, nil
 }
 
+// DirSize returns the total size of all files under given dir
+func DirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
 // Exist check file or dir if exist
 func Exist(file string) bool {
 	if _, err := os.Stat(file); err != nil && os.IsNotExist(err) {
@@ -88,6 +106,23 @@ func Exist(file string) bool {
 	return true
 }
 
+// IsSubPath checks if the path is under the parent dir after cleaned, the parent itself is not a sub path
+func IsSubPath(parent, path string) bool {
+	absParent, err := filepath.Abs(parent)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absParent, absPath)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // GetExistPath get exist path based on given path
 func GetExistPath(path string) string {
 	if Exist(path) {
@@ -100,3 +135,53 @@ func GetExistPath(path string) string {
 	}
 	return GetExistPath(dir)
 }
+
+// CopyFile copies the content of src file into dst file, creates dst file if it not exist
+func CopyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := out.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if _, err = io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
+// LinkOrCopyFile creates dst file as hard link of src file, copies src file if hard link fails(e.g. cross devices)
+func LinkOrCopyFile(src, dst string) error {
+	if err := linkFunc(src, dst); err == nil {
+		return nil
+	}
+	return CopyFile(src, dst)
+}
+
+// CopyDir copies all files under src dir into dst dir recursively, creates dst dir if it not exist
+func CopyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relPath)
+		if info.IsDir() {
+			return MkDirIfNotExist(target)
+		}
+		return CopyFile(path, target)
+	})
+}
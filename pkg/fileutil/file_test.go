@@ -19,6 +19,7 @@ package fileutil
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -121,3 +122,79 @@ func TestRemoveFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, files, 1)
 }
+
+func TestDirSize(t *testing.T) {
+	_ = MkDirIfNotExist(filepath.Join(testPath, "sub"))
+
+	defer func() {
+		_ = RemoveDir(testPath)
+	}()
+	_ = ioutil.WriteFile(filepath.Join(testPath, "file1"), []byte("12345"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(testPath, "sub", "file2"), []byte("123"), 0644)
+	size, err := DirSize(testPath)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), size)
+
+	_, err = DirSize(filepath.Join(testPath, "not_exist"))
+	assert.Error(t, err)
+}
+
+func TestLinkOrCopyFile(t *testing.T) {
+	_ = MkDirIfNotExist(testPath)
+	defer func() {
+		linkFunc = os.Link
+		_ = RemoveDir(testPath)
+	}()
+	src := filepath.Join(testPath, "src")
+	assert.NoError(t, ioutil.WriteFile(src, []byte("data"), 0644))
+	// case 1: src not exist
+	assert.Error(t, LinkOrCopyFile(filepath.Join(testPath, "not_exist"), filepath.Join(testPath, "dst")))
+	// case 2: dst dir not exist
+	linkFunc = func(oldname, newname string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, LinkOrCopyFile(src, filepath.Join(testPath, "not_exist", "dst")))
+	// case 3: copy file if link fail
+	assert.NoError(t, LinkOrCopyFile(src, filepath.Join(testPath, "copy")))
+	data, err := ioutil.ReadFile(filepath.Join(testPath, "copy"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	// case 4: hard link
+	linkFunc = os.Link
+	assert.NoError(t, LinkOrCopyFile(src, filepath.Join(testPath, "link")))
+	data, err = ioutil.ReadFile(filepath.Join(testPath, "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
+
+func TestCopyDir(t *testing.T) {
+	src := filepath.Join(testPath, "src")
+	dst := filepath.Join(testPath, "dst")
+	defer func() {
+		_ = RemoveDir(testPath)
+	}()
+	// case 1: src not exist
+	assert.Error(t, CopyDir(src, dst))
+	// case 2: copy dir recursively
+	_ = MkDirIfNotExist(filepath.Join(src, "a"))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "f1"), []byte("f1"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "a", "f2"), []byte("f2"), 0644))
+	assert.NoError(t, CopyDir(src, dst))
+	data, err := ioutil.ReadFile(filepath.Join(dst, "f1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("f1"), data)
+	data, err = ioutil.ReadFile(filepath.Join(dst, "a", "f2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("f2"), data)
+}
+
+func TestIsSubPath(t *testing.T) {
+	assert.True(t, IsSubPath("/data/backup", "/data/backup/b1"))
+	assert.True(t, IsSubPath("/data/backup", "/data/backup/a/../b1"))
+	assert.True(t, IsSubPath("data/backup", "data/backup/b1"))
+	assert.False(t, IsSubPath("/data/backup", "/data/backup"))
+	assert.False(t, IsSubPath("/data/backup", "/data/backup/.."))
+	assert.False(t, IsSubPath("/data/backup", "/data/backup/../../etc"))
+	assert.False(t, IsSubPath("/data/backup", "/data/backup2"))
+	assert.False(t, IsSubPath("/data/backup", "/data/..backup"))
+}
@@ -19,6 +19,7 @@ package option
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/lindb/lindb/pkg/timeutil"
 )
@@ -29,14 +30,71 @@ type DatabaseOption struct {
 	// rollup intervals(like seconds->minute->hour->day)
 	Rollup []string `toml:"rollup" json:"rollup,omitempty"`
 
+	// data retention(ttl) for write interval data, keeps data forever if not set
+	TTL string `toml:"ttl" json:"ttl,omitempty"`
+	// data retention(ttl) for each rollup interval, same order with rollup intervals
+	RollupTTL []string `toml:"rollupTTL" json:"rollupTTL,omitempty"`
+
 	// auto create namespace
 	AutoCreateNS bool `toml:"autoCreateNS" json:"autoCreateNS,omitempty"`
+	// pre-defined namespaces which are allowed to write if auto create namespace is disabled,
+	// all namespaces are allowed if not set
+	Namespaces []string `toml:"namespaces" json:"namespaces,omitempty"`
 
 	Behind string `toml:"behind" json:"behind,omitempty"` // allowed timestamp write behind
 	Ahead  string `toml:"ahead" json:"ahead,omitempty"`   // allowed timestamp write ahead
+	// policy of handling the writes out of time window(behind/ahead), drop if not set
+	OutOfWindow string `toml:"outOfWindow" json:"outOfWindow,omitempty"`
+	// durability level of write acknowledgement(buffered/wal/replicated:N), buffered if not set
+	WriteConsistency string `toml:"writeConsistency" json:"writeConsistency,omitempty"`
 
 	Index FlusherOption `toml:"index" json:"index,omitempty"` // index flusher option
 	Data  FlusherOption `toml:"data" json:"data,omitempty"`   // data flusher data
+
+	Limits LimitOption `toml:"limits" json:"limits,omitempty"` // cardinality limits of writing
+}
+
+// defines the policies of handling the writes out of time window(older than behind or newer than ahead)
+const (
+	// OutOfWindowDrop drops the out-of-window writes, reports the dropped count(default policy)
+	OutOfWindowDrop = "drop"
+	// OutOfWindowReject rejects the out-of-window writes, reports the errors to client
+	OutOfWindowReject = "reject"
+	// OutOfWindowBackfill writes the late data(older than behind) by backfill path, drops the data newer than ahead
+	OutOfWindowBackfill = "backfill"
+)
+
+// defines the reasons of out-of-window writes
+const (
+	// TooLate represents the timestamp is older than behind
+	TooLate = "too_late"
+	// TooEarly represents the timestamp is newer than ahead
+	TooEarly = "too_early"
+)
+
+// CheckTimeWindow checks if the timestamp is in acceptable time range based on behind/ahead(0 means no limit),
+// returns the reason if out of time window, else returns empty string.
+func CheckTimeWindow(timestamp, now, behind, ahead int64) string {
+	if behind > 0 && timestamp < now-behind {
+		return TooLate
+	}
+	if ahead > 0 && timestamp > now+ahead {
+		return TooEarly
+	}
+	return ""
+}
+
+// TimeWindow returns the allowed write behind/ahead of timestamp(millisecond), 0 means no limit
+func (e DatabaseOption) TimeWindow() (behind, ahead int64) {
+	var behindInterval, aheadInterval timeutil.Interval
+	_ = behindInterval.ValueOf(e.Behind)
+	_ = aheadInterval.ValueOf(e.Ahead)
+	return behindInterval.Int64(), aheadInterval.Int64()
+}
+
+// IsBackfill checks if the out-of-window write need to be written by backfill path based on policy
+func (e DatabaseOption) IsBackfill(reason string) bool {
+	return reason == TooLate && e.OutOfWindow == OutOfWindowBackfill
 }
 
 // FlusherOption represents a flusher configuration for index and memory db
@@ -45,6 +103,31 @@ type FlusherOption struct {
 	SizeThreshold int64 `toml:"sizeThreshold" json:"sizeThreshold"` // size level flush threshold, unit(MB)
 }
 
+// LimitOption represents the cardinality limits of writing, unlimited if limit value is 0.
+// NOTICE: limits are not global for the whole cluster, they are checked on each storage node,
+// 1) metrics of namespace and tag values of tag key are limited per node, because metadata is node-local,
+// they maybe exceeded slightly by the concurrent writes of different shards
+// 2) series of metric are limited per shard, because series ids are generated by each shard,
+// so the effective series limit of one metric is num. of shards × MaxSeriesPerMetric.
+type LimitOption struct {
+	MaxMetricsPerNamespace int `toml:"maxMetricsPerNamespace" json:"maxMetricsPerNamespace,omitempty"` // max metrics of one namespace
+	MaxSeriesPerMetric     int `toml:"maxSeriesPerMetric" json:"maxSeriesPerMetric,omitempty"`         // max series of one metric per shard
+	MaxTagValuesPerTagKey  int `toml:"maxTagValuesPerTagKey" json:"maxTagValuesPerTagKey,omitempty"`   // max tag values of one tag key
+}
+
+// HasSeriesLimit checks if series or tag values limit is set
+func (l LimitOption) HasSeriesLimit() bool {
+	return l.MaxSeriesPerMetric > 0 || l.MaxTagValuesPerTagKey > 0
+}
+
+// Validate checks if the limit values are valid
+func (l LimitOption) Validate() error {
+	if l.MaxMetricsPerNamespace < 0 || l.MaxSeriesPerMetric < 0 || l.MaxTagValuesPerTagKey < 0 {
+		return fmt.Errorf("cardinality limit cannot be negative")
+	}
+	return nil
+}
+
 // Validate validates engine option if valid
 func (e DatabaseOption) Validate() error {
 	if err := validateInterval(e.Interval, true); err != nil {
@@ -55,24 +138,145 @@ func (e DatabaseOption) Validate() error {
 			return err
 		}
 	}
+	if err := validateInterval(e.TTL, false); err != nil {
+		return err
+	}
+	if len(e.RollupTTL) > 0 && len(e.RollupTTL) != len(e.Rollup) {
+		return fmt.Errorf("rollup ttl must be matched with rollup interval")
+	}
+	for _, ttl := range e.RollupTTL {
+		if err := validateInterval(ttl, false); err != nil {
+			return err
+		}
+	}
 	if err := validateInterval(e.Ahead, false); err != nil {
 		return err
 	}
 	if err := validateInterval(e.Behind, false); err != nil {
 		return err
 	}
+	switch e.OutOfWindow {
+	case "", OutOfWindowDrop, OutOfWindowReject, OutOfWindowBackfill:
+	default:
+		return fmt.Errorf("unknown out of window policy: %s", e.OutOfWindow)
+	}
+	if _, err := ParseWriteConsistency(e.WriteConsistency); err != nil {
+		return err
+	}
+	if err := e.Limits.Validate(); err != nil {
+		return err
+	}
 	var interval timeutil.Interval
 	_ = interval.ValueOf(e.Interval)
-	for _, intervalStr := range e.Rollup {
+	rollupIntervals := make([]string, len(e.Rollup))
+	copy(rollupIntervals, e.Rollup)
+	for _, intervalStr := range rollupIntervals {
 		var rollupInterval timeutil.Interval
 		_ = rollupInterval.ValueOf(intervalStr)
 		if interval.Int64() >= rollupInterval.Int64() {
 			return fmt.Errorf("rollup interval must be large than write interval")
 		}
 	}
+	sort.Slice(rollupIntervals, func(i, j int) bool {
+		var left, right timeutil.Interval
+		_ = left.ValueOf(rollupIntervals[i])
+		_ = right.ValueOf(rollupIntervals[j])
+		return left < right
+	})
+	// there is one segment for each interval type, so each storage interval must have different interval type
+	previous, previousStr := interval, e.Interval
+	for _, intervalStr := range rollupIntervals {
+		var rollupInterval timeutil.Interval
+		_ = rollupInterval.ValueOf(intervalStr)
+		if rollupInterval.Type() == previous.Type() {
+			return fmt.Errorf("rollup interval [%s] has same interval type [%s] with interval [%s]",
+				intervalStr, rollupInterval.Type(), previousStr)
+		}
+		if rollupInterval.Int64()%previous.Int64() != 0 {
+			return fmt.Errorf("rollup interval [%s] must be a multiple of interval [%s]", intervalStr, previousStr)
+		}
+		previous, previousStr = rollupInterval, intervalStr
+	}
+	return e.validateRetention(interval)
+}
+
+// storageRetention represents the data retention(ttl) of storage interval, empty ttl means keeps data forever
+type storageRetention struct {
+	interval    timeutil.Interval
+	intervalStr string
+	ttl         timeutil.Interval
+	ttlStr      string
+}
+
+// validateRetention checks the data retention(ttl) of storage intervals sorted by interval,
+// 1) ttl cannot be shorter than next rollup interval, else data expires before it is rolled up
+// 2) ttl of rollup interval cannot be shorter than ttl of its source interval
+func (e DatabaseOption) validateRetention(interval timeutil.Interval) error {
+	retentions := []storageRetention{{interval: interval, intervalStr: e.Interval, ttlStr: e.TTL}}
+	for idx, intervalStr := range e.Rollup {
+		retention := storageRetention{intervalStr: intervalStr}
+		_ = retention.interval.ValueOf(intervalStr)
+		if idx < len(e.RollupTTL) {
+			retention.ttlStr = e.RollupTTL[idx]
+		}
+		retentions = append(retentions, retention)
+	}
+	for idx := range retentions {
+		_ = retentions[idx].ttl.ValueOf(retentions[idx].ttlStr)
+	}
+	sort.Slice(retentions, func(i, j int) bool {
+		return retentions[i].interval < retentions[j].interval
+	})
+	for idx := 1; idx < len(retentions); idx++ {
+		source, rollup := retentions[idx-1], retentions[idx]
+		if source.ttlStr == "" {
+			if rollup.ttlStr != "" {
+				return fmt.Errorf("rollup ttl [%s] of interval [%s] cannot be shorter than ttl(forever) of interval [%s]",
+					rollup.ttlStr, rollup.intervalStr, source.intervalStr)
+			}
+			continue
+		}
+		if source.ttl < rollup.interval {
+			return fmt.Errorf("ttl [%s] of interval [%s] cannot be shorter than rollup interval [%s]",
+				source.ttlStr, source.intervalStr, rollup.intervalStr)
+		}
+		if rollup.ttlStr != "" && rollup.ttl < source.ttl {
+			return fmt.Errorf("rollup ttl [%s] of interval [%s] cannot be shorter than ttl [%s] of interval [%s]",
+				rollup.ttlStr, rollup.intervalStr, source.ttlStr, source.intervalStr)
+		}
+	}
 	return nil
 }
 
+// GetStorageIntervals returns the intervals which store data, sorted by interval asc,
+// includes write interval and rollup intervals.
+// NOTICE: there is one segment for each interval type, Validate rejects the rollup intervals with same interval type
+// or which are not a multiple of previous storage interval, here still ignores them for the options created before.
+func (e DatabaseOption) GetStorageIntervals() []timeutil.Interval {
+	var interval timeutil.Interval
+	if err := interval.ValueOf(e.Interval); err != nil || interval <= 0 {
+		return nil
+	}
+	var rollupIntervals []timeutil.Interval
+	for _, intervalStr := range e.Rollup {
+		var rollupInterval timeutil.Interval
+		if err := rollupInterval.ValueOf(intervalStr); err == nil && rollupInterval > interval {
+			rollupIntervals = append(rollupIntervals, rollupInterval)
+		}
+	}
+	sort.Slice(rollupIntervals, func(i, j int) bool {
+		return rollupIntervals[i] < rollupIntervals[j]
+	})
+	intervals := []timeutil.Interval{interval}
+	for _, rollupInterval := range rollupIntervals {
+		previous := intervals[len(intervals)-1]
+		if rollupInterval.Type() != previous.Type() && rollupInterval.Int64()%previous.Int64() == 0 {
+			intervals = append(intervals, rollupInterval)
+		}
+	}
+	return intervals
+}
+
 // validateInterval checks interval string if valid
 func validateInterval(intervalStr string, require bool) error {
 	if !require && intervalStr == "" {
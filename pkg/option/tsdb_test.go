@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func Test_DatabaseOption_Validate(t *testing.T) {
@@ -36,12 +38,101 @@ func Test_DatabaseOption_Validate(t *testing.T) {
 	assert.NotNil(t, databaseOption.Validate())
 	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1s", "1m", "1h"}}
 	assert.NotNil(t, databaseOption.Validate())
-	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"20s", "1m", "1h"}}
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1h", "5m"}}
 	assert.Nil(t, databaseOption.Validate())
+	// same interval type with write interval or other rollup interval
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"20s", "1m", "1h"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "10m"}}
+	assert.NotNil(t, databaseOption.Validate())
+	// not a multiple of previous interval
+	databaseOption = DatabaseOption{Interval: "7s", Rollup: []string{"5m"}}
+	assert.NotNil(t, databaseOption.Validate())
 	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"10s", "1m", "1h"}, Ahead: "aa"}
 	assert.NotNil(t, databaseOption.Validate())
 	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"10s", "1m", "1h"}, Behind: "aa"}
 	assert.NotNil(t, databaseOption.Validate())
-	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"20s", "1m", "1h"}, Behind: "10h", Ahead: "1h"}
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}, Behind: "10h", Ahead: "1h"}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", TTL: "aa"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}, RollupTTL: []string{"30d"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}, RollupTTL: []string{"30d", "aa"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}, TTL: "7d", RollupTTL: []string{"30d", ""}}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Limits: LimitOption{MaxSeriesPerMetric: -1}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Limits: LimitOption{MaxSeriesPerMetric: 100, MaxTagValuesPerTagKey: 10}}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", OutOfWindow: "abc"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", OutOfWindow: OutOfWindowReject}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", WriteConsistency: "replicated:0"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", WriteConsistency: "replicated:2"}
+	assert.Nil(t, databaseOption.Validate())
+}
+
+func Test_DatabaseOption_Validate_Retention(t *testing.T) {
+	// ttl shorter than next rollup interval
+	databaseOption := DatabaseOption{Interval: "10s", Rollup: []string{"1d"}, TTL: "1h"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1d", "5m"}, TTL: "1d", RollupTTL: []string{"", "1h"}}
+	assert.NotNil(t, databaseOption.Validate())
+	// rollup ttl shorter than ttl of source interval
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, TTL: "7d", RollupTTL: []string{"1d"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, RollupTTL: []string{"30d"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1h", "5m"}, TTL: "7d", RollupTTL: []string{"7d", "30d"}}
+	assert.NotNil(t, databaseOption.Validate())
+	// valid retentions
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1d"}, TTL: "1d"}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1h", "5m"}, TTL: "7d", RollupTTL: []string{"90d", "30d"}}
+	assert.Nil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"5m"}, TTL: "7d"}
 	assert.Nil(t, databaseOption.Validate())
 }
+
+func Test_DatabaseOption_TimeWindow(t *testing.T) {
+	behind, ahead := DatabaseOption{Behind: "1m", Ahead: "10s"}.TimeWindow()
+	assert.Equal(t, int64(60*1000), behind)
+	assert.Equal(t, int64(10*1000), ahead)
+	behind, ahead = DatabaseOption{}.TimeWindow()
+	assert.Zero(t, behind)
+	assert.Zero(t, ahead)
+
+	assert.Equal(t, "", CheckTimeWindow(100, 100, 10, 10))
+	assert.Equal(t, TooLate, CheckTimeWindow(89, 100, 10, 10))
+	assert.Equal(t, TooEarly, CheckTimeWindow(111, 100, 10, 10))
+	assert.Equal(t, "", CheckTimeWindow(0, 100, 0, 0))
+
+	assert.True(t, DatabaseOption{OutOfWindow: OutOfWindowBackfill}.IsBackfill(TooLate))
+	assert.False(t, DatabaseOption{OutOfWindow: OutOfWindowBackfill}.IsBackfill(TooEarly))
+	assert.False(t, DatabaseOption{OutOfWindow: OutOfWindowDrop}.IsBackfill(TooLate))
+}
+
+func Test_LimitOption_HasSeriesLimit(t *testing.T) {
+	assert.False(t, LimitOption{MaxMetricsPerNamespace: 10}.HasSeriesLimit())
+	assert.True(t, LimitOption{MaxSeriesPerMetric: 10}.HasSeriesLimit())
+	assert.True(t, LimitOption{MaxTagValuesPerTagKey: 10}.HasSeriesLimit())
+}
+
+func Test_DatabaseOption_GetStorageIntervals(t *testing.T) {
+	assert.Empty(t, DatabaseOption{Interval: "aa"}.GetStorageIntervals())
+	assert.Equal(t, []timeutil.Interval{timeutil.Interval(10 * timeutil.OneSecond)},
+		DatabaseOption{Interval: "10s"}.GetStorageIntervals())
+	// keep smallest interval for each interval type, sort by interval
+	assert.Equal(t, []timeutil.Interval{
+		timeutil.Interval(10 * timeutil.OneSecond),
+		timeutil.Interval(5 * timeutil.OneMinute),
+		timeutil.Interval(timeutil.OneHour)},
+		DatabaseOption{Interval: "10s", Rollup: []string{"1h", "20s", "10m", "5m", "2h"}}.GetStorageIntervals())
+	// ignore invalid interval and interval which is not a multiple of previous interval
+	assert.Equal(t, []timeutil.Interval{timeutil.Interval(7 * timeutil.OneSecond), timeutil.Interval(7 * timeutil.OneMinute)},
+		DatabaseOption{Interval: "7s", Rollup: []string{"aa", "5m", "7m"}}.GetStorageIntervals())
+}
@@ -0,0 +1,74 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package option
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defines the durability levels of write acknowledgement
+const (
+	// ConsistencyBuffered acknowledges after the data is appended into the buffer of broker(default level)
+	ConsistencyBuffered = "buffered"
+	// ConsistencyWAL acknowledges after the data is persisted in the write ahead log of broker
+	ConsistencyWAL = "wal"
+	// ConsistencyReplicated acknowledges after N storage replicas have acked the data
+	ConsistencyReplicated = "replicated"
+)
+
+// WriteConsistency represents the durability level of write acknowledgement
+type WriteConsistency struct {
+	Level    string // buffered/wal/replicated
+	Replicas int    // number of replicas need to ack the data for replicated level
+}
+
+// ParseWriteConsistency parses the write consistency from string(buffered/wal/replicated:N),
+// returns buffered level if string is empty.
+func ParseWriteConsistency(value string) (WriteConsistency, error) {
+	switch value {
+	case "", ConsistencyBuffered:
+		return WriteConsistency{Level: ConsistencyBuffered}, nil
+	case ConsistencyWAL:
+		return WriteConsistency{Level: ConsistencyWAL}, nil
+	}
+	if strings.HasPrefix(value, ConsistencyReplicated+":") {
+		replicas, err := strconv.Atoi(strings.TrimPrefix(value, ConsistencyReplicated+":"))
+		if err == nil && replicas > 0 {
+			return WriteConsistency{Level: ConsistencyReplicated, Replicas: replicas}, nil
+		}
+	}
+	return WriteConsistency{}, fmt.Errorf("unknown write consistency: %s, supports buffered/wal/replicated:N", value)
+}
+
+// NeedWAL checks if the write need to wait the data persisted in write ahead log
+func (c WriteConsistency) NeedWAL() bool {
+	return c.Level == ConsistencyWAL || c.Level == ConsistencyReplicated
+}
+
+// String returns the string value of write consistency
+func (c WriteConsistency) String() string {
+	if c.Level == ConsistencyReplicated {
+		return fmt.Sprintf("%s:%d", c.Level, c.Replicas)
+	}
+	if c.Level == "" {
+		return ConsistencyBuffered
+	}
+	return c.Level
+}
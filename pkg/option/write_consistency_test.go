@@ -0,0 +1,51 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWriteConsistency(t *testing.T) {
+	cases := []struct {
+		value    string
+		expect   WriteConsistency
+		needWAL  bool
+		toString string
+	}{
+		{"", WriteConsistency{Level: ConsistencyBuffered}, false, "buffered"},
+		{"buffered", WriteConsistency{Level: ConsistencyBuffered}, false, "buffered"},
+		{"wal", WriteConsistency{Level: ConsistencyWAL}, true, "wal"},
+		{"replicated:2", WriteConsistency{Level: ConsistencyReplicated, Replicas: 2}, true, "replicated:2"},
+	}
+	for _, c := range cases {
+		consistency, err := ParseWriteConsistency(c.value)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expect, consistency)
+		assert.Equal(t, c.needWAL, consistency.NeedWAL())
+		assert.Equal(t, c.toString, consistency.String())
+	}
+	assert.Equal(t, "buffered", WriteConsistency{}.String())
+
+	for _, value := range []string{"abc", "replicated", "replicated:", "replicated:0", "replicated:a"} {
+		_, err := ParseWriteConsistency(value)
+		assert.Error(t, err)
+	}
+}
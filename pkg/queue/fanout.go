@@ -57,6 +57,10 @@ type FanOutQueue interface {
 	HeadSeq() int64
 	// TailSeq returns the tailSeq which is the smallest seq among all the fanOut tailSeq.
 	TailSeq() int64
+	// DataSize returns the disk size of data/index page files.
+	DataSize() int64
+	// DataSizeLimit returns the max disk size limit of data/index page files.
+	DataSizeLimit() int64
 	// Close persists Seq meta, FanOut seq meta, release resources.
 	Close()
 	// get gets the message data by spec consume sequence
@@ -159,6 +163,16 @@ func (fq *fanOutQueue) TailSeq() int64 {
 	return fq.queue.TailSeq()
 }
 
+// DataSize returns the disk size of data/index page files.
+func (fq *fanOutQueue) DataSize() int64 {
+	return fq.queue.DataSize()
+}
+
+// DataSizeLimit returns the max disk size limit of data/index page files.
+func (fq *fanOutQueue) DataSizeLimit() int64 {
+	return fq.queue.DataSizeLimit()
+}
+
 // Sync checks all the FanOuts tailSeqs, update the tailSeq as the smallest one.
 // Then syncs meta data to storage.
 func (fq *fanOutQueue) Sync() {
@@ -259,6 +259,8 @@ func TestFanOutQueue_one_consumer(t *testing.T) {
 	assert.Empty(t, fq.FanOutNames())
 	assert.Equal(t, int64(0), fq.HeadSeq())
 	assert.Equal(t, int64(-1), fq.TailSeq())
+	assert.True(t, fq.DataSize() > 0)
+	assert.Equal(t, int64(defaultDataSizeLimit), fq.DataSizeLimit())
 
 	f1, err := fq.GetOrCreateFanOut("f1")
 	assert.NoError(t, err)
@@ -57,6 +57,10 @@ type Queue interface {
 	Get(sequence int64) (message []byte, err error)
 	// Size returns the total size of message.
 	Size() int64
+	// DataSize returns the disk size of data/index page files.
+	DataSize() int64
+	// DataSizeLimit returns the max disk size limit of data/index page files.
+	DataSizeLimit() int64
 	// IsEmpty returns if queue is empty
 	IsEmpty() bool
 	// HeadSeq returns the head seq which stands for the latest read barrier.
@@ -252,6 +256,16 @@ func (q *queue) Size() int64 {
 	return q.HeadSeq() - q.TailSeq()
 }
 
+// DataSize returns the disk size of data/index page files.
+func (q *queue) DataSize() int64 {
+	return q.dataPageFct.Size() + q.indexPageFct.Size()
+}
+
+// DataSizeLimit returns the max disk size limit of data/index page files.
+func (q *queue) DataSizeLimit() int64 {
+	return q.dataSizeLimit
+}
+
 // HeadSeq returns the head seq which stands for the latest read barrier.
 // New message is appended at head seq.
 func (q *queue) HeadSeq() int64 {
@@ -507,7 +521,7 @@ func (q *queue) validateSequence(sequence int64) error {
 
 // checkDataSize checks the data size if exceeds the size limit
 func (q *queue) checkDataSize() error {
-	if q.dataPageFct.Size()+q.indexPageFct.Size() > q.dataSizeLimit {
+	if q.DataSize() > q.dataSizeLimit {
 		return ErrExceedingTotalSizeLimit
 	}
 	return nil
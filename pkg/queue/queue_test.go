@@ -47,6 +47,8 @@ func TestQueue_Put(t *testing.T) {
 	assert.Equal(t, int64(0), q.Size())
 	assert.Equal(t, int64(-1), q.HeadSeq())
 	assert.Equal(t, int64(-1), q.TailSeq())
+	assert.True(t, q.DataSize() > 0)
+	assert.Equal(t, int64(defaultDataSizeLimit), q.DataSizeLimit())
 	// case 2: put data
 	err = q.Put([]byte("123"))
 	assert.NoError(t, err)
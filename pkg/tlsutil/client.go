@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lindb/lindb/config"
+)
+
+// NewHTTPClient creates the http client with timeout, returns the scheme(http/https) of requests,
+// if tls enable, requests by https and presents the certificate for mutual tls.
+func NewHTTPClient(cfg config.TLS, timeout time.Duration) (scheme string, client *http.Client, err error) {
+	client = &http.Client{Timeout: timeout}
+	if !cfg.Enable {
+		return "http", client, nil
+	}
+	reloader, err := NewCertReloader(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	client.Transport = &http.Transport{
+		// builds tls config for each connection, so that the reloaded certificate/ca are used
+		DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialTLS(ctx, dialer, reloader, network, addr)
+		},
+	}
+	return "https", client, nil
+}
+
+// dialTLS dials the address and does the tls handshake with current certificate/ca pool,
+// verifies the server certificate by the host of address if server name not set.
+func dialTLS(ctx context.Context, dialer *net.Dialer, reloader *CertReloader, network, addr string) (net.Conn, error) {
+	rawConn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	cfg := reloader.ClientConfig()
+	if len(cfg.ServerName) == 0 {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			_ = rawConn.Close()
+			return nil, err
+		}
+		cfg.ServerName = host
+	}
+	conn := tls.Client(rawConn, cfg)
+	if err := conn.Handshake(); err != nil {
+		_ = rawConn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,120 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	scheme, client, err := NewHTTPClient(config.TLS{}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "http", scheme)
+	assert.Equal(t, time.Second, client.Timeout)
+
+	_, _, err = NewHTTPClient(config.TLS{Enable: true}, time.Second)
+	assert.Error(t, err)
+
+	// mutual tls
+	cfg := newTestConfig(t)
+	r, err := NewCertReloader(cfg)
+	assert.NoError(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = r.ServerConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	scheme, client, err = NewHTTPClient(cfg, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "https", scheme)
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+func TestDialTLS(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	cfg := newTestConfig(t)
+	r, err := NewCertReloader(cfg)
+	assert.NoError(t, err)
+	r.reloadInterval = time.Millisecond
+	listener, err := tls.Listen("tcp", "localhost:0", r.ServerConfig())
+	assert.NoError(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+	dialer := &net.Dialer{Timeout: time.Second}
+	addr := listener.Addr().String()
+	conn, err := dialTLS(context.TODO(), dialer, r, "tcp", addr)
+	assert.NoError(t, err)
+	_ = conn.Close()
+
+	// rotate certificate and ca, verify server by new ca
+	ca := newTestCert(t, 9, nil, true)
+	ca.write(t, cfg.CAFile, "")
+	newTestCert(t, 10, ca, false).write(t, cfg.CertFile, cfg.KeyFile)
+	modTime := time.Now().Add(time.Minute)
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.CAFile} {
+		assert.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+	time.Sleep(2 * time.Millisecond)
+	conn, err = dialTLS(context.TODO(), dialer, r, "tcp", addr)
+	assert.NoError(t, err)
+	_ = conn.Close()
+
+	// server name verified by host of address if not set
+	r.cfg.ServerName = ""
+	_, err = dialTLS(context.TODO(), dialer, r, "tcp", addr)
+	assert.Error(t, err)
+	_, err = dialTLS(context.TODO(), dialer, r, "tcp", "localhost")
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsutil
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// clientCredentials implements credentials.TransportCredentials of grpc client,
+// builds the tls config by current certificate/ca pool for each handshake,
+// so that the long-lived connections verify the server certificate by the reloaded ca when reconnecting.
+type clientCredentials struct {
+	reloader   *CertReloader
+	serverName string
+}
+
+// NewClientCredentials creates the grpc client transport credentials based on certificate reloader
+func NewClientCredentials(reloader *CertReloader) credentials.TransportCredentials {
+	return &clientCredentials{reloader: reloader}
+}
+
+// ClientHandshake does the tls handshake with the current tls config
+func (c *clientCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.newTLS().ClientHandshake(ctx, authority, rawConn)
+}
+
+// ServerHandshake does the tls handshake with the current tls config
+func (c *clientCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.newTLS().ServerHandshake(rawConn)
+}
+
+// Info returns the protocol info of tls
+func (c *clientCredentials) Info() credentials.ProtocolInfo {
+	return c.newTLS().Info()
+}
+
+// Clone returns a copy of credentials
+func (c *clientCredentials) Clone() credentials.TransportCredentials {
+	return &clientCredentials{reloader: c.reloader, serverName: c.serverName}
+}
+
+// OverrideServerName overrides the server name used to verify the server certificate
+func (c *clientCredentials) OverrideServerName(serverName string) error {
+	c.serverName = serverName
+	return nil
+}
+
+// newTLS returns the tls credentials with current certificate/ca pool
+func (c *clientCredentials) newTLS() credentials.TransportCredentials {
+	cfg := c.reloader.ClientConfig()
+	if len(c.serverName) > 0 {
+		cfg.ServerName = c.serverName
+	}
+	return credentials.NewTLS(cfg)
+}
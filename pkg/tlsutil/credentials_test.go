@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestClientCredentials(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	cfg := newTestConfig(t)
+	r, err := NewCertReloader(cfg)
+	assert.NoError(t, err)
+	r.reloadInterval = time.Millisecond
+	creds := NewClientCredentials(r)
+	assert.Equal(t, "tls", creds.Info().SecurityProtocol)
+	assert.NoError(t, creds.OverrideServerName("localhost"))
+	assert.NotNil(t, creds.Clone())
+
+	clientHandshake := func() error {
+		serverConn, clientConn := net.Pipe()
+		defer func() {
+			_ = serverConn.Close()
+			_ = clientConn.Close()
+		}()
+		go func() {
+			_ = tls.Server(serverConn, r.ServerConfig()).Handshake()
+			_ = serverConn.Close()
+		}()
+		_, _, err := creds.ClientHandshake(context.TODO(), "localhost:9000", clientConn)
+		return err
+	}
+	assert.NoError(t, clientHandshake())
+
+	// rotate certificate and ca, credentials created before verify server by new ca
+	ca := newTestCert(t, 7, nil, true)
+	ca.write(t, cfg.CAFile, "")
+	newTestCert(t, 8, ca, false).write(t, cfg.CertFile, cfg.KeyFile)
+	modTime := time.Now().Add(time.Minute)
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.CAFile} {
+		assert.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+	time.Sleep(2 * time.Millisecond)
+	assert.NoError(t, clientHandshake())
+}
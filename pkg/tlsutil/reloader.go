@@ -0,0 +1,219 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// defaultReloadInterval represents the default interval of checking certificate files modified
+const defaultReloadInterval = 10 * time.Second
+
+var errNoClientCertificate = errors.New("client certificate required")
+
+// CertReloader loads the certificate/private key/ca files,
+// checks if files modified when using the certificate, then reloads them,
+// so that the certificates can be rotated without restart.
+type CertReloader struct {
+	cfg            config.TLS
+	reloadInterval time.Duration
+
+	cert   atomic.Value // *tls.Certificate
+	caPool atomic.Value // *x509.CertPool
+
+	mutex     sync.Mutex
+	modTimes  map[string]time.Time
+	lastCheck time.Time
+
+	logger *logger.Logger
+}
+
+// NewCertReloader creates the certificate reloader, loads certificate files at once
+func NewCertReloader(cfg config.TLS) (*CertReloader, error) {
+	if len(cfg.CertFile) == 0 || len(cfg.KeyFile) == 0 {
+		return nil, fmt.Errorf("cert file and key file are required when tls enable")
+	}
+	reloadInterval := cfg.ReloadInterval.Duration()
+	if reloadInterval <= 0 {
+		reloadInterval = defaultReloadInterval
+	}
+	r := &CertReloader{
+		cfg:            cfg,
+		reloadInterval: reloadInterval,
+		lastCheck:      time.Now(),
+		logger:         logger.GetLogger("pkg/tlsutil", "CertReloader"),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ServerConfig returns the tls config of server,
+// if ca file is set, requires and verifies the client certificate(mutual tls).
+func (r *CertReloader) ServerConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.getCertificate(), nil
+		},
+	}
+	if len(r.cfg.CAFile) > 0 {
+		// verifies client certificate by current ca pool, because ca maybe reloaded
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyPeerCertificate = r.verifyClientCertificate
+	}
+	return cfg
+}
+
+// ClientConfig returns the tls config of client, presents the certificate for mutual tls,
+// verifies the server certificate by current ca pool(system ca pool if ca file not set).
+func (r *CertReloader) ClientConfig() *tls.Config {
+	r.maybeReload()
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.cfg.ServerName,
+		RootCAs:    r.getCAPool(),
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.getCertificate(), nil
+		},
+	}
+}
+
+// getCertificate returns the current certificate, reloads it if files modified
+func (r *CertReloader) getCertificate() *tls.Certificate {
+	r.maybeReload()
+	return r.cert.Load().(*tls.Certificate)
+}
+
+// getCAPool returns the current ca pool, returns nil if ca file not set
+func (r *CertReloader) getCAPool() *x509.CertPool {
+	pool, ok := r.caPool.Load().(*x509.CertPool)
+	if !ok {
+		return nil
+	}
+	return pool
+}
+
+// verifyClientCertificate verifies the client certificate chain by current ca pool
+func (r *CertReloader) verifyClientCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errNoClientCertificate
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for idx, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return err
+		}
+		certs[idx] = cert
+	}
+	opts := x509.VerifyOptions{
+		Roots:         r.getCAPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// maybeReload checks if certificate files modified every reload interval, then reloads them.
+// keeps the old certificates if reload failure.
+func (r *CertReloader) maybeReload() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCheck) < r.reloadInterval {
+		return
+	}
+	r.lastCheck = now
+	if !r.modified() {
+		return
+	}
+	if err := r.load(); err != nil {
+		r.logger.Error("reload tls certificate error, keep using old certificate", logger.Error(err))
+		return
+	}
+	r.logger.Info("reload tls certificate successfully", logger.String("cert", r.cfg.CertFile))
+}
+
+// modified checks if the modification time of certificate files changed
+func (r *CertReloader) modified() bool {
+	for file, modTime := range r.modTimes {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// load loads the certificate/private key/ca files, records modification time of files
+func (r *CertReloader) load() error {
+	modTimes := make(map[string]time.Time)
+	// record modification time before loading, so that the files modified when loading will be reloaded
+	for _, file := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		if len(file) == 0 {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+	var pool *x509.CertPool
+	if len(r.cfg.CAFile) > 0 {
+		data, err := ioutil.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("cannot parse ca certificate from file: %s", r.cfg.CAFile)
+		}
+	}
+	r.cert.Store(&cert)
+	if pool != nil {
+		r.caPool.Store(pool)
+	}
+	r.modTimes = modTimes
+	return nil
+}
@@ -0,0 +1,213 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+var testPath = "./tmp"
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, serial int64, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "lindb"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	}
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	assert.NoError(t, ioutil.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	if len(keyFile) > 0 {
+		keyDer, err := x509.MarshalECPrivateKey(c.key)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(keyFile,
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	}
+}
+
+func newTestConfig(t *testing.T) config.TLS {
+	ca := newTestCert(t, 1, nil, true)
+	cert := newTestCert(t, 2, ca, false)
+	cfg := config.TLS{
+		Enable:     true,
+		CertFile:   filepath.Join(testPath, "cert.pem"),
+		KeyFile:    filepath.Join(testPath, "key.pem"),
+		CAFile:     filepath.Join(testPath, "ca.pem"),
+		ServerName: "localhost",
+	}
+	ca.write(t, cfg.CAFile, "")
+	cert.write(t, cfg.CertFile, cfg.KeyFile)
+	return cfg
+}
+
+// handshake does tls handshake between client and server, returns the error of server side
+func handshake(serverCfg, clientCfg *tls.Config) error {
+	serverConn, clientConn := net.Pipe()
+	defer func() {
+		_ = serverConn.Close()
+		_ = clientConn.Close()
+	}()
+	go func() {
+		_ = tls.Client(clientConn, clientCfg).Handshake()
+		_ = clientConn.Close()
+	}()
+	return tls.Server(serverConn, serverCfg).Handshake()
+}
+
+func TestNewCertReloader(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	_, err := NewCertReloader(config.TLS{Enable: true})
+	assert.Error(t, err)
+	_, err = NewCertReloader(config.TLS{Enable: true, CertFile: "not_exist", KeyFile: "not_exist"})
+	assert.Error(t, err)
+
+	cfg := newTestConfig(t)
+	_, err = NewCertReloader(cfg)
+	assert.NoError(t, err)
+
+	// bad ca file
+	assert.NoError(t, ioutil.WriteFile(cfg.CAFile, []byte("bad ca"), 0600))
+	_, err = NewCertReloader(cfg)
+	assert.Error(t, err)
+	assert.NoError(t, os.Remove(cfg.CAFile))
+	_, err = NewCertReloader(cfg)
+	assert.Error(t, err)
+	// without ca
+	cfg.CAFile = ""
+	r, err := NewCertReloader(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, r.getCAPool())
+	assert.Equal(t, tls.NoClientCert, r.ServerConfig().ClientAuth)
+	assert.Equal(t, defaultReloadInterval, r.reloadInterval)
+}
+
+func TestCertReloader_MutualTLS(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	r, err := NewCertReloader(newTestConfig(t))
+	assert.NoError(t, err)
+	serverCfg := r.ServerConfig()
+	assert.NoError(t, handshake(serverCfg, r.ClientConfig()))
+
+	// client without certificate
+	clientCfg := r.ClientConfig()
+	clientCfg.GetClientCertificate = nil
+	assert.Error(t, handshake(serverCfg, clientCfg))
+
+	// client certificate signed by other ca
+	other := newTestCert(t, 3, newTestCert(t, 4, nil, true), false)
+	clientCfg = r.ClientConfig()
+	clientCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return &tls.Certificate{Certificate: [][]byte{other.cert.Raw}, PrivateKey: other.key}, nil
+	}
+	assert.Error(t, handshake(serverCfg, clientCfg))
+
+	assert.Equal(t, errNoClientCertificate, r.verifyClientCertificate(nil, nil))
+	assert.Error(t, r.verifyClientCertificate([][]byte{{1, 2, 3}}, nil))
+}
+
+func TestCertReloader_Reload(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	cfg := newTestConfig(t)
+	r, err := NewCertReloader(cfg)
+	assert.NoError(t, err)
+	r.reloadInterval = time.Millisecond
+	oldCert := r.getCertificate()
+
+	// not modified
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, oldCert, r.getCertificate())
+
+	// reload failure, keep old certificate
+	assert.NoError(t, ioutil.WriteFile(cfg.CertFile, []byte("bad cert"), 0600))
+	modTime := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(cfg.CertFile, modTime, modTime))
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, oldCert, r.getCertificate())
+
+	// reload new certificate signed by new ca
+	ca := newTestCert(t, 5, nil, true)
+	ca.write(t, cfg.CAFile, "")
+	newTestCert(t, 6, ca, false).write(t, cfg.CertFile, cfg.KeyFile)
+	modTime = modTime.Add(time.Minute)
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.CAFile} {
+		assert.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+	time.Sleep(2 * time.Millisecond)
+	newCert := r.getCertificate()
+	assert.NotEqual(t, oldCert, newCert)
+	leaf, err := x509.ParseCertificate(newCert.Certificate[0])
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), leaf.SerialNumber.Int64())
+	assert.NoError(t, handshake(r.ServerConfig(), r.ClientConfig()))
+}
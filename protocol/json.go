@@ -0,0 +1,120 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protocol
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/point"
+)
+
+var (
+	errMissingMetricName = errors.New("metric name is missing")
+	errMissingFields     = errors.New("fields is missing")
+	errMissingFieldName  = errors.New("field name is missing")
+)
+
+// jsonFieldTypes represents the mapping of json field type => pb field type
+var jsonFieldTypes = map[string]pb.FieldType{
+	"sum":   pb.FieldType_Sum,
+	"min":   pb.FieldType_Min,
+	"max":   pb.FieldType_Max,
+	"gauge": pb.FieldType_Gauge,
+}
+
+// jsonMetricList represents the json format of metric list, example:
+// {"metrics":[{"name":"cpu","timestamp":1577000000000,"tags":{"host":"1.1.1.1"},
+//
+//	"fields":[{"name":"usage","type":"sum","value":1.0}]}]}
+type jsonMetricList struct {
+	Metrics []*jsonMetric `json:"metrics"`
+}
+
+// jsonMetric represents the json format of metric
+type jsonMetric struct {
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Fields    []*jsonField      `json:"fields"`
+}
+
+// jsonField represents the json format of field, type is one of sum/min/max/gauge
+type jsonField struct {
+	Name  string  `json:"name"`
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+}
+
+// JSONParse parses json format to LinDB pb protocol.
+// It returns the valid metrics, and point.ParseErrors(line is the index of metric) if some metrics are invalid.
+func JSONParse(data []byte) (*pb.MetricList, error) {
+	jsonMetrics := &jsonMetricList{}
+	if err := encoding.JSONUnmarshal(data, jsonMetrics); err != nil {
+		return nil, err
+	}
+	var failed point.ParseErrors
+	metricList := &pb.MetricList{}
+	for idx, m := range jsonMetrics.Metrics {
+		metric, err := jsonToMetric(m)
+		if err != nil {
+			failed = append(failed, &point.ParseError{Line: idx + 1, Text: m.Name, Err: err})
+			continue
+		}
+		metricList.Metrics = append(metricList.Metrics, metric)
+	}
+	if len(failed) > 0 {
+		return metricList, failed
+	}
+	return metricList, nil
+}
+
+// jsonToMetric converts the json metric to pb metric
+func jsonToMetric(m *jsonMetric) (*pb.Metric, error) {
+	if m == nil || len(m.Name) == 0 {
+		return nil, errMissingMetricName
+	}
+	if len(m.Fields) == 0 {
+		return nil, errMissingFields
+	}
+	metric := &pb.Metric{
+		Namespace: m.Namespace,
+		Name:      m.Name,
+		Timestamp: m.Timestamp,
+		Tags:      m.Tags,
+		TagsHash:  tagsHash(m.Name, m.Tags),
+	}
+	if metric.Timestamp <= 0 {
+		metric.Timestamp = timeutil.Now()
+	}
+	for _, f := range m.Fields {
+		if f == nil || len(f.Name) == 0 {
+			return nil, errMissingFieldName
+		}
+		fieldType, ok := jsonFieldTypes[f.Type]
+		if !ok {
+			return nil, fmt.Errorf("not support field type: %s", f.Type)
+		}
+		metric.Fields = append(metric.Fields, &pb.Field{Name: f.Name, Type: fieldType, Value: f.Value})
+	}
+	return metric, nil
+}
@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/point"
+)
+
+func TestJSONParse(t *testing.T) {
+	input := `{"metrics":[
+{"name":"cpu","timestamp":1577000000000,"tags":{"host":"1.1.1.1"},
+ "fields":[{"name":"usage","type":"sum","value":1},{"name":"load","type":"gauge","value":2.5}]},
+{"namespace":"ns","name":"memory","fields":[{"name":"used","type":"max","value":10}]}
+]}`
+	metricList, err := JSONParse([]byte(input))
+	assert.NoError(t, err)
+	assert.Len(t, metricList.Metrics, 2)
+	cpu := metricList.Metrics[0]
+	assert.Equal(t, "cpu", cpu.Name)
+	assert.Equal(t, int64(1577000000000), cpu.Timestamp)
+	assert.Equal(t, tagsHash("cpu", cpu.Tags), cpu.TagsHash)
+	assert.Equal(t, []*pb.Field{
+		{Name: "usage", Type: pb.FieldType_Sum, Value: 1},
+		{Name: "load", Type: pb.FieldType_Gauge, Value: 2.5},
+	}, cpu.Fields)
+	memory := metricList.Metrics[1]
+	assert.Equal(t, "ns", memory.Namespace)
+	assert.True(t, memory.Timestamp > 0)
+}
+
+func TestJSONParse_Error(t *testing.T) {
+	_, err := JSONParse([]byte("abc"))
+	assert.Error(t, err)
+
+	input := `{"metrics":[
+{"name":"cpu","fields":[{"name":"usage","type":"sum","value":1}]},
+{"fields":[{"name":"usage","type":"sum","value":1}]},
+{"name":"cpu"},
+{"name":"cpu","fields":[{"type":"sum","value":1}]},
+{"name":"cpu","fields":[{"name":"usage","type":"histogram","value":1}]}
+]}`
+	metricList, err := JSONParse([]byte(input))
+	assert.Error(t, err)
+	assert.Len(t, metricList.Metrics, 1)
+	parseErrors, ok := err.(point.ParseErrors)
+	assert.True(t, ok)
+	assert.Len(t, parseErrors, 4)
+	assert.Equal(t, errMissingMetricName, parseErrors[0].Err)
+	assert.Equal(t, errMissingFields, parseErrors[1].Err)
+	assert.Equal(t, errMissingFieldName, parseErrors[2].Err)
+	assert.Equal(t, 5, parseErrors[3].Line)
+}
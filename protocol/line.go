@@ -0,0 +1,105 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protocol
+
+import (
+	"fmt"
+	"sort"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/point"
+)
+
+// LineParse parses InfluxDB-style line protocol with field type suffix(_SUM/_MIN/_MAX) to LinDB pb protocol.
+// It returns the metrics of valid lines, and point.ParseErrors if some lines are invalid.
+func LineParse(data []byte) (*pb.MetricList, error) {
+	var failed point.ParseErrors
+	points, err := point.ParsePoints(data)
+	if err != nil {
+		parseErrors, ok := err.(point.ParseErrors)
+		if !ok {
+			return nil, err
+		}
+		failed = append(failed, parseErrors...)
+	}
+	metricList := &pb.MetricList{}
+	for _, pt := range points {
+		metric, err := pointToMetric(pt)
+		if err != nil {
+			failed = append(failed, &point.ParseError{Line: pt.Line(), Text: pt.String(), Err: err})
+			continue
+		}
+		metricList.Metrics = append(metricList.Metrics, metric)
+	}
+	if len(failed) > 0 {
+		sort.Slice(failed, func(i, j int) bool {
+			return failed[i].Line < failed[j].Line
+		})
+		return metricList, failed
+	}
+	return metricList, nil
+}
+
+// pointToMetric converts the point of line protocol to pb metric
+func pointToMetric(pt *point.Point) (*pb.Metric, error) {
+	fields, err := pt.Fields()
+	if err != nil {
+		return nil, err
+	}
+	metric := &pb.Metric{
+		Name:      string(pt.Name()),
+		Timestamp: pt.UnixMilli(),
+	}
+	tags := pt.Tags()
+	if len(tags) > 0 {
+		metric.Tags = make(map[string]string, len(tags))
+		for _, t := range tags {
+			metric.Tags[string(t.Key)] = string(t.Value)
+		}
+	}
+	metric.TagsHash = tagsHash(metric.Name, metric.Tags)
+	for _, f := range fields {
+		fieldType, err := toPBFieldType(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := f.Value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("field: %s value is not float64", string(f.Name))
+		}
+		metric.Fields = append(metric.Fields, &pb.Field{Name: string(f.Name), Type: fieldType, Value: value})
+	}
+	return metric, nil
+}
+
+// toPBFieldType converts the field type to pb field type
+func toPBFieldType(fieldType field.Type) (pb.FieldType, error) {
+	switch fieldType {
+	case field.SumField:
+		return pb.FieldType_Sum, nil
+	case field.MinField:
+		return pb.FieldType_Min, nil
+	case field.MaxField:
+		return pb.FieldType_Max, nil
+	case field.GaugeField:
+		return pb.FieldType_Gauge, nil
+	default:
+		return pb.FieldType_UNKNOWN, fmt.Errorf("not support field type: %s", fieldType)
+	}
+}
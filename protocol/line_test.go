@@ -0,0 +1,70 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/point"
+)
+
+func TestLineParse(t *testing.T) {
+	input := "cpu,host=1.1.1.1,zone=sh usage_SUM=1,idle_MIN=2,load_MAX=3 1577000000000\n" +
+		"memory used_SUM=10.5 1577000000000\n"
+	metricList, err := LineParse([]byte(input))
+	assert.NoError(t, err)
+	assert.Len(t, metricList.Metrics, 2)
+	cpu := metricList.Metrics[0]
+	assert.Equal(t, "cpu", cpu.Name)
+	assert.Equal(t, int64(1577000000000), cpu.Timestamp)
+	assert.Equal(t, map[string]string{"host": "1.1.1.1", "zone": "sh"}, cpu.Tags)
+	assert.Equal(t, tagsHash("cpu", cpu.Tags), cpu.TagsHash)
+	assert.Len(t, cpu.Fields, 3)
+	fieldTypes := make(map[string]pb.FieldType)
+	for _, f := range cpu.Fields {
+		fieldTypes[f.Name] = f.Type
+	}
+	assert.Equal(t, map[string]pb.FieldType{
+		"usage": pb.FieldType_Sum,
+		"idle":  pb.FieldType_Min,
+		"load":  pb.FieldType_Max,
+	}, fieldTypes)
+	memory := metricList.Metrics[1]
+	assert.Empty(t, memory.Tags)
+	assert.Equal(t, tagsHash("memory", nil), memory.TagsHash)
+	assert.Equal(t, 10.5, memory.Fields[0].Value)
+}
+
+func TestLineParse_Error(t *testing.T) {
+	input := "cpu,host=1.1.1.1 usage_SUM=1 1577000000000\n" +
+		",host=1.1.1.1 usage_SUM=1\n" +
+		"cpu,host=1.1.1.1 usage_HGM=1\n" +
+		"cpu,host=1.1.1.1 usage_SUM=abc\n"
+	metricList, err := LineParse([]byte(input))
+	assert.Error(t, err)
+	assert.Len(t, metricList.Metrics, 1)
+	parseErrors, ok := err.(point.ParseErrors)
+	assert.True(t, ok)
+	assert.Len(t, parseErrors, 3)
+	assert.Equal(t, 2, parseErrors[0].Line)
+	assert.Equal(t, 3, parseErrors[1].Line)
+	assert.Equal(t, 4, parseErrors[2].Line)
+}
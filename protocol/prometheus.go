@@ -19,14 +19,14 @@ package protocol
 
 import (
 	"bytes"
+	"math"
 
-	"github.com/cespare/xxhash"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 
 	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/field"
-	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/series/field"
 )
 
 // PromParse parses prometheus text protocol to LinDB pb protocol.
@@ -44,13 +44,13 @@ func PromParse(data []byte) (*pb.MetricList, error) {
 			continue
 		}
 		for _, m := range pm.Metric {
-			f := getFieldType(metricType, m)
-			if f == nil {
+			fields := getFields(metricType, m)
+			if len(fields) == 0 {
 				continue
 			}
 
 			metric := &pb.Metric{Name: name}
-			metric.Fields = []*pb.Field{f}
+			metric.Fields = fields
 			if m.TimestampMs != nil {
 				metric.Timestamp = *m.TimestampMs
 			} else {
@@ -62,11 +62,9 @@ func PromParse(data []byte) (*pb.MetricList, error) {
 				for _, label := range m.Label {
 					tags[*label.Name] = *label.Value
 				}
-				metric.TagsHash = xxhash.Sum64String(tag.Concat(tags))
 				metric.Tags = tags
-			} else {
-				metric.TagsHash = xxhash.Sum64String(metric.Name)
 			}
+			metric.TagsHash = tagsHash(metric.Name, metric.Tags)
 
 			metricList.Metrics = append(metricList.Metrics, metric)
 		}
@@ -74,82 +72,88 @@ func PromParse(data []byte) (*pb.MetricList, error) {
 	return metricList, nil
 }
 
-func getFieldType(metricType dto.MetricType, metric *dto.Metric) *pb.Field {
+// getFields returns the fields of prometheus metric,
+// histogram/summary metric is stored as multiple fields which have the same base name.
+func getFields(metricType dto.MetricType, metric *dto.Metric) []*pb.Field {
 	switch metricType {
 	case dto.MetricType_COUNTER:
 		if metric.Counter != nil && metric.Counter.Value != nil {
-			return &pb.Field{
+			return []*pb.Field{{
 				Name: "counter",
 				//Type:   pb.FieldType_Increase,
 				Value: *metric.Counter.Value,
-			}
+			}}
 		}
 	case dto.MetricType_GAUGE:
 		if metric.Gauge != nil && metric.Gauge.Value != nil {
-			return &pb.Field{
+			return []*pb.Field{{
 				Name: "gauge",
 				//Type:   pb.FieldType_Gauge,
 				Value: *metric.Gauge.Value,
-			}
+			}}
 		}
 	case dto.MetricType_SUMMARY:
-		if metric.Summary == nil || metric.Summary.SampleCount == nil || metric.Summary.SampleSum == nil {
+		summary := metric.Summary
+		if summary == nil || summary.SampleCount == nil || summary.SampleSum == nil {
+			return nil
+		}
+		fields := countAndSumFields("summary", pb.FieldType_Summary, summary.GetSampleCount(), summary.GetSampleSum())
+		for _, q := range summary.Quantile {
+			if q.Quantile == nil || q.Value == nil || math.IsNaN(*q.Value) {
+				continue
+			}
+			fields = append(fields, &pb.Field{
+				Name:  string(field.QuantileFieldName("summary", *q.Quantile)),
+				Type:  pb.FieldType_Summary,
+				Value: *q.Value,
+			})
+		}
+		return fields
+	case dto.MetricType_HISTOGRAM:
+		histogram := metric.Histogram
+		if histogram == nil || histogram.SampleCount == nil || histogram.SampleSum == nil {
 			return nil
 		}
-		f := &pb.Field{
-			Name: "summary",
-			//Type: pb.FieldType_Summary,
+		fields := countAndSumFields("histogram", pb.FieldType_Histogram, histogram.GetSampleCount(), histogram.GetSampleSum())
+		hasInfBucket := false
+		for _, bucket := range histogram.Bucket {
+			if bucket.UpperBound == nil || bucket.CumulativeCount == nil {
+				continue
+			}
+			upperBound := *bucket.UpperBound
+			if math.IsInf(upperBound, 1) {
+				hasInfBucket = true
+			}
+			fields = append(fields, &pb.Field{
+				Name:  string(field.BucketFieldName("histogram", upperBound)),
+				Type:  pb.FieldType_Histogram,
+				Value: float64(*bucket.CumulativeCount),
+			})
 		}
-		//FIXME stone1100
-		//count := float64(*metric.Summary.SampleCount)
-		//f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//	PrimitiveID: int32(1),
-		//	Value:       *metric.Summary.SampleSum,
-		//}, &pb.PrimitiveField{
-		//	PrimitiveID: int32(2),
-		//	Value:       count,
-		//})
-		//quantile := metric.Summary.Quantile
-		//for _, q := range quantile {
-		//	switch *q.Quantile {
-		//	case 0.5:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(50),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.75:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(75),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.90:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(90),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.95:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(95),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.99:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(99),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.999:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(39),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.9999:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(49),
-		//			Value:       *q.Value * count,
-		//		})
-		//	}
-		//}
-		return f
+		if !hasInfBucket {
+			// +Inf bucket is required for calculating quantile, count of +Inf bucket = sample count
+			fields = append(fields, &pb.Field{
+				Name:  string(field.BucketFieldName("histogram", math.Inf(1))),
+				Type:  pb.FieldType_Histogram,
+				Value: float64(histogram.GetSampleCount()),
+			})
+		}
+		return fields
 	}
 	return nil
 }
+
+// countAndSumFields returns the count/sum fields of histogram/summary,
+// count/sum are cumulative like buckets, so they are stored as the last value with same field type,
+// the increase is calculated at query time.
+func countAndSumFields(name string, fieldType pb.FieldType, count uint64, sum float64) []*pb.Field {
+	return []*pb.Field{{
+		Name:  string(field.CountFieldName(name)),
+		Type:  fieldType,
+		Value: float64(count),
+	}, {
+		Name:  string(field.SumFieldName(name)),
+		Type:  fieldType,
+		Value: sum,
+	}}
+}
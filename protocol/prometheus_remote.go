@@ -0,0 +1,228 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/protocol/prompb"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+const (
+	// PromMetricNameLabel is the label name of metric name in prometheus
+	PromMetricNameLabel = "__name__"
+	// PromFieldName is the field name which stores the sample value of prometheus
+	PromFieldName = "value"
+)
+
+var errMissingMetricNameMatcher = errors.New("remote read requires an equality matcher of metric name(__name__)")
+
+// cumulativeSuffixes are the name suffixes of prometheus metrics which are monotonic increasing
+var cumulativeSuffixes = []string{"_total", "_count", "_sum", "_bucket"}
+
+// PromRemoteWriteParse parses the snappy-compressed protobuf WriteRequest of prometheus remote write to LinDB pb protocol.
+// Sample values of cumulative metrics(name with _total/_count/_sum/_bucket suffix) are stored as max field
+// (the max value is the latest one in an interval unless counter reset), others are stored as gauge field.
+// The labels with empty value are dropped, because empty label value is same as label not present in prometheus.
+func PromRemoteWriteParse(data []byte) (*pb.MetricList, error) {
+	req := &prompb.WriteRequest{}
+	if err := decodeSnappyProto(data, req); err != nil {
+		return nil, err
+	}
+	metricList := &pb.MetricList{}
+	for _, ts := range req.Timeseries {
+		metricName := ""
+		var tags map[string]string
+		for _, label := range ts.Labels {
+			if label.Name == PromMetricNameLabel {
+				metricName = label.Value
+				continue
+			}
+			if label.Value == "" {
+				continue
+			}
+			if tags == nil {
+				tags = make(map[string]string, len(ts.Labels))
+			}
+			tags[label.Name] = label.Value
+		}
+		if metricName == "" {
+			// ignore the time series without metric name
+			continue
+		}
+		fieldType := promFieldType(metricName)
+		hash := tagsHash(metricName, tags)
+		for _, sample := range ts.Samples {
+			metricList.Metrics = append(metricList.Metrics, &pb.Metric{
+				Name:      metricName,
+				Timestamp: sample.Timestamp,
+				Tags:      tags,
+				TagsHash:  hash,
+				Fields: []*pb.Field{{
+					Name:  PromFieldName,
+					Type:  fieldType,
+					Value: sample.Value,
+				}},
+			})
+		}
+	}
+	return metricList, nil
+}
+
+// PromRemoteReadParse parses the snappy-compressed protobuf ReadRequest of prometheus remote read
+func PromRemoteReadParse(data []byte) (*prompb.ReadRequest, error) {
+	req := &prompb.ReadRequest{}
+	if err := decodeSnappyProto(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// PromRemoteReadEncode encodes the ReadResponse of prometheus remote read with protobuf then compresses by snappy
+func PromRemoteReadEncode(resp *prompb.ReadResponse) ([]byte, error) {
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+// PromQueryToStmt translates the query of prometheus remote read to LinDB query statement,
+// the label matchers(except metric name) are translated to tag filter condition.
+// NOTE: the caller needs to set the group by tag keys for returning raw time series.
+func PromQueryToStmt(query *prompb.Query) (*stmt.Query, error) {
+	q := &stmt.Query{
+		SelectItems: []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: PromFieldName}}},
+		FieldNames:  []string{PromFieldName},
+		TimeRange: timeutil.TimeRange{
+			Start: query.StartTimestampMs,
+			End:   query.EndTimestampMs,
+		},
+	}
+	if query.Hints != nil && query.Hints.StepMs > 0 {
+		q.Interval = timeutil.Interval(query.Hints.StepMs)
+	}
+	for _, matcher := range query.Matchers {
+		if matcher.Name == PromMetricNameLabel {
+			if matcher.Type != prompb.MatchEqual {
+				return nil, errMissingMetricNameMatcher
+			}
+			q.MetricName = matcher.Value
+			continue
+		}
+		var condition stmt.Expr
+		switch matcher.Type {
+		case prompb.MatchEqual:
+			if matcher.Value == "" {
+				// empty label value means label not exist
+				condition = &stmt.AbsentExpr{Key: matcher.Name}
+			} else {
+				condition = &stmt.EqualsExpr{Key: matcher.Name, Value: matcher.Value}
+			}
+		case prompb.MatchNotEqual:
+			if matcher.Value == "" {
+				// label exists with any value
+				condition = &stmt.RegexExpr{Key: matcher.Name, Regexp: anchorRegexp(".+")}
+			} else {
+				condition = &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: matcher.Name, Value: matcher.Value}}
+			}
+		case prompb.MatchRegexp:
+			condition = &stmt.RegexExpr{Key: matcher.Name, Regexp: anchorRegexp(matcher.Value)}
+		case prompb.MatchNotRegexp:
+			condition = &stmt.NotExpr{Expr: &stmt.RegexExpr{Key: matcher.Name, Regexp: anchorRegexp(matcher.Value)}}
+		default:
+			return nil, fmt.Errorf("unknown label matcher type: %d", matcher.Type)
+		}
+		if q.Condition == nil {
+			q.Condition = condition
+		} else {
+			q.Condition = &stmt.BinaryExpr{Left: q.Condition, Operator: stmt.AND, Right: condition}
+		}
+	}
+	if q.MetricName == "" {
+		return nil, errMissingMetricNameMatcher
+	}
+	return q, nil
+}
+
+// PromQueryResult builds the query result of prometheus remote read based on LinDB result set
+func PromQueryResult(rs *models.ResultSet) *prompb.QueryResult {
+	result := &prompb.QueryResult{}
+	if rs == nil {
+		return result
+	}
+	for _, s := range rs.Series {
+		points := s.Fields[PromFieldName]
+		if len(points) == 0 {
+			continue
+		}
+		ts := &prompb.TimeSeries{
+			Labels: []*prompb.Label{{Name: PromMetricNameLabel, Value: rs.MetricName}},
+		}
+		for name, value := range s.Tags {
+			ts.Labels = append(ts.Labels, &prompb.Label{Name: name, Value: value})
+		}
+		// prometheus requires labels/samples are sorted
+		sort.Slice(ts.Labels, func(i, j int) bool {
+			return ts.Labels[i].Name < ts.Labels[j].Name
+		})
+		for timestamp, value := range points {
+			ts.Samples = append(ts.Samples, &prompb.Sample{Timestamp: timestamp, Value: value})
+		}
+		sort.Slice(ts.Samples, func(i, j int) bool {
+			return ts.Samples[i].Timestamp < ts.Samples[j].Timestamp
+		})
+		result.Timeseries = append(result.Timeseries, ts)
+	}
+	return result
+}
+
+// promFieldType returns the field type based on metric name, the field type of a metric must be deterministic,
+// so the metadata of write request isn't used, because prometheus sends metadata in separate requests.
+func promFieldType(metricName string) pb.FieldType {
+	for _, suffix := range cumulativeSuffixes {
+		if strings.HasSuffix(metricName, suffix) {
+			return pb.FieldType_Max
+		}
+	}
+	return pb.FieldType_Gauge
+}
+
+// anchorRegexp anchors the regexp, because prometheus regexp matchers are fully anchored
+func anchorRegexp(regexp string) string {
+	return "^(?:" + regexp + ")$"
+}
+
+// decodeSnappyProto decompresses the data by snappy then decodes it to protobuf message
+func decodeSnappyProto(data []byte, msg proto.Message) error {
+	decoded, err := snappy.Decode(nil, data)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(decoded, msg)
+}
@@ -0,0 +1,171 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/protocol/prompb"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func encodeSnappyProto(t *testing.T, msg proto.Message) []byte {
+	data, err := proto.Marshal(msg)
+	assert.NoError(t, err)
+	return snappy.Encode(nil, data)
+}
+
+func TestPromRemoteWriteParse(t *testing.T) {
+	data := encodeSnappyProto(t, &prompb.WriteRequest{
+		Timeseries: []*prompb.TimeSeries{
+			{
+				Labels: []*prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "host", Value: "1.1.1.1"},
+					{Name: "zone", Value: ""},
+				},
+				Samples: []*prompb.Sample{{Value: 10, Timestamp: 1000}, {Value: 11, Timestamp: 2000}},
+			},
+			{
+				Labels:  []*prompb.Label{{Name: "__name__", Value: "memory"}},
+				Samples: []*prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels:  []*prompb.Label{{Name: "__name__", Value: "up"}, {Name: "job", Value: ""}},
+				Samples: []*prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				// no metric name
+				Labels:  []*prompb.Label{{Name: "host", Value: "1.1.1.1"}},
+				Samples: []*prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+		Metadata: []*prompb.MetricMetadata{{Type: prompb.MetricTypeCounter, MetricFamilyName: "up"}},
+	})
+	metricList, err := PromRemoteWriteParse(data)
+	assert.NoError(t, err)
+	assert.Len(t, metricList.Metrics, 4)
+	m := metricList.Metrics[0]
+	assert.Equal(t, "http_requests_total", m.Name)
+	assert.Equal(t, int64(1000), m.Timestamp)
+	// label with empty value is dropped
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, m.Tags)
+	assert.Equal(t, tagsHash(m.Name, m.Tags), m.TagsHash)
+	assert.Equal(t, []*pb.Field{{Name: PromFieldName, Type: pb.FieldType_Max, Value: 10}}, m.Fields)
+	assert.Equal(t, float64(11), metricList.Metrics[1].Fields[0].Value)
+	assert.Equal(t, pb.FieldType_Gauge, metricList.Metrics[2].Fields[0].Type)
+	assert.Nil(t, metricList.Metrics[2].Tags)
+	// field type doesn't depend on metadata of request
+	assert.Equal(t, pb.FieldType_Gauge, metricList.Metrics[3].Fields[0].Type)
+	assert.Nil(t, metricList.Metrics[3].Tags)
+
+	_, err = PromRemoteWriteParse([]byte("abc"))
+	assert.Error(t, err)
+	_, err = PromRemoteWriteParse(snappy.Encode(nil, []byte("abc")))
+	assert.Error(t, err)
+}
+
+func TestPromFieldType(t *testing.T) {
+	assert.Equal(t, pb.FieldType_Gauge, promFieldType("rpc"))
+	assert.Equal(t, pb.FieldType_Max, promFieldType("rpc_sum"))
+	assert.Equal(t, pb.FieldType_Max, promFieldType("latency_bucket"))
+	assert.Equal(t, pb.FieldType_Max, promFieldType("requests_count"))
+	assert.Equal(t, pb.FieldType_Max, promFieldType("http_requests_total"))
+	assert.Equal(t, pb.FieldType_Gauge, promFieldType("requests"))
+}
+
+func TestPromRemoteReadParse(t *testing.T) {
+	req := &prompb.ReadRequest{Queries: []*prompb.Query{{StartTimestampMs: 10, EndTimestampMs: 20}}}
+	req2, err := PromRemoteReadParse(encodeSnappyProto(t, req))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), req2.Queries[0].StartTimestampMs)
+	assert.Equal(t, int64(20), req2.Queries[0].EndTimestampMs)
+
+	_, err = PromRemoteReadParse([]byte("abc"))
+	assert.Error(t, err)
+}
+
+func TestPromRemoteReadEncode(t *testing.T) {
+	resp := &prompb.ReadResponse{Results: []*prompb.QueryResult{{}}}
+	data, err := PromRemoteReadEncode(resp)
+	assert.NoError(t, err)
+	decoded := &prompb.ReadResponse{}
+	assert.NoError(t, decodeSnappyProto(data, decoded))
+	assert.Len(t, decoded.Results, 1)
+}
+
+func TestPromQueryToStmt(t *testing.T) {
+	q, err := PromQueryToStmt(&prompb.Query{
+		StartTimestampMs: 10,
+		EndTimestampMs:   20,
+		Matchers: []*prompb.LabelMatcher{
+			{Type: prompb.MatchEqual, Name: "__name__", Value: "cpu"},
+			{Type: prompb.MatchEqual, Name: "host", Value: "a"},
+			{Type: prompb.MatchEqual, Name: "ip", Value: ""},
+			{Type: prompb.MatchNotEqual, Name: "zone", Value: "sh"},
+			{Type: prompb.MatchRegexp, Name: "app", Value: "web.*"},
+			{Type: prompb.MatchNotRegexp, Name: "env", Value: "dev|test"},
+			{Type: prompb.MatchNotEqual, Name: "idc", Value: ""},
+		},
+		Hints: &prompb.ReadHints{StepMs: 10000},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu", q.MetricName)
+	assert.Equal(t, int64(10), q.TimeRange.Start)
+	assert.Equal(t, int64(20), q.TimeRange.End)
+	assert.Equal(t, int64(10000), q.Interval.Int64())
+	assert.Equal(t, []string{PromFieldName}, q.FieldNames)
+	assert.Equal(t, "host=aandip absentandnot zone=shandapp=~^(?:web.*)$andnot env=~^(?:dev|test)$"+
+		"andidc=~^(?:.+)$", q.Condition.Rewrite())
+
+	_, err = PromQueryToStmt(&prompb.Query{
+		Matchers: []*prompb.LabelMatcher{{Type: prompb.MatchRegexp, Name: "__name__", Value: "cpu.*"}},
+	})
+	assert.Error(t, err)
+	_, err = PromQueryToStmt(&prompb.Query{
+		Matchers: []*prompb.LabelMatcher{{Type: prompb.MatchEqual, Name: "host", Value: "a"}},
+	})
+	assert.Error(t, err)
+	_, err = PromQueryToStmt(&prompb.Query{
+		Matchers: []*prompb.LabelMatcher{{Type: 10, Name: "host", Value: "a"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestPromQueryResult(t *testing.T) {
+	assert.NotNil(t, PromQueryResult(nil))
+	rs := models.NewResultSet()
+	rs.MetricName = "cpu"
+	s := models.NewSeries(map[string]string{"zone": "sh", "host": "a"})
+	s.Fields[PromFieldName] = map[int64]float64{20: 2, 10: 1}
+	rs.AddSeries(s)
+	rs.AddSeries(models.NewSeries(nil))
+	result := PromQueryResult(rs)
+	assert.Len(t, result.Timeseries, 1)
+	assert.Equal(t, []*prompb.Label{
+		{Name: "__name__", Value: "cpu"},
+		{Name: "host", Value: "a"},
+		{Name: "zone", Value: "sh"},
+	}, result.Timeseries[0].Labels)
+	assert.Equal(t, []*prompb.Sample{{Value: 1, Timestamp: 10}, {Value: 2, Timestamp: 20}}, result.Timeseries[0].Samples)
+}
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
 )
 
 func TestPromParse(t *testing.T) {
@@ -98,3 +100,41 @@ go_gc_duration_seconds_sum 90
 	assert.NoError(t, err)
 	assert.NotEmpty(t, metrics)
 }
+
+func TestPromParse_Histogram(t *testing.T) {
+	input := `# TYPE rpc_duration_seconds histogram
+rpc_duration_seconds_bucket{le="0.5"} 10
+rpc_duration_seconds_bucket{le="1"} 20
+rpc_duration_seconds_bucket{le="+Inf"} 30
+rpc_duration_seconds_sum 25.5
+rpc_duration_seconds_count 30
+`
+	metrics, err := PromParse([]byte(input))
+	assert.NoError(t, err)
+	assert.Len(t, metrics.Metrics, 1)
+	fields := metrics.Metrics[0].Fields
+	assert.Len(t, fields, 5)
+	assert.Equal(t, &pb.Field{Name: "histogram__count", Type: pb.FieldType_Histogram, Value: 30}, fields[0])
+	assert.Equal(t, &pb.Field{Name: "histogram__sum", Type: pb.FieldType_Histogram, Value: 25.5}, fields[1])
+	assert.Equal(t, &pb.Field{Name: "histogram__bucket_0.5", Type: pb.FieldType_Histogram, Value: 10}, fields[2])
+	assert.Equal(t, &pb.Field{Name: "histogram__bucket_1", Type: pb.FieldType_Histogram, Value: 20}, fields[3])
+	assert.Equal(t, &pb.Field{Name: "histogram__bucket_+Inf", Type: pb.FieldType_Histogram, Value: 30}, fields[4])
+}
+
+func TestPromParse_Summary(t *testing.T) {
+	input := `# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 0.2
+rpc_duration_seconds{quantile="0.99"} 1.5
+rpc_duration_seconds_sum 25.5
+rpc_duration_seconds_count 30
+`
+	metrics, err := PromParse([]byte(input))
+	assert.NoError(t, err)
+	assert.Len(t, metrics.Metrics, 1)
+	fields := metrics.Metrics[0].Fields
+	assert.Len(t, fields, 4)
+	assert.Equal(t, &pb.Field{Name: "summary__count", Type: pb.FieldType_Summary, Value: 30}, fields[0])
+	assert.Equal(t, &pb.Field{Name: "summary__sum", Type: pb.FieldType_Summary, Value: 25.5}, fields[1])
+	assert.Equal(t, &pb.Field{Name: "summary__quantile_0.5", Type: pb.FieldType_Summary, Value: 0.2}, fields[2])
+	assert.Equal(t, &pb.Field{Name: "summary__quantile_0.99", Type: pb.FieldType_Summary, Value: 1.5}, fields[3])
+}
@@ -19,7 +19,9 @@ package query
 
 import (
 	"context"
+	"time"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
@@ -43,21 +45,31 @@ type brokerExecutor struct {
 	ctx context.Context
 
 	executeCtx parallel.BrokerExecuteContext
+
+	maxPoints int // maximum number of points returned by query, 0 means no limit
+
+	retryTimeout time.Duration // retries the shards of slow leaf task on other replica, 0 means no retry timeout
+	hedgeDelay   time.Duration // sends hedged request of slow leaf task to other replica, 0 means disable
 }
 
-// newBrokerExecutor creates the execution which executes the job of parallel query
-func newBrokerExecutor(ctx context.Context, database string, sql string,
+// newBrokerExecutor creates the execution which executes the job of parallel query,
+// executes the parsed query statement if not nil, else parses the sql.
+func newBrokerExecutor(ctx context.Context, database string, sql string, query *stmt.Query,
 	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
 	databaseStateMachine database.DBStateMachine,
-	jobManager parallel.JobManager) parallel.BrokerExecutor {
+	jobManager parallel.JobManager, cfg config.Query) parallel.BrokerExecutor {
 	exec := &brokerExecutor{
 		sql:                  sql,
+		query:                query,
 		database:             database,
 		replicaStateMachine:  replicaStateMachine,
 		nodeStateMachine:     nodeStateMachine,
 		databaseStateMachine: databaseStateMachine,
 		jobManager:           jobManager,
 		ctx:                  ctx,
+		maxPoints:            cfg.MaxPoints,
+		retryTimeout:         cfg.RetryTimeout.Duration(),
+		hedgeDelay:           cfg.HedgeDelay.Duration(),
 	}
 	return exec
 }
@@ -71,7 +83,7 @@ func (e *brokerExecutor) Execute() {
 
 	databaseCfg, ok := e.databaseStateMachine.GetDatabaseCfg(e.database)
 	if !ok {
-		e.executeCtx = parallel.NewBrokerExecuteContext(startTime, nil)
+		e.executeCtx = parallel.NewBrokerExecuteContext(startTime, nil, e.maxPoints)
 		e.executeCtx.Complete(errDatabaseNotExist)
 		return
 	}
@@ -79,7 +91,8 @@ func (e *brokerExecutor) Execute() {
 	//FIXME need using storage's replica state ???
 	storageNodes := e.replicaStateMachine.GetQueryableReplicas(e.database)
 	brokerNodes := e.nodeStateMachine.GetActiveNodes()
-	plan := newBrokerPlan(e.sql, databaseCfg, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
+	// use the parsed query statement if exist
+	plan := newBrokerPlan(e.sql, e.query, databaseCfg, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
 
 	var err error
 	if len(storageNodes) == 0 {
@@ -90,7 +103,7 @@ func (e *brokerExecutor) Execute() {
 
 	// maybe plan doesn't execute(query statement is nil), because storage nodes is empty
 	brokerPlan := plan.(*brokerPlan)
-	e.executeCtx = parallel.NewBrokerExecuteContext(startTime, brokerPlan.query)
+	e.executeCtx = parallel.NewBrokerExecuteContext(startTime, brokerPlan.query, e.maxPoints)
 
 	if err != nil {
 		e.executeCtx.Complete(err)
@@ -100,9 +113,13 @@ func (e *brokerExecutor) Execute() {
 	brokerPlan.physicalPlan.Database = e.database
 	e.query = brokerPlan.query
 
-	if err := e.jobManager.SubmitJob(parallel.NewJobContext(e.ctx,
-		e.executeCtx.ResultCh(), brokerPlan.physicalPlan, e.query),
-	); err != nil {
+	jobCtx := parallel.NewJobContext(e.ctx, e.executeCtx.ResultCh(), brokerPlan.physicalPlan, e.query, e.sql)
+	jobCtx.SetFailover(&parallel.FailoverOption{
+		Replicas:     e.replicaStateMachine.GetShardReplicas(e.database),
+		RetryTimeout: e.retryTimeout,
+		HedgeDelay:   e.hedgeDelay,
+	})
+	if err := e.jobManager.SubmitJob(jobCtx); err != nil {
 		e.executeCtx.Complete(err)
 		return
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
@@ -46,8 +47,8 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	jobManager := parallel.NewMockJobManager(ctrl)
 
 	// case 1: database not found
-	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, config.Query{})
 	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").Return(models.Database{}, false)
 	exec.Execute()
 	assert.NotNil(t, exec.ExecuteContext())
@@ -55,8 +56,8 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	// case 2: storage nodes not exist
 	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").
 		Return(models.Database{Option: option.DatabaseOption{Interval: "10s"}}, true).AnyTimes()
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, config.Query{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(nil)
 	exec.Execute()
 	assert.NotNil(t, exec.ExecuteContext())
@@ -74,23 +75,29 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 		currentNode,
 		generateBrokerActiveNode("1.1.1.4", 8000),
 	}
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f fro",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f fro", nil,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, config.Query{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	exec.Execute()
 
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, config.Query{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	replicaStateMachine.EXPECT().GetShardReplicas("test_db").
+		Return(map[int32][]string{1: {"1.1.1.1:9000", "1.1.1.2:9000"}})
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
-	jobManager.EXPECT().SubmitJob(gomock.Any())
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		assert.Equal(t, []string{"1.1.1.1:9000", "1.1.1.2:9000"}, ctx.Failover().Replicas[1])
+		return nil
+	})
 	exec.Execute()
 
 	// submit job error
-	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu", nil,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager, config.Query{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	replicaStateMachine.EXPECT().GetShardReplicas("test_db").Return(nil)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
 	exec.Execute()
@@ -38,10 +38,11 @@ type brokerPlan struct {
 }
 
 // newBrokerPlan creates broker execute plan
-func newBrokerPlan(sql string, databaseCfg models.Database, storageNodes map[string][]int32,
+func newBrokerPlan(sql string, query *stmt.Query, databaseCfg models.Database, storageNodes map[string][]int32,
 	currentBrokerNode models.Node, brokerNodes []models.ActiveNode) Plan {
 	return &brokerPlan{
 		sql:               sql,
+		query:             query,
 		databaseCfg:       databaseCfg,
 		storageNodes:      storageNodes,
 		currentBrokerNode: currentBrokerNode,
@@ -50,7 +51,7 @@ func newBrokerPlan(sql string, databaseCfg models.Database, storageNodes map[str
 }
 
 // Plan plans broker level query execute plan, there are some scenarios as below:
-// 1) parse sql => stmt
+// 1) parse sql => stmt(if query statement is not parsed)
 // 2) build parallel exec tree
 //    a) no group by => only need leafs
 //    b) one storage node => only need leafs
@@ -62,12 +63,14 @@ func (p *brokerPlan) Plan() error {
 		return errNoAvailableStorageNode
 	}
 
-	query, err := sql.Parse(p.sql)
-	if err != nil {
-		return err
+	if p.query == nil {
+		query, err := sql.Parse(p.sql)
+		if err != nil {
+			return err
+		}
+		// set query statement
+		p.query = query.(*stmt.Query)
 	}
-	// set query statement
-	p.query = query.(*stmt.Query)
 
 	if p.query.Interval <= 0 {
 		var interval timeutil.Interval
@@ -24,17 +24,18 @@ import (
 
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestBrokerPlan_Wrong_Case(t *testing.T) {
-	plan := newBrokerPlan("sql", models.Database{}, nil, models.Node{}, nil)
+	plan := newBrokerPlan("sql", nil, models.Database{}, nil, models.Node{}, nil)
 	// storage nodes cannot be empty
 	err := plan.Plan()
 	assert.Equal(t, errNoAvailableStorageNode, err)
 
 	storageNodes := map[string][]int32{"1.1.1.1:8000": {1, 2, 4}}
 	// wrong sql
-	plan = newBrokerPlan("sql", models.Database{}, storageNodes, models.Node{}, nil)
+	plan = newBrokerPlan("sql", nil, models.Database{}, storageNodes, models.Node{}, nil)
 	err = plan.Plan()
 	assert.NotNil(t, err)
 }
@@ -43,7 +44,7 @@ func TestBrokerPlan_wrong_database_interval(t *testing.T) {
 	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	// no group sql
-	plan := newBrokerPlan("select f from cpu",
+	plan := newBrokerPlan("select f from cpu", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "s"}},
 		storageNodes, currentNode.Node, nil)
 	err := plan.Plan()
@@ -54,7 +55,7 @@ func TestBrokerPlan_No_GroupBy(t *testing.T) {
 	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}, "1.1.1.2:9000": {3, 5, 6}}
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	// no group sql
-	plan := newBrokerPlan("select f from cpu",
+	plan := newBrokerPlan("select f from cpu", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes, currentNode.Node, nil)
 	err := plan.Plan()
@@ -84,6 +85,20 @@ func TestBrokerPlan_No_GroupBy(t *testing.T) {
 	assert.Equal(t, 0, len(p.physicalPlan.Intermediates))
 }
 
+func TestBrokerPlan_Parsed_Query(t *testing.T) {
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	plan := newBrokerPlan("", &stmt.Query{MetricName: "cpu", FieldNames: []string{"f"}},
+		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+		storageNodes, currentNode.Node, nil)
+	p := plan.(*brokerPlan)
+	err := plan.Plan()
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu", p.query.MetricName)
+	assert.Equal(t, int64(10000), p.query.Interval.Int64())
+	assert.Equal(t, 1, len(p.physicalPlan.Leafs))
+}
+
 func TestBrokerPlan_GroupBy(t *testing.T) {
 	storageNodes := map[string][]int32{
 		"1.1.1.1:9000": {1, 2, 4},
@@ -94,7 +109,7 @@ func TestBrokerPlan_GroupBy(t *testing.T) {
 	}
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	plan := newBrokerPlan(
-		"select f from cpu group by host",
+		"select f from cpu group by host", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -133,7 +148,7 @@ func TestBrokerPlan_GroupBy_Less_StorageNodes(t *testing.T) {
 	}
 	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
 	plan := newBrokerPlan(
-		"select f from cpu group by host",
+		"select f from cpu group by host", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -171,7 +186,7 @@ func TestBrokerPlan_GroupBy_Same_Broker(t *testing.T) {
 
 	// current node = active node
 	plan := newBrokerPlan(
-		"select f from cpu group by host",
+		"select f from cpu group by host", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -200,7 +215,7 @@ func TestBrokerPlan_GroupBy_No_Broker(t *testing.T) {
 
 	// only one storage node
 	plan := newBrokerPlan(
-		"select f from cpu group by host",
+		"select f from cpu group by host", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
@@ -229,7 +244,7 @@ func TestBrokerPlan_GroupBy_One_StorageNode(t *testing.T) {
 
 	// only one storage node
 	plan := newBrokerPlan(
-		"select f from cpu group by host",
+		"select f from cpu group by host", nil,
 		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
 		storageNodes,
 		currentNode.Node,
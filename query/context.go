@@ -18,8 +18,15 @@
 package query
 
 import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
 )
 
 // storageExecuteContext represents storage query execute context
@@ -30,6 +37,12 @@ type storageExecuteContext struct {
 	tagFilterResult map[string]*tagFilterResult
 
 	stats *models.StorageStats // storage query stats track for explain query
+
+	maxSeries     int          // maximum number of series scanned by query, 0 means no limit
+	scannedSeries atomic.Int64 // number of series scanned by query of all shards
+
+	families []tsdb.DataFamily // data families retained by query, cannot be evicted before query completed
+	mutex    sync.Mutex
 }
 
 // newStorageExecuteContext creates storage execute context
@@ -53,6 +66,39 @@ func (ctx *storageExecuteContext) QueryStats() *models.StorageStats {
 	return ctx.stats
 }
 
+// retainDataFamily retains the data family until query completed, returns false if the data family is evicted
+func (ctx *storageExecuteContext) retainDataFamily(family tsdb.DataFamily) bool {
+	if !family.Retain() {
+		return false
+	}
+	ctx.mutex.Lock()
+	ctx.families = append(ctx.families, family)
+	ctx.mutex.Unlock()
+	return true
+}
+
+// Release releases the data families retained by query
+func (ctx *storageExecuteContext) Release() {
+	ctx.mutex.Lock()
+	families := ctx.families
+	ctx.families = nil
+	ctx.mutex.Unlock()
+
+	for _, family := range families {
+		family.Release()
+	}
+}
+
+// scanSeries accumulates the number of series scanned by shard,
+// returns ErrQueryTooManySeries if total number exceeds the limit.
+func (ctx *storageExecuteContext) scanSeries(count uint64) error {
+	scanned := ctx.scannedSeries.Add(int64(count))
+	if ctx.maxSeries > 0 && scanned > int64(ctx.maxSeries) {
+		return fmt.Errorf("%s, limit: %d", constants.ErrQueryTooManySeries, ctx.maxSeries)
+	}
+	return nil
+}
+
 // setTagFilterResult sets tag filter result
 func (ctx *storageExecuteContext) setTagFilterResult(tagFilterResult map[string]*tagFilterResult) {
 	ctx.tagFilterResult = tagFilterResult
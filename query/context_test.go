@@ -20,9 +20,11 @@ package query
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
 )
 
 func TestStorageExecuteContext(t *testing.T) {
@@ -30,3 +32,28 @@ func TestStorageExecuteContext(t *testing.T) {
 	ctx.setTagFilterResult(nil)
 	assert.NotNil(t, ctx.QueryStats())
 }
+
+func TestStorageExecuteContext_scanSeries(t *testing.T) {
+	ctx := newStorageExecuteContext(nil, &stmt.Query{})
+	assert.NoError(t, ctx.scanSeries(100))
+	ctx.maxSeries = 150
+	assert.NoError(t, ctx.scanSeries(50))
+	assert.Error(t, ctx.scanSeries(1))
+}
+
+func TestStorageExecuteContext_retainDataFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := newStorageExecuteContext(nil, &stmt.Query{})
+	family := tsdb.NewMockDataFamily(ctrl)
+	family.EXPECT().Retain().Return(false)
+	assert.False(t, ctx.retainDataFamily(family))
+	family.EXPECT().Retain().Return(true).Times(2)
+	assert.True(t, ctx.retainDataFamily(family))
+	assert.True(t, ctx.retainDataFamily(family))
+	// release all retained data families once
+	family.EXPECT().Release().Times(2)
+	ctx.Release()
+	ctx.Release()
+}
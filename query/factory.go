@@ -20,6 +20,7 @@ package query
 import (
 	"context"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
@@ -30,11 +31,13 @@ import (
 )
 
 // executorFactory implements parallel.ExecutorFactory
-type executorFactory struct{}
+type executorFactory struct {
+	cfg config.Query // resource limits of query
+}
 
 // NewExecutorFactory creates executor factory
-func NewExecutorFactory() parallel.ExecutorFactory {
-	return &executorFactory{}
+func NewExecutorFactory(cfg config.Query) parallel.ExecutorFactory {
+	return &executorFactory{cfg: cfg}
 }
 
 // NewStorageExecutor creates storage executor
@@ -56,7 +59,7 @@ func (*executorFactory) NewMetadataStorageExecutor(
 }
 
 // NewStorageExecutor creates broker executor
-func (*executorFactory) NewBrokerExecutor(
+func (f *executorFactory) NewBrokerExecutor(
 	ctx context.Context,
 	databaseName string,
 	sql string,
@@ -65,9 +68,24 @@ func (*executorFactory) NewBrokerExecutor(
 	databaseStateMachine database.DBStateMachine,
 	jobManager parallel.JobManager,
 ) parallel.BrokerExecutor {
-	return newBrokerExecutor(ctx, databaseName, sql,
+	return newBrokerExecutor(ctx, databaseName, sql, nil,
+		replicaStateMachine, nodeStateMachine, databaseStateMachine,
+		jobManager, f.cfg)
+}
+
+// NewBrokerQueryExecutor creates broker executor based on parsed query statement
+func (f *executorFactory) NewBrokerQueryExecutor(
+	ctx context.Context,
+	databaseName string,
+	query *stmt.Query,
+	replicaStateMachine replica.StatusStateMachine,
+	nodeStateMachine broker.NodeStateMachine,
+	databaseStateMachine database.DBStateMachine,
+	jobManager parallel.JobManager,
+) parallel.BrokerExecutor {
+	return newBrokerExecutor(ctx, databaseName, "", query,
 		replicaStateMachine, nodeStateMachine, databaseStateMachine,
-		jobManager)
+		jobManager, f.cfg)
 }
 
 // NewMetadataBrokerExecutor creates the metadata executor in broker side
@@ -83,6 +101,8 @@ func (*executorFactory) NewMetadataBrokerExecutor(
 }
 
 // NewStorageExecuteContext creates the storage execute context in storage side
-func (*executorFactory) NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) parallel.StorageExecuteContext {
-	return newStorageExecuteContext(shardIDs, query)
+func (f *executorFactory) NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) parallel.StorageExecuteContext {
+	ctx := newStorageExecuteContext(shardIDs, query)
+	ctx.maxSeries = f.cfg.MaxSeries
+	return ctx
 }
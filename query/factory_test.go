@@ -24,6 +24,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -32,17 +33,19 @@ func TestExecutorFactory_NewExecutor(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	factory := NewExecutorFactory()
+	factory := NewExecutorFactory(*config.NewDefaultQuery())
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 	assert.NotNil(t, factory.NewStorageExecutor(nil, mockDatabase, newStorageExecuteContext(nil, &stmt.Query{})))
 	assert.NotNil(t, factory.NewBrokerExecutor(
 		context.TODO(), "db", "sql", nil, nil, nil, nil))
+	assert.NotNil(t, factory.NewBrokerQueryExecutor(
+		context.TODO(), "db", &stmt.Query{}, nil, nil, nil, nil))
 	assert.NotNil(t, factory.NewMetadataStorageExecutor(nil, nil, nil))
 	assert.NotNil(t, factory.NewMetadataBrokerExecutor(
 		context.TODO(), "db", nil, nil, nil, nil))
 }
 
 func TestNewExecutorFactory_NewContext(t *testing.T) {
-	factory := NewExecutorFactory()
+	factory := NewExecutorFactory(*config.NewDefaultQuery())
 	assert.NotNil(t, factory.NewStorageExecuteContext(nil, &stmt.Query{}))
 }
@@ -34,7 +34,7 @@ func newMockDatabase(ctrl *gomock.Controller) *tsdb.MockDatabase {
 	shard := tsdb.NewMockShard(ctrl)
 	memDB := memdb.NewMockMemoryDatabase(ctrl)
 	shard.EXPECT().MemoryDatabase(gomock.Any()).Return(memDB, nil).AnyTimes()
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	shard.EXPECT().IndexDatabase().Return(nil).AnyTimes()
 	metadata := metadb.NewMockMetadata(ctrl)
 	metadataIndex := metadb.NewMockMetadataDatabase(ctrl)
@@ -89,7 +89,8 @@ func (e *metadataStorageExecutor) Execute() (result []string, err error) {
 				// if shard exist, do series search
 				if ok {
 					// if get tag filter result do series ids searching
-					seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult, req.Condition)
+					seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult,
+						req.Namespace, req.MetricName, req.Condition)
 					seriesIDs, err := seriesSearch.Search()
 					if err != nil {
 						return nil, err
@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+// seriesFinder implements tsdb.SeriesFinder, finds series ids by tag filter condition
+type seriesFinder struct {
+}
+
+// NewSeriesFinder creates the series finder which finds series ids by tag filter condition
+func NewSeriesFinder() tsdb.SeriesFinder {
+	return &seriesFinder{}
+}
+
+// FindSeriesIDs returns the series ids of metric which match the tag filter condition for each shard,
+// the key of result is shard id
+func (f *seriesFinder) FindSeriesIDs(metadata metadb.Metadata, shards []tsdb.Shard,
+	namespace, metricName string, condition stmt.Expr,
+) (map[int32]*roaring.Bitmap, error) {
+	tagSearch := newTagSearchFunc(namespace, metricName, condition, metadata)
+	tagFilterResult, err := tagSearch.Filter()
+	if err != nil {
+		if err == constants.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	result := make(map[int32]*roaring.Bitmap)
+	if len(tagFilterResult) == 0 {
+		// filter not match
+		return result, nil
+	}
+	for _, shard := range shards {
+		seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult, namespace, metricName, condition)
+		seriesIDs, err := seriesSearch.Search()
+		if err != nil {
+			if err == constants.ErrNotFound {
+				// maybe series ids not found in shard, so ignore not found err
+				continue
+			}
+			return nil, err
+		}
+		if seriesIDs == nil || seriesIDs.IsEmpty() {
+			continue
+		}
+		result[shard.ShardID()] = seriesIDs
+	}
+	return result, nil
+}
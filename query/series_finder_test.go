@@ -0,0 +1,93 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestSeriesFinder_FindSeriesIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagSearchFunc = newTagSearch
+		newSeriesSearchFunc = newSeriesSearch
+		ctrl.Finish()
+	}()
+
+	tagSearch := NewMockTagSearch(ctrl)
+	newTagSearchFunc = func(namespace, metricName string, condition stmt.Expr, metadata metadb.Metadata) TagSearch {
+		return tagSearch
+	}
+	seriesSearch := NewMockSeriesSearch(ctrl)
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult,
+		namespace, metricName string, condition stmt.Expr) SeriesSearch {
+		return seriesSearch
+	}
+	shard1 := tsdb.NewMockShard(ctrl)
+	shard1.EXPECT().ShardID().Return(int32(1)).AnyTimes()
+	shard1.EXPECT().IndexDatabase().Return(indexdb.NewMockIndexDatabase(ctrl)).AnyTimes()
+	shards := []tsdb.Shard{shard1}
+	condition := &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}
+	finder := NewSeriesFinder()
+
+	// case 1: tag filter err
+	tagSearch.EXPECT().Filter().Return(nil, fmt.Errorf("err"))
+	_, err := finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.Error(t, err)
+	// case 2: tag not found
+	tagSearch.EXPECT().Filter().Return(nil, constants.ErrNotFound)
+	result, err := finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// case 3: tag filter not match
+	tagSearch.EXPECT().Filter().Return(nil, nil)
+	result, err = finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// case 4: series search err
+	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{"host": {}}, nil).AnyTimes()
+	seriesSearch.EXPECT().Search().Return(nil, fmt.Errorf("err"))
+	_, err = finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.Error(t, err)
+	// case 5: series not found
+	seriesSearch.EXPECT().Search().Return(nil, constants.ErrNotFound)
+	result, err = finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	seriesSearch.EXPECT().Search().Return(roaring.New(), nil)
+	result, err = finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// case 6: find series ids
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2), nil)
+	result, err = finder.FindSeriesIDs(nil, shards, "ns", "cpu", condition)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, result[1].ToArray())
+}
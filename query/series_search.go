@@ -37,6 +37,8 @@ type SeriesSearch interface {
 // only do tag filter, return series ids.
 // return series id set for condition
 type seriesSearch struct {
+	namespace    string
+	metricName   string
 	condition    stmt.Expr
 	filterResult map[string]*tagFilterResult
 
@@ -46,8 +48,12 @@ type seriesSearch struct {
 }
 
 // newSeriesSearch creates a a series search using query condition
-func newSeriesSearch(filter series.Filter, filterResult map[string]*tagFilterResult, condition stmt.Expr) SeriesSearch {
+func newSeriesSearch(filter series.Filter, filterResult map[string]*tagFilterResult,
+	namespace, metricName string, condition stmt.Expr,
+) SeriesSearch {
 	return &seriesSearch{
+		namespace:    namespace,
+		metricName:   metricName,
 		filterResult: filterResult,
 		filter:       filter,
 		condition:    condition,
@@ -72,6 +78,13 @@ func (s *seriesSearch) findSeriesIDsByExpr(condition stmt.Expr) (uint32, *roarin
 		return 0, roaring.New() // create a empty series ids for parent expr
 	}
 	switch expr := condition.(type) {
+	case *stmt.AbsentExpr:
+		seriesIDs, err := s.getSeriesIDsWithoutTagKey(expr)
+		if err != nil {
+			s.err = err
+			return 0, roaring.New() // create a empty series ids for parent expr
+		}
+		return 0, seriesIDs
 	case stmt.TagFilter:
 		tagKey, seriesIDs, err := s.getSeriesIDsByExpr(expr)
 		if err != nil {
@@ -118,3 +131,24 @@ func (s *seriesSearch) getSeriesIDsByExpr(expr stmt.Expr) (uint32, *roaring.Bitm
 	}
 	return tagValues.tagKey, seriesIDs, nil
 }
+
+// getSeriesIDsWithoutTagKey returns the series ids of metric which don't have the tag key of absent expr
+func (s *seriesSearch) getSeriesIDsWithoutTagKey(expr *stmt.AbsentExpr) (*roaring.Bitmap, error) {
+	result, ok := s.filterResult[expr.Rewrite()]
+	if !ok {
+		return nil, constants.ErrNotFound
+	}
+	all, err := s.filter.GetSeriesIDsForMetric(s.namespace, s.metricName)
+	if err != nil {
+		return nil, err
+	}
+	if !result.tagKeyExist {
+		return all, nil
+	}
+	seriesIDs, err := s.filter.GetSeriesIDsForTag(result.tagKey)
+	if err != nil {
+		return nil, err
+	}
+	all.AndNot(seriesIDs)
+	return all, nil
+}
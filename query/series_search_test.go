@@ -39,7 +39,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	// case 1: empty filter expr
 	q, _ := sql.Parse("select f from cpu")
 	query := q.(*stmt.Query)
-	search := newSeriesSearch(mockFilter, nil, query.Condition)
+	search := newSeriesSearch(mockFilter, nil, "ns", "cpu", query.Condition)
 	resultSet, err := search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(0), resultSet.GetCardinality())
@@ -47,7 +47,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	q, _ = sql.Parse("select f from cpu where ip='1.1.1.1'")
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs.Clone(), nil)
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, seriesIDs, resultSet)
@@ -56,7 +56,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs.Clone(), nil)
 	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(roaring.BitmapOf(10, 20, 40, 50), nil)
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(40, 50), resultSet)
@@ -66,7 +66,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs.Clone(), nil)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).Return(roaring.BitmapOf(20), nil)
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(20), resultSet)
@@ -76,7 +76,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs.Clone(), nil)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), gomock.Any()).Return(roaring.BitmapOf(200), nil)
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(10, 20, 30, 200), resultSet)
@@ -84,7 +84,7 @@ func TestSeriesSearch_Search(t *testing.T) {
 	q, _ = sql.Parse("select f from cpu where (ip='1.1.1.1')")
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs.Clone(), nil)
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err = search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, seriesIDs, resultSet)
@@ -99,12 +99,12 @@ func TestSeriesSearch_Search_err(t *testing.T) {
 	// case 1: expr not exist
 	q, _ := sql.Parse("select f from cpu where ip='1.1.1.1'")
 	query := q.(*stmt.Query)
-	search := newSeriesSearch(mockFilter, make(map[string]*tagFilterResult), query.Condition)
+	search := newSeriesSearch(mockFilter, make(map[string]*tagFilterResult), "ns", "cpu", query.Condition)
 	resultSet, err := search.Search()
 	assert.Error(t, err)
 	assert.Nil(t, resultSet)
 	// case 2: get series id err
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	resultSet, err = search.Search()
 	assert.Error(t, err)
@@ -114,7 +114,7 @@ func TestSeriesSearch_Search_err(t *testing.T) {
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(seriesIDs, nil)
 	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err = search.Search()
 	assert.Error(t, err)
 	assert.Nil(t, resultSet)
@@ -122,7 +122,54 @@ func TestSeriesSearch_Search_err(t *testing.T) {
 	q, _ = sql.Parse("select f from cpu where ip='1.1.1.1' or ip='1.1.1.1'")
 	query = q.(*stmt.Query)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(1), gomock.Any()).Return(nil, fmt.Errorf("err"))
-	search = newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search = newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
+	resultSet, err = search.Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+}
+
+func TestSeriesSearch_Search_absent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilter := series.NewMockFilter(ctrl)
+	expr := &stmt.AbsentExpr{Key: "zone"}
+
+	// case 1: tag key exist
+	mockFilter.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(10, 20, 30), nil)
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(5)).Return(roaring.BitmapOf(10, 30), nil)
+	search := newSeriesSearch(mockFilter, map[string]*tagFilterResult{
+		expr.Rewrite(): {tagKey: 5, tagKeyExist: true},
+	}, "ns", "cpu", expr)
+	resultSet, err := search.Search()
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(20), resultSet)
+	// case 2: tag key not exist, match all series of metric
+	mockFilter.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(10, 20, 30), nil)
+	search = newSeriesSearch(mockFilter, map[string]*tagFilterResult{
+		expr.Rewrite(): {},
+	}, "ns", "cpu", expr)
+	resultSet, err = search.Search()
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(10, 20, 30), resultSet)
+	// case 3: filter result not exist
+	search = newSeriesSearch(mockFilter, make(map[string]*tagFilterResult), "ns", "cpu", expr)
+	resultSet, err = search.Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+	// case 4: get series ids for metric err
+	mockFilter.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	search = newSeriesSearch(mockFilter, map[string]*tagFilterResult{
+		expr.Rewrite(): {},
+	}, "ns", "cpu", expr)
+	resultSet, err = search.Search()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+	// case 5: get series ids for tag err
+	mockFilter.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(10, 20, 30), nil)
+	mockFilter.EXPECT().GetSeriesIDsForTag(uint32(5)).Return(nil, fmt.Errorf("err"))
+	search = newSeriesSearch(mockFilter, map[string]*tagFilterResult{
+		expr.Rewrite(): {tagKey: 5, tagKeyExist: true},
+	}, "ns", "cpu", expr)
 	resultSet, err = search.Search()
 	assert.Error(t, err)
 	assert.Nil(t, resultSet)
@@ -136,7 +183,7 @@ func TestSeriesSearch_Search_expr_not_match(t *testing.T) {
 	q, _ := sql.Parse("select f from cpu where ip='1.1.1.1'")
 	query := q.(*stmt.Query)
 	query.Condition = &stmt.CallExpr{}
-	search := newSeriesSearch(mockFilter, make(map[string]*tagFilterResult), query.Condition)
+	search := newSeriesSearch(mockFilter, make(map[string]*tagFilterResult), "ns", "cpu", query.Condition)
 	resultSet, err := search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(0), resultSet.GetCardinality())
@@ -155,7 +202,7 @@ func TestSeriesSearch_Search_complex(t *testing.T) {
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(3), roaring.BitmapOf(4)).Return(roaring.BitmapOf(3, 5, 6, 7), nil)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), roaring.BitmapOf(2)).Return(roaring.BitmapOf(7), nil)
 	mockFilter.EXPECT().GetSeriesIDsByTagValueIDs(uint32(2), roaring.BitmapOf(3)).Return(roaring.BitmapOf(5), nil)
-	search := newSeriesSearch(mockFilter, mockFilterResult(), query.Condition)
+	search := newSeriesSearch(mockFilter, mockFilterResult(), "ns", "cpu", query.Condition)
 	resultSet, err := search.Search()
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(5, 7), resultSet)
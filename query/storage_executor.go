@@ -216,10 +216,19 @@ func (e *storageExecutor) executeQuery() {
 				// maybe series ids not found in shard, so ignore not found err
 				e.queryFlow.Complete(err)
 			}
+			// remove the series ids which are deleted, but not purged by compaction job
+			if deletedSeriesIDs := shard.DeletedSeriesIDs(e.metricID); deletedSeriesIDs != nil {
+				seriesIDs.AndNot(deletedSeriesIDs)
+			}
 			// if series ids not found
 			if seriesIDs.IsEmpty() {
 				return
 			}
+			// check if the number of series scanned by query exceeds the limit
+			if err := e.ctx.scanSeries(seriesIDs.GetCardinality()); err != nil {
+				e.queryFlow.Complete(err)
+				return
+			}
 
 			rs := &filterResultSet{}
 			// 2. filter data in memory database
@@ -231,7 +240,7 @@ func (e *storageExecutor) executeQuery() {
 				return
 			}
 			// 3. filter data each data family in shard
-			t = newFileDataFilterTask(e.ctx, shard, e.metricID, e.fields, seriesIDs, rs)
+			t = newFileDataFilterTask(e.ctx, shard, e.queryFlow.StorageInterval().Type(), e.metricID, e.fields, seriesIDs, rs)
 			err = t.Run()
 			if err != nil && err != constants.ErrNotFound {
 				// maybe data not exist in shard, so ignore not found err
@@ -23,6 +23,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/constants"
@@ -72,6 +73,10 @@ func (m *mockQueryFlow) Reduce(_ string, _ aggregation.ContainerAggregator) {
 func (m *mockQueryFlow) Complete(_ error) {
 }
 
+func (m *mockQueryFlow) StorageInterval() timeutil.Interval {
+	return timeutil.Interval(timeutil.OneSecond * 10)
+}
+
 func newMockQueryFlow() flow.StorageQueryFlow {
 	return &mockQueryFlow{}
 }
@@ -198,7 +203,8 @@ func TestStorageExecute_Execute(t *testing.T) {
 		"host": {tagValueIDs: roaring.BitmapOf(1, 2)},
 	}, nil).AnyTimes()
 	seriesSearch := NewMockSeriesSearch(ctrl)
-	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult, condition stmt.Expr) SeriesSearch {
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult,
+		namespace, metricName string, condition stmt.Expr) SeriesSearch {
 		return seriesSearch
 	}
 	queryFlow := newMockQueryFlow()
@@ -212,6 +218,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	index := indexdb.NewMockIndexDatabase(ctrl)
 	shard := tsdb.NewMockShard(ctrl)
 	shard.EXPECT().IndexDatabase().Return(index).AnyTimes()
+	shard.EXPECT().DeletedSeriesIDs(gomock.Any()).Return(nil).AnyTimes()
 	memDB := memdb.NewMockMemoryDatabase(ctrl)
 
 	// mock data
@@ -243,7 +250,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	filterRS := flow.NewMockFilterResultSet(ctrl)
 	filterRS.EXPECT().Load(gomock.Any(), gomock.Any()).MaxTimes(3)
 	filterRS.EXPECT().SeriesIDs().Return(roaring.BitmapOf(1, 2, 3)).MaxTimes(3)
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, nil).MaxTimes(3)
 	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
@@ -255,7 +262,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	filterRS = flow.NewMockFilterResultSet(ctrl)
 	filterRS.EXPECT().Load(gomock.Any(), gomock.Any()).MaxTimes(3)
 	filterRS.EXPECT().SeriesIDs().Return(roaring.BitmapOf(1, 2, 3)).MaxTimes(3)
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, nil).MaxTimes(3)
 	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
@@ -270,7 +277,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	// case 5: filter result is nil
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, nil).MaxTimes(3)
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
 	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
@@ -278,7 +285,8 @@ func TestStorageExecute_Execute(t *testing.T) {
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, nil).MaxTimes(3)
 	family := tsdb.NewMockDataFamily(ctrl)
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return([]tsdb.DataFamily{family}).MaxTimes(3)
+	family.EXPECT().Retain().Return(true).AnyTimes()
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return([]tsdb.DataFamily{family}).MaxTimes(3)
 	family.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, fmt.Errorf("err")).MaxTimes(3)
 	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
@@ -291,13 +299,21 @@ func TestStorageExecute_Execute(t *testing.T) {
 	filterRS = flow.NewMockFilterResultSet(ctrl)
 	filterRS.EXPECT().Load(gomock.Any(), gomock.Any()).MaxTimes(3)
 	filterRS.EXPECT().SeriesIDs().Return(roaring.BitmapOf(1, 2, 3)).MaxTimes(3)
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(3)
 	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{filterRS}, nil).MaxTimes(3)
 	index.EXPECT().GetGroupingContext(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err")).MaxTimes(3)
 	exec = newStorageExecutor(queryFlow, mockDatabase, newStorageExecuteContext([]int32{1, 2, 3}, query))
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
 	exec.Execute()
+	// case 8: too many series scanned
+	exeCtx := newStorageExecuteContext([]int32{1, 2, 3}, query)
+	exeCtx.maxSeries = 2
+	metadataIndex.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "host").Return(uint32(10), nil)
+	exec = newStorageExecutor(queryFlow, mockDatabase, exeCtx)
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil).Times(3)
+	exec.Execute()
+	assert.Equal(t, int64(9), exeCtx.scannedSeries.Load())
 }
 
 func TestStorageExecutor_Execute_GroupBy(t *testing.T) {
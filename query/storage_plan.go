@@ -24,6 +24,7 @@ import (
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql/stmt"
@@ -136,12 +137,13 @@ func (p *storageExecutePlan) getFields() field.Metas {
 	return p.fieldMetas
 }
 
-// selectList plans the select list from down sampling aggregation specification
+// selectList plans the select list from down sampling aggregation specification,
+// includes the hidden items which having/order by clause depends on.
 func (p *storageExecutePlan) selectList() error {
-	selectItems := p.query.SelectItems
-	if len(selectItems) == 0 {
+	if len(p.query.SelectItems) == 0 {
 		return errEmptySelectList
 	}
+	selectItems := p.query.AllSelectItems()
 
 	for _, selectItem := range selectItems {
 		if p.err != nil {
@@ -171,12 +173,16 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 		p.field(nil, e.Right)
 	case *stmt.FieldExpr:
 		fieldMeta, err := p.metadata.MetadataDatabase().GetField(p.namespace, p.query.MetricName, field.Name(e.Name))
+		if err == constants.ErrNotFound && isQuantileFunc(parentFunc) {
+			// histogram/summary field is stored as multiple fields which have the same base name
+			p.histogramFields(parentFunc, e.Name)
+			return
+		}
 		if err != nil {
 			p.err = err
 			return
 		}
 		fieldType := fieldMeta.Type
-		fieldID := fieldMeta.ID
 		var funcType function.FuncType
 		// tests if has func with field
 		if parentFunc == nil {
@@ -194,11 +200,71 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 			}
 			funcType = parentFunc.FuncType
 		}
-		downSampling, exist := p.fields[fieldID]
-		if !exist {
-			downSampling = aggregation.NewDownSamplingSpec(field.Name(e.Name), fieldType)
-			p.fields[fieldID] = downSampling
+		if err := p.checkRateFunc(fieldMeta.ID, e.Name, funcType); err != nil {
+			p.err = err
+			return
 		}
-		downSampling.AddFunctionType(funcType)
+		p.addDownSamplingSpec(fieldMeta.ID, field.Name(e.Name), fieldType, funcType)
+	}
+}
+
+// histogramFields plans the bucket fields of histogram or the quantile fields of summary by base field name
+func (p *storageExecutePlan) histogramFields(parentFunc *stmt.CallExpr, fieldName string) {
+	allFields, err := p.metadata.MetadataDatabase().GetAllFields(p.namespace, p.query.MetricName)
+	if err != nil {
+		p.err = err
+		return
+	}
+	found := false
+	for _, f := range allFields {
+		var name string
+		var ok bool
+		switch f.Type {
+		case field.HistogramField:
+			name, _, ok = field.ParseBucketFieldName(f.Name)
+		case field.SummaryField:
+			name, _, ok = field.ParseQuantileFieldName(f.Name)
+		}
+		if !ok || name != fieldName {
+			continue
+		}
+		found = true
+		p.addDownSamplingSpec(f.ID, f.Name, f.Type, parentFunc.FuncType)
+	}
+	if !found {
+		p.err = fmt.Errorf("histogram/summary field[%s] not found for function[%s]", fieldName, parentFunc.FuncType)
 	}
 }
+
+// addDownSamplingSpec adds the function type into the down sampling spec of field
+func (p *storageExecutePlan) addDownSamplingSpec(
+	fieldID field.ID, fieldName field.Name, fieldType field.Type, funcType function.FuncType,
+) {
+	downSampling, exist := p.fields[fieldID]
+	if !exist {
+		downSampling = aggregation.NewDownSamplingSpec(fieldName, fieldType)
+		p.fields[fieldID] = downSampling
+	}
+	downSampling.AddFunctionType(funcType)
+}
+
+// checkRateFunc checks if rate function used with other functions for the same field,
+// because rate/irate/delta/derivative are calculated per series before merging series,
+// the data of field cannot be used by other functions.
+func (p *storageExecutePlan) checkRateFunc(fieldID field.ID, fieldName string, funcType function.FuncType) error {
+	downSampling, exist := p.fields[fieldID]
+	if !exist {
+		return nil
+	}
+	for existFunc := range downSampling.Functions() {
+		if existFunc != funcType && (function.IsRateFunc(existFunc) || function.IsRateFunc(funcType)) {
+			return fmt.Errorf("function[%s] cannot be used with function[%s] for field[%s]", funcType, existFunc, fieldName)
+		}
+	}
+	return nil
+}
+
+// isQuantileFunc checks if the function calculates quantile based on histogram/summary fields
+func isQuantileFunc(funcExpr *stmt.CallExpr) bool {
+	return funcExpr != nil && (funcExpr.FuncType == function.Histogram || funcExpr.FuncType == function.Quantile)
+}
@@ -19,6 +19,7 @@ package query
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -27,6 +28,12 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
 	"github.com/lindb/lindb/sql"
@@ -279,3 +286,151 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 	err = plan.Plan()
 	assert.Error(t, err)
 }
+
+func TestStorageExecutePlan_histogram_fields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(field.Meta{}, constants.ErrNotFound).AnyTimes()
+
+	q, _ := sql.Parse("select quantile(0.99, latency) from cpu")
+	query := q.(*stmt.Query)
+	// case 1: get all fields err
+	metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	plan := newStorageExecutePlan("ns", metadata, query)
+	assert.Error(t, plan.Plan())
+	// case 2: histogram fields not found
+	metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).Return(field.Metas{
+		{ID: 1, Type: field.SumField, Name: field.CountFieldName("latency")},
+		{ID: 2, Type: field.HistogramField, Name: field.BucketFieldName("rt", 1)},
+	}, nil)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	assert.Error(t, plan.Plan())
+	// case 3: plan bucket fields
+	metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).Return(field.Metas{
+		{ID: 1, Type: field.SumField, Name: field.CountFieldName("latency")},
+		{ID: 2, Type: field.HistogramField, Name: field.BucketFieldName("latency", 1)},
+		{ID: 3, Type: field.HistogramField, Name: field.BucketFieldName("latency", math.Inf(1))},
+		{ID: 4, Type: field.SummaryField, Name: field.QuantileFieldName("rt", 0.99)},
+	}, nil)
+	plan = newStorageExecutePlan("ns", metadata, query)
+	assert.NoError(t, plan.Plan())
+	storagePlan := plan.(*storageExecutePlan)
+	bucket1 := aggregation.NewDownSamplingSpec("latency__bucket_1", field.HistogramField)
+	bucket1.AddFunctionType(function.Quantile)
+	bucketInf := aggregation.NewDownSamplingSpec("latency__bucket_+Inf", field.HistogramField)
+	bucketInf.AddFunctionType(function.Quantile)
+	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{2: bucket1, 3: bucketInf}, storagePlan.fields)
+	// case 4: plan summary fields
+	q, _ = sql.Parse("select histogram(0.99, rt) from cpu")
+	metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).Return(field.Metas{
+		{ID: 4, Type: field.SummaryField, Name: field.QuantileFieldName("rt", 0.99)},
+	}, nil)
+	plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	assert.Equal(t, field.Metas{{ID: 4, Type: field.SummaryField}}, plan.(*storageExecutePlan).getFields())
+}
+
+func TestStorageExecutePlan_rate_funcs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
+
+	q, _ := sql.Parse("select rate(f) as r1, rate(f) as r2 from disk")
+	plan := newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+	assert.NoError(t, plan.Plan())
+	downSampling := aggregation.NewDownSamplingSpec("f", field.SumField)
+	downSampling.AddFunctionType(function.Rate)
+	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{10: downSampling}, plan.(*storageExecutePlan).fields)
+
+	// rate function cannot be used with other functions for same field
+	for _, sqlStr := range []string{
+		"select rate(f), sum(f) from disk",
+		"select max(f), irate(f) from disk",
+		"select rate(f), delta(f) from disk",
+	} {
+		q, _ = sql.Parse(sqlStr)
+		plan = newStorageExecutePlan("ns", metadata, q.(*stmt.Query))
+		assert.Error(t, plan.Plan(), sqlStr)
+	}
+}
+
+func TestStoragePlan_HiddenItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), "cpu").Return(uint32(10), nil)
+	metadataDB.EXPECT().GetTagKeyID(gomock.Any(), gomock.Any(), "host").Return(uint32(10), nil)
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
+
+	q, _ := sql.Parse("select sum(f) from cpu group by host having max(f) > 90")
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f"}, query.FieldNames)
+	query.TimeRange = timeutil.TimeRange{Start: 0, End: 40 * timeutil.OneSecond}
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+
+	// storage side loads the function which having clause depends on
+	plan := newStorageExecutePlan("ns", metadata, query)
+	assert.NoError(t, plan.Plan())
+	aggSpecs := plan.(*storageExecutePlan).getDownSamplingAggSpecs()
+	assert.Len(t, aggSpecs, 1)
+	assert.Equal(t, map[function.FuncType]function.FuncType{
+		function.Sum: function.Sum,
+		function.Max: function.Max,
+	}, aggSpecs[0].Functions())
+
+	// broker side evaluates the hidden item, but doesn't return it
+	newSeries := func(host string, sum, max float64) series.GroupedIterator {
+		writer := stream.NewBufferWriter(nil)
+		writer.PutByte(byte(aggSpecs[0].GetFieldType()))
+		for funcType := range aggSpecs[0].Functions() {
+			for _, aggType := range aggSpecs[0].GetFieldType().GetFuncFieldParams(funcType) {
+				value := sum
+				if aggType == field.Max {
+					value = max
+				}
+				writer.PutVarint64(0)
+				writer.PutBytes(newTestFieldData(aggType, value))
+			}
+		}
+		data, err := writer.Bytes()
+		assert.NoError(t, err)
+		return series.NewGroupedIterator(host, map[field.Name][]byte{"f": data})
+	}
+	ctx := parallel.NewBrokerExecuteContext(timeutil.NowNano(), query, 0)
+	ctx.Emit(&series.TimeSeriesEvent{
+		SeriesList: []series.GroupedIterator{newSeries("1", 10, 95), newSeries("2", 100, 50)},
+	})
+	ctx.Complete(nil)
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Len(t, rs.Series, 1)
+	assert.Equal(t, "1", rs.Series[0].Tags["host"])
+	assert.Equal(t, map[int64]float64{0: 10}, rs.Series[0].Fields["sum(f)"])
+	assert.Len(t, rs.Series[0].Fields, 1)
+}
+
+func newTestFieldData(aggType field.AggType, value float64) []byte {
+	encoder := encoding.NewTSDEncoder(0)
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(math.Float64bits(value))
+	data, _ := encoder.Bytes()
+	writer := stream.NewBufferWriter(nil)
+	writer.PutByte(byte(aggType))
+	writer.PutVarint32(int32(len(data)))
+	writer.PutBytes(data)
+	d, _ := writer.Bytes()
+	return d
+}
@@ -183,7 +183,8 @@ func (t *seriesIDsSearchTask) Run() (err error) {
 	var seriesIDs *roaring.Bitmap
 	if condition != nil {
 		// if get tag filter result do series ids searching
-		seriesSearch := newSeriesSearchFunc(t.shard.IndexDatabase(), t.ctx.tagFilterResult, t.ctx.query.Condition)
+		seriesSearch := newSeriesSearchFunc(t.shard.IndexDatabase(), t.ctx.tagFilterResult,
+			t.ctx.query.Namespace, t.ctx.query.MetricName, t.ctx.query.Condition)
 		seriesIDs, err = seriesSearch.Search()
 	} else {
 		// get series ids for metric level
@@ -259,27 +260,29 @@ func (t *memoryDataFilterTask) AfterRun() {
 type fileDataFilterTask struct {
 	baseQueryTask
 
-	ctx       *storageExecuteContext
-	shard     tsdb.Shard
-	metricID  uint32
-	fields    field.Metas
-	seriesIDs *roaring.Bitmap
+	ctx          *storageExecuteContext
+	shard        tsdb.Shard
+	intervalType timeutil.IntervalType // interval type of storage which query data from
+	metricID     uint32
+	fields       field.Metas
+	seriesIDs    *roaring.Bitmap
 
 	result *filterResultSet
 }
 
 // newFileDataFilterTask creates file data filtering task
-func newFileDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard,
+func newFileDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard, intervalType timeutil.IntervalType,
 	metricID uint32, fields field.Metas, seriesIDs *roaring.Bitmap,
 	result *filterResultSet,
 ) flow.QueryTask {
 	task := &fileDataFilterTask{
-		ctx:       ctx,
-		shard:     shard,
-		metricID:  metricID,
-		fields:    fields,
-		seriesIDs: seriesIDs,
-		result:    result,
+		ctx:          ctx,
+		shard:        shard,
+		intervalType: intervalType,
+		metricID:     metricID,
+		fields:       fields,
+		seriesIDs:    seriesIDs,
+		result:       result,
 	}
 	if ctx.query.Explain {
 		return &queryStatTask{
@@ -291,12 +294,16 @@ func newFileDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard,
 
 // Run executes file data filtering based on series ids and time range for each data family
 func (t *fileDataFilterTask) Run() error {
-	families := t.shard.GetDataFamilies(t.ctx.query.Interval.Type(), t.ctx.query.TimeRange)
+	families := t.shard.GetQueryDataFamilies(t.intervalType, t.ctx.query.TimeRange)
 	if len(families) == 0 {
 		return nil
 	}
 	for idx := range families {
 		family := families[idx]
+		// retain data family until query completed, skip it if evicted by data retention
+		if !t.ctx.retainDataFamily(family) {
+			continue
+		}
 		// execute data family search in background goroutine
 		resultSet, err := family.Filter(t.metricID, t.seriesIDs, t.ctx.query.TimeRange, t.fields)
 		if err != nil {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
@@ -122,7 +123,8 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	q, _ := sql.Parse("select f from cpu where ip<>'1.1.1.1'")
 	query := q.(*stmt.Query)
 	seriesSearch := NewMockSeriesSearch(ctrl)
-	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult, condition stmt.Expr) SeriesSearch {
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult,
+		namespace, metricName string, condition stmt.Expr) SeriesSearch {
 		return seriesSearch
 	}
 	seriesSearch.EXPECT().Search().Return(nil, fmt.Errorf("err"))
@@ -181,28 +183,34 @@ func TestFileDataFilterTask_Run(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	seriesIDs := roaring.BitmapOf(1, 2, 3)
 	result := &filterResultSet{}
-	task := newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{}),
-		shard, 1, field.Metas{{ID: 10}}, seriesIDs, result)
+	task := newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{}), shard, timeutil.Day,
+		1, field.Metas{{ID: 10}}, seriesIDs, result)
 	// case 1: get empty family
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return(nil)
+	shard.EXPECT().GetQueryDataFamilies(timeutil.Day, gomock.Any()).Return(nil)
 	err := task.Run()
 	assert.NoError(t, err)
 	assert.Nil(t, result.rs)
-	// case 2: family filter err
+	// case 2: family evicted
 	family := tsdb.NewMockDataFamily(ctrl)
-	shard.EXPECT().GetDataFamilies(gomock.Any(), gomock.Any()).Return([]tsdb.DataFamily{family}).AnyTimes()
+	shard.EXPECT().GetQueryDataFamilies(gomock.Any(), gomock.Any()).Return([]tsdb.DataFamily{family}).AnyTimes()
+	family.EXPECT().Retain().Return(false)
+	err = task.Run()
+	assert.NoError(t, err)
+	assert.Nil(t, result.rs)
+	// case 3: family filter err
+	family.EXPECT().Retain().Return(true).AnyTimes()
 	family.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	err = task.Run()
 	assert.Error(t, err)
-	// case 3: get data
+	// case 4: get data
 	family.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
 	err = task.Run()
 	assert.NoError(t, err)
 	assert.NotNil(t, result.rs)
-	// case 4: explain
-	task = newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}),
-		shard, 1, field.Metas{{ID: 10}}, seriesIDs, result)
+	// case 5: explain
+	task = newFileDataFilterTask(newStorageExecuteContext(nil, &stmt.Query{Explain: true}), shard, timeutil.Day,
+		1, field.Metas{{ID: 10}}, seriesIDs, result)
 	family.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
 	shard.EXPECT().ShardID().Return(int32(10))
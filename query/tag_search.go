@@ -22,6 +22,7 @@ import (
 
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
 )
@@ -32,6 +33,7 @@ import (
 type tagFilterResult struct {
 	tagKey      uint32
 	tagValueIDs *roaring.Bitmap
+	tagKeyExist bool // if tag key of absent expr exists
 }
 
 // TagSearch represents the tag filtering by tag filter expr
@@ -82,6 +84,19 @@ func (s *tagSearch) findTagValueIDsByExpr(expr stmt.Expr) {
 		return
 	}
 	switch expr := expr.(type) {
+	case *stmt.AbsentExpr:
+		// absent expr matches all series if tag key not exist, so always saves the result
+		result := &tagFilterResult{}
+		tagKeyID, err := s.getTagKeyID(expr.Key)
+		switch {
+		case err == nil:
+			result.tagKey = tagKeyID
+			result.tagKeyExist = true
+		case err != constants.ErrNotFound:
+			s.err = err
+			return
+		}
+		s.result[expr.Rewrite()] = result
 	case stmt.TagFilter:
 		tagKeyID, err := s.getTagKeyID(expr.TagKey())
 		if err != nil {
@@ -25,6 +25,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/metadb"
@@ -145,6 +146,35 @@ func TestTagSearch_Filter_err(t *testing.T) {
 	assert.Nil(t, resultSet)
 }
 
+func TestTagSearch_Filter_absent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	expr := &stmt.AbsentExpr{Key: "zone"}
+
+	// case 1: tag key exist
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "zone").Return(uint32(5), nil)
+	search := newTagSearch("ns", "cpu", expr, metadata)
+	resultSet, err := search.Filter()
+	assert.NoError(t, err)
+	assert.Equal(t, &tagFilterResult{tagKey: 5, tagKeyExist: true}, resultSet[expr.Rewrite()])
+	// case 2: tag key not exist
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "zone").Return(uint32(0), constants.ErrNotFound)
+	search = newTagSearch("ns", "cpu", expr, metadata)
+	resultSet, err = search.Filter()
+	assert.NoError(t, err)
+	assert.Equal(t, &tagFilterResult{}, resultSet[expr.Rewrite()])
+	// case 3: get tag key err
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "zone").Return(uint32(0), fmt.Errorf("err"))
+	search = newTagSearch("ns", "cpu", expr, metadata)
+	resultSet, err = search.Filter()
+	assert.Error(t, err)
+	assert.Nil(t, resultSet)
+}
+
 func TestTagSearch_Filter_Complex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
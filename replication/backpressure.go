@@ -0,0 +1,103 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/monitoring"
+)
+
+// defines the reasons of throttling writes
+const (
+	// reasonPending represents the pending messages of replicator exceed the limit
+	reasonPending = "pending"
+	// reasonQueueUsage represents the disk usage of replication queue exceeds the limit
+	reasonQueueUsage = "queue_usage"
+)
+
+const defaultRetryAfter = 5 * time.Second
+
+var (
+	throttledCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "broker_write_throttled",
+			Help: "The number of throttled writes because the replication queue of shard is overloaded.",
+		},
+		[]string{"db", "shard", "reason"},
+	)
+)
+
+func init() {
+	monitoring.BrokerRegistry.MustRegister(throttledCounter)
+}
+
+// OverloadedError represents the error of throttling writes because the replication queue of shard is overloaded,
+// the client should retry the writes after a while.
+type OverloadedError struct {
+	Database   string
+	ShardID    int32
+	Reason     string
+	RetryAfter time.Duration
+}
+
+// Error returns the error message
+func (e *OverloadedError) Error() string {
+	return fmt.Sprintf("replication queue of database [%s] shard [%d] is overloaded(%s), please retry after %s",
+		e.Database, e.ShardID, e.Reason, e.RetryAfter)
+}
+
+// CheckPressure checks if the replication queue is overloaded based on the pending messages of replicators
+// and the disk usage of queue, returns OverloadedError if overloaded.
+func (c *channel) CheckPressure() error {
+	if c.maxPending > 0 {
+		var pending int64
+		c.replicatorMap.Range(func(key, value interface{}) bool {
+			rep, _ := value.(Replicator)
+			if p := rep.Pending(); p > pending {
+				pending = p
+			}
+			return true
+		})
+		if pending > c.maxPending {
+			return c.overloaded(reasonPending)
+		}
+	}
+	if c.maxQueueUsage > 0 {
+		limit := c.q.DataSizeLimit()
+		if limit > 0 && float64(c.q.DataSize()) >= float64(limit)*c.maxQueueUsage {
+			return c.overloaded(reasonQueueUsage)
+		}
+	}
+	return nil
+}
+
+// overloaded records the throttled writes, returns the OverloadedError with reason
+func (c *channel) overloaded(reason string) error {
+	throttledCounter.WithLabelValues(c.database, strconv.Itoa(int(c.shardID)), reason).Inc()
+	return &OverloadedError{
+		Database:   c.database,
+		ShardID:    c.shardID,
+		Reason:     reason,
+		RetryAfter: c.retryAfter,
+	}
+}
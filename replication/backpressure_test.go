@@ -0,0 +1,62 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/queue"
+)
+
+func TestChannel_CheckPressure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q := queue.NewMockFanOutQueue(ctrl)
+	rep := NewMockReplicator(ctrl)
+	ch := &channel{database: "db", shardID: 1, q: q, retryAfter: time.Second}
+	ch.replicatorMap.Store(models.Node{IP: "1.1.1.1", Port: 2000}, rep)
+
+	// case 1: no limit
+	assert.NoError(t, ch.CheckPressure())
+	// case 2: pending under limit
+	ch.maxPending = 10
+	rep.EXPECT().Pending().Return(int64(10))
+	assert.NoError(t, ch.CheckPressure())
+	// case 3: too many pending messages
+	rep.EXPECT().Pending().Return(int64(11))
+	err := ch.CheckPressure()
+	assert.Equal(t, &OverloadedError{Database: "db", ShardID: 1, Reason: reasonPending, RetryAfter: time.Second}, err)
+	assert.Equal(t, "replication queue of database [db] shard [1] is overloaded(pending), please retry after 1s",
+		err.Error())
+	// case 4: queue usage under limit
+	ch.maxQueueUsage = 0.9
+	rep.EXPECT().Pending().Return(int64(0)).AnyTimes()
+	q.EXPECT().DataSizeLimit().Return(int64(100)).AnyTimes()
+	q.EXPECT().DataSize().Return(int64(80))
+	assert.NoError(t, ch.CheckPressure())
+	// case 5: queue usage exceeds limit
+	q.EXPECT().DataSize().Return(int64(90))
+	err = ch.CheckPressure()
+	assert.Equal(t, &OverloadedError{Database: "db", ShardID: 1, Reason: reasonQueueUsage, RetryAfter: time.Second}, err)
+}
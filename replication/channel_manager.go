@@ -27,6 +27,7 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/field"
@@ -40,18 +41,22 @@ var ErrCanceled = errors.New("write data ctx done")
 const (
 	defaultReportInterval = 30 * time.Second
 	defaultBufferSize     = 1024
+	defaultAckTimeout     = 10 * time.Second
 )
 
 var log = logger.GetLogger("replication", "ChannelManager")
 
 // ChannelManager manages the construction, retrieving, closing for all channels.
 type ChannelManager interface {
-	// Write writes a MetricList, the manager handler the database, sharding things.
-	Write(database string, list *field.MetricList) error
+	// Write writes a MetricList, the manager handler the database, sharding things,
+	// then waits the data acknowledged based on write consistency.
+	Write(ctx context.Context, database string, list *field.MetricList, consistency option.WriteConsistency) error
 	// CreateChannel creates a new channel or returns a existed channel for storage with specific database and shardID,
 	// numOfShard should be greater or equal than the origin setting, otherwise error is returned.
 	// numOfShard is used eot calculate the shardID for a given hash.
 	CreateChannel(database string, numOfShard, shardID int32) (Channel, error)
+	// SetRoutingEpochs sets the routing epochs for spec database, returns error if database channel not exist
+	SetRoutingEpochs(database string, epochs models.RoutingEpochs) error
 	// SyncReplicatorState syncs replicator state
 	SyncReplicatorState()
 
@@ -97,13 +102,29 @@ func NewChannelManager(cfg config.ReplicationChannel, fct rpc.ClientStreamFactor
 	return cm
 }
 
-// Write writes a MetricList, the manager handler the database, sharding things.
-func (cm *channelManager) Write(database string, metricList *field.MetricList) error {
+// Write writes a MetricList, the manager handler the database, sharding things,
+// then waits the data acknowledged based on write consistency, the max wait time is ack timeout.
+func (cm *channelManager) Write(ctx context.Context, database string, metricList *field.MetricList,
+	consistency option.WriteConsistency,
+) error {
 	databaseChannel, ok := cm.getDatabaseChannel(database)
 	if !ok {
 		return fmt.Errorf("database [%s] not found", database)
 	}
-	return databaseChannel.Write(metricList)
+	if consistency.NeedWAL() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cm.ackTimeout())
+		defer cancel()
+	}
+	return databaseChannel.Write(ctx, metricList, consistency)
+}
+
+// ackTimeout returns the max wait time of write acknowledgement
+func (cm *channelManager) ackTimeout() time.Duration {
+	if cm.cfg.AckTimeout > 0 {
+		return cm.cfg.AckTimeout.Duration()
+	}
+	return defaultAckTimeout
 }
 
 // CreateChannel creates a new channel or returns a existed channel for storage with specific database and shardID.
@@ -134,6 +155,16 @@ func (cm *channelManager) CreateChannel(database string, numOfShard, shardID int
 	return ch.CreateChannel(numOfShard, shardID)
 }
 
+// SetRoutingEpochs sets the routing epochs for spec database, returns error if database channel not exist
+func (cm *channelManager) SetRoutingEpochs(database string, epochs models.RoutingEpochs) error {
+	databaseChannel, ok := cm.getDatabaseChannel(database)
+	if !ok {
+		return fmt.Errorf("database [%s] not found", database)
+	}
+	databaseChannel.SetRoutingEpochs(epochs)
+	return nil
+}
+
 // SyncReplicatorState syncs replicator state
 func (cm *channelManager) SyncReplicatorState() {
 	cm.syncState <- struct{}{}
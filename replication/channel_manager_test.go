@@ -18,6 +18,7 @@
 package replication
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -31,6 +32,8 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/option"
+	pb "github.com/lindb/lindb/rpc/proto/field"
 )
 
 var replicationConfig = config.ReplicationChannel{
@@ -102,14 +105,52 @@ func TestChannelManager_Write(t *testing.T) {
 
 	replicationConfig.Dir = dirPath
 	cm := NewChannelManager(replicationConfig, nil, replicatorStateReport)
-	err := cm.Write("database", nil)
+	err := cm.Write(context.TODO(), "database", nil, option.WriteConsistency{})
 	assert.Error(t, err)
 
 	dbChannel := NewMockDatabaseChannel(ctrl)
 	cm1 := cm.(*channelManager)
 	cm1.databaseChannelMap.Store("database", dbChannel)
-	dbChannel.EXPECT().Write(gomock.Any()).Return(nil)
-	err = cm.Write("database", nil)
+	dbChannel.EXPECT().Write(gomock.Any(), gomock.Any(), option.WriteConsistency{}).Return(nil)
+	err = cm.Write(context.TODO(), "database", nil, option.WriteConsistency{})
+	assert.NoError(t, err)
+	// wait ack with timeout
+	consistency := option.WriteConsistency{Level: option.ConsistencyWAL}
+	dbChannel.EXPECT().Write(gomock.Any(), gomock.Any(), consistency).
+		DoAndReturn(func(ctx context.Context, _ *pb.MetricList, _ option.WriteConsistency) error {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			return nil
+		})
+	err = cm.Write(context.TODO(), "database", nil, consistency)
+	assert.NoError(t, err)
+	cm.Close()
+}
+
+func TestChannelManager_SetRoutingEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dirPath := path.Join(os.TempDir(), "test_channel_manager")
+	defer func() {
+		if err := os.RemoveAll(dirPath); err != nil {
+			t.Error(err)
+		}
+		ctrl.Finish()
+	}()
+
+	replicatorStateReport := NewMockReplicatorStateReport(ctrl)
+	replicatorStateReport.EXPECT().Report(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
+
+	replicationConfig.Dir = dirPath
+	cm := NewChannelManager(replicationConfig, nil, replicatorStateReport)
+	epochs := models.RoutingEpochs{{Epoch: 0, NumOfShard: 1}, {Epoch: 1, NumOfShard: 2, StartTime: 100}}
+	err := cm.SetRoutingEpochs("database", epochs)
+	assert.Error(t, err)
+
+	dbChannel := NewMockDatabaseChannel(ctrl)
+	cm1 := cm.(*channelManager)
+	cm1.databaseChannelMap.Store("database", dbChannel)
+	dbChannel.EXPECT().SetRoutingEpochs(epochs)
+	err = cm.SetRoutingEpochs("database", epochs)
 	assert.NoError(t, err)
 	cm.Close()
 }
@@ -25,11 +25,13 @@ import (
 
 	"github.com/cespare/xxhash"
 	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/field"
 	"github.com/lindb/lindb/series/tag"
@@ -52,12 +54,14 @@ var (
 
 // DatabaseChannel represents the database level replication channel
 type DatabaseChannel interface {
-	// Write writes the metric data into channel's buffer
-	Write(metricList *field.MetricList) error
+	// Write writes the metric data into channel's buffer, then waits the data acknowledged based on write consistency
+	Write(ctx context.Context, metricList *field.MetricList, consistency option.WriteConsistency) error
 	// CreateChannel creates the shard level replication channel by given shard id
 	CreateChannel(numOfShard, shardID int32) (Channel, error)
 	// ReplicaState returns the replica state
 	ReplicaState() (replicas []models.ReplicaState)
+	// SetRoutingEpochs sets the routing epochs which route metric by timestamp after num. of shard changed
+	SetRoutingEpochs(epochs models.RoutingEpochs)
 }
 
 type databaseChannel struct {
@@ -66,6 +70,7 @@ type databaseChannel struct {
 	cfg           config.ReplicationChannel
 	fct           rpc.ClientStreamFactory
 	numOfShard    atomic.Int32
+	epochs        atomic.Value // models.RoutingEpochs
 	shardChannels sync.Map
 	mutex         sync.Mutex
 }
@@ -89,16 +94,29 @@ func newDatabaseChannel(ctx context.Context,
 	return ch, nil
 }
 
-// Write writes the metric data into channel's buffer
-func (dc *databaseChannel) Write(metricList *field.MetricList) (err error) {
+// Write writes the metric data into channel's buffer, then waits the data acknowledged based on write consistency
+func (dc *databaseChannel) Write(ctx context.Context, metricList *field.MetricList,
+	consistency option.WriteConsistency,
+) (err error) {
 	// sharding metrics to shards
-	numOfShard := uint64(dc.numOfShard.Load())
+	defaultNumOfShard := uint64(dc.numOfShard.Load())
+	epochs, _ := dc.epochs.Load().(models.RoutingEpochs)
+	shardMetrics := make(map[int32][]*field.Metric)
 	for _, metric := range metricList.Metrics {
 		hash := xxhash.Sum64String(tag.Concat(metric.Tags))
 		// set tags hash code for storage side reuse
 		// !!!IMPORTANT: storage side will use this hash for write
 		metric.TagsHash = hash
+		numOfShard := defaultNumOfShard
+		// route metric by the epoch which contains metric's timestamp, make sure history series never moves
+		if n := epochs.NumOfShard(metric.Timestamp); n > 0 {
+			numOfShard = uint64(n)
+		}
 		shardID := int32(hash % numOfShard)
+		shardMetrics[shardID] = append(shardMetrics[shardID], metric)
+	}
+	channels := make(map[int32]Channel, len(shardMetrics))
+	for shardID := range shardMetrics {
 		channel, ok := dc.getChannelByShardID(shardID)
 		if !ok {
 			err = errChannelNotFound
@@ -106,9 +124,38 @@ func (dc *databaseChannel) Write(metricList *field.MetricList) (err error) {
 			log.Error("channel not found", logger.String("database", dc.database), logger.Int32("shardID", shardID))
 			continue
 		}
-		if err = channel.Write(metric); err != nil {
-			log.Error("channel write data error", logger.String("database", dc.database), logger.Int32("shardID", shardID))
+		// admission control, rejects all metrics before writing if any target shard is overloaded,
+		// so client can retry the whole writes without duplicate data
+		if pressureErr := channel.CheckPressure(); pressureErr != nil {
+			return pressureErr
+		}
+		channels[shardID] = channel
+	}
+	// appends metrics into all shards first, then waits the acknowledgements of shards concurrently
+	g, gCtx := errgroup.WithContext(ctx)
+	for shardID, channel := range channels {
+		shardID := shardID
+		wait, appendErr := channel.Append(shardMetrics[shardID], consistency)
+		if appendErr != nil {
+			log.Error("channel append data error", logger.String("database", dc.database),
+				logger.Int32("shardID", shardID), logger.Error(appendErr))
+			// keeps the first error, the failure of any shard must be returned to client
+			if err == nil {
+				err = appendErr
+			}
+			continue
 		}
+		g.Go(func() error {
+			if waitErr := wait(gCtx); waitErr != nil {
+				log.Error("channel write data error", logger.String("database", dc.database),
+					logger.Int32("shardID", shardID), logger.Error(waitErr))
+				return waitErr
+			}
+			return nil
+		})
+	}
+	if waitErr := g.Wait(); waitErr != nil && err == nil {
+		err = waitErr
 	}
 	return
 }
@@ -143,6 +190,11 @@ func (dc *databaseChannel) CreateChannel(numOfShard, shardID int32) (Channel, er
 	return channel, nil
 }
 
+// SetRoutingEpochs sets the routing epochs which route metric by timestamp after num. of shard changed
+func (dc *databaseChannel) SetRoutingEpochs(epochs models.RoutingEpochs) {
+	dc.epochs.Store(epochs)
+}
+
 // ReplicaState returns the replica state
 func (dc *databaseChannel) ReplicaState() (replicas []models.ReplicaState) {
 	dc.shardChannels.Range(func(key, value interface{}) bool {
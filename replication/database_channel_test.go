@@ -21,16 +21,21 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/cespare/xxhash"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/tag"
 )
 
 func TestDatabaseChannel_new(t *testing.T) {
@@ -52,7 +57,7 @@ func TestDatabaseChannel_Write(t *testing.T) {
 	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 1, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, ch)
-	err = ch.Write(&pb.MetricList{Metrics: []*pb.Metric{
+	err = ch.Write(context.TODO(), &pb.MetricList{Metrics: []*pb.Metric{
 		{
 			Name:      "cpu",
 			Timestamp: timeutil.Now(),
@@ -63,15 +68,33 @@ func TestDatabaseChannel_Write(t *testing.T) {
 			}},
 			Tags: map[string]string{"host": "1.1.1.1"},
 		},
-	}})
+	}}, option.WriteConsistency{})
 	assert.Equal(t, errChannelNotFound, err)
 
 	shardCh := NewMockChannel(ctrl)
 	ch1 := ch.(*databaseChannel)
 	ch1.shardChannels.Store(int32(0), shardCh)
 
-	shardCh.EXPECT().Write(gomock.Any()).Return(fmt.Errorf("err"))
-	err = ch.Write(&pb.MetricList{Metrics: []*pb.Metric{
+	// case: shard overloaded
+	shardCh.EXPECT().CheckPressure().Return(&OverloadedError{Database: "test-db", Reason: reasonPending})
+	err = ch.Write(context.TODO(), &pb.MetricList{Metrics: []*pb.Metric{
+		{Name: "cpu", Timestamp: timeutil.Now(), Tags: map[string]string{"host": "1.1.1.1"}},
+	}}, option.WriteConsistency{})
+	_, ok := err.(*OverloadedError)
+	assert.True(t, ok)
+
+	shardCh.EXPECT().CheckPressure().Return(nil).AnyTimes()
+	// case: append failure
+	shardCh.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	err = ch.Write(context.TODO(), &pb.MetricList{Metrics: []*pb.Metric{
+		{Name: "cpu", Timestamp: timeutil.Now(), Tags: map[string]string{"host": "1.1.1.1"}},
+	}}, option.WriteConsistency{})
+	assert.Error(t, err)
+	// case: wait failure
+	shardCh.EXPECT().Append(gomock.Any(), gomock.Any()).Return(func(ctx context.Context) error {
+		return fmt.Errorf("err")
+	}, nil)
+	err = ch.Write(context.TODO(), &pb.MetricList{Metrics: []*pb.Metric{
 		{
 			Name:      "cpu",
 			Timestamp: timeutil.Now(),
@@ -82,10 +105,129 @@ func TestDatabaseChannel_Write(t *testing.T) {
 			}},
 			Tags: map[string]string{"host": "1.1.1.1"},
 		},
-	}})
+	}}, option.WriteConsistency{})
 	assert.Error(t, err)
 }
 
+func TestDatabaseChannel_Write_ShardFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 2, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	failedCh := NewMockChannel(ctrl)
+	succeedCh := NewMockChannel(ctrl)
+	ch1.shardChannels.Store(int32(0), failedCh)
+	ch1.shardChannels.Store(int32(1), succeedCh)
+	failedCh.EXPECT().CheckPressure().Return(nil).AnyTimes()
+	succeedCh.EXPECT().CheckPressure().Return(nil).AnyTimes()
+	failedCh.EXPECT().Append(gomock.Any(), gomock.Any()).Return(func(ctx context.Context) error {
+		return fmt.Errorf("err")
+	}, nil).AnyTimes()
+	succeedCh.EXPECT().Append(gomock.Any(), gomock.Any()).Return(func(ctx context.Context) error {
+		return nil
+	}, nil).AnyTimes()
+
+	var metrics []*pb.Metric
+	for i := 0; i < 10; i++ {
+		metrics = append(metrics, &pb.Metric{
+			Name:      "cpu",
+			Timestamp: timeutil.Now(),
+			Tags:      map[string]string{"host": fmt.Sprintf("1.1.1.%d", i)},
+		})
+	}
+	// the failure of one shard cannot be overwritten by other succeed shard in any order
+	for i := 0; i < 10; i++ {
+		err = ch.Write(context.TODO(), &pb.MetricList{Metrics: metrics}, option.WriteConsistency{})
+		assert.Error(t, err)
+	}
+}
+
+func TestDatabaseChannel_Write_ConcurrentWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 2, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	var metrics []*pb.Metric
+	for i := 0; i < 10; i++ {
+		metrics = append(metrics, &pb.Metric{
+			Name:      "cpu",
+			Timestamp: timeutil.Now(),
+			Tags:      map[string]string{"host": fmt.Sprintf("1.1.1.%d", i)},
+		})
+	}
+	var appended atomic.Int32
+	waiting := make(chan struct{}, 2)
+	for shardID := 0; shardID < 2; shardID++ {
+		shardCh := NewMockChannel(ctrl)
+		ch1.shardChannels.Store(int32(shardID), shardCh)
+		shardCh.EXPECT().CheckPressure().Return(nil)
+		shardCh.EXPECT().Append(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ []*pb.Metric, _ option.WriteConsistency) (func(ctx context.Context) error, error) {
+				appended.Inc()
+				return func(ctx context.Context) error {
+					// all shards appended before waiting
+					assert.Equal(t, int32(2), appended.Load())
+					// waits the other shard concurrently, blocks until timeout if waiting shard by shard
+					waiting <- struct{}{}
+					for len(waiting) < 2 {
+						select {
+						case <-ctx.Done():
+							return ctx.Err()
+						case <-time.After(time.Millisecond):
+						}
+					}
+					return nil
+				}, nil
+			})
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	err = ch.Write(ctx, &pb.MetricList{Metrics: metrics}, option.WriteConsistency{})
+	assert.NoError(t, err)
+}
+
+func TestDatabaseChannel_Write_RoutingEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 1, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	shardChs := []*MockChannel{NewMockChannel(ctrl), NewMockChannel(ctrl)}
+	for shardID, shardCh := range shardChs {
+		ch1.shardChannels.Store(int32(shardID), shardCh)
+	}
+	now := timeutil.Now()
+	ch.SetRoutingEpochs(models.RoutingEpochs{
+		{Epoch: 0, NumOfShard: 1, StartTime: 0},
+		{Epoch: 1, NumOfShard: 2, StartTime: now},
+	})
+	tags := map[string]string{"host": "1.1.1.1"}
+	newShardID := xxhash.Sum64String(tag.Concat(tags)) % 2
+	shardMetrics := make(map[int][]*pb.Metric)
+	for shardID := range shardChs {
+		id := shardID
+		shardChs[id].EXPECT().CheckPressure().Return(nil).AnyTimes()
+		shardChs[id].EXPECT().Append(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(metrics []*pb.Metric, _ option.WriteConsistency) (func(ctx context.Context) error, error) {
+				shardMetrics[id] = append(shardMetrics[id], metrics...)
+				return func(ctx context.Context) error { return nil }, nil
+			}).AnyTimes()
+	}
+	history := &pb.Metric{Name: "cpu", Timestamp: now - timeutil.OneHour, Tags: tags}
+	newData := &pb.Metric{Name: "cpu", Timestamp: now + timeutil.OneMinute, Tags: tags}
+	err = ch.Write(context.TODO(), &pb.MetricList{Metrics: []*pb.Metric{history, newData}}, option.WriteConsistency{})
+	assert.NoError(t, err)
+	// history data routes by original num. of shard
+	assert.Contains(t, shardMetrics[0], history)
+	// new data routes by new num. of shard
+	assert.Contains(t, shardMetrics[int(newShardID)], newData)
+}
+
 func TestDatabaseChannel_CreateChannel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -76,6 +76,8 @@ type replicator struct {
 	stopped atomic.Bool
 	// false -> notReady, true -> ready
 	ready atomic.Bool
+	// max sequence acked by remote replica, -1 means nothing acked
+	ackSeq atomic.Int64
 	//storage received cur sequence num
 	//storageCurSeq int64
 	logger *logger.Logger
@@ -90,6 +92,7 @@ func newReplicator(target models.Node, database string, shardID int32,
 		shardID:  shardID,
 		fo:       fo,
 		fct:      fct,
+		ackSeq:   *atomic.NewInt64(-1),
 		logger:   logger.GetLogger("replication", "Replicator"),
 	}
 
@@ -124,9 +127,25 @@ func (r *replicator) ReplicaIndex() int64 {
 	return r.fo.HeadSeq()
 }
 
-// AckIndex returns the index of message replica ack
+// AckIndex returns the index of message replica ack, returns -1 if nothing acked.
+// NOTICE: tail sequence 0 of fanOut means nothing acked, so keeps the ack sequence received from remote replica,
+// then the first sequence(0) can be acked alone.
 func (r *replicator) AckIndex() int64 {
-	return r.fo.TailSeq()
+	ackSeq := r.ackSeq.Load()
+	if tailSeq := r.fo.TailSeq(); tailSeq > 0 && tailSeq > ackSeq {
+		return tailSeq
+	}
+	return ackSeq
+}
+
+// setAckSeq sets the max sequence acked by remote replica
+func (r *replicator) setAckSeq(seq int64) {
+	for {
+		ackSeq := r.ackSeq.Load()
+		if seq <= ackSeq || r.ackSeq.CAS(ackSeq, seq) {
+			return
+		}
+	}
 }
 
 // Stop stops the replication task.
@@ -189,6 +208,7 @@ func (r *replicator) recvLoop() {
 		ack, ok := resp.Ack.(*storage.WriteResponse_AckSeq)
 		if ok {
 			r.fo.Ack(ack.AckSeq)
+			r.setAckSeq(ack.AckSeq)
 		}
 	}
 }
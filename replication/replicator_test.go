@@ -150,7 +150,8 @@ func TestSimple(t *testing.T) {
 	assert.Equal(t, shardID, rep.ShardID())
 	assert.Equal(t, node, rep.Target())
 	assert.True(t, rep.Pending() == 0)
-	assert.True(t, rep.AckIndex() == 0)
+	// nothing acked
+	assert.Equal(t, int64(-1), rep.AckIndex())
 	assert.True(t, rep.ReplicaIndex() == 0)
 
 	rep.Stop()
@@ -528,3 +529,23 @@ func TestReplicator_Loop_panic(t *testing.T) {
 	rep.Stop()
 	close(done1)
 }
+
+func TestReplicator_AckIndex(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	fanOut := queue.NewMockFanOut(ctl)
+	r := &replicator{fo: fanOut, ackSeq: *atomic.NewInt64(-1)}
+	fanOut.EXPECT().TailSeq().Return(int64(0)).Times(3)
+	// nothing acked
+	assert.Equal(t, int64(-1), r.AckIndex())
+	// first sequence acked alone
+	r.setAckSeq(0)
+	assert.Equal(t, int64(0), r.AckIndex())
+	// ignore smaller ack sequence
+	r.setAckSeq(-1)
+	assert.Equal(t, int64(0), r.AckIndex())
+	// ack sequence persisted by fanOut before restart
+	fanOut.EXPECT().TailSeq().Return(int64(10))
+	assert.Equal(t, int64(10), r.AckIndex())
+}
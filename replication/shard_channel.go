@@ -19,6 +19,8 @@ package replication
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path"
 	"strconv"
 	"sync"
@@ -27,6 +29,7 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/queue"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/field"
@@ -36,9 +39,26 @@ import (
 
 // for testing
 var (
-	newFanOutQueue = queue.NewFanOutQueue
+	newFanOutQueue   = queue.NewFanOutQueue
+	ackCheckInterval = 10 * time.Millisecond
 )
 
+// ErrWaitWALTimeout is the error returned when the data isn't persisted in wal before ctx done.
+var ErrWaitWALTimeout = errors.New("wait data persisted in wal timeout")
+
+// walRequest represents the data which need to append into wal queue,
+// notifies the result if done isn't nil.
+type walRequest struct {
+	data []byte
+	done chan walResult
+}
+
+// walResult represents the result of appending data into wal queue, seq is the sequence of data in queue.
+type walResult struct {
+	seq int64
+	err error
+}
+
 // Channel represents a place to buffer the data for a specific cluster, database, shardID.
 type Channel interface {
 	// Database returns the database attribution.
@@ -47,10 +67,16 @@ type Channel interface {
 	ShardID() int32
 	// Startup starts the channel internal goroutine worker which consumes chan data and writes wal
 	Startup()
-	// Write writes the data into the channel, ErrCanceled is returned when the channel is canceled before
-	// data is wrote successfully.
+	// Write writes the metrics into the channel, then waits the data acknowledged based on write consistency,
+	// ErrCanceled is returned when the channel is canceled before data is wrote successfully.
 	// Concurrent safe.
-	Write(metric *field.Metric) error
+	Write(ctx context.Context, metrics []*field.Metric, consistency option.WriteConsistency) error
+	// Append appends the metrics into the channel, returns the function which waits the data acknowledged
+	// based on write consistency, so that the caller can append into multi channels before waiting.
+	// Concurrent safe.
+	Append(metrics []*field.Metric, consistency option.WriteConsistency) (wait func(ctx context.Context) error, err error)
+	// CheckPressure checks if the replication queue is overloaded, returns OverloadedError if overloaded.
+	CheckPressure() error
 	// GetOrCreateReplicator get a existed or creates a new replicator for target.
 	// Concurrent safe.
 	GetOrCreateReplicator(target models.Node) (Replicator, error)
@@ -70,7 +96,7 @@ type channel struct {
 	// underlying storage for written data
 	q queue.FanOutQueue
 	// chanel to convert multiple goroutine write to single goroutine write to FanOutQueue
-	ch chan []byte
+	ch chan *walRequest
 
 	chunk Chunk // buffer current write metric for compress
 
@@ -82,6 +108,10 @@ type channel struct {
 	flushInterval time.Duration
 	//buffer size limit for batch bytes before append to queue
 	bufferSizeLimit int
+	// backpressure limits of replication queue
+	maxPending    int64
+	maxQueueUsage float64
+	retryAfter    time.Duration
 
 	// target -> replicator map
 	replicatorMap sync.Map
@@ -111,6 +141,10 @@ func newChannel(
 	if cfg.BufferSize > 0 {
 		bufferSize = cfg.BufferSize
 	}
+	retryAfter := defaultRetryAfter
+	if cfg.RetryAfter > 0 {
+		retryAfter = cfg.RetryAfter.Duration()
+	}
 
 	c := &channel{
 		ctx:                cxt,
@@ -119,12 +153,15 @@ func newChannel(
 		database:           database,
 		shardID:            shardID,
 		q:                  q,
-		ch:                 make(chan []byte, 2),
+		ch:                 make(chan *walRequest, 2),
 		chunk:              newChunk(bufferSize),
 		lastFlushTime:      time.Now(),
 		checkFlushInterval: cfg.CheckFlushInterval.Duration(),
 		flushInterval:      cfg.FlushInterval.Duration(),
 		bufferSizeLimit:    cfg.BufferSizeInBytes(),
+		maxPending:         cfg.MaxPending,
+		maxQueueUsage:      cfg.MaxQueueUsage,
+		retryAfter:         retryAfter,
 		logger:             logger.GetLogger("replication", "Channel"),
 	}
 
@@ -182,16 +219,53 @@ func (c *channel) Targets() []models.Node {
 	return nodes
 }
 
-// Write writes the data into the channel, ErrCanceled is returned when the ctx is canceled before
-// data is wrote successfully.
+// Write writes the metrics into the channel, then waits the data acknowledged based on write consistency:
+// 1. buffered: returns after the metrics are appended into chunk
+// 2. wal: returns after the chunk which contains the metrics is persisted in wal queue
+// 3. replicated:N: returns after N replicas have acked the sequence of the chunk
+// ErrCanceled is returned when the channel is canceled before data is wrote successfully.
 // Concurrent safe.
-func (c *channel) Write(metric *field.Metric) error {
+func (c *channel) Write(ctx context.Context, metrics []*field.Metric, consistency option.WriteConsistency) error {
+	wait, err := c.Append(metrics, consistency)
+	if err != nil {
+		return err
+	}
+	return wait(ctx)
+}
+
+// Append appends the metrics into the channel, returns the function which waits the data acknowledged
+// based on write consistency, so that the caller can append into multi channels before waiting.
+// Concurrent safe.
+func (c *channel) Append(metrics []*field.Metric,
+	consistency option.WriteConsistency,
+) (wait func(ctx context.Context) error, err error) {
+	needWAL := consistency.NeedWAL()
+	dones, err := c.append(metrics, needWAL)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) error {
+		if !needWAL {
+			return nil
+		}
+		seq, err := c.waitWAL(ctx, dones)
+		if err != nil {
+			return err
+		}
+		if consistency.Level == option.ConsistencyReplicated {
+			return c.waitReplicas(ctx, seq, consistency.Replicas)
+		}
+		return nil
+	}, nil
+}
+
+// append appends the metrics into chunk, sends the chunk into wal queue if chunk is full,
+// if flush is true, sends the remaining data of chunk and returns the notifiers of all sent chunks.
+func (c *channel) append(metrics []*field.Metric, flush bool) (dones []chan walResult, err error) {
 	c.lock4write.Lock()
 	defer c.lock4write.Unlock()
 
-	c.chunk.Append(metric)
-
-	if c.chunk.IsFull() {
+	send := func() error {
 		data, err := c.chunk.MarshalBinary()
 		if err != nil {
 			return err
@@ -199,14 +273,89 @@ func (c *channel) Write(metric *field.Metric) error {
 		if len(data) == 0 {
 			return nil
 		}
+		req := &walRequest{data: data}
+		if flush {
+			req.done = make(chan walResult, 1)
+			dones = append(dones, req.done)
+		}
+		select {
+		case c.ch <- req:
+			return nil
+		case <-c.ctx.Done():
+			return ErrCanceled
+		}
+	}
+	for _, metric := range metrics {
+		c.chunk.Append(metric)
+		if c.chunk.IsFull() {
+			if err := send(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if flush && !c.chunk.IsEmpty() {
+		if err := send(); err != nil {
+			return nil, err
+		}
+	}
+	return dones, nil
+}
+
+// waitWAL waits all chunks persisted in wal queue, returns the max sequence of chunks(-1 if no chunk).
+func (c *channel) waitWAL(ctx context.Context, dones []chan walResult) (int64, error) {
+	seq := int64(-1)
+	for _, done := range dones {
 		select {
-		case c.ch <- data:
+		case result := <-done:
+			if result.err != nil {
+				return -1, fmt.Errorf("append data into wal error: %s", result.err)
+			}
+			seq = result.seq
+		case <-ctx.Done():
+			return -1, ErrWaitWALTimeout
+		case <-c.ctx.Done():
+			return -1, ErrCanceled
+		}
+	}
+	return seq, nil
+}
+
+// waitReplicas waits the number of replicas which have acked the sequence reaches the required replicas.
+func (c *channel) waitReplicas(ctx context.Context, seq int64, replicas int) error {
+	if seq < 0 {
+		return nil
+	}
+	if targets := len(c.Targets()); targets < replicas {
+		return fmt.Errorf("not enough replicas, required: %d, actual: %d", replicas, targets)
+	}
+	ticker := time.NewTicker(ackCheckInterval)
+	defer ticker.Stop()
+	for {
+		acked := c.ackedReplicas(seq)
+		if acked >= replicas {
 			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("wait replicas ack timeout, acked: %d, required: %d", acked, replicas)
 		case <-c.ctx.Done():
 			return ErrCanceled
 		}
 	}
-	return nil
+}
+
+// ackedReplicas returns the number of replicas which have acked the sequence
+func (c *channel) ackedReplicas(seq int64) (acked int) {
+	c.replicatorMap.Range(func(key, value interface{}) bool {
+		rep, _ := value.(Replicator)
+		// ack index is -1 if nothing acked
+		if rep.AckIndex() >= seq {
+			acked++
+		}
+		return true
+	})
+	return
 }
 
 // initAppendTask starts a goroutine to consume data from ch and batch append to q.
@@ -224,11 +373,8 @@ func (c *channel) writePendingBeforeClose() {
 	wait.Add(1)
 	go func() {
 		// try to drain data from chan
-		for data := range c.ch {
-			err := c.q.Put(data)
-			if err != nil {
-				c.logger.Error("append to queue err", logger.Error(err))
-			}
+		for req := range c.ch {
+			c.put(req)
 		}
 		wait.Done()
 	}()
@@ -270,6 +416,18 @@ func (c *channel) flushChunk() {
 	}
 }
 
+// put appends the data of request into queue, notifies the result with the sequence of data if need.
+// NOTICE: must be invoked by the append goroutine, because the sequence is the last sequence of queue.
+func (c *channel) put(req *walRequest) {
+	err := c.q.Put(req.data)
+	if err != nil {
+		c.logger.Error("append to queue err", logger.Error(err))
+	}
+	if req.done != nil {
+		req.done <- walResult{seq: c.q.HeadSeq() - 1, err: err}
+	}
+}
+
 // writeWAL consumes data from chan, then appends the data into queue
 func (c *channel) writeWAL() {
 	// on avg 2 * limit could avoid buffer grow
@@ -279,11 +437,8 @@ func (c *channel) writeWAL() {
 		select {
 		case <-c.ctx.Done():
 			return
-		case data := <-c.ch:
-			err := c.q.Put(data)
-			if err != nil {
-				c.logger.Error("append to queue err", logger.Error(err))
-			}
+		case req := <-c.ch:
+			c.put(req)
 		case <-ticker.C:
 			// check
 			c.checkFlush()
@@ -25,8 +25,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/queue"
 	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/field"
@@ -103,9 +105,9 @@ func TestChannel_Write(t *testing.T) {
 			Value: 1.0,
 		}},
 	}
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.NoError(t, err)
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.NoError(t, err)
 
 	cancel()
@@ -118,12 +120,12 @@ func TestChannel_Write(t *testing.T) {
 	chunk := NewMockChunk(ctrl)
 	ch1.chunk = chunk
 	// make sure chan is full
-	ch1.ch <- []byte{1, 2}
-	ch1.ch <- []byte{1, 2}
+	ch1.ch <- &walRequest{data: []byte{1, 2}}
+	ch1.ch <- &walRequest{data: []byte{1, 2}}
 	chunk.EXPECT().Append(gomock.Any())
 	chunk.EXPECT().IsFull().Return(true)
 	chunk.EXPECT().MarshalBinary().Return([]byte{1, 2, 3}, nil)
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.Error(t, err)
 	time.Sleep(time.Millisecond * 500)
 }
@@ -151,7 +153,7 @@ func TestChannel_checkFlush(t *testing.T) {
 			Value: 1.0,
 		}},
 	}
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.NoError(t, err)
 
 	time.Sleep(time.Second)
@@ -174,11 +176,11 @@ func TestChannel_write_pending_before_close(t *testing.T) {
 			Value: 1.0,
 		}},
 	}
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.NoError(t, err)
 
 	ch1 := ch.(*channel)
-	ch1.ch <- []byte{1, 2, 3}
+	ch1.ch <- &walRequest{data: []byte{1, 2, 3}}
 	fanOut := queue.NewMockFanOutQueue(ctrl)
 	fanOut.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	ch1.q = fanOut
@@ -207,13 +209,13 @@ func TestChannel_chunk_marshal_err(t *testing.T) {
 	chunk.EXPECT().Append(gomock.Any())
 	chunk.EXPECT().IsFull().Return(true)
 	chunk.EXPECT().MarshalBinary().Return(nil, fmt.Errorf("err"))
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.Error(t, err)
 
 	chunk.EXPECT().Append(gomock.Any())
 	chunk.EXPECT().IsFull().Return(true)
 	chunk.EXPECT().MarshalBinary().Return(nil, nil)
-	err = ch.Write(metric)
+	err = ch.Write(context.TODO(), []*pb.Metric{metric}, option.WriteConsistency{})
 	assert.NoError(t, err)
 
 	chunk.EXPECT().MarshalBinary().Return(nil, fmt.Errorf("err"))
@@ -221,3 +223,102 @@ func TestChannel_chunk_marshal_err(t *testing.T) {
 	chunk.EXPECT().MarshalBinary().Return(nil, nil)
 	ch1.flushChunk()
 }
+
+func TestChannel_Write_Consistency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		ackCheckInterval = 10 * time.Millisecond
+		ctrl.Finish()
+	}()
+	ackCheckInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	ch, err := newChannel(ctx, replicationConfig, "database", 1, nil)
+	assert.NoError(t, err)
+	ch.Startup()
+	ch1 := ch.(*channel)
+	fanout := queue.NewMockFanOutQueue(ctrl)
+	ch1.q = fanout
+
+	metrics := []*pb.Metric{{
+		Name:      "cpu",
+		Timestamp: timeutil.Now(),
+		Fields: []*pb.Field{{
+			Name:  "f1",
+			Type:  pb.FieldType_Sum,
+			Value: 1.0,
+		}},
+	}}
+	wal := option.WriteConsistency{Level: option.ConsistencyWAL}
+	replicated := option.WriteConsistency{Level: option.ConsistencyReplicated, Replicas: 2}
+	// case 1: no data
+	assert.NoError(t, ch.Write(context.TODO(), nil, replicated))
+	// case 2: append into wal err
+	fanout.EXPECT().Put(gomock.Any()).Return(queue.ErrExceedingTotalSizeLimit)
+	fanout.EXPECT().HeadSeq().Return(int64(10))
+	assert.Error(t, ch.Write(context.TODO(), metrics, wal))
+	// case 3: persisted in wal
+	fanout.EXPECT().Put(gomock.Any()).Return(nil).AnyTimes()
+	fanout.EXPECT().HeadSeq().Return(int64(11)).AnyTimes()
+	assert.NoError(t, ch.Write(context.TODO(), metrics, wal))
+	// case 4: not enough replicas
+	rep1 := NewMockReplicator(ctrl)
+	// replicators are stopped when channel closed
+	rep1.EXPECT().Stop().AnyTimes()
+	ch1.replicatorMap.Store(models.Node{IP: "1.1.1.1", Port: 2000}, rep1)
+	assert.Error(t, ch.Write(context.TODO(), metrics, replicated))
+	// case 5: wait replicas ack timeout
+	rep2 := NewMockReplicator(ctrl)
+	rep2.EXPECT().Stop().AnyTimes()
+	ch1.replicatorMap.Store(models.Node{IP: "1.1.1.2", Port: 2000}, rep2)
+	rep1.EXPECT().AckIndex().Return(int64(10)).AnyTimes()
+	rep2Ack := atomic.NewInt64(5)
+	rep2.EXPECT().AckIndex().DoAndReturn(func() int64 { return rep2Ack.Load() }).AnyTimes()
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.TODO(), 5*time.Millisecond)
+	defer timeoutCancel()
+	assert.Error(t, ch.Write(timeoutCtx, metrics, replicated))
+	// case 6: replicas acked
+	rep2Ack.Store(10)
+	assert.NoError(t, ch.Write(context.TODO(), metrics, replicated))
+	// case 7: wait wal timeout
+	timeoutCtx, timeoutCancel = context.WithCancel(context.TODO())
+	timeoutCancel()
+	_, err = ch1.waitWAL(timeoutCtx, []chan walResult{make(chan walResult)})
+	assert.Equal(t, ErrWaitWALTimeout, err)
+}
+
+func TestChannel_Write_Replicated_FirstSequence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		ackCheckInterval = 10 * time.Millisecond
+		ctrl.Finish()
+	}()
+	ackCheckInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	ch, err := newChannel(ctx, replicationConfig, "database", 1, nil)
+	assert.NoError(t, err)
+	ch.Startup()
+	ch1 := ch.(*channel)
+	// empty queue, first data is sequence 0
+	fanout := queue.NewMockFanOutQueue(ctrl)
+	ch1.q = fanout
+	fanout.EXPECT().Put(gomock.Any()).Return(nil)
+	fanout.EXPECT().HeadSeq().Return(int64(1))
+	for _, ip := range []string{"1.1.1.1", "1.1.1.2"} {
+		rep := NewMockReplicator(ctrl)
+		rep.EXPECT().Stop().AnyTimes()
+		rep.EXPECT().AckIndex().Return(int64(0)).AnyTimes()
+		ch1.replicatorMap.Store(models.Node{IP: ip, Port: 2000}, rep)
+	}
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.TODO(), time.Second)
+	defer timeoutCancel()
+	err = ch.Write(timeoutCtx, []*pb.Metric{{
+		Name:      "cpu",
+		Timestamp: timeutil.Now(),
+		Fields:    []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: 1.0}},
+	}}, option.WriteConsistency{Level: option.ConsistencyReplicated, Replicas: 2})
+	assert.NoError(t, err)
+}
@@ -76,16 +76,19 @@ type RequestType int32
 const (
 	RequestType_Data     RequestType = 0
 	RequestType_Metadata RequestType = 1
+	RequestType_Cancel   RequestType = 2
 )
 
 var RequestType_name = map[int32]string{
 	0: "Data",
 	1: "Metadata",
+	2: "Cancel",
 }
 
 var RequestType_value = map[string]int32{
 	"Data":     0,
 	"Metadata": 1,
+	"Cancel":   2,
 }
 
 func (x RequestType) String() string {
@@ -393,38 +396,39 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 494 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xcd, 0x6a, 0xdb, 0x40,
-	0x10, 0xf6, 0xda, 0x8e, 0x6c, 0x8f, 0x84, 0x11, 0x93, 0x50, 0x84, 0x29, 0xc6, 0x88, 0x16, 0x44,
-	0x0e, 0xa6, 0x38, 0xb4, 0x34, 0x39, 0x16, 0xb7, 0xc4, 0xd4, 0x71, 0xcb, 0xc6, 0xa5, 0xe7, 0x8d,
-	0x35, 0x49, 0xd5, 0xc8, 0x92, 0xaa, 0xdd, 0x04, 0xf4, 0x1c, 0xbd, 0xf4, 0x69, 0x7a, 0xee, 0xb1,
-	0x8f, 0x50, 0xdc, 0x17, 0x29, 0xbb, 0x92, 0xff, 0x1a, 0x72, 0xdb, 0xef, 0x67, 0x67, 0xe7, 0x1b,
-	0x66, 0xc1, 0x59, 0xa4, 0xcb, 0x65, 0x9a, 0x0c, 0xb3, 0x3c, 0x55, 0x29, 0x5a, 0x25, 0xf2, 0x57,
-	0x0c, 0xec, 0xb9, 0x90, 0xb7, 0x9c, 0xbe, 0xdd, 0x91, 0x54, 0x78, 0x04, 0x07, 0x5f, 0xd3, 0xab,
-	0xc9, 0xd8, 0x63, 0x03, 0x16, 0x34, 0x78, 0x09, 0xd0, 0x07, 0x27, 0x13, 0x39, 0x25, 0x4a, 0x5b,
-	0x27, 0x63, 0xaf, 0x3e, 0x60, 0x41, 0x87, 0xef, 0x71, 0xf8, 0x0c, 0x9a, 0xaa, 0xc8, 0xc8, 0x6b,
-	0x0c, 0x58, 0xd0, 0x1d, 0xb9, 0xc3, 0xea, 0x39, 0xad, 0xce, 0x8b, 0x8c, 0xb8, 0x51, 0xf1, 0x25,
-	0xd8, 0x79, 0xf9, 0x94, 0x26, 0xbd, 0xa6, 0x31, 0x1f, 0xae, 0xcd, 0x7c, 0x2b, 0xf1, 0x5d, 0x9f,
-	0x69, 0xe0, 0x4b, 0x21, 0xa3, 0x85, 0x88, 0x3f, 0xc6, 0x22, 0xf1, 0x0e, 0x06, 0x2c, 0x70, 0xf8,
-	0x1e, 0x87, 0x1e, 0xb4, 0x32, 0x51, 0xc4, 0xa9, 0x08, 0x3d, 0xcb, 0xc8, 0x6b, 0xe8, 0xff, 0x64,
-	0xe0, 0x94, 0x21, 0x65, 0x96, 0x26, 0x92, 0x1e, 0x49, 0xf9, 0x04, 0xac, 0xbd, 0x7c, 0x15, 0xc2,
-	0xa7, 0xd0, 0x59, 0xa4, 0xcb, 0x2c, 0x26, 0x45, 0xa1, 0x89, 0xd7, 0xe6, 0x5b, 0x42, 0xdf, 0xa2,
-	0x3c, 0xbf, 0x90, 0x37, 0x26, 0x4c, 0x87, 0x57, 0x08, 0x7b, 0xd0, 0x96, 0x94, 0x84, 0xf3, 0x68,
-	0x49, 0xa6, 0xdd, 0x06, 0xdf, 0xe0, 0xc7, 0x5b, 0xd5, 0x9d, 0x49, 0x25, 0x94, 0xf4, 0x5a, 0x86,
-	0x2f, 0x81, 0x3f, 0x85, 0xae, 0xbe, 0x77, 0x49, 0x79, 0x44, 0x72, 0x1a, 0x49, 0x85, 0x67, 0xd0,
-	0x55, 0x7b, 0x8c, 0xc7, 0x06, 0x8d, 0xc0, 0x1e, 0xe1, 0x66, 0xee, 0x1b, 0x95, 0xff, 0xe7, 0xf4,
-	0xbf, 0x33, 0x80, 0xad, 0x8c, 0x08, 0x4d, 0x25, 0x6e, 0xa4, 0x99, 0x45, 0x87, 0x9b, 0x33, 0xbe,
-	0x02, 0xeb, 0x3a, 0xa2, 0x38, 0x94, 0x5e, 0xdd, 0x94, 0xed, 0x3f, 0x2c, 0x3b, 0x7c, 0x67, 0x0c,
-	0x6f, 0x13, 0x95, 0x17, 0xbc, 0x72, 0xf7, 0x4e, 0xc1, 0xde, 0xa1, 0xd1, 0x85, 0xc6, 0x2d, 0x15,
-	0x55, 0x65, 0x7d, 0xd4, 0xf9, 0xee, 0x45, 0x7c, 0x47, 0x66, 0xc4, 0x0e, 0x2f, 0xc1, 0x59, 0xfd,
-	0x35, 0x3b, 0x3e, 0x81, 0xf6, 0x7a, 0x57, 0xd0, 0x86, 0xd6, 0xa7, 0xd9, 0xfb, 0xd9, 0x87, 0xcf,
-	0x33, 0xb7, 0x86, 0x2e, 0x38, 0x93, 0x44, 0x51, 0xbe, 0xa4, 0x30, 0x12, 0x8a, 0x5c, 0x86, 0x6d,
-	0x68, 0x4e, 0x49, 0x5c, 0xbb, 0xf5, 0xe3, 0xe7, 0x60, 0xef, 0xec, 0x8c, 0x16, 0xc6, 0x42, 0x09,
-	0xb7, 0x86, 0x0e, 0xb4, 0x2f, 0x48, 0x89, 0x50, 0x23, 0x36, 0x3a, 0x2f, 0x97, 0xfc, 0x92, 0xf2,
-	0xfb, 0x68, 0x41, 0x78, 0x0a, 0xd6, 0xb9, 0x48, 0xc2, 0x98, 0xf0, 0x70, 0x77, 0x4d, 0xab, 0x4a,
-	0xbd, 0xa3, 0x7d, 0xb2, 0xdc, 0x19, 0xbf, 0x16, 0xb0, 0x17, 0xec, 0x8d, 0xfb, 0x6b, 0xd5, 0x67,
-	0xbf, 0x57, 0x7d, 0xf6, 0x67, 0xd5, 0x67, 0x3f, 0xfe, 0xf6, 0x6b, 0x57, 0x96, 0xf9, 0x50, 0x27,
-	0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xdb, 0x0d, 0xee, 0x09, 0x60, 0x03, 0x00, 0x00,
+	// 499 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xce, 0x26, 0xa9, 0x93, 0x8c, 0xad, 0xc8, 0x9a, 0x56, 0xc8, 0x8a, 0x50, 0x14, 0x59, 0x1c,
+	0xac, 0x1e, 0x22, 0x48, 0x05, 0xa2, 0x3d, 0x42, 0x40, 0x8d, 0x48, 0x03, 0xda, 0x06, 0x71, 0xde,
+	0xc6, 0xd3, 0x62, 0xea, 0xd8, 0xc6, 0xbb, 0xad, 0xe4, 0xe7, 0xe0, 0xc2, 0xd3, 0x70, 0xe6, 0xc8,
+	0x23, 0xa0, 0xf0, 0x22, 0x68, 0xd7, 0xce, 0x1f, 0xa8, 0xb7, 0xfd, 0x7e, 0x76, 0x76, 0xbe, 0xd1,
+	0x2c, 0x38, 0x8b, 0x74, 0xb9, 0x4c, 0x93, 0x61, 0x96, 0xa7, 0x2a, 0x45, 0xab, 0x44, 0xfe, 0x8a,
+	0x81, 0x3d, 0x17, 0xf2, 0x96, 0xd3, 0xd7, 0x3b, 0x92, 0x0a, 0x8f, 0xe0, 0xe0, 0x4b, 0x7a, 0x35,
+	0x19, 0x7b, 0x6c, 0xc0, 0x82, 0x06, 0x2f, 0x01, 0xfa, 0xe0, 0x64, 0x22, 0xa7, 0x44, 0x69, 0xeb,
+	0x64, 0xec, 0xd5, 0x07, 0x2c, 0xe8, 0xf0, 0x3d, 0x0e, 0x9f, 0x40, 0x53, 0x15, 0x19, 0x79, 0x8d,
+	0x01, 0x0b, 0xba, 0x23, 0x77, 0x58, 0x3d, 0xa7, 0xd5, 0x79, 0x91, 0x11, 0x37, 0x2a, 0x3e, 0x07,
+	0x3b, 0x2f, 0x9f, 0xd2, 0xa4, 0xd7, 0x34, 0xe6, 0xc3, 0xb5, 0x99, 0x6f, 0x25, 0xbe, 0xeb, 0x33,
+	0x0d, 0x7c, 0x2e, 0x64, 0xb4, 0x10, 0xf1, 0x87, 0x58, 0x24, 0xde, 0xc1, 0x80, 0x05, 0x0e, 0xdf,
+	0xe3, 0xd0, 0x83, 0x56, 0x26, 0x8a, 0x38, 0x15, 0xa1, 0x67, 0x19, 0x79, 0x0d, 0xfd, 0x1f, 0x0c,
+	0x9c, 0x32, 0xa4, 0xcc, 0xd2, 0x44, 0xd2, 0x03, 0x29, 0x1f, 0x81, 0xb5, 0x97, 0xaf, 0x42, 0xf8,
+	0x18, 0x3a, 0x8b, 0x74, 0x99, 0xc5, 0xa4, 0x28, 0x34, 0xf1, 0xda, 0x7c, 0x4b, 0xe8, 0x5b, 0x94,
+	0xe7, 0x17, 0xf2, 0xc6, 0x84, 0xe9, 0xf0, 0x0a, 0x61, 0x0f, 0xda, 0x92, 0x92, 0x70, 0x1e, 0x2d,
+	0xc9, 0xb4, 0xdb, 0xe0, 0x1b, 0xfc, 0x70, 0xab, 0xba, 0x33, 0xa9, 0x84, 0x92, 0x5e, 0xcb, 0xf0,
+	0x25, 0xf0, 0xa7, 0xd0, 0xd5, 0xf7, 0x2e, 0x29, 0x8f, 0x48, 0x4e, 0x23, 0xa9, 0xf0, 0x0c, 0xba,
+	0x6a, 0x8f, 0xf1, 0xd8, 0xa0, 0x11, 0xd8, 0x23, 0xdc, 0xcc, 0x7d, 0xa3, 0xf2, 0x7f, 0x9c, 0xfe,
+	0x37, 0x06, 0xb0, 0x95, 0x11, 0xa1, 0xa9, 0xc4, 0x8d, 0x34, 0xb3, 0xe8, 0x70, 0x73, 0xc6, 0x17,
+	0x60, 0x5d, 0x47, 0x14, 0x87, 0xd2, 0xab, 0x9b, 0xb2, 0xfd, 0xff, 0xcb, 0x0e, 0xdf, 0x1a, 0xc3,
+	0x9b, 0x44, 0xe5, 0x05, 0xaf, 0xdc, 0xbd, 0x53, 0xb0, 0x77, 0x68, 0x74, 0xa1, 0x71, 0x4b, 0x45,
+	0x55, 0x59, 0x1f, 0x75, 0xbe, 0x7b, 0x11, 0xdf, 0x91, 0x19, 0xb1, 0xc3, 0x4b, 0x70, 0x56, 0x7f,
+	0xc9, 0x8e, 0x4f, 0xa0, 0xbd, 0xde, 0x15, 0xb4, 0xa1, 0xf5, 0x71, 0xf6, 0x6e, 0xf6, 0xfe, 0xd3,
+	0xcc, 0xad, 0xa1, 0x0b, 0xce, 0x24, 0x51, 0x94, 0x2f, 0x29, 0x8c, 0x84, 0x22, 0x97, 0x61, 0x1b,
+	0x9a, 0x53, 0x12, 0xd7, 0x6e, 0xfd, 0xf8, 0x19, 0xd8, 0x3b, 0x3b, 0xa3, 0x85, 0xb1, 0x50, 0xc2,
+	0xad, 0xa1, 0x03, 0xed, 0x0b, 0x52, 0x22, 0xd4, 0x88, 0x21, 0x80, 0xf5, 0x5a, 0x24, 0x0b, 0x8a,
+	0xdd, 0xfa, 0xe8, 0xbc, 0x5c, 0xf8, 0x4b, 0xca, 0xef, 0xa3, 0x05, 0xe1, 0x29, 0x58, 0xe7, 0x22,
+	0x09, 0x63, 0xc2, 0xc3, 0xdd, 0x95, 0xad, 0xaa, 0xf6, 0x8e, 0xf6, 0xc9, 0x72, 0x7f, 0xfc, 0x5a,
+	0xc0, 0x9e, 0xb2, 0x57, 0xee, 0xcf, 0x55, 0x9f, 0xfd, 0x5a, 0xf5, 0xd9, 0xef, 0x55, 0x9f, 0x7d,
+	0xff, 0xd3, 0xaf, 0x5d, 0x59, 0xe6, 0x73, 0x9d, 0xfc, 0x1d, 0x00, 0x06, 0x44, 0x1f, 0x8a, 0x6c,
+	0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -43,11 +43,13 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type FieldType int32
 
 const (
-	FieldType_UNKNOWN FieldType = 0
-	FieldType_Sum     FieldType = 1
-	FieldType_Min     FieldType = 2
-	FieldType_Max     FieldType = 3
-	FieldType_Gauge   FieldType = 4
+	FieldType_UNKNOWN   FieldType = 0
+	FieldType_Sum       FieldType = 1
+	FieldType_Min       FieldType = 2
+	FieldType_Max       FieldType = 3
+	FieldType_Gauge     FieldType = 4
+	FieldType_Histogram FieldType = 5
+	FieldType_Summary   FieldType = 6
 )
 
 var FieldType_name = map[int32]string{
@@ -56,14 +58,18 @@ var FieldType_name = map[int32]string{
 	2: "Min",
 	3: "Max",
 	4: "Gauge",
+	5: "Histogram",
+	6: "Summary",
 }
 
 var FieldType_value = map[string]int32{
-	"UNKNOWN": 0,
-	"Sum":     1,
-	"Min":     2,
-	"Max":     3,
-	"Gauge":   4,
+	"UNKNOWN":   0,
+	"Sum":       1,
+	"Min":       2,
+	"Max":       3,
+	"Gauge":     4,
+	"Histogram": 5,
+	"Summary":   6,
 }
 
 func (x FieldType) String() string {
@@ -282,29 +288,30 @@ func init() {
 func init() { proto.RegisterFile("field.proto", fileDescriptor_04234ff7fdd53e6e) }
 
 var fileDescriptor_04234ff7fdd53e6e = []byte{
-	// 345 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x51, 0xcd, 0x4a, 0xf3, 0x40,
-	0x14, 0xed, 0xe4, 0xaf, 0x5f, 0x6e, 0x3f, 0x65, 0x18, 0x04, 0x87, 0x22, 0x21, 0x94, 0x82, 0x41,
-	0xa1, 0x8b, 0x8a, 0x28, 0x2e, 0x5c, 0x08, 0xfe, 0x80, 0xb6, 0xc2, 0x58, 0xe9, 0x7a, 0xac, 0x63,
-	0x0d, 0x36, 0x6d, 0xe8, 0x4c, 0xc5, 0xec, 0x7c, 0x0c, 0x1f, 0xc9, 0xa5, 0x8f, 0x20, 0xf5, 0x45,
-	0x64, 0x26, 0x69, 0x52, 0x37, 0xc9, 0x39, 0xf7, 0xdc, 0x73, 0x32, 0x27, 0x03, 0x8d, 0xa7, 0x58,
-	0x4c, 0x1e, 0x3b, 0xe9, 0x7c, 0xa6, 0x66, 0xc4, 0x35, 0xa4, 0x75, 0x08, 0xd0, 0x13, 0x6a, 0x1e,
-	0x8f, 0x6e, 0x62, 0xa9, 0xc8, 0x2e, 0xd4, 0x13, 0xc3, 0x24, 0xb5, 0x42, 0x3b, 0x6a, 0x74, 0x37,
-	0x3a, 0xb9, 0x27, 0xdf, 0x61, 0x2b, 0xb5, 0xf5, 0x6e, 0x81, 0x97, 0xcf, 0xc8, 0x0e, 0xf8, 0x53,
-	0x9e, 0x08, 0x99, 0xf2, 0x91, 0xa0, 0x28, 0x44, 0x91, 0xcf, 0xaa, 0x01, 0x21, 0xe0, 0x68, 0x42,
-	0x2d, 0x23, 0x18, 0xac, 0x1d, 0x2a, 0x4e, 0x84, 0x54, 0x3c, 0x49, 0xa9, 0x1d, 0xa2, 0xc8, 0x66,
-	0xd5, 0x80, 0xec, 0x83, 0xa3, 0xf8, 0x58, 0x52, 0xc7, 0x1c, 0x60, 0xfb, 0xcf, 0x01, 0x3a, 0x03,
-	0x3e, 0x96, 0xe7, 0x53, 0x35, 0xcf, 0x98, 0x59, 0x22, 0x4d, 0xf8, 0xa7, 0xdf, 0x57, 0x5c, 0x3e,
-	0x53, 0x37, 0x44, 0x91, 0xc3, 0x4a, 0x4e, 0xda, 0xe0, 0x19, 0xaf, 0xa4, 0x9e, 0x89, 0xfa, 0x5f,
-	0x44, 0x5d, 0xe8, 0x27, 0x2b, 0xb4, 0xe6, 0x11, 0xf8, 0x65, 0x28, 0xc1, 0x60, 0xbf, 0x88, 0xac,
-	0x68, 0xa1, 0x21, 0xd9, 0x02, 0xf7, 0x95, 0x4f, 0x16, 0xab, 0x02, 0x39, 0x39, 0xb1, 0x8e, 0x51,
-	0x6b, 0x08, 0xae, 0x49, 0x2a, 0x2b, 0xa2, 0xb5, 0x8a, 0x6d, 0x70, 0x54, 0x96, 0xe6, 0xae, 0xcd,
-	0x2e, 0x5e, 0xff, 0xf2, 0x20, 0x4b, 0x05, 0x33, 0x6a, 0x15, 0xae, 0x7f, 0x02, 0x2a, 0xc2, 0xf7,
-	0x4e, 0xc1, 0x2f, 0x17, 0x49, 0x03, 0xea, 0xf7, 0xfd, 0xeb, 0xfe, 0xed, 0xb0, 0x8f, 0x6b, 0xa4,
-	0x0e, 0xf6, 0xdd, 0x22, 0xc1, 0x48, 0x83, 0x5e, 0x3c, 0xc5, 0x96, 0x01, 0xfc, 0x0d, 0xdb, 0xc4,
-	0x07, 0xf7, 0x92, 0x2f, 0xc6, 0x02, 0x3b, 0x67, 0xf8, 0x73, 0x19, 0xa0, 0xaf, 0x65, 0x80, 0xbe,
-	0x97, 0x01, 0xfa, 0xf8, 0x09, 0x6a, 0x0f, 0x9e, 0xb9, 0xf2, 0x83, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xd2, 0xc7, 0x09, 0xaf, 0x01, 0x02, 0x00, 0x00,
+	// 363 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x52, 0xdd, 0x4a, 0xe3, 0x40,
+	0x18, 0xed, 0xe4, 0xaf, 0x9b, 0xaf, 0xdb, 0x65, 0x18, 0x16, 0x36, 0x94, 0x25, 0x84, 0x52, 0xd8,
+	0xb0, 0x42, 0x2f, 0x2a, 0xa2, 0x78, 0x29, 0xa8, 0x05, 0x6d, 0x85, 0xb4, 0x52, 0xf0, 0x6e, 0xac,
+	0x63, 0x0c, 0x76, 0xda, 0x90, 0x99, 0x88, 0xb9, 0xf3, 0x31, 0x7c, 0x24, 0x2f, 0x7d, 0x04, 0xa9,
+	0x2f, 0x22, 0x33, 0x49, 0xd3, 0x7a, 0x93, 0x9c, 0xf3, 0x9d, 0xef, 0x9c, 0xcc, 0x09, 0x03, 0xad,
+	0xfb, 0x84, 0x2d, 0xee, 0xfa, 0x69, 0xb6, 0x92, 0x2b, 0x62, 0x6b, 0xd2, 0x3d, 0x00, 0x18, 0x31,
+	0x99, 0x25, 0xf3, 0xcb, 0x44, 0x48, 0xf2, 0x0f, 0x9a, 0x5c, 0x33, 0xe1, 0x19, 0x81, 0x19, 0xb6,
+	0x06, 0xed, 0x7e, 0xe9, 0x29, 0x77, 0xa2, 0x8d, 0xda, 0x7d, 0x31, 0xc0, 0x29, 0x67, 0xe4, 0x2f,
+	0xb8, 0x4b, 0xca, 0x99, 0x48, 0xe9, 0x9c, 0x79, 0x28, 0x40, 0xa1, 0x1b, 0x6d, 0x07, 0x84, 0x80,
+	0xa5, 0x88, 0x67, 0x68, 0x41, 0x63, 0xe5, 0x90, 0x09, 0x67, 0x42, 0x52, 0x9e, 0x7a, 0x66, 0x80,
+	0x42, 0x33, 0xda, 0x0e, 0xc8, 0x1e, 0x58, 0x92, 0xc6, 0xc2, 0xb3, 0xf4, 0x01, 0xfe, 0x7c, 0x3b,
+	0x40, 0x7f, 0x4a, 0x63, 0x71, 0xba, 0x94, 0x59, 0x11, 0xe9, 0x25, 0xd2, 0x81, 0x1f, 0xea, 0x3d,
+	0xa4, 0xe2, 0xc1, 0xb3, 0x03, 0x14, 0x5a, 0x51, 0xcd, 0x49, 0x0f, 0x1c, 0xed, 0x15, 0x9e, 0xa3,
+	0xa3, 0x7e, 0x56, 0x51, 0x67, 0xea, 0x19, 0x55, 0x5a, 0xe7, 0x10, 0xdc, 0x3a, 0x94, 0x60, 0x30,
+	0x1f, 0x59, 0x51, 0xb5, 0x50, 0x90, 0xfc, 0x06, 0xfb, 0x89, 0x2e, 0xf2, 0x4d, 0x81, 0x92, 0x1c,
+	0x1b, 0x47, 0xa8, 0x3b, 0x03, 0x5b, 0x27, 0xd5, 0x15, 0xd1, 0x4e, 0xc5, 0x1e, 0x58, 0xb2, 0x48,
+	0x4b, 0xd7, 0xaf, 0x01, 0xde, 0xfd, 0xf2, 0xb4, 0x48, 0x59, 0xa4, 0xd5, 0x6d, 0xb8, 0xfa, 0x09,
+	0xa8, 0x0a, 0xff, 0x7f, 0x03, 0x6e, 0xbd, 0x48, 0x5a, 0xd0, 0xbc, 0x1e, 0x5f, 0x8c, 0xaf, 0x66,
+	0x63, 0xdc, 0x20, 0x4d, 0x30, 0x27, 0x39, 0xc7, 0x48, 0x81, 0x51, 0xb2, 0xc4, 0x86, 0x06, 0xf4,
+	0x19, 0x9b, 0xc4, 0x05, 0xfb, 0x9c, 0xe6, 0x31, 0xc3, 0x16, 0x69, 0x83, 0x3b, 0x4c, 0x84, 0x5c,
+	0xc5, 0x19, 0xe5, 0xd8, 0x56, 0x09, 0x93, 0x9c, 0x73, 0x9a, 0x15, 0xd8, 0x39, 0xc1, 0x6f, 0x6b,
+	0x1f, 0xbd, 0xaf, 0x7d, 0xf4, 0xb1, 0xf6, 0xd1, 0xeb, 0xa7, 0xdf, 0xb8, 0x75, 0xf4, 0x75, 0xd8,
+	0xff, 0x1a, 0x00, 0x91, 0x8a, 0xe8, 0x6c, 0x1d, 0x02, 0x00, 0x00,
 }
 
 func (m *MetricList) Marshal() (dAtA []byte, err error) {
@@ -26,7 +26,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/tlsutil"
 	"github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/rpc/proto/storage"
 )
@@ -40,7 +42,7 @@ const (
 )
 
 var (
-	clientConnFct ClientConnFactory
+	clientConnFct *clientConnFactory
 )
 
 func init() {
@@ -63,6 +65,8 @@ type clientConnFactory struct {
 	connMap map[models.Node]*grpc.ClientConn
 	// lock to protect connMap
 	lock4map sync.Mutex
+	// tls certificate reloader, dials without tls if nil
+	tlsReloader *tlsutil.CertReloader
 }
 
 // GetClientConnFactory returns a singleton ClientConnFactory.
@@ -80,7 +84,11 @@ func (fct *clientConnFactory) GetClientConn(target models.Node) (*grpc.ClientCon
 	if ok {
 		return coon, nil
 	}
-	conn, err := grpc.Dial(target.Indicator(), grpc.WithInsecure())
+	dialOption := grpc.WithInsecure()
+	if fct.tlsReloader != nil {
+		dialOption = grpc.WithTransportCredentials(tlsutil.NewClientCredentials(fct.tlsReloader))
+	}
+	conn, err := grpc.Dial(target.Indicator(), dialOption)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +98,23 @@ func (fct *clientConnFactory) GetClientConn(target models.Node) (*grpc.ClientCon
 	return conn, nil
 }
 
+// SetClientTLS sets the tls config of grpc client connections, need be invoked before creating connections.
+// if tls enable, client verifies the server certificate and presents the certificate for mutual tls.
+func SetClientTLS(cfg config.TLS) error {
+	var reloader *tlsutil.CertReloader
+	if cfg.Enable {
+		r, err := tlsutil.NewCertReloader(cfg)
+		if err != nil {
+			return err
+		}
+		reloader = r
+	}
+	clientConnFct.lock4map.Lock()
+	clientConnFct.tlsReloader = reloader
+	clientConnFct.lock4map.Unlock()
+	return nil
+}
+
 // ClientStreamFactory is the factory to get ClientStream.
 type ClientStreamFactory interface {
 	// LogicNode returns the a logic Node which will be transferred to the target server for identification.
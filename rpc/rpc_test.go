@@ -25,6 +25,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	commonmock "github.com/lindb/lindb/rpc/pbmock/common"
 	"github.com/lindb/lindb/rpc/proto/common"
@@ -139,3 +140,20 @@ func TestClientStreamFactory_CreateTaskClient(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 	grpcServer.Stop()
 }
+
+func TestTLS(t *testing.T) {
+	defer func() {
+		_ = SetClientTLS(config.TLS{})
+	}()
+	// tls disable
+	assert.NoError(t, SetClientTLS(config.TLS{}))
+	opts, err := NewServerTLSOptions(config.TLS{})
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+
+	// certificate files not exist
+	cfg := config.TLS{Enable: true, CertFile: "not_exist", KeyFile: "not_exist"}
+	assert.Error(t, SetClientTLS(cfg))
+	_, err = NewServerTLSOptions(cfg)
+	assert.Error(t, err)
+}
@@ -21,8 +21,11 @@ import (
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/tlsutil"
 )
 
 //go:generate mockgen -source ./server.go -destination=./server_mock.go -package=rpc
@@ -42,14 +45,28 @@ type grpcServer struct {
 	gs          *grpc.Server
 }
 
-func NewGRPCServer(bindAddress string) GRPCServer {
+func NewGRPCServer(bindAddress string, opts ...grpc.ServerOption) GRPCServer {
 	return &grpcServer{
 		bindAddress: bindAddress,
 		logger:      logger.GetLogger("rpc", "GRPCServer"),
-		gs:          grpc.NewServer(),
+		gs:          grpc.NewServer(opts...),
 	}
 }
 
+// NewServerTLSOptions returns the grpc server options with tls credentials,
+// requires and verifies client certificate if ca file set(mutual tls),
+// returns empty options if tls not enable.
+func NewServerTLSOptions(cfg config.TLS) ([]grpc.ServerOption, error) {
+	if !cfg.Enable {
+		return nil, nil
+	}
+	reloader, err := tlsutil.NewCertReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(reloader.ServerConfig()))}, nil
+}
+
 // Start listens the bind address and serves grpc tcpServer,
 // block the caller, return fatal error or non-nil error if server is not stop gracefully.
 func (s *grpcServer) Start() error {
@@ -0,0 +1,92 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package field
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Histogram/summary field is stored as multiple primitive fields which have the same base name,
+// e.g. histogram "latency" => latency__bucket_0.5/latency__bucket_1/latency__bucket_+Inf/latency__sum/latency__count,
+// summary "latency" => latency__quantile_0.99/latency__sum/latency__count.
+const (
+	bucketFieldSeparator   = "__bucket_"
+	quantileFieldSeparator = "__quantile_"
+	sumFieldSuffix         = "__sum"
+	countFieldSuffix       = "__count"
+	positiveInf            = "+Inf"
+)
+
+// BucketFieldName returns the field name of histogram bucket by base name and bucket upper bound
+func BucketFieldName(name string, upperBound float64) Name {
+	return Name(name + bucketFieldSeparator + formatBound(upperBound))
+}
+
+// QuantileFieldName returns the field name of summary quantile by base name and quantile
+func QuantileFieldName(name string, quantile float64) Name {
+	return Name(name + quantileFieldSeparator + formatBound(quantile))
+}
+
+// SumFieldName returns the field name of histogram/summary's sum of observed values
+func SumFieldName(name string) Name {
+	return Name(name + sumFieldSuffix)
+}
+
+// CountFieldName returns the field name of histogram/summary's count of observed values
+func CountFieldName(name string) Name {
+	return Name(name + countFieldSuffix)
+}
+
+// ParseBucketFieldName parses the histogram bucket field name, returns base name and bucket upper bound,
+// if field name isn't a bucket field name, returns false.
+func ParseBucketFieldName(fieldName Name) (name string, upperBound float64, ok bool) {
+	return parseFieldName(string(fieldName), bucketFieldSeparator)
+}
+
+// ParseQuantileFieldName parses the summary quantile field name, returns base name and quantile,
+// if field name isn't a quantile field name, returns false.
+func ParseQuantileFieldName(fieldName Name) (name string, quantile float64, ok bool) {
+	return parseFieldName(string(fieldName), quantileFieldSeparator)
+}
+
+// parseFieldName parses the base name and float value which after the separator
+func parseFieldName(fieldName, separator string) (name string, value float64, ok bool) {
+	idx := strings.LastIndex(fieldName, separator)
+	if idx <= 0 {
+		return "", 0, false
+	}
+	valueStr := fieldName[idx+len(separator):]
+	if valueStr == positiveInf {
+		return fieldName[:idx], math.Inf(1), true
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return fieldName[:idx], value, true
+}
+
+// formatBound formats the bucket upper bound/quantile
+func formatBound(value float64) string {
+	if math.IsInf(value, 1) {
+		return positiveInf
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
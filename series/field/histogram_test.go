@@ -0,0 +1,55 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package field
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram_FieldName(t *testing.T) {
+	assert.Equal(t, Name("latency__bucket_0.5"), BucketFieldName("latency", 0.5))
+	assert.Equal(t, Name("latency__bucket_+Inf"), BucketFieldName("latency", math.Inf(1)))
+	assert.Equal(t, Name("latency__quantile_0.99"), QuantileFieldName("latency", 0.99))
+	assert.Equal(t, Name("latency__sum"), SumFieldName("latency"))
+	assert.Equal(t, Name("latency__count"), CountFieldName("latency"))
+
+	name, upperBound, ok := ParseBucketFieldName(BucketFieldName("latency", 10))
+	assert.True(t, ok)
+	assert.Equal(t, "latency", name)
+	assert.Equal(t, 10.0, upperBound)
+	name, upperBound, ok = ParseBucketFieldName(BucketFieldName("latency", math.Inf(1)))
+	assert.True(t, ok)
+	assert.Equal(t, "latency", name)
+	assert.True(t, math.IsInf(upperBound, 1))
+	name, quantile, ok := ParseQuantileFieldName(QuantileFieldName("latency", 0.99))
+	assert.True(t, ok)
+	assert.Equal(t, "latency", name)
+	assert.Equal(t, 0.99, quantile)
+
+	_, _, ok = ParseBucketFieldName("latency")
+	assert.False(t, ok)
+	_, _, ok = ParseBucketFieldName("__bucket_1")
+	assert.False(t, ok)
+	_, _, ok = ParseBucketFieldName("latency__bucket_abc")
+	assert.False(t, ok)
+	_, _, ok = ParseQuantileFieldName("latency__bucket_1")
+	assert.False(t, ok)
+}
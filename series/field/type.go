@@ -86,6 +86,13 @@ func (t Type) GetAggFunc() AggFunc {
 		return minAggregator
 	case MaxField:
 		return maxAggregator
+	case HistogramField:
+		// bucket's cumulative count of observed values, keep the last value,
+		// the increase of bucket is calculated at query time(handles counter reset)
+		return replaceAggregator
+	case SummaryField:
+		// quantile calculated by client, keep the last value
+		return replaceAggregator
 	default:
 		return nil
 	}
@@ -116,7 +123,8 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 	switch t {
 	case SumField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max:
+		case function.Sum, function.Min, function.Max,
+			function.Rate, function.IRate, function.Delta, function.Derivative:
 			return true
 		default:
 			return false
@@ -130,14 +138,24 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 		}
 	case MaxField:
 		switch funcType {
-		case function.Max:
+		case function.Max,
+			function.Rate, function.IRate, function.Delta, function.Derivative:
 			return true
 		default:
 			return false
 		}
 	case GaugeField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max, function.Replace:
+		case function.Sum, function.Min, function.Max, function.Replace,
+			function.Rate, function.IRate, function.Delta, function.Derivative:
+			return true
+		default:
+			return false
+		}
+	case IncreaseField:
+		switch funcType {
+		case function.Sum,
+			function.Rate, function.IRate, function.Delta, function.Derivative:
 			return true
 		default:
 			return false
@@ -153,11 +171,19 @@ func (t Type) IsFuncSupported(funcType function.FuncType) bool {
 
 // GetFuncFieldParams returns the fields for aggregator's function params.
 func (t Type) GetFuncFieldParams(funcType function.FuncType) []AggType {
+	if function.IsRateFunc(funcType) {
+		// rate/irate/delta/derivative of series are summed when merging series
+		return []AggType{Sum}
+	}
 	switch t {
 	case SumField:
 		return getFieldParamsForSumField(funcType)
 	case MinField:
 		return getFieldParamsForMinField(funcType)
+	case HistogramField:
+		return []AggType{Sum}
+	case SummaryField:
+		return getFieldParamsForSummaryField(funcType)
 	}
 	return nil
 }
@@ -179,3 +205,14 @@ func getFieldParamsForMinField(funcType function.FuncType) []AggType {
 		return []AggType{Min}
 	}
 }
+
+func getFieldParamsForSummaryField(funcType function.FuncType) []AggType {
+	switch funcType {
+	case function.Min:
+		return []AggType{Min}
+	case function.Max:
+		return []AggType{Max}
+	default:
+		return []AggType{Replace}
+	}
+}
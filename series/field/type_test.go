@@ -69,6 +69,16 @@ func TestIsSupportFunc(t *testing.T) {
 	assert.True(t, HistogramField.IsFuncSupported(function.Max))
 	assert.True(t, HistogramField.IsFuncSupported(function.Histogram))
 
+	for _, funcType := range []function.FuncType{function.Rate, function.IRate, function.Delta, function.Derivative} {
+		assert.True(t, SumField.IsFuncSupported(funcType))
+		assert.True(t, MaxField.IsFuncSupported(funcType))
+		assert.True(t, GaugeField.IsFuncSupported(funcType))
+		assert.True(t, IncreaseField.IsFuncSupported(funcType))
+		assert.False(t, MinField.IsFuncSupported(funcType))
+	}
+	assert.True(t, IncreaseField.IsFuncSupported(function.Sum))
+	assert.False(t, IncreaseField.IsFuncSupported(function.Max))
+
 	assert.False(t, Unknown.IsFuncSupported(function.Histogram))
 }
 
@@ -76,5 +86,17 @@ func TestType_GetAggFunc(t *testing.T) {
 	assert.Equal(t, maxAggregator, MaxField.GetAggFunc())
 	assert.Equal(t, sumAggregator, SumField.GetAggFunc())
 	assert.Equal(t, minAggregator, MinField.GetAggFunc())
+	assert.Equal(t, replaceAggregator, HistogramField.GetAggFunc())
+	assert.Equal(t, replaceAggregator, SummaryField.GetAggFunc())
 	assert.Nil(t, Unknown.GetAggFunc())
 }
+
+func TestType_GetFuncFieldParams(t *testing.T) {
+	assert.Equal(t, []AggType{Sum}, HistogramField.GetFuncFieldParams(function.Quantile))
+	assert.Equal(t, []AggType{Replace}, SummaryField.GetFuncFieldParams(function.Quantile))
+	assert.Equal(t, []AggType{Min}, SummaryField.GetFuncFieldParams(function.Min))
+	assert.Equal(t, []AggType{Max}, SummaryField.GetFuncFieldParams(function.Max))
+	assert.Nil(t, GaugeField.GetFuncFieldParams(function.Sum))
+	assert.Equal(t, []AggType{Sum}, GaugeField.GetFuncFieldParams(function.Rate))
+	assert.Equal(t, []AggType{Sum}, MaxField.GetFuncFieldParams(function.Derivative))
+}
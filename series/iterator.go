@@ -33,6 +33,8 @@ type TimeSeriesEvent struct {
 
 	Stats *models.QueryStats
 	Err   error
+	// Warnings represents the shards which are not queried
+	Warnings []models.ShardWarning
 }
 
 // GroupedIterator represents a iterator for the grouped time series data
@@ -17,7 +17,10 @@
 
 package point
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrInvalidPoint      = fmt.Errorf("point is invalid")
@@ -29,3 +32,27 @@ var (
 	ErrMissingFieldValue = fmt.Errorf("field value is missing")
 	ErrInvalidNumber     = fmt.Errorf("invalid number")
 )
+
+// ParseError represents the error of parsing one line of the text
+type ParseError struct {
+	Line int    // line number of the text(item index for structured format), starts with 1
+	Text string // content of the line
+	Err  error  // parse error
+}
+
+// Error returns the error message with line number
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: unable to parse '%s': %v", e.Line, e.Text, e.Err)
+}
+
+// ParseErrors represents the parse errors of multi lines
+type ParseErrors []*ParseError
+
+// Error returns the error messages of all lines, separated by new line
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for idx, err := range e {
+		msgs[idx] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/lindb/lindb/pkg/escape"
@@ -42,6 +41,7 @@ func (a Points) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 // Point implements point
 type Point struct {
+	line               int            // line number of the text which the point is parsed from
 	timestamp          int64          // milliseconds
 	key                []byte         // text encoding of metric-name and sorted tags
 	fields             []byte         // text encoding of field data
@@ -54,6 +54,11 @@ type Point struct {
 	itr                *FieldIterator // field iterator
 }
 
+// Line returns the line number of the text which the point is parsed from, 0 if not parsed from text
+func (p *Point) Line() int {
+	return p.line
+}
+
 func (p *Point) Key() []byte {
 	return p.key
 }
@@ -242,8 +247,10 @@ func (p *Point) parseFields() (field.Fields, error) {
 			if err != nil {
 				return nil, fmt.Errorf("parse field: %s with error: %s", string(itr.Name()), err)
 			}
+			// copy the name, because SetFields will rewrite the fields buffer which name refers to
+			name := append([]byte(nil), itr.Name()...)
 			fields = append(fields, field.Field{
-				Name: itr.Name(), Type: itr.Type(), Value: v})
+				Name: name, Type: itr.Type(), Value: v})
 		}
 	}
 	if len(fields) == 0 {
@@ -289,18 +296,20 @@ func (p *Point) SetFields(fs field.Fields) *Point {
 }
 
 // ParsePoints returns a slice of *Point from the text representation of lines separated by new lines,
-// If any poins fails to parse, a error will be returned.
+// If any points fails to parse, the points parsed successfully and ParseErrors will be returned.
 func ParsePoints(text []byte) ([]*Point, error) {
 	points := make([]*Point, bytes.Count(text, []byte{'\n'})+1)[:0]
 	var (
 		pos    int
+		line   int
 		block  []byte
-		failed []string
+		failed ParseErrors
 		err    error
 	)
 	for pos < len(text) {
 		pos, block = scanLine(text, pos)
 		pos++
+		line += bytes.Count(block, []byte{'\n'}) + 1
 
 		if len(block) == 0 {
 			continue
@@ -323,13 +332,13 @@ func ParsePoints(text []byte) ([]*Point, error) {
 			block = block[:len(block)-1]
 		}
 
-		points, err = parsePointsAppend(points, block[start:])
+		points, err = parsePointsAppend(points, block[start:], line)
 		if err != nil {
-			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block[start:]), err))
+			failed = append(failed, &ParseError{Line: line, Text: string(block[start:]), Err: err})
 		}
 	}
 	if len(failed) > 0 {
-		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
+		return points, failed
 	}
 
 	return points, nil
@@ -340,11 +349,11 @@ func ParsePointsFromString(text string) ([]*Point, error) {
 	return ParsePoints([]byte(text))
 }
 
-func parsePointsAppend(points []*Point, text []byte) ([]*Point, error) {
+func parsePointsAppend(points []*Point, text []byte, line int) ([]*Point, error) {
 	// scan the first block which is measurement[,tag1=value1,tag2=value=2...]
 	pos, key, err := scanKey(text, 0)
 	if err != nil {
-		return nil, err
+		return points, err
 	}
 	// metric-name name is required
 	if len(key) == 0 {
@@ -380,7 +389,7 @@ func parsePointsAppend(points []*Point, text []byte) ([]*Point, error) {
 	}
 
 	// Build point with timestamp only.
-	pt := &Point{ts: ts}
+	pt := &Point{ts: ts, line: line}
 
 	if len(ts) == 0 {
 		pt.timestamp = timeutil.Now()
@@ -205,6 +205,26 @@ func Test_ParsePoints_error(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_ParsePoints_LineErrors(t *testing.T) {
+	points, err := point.ParsePointsFromString(
+		"cpu,host=a f_SUM=1 1577000000000\n" +
+			",host=b f_SUM=1\n" +
+			"# comment\n" +
+			"cpu,host=c\n" +
+			"cpu,host=d f_MAX=2 1577000000000")
+	assert.Len(t, points, 2)
+	assert.Equal(t, 1, points[0].Line())
+	assert.Equal(t, 5, points[1].Line())
+	parseErrors, ok := err.(point.ParseErrors)
+	assert.True(t, ok)
+	assert.Len(t, parseErrors, 2)
+	assert.Equal(t, 2, parseErrors[0].Line)
+	assert.Equal(t, ",host=b f_SUM=1", parseErrors[0].Text)
+	assert.Equal(t, 4, parseErrors[1].Line)
+	assert.Equal(t, "line 2: unable to parse ',host=b f_SUM=1': missing metric-name\n"+
+		"line 4: unable to parse 'cpu,host=c': fields is missing", err.Error())
+}
+
 func Test_ParsePoints_TagsError(t *testing.T) {
 	// duplicate tags
 	_, err := point.ParsePointsFromString(
@@ -40,6 +40,8 @@ type ShardAssignService interface {
 	Get(databaseName string) (*models.ShardAssignment, error)
 	// Save saves shard assignment for given database name, if fail return error
 	Save(databaseName string, shardAssign *models.ShardAssignment) error
+	// Delete deletes shard assignment for given database name, if fail return error
+	Delete(databaseName string) error
 }
 
 // shardAssignService implements shard assign service interface
@@ -94,3 +96,8 @@ func (s *shardAssignService) Save(databaseName string, shardAssign *models.Shard
 	data, _ := json.Marshal(shardAssign)
 	return s.repo.Put(context.TODO(), constants.GetDatabaseAssignPath(databaseName), data)
 }
+
+// Delete deletes shard assignment for given database name, if fail return error
+func (s *shardAssignService) Delete(databaseName string) error {
+	return s.repo.Delete(context.TODO(), constants.GetDatabaseAssignPath(databaseName))
+}
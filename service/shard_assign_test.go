@@ -25,6 +25,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/state"
 )
@@ -82,4 +83,8 @@ func TestShardAssignService(t *testing.T) {
 	list, err = srv.List()
 	assert.Nil(t, list)
 	assert.NotNil(t, err)
+
+	repo.EXPECT().Delete(gomock.Any(), constants.GetDatabaseAssignPath("db1")).Return(nil)
+	err = srv.Delete("db1")
+	assert.NoError(t, err)
 }
@@ -23,7 +23,9 @@ import (
 	"sync"
 
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/backup"
 )
 
 //go:generate mockgen -source ./storage.go -destination=./storage_mock.go -package service
@@ -48,20 +50,39 @@ type StorageService interface {
 	// FLush produces a signal to workers for flushing memory database by name
 	FlushDatabase(ctx context.Context, databaseName string) bool
 
+	// DropDatabase drops the database and removes all data of database
+	DropDatabase(databaseName string) error
+
+	// DropMetric drops the metric of database, does nothing if database not exist
+	DropMetric(databaseName, namespace, metricName string) error
+
+	// DeleteSeries deletes the series which match the tag filter condition under metric,
+	// does nothing if database not exist
+	DeleteSeries(databaseName, namespace, metricName string, condition stmt.Expr) error
+
+	// BackupDatabase backups the database of this node into the target(uri) under prefix,
+	// returns error if database not exist or local target is out of backup dir
+	BackupDatabase(databaseName, target, name, prefix string) error
+
 	// Close closes the time series engine
 	Close()
 }
 
 // storageService implements StorageService interface
 type storageService struct {
-	engine tsdb.Engine
-	mutex  sync.Mutex
+	engine       tsdb.Engine
+	seriesFinder tsdb.SeriesFinder
+	backupDir    string // local backup target must be under this dir
+	mutex        sync.Mutex
 }
 
-// NewStorageService creates storage service instance for managing time series engine
-func NewStorageService(engine tsdb.Engine) StorageService {
+// NewStorageService creates storage service instance for managing time series engine,
+// backupDir is the root dir of local backup target.
+func NewStorageService(engine tsdb.Engine, seriesFinder tsdb.SeriesFinder, backupDir string) StorageService {
 	return &storageService{
-		engine: engine,
+		engine:       engine,
+		seriesFinder: seriesFinder,
+		backupDir:    backupDir,
 	}
 }
 
@@ -112,6 +133,41 @@ func (s *storageService) FlushDatabase(ctx context.Context, databaseName string)
 	return s.engine.FlushDatabase(ctx, databaseName)
 }
 
+func (s *storageService) DropDatabase(databaseName string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.engine.DropDatabase(databaseName)
+}
+
+func (s *storageService) DropMetric(databaseName, namespace, metricName string) error {
+	db, ok := s.GetDatabase(databaseName)
+	if !ok {
+		return nil
+	}
+	return db.DropMetric(namespace, metricName)
+}
+
+func (s *storageService) DeleteSeries(databaseName, namespace, metricName string, condition stmt.Expr) error {
+	db, ok := s.GetDatabase(databaseName)
+	if !ok {
+		return nil
+	}
+	return db.DeleteSeries(namespace, metricName, condition, s.seriesFinder)
+}
+
+func (s *storageService) BackupDatabase(databaseName, target, name, prefix string) error {
+	db, ok := s.GetDatabase(databaseName)
+	if !ok {
+		return fmt.Errorf("database[%s] not exist", databaseName)
+	}
+	backupTarget, err := backup.NewRestrictedTarget(target, s.backupDir)
+	if err != nil {
+		return err
+	}
+	return db.Backup(backupTarget, name, prefix)
+}
+
 func (s *storageService) Close() {
 	s.engine.Close()
 }
@@ -27,7 +27,9 @@ import (
 
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/backup"
 )
 
 var testPath = "test_data"
@@ -43,7 +45,7 @@ func TestStorageService_CreateShards(t *testing.T) {
 	mockEngine := tsdb.NewMockEngine(ctrl)
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 
-	service := NewStorageService(mockEngine)
+	service := NewStorageService(mockEngine, nil, "/tmp/backup")
 	// 2 times for double check
 	err := service.CreateShards("test_db", option.DatabaseOption{})
 	assert.NotNil(t, err)
@@ -91,13 +93,91 @@ func TestStorageService_FlushDatabase(t *testing.T) {
 
 	mockEngine := tsdb.NewMockEngine(ctrl)
 
-	service := NewStorageService(mockEngine)
+	service := NewStorageService(mockEngine, nil, "/tmp/backup")
 	mockEngine.EXPECT().FlushDatabase(gomock.Any(), gomock.Any()).Return(true)
 	ok := service.FlushDatabase(context.TODO(), "db")
 	assert.True(t, ok)
 
 }
 
+func TestStorageService_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := tsdb.NewMockEngine(ctrl)
+	service := NewStorageService(mockEngine, nil, "/tmp/backup")
+	mockEngine.EXPECT().DropDatabase("db").Return(fmt.Errorf("err"))
+	err := service.DropDatabase("db")
+	assert.Error(t, err)
+	mockEngine.EXPECT().DropDatabase("db").Return(nil)
+	err = service.DropDatabase("db")
+	assert.NoError(t, err)
+}
+
+func TestStorageService_DropMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := tsdb.NewMockEngine(ctrl)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	service := NewStorageService(mockEngine, nil, "/tmp/backup")
+	// case 1: database not exist
+	mockEngine.EXPECT().GetDatabase("db").Return(nil, false)
+	err := service.DropMetric("db", "ns", "cpu")
+	assert.NoError(t, err)
+	// case 2: drop metric
+	mockEngine.EXPECT().GetDatabase("db").Return(mockDatabase, true)
+	mockDatabase.EXPECT().DropMetric("ns", "cpu").Return(fmt.Errorf("err"))
+	err = service.DropMetric("db", "ns", "cpu")
+	assert.Error(t, err)
+}
+
+func TestStorageService_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := tsdb.NewMockEngine(ctrl)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	finder := tsdb.NewMockSeriesFinder(ctrl)
+	service := NewStorageService(mockEngine, finder, "/tmp/backup")
+	condition := &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}
+	// case 1: database not exist
+	mockEngine.EXPECT().GetDatabase("db").Return(nil, false)
+	err := service.DeleteSeries("db", "ns", "cpu", condition)
+	assert.NoError(t, err)
+	// case 2: delete series
+	mockEngine.EXPECT().GetDatabase("db").Return(mockDatabase, true)
+	mockDatabase.EXPECT().DeleteSeries("ns", "cpu", condition, finder).Return(nil)
+	err = service.DeleteSeries("db", "ns", "cpu", condition)
+	assert.NoError(t, err)
+}
+
+func TestStorageService_BackupDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := tsdb.NewMockEngine(ctrl)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	service := NewStorageService(mockEngine, nil, "/tmp/backup")
+	// case 1: database not exist
+	mockEngine.EXPECT().GetDatabase("db").Return(nil, false)
+	err := service.BackupDatabase("db", "/tmp/backup", "b1", "b1/node/db")
+	assert.Error(t, err)
+	// case 2: target invalid
+	mockEngine.EXPECT().GetDatabase("db").Return(mockDatabase, true)
+	err = service.BackupDatabase("db", "", "b1", "b1/node/db")
+	assert.Error(t, err)
+	// case 3: local target out of backup dir
+	mockEngine.EXPECT().GetDatabase("db").Return(mockDatabase, true)
+	err = service.BackupDatabase("db", "file:///tmp/backup/../../etc", "b1", "b1/node/db")
+	assert.Error(t, err)
+	// case 4: backup database
+	mockEngine.EXPECT().GetDatabase("db").Return(mockDatabase, true)
+	mockDatabase.EXPECT().Backup(backup.NewLocalTarget("/tmp/backup/b"), "b1", "b1/node/db").Return(nil)
+	err = service.BackupDatabase("db", "/tmp/backup/b", "b1", "b1/node/db")
+	assert.NoError(t, err)
+}
+
 func TestStorageService_Close(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -106,7 +186,7 @@ func TestStorageService_Close(t *testing.T) {
 	}()
 
 	mockEngine := tsdb.NewMockEngine(ctrl)
-	service := NewStorageService(mockEngine)
+	service := NewStorageService(mockEngine, nil, "/tmp/backup")
 	mockEngine.EXPECT().Close()
 	service.Close()
 }
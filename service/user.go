@@ -0,0 +1,370 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+//go:generate mockgen -source=./user.go -destination=./user_mock.go -package service
+
+// for testing
+var (
+	randRead = rand.Read
+)
+
+var errAuthenticate = fmt.Errorf("username or password is invalid")
+var errInvalidAPIKey = fmt.Errorf("api key is invalid")
+
+// UserService defines user/role/api key service interface, all auth configs are stored in state's repo
+type UserService interface {
+	// SaveUser saves user config, hashes the plain password if password not empty
+	SaveUser(user *models.User) error
+	// GetUser returns user config by name, if not exist return ErrNotExist
+	GetUser(name string) (*models.User, error)
+	// ListUsers returns all user configs
+	ListUsers() ([]*models.User, error)
+	// DeleteUser deletes user config by name
+	DeleteUser(name string) error
+	// Authenticate authenticates user by name and password, returns user config if success
+	Authenticate(name, password string) (*models.User, error)
+
+	// SaveRole saves role config
+	SaveRole(role *models.Role) error
+	// ListRoles returns all role configs
+	ListRoles() ([]*models.Role, error)
+	// DeleteRole deletes role config by name
+	DeleteRole(name string) error
+	// GetGrants returns the grants of roles, ignores the role which not exist
+	GetGrants(roles []string) (models.Grants, error)
+
+	// CreateAPIKey creates api key with roles, returns the api key which includes the plain key
+	CreateAPIKey(name string, roles []string) (*models.APIKey, error)
+	// ListAPIKeys returns all api key configs
+	ListAPIKeys() ([]*models.APIKey, error)
+	// DeleteAPIKey deletes api key by name
+	DeleteAPIKey(name string) error
+	// AuthenticateAPIKey authenticates the api key, returns api key config if success
+	AuthenticateAPIKey(key string) (*models.APIKey, error)
+
+	// RevokeToken revokes the token by token id, expiresAt is the expire time(unix seconds) of token
+	RevokeToken(tokenID string, expiresAt int64) error
+	// IsTokenRevoked checks if the token is revoked
+	IsTokenRevoked(tokenID string) (bool, error)
+}
+
+// userService implements UserService interface
+type userService struct {
+	repo   state.Repository
+	logger *logger.Logger
+}
+
+// NewUserService creates user service
+func NewUserService(repo state.Repository) UserService {
+	return &userService{
+		repo:   repo,
+		logger: logger.GetLogger("service", "UserService"),
+	}
+}
+
+// SaveUser saves user config into state's repo, only stores the hash of password
+func (s *userService) SaveUser(user *models.User) error {
+	if len(user.UserName) == 0 {
+		return fmt.Errorf("username cannot be empty")
+	}
+	if len(user.Password) > 0 {
+		salt, err := randomHex(16)
+		if err != nil {
+			return err
+		}
+		user.Salt = salt
+		user.PasswordHash = hashSecret(salt, user.Password)
+		user.Password = ""
+	} else {
+		// keep the password of existing user
+		existUser, err := s.GetUser(user.UserName)
+		if err != nil {
+			return fmt.Errorf("password cannot be empty for new user")
+		}
+		user.Salt = existUser.Salt
+		user.PasswordHash = existUser.PasswordHash
+	}
+	data, _ := json.Marshal(user)
+	return s.repo.Put(context.TODO(), constants.GetUserPath(user.UserName), data)
+}
+
+// GetUser returns the user config in the state's repo, if not exist return ErrNotExist
+func (s *userService) GetUser(name string) (*models.User, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("username must not be null")
+	}
+	data, err := s.repo.Get(context.TODO(), constants.GetUserPath(name))
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{}
+	if err := json.Unmarshal(data, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers returns all user configs
+func (s *userService) ListUsers() ([]*models.User, error) {
+	var result []*models.User
+	data, err := s.repo.List(context.TODO(), constants.UserPath)
+	if err != nil {
+		return result, err
+	}
+	for _, val := range data {
+		user := &models.User{}
+		if err := json.Unmarshal(val.Value, user); err != nil {
+			s.logger.Warn("unmarshal user data error", logger.String("data", string(val.Value)))
+			continue
+		}
+		result = append(result, user)
+	}
+	return result, nil
+}
+
+// DeleteUser deletes the user config in the state's repo
+func (s *userService) DeleteUser(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("username must not be null")
+	}
+	return s.repo.Delete(context.TODO(), constants.GetUserPath(name))
+}
+
+// Authenticate authenticates user by name and password
+func (s *userService) Authenticate(name, password string) (*models.User, error) {
+	user, err := s.GetUser(name)
+	if err != nil {
+		return nil, errAuthenticate
+	}
+	if !equalSecret(hashSecret(user.Salt, password), user.PasswordHash) {
+		return nil, errAuthenticate
+	}
+	return user, nil
+}
+
+// SaveRole saves role config into state's repo
+func (s *userService) SaveRole(role *models.Role) error {
+	if len(role.Name) == 0 {
+		return fmt.Errorf("role name cannot be empty")
+	}
+	for _, grant := range role.Grants {
+		if len(grant.Database) == 0 {
+			return fmt.Errorf("database of grant cannot be empty")
+		}
+		if !grant.Permission.IsValid() {
+			return fmt.Errorf("unknown permission: %s", grant.Permission)
+		}
+	}
+	data, _ := json.Marshal(role)
+	return s.repo.Put(context.TODO(), constants.GetRolePath(role.Name), data)
+}
+
+// ListRoles returns all role configs
+func (s *userService) ListRoles() ([]*models.Role, error) {
+	var result []*models.Role
+	data, err := s.repo.List(context.TODO(), constants.RolePath)
+	if err != nil {
+		return result, err
+	}
+	for _, val := range data {
+		role := &models.Role{}
+		if err := json.Unmarshal(val.Value, role); err != nil {
+			s.logger.Warn("unmarshal role data error", logger.String("data", string(val.Value)))
+			continue
+		}
+		result = append(result, role)
+	}
+	return result, nil
+}
+
+// DeleteRole deletes the role config in the state's repo
+func (s *userService) DeleteRole(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("role name must not be null")
+	}
+	return s.repo.Delete(context.TODO(), constants.GetRolePath(name))
+}
+
+// GetGrants returns the grants of roles, ignores the role which not exist
+func (s *userService) GetGrants(roles []string) (models.Grants, error) {
+	var grants models.Grants
+	for _, name := range roles {
+		data, err := s.repo.Get(context.TODO(), constants.GetRolePath(name))
+		if err == state.ErrNotExist {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		role := &models.Role{}
+		if err := json.Unmarshal(data, role); err != nil {
+			return nil, err
+		}
+		grants = append(grants, role.Grants...)
+	}
+	return grants, nil
+}
+
+// CreateAPIKey creates api key with roles, the key format is name.secret,
+// only stores the hash of secret, the plain key cannot be got again.
+func (s *userService) CreateAPIKey(name string, roles []string) (*models.APIKey, error) {
+	if len(name) == 0 || strings.Contains(name, ".") {
+		return nil, fmt.Errorf("api key name cannot be empty or contain '.'")
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	apiKey := &models.APIKey{
+		Name:    name,
+		KeyHash: hashSecret(name, secret),
+		Roles:   roles,
+	}
+	data, _ := json.Marshal(apiKey)
+	if err := s.repo.Put(context.TODO(), constants.GetAPIKeyPath(name), data); err != nil {
+		return nil, err
+	}
+	apiKey.Key = name + "." + secret
+	return apiKey, nil
+}
+
+// ListAPIKeys returns all api key configs
+func (s *userService) ListAPIKeys() ([]*models.APIKey, error) {
+	var result []*models.APIKey
+	data, err := s.repo.List(context.TODO(), constants.APIKeyPath)
+	if err != nil {
+		return result, err
+	}
+	for _, val := range data {
+		apiKey := &models.APIKey{}
+		if err := json.Unmarshal(val.Value, apiKey); err != nil {
+			s.logger.Warn("unmarshal api key data error", logger.String("data", string(val.Value)))
+			continue
+		}
+		result = append(result, apiKey)
+	}
+	return result, nil
+}
+
+// DeleteAPIKey deletes the api key in the state's repo
+func (s *userService) DeleteAPIKey(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("api key name must not be null")
+	}
+	return s.repo.Delete(context.TODO(), constants.GetAPIKeyPath(name))
+}
+
+// AuthenticateAPIKey authenticates the api key(name.secret)
+func (s *userService) AuthenticateAPIKey(key string) (*models.APIKey, error) {
+	idx := strings.Index(key, ".")
+	if idx <= 0 {
+		return nil, errInvalidAPIKey
+	}
+	name, secret := key[:idx], key[idx+1:]
+	data, err := s.repo.Get(context.TODO(), constants.GetAPIKeyPath(name))
+	if err != nil {
+		return nil, errInvalidAPIKey
+	}
+	apiKey := &models.APIKey{}
+	if err := json.Unmarshal(data, apiKey); err != nil {
+		return nil, errInvalidAPIKey
+	}
+	if !equalSecret(hashSecret(name, secret), apiKey.KeyHash) {
+		return nil, errInvalidAPIKey
+	}
+	return apiKey, nil
+}
+
+// RevokeToken revokes the token by token id, and removes the expired revoked tokens
+func (s *userService) RevokeToken(tokenID string, expiresAt int64) error {
+	if len(tokenID) == 0 {
+		return fmt.Errorf("token id must not be null")
+	}
+	if err := s.repo.Put(context.TODO(), constants.GetRevokedTokenPath(tokenID),
+		[]byte(strconv.FormatInt(expiresAt, 10))); err != nil {
+		return err
+	}
+	// expired token cannot pass validation, so no need to keep it
+	revokedTokens, err := s.repo.List(context.TODO(), constants.RevokedTokenPath)
+	if err != nil {
+		s.logger.Warn("list revoked tokens error", logger.Error(err))
+		return nil
+	}
+	now := timeutil.Now() / 1000
+	for _, kv := range revokedTokens {
+		expiresAt, err := strconv.ParseInt(string(kv.Value), 10, 64)
+		if err == nil && expiresAt < now {
+			if err := s.repo.Delete(context.TODO(), kv.Key); err != nil {
+				s.logger.Warn("delete expired revoked token error", logger.String("key", kv.Key), logger.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// IsTokenRevoked checks if the token is revoked
+func (s *userService) IsTokenRevoked(tokenID string) (bool, error) {
+	_, err := s.repo.Get(context.TODO(), constants.GetRevokedTokenPath(tokenID))
+	switch {
+	case err == state.ErrNotExist:
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return true, nil
+	}
+}
+
+// hashSecret returns the hex encoded sha256 hash of salt and secret
+func hashSecret(salt, secret string) string {
+	h := sha256.Sum256([]byte(salt + "/" + secret))
+	return hex.EncodeToString(h[:])
+}
+
+// equalSecret compares two secret hashes in constant time
+func equalSecret(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// randomHex returns hex encoded random bytes
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := randRead(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
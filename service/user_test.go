@@ -0,0 +1,241 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestUserService_User(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	// case 1: save user
+	assert.Error(t, srv.SaveUser(&models.User{}))
+	var data []byte
+	repo.EXPECT().Put(gomock.Any(), constants.GetUserPath("test"), gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, val []byte) error {
+			data = val
+			return nil
+		})
+	err := srv.SaveUser(&models.User{UserName: "test", Password: "pwd", Roles: []string{"reader"}})
+	assert.NoError(t, err)
+	user := &models.User{}
+	_ = json.Unmarshal(data, user)
+	assert.Empty(t, user.Password)
+	assert.NotEmpty(t, user.Salt)
+	assert.Equal(t, hashSecret(user.Salt, "pwd"), user.PasswordHash)
+	// case 2: update user without password
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(data, nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetUserPath("test"), gomock.Any()).Return(nil)
+	user2 := &models.User{UserName: "test", Roles: []string{"writer"}}
+	assert.NoError(t, srv.SaveUser(user2))
+	assert.Equal(t, user.PasswordHash, user2.PasswordHash)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("new")).Return(nil, state.ErrNotExist)
+	assert.Error(t, srv.SaveUser(&models.User{UserName: "new"}))
+	// case 3: random err
+	randRead = func(b []byte) (n int, err error) {
+		return 0, fmt.Errorf("err")
+	}
+	assert.Error(t, srv.SaveUser(&models.User{UserName: "test", Password: "pwd"}))
+	randRead = rand.Read
+
+	// case 4: get user
+	_, err = srv.GetUser("")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte{1, 2}, nil)
+	_, err = srv.GetUser("test")
+	assert.Error(t, err)
+
+	// case 5: authenticate
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(data, nil).Times(2)
+	user3, err := srv.Authenticate("test", "pwd")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reader"}, user3.Roles)
+	_, err = srv.Authenticate("test", "pwd2")
+	assert.Equal(t, errAuthenticate, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(nil, state.ErrNotExist)
+	_, err = srv.Authenticate("test", "pwd")
+	assert.Equal(t, errAuthenticate, err)
+
+	// case 6: list users
+	repo.EXPECT().List(gomock.Any(), constants.UserPath).Return(nil, fmt.Errorf("err"))
+	_, err = srv.ListUsers()
+	assert.Error(t, err)
+	repo.EXPECT().List(gomock.Any(), constants.UserPath).Return([]state.KeyValue{{Value: data}, {Value: []byte{1}}}, nil)
+	users, err := srv.ListUsers()
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	// case 7: delete user
+	assert.Error(t, srv.DeleteUser(""))
+	repo.EXPECT().Delete(gomock.Any(), constants.GetUserPath("test")).Return(nil)
+	assert.NoError(t, srv.DeleteUser("test"))
+}
+
+func TestUserService_Role(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	assert.Error(t, srv.SaveRole(&models.Role{}))
+	assert.Error(t, srv.SaveRole(&models.Role{Name: "r", Grants: models.Grants{{Permission: models.ReadPermission}}}))
+	assert.Error(t, srv.SaveRole(&models.Role{Name: "r", Grants: models.Grants{{Database: "db", Permission: "xx"}}}))
+	role := &models.Role{Name: "reader", Grants: models.Grants{{Database: "db", Permission: models.ReadPermission}}}
+	data, _ := json.Marshal(role)
+	repo.EXPECT().Put(gomock.Any(), constants.GetRolePath("reader"), data).Return(nil)
+	assert.NoError(t, srv.SaveRole(role))
+
+	repo.EXPECT().List(gomock.Any(), constants.RolePath).Return(nil, fmt.Errorf("err"))
+	_, err := srv.ListRoles()
+	assert.Error(t, err)
+	repo.EXPECT().List(gomock.Any(), constants.RolePath).Return([]state.KeyValue{{Value: data}, {Value: []byte{1}}}, nil)
+	roles, err := srv.ListRoles()
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Role{role}, roles)
+
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("reader")).Return(data, nil)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("not_exist")).Return(nil, state.ErrNotExist)
+	grants, err := srv.GetGrants([]string{"reader", "not_exist"})
+	assert.NoError(t, err)
+	assert.Equal(t, role.Grants, grants)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("reader")).Return(nil, fmt.Errorf("err"))
+	_, err = srv.GetGrants([]string{"reader"})
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("reader")).Return([]byte{1}, nil)
+	_, err = srv.GetGrants([]string{"reader"})
+	assert.Error(t, err)
+
+	assert.Error(t, srv.DeleteRole(""))
+	repo.EXPECT().Delete(gomock.Any(), constants.GetRolePath("reader")).Return(nil)
+	assert.NoError(t, srv.DeleteRole("reader"))
+}
+
+func TestUserService_APIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	_, err := srv.CreateAPIKey("", nil)
+	assert.Error(t, err)
+	_, err = srv.CreateAPIKey("a.b", nil)
+	assert.Error(t, err)
+	randRead = func(b []byte) (n int, err error) {
+		return 0, fmt.Errorf("err")
+	}
+	_, err = srv.CreateAPIKey("agent", nil)
+	assert.Error(t, err)
+	randRead = rand.Read
+	repo.EXPECT().Put(gomock.Any(), constants.GetAPIKeyPath("agent"), gomock.Any()).Return(fmt.Errorf("err"))
+	_, err = srv.CreateAPIKey("agent", nil)
+	assert.Error(t, err)
+
+	var data []byte
+	repo.EXPECT().Put(gomock.Any(), constants.GetAPIKeyPath("agent"), gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, val []byte) error {
+			data = val
+			return nil
+		})
+	apiKey, err := srv.CreateAPIKey("agent", []string{"writer"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), apiKey.Key)
+
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath("agent")).Return(data, nil).Times(2)
+	apiKey2, err := srv.AuthenticateAPIKey(apiKey.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"writer"}, apiKey2.Roles)
+	_, err = srv.AuthenticateAPIKey("agent.wrong")
+	assert.Equal(t, errInvalidAPIKey, err)
+	_, err = srv.AuthenticateAPIKey("agent")
+	assert.Equal(t, errInvalidAPIKey, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath("agent")).Return(nil, state.ErrNotExist)
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Equal(t, errInvalidAPIKey, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath("agent")).Return([]byte{1}, nil)
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Equal(t, errInvalidAPIKey, err)
+
+	repo.EXPECT().List(gomock.Any(), constants.APIKeyPath).Return(nil, fmt.Errorf("err"))
+	_, err = srv.ListAPIKeys()
+	assert.Error(t, err)
+	repo.EXPECT().List(gomock.Any(), constants.APIKeyPath).Return([]state.KeyValue{{Value: data}, {Value: []byte{1}}}, nil)
+	apiKeys, err := srv.ListAPIKeys()
+	assert.NoError(t, err)
+	assert.Len(t, apiKeys, 1)
+
+	assert.Error(t, srv.DeleteAPIKey(""))
+	repo.EXPECT().Delete(gomock.Any(), constants.GetAPIKeyPath("agent")).Return(nil)
+	assert.NoError(t, srv.DeleteAPIKey("agent"))
+}
+
+func TestUserService_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	assert.Error(t, srv.RevokeToken("", 0))
+	repo.EXPECT().Put(gomock.Any(), constants.GetRevokedTokenPath("id"), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, srv.RevokeToken("id", 10))
+	// list revoked tokens err
+	repo.EXPECT().Put(gomock.Any(), constants.GetRevokedTokenPath("id"), gomock.Any()).Return(nil).Times(2)
+	repo.EXPECT().List(gomock.Any(), constants.RevokedTokenPath).Return(nil, fmt.Errorf("err"))
+	assert.NoError(t, srv.RevokeToken("id", 10))
+	// remove expired revoked tokens
+	notExpired := strconv.FormatInt(timeutil.Now()/1000+100, 10)
+	repo.EXPECT().List(gomock.Any(), constants.RevokedTokenPath).Return([]state.KeyValue{
+		{Key: constants.GetRevokedTokenPath("expired1"), Value: []byte("10")},
+		{Key: constants.GetRevokedTokenPath("expired2"), Value: []byte("10")},
+		{Key: constants.GetRevokedTokenPath("id"), Value: []byte(notExpired)},
+	}, nil)
+	repo.EXPECT().Delete(gomock.Any(), constants.GetRevokedTokenPath("expired1")).Return(nil)
+	repo.EXPECT().Delete(gomock.Any(), constants.GetRevokedTokenPath("expired2")).Return(fmt.Errorf("err"))
+	assert.NoError(t, srv.RevokeToken("id", timeutil.Now()/1000+100))
+
+	repo.EXPECT().Get(gomock.Any(), constants.GetRevokedTokenPath("id")).Return([]byte(notExpired), nil)
+	revoked, err := srv.IsTokenRevoked("id")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRevokedTokenPath("id")).Return(nil, state.ErrNotExist)
+	revoked, err = srv.IsTokenRevoked("id")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRevokedTokenPath("id")).Return(nil, fmt.Errorf("err"))
+	_, err = srv.IsTokenRevoked("id")
+	assert.Error(t, err)
+}
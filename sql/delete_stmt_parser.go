@@ -0,0 +1,78 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/strutil"
+	"github.com/lindb/lindb/sql/grammar"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// deleteType represents the type of drop/delete statement
+type deleteType uint8
+
+// Defines all types of drop/delete statement
+const (
+	dropDatabase deleteType = iota + 1
+	dropMetric
+	deleteSeries
+)
+
+// deleteStmtParser represents drop/delete statement parser
+type deleteStmtParser struct {
+	baseStmtParser
+	deleteType deleteType
+	database   string
+}
+
+// newDeleteStmtParser creates a new drop/delete statement parser
+func newDeleteStmtParser(deleteType deleteType) *deleteStmtParser {
+	return &deleteStmtParser{
+		deleteType: deleteType,
+		baseStmtParser: baseStmtParser{
+			exprStack: collections.NewStack(),
+			namespace: constants.DefaultNamespace,
+		},
+	}
+}
+
+// build builds the drop/delete statement
+func (s *deleteStmtParser) build() (stmt.Statement, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	switch s.deleteType {
+	case dropDatabase:
+		return &stmt.DropDatabase{Database: s.database}, nil
+	case dropMetric:
+		return &stmt.DropMetric{Namespace: s.namespace, MetricName: s.metricName}, nil
+	default:
+		return &stmt.DeleteSeries{
+			Namespace:  s.namespace,
+			MetricName: s.metricName,
+			Condition:  s.condition,
+		}, nil
+	}
+}
+
+// visitDatabaseName visits when production database name expression is entered
+func (s *deleteStmtParser) visitDatabaseName(ctx *grammar.DatabaseNameContext) {
+	s.database = strutil.GetStringValue(ctx.Ident().GetText())
+}
@@ -0,0 +1,100 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestDeleteStmt_DropDatabase(t *testing.T) {
+	q, err := Parse("drop database db1")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.DropDatabase{Database: "db1"}, q)
+	q, err = Parse("DROP DATABASE 'db-test'")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.DropDatabase{Database: "db-test"}, q)
+
+	_, err = Parse("drop database")
+	assert.Error(t, err)
+	_, err = Parse("drop database db1 db2")
+	assert.Error(t, err)
+	_, err = Parse("drop")
+	assert.Error(t, err)
+	_, err = Parse("drop series")
+	assert.Error(t, err)
+	_, err = Parse("drop database ,")
+	assert.Error(t, err)
+}
+
+func TestDeleteStmt_DropMetric(t *testing.T) {
+	q, err := Parse("drop metric cpu.load")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.DropMetric{Namespace: constants.DefaultNamespace, MetricName: "cpu.load"}, q)
+	q, err = Parse("drop measurement on ns cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.DropMetric{Namespace: "ns", MetricName: "cpu"}, q)
+	// on is non-reserved word, so can be metric name
+	q, err = Parse("drop metric on")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.DropMetric{Namespace: constants.DefaultNamespace, MetricName: "on"}, q)
+
+	_, err = Parse("drop metric")
+	assert.Error(t, err)
+	_, err = Parse("drop metric on ns")
+	assert.Error(t, err)
+	_, err = Parse("drop metric cpu where")
+	assert.Error(t, err)
+}
+
+func TestDeleteStmt_DeleteSeries(t *testing.T) {
+	q, err := Parse("delete series on ns from cpu where host='1.1.1.1' and region in ('sh','bj')")
+	assert.NoError(t, err)
+	deleteStmt := q.(*stmt.DeleteSeries)
+	assert.Equal(t, "ns", deleteStmt.Namespace)
+	assert.Equal(t, "cpu", deleteStmt.MetricName)
+	assert.Equal(t, "host=1.1.1.1andregion in (sh,bj)", deleteStmt.Condition.Rewrite())
+	q, err = Parse("DELETE SERIES from cpu where host='1.1.1.1'")
+	assert.NoError(t, err)
+	deleteStmt = q.(*stmt.DeleteSeries)
+	assert.Equal(t, constants.DefaultNamespace, deleteStmt.Namespace)
+
+	_, err = Parse("delete")
+	assert.Error(t, err)
+	_, err = Parse("delete series")
+	assert.Error(t, err)
+	_, err = Parse("delete metric cpu")
+	assert.Error(t, err)
+	_, err = Parse("delete series from cpu")
+	assert.Error(t, err)
+	_, err = Parse("delete series from cpu where host='1.1.1.1' limit 10")
+	assert.Error(t, err)
+	_, err = Parse("delete series from cpu where host='1.1.1.1' and time>now()-1h")
+	assert.Error(t, err)
+	_, err = Parse("delete series from cpu where time>now()-1h")
+	assert.Error(t, err)
+	_, err = Parse("delete series from where host='1.1.1.1'")
+	assert.Error(t, err)
+	// delete/metric/series are non-reserved words
+	_, err = Parse("select series from metric where delete='1'")
+	assert.NoError(t, err)
+}
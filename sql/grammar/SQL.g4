@@ -9,7 +9,12 @@ statementList           : showDatabaseStmt
                           | showFieldsStmt
                           | showTagKeysStmt
                           | showTagValuesStmt
-                          | queryStmt;
+                          | queryStmt
+                          | dropDatabaseStmt
+                          | dropMetricStmt
+                          | deleteSeriesStmt
+                          | showQueriesStmt
+                          | killQueryStmt;
 //meta data query statement
 showDatabaseStmt     : T_SHOW T_DATASBAES ;
 showNameSpacesStmt   : T_SHOW T_NAMESPACES (T_WHERE T_NAMESPACE T_EQUAL prefix)? limitClause?;
@@ -104,7 +109,7 @@ intervalItem            :
                          | T_YEAR
                          ;
 exprFunc                : funcName T_OPEN_P exprFuncParams? T_CLOSE_P ;
-funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_STDDEV | T_HISTOGRAM;
+funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_STDDEV | T_HISTOGRAM | T_RATE | T_IRATE | T_DELTA | T_DERIVATIVE | T_QUANTILE;
 exprFuncParams          : funcParam (T_COMMA funcParam)* ;
 funcParam               :
                            fieldExpr
@@ -130,6 +135,7 @@ ident                    :  (L_ID | nonReservedWords) ('.' (L_ID | nonReservedWo
 
 nonReservedWords      :
                           T_CREATE
+                        | T_DELETE
                         | T_INTERVAL
                         | T_SHARD
                         | T_REPLICATION
@@ -143,6 +149,8 @@ nonReservedWords      :
                         | T_NODE
                         | T_MEASUREMENTS
                         | T_MEASUREMENT
+                        | T_METRIC
+                        | T_SERIES
                         | T_FIELD
                         | T_FIELDS
                         | T_TAG
@@ -190,13 +198,29 @@ nonReservedWords      :
                         | T_AVG
                         | T_STDDEV
                         | T_HISTOGRAM
+                        | T_RATE
+                        | T_IRATE
+                        | T_DELTA
+                        | T_DERIVATIVE
+                        | T_QUANTILE
                         ;
 
+//drop/delete statement
+dropDatabaseStmt     : T_DROP T_DATASBAE databaseName ;
+dropMetricStmt       : T_DROP (T_MEASUREMENT | T_METRIC) (T_ON namespace)? metricName ;
+deleteSeriesStmt     : T_DELETE T_SERIES (T_ON namespace)? fromClause T_WHERE tagFilterExpr ;
+databaseName         : ident ;
+
+//running query manage statement
+showQueriesStmt      : T_SHOW T_QUERIES ;
+killQueryStmt        : T_KILL T_QUERY L_INT ;
+
 // Lexer rules
 T_CREATE             : C R E A T E                      ;
 T_UPDATE             : U P D A T E                      ;
 T_SET                : S E T                            ;
 T_DROP               : D R O P                          ;
+T_DELETE             : D E L E T E                      ;
 T_INTERVAL           : I N T E R V A L                  ;
 T_INTERVAL_NAME      : N A M E                          ;
 T_SHARD              : S H A R D                        ;
@@ -215,6 +239,8 @@ T_NAMESPACES         : N A M E S P A C E S              ;
 T_NODE               : N O D E                          ;
 T_MEASUREMENTS       : M E A S U R E M E N T S          ;
 T_MEASUREMENT        : M E A S U R E M E N T            ;
+T_METRIC             : M E T R I C                      ;
+T_SERIES             : S E R I E S                      ;
 T_FIELD              : F I E L D                        ;
 T_FIELDS             : F I E L D S                      ;
 T_TAG                : T A G                            ;
@@ -264,6 +290,11 @@ T_COUNT              : C O U N T                        ;
 T_AVG                : A V G                            ;
 T_STDDEV             : S T D D E V                      ;
 T_HISTOGRAM          : H I S T O G R A M                ;
+T_RATE               : R A T E                          ;
+T_IRATE              : I R A T E                        ;
+T_DELTA              : D E L T A                        ;
+T_DERIVATIVE         : D E R I V A T I V E              ;
+T_QUANTILE           : Q U A N T I L E                  ;
 
 //time unit
 T_SECOND             : S                                ;
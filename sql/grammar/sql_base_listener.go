@@ -366,3 +366,39 @@ func (s *BaseSQLListener) EnterNonReservedWords(ctx *NonReservedWordsContext) {}
 
 // ExitNonReservedWords is called when production nonReservedWords is exited.
 func (s *BaseSQLListener) ExitNonReservedWords(ctx *NonReservedWordsContext) {}
+
+// EnterDropDatabaseStmt is called when production dropDatabaseStmt is entered.
+func (s *BaseSQLListener) EnterDropDatabaseStmt(ctx *DropDatabaseStmtContext) {}
+
+// ExitDropDatabaseStmt is called when production dropDatabaseStmt is exited.
+func (s *BaseSQLListener) ExitDropDatabaseStmt(ctx *DropDatabaseStmtContext) {}
+
+// EnterDropMetricStmt is called when production dropMetricStmt is entered.
+func (s *BaseSQLListener) EnterDropMetricStmt(ctx *DropMetricStmtContext) {}
+
+// ExitDropMetricStmt is called when production dropMetricStmt is exited.
+func (s *BaseSQLListener) ExitDropMetricStmt(ctx *DropMetricStmtContext) {}
+
+// EnterDeleteSeriesStmt is called when production deleteSeriesStmt is entered.
+func (s *BaseSQLListener) EnterDeleteSeriesStmt(ctx *DeleteSeriesStmtContext) {}
+
+// ExitDeleteSeriesStmt is called when production deleteSeriesStmt is exited.
+func (s *BaseSQLListener) ExitDeleteSeriesStmt(ctx *DeleteSeriesStmtContext) {}
+
+// EnterDatabaseName is called when production databaseName is entered.
+func (s *BaseSQLListener) EnterDatabaseName(ctx *DatabaseNameContext) {}
+
+// ExitDatabaseName is called when production databaseName is exited.
+func (s *BaseSQLListener) ExitDatabaseName(ctx *DatabaseNameContext) {}
+
+// EnterShowQueriesStmt is called when production showQueriesStmt is entered.
+func (s *BaseSQLListener) EnterShowQueriesStmt(ctx *ShowQueriesStmtContext) {}
+
+// ExitShowQueriesStmt is called when production showQueriesStmt is exited.
+func (s *BaseSQLListener) ExitShowQueriesStmt(ctx *ShowQueriesStmtContext) {}
+
+// EnterKillQueryStmt is called when production killQueryStmt is entered.
+func (s *BaseSQLListener) EnterKillQueryStmt(ctx *KillQueryStmtContext) {}
+
+// ExitKillQueryStmt is called when production killQueryStmt is exited.
+func (s *BaseSQLListener) ExitKillQueryStmt(ctx *KillQueryStmtContext) {}
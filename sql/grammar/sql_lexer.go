@@ -30,412 +30,445 @@ var _ = unicode.IsLetter
 
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 105, 902, 
-	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 
-	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 
-	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 
-	18, 9, 18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 
-	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 
-	28, 4, 29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 4, 32, 9, 32, 4, 33, 9, 33, 
-	4, 34, 9, 34, 4, 35, 9, 35, 4, 36, 9, 36, 4, 37, 9, 37, 4, 38, 9, 38, 4, 
-	39, 9, 39, 4, 40, 9, 40, 4, 41, 9, 41, 4, 42, 9, 42, 4, 43, 9, 43, 4, 44, 
-	9, 44, 4, 45, 9, 45, 4, 46, 9, 46, 4, 47, 9, 47, 4, 48, 9, 48, 4, 49, 9, 
-	49, 4, 50, 9, 50, 4, 51, 9, 51, 4, 52, 9, 52, 4, 53, 9, 53, 4, 54, 9, 54, 
-	4, 55, 9, 55, 4, 56, 9, 56, 4, 57, 9, 57, 4, 58, 9, 58, 4, 59, 9, 59, 4, 
-	60, 9, 60, 4, 61, 9, 61, 4, 62, 9, 62, 4, 63, 9, 63, 4, 64, 9, 64, 4, 65, 
-	9, 65, 4, 66, 9, 66, 4, 67, 9, 67, 4, 68, 9, 68, 4, 69, 9, 69, 4, 70, 9, 
-	70, 4, 71, 9, 71, 4, 72, 9, 72, 4, 73, 9, 73, 4, 74, 9, 74, 4, 75, 9, 75, 
-	4, 76, 9, 76, 4, 77, 9, 77, 4, 78, 9, 78, 4, 79, 9, 79, 4, 80, 9, 80, 4, 
-	81, 9, 81, 4, 82, 9, 82, 4, 83, 9, 83, 4, 84, 9, 84, 4, 85, 9, 85, 4, 86, 
-	9, 86, 4, 87, 9, 87, 4, 88, 9, 88, 4, 89, 9, 89, 4, 90, 9, 90, 4, 91, 9, 
-	91, 4, 92, 9, 92, 4, 93, 9, 93, 4, 94, 9, 94, 4, 95, 9, 95, 4, 96, 9, 96, 
-	4, 97, 9, 97, 4, 98, 9, 98, 4, 99, 9, 99, 4, 100, 9, 100, 4, 101, 9, 101, 
-	4, 102, 9, 102, 4, 103, 9, 103, 4, 104, 9, 104, 4, 105, 9, 105, 4, 106, 
-	9, 106, 4, 107, 9, 107, 4, 108, 9, 108, 4, 109, 9, 109, 4, 110, 9, 110, 
-	4, 111, 9, 111, 4, 112, 9, 112, 4, 113, 9, 113, 4, 114, 9, 114, 4, 115, 
-	9, 115, 4, 116, 9, 116, 4, 117, 9, 117, 4, 118, 9, 118, 4, 119, 9, 119, 
-	4, 120, 9, 120, 4, 121, 9, 121, 4, 122, 9, 122, 4, 123, 9, 123, 4, 124, 
-	9, 124, 4, 125, 9, 125, 4, 126, 9, 126, 4, 127, 9, 127, 4, 128, 9, 128, 
-	4, 129, 9, 129, 4, 130, 9, 130, 4, 131, 9, 131, 4, 132, 9, 132, 4, 133, 
-	9, 133, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 
-	3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 
-	5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 
-	7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 
-	9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 
-	3, 10, 3, 11, 3, 11, 3, 11, 3, 11, 3, 11, 3, 11, 3, 11, 3, 11, 3, 12, 3, 
-	12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 
-	3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3, 14, 3, 
-	14, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 3, 17, 3, 17, 
-	3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 18, 3, 18, 3, 18, 3, 
-	18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 19, 
-	3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 
-	20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 21, 3, 21, 3, 21, 3, 21, 
-	3, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 
-	22, 3, 22, 3, 22, 3, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 
-	3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 
-	24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 26, 3, 26, 3, 26, 
-	3, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 28, 3, 28, 3, 28, 3, 28, 3, 
-	28, 3, 29, 3, 29, 3, 29, 3, 29, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 31, 
-	3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 32, 3, 32, 3, 32, 3, 32, 3, 
-	32, 3, 32, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 34, 3, 34, 3, 34, 3, 34, 
-	3, 34, 3, 34, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 36, 3, 36, 3, 
-	36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 37, 3, 37, 3, 37, 3, 37, 3, 37, 
-	3, 37, 3, 38, 3, 38, 3, 38, 3, 38, 3, 38, 3, 38, 3, 38, 3, 38, 3, 39, 3, 
-	39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 40, 3, 40, 
-	3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 41, 3, 41, 3, 41, 3, 42, 3, 42, 3, 
-	42, 3, 42, 3, 43, 3, 43, 3, 43, 3, 44, 3, 44, 3, 44, 3, 44, 3, 44, 3, 45, 
-	3, 45, 3, 45, 3, 45, 3, 45, 3, 46, 3, 46, 3, 46, 3, 46, 3, 46, 3, 46, 3, 
-	46, 3, 46, 3, 46, 3, 47, 3, 47, 3, 47, 3, 47, 3, 47, 3, 47, 3, 48, 3, 48, 
-	3, 48, 3, 48, 3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 50, 3, 50, 3, 50, 3, 
-	50, 3, 50, 3, 51, 3, 51, 3, 51, 3, 51, 3, 52, 3, 52, 3, 52, 3, 52, 3, 52, 
-	3, 52, 3, 52, 3, 52, 3, 53, 3, 53, 3, 53, 3, 54, 3, 54, 3, 54, 3, 54, 3, 
-	54, 3, 54, 3, 55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 56, 3, 56, 
-	3, 56, 3, 57, 3, 57, 3, 57, 3, 57, 3, 58, 3, 58, 3, 58, 3, 58, 3, 58, 3, 
-	58, 3, 59, 3, 59, 3, 59, 3, 59, 3, 59, 3, 60, 3, 60, 3, 60, 3, 60, 3, 61, 
-	3, 61, 3, 61, 3, 62, 3, 62, 3, 62, 3, 62, 3, 63, 3, 63, 3, 63, 3, 63, 3, 
-	63, 3, 63, 3, 63, 3, 63, 3, 64, 3, 64, 3, 64, 3, 64, 3, 65, 3, 65, 3, 65, 
-	3, 65, 3, 66, 3, 66, 3, 66, 3, 66, 3, 67, 3, 67, 3, 67, 3, 67, 3, 67, 3, 
-	67, 3, 68, 3, 68, 3, 68, 3, 68, 3, 69, 3, 69, 3, 69, 3, 69, 3, 69, 3, 69, 
-	3, 69, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 
-	70, 3, 71, 3, 71, 3, 72, 3, 72, 3, 73, 3, 73, 3, 74, 3, 74, 3, 75, 3, 75, 
-	3, 76, 3, 76, 3, 77, 3, 77, 3, 78, 3, 78, 3, 79, 3, 79, 3, 80, 3, 80, 3, 
-	81, 3, 81, 3, 81, 3, 82, 3, 82, 3, 82, 3, 83, 3, 83, 3, 84, 3, 84, 3, 84, 
-	3, 85, 3, 85, 3, 86, 3, 86, 3, 86, 3, 87, 3, 87, 3, 87, 3, 88, 3, 88, 3, 
-	88, 3, 89, 3, 89, 3, 90, 3, 90, 3, 91, 3, 91, 3, 92, 3, 92, 3, 93, 3, 93, 
-	3, 94, 3, 94, 3, 95, 3, 95, 3, 96, 3, 96, 3, 97, 3, 97, 3, 98, 3, 98, 3, 
-	99, 3, 99, 3, 100, 3, 100, 3, 101, 3, 101, 3, 102, 6, 102, 763, 10, 102, 
-	13, 102, 14, 102, 764, 3, 103, 6, 103, 768, 10, 103, 13, 103, 14, 103, 
-	769, 3, 103, 3, 103, 3, 103, 7, 103, 775, 10, 103, 12, 103, 14, 103, 778, 
-	11, 103, 3, 103, 3, 103, 6, 103, 782, 10, 103, 13, 103, 14, 103, 783, 5, 
-	103, 786, 10, 103, 3, 104, 6, 104, 789, 10, 104, 13, 104, 14, 104, 790, 
-	3, 104, 3, 104, 3, 105, 3, 105, 3, 106, 3, 106, 3, 107, 3, 107, 3, 107, 
-	3, 107, 7, 107, 803, 10, 107, 12, 107, 14, 107, 806, 11, 107, 3, 107, 3, 
-	107, 3, 107, 7, 107, 811, 10, 107, 12, 107, 14, 107, 814, 11, 107, 3, 107, 
-	3, 107, 3, 107, 3, 107, 3, 107, 6, 107, 821, 10, 107, 13, 107, 14, 107, 
-	822, 3, 107, 3, 107, 7, 107, 827, 10, 107, 12, 107, 14, 107, 830, 11, 107, 
-	3, 107, 3, 107, 3, 107, 7, 107, 835, 10, 107, 12, 107, 14, 107, 838, 11, 
-	107, 3, 107, 3, 107, 3, 107, 7, 107, 843, 10, 107, 12, 107, 14, 107, 846, 
-	11, 107, 3, 107, 5, 107, 849, 10, 107, 3, 108, 3, 108, 3, 109, 3, 109, 
-	3, 110, 3, 110, 3, 111, 3, 111, 3, 112, 3, 112, 3, 113, 3, 113, 3, 114, 
-	3, 114, 3, 115, 3, 115, 3, 116, 3, 116, 3, 117, 3, 117, 3, 118, 3, 118, 
-	3, 119, 3, 119, 3, 120, 3, 120, 3, 121, 3, 121, 3, 122, 3, 122, 3, 123, 
-	3, 123, 3, 124, 3, 124, 3, 125, 3, 125, 3, 126, 3, 126, 3, 127, 3, 127, 
-	3, 128, 3, 128, 3, 129, 3, 129, 3, 130, 3, 130, 3, 131, 3, 131, 3, 132, 
-	3, 132, 3, 133, 3, 133, 6, 812, 828, 836, 844, 2, 134, 3, 3, 5, 4, 7, 5, 
-	9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 
-	15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 
-	24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 63, 
-	33, 65, 34, 67, 35, 69, 36, 71, 37, 73, 38, 75, 39, 77, 40, 79, 41, 81, 
-	42, 83, 43, 85, 44, 87, 45, 89, 46, 91, 47, 93, 48, 95, 49, 97, 50, 99, 
-	51, 101, 52, 103, 53, 105, 54, 107, 55, 109, 56, 111, 57, 113, 58, 115, 
-	59, 117, 60, 119, 61, 121, 62, 123, 63, 125, 64, 127, 65, 129, 66, 131, 
-	67, 133, 68, 135, 69, 137, 70, 139, 71, 141, 72, 143, 73, 145, 74, 147, 
-	75, 149, 76, 151, 77, 153, 78, 155, 79, 157, 80, 159, 81, 161, 82, 163, 
-	83, 165, 84, 167, 85, 169, 86, 171, 87, 173, 88, 175, 89, 177, 90, 179, 
-	91, 181, 92, 183, 93, 185, 94, 187, 95, 189, 96, 191, 97, 193, 98, 195, 
-	99, 197, 100, 199, 101, 201, 102, 203, 103, 205, 104, 207, 105, 209, 2, 
-	211, 2, 213, 2, 215, 2, 217, 2, 219, 2, 221, 2, 223, 2, 225, 2, 227, 2, 
-	229, 2, 231, 2, 233, 2, 235, 2, 237, 2, 239, 2, 241, 2, 243, 2, 245, 2, 
-	247, 2, 249, 2, 251, 2, 253, 2, 255, 2, 257, 2, 259, 2, 261, 2, 263, 2, 
-	265, 2, 3, 2, 34, 3, 2, 48, 48, 5, 2, 11, 12, 15, 15, 34, 34, 3, 2, 50, 
-	59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 48, 97, 97, 6, 2, 37, 38, 60, 60, 
-	66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100, 4, 2, 69, 
-	69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71, 103, 103, 4, 2, 72, 
-	72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74, 106, 106, 4, 2, 75, 
-	75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77, 109, 109, 4, 2, 78, 
-	78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80, 112, 112, 4, 2, 81, 
-	81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83, 115, 115, 4, 2, 84, 
-	84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86, 118, 118, 4, 2, 87, 
-	87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89, 121, 121, 4, 2, 90, 
-	90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92, 124, 124, 2, 893, 2, 
-	3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 
-	11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 
-	2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 
-	2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 
-	2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 
-	2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 
-	3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 
-	57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63, 3, 2, 2, 2, 
-	2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 2, 2, 2, 2, 71, 3, 2, 2, 
-	2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2, 79, 3, 2, 
-	2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 2, 87, 3, 
-	2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2, 2, 95, 
-	3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2, 2, 2, 2, 
-	103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109, 3, 2, 
-	2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2, 2, 117, 
-	3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3, 2, 2, 2, 
-	2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2, 131, 3, 
-	2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2, 2, 2, 2, 137, 3, 2, 2, 2, 2, 
-	139, 3, 2, 2, 2, 2, 141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 
-	2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 
-	3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 2, 157, 3, 2, 2, 2, 2, 159, 3, 2, 2, 2, 
-	2, 161, 3, 2, 2, 2, 2, 163, 3, 2, 2, 2, 2, 165, 3, 2, 2, 2, 2, 167, 3, 
-	2, 2, 2, 2, 169, 3, 2, 2, 2, 2, 171, 3, 2, 2, 2, 2, 173, 3, 2, 2, 2, 2, 
-	175, 3, 2, 2, 2, 2, 177, 3, 2, 2, 2, 2, 179, 3, 2, 2, 2, 2, 181, 3, 2, 
-	2, 2, 2, 183, 3, 2, 2, 2, 2, 185, 3, 2, 2, 2, 2, 187, 3, 2, 2, 2, 2, 189, 
-	3, 2, 2, 2, 2, 191, 3, 2, 2, 2, 2, 193, 3, 2, 2, 2, 2, 195, 3, 2, 2, 2, 
-	2, 197, 3, 2, 2, 2, 2, 199, 3, 2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 
-	2, 2, 2, 2, 205, 3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 
-	274, 3, 2, 2, 2, 7, 281, 3, 2, 2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 
-	2, 2, 13, 299, 3, 2, 2, 2, 15, 304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 
-	322, 3, 2, 2, 2, 21, 326, 3, 2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 
-	2, 2, 2, 27, 352, 3, 2, 2, 2, 29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 
-	33, 365, 3, 2, 2, 2, 35, 374, 3, 2, 2, 2, 37, 384, 3, 2, 2, 2, 39, 394, 
-	3, 2, 2, 2, 41, 405, 3, 2, 2, 2, 43, 410, 3, 2, 2, 2, 45, 423, 3, 2, 2, 
-	2, 47, 435, 3, 2, 2, 2, 49, 441, 3, 2, 2, 2, 51, 448, 3, 2, 2, 2, 53, 452, 
-	3, 2, 2, 2, 55, 457, 3, 2, 2, 2, 57, 462, 3, 2, 2, 2, 59, 466, 3, 2, 2, 
-	2, 61, 471, 3, 2, 2, 2, 63, 478, 3, 2, 2, 2, 65, 484, 3, 2, 2, 2, 67, 489, 
-	3, 2, 2, 2, 69, 495, 3, 2, 2, 2, 71, 501, 3, 2, 2, 2, 73, 509, 3, 2, 2, 
-	2, 75, 515, 3, 2, 2, 2, 77, 523, 3, 2, 2, 2, 79, 533, 3, 2, 2, 2, 81, 540, 
-	3, 2, 2, 2, 83, 543, 3, 2, 2, 2, 85, 547, 3, 2, 2, 2, 87, 550, 3, 2, 2, 
-	2, 89, 555, 3, 2, 2, 2, 91, 560, 3, 2, 2, 2, 93, 569, 3, 2, 2, 2, 95, 575, 
-	3, 2, 2, 2, 97, 579, 3, 2, 2, 2, 99, 584, 3, 2, 2, 2, 101, 589, 3, 2, 2, 
-	2, 103, 593, 3, 2, 2, 2, 105, 601, 3, 2, 2, 2, 107, 604, 3, 2, 2, 2, 109, 
-	610, 3, 2, 2, 2, 111, 617, 3, 2, 2, 2, 113, 620, 3, 2, 2, 2, 115, 624, 
-	3, 2, 2, 2, 117, 630, 3, 2, 2, 2, 119, 635, 3, 2, 2, 2, 121, 639, 3, 2, 
-	2, 2, 123, 642, 3, 2, 2, 2, 125, 646, 3, 2, 2, 2, 127, 654, 3, 2, 2, 2, 
-	129, 658, 3, 2, 2, 2, 131, 662, 3, 2, 2, 2, 133, 666, 3, 2, 2, 2, 135, 
-	672, 3, 2, 2, 2, 137, 676, 3, 2, 2, 2, 139, 683, 3, 2, 2, 2, 141, 693, 
-	3, 2, 2, 2, 143, 695, 3, 2, 2, 2, 145, 697, 3, 2, 2, 2, 147, 699, 3, 2, 
-	2, 2, 149, 701, 3, 2, 2, 2, 151, 703, 3, 2, 2, 2, 153, 705, 3, 2, 2, 2, 
-	155, 707, 3, 2, 2, 2, 157, 709, 3, 2, 2, 2, 159, 711, 3, 2, 2, 2, 161, 
-	713, 3, 2, 2, 2, 163, 716, 3, 2, 2, 2, 165, 719, 3, 2, 2, 2, 167, 721, 
-	3, 2, 2, 2, 169, 724, 3, 2, 2, 2, 171, 726, 3, 2, 2, 2, 173, 729, 3, 2, 
-	2, 2, 175, 732, 3, 2, 2, 2, 177, 735, 3, 2, 2, 2, 179, 737, 3, 2, 2, 2, 
-	181, 739, 3, 2, 2, 2, 183, 741, 3, 2, 2, 2, 185, 743, 3, 2, 2, 2, 187, 
-	745, 3, 2, 2, 2, 189, 747, 3, 2, 2, 2, 191, 749, 3, 2, 2, 2, 193, 751, 
-	3, 2, 2, 2, 195, 753, 3, 2, 2, 2, 197, 755, 3, 2, 2, 2, 199, 757, 3, 2, 
-	2, 2, 201, 759, 3, 2, 2, 2, 203, 762, 3, 2, 2, 2, 205, 785, 3, 2, 2, 2, 
-	207, 788, 3, 2, 2, 2, 209, 794, 3, 2, 2, 2, 211, 796, 3, 2, 2, 2, 213, 
-	848, 3, 2, 2, 2, 215, 850, 3, 2, 2, 2, 217, 852, 3, 2, 2, 2, 219, 854, 
-	3, 2, 2, 2, 221, 856, 3, 2, 2, 2, 223, 858, 3, 2, 2, 2, 225, 860, 3, 2, 
-	2, 2, 227, 862, 3, 2, 2, 2, 229, 864, 3, 2, 2, 2, 231, 866, 3, 2, 2, 2, 
-	233, 868, 3, 2, 2, 2, 235, 870, 3, 2, 2, 2, 237, 872, 3, 2, 2, 2, 239, 
-	874, 3, 2, 2, 2, 241, 876, 3, 2, 2, 2, 243, 878, 3, 2, 2, 2, 245, 880, 
-	3, 2, 2, 2, 247, 882, 3, 2, 2, 2, 249, 884, 3, 2, 2, 2, 251, 886, 3, 2, 
-	2, 2, 253, 888, 3, 2, 2, 2, 255, 890, 3, 2, 2, 2, 257, 892, 3, 2, 2, 2, 
-	259, 894, 3, 2, 2, 2, 261, 896, 3, 2, 2, 2, 263, 898, 3, 2, 2, 2, 265, 
-	900, 3, 2, 2, 2, 267, 268, 5, 219, 110, 2, 268, 269, 5, 249, 125, 2, 269, 
-	270, 5, 223, 112, 2, 270, 271, 5, 215, 108, 2, 271, 272, 5, 253, 127, 2, 
-	272, 273, 5, 223, 112, 2, 273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 128, 2, 
-	275, 276, 5, 245, 123, 2, 276, 277, 5, 221, 111, 2, 277, 278, 5, 215, 108, 
-	2, 278, 279, 5, 253, 127, 2, 279, 280, 5, 223, 112, 2, 280, 6, 3, 2, 2, 
-	2, 281, 282, 5, 251, 126, 2, 282, 283, 5, 223, 112, 2, 283, 284, 5, 253, 
-	127, 2, 284, 8, 3, 2, 2, 2, 285, 286, 5, 221, 111, 2, 286, 287, 5, 249, 
-	125, 2, 287, 288, 5, 243, 122, 2, 288, 289, 5, 245, 123, 2, 289, 10, 3, 
-	2, 2, 2, 290, 291, 5, 231, 116, 2, 291, 292, 5, 241, 121, 2, 292, 293, 
-	5, 253, 127, 2, 293, 294, 5, 223, 112, 2, 294, 295, 5, 249, 125, 2, 295, 
-	296, 5, 257, 129, 2, 296, 297, 5, 215, 108, 2, 297, 298, 5, 237, 119, 2, 
-	298, 12, 3, 2, 2, 2, 299, 300, 5, 241, 121, 2, 300, 301, 5, 215, 108, 2, 
-	301, 302, 5, 239, 120, 2, 302, 303, 5, 223, 112, 2, 303, 14, 3, 2, 2, 2, 
-	304, 305, 5, 251, 126, 2, 305, 306, 5, 229, 115, 2, 306, 307, 5, 215, 108, 
-	2, 307, 308, 5, 249, 125, 2, 308, 309, 5, 221, 111, 2, 309, 16, 3, 2, 2, 
-	2, 310, 311, 5, 249, 125, 2, 311, 312, 5, 223, 112, 2, 312, 313, 5, 245, 
-	123, 2, 313, 314, 5, 237, 119, 2, 314, 315, 5, 231, 116, 2, 315, 316, 5, 
-	219, 110, 2, 316, 317, 5, 215, 108, 2, 317, 318, 5, 253, 127, 2, 318, 319, 
-	5, 231, 116, 2, 319, 320, 5, 243, 122, 2, 320, 321, 5, 241, 121, 2, 321, 
-	18, 3, 2, 2, 2, 322, 323, 5, 253, 127, 2, 323, 324, 5, 253, 127, 2, 324, 
-	325, 5, 237, 119, 2, 325, 20, 3, 2, 2, 2, 326, 327, 5, 239, 120, 2, 327, 
-	328, 5, 223, 112, 2, 328, 329, 5, 253, 127, 2, 329, 330, 5, 215, 108, 2, 
-	330, 331, 5, 253, 127, 2, 331, 332, 5, 253, 127, 2, 332, 333, 5, 237, 119, 
-	2, 333, 22, 3, 2, 2, 2, 334, 335, 5, 245, 123, 2, 335, 336, 5, 215, 108, 
-	2, 336, 337, 5, 251, 126, 2, 337, 338, 5, 253, 127, 2, 338, 339, 5, 253, 
-	127, 2, 339, 340, 5, 253, 127, 2, 340, 341, 5, 237, 119, 2, 341, 24, 3, 
-	2, 2, 2, 342, 343, 5, 225, 113, 2, 343, 344, 5, 255, 128, 2, 344, 345, 
-	5, 253, 127, 2, 345, 346, 5, 255, 128, 2, 346, 347, 5, 249, 125, 2, 347, 
-	348, 5, 223, 112, 2, 348, 349, 5, 253, 127, 2, 349, 350, 5, 253, 127, 2, 
-	350, 351, 5, 237, 119, 2, 351, 26, 3, 2, 2, 2, 352, 353, 5, 235, 118, 2, 
-	353, 354, 5, 231, 116, 2, 354, 355, 5, 237, 119, 2, 355, 356, 5, 237, 119, 
-	2, 356, 28, 3, 2, 2, 2, 357, 358, 5, 243, 122, 2, 358, 359, 5, 241, 121, 
-	2, 359, 30, 3, 2, 2, 2, 360, 361, 5, 251, 126, 2, 361, 362, 5, 229, 115, 
-	2, 362, 363, 5, 243, 122, 2, 363, 364, 5, 259, 130, 2, 364, 32, 3, 2, 2, 
-	2, 365, 366, 5, 221, 111, 2, 366, 367, 5, 215, 108, 2, 367, 368, 5, 253, 
-	127, 2, 368, 369, 5, 215, 108, 2, 369, 370, 5, 217, 109, 2, 370, 371, 5, 
-	215, 108, 2, 371, 372, 5, 251, 126, 2, 372, 373, 5, 223, 112, 2, 373, 34, 
-	3, 2, 2, 2, 374, 375, 5, 221, 111, 2, 375, 376, 5, 215, 108, 2, 376, 377, 
-	5, 253, 127, 2, 377, 378, 5, 215, 108, 2, 378, 379, 5, 217, 109, 2, 379, 
-	380, 5, 215, 108, 2, 380, 381, 5, 251, 126, 2, 381, 382, 5, 223, 112, 2, 
-	382, 383, 5, 251, 126, 2, 383, 36, 3, 2, 2, 2, 384, 385, 5, 241, 121, 2, 
-	385, 386, 5, 215, 108, 2, 386, 387, 5, 239, 120, 2, 387, 388, 5, 223, 112, 
-	2, 388, 389, 5, 251, 126, 2, 389, 390, 5, 245, 123, 2, 390, 391, 5, 215, 
-	108, 2, 391, 392, 5, 219, 110, 2, 392, 393, 5, 223, 112, 2, 393, 38, 3, 
-	2, 2, 2, 394, 395, 5, 241, 121, 2, 395, 396, 5, 215, 108, 2, 396, 397, 
-	5, 239, 120, 2, 397, 398, 5, 223, 112, 2, 398, 399, 5, 251, 126, 2, 399, 
-	400, 5, 245, 123, 2, 400, 401, 5, 215, 108, 2, 401, 402, 5, 219, 110, 2, 
-	402, 403, 5, 223, 112, 2, 403, 404, 5, 251, 126, 2, 404, 40, 3, 2, 2, 2, 
-	405, 406, 5, 241, 121, 2, 406, 407, 5, 243, 122, 2, 407, 408, 5, 221, 111, 
-	2, 408, 409, 5, 223, 112, 2, 409, 42, 3, 2, 2, 2, 410, 411, 5, 239, 120, 
-	2, 411, 412, 5, 223, 112, 2, 412, 413, 5, 215, 108, 2, 413, 414, 5, 251, 
-	126, 2, 414, 415, 5, 255, 128, 2, 415, 416, 5, 249, 125, 2, 416, 417, 5, 
-	223, 112, 2, 417, 418, 5, 239, 120, 2, 418, 419, 5, 223, 112, 2, 419, 420, 
-	5, 241, 121, 2, 420, 421, 5, 253, 127, 2, 421, 422, 5, 251, 126, 2, 422, 
-	44, 3, 2, 2, 2, 423, 424, 5, 239, 120, 2, 424, 425, 5, 223, 112, 2, 425, 
-	426, 5, 215, 108, 2, 426, 427, 5, 251, 126, 2, 427, 428, 5, 255, 128, 2, 
-	428, 429, 5, 249, 125, 2, 429, 430, 5, 223, 112, 2, 430, 431, 5, 239, 120, 
-	2, 431, 432, 5, 223, 112, 2, 432, 433, 5, 241, 121, 2, 433, 434, 5, 253, 
-	127, 2, 434, 46, 3, 2, 2, 2, 435, 436, 5, 225, 113, 2, 436, 437, 5, 231, 
-	116, 2, 437, 438, 5, 223, 112, 2, 438, 439, 5, 237, 119, 2, 439, 440, 5, 
-	221, 111, 2, 440, 48, 3, 2, 2, 2, 441, 442, 5, 225, 113, 2, 442, 443, 5, 
-	231, 116, 2, 443, 444, 5, 223, 112, 2, 444, 445, 5, 237, 119, 2, 445, 446, 
-	5, 221, 111, 2, 446, 447, 5, 251, 126, 2, 447, 50, 3, 2, 2, 2, 448, 449, 
-	5, 253, 127, 2, 449, 450, 5, 215, 108, 2, 450, 451, 5, 227, 114, 2, 451, 
-	52, 3, 2, 2, 2, 452, 453, 5, 231, 116, 2, 453, 454, 5, 241, 121, 2, 454, 
-	455, 5, 225, 113, 2, 455, 456, 5, 243, 122, 2, 456, 54, 3, 2, 2, 2, 457, 
-	458, 5, 235, 118, 2, 458, 459, 5, 223, 112, 2, 459, 460, 5, 263, 132, 2, 
-	460, 461, 5, 251, 126, 2, 461, 56, 3, 2, 2, 2, 462, 463, 5, 235, 118, 2, 
-	463, 464, 5, 223, 112, 2, 464, 465, 5, 263, 132, 2, 465, 58, 3, 2, 2, 2, 
-	466, 467, 5, 259, 130, 2, 467, 468, 5, 231, 116, 2, 468, 469, 5, 253, 127, 
-	2, 469, 470, 5, 229, 115, 2, 470, 60, 3, 2, 2, 2, 471, 472, 5, 257, 129, 
-	2, 472, 473, 5, 215, 108, 2, 473, 474, 5, 237, 119, 2, 474, 475, 5, 255, 
-	128, 2, 475, 476, 5, 223, 112, 2, 476, 477, 5, 251, 126, 2, 477, 62, 3, 
-	2, 2, 2, 478, 479, 5, 257, 129, 2, 479, 480, 5, 215, 108, 2, 480, 481, 
-	5, 237, 119, 2, 481, 482, 5, 255, 128, 2, 482, 483, 5, 223, 112, 2, 483, 
-	64, 3, 2, 2, 2, 484, 485, 5, 225, 113, 2, 485, 486, 5, 249, 125, 2, 486, 
-	487, 5, 243, 122, 2, 487, 488, 5, 239, 120, 2, 488, 66, 3, 2, 2, 2, 489, 
-	490, 5, 259, 130, 2, 490, 491, 5, 229, 115, 2, 491, 492, 5, 223, 112, 2, 
-	492, 493, 5, 249, 125, 2, 493, 494, 5, 223, 112, 2, 494, 68, 3, 2, 2, 2, 
-	495, 496, 5, 237, 119, 2, 496, 497, 5, 231, 116, 2, 497, 498, 5, 239, 120, 
-	2, 498, 499, 5, 231, 116, 2, 499, 500, 5, 253, 127, 2, 500, 70, 3, 2, 2, 
-	2, 501, 502, 5, 247, 124, 2, 502, 503, 5, 255, 128, 2, 503, 504, 5, 223, 
-	112, 2, 504, 505, 5, 249, 125, 2, 505, 506, 5, 231, 116, 2, 506, 507, 5, 
-	223, 112, 2, 507, 508, 5, 251, 126, 2, 508, 72, 3, 2, 2, 2, 509, 510, 5, 
-	247, 124, 2, 510, 511, 5, 255, 128, 2, 511, 512, 5, 223, 112, 2, 512, 513, 
-	5, 249, 125, 2, 513, 514, 5, 263, 132, 2, 514, 74, 3, 2, 2, 2, 515, 516, 
-	5, 223, 112, 2, 516, 517, 5, 261, 131, 2, 517, 518, 5, 245, 123, 2, 518, 
-	519, 5, 237, 119, 2, 519, 520, 5, 215, 108, 2, 520, 521, 5, 231, 116, 2, 
-	521, 522, 5, 241, 121, 2, 522, 76, 3, 2, 2, 2, 523, 524, 5, 259, 130, 2, 
-	524, 525, 5, 231, 116, 2, 525, 526, 5, 253, 127, 2, 526, 527, 5, 229, 115, 
-	2, 527, 528, 5, 257, 129, 2, 528, 529, 5, 215, 108, 2, 529, 530, 5, 237, 
-	119, 2, 530, 531, 5, 255, 128, 2, 531, 532, 5, 223, 112, 2, 532, 78, 3, 
-	2, 2, 2, 533, 534, 5, 251, 126, 2, 534, 535, 5, 223, 112, 2, 535, 536, 
-	5, 237, 119, 2, 536, 537, 5, 223, 112, 2, 537, 538, 5, 219, 110, 2, 538, 
-	539, 5, 253, 127, 2, 539, 80, 3, 2, 2, 2, 540, 541, 5, 215, 108, 2, 541, 
-	542, 5, 251, 126, 2, 542, 82, 3, 2, 2, 2, 543, 544, 5, 215, 108, 2, 544, 
-	545, 5, 241, 121, 2, 545, 546, 5, 221, 111, 2, 546, 84, 3, 2, 2, 2, 547, 
-	548, 5, 243, 122, 2, 548, 549, 5, 249, 125, 2, 549, 86, 3, 2, 2, 2, 550, 
-	551, 5, 225, 113, 2, 551, 552, 5, 231, 116, 2, 552, 553, 5, 237, 119, 2, 
-	553, 554, 5, 237, 119, 2, 554, 88, 3, 2, 2, 2, 555, 556, 5, 241, 121, 2, 
-	556, 557, 5, 255, 128, 2, 557, 558, 5, 237, 119, 2, 558, 559, 5, 237, 119, 
-	2, 559, 90, 3, 2, 2, 2, 560, 561, 5, 245, 123, 2, 561, 562, 5, 249, 125, 
-	2, 562, 563, 5, 223, 112, 2, 563, 564, 5, 257, 129, 2, 564, 565, 5, 231, 
-	116, 2, 565, 566, 5, 243, 122, 2, 566, 567, 5, 255, 128, 2, 567, 568, 5, 
-	251, 126, 2, 568, 92, 3, 2, 2, 2, 569, 570, 5, 243, 122, 2, 570, 571, 5, 
-	249, 125, 2, 571, 572, 5, 221, 111, 2, 572, 573, 5, 223, 112, 2, 573, 574, 
-	5, 249, 125, 2, 574, 94, 3, 2, 2, 2, 575, 576, 5, 215, 108, 2, 576, 577, 
-	5, 251, 126, 2, 577, 578, 5, 219, 110, 2, 578, 96, 3, 2, 2, 2, 579, 580, 
-	5, 221, 111, 2, 580, 581, 5, 223, 112, 2, 581, 582, 5, 251, 126, 2, 582, 
-	583, 5, 219, 110, 2, 583, 98, 3, 2, 2, 2, 584, 585, 5, 237, 119, 2, 585, 
-	586, 5, 231, 116, 2, 586, 587, 5, 235, 118, 2, 587, 588, 5, 223, 112, 2, 
-	588, 100, 3, 2, 2, 2, 589, 590, 5, 241, 121, 2, 590, 591, 5, 243, 122, 
-	2, 591, 592, 5, 253, 127, 2, 592, 102, 3, 2, 2, 2, 593, 594, 5, 217, 109, 
-	2, 594, 595, 5, 223, 112, 2, 595, 596, 5, 253, 127, 2, 596, 597, 5, 259, 
-	130, 2, 597, 598, 5, 223, 112, 2, 598, 599, 5, 223, 112, 2, 599, 600, 5, 
-	241, 121, 2, 600, 104, 3, 2, 2, 2, 601, 602, 5, 231, 116, 2, 602, 603, 
-	5, 251, 126, 2, 603, 106, 3, 2, 2, 2, 604, 605, 5, 227, 114, 2, 605, 606, 
-	5, 249, 125, 2, 606, 607, 5, 243, 122, 2, 607, 608, 5, 255, 128, 2, 608, 
-	609, 5, 245, 123, 2, 609, 108, 3, 2, 2, 2, 610, 611, 5, 229, 115, 2, 611, 
-	612, 5, 215, 108, 2, 612, 613, 5, 257, 129, 2, 613, 614, 5, 231, 116, 2, 
-	614, 615, 5, 241, 121, 2, 615, 616, 5, 227, 114, 2, 616, 110, 3, 2, 2, 
-	2, 617, 618, 5, 217, 109, 2, 618, 619, 5, 263, 132, 2, 619, 112, 3, 2, 
-	2, 2, 620, 621, 5, 225, 113, 2, 621, 622, 5, 243, 122, 2, 622, 623, 5, 
-	249, 125, 2, 623, 114, 3, 2, 2, 2, 624, 625, 5, 251, 126, 2, 625, 626, 
-	5, 253, 127, 2, 626, 627, 5, 215, 108, 2, 627, 628, 5, 253, 127, 2, 628, 
-	629, 5, 251, 126, 2, 629, 116, 3, 2, 2, 2, 630, 631, 5, 253, 127, 2, 631, 
-	632, 5, 231, 116, 2, 632, 633, 5, 239, 120, 2, 633, 634, 5, 223, 112, 2, 
-	634, 118, 3, 2, 2, 2, 635, 636, 5, 241, 121, 2, 636, 637, 5, 243, 122, 
-	2, 637, 638, 5, 259, 130, 2, 638, 120, 3, 2, 2, 2, 639, 640, 5, 231, 116, 
-	2, 640, 641, 5, 241, 121, 2, 641, 122, 3, 2, 2, 2, 642, 643, 5, 237, 119, 
-	2, 643, 644, 5, 243, 122, 2, 644, 645, 5, 227, 114, 2, 645, 124, 3, 2, 
-	2, 2, 646, 647, 5, 245, 123, 2, 647, 648, 5, 249, 125, 2, 648, 649, 5, 
-	243, 122, 2, 649, 650, 5, 225, 113, 2, 650, 651, 5, 231, 116, 2, 651, 652, 
-	5, 237, 119, 2, 652, 653, 5, 223, 112, 2, 653, 126, 3, 2, 2, 2, 654, 655, 
-	5, 251, 126, 2, 655, 656, 5, 255, 128, 2, 656, 657, 5, 239, 120, 2, 657, 
-	128, 3, 2, 2, 2, 658, 659, 5, 239, 120, 2, 659, 660, 5, 231, 116, 2, 660, 
-	661, 5, 241, 121, 2, 661, 130, 3, 2, 2, 2, 662, 663, 5, 239, 120, 2, 663, 
-	664, 5, 215, 108, 2, 664, 665, 5, 261, 131, 2, 665, 132, 3, 2, 2, 2, 666, 
-	667, 5, 219, 110, 2, 667, 668, 5, 243, 122, 2, 668, 669, 5, 255, 128, 2, 
-	669, 670, 5, 241, 121, 2, 670, 671, 5, 253, 127, 2, 671, 134, 3, 2, 2, 
-	2, 672, 673, 5, 215, 108, 2, 673, 674, 5, 257, 129, 2, 674, 675, 5, 227, 
-	114, 2, 675, 136, 3, 2, 2, 2, 676, 677, 5, 251, 126, 2, 677, 678, 5, 253, 
-	127, 2, 678, 679, 5, 221, 111, 2, 679, 680, 5, 221, 111, 2, 680, 681, 5, 
-	223, 112, 2, 681, 682, 5, 257, 129, 2, 682, 138, 3, 2, 2, 2, 683, 684, 
-	5, 229, 115, 2, 684, 685, 5, 231, 116, 2, 685, 686, 5, 251, 126, 2, 686, 
-	687, 5, 253, 127, 2, 687, 688, 5, 243, 122, 2, 688, 689, 5, 227, 114, 2, 
-	689, 690, 5, 249, 125, 2, 690, 691, 5, 215, 108, 2, 691, 692, 5, 239, 120, 
-	2, 692, 140, 3, 2, 2, 2, 693, 694, 5, 251, 126, 2, 694, 142, 3, 2, 2, 2, 
-	695, 696, 7, 111, 2, 2, 696, 144, 3, 2, 2, 2, 697, 698, 5, 229, 115, 2, 
-	698, 146, 3, 2, 2, 2, 699, 700, 5, 221, 111, 2, 700, 148, 3, 2, 2, 2, 701, 
-	702, 5, 259, 130, 2, 702, 150, 3, 2, 2, 2, 703, 704, 7, 79, 2, 2, 704, 
-	152, 3, 2, 2, 2, 705, 706, 5, 263, 132, 2, 706, 154, 3, 2, 2, 2, 707, 708, 
-	7, 48, 2, 2, 708, 156, 3, 2, 2, 2, 709, 710, 7, 60, 2, 2, 710, 158, 3, 
-	2, 2, 2, 711, 712, 7, 63, 2, 2, 712, 160, 3, 2, 2, 2, 713, 714, 7, 62, 
-	2, 2, 714, 715, 7, 64, 2, 2, 715, 162, 3, 2, 2, 2, 716, 717, 7, 35, 2, 
-	2, 717, 718, 7, 63, 2, 2, 718, 164, 3, 2, 2, 2, 719, 720, 7, 64, 2, 2, 
-	720, 166, 3, 2, 2, 2, 721, 722, 7, 64, 2, 2, 722, 723, 7, 63, 2, 2, 723, 
-	168, 3, 2, 2, 2, 724, 725, 7, 62, 2, 2, 725, 170, 3, 2, 2, 2, 726, 727, 
-	7, 62, 2, 2, 727, 728, 7, 63, 2, 2, 728, 172, 3, 2, 2, 2, 729, 730, 7, 
-	63, 2, 2, 730, 731, 7, 128, 2, 2, 731, 174, 3, 2, 2, 2, 732, 733, 7, 35, 
-	2, 2, 733, 734, 7, 128, 2, 2, 734, 176, 3, 2, 2, 2, 735, 736, 7, 46, 2, 
-	2, 736, 178, 3, 2, 2, 2, 737, 738, 7, 125, 2, 2, 738, 180, 3, 2, 2, 2, 
-	739, 740, 7, 127, 2, 2, 740, 182, 3, 2, 2, 2, 741, 742, 7, 93, 2, 2, 742, 
-	184, 3, 2, 2, 2, 743, 744, 7, 95, 2, 2, 744, 186, 3, 2, 2, 2, 745, 746, 
-	7, 42, 2, 2, 746, 188, 3, 2, 2, 2, 747, 748, 7, 43, 2, 2, 748, 190, 3, 
-	2, 2, 2, 749, 750, 7, 45, 2, 2, 750, 192, 3, 2, 2, 2, 751, 752, 7, 47, 
-	2, 2, 752, 194, 3, 2, 2, 2, 753, 754, 7, 49, 2, 2, 754, 196, 3, 2, 2, 2, 
-	755, 756, 7, 44, 2, 2, 756, 198, 3, 2, 2, 2, 757, 758, 7, 39, 2, 2, 758, 
-	200, 3, 2, 2, 2, 759, 760, 5, 213, 107, 2, 760, 202, 3, 2, 2, 2, 761, 763, 
-	5, 211, 106, 2, 762, 761, 3, 2, 2, 2, 763, 764, 3, 2, 2, 2, 764, 762, 3, 
-	2, 2, 2, 764, 765, 3, 2, 2, 2, 765, 204, 3, 2, 2, 2, 766, 768, 5, 211, 
-	106, 2, 767, 766, 3, 2, 2, 2, 768, 769, 3, 2, 2, 2, 769, 767, 3, 2, 2, 
-	2, 769, 770, 3, 2, 2, 2, 770, 771, 3, 2, 2, 2, 771, 772, 7, 48, 2, 2, 772, 
-	776, 10, 2, 2, 2, 773, 775, 5, 211, 106, 2, 774, 773, 3, 2, 2, 2, 775, 
-	778, 3, 2, 2, 2, 776, 774, 3, 2, 2, 2, 776, 777, 3, 2, 2, 2, 777, 786, 
-	3, 2, 2, 2, 778, 776, 3, 2, 2, 2, 779, 781, 7, 48, 2, 2, 780, 782, 5, 211, 
-	106, 2, 781, 780, 3, 2, 2, 2, 782, 783, 3, 2, 2, 2, 783, 781, 3, 2, 2, 
-	2, 783, 784, 3, 2, 2, 2, 784, 786, 3, 2, 2, 2, 785, 767, 3, 2, 2, 2, 785, 
-	779, 3, 2, 2, 2, 786, 206, 3, 2, 2, 2, 787, 789, 5, 209, 105, 2, 788, 787, 
-	3, 2, 2, 2, 789, 790, 3, 2, 2, 2, 790, 788, 3, 2, 2, 2, 790, 791, 3, 2, 
-	2, 2, 791, 792, 3, 2, 2, 2, 792, 793, 8, 104, 2, 2, 793, 208, 3, 2, 2, 
-	2, 794, 795, 9, 3, 2, 2, 795, 210, 3, 2, 2, 2, 796, 797, 9, 4, 2, 2, 797, 
-	212, 3, 2, 2, 2, 798, 804, 9, 5, 2, 2, 799, 803, 9, 5, 2, 2, 800, 803, 
-	5, 211, 106, 2, 801, 803, 9, 6, 2, 2, 802, 799, 3, 2, 2, 2, 802, 800, 3, 
-	2, 2, 2, 802, 801, 3, 2, 2, 2, 803, 806, 3, 2, 2, 2, 804, 802, 3, 2, 2, 
-	2, 804, 805, 3, 2, 2, 2, 805, 849, 3, 2, 2, 2, 806, 804, 3, 2, 2, 2, 807, 
-	808, 7, 38, 2, 2, 808, 812, 7, 125, 2, 2, 809, 811, 11, 2, 2, 2, 810, 809, 
-	3, 2, 2, 2, 811, 814, 3, 2, 2, 2, 812, 813, 3, 2, 2, 2, 812, 810, 3, 2, 
-	2, 2, 813, 815, 3, 2, 2, 2, 814, 812, 3, 2, 2, 2, 815, 849, 7, 127, 2, 
-	2, 816, 820, 9, 7, 2, 2, 817, 821, 9, 5, 2, 2, 818, 821, 5, 211, 106, 2, 
-	819, 821, 9, 7, 2, 2, 820, 817, 3, 2, 2, 2, 820, 818, 3, 2, 2, 2, 820, 
-	819, 3, 2, 2, 2, 821, 822, 3, 2, 2, 2, 822, 820, 3, 2, 2, 2, 822, 823, 
-	3, 2, 2, 2, 823, 849, 3, 2, 2, 2, 824, 828, 7, 36, 2, 2, 825, 827, 11, 
-	2, 2, 2, 826, 825, 3, 2, 2, 2, 827, 830, 3, 2, 2, 2, 828, 829, 3, 2, 2, 
-	2, 828, 826, 3, 2, 2, 2, 829, 831, 3, 2, 2, 2, 830, 828, 3, 2, 2, 2, 831, 
-	849, 7, 36, 2, 2, 832, 836, 7, 98, 2, 2, 833, 835, 11, 2, 2, 2, 834, 833, 
-	3, 2, 2, 2, 835, 838, 3, 2, 2, 2, 836, 837, 3, 2, 2, 2, 836, 834, 3, 2, 
-	2, 2, 837, 839, 3, 2, 2, 2, 838, 836, 3, 2, 2, 2, 839, 849, 7, 98, 2, 2, 
-	840, 844, 7, 41, 2, 2, 841, 843, 11, 2, 2, 2, 842, 841, 3, 2, 2, 2, 843, 
-	846, 3, 2, 2, 2, 844, 845, 3, 2, 2, 2, 844, 842, 3, 2, 2, 2, 845, 847, 
-	3, 2, 2, 2, 846, 844, 3, 2, 2, 2, 847, 849, 7, 41, 2, 2, 848, 798, 3, 2, 
-	2, 2, 848, 807, 3, 2, 2, 2, 848, 816, 3, 2, 2, 2, 848, 824, 3, 2, 2, 2, 
-	848, 832, 3, 2, 2, 2, 848, 840, 3, 2, 2, 2, 849, 214, 3, 2, 2, 2, 850, 
-	851, 9, 8, 2, 2, 851, 216, 3, 2, 2, 2, 852, 853, 9, 9, 2, 2, 853, 218, 
-	3, 2, 2, 2, 854, 855, 9, 10, 2, 2, 855, 220, 3, 2, 2, 2, 856, 857, 9, 11, 
-	2, 2, 857, 222, 3, 2, 2, 2, 858, 859, 9, 12, 2, 2, 859, 224, 3, 2, 2, 2, 
-	860, 861, 9, 13, 2, 2, 861, 226, 3, 2, 2, 2, 862, 863, 9, 14, 2, 2, 863, 
-	228, 3, 2, 2, 2, 864, 865, 9, 15, 2, 2, 865, 230, 3, 2, 2, 2, 866, 867, 
-	9, 16, 2, 2, 867, 232, 3, 2, 2, 2, 868, 869, 9, 17, 2, 2, 869, 234, 3, 
-	2, 2, 2, 870, 871, 9, 18, 2, 2, 871, 236, 3, 2, 2, 2, 872, 873, 9, 19, 
-	2, 2, 873, 238, 3, 2, 2, 2, 874, 875, 9, 20, 2, 2, 875, 240, 3, 2, 2, 2, 
-	876, 877, 9, 21, 2, 2, 877, 242, 3, 2, 2, 2, 878, 879, 9, 22, 2, 2, 879, 
-	244, 3, 2, 2, 2, 880, 881, 9, 23, 2, 2, 881, 246, 3, 2, 2, 2, 882, 883, 
-	9, 24, 2, 2, 883, 248, 3, 2, 2, 2, 884, 885, 9, 25, 2, 2, 885, 250, 3, 
-	2, 2, 2, 886, 887, 9, 26, 2, 2, 887, 252, 3, 2, 2, 2, 888, 889, 9, 27, 
-	2, 2, 889, 254, 3, 2, 2, 2, 890, 891, 9, 28, 2, 2, 891, 256, 3, 2, 2, 2, 
-	892, 893, 9, 29, 2, 2, 893, 258, 3, 2, 2, 2, 894, 895, 9, 30, 2, 2, 895, 
-	260, 3, 2, 2, 2, 896, 897, 9, 31, 2, 2, 897, 262, 3, 2, 2, 2, 898, 899, 
-	9, 32, 2, 2, 899, 264, 3, 2, 2, 2, 900, 901, 9, 33, 2, 2, 901, 266, 3, 
-	2, 2, 2, 18, 2, 764, 769, 776, 783, 785, 790, 802, 804, 812, 820, 822, 
-	828, 836, 844, 848, 3, 8, 2, 2,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 113, 976, 
+	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 7, 9, 7, 4, 8, 
+	9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 9, 13, 
+	4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9, 18, 4, 
+	19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23, 4, 24, 
+	9, 24, 4, 27, 9, 27, 4, 28, 9, 28, 4, 29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 
+	31, 4, 32, 9, 32, 4, 33, 9, 33, 4, 34, 9, 34, 4, 35, 9, 35, 4, 36, 9, 36, 
+	4, 37, 9, 37, 4, 38, 9, 38, 4, 39, 9, 39, 4, 40, 9, 40, 4, 41, 9, 41, 4, 
+	42, 9, 42, 4, 43, 9, 43, 4, 44, 9, 44, 4, 45, 9, 45, 4, 46, 9, 46, 4, 47, 
+	9, 47, 4, 48, 9, 48, 4, 49, 9, 49, 4, 50, 9, 50, 4, 51, 9, 51, 4, 52, 9, 
+	52, 4, 53, 9, 53, 4, 54, 9, 54, 4, 55, 9, 55, 4, 56, 9, 56, 4, 57, 9, 57, 
+	4, 58, 9, 58, 4, 59, 9, 59, 4, 60, 9, 60, 4, 61, 9, 61, 4, 62, 9, 62, 4, 
+	63, 9, 63, 4, 64, 9, 64, 4, 65, 9, 65, 4, 66, 9, 66, 4, 67, 9, 67, 4, 68, 
+	9, 68, 4, 69, 9, 69, 4, 70, 9, 70, 4, 71, 9, 71, 4, 72, 9, 72, 4, 73, 9, 
+	73, 4, 79, 9, 79, 4, 80, 9, 80, 4, 81, 9, 81, 4, 82, 9, 82, 4, 83, 9, 83, 
+	4, 84, 9, 84, 4, 85, 9, 85, 4, 86, 9, 86, 4, 87, 9, 87, 4, 88, 9, 88, 4, 
+	89, 9, 89, 4, 90, 9, 90, 4, 91, 9, 91, 4, 92, 9, 92, 4, 93, 9, 93, 4, 94, 
+	9, 94, 4, 95, 9, 95, 4, 96, 9, 96, 4, 97, 9, 97, 4, 98, 9, 98, 4, 99, 9, 
+	99, 4, 100, 9, 100, 4, 101, 9, 101, 4, 102, 9, 102, 4, 103, 9, 103, 4, 
+	104, 9, 104, 4, 105, 9, 105, 4, 106, 9, 106, 4, 107, 9, 107, 4, 108, 9, 
+	108, 4, 109, 9, 109, 4, 110, 9, 110, 4, 111, 9, 111, 4, 112, 9, 112, 4, 
+	113, 9, 113, 4, 114, 9, 114, 4, 115, 9, 115, 4, 116, 9, 116, 4, 117, 9, 
+	117, 4, 118, 9, 118, 4, 119, 9, 119, 4, 120, 9, 120, 4, 121, 9, 121, 4, 
+	122, 9, 122, 4, 123, 9, 123, 4, 124, 9, 124, 4, 125, 9, 125, 4, 126, 9, 
+	126, 4, 127, 9, 127, 4, 128, 9, 128, 4, 129, 9, 129, 4, 130, 9, 130, 4, 
+	131, 9, 131, 4, 132, 9, 132, 4, 133, 9, 133, 4, 134, 9, 134, 4, 135, 9, 
+	135, 4, 136, 9, 136, 4, 137, 9, 137, 4, 138, 9, 138, 4, 139, 9, 139, 4, 
+	140, 9, 140, 4, 141, 9, 141, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 
+	3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 
+	3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 
+	10, 3, 10, 3, 11, 3, 11, 3, 11, 3, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 
+	3, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 3, 
+	13, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 
+	3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 17, 3, 17, 3, 
+	17, 3, 17, 3, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 
+	3, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 
+	19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 
+	3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 
+	21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 
+	3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 24, 3, 24, 3, 
+	24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 27, 
+	3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 
+	28, 3, 28, 3, 29, 3, 29, 3, 29, 3, 29, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 
+	3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 32, 3, 32, 3, 32, 3, 32, 3, 33, 3, 
+	33, 3, 33, 3, 33, 3, 33, 3, 34, 3, 34, 3, 34, 3, 34, 3, 34, 3, 34, 3, 34, 
+	3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 36, 3, 36, 3, 36, 3, 36, 3, 
+	36, 3, 37, 3, 37, 3, 37, 3, 37, 3, 37, 3, 37, 3, 38, 3, 38, 3, 38, 3, 38, 
+	3, 38, 3, 38, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 
+	40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 41, 3, 41, 3, 41, 3, 41, 3, 41, 
+	3, 41, 3, 41, 3, 41, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 
+	42, 3, 42, 3, 42, 3, 43, 3, 43, 3, 43, 3, 43, 3, 43, 3, 43, 3, 43, 3, 44, 
+	3, 44, 3, 44, 3, 45, 3, 45, 3, 45, 3, 45, 3, 46, 3, 46, 3, 46, 3, 47, 3, 
+	47, 3, 47, 3, 47, 3, 47, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 49, 3, 49, 
+	3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 49, 3, 50, 3, 50, 3, 50, 3, 
+	50, 3, 50, 3, 50, 3, 51, 3, 51, 3, 51, 3, 51, 3, 52, 3, 52, 3, 52, 3, 52, 
+	3, 52, 3, 53, 3, 53, 3, 53, 3, 53, 3, 53, 3, 54, 3, 54, 3, 54, 3, 54, 3, 
+	55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 55, 3, 56, 3, 56, 3, 56, 
+	3, 57, 3, 57, 3, 57, 3, 57, 3, 57, 3, 57, 3, 58, 3, 58, 3, 58, 3, 58, 3, 
+	58, 3, 58, 3, 58, 3, 59, 3, 59, 3, 59, 3, 60, 3, 60, 3, 60, 3, 60, 3, 61, 
+	3, 61, 3, 61, 3, 61, 3, 61, 3, 61, 3, 62, 3, 62, 3, 62, 3, 62, 3, 62, 3, 
+	63, 3, 63, 3, 63, 3, 63, 3, 64, 3, 64, 3, 64, 3, 65, 3, 65, 3, 65, 3, 65, 
+	3, 66, 3, 66, 3, 66, 3, 66, 3, 66, 3, 66, 3, 66, 3, 66, 3, 67, 3, 67, 3, 
+	67, 3, 67, 3, 68, 3, 68, 3, 68, 3, 68, 3, 69, 3, 69, 3, 69, 3, 69, 3, 70, 
+	3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 71, 3, 71, 3, 71, 3, 71, 3, 72, 3, 
+	72, 3, 72, 3, 72, 3, 72, 3, 72, 3, 72, 3, 73, 3, 73, 3, 73, 3, 73, 3, 73, 
+	3, 73, 3, 73, 3, 73, 3, 73, 3, 73, 3, 79, 3, 79, 3, 80, 3, 80, 3, 81, 3, 
+	81, 3, 82, 3, 82, 3, 83, 3, 83, 3, 84, 3, 84, 3, 85, 3, 85, 3, 86, 3, 86, 
+	3, 87, 3, 87, 3, 88, 3, 88, 3, 89, 3, 89, 3, 89, 3, 90, 3, 90, 3, 90, 3, 
+	91, 3, 91, 3, 92, 3, 92, 3, 92, 3, 93, 3, 93, 3, 94, 3, 94, 3, 94, 3, 95, 
+	3, 95, 3, 95, 3, 96, 3, 96, 3, 96, 3, 97, 3, 97, 3, 98, 3, 98, 3, 99, 3, 
+	99, 3, 100, 3, 100, 3, 101, 3, 101, 3, 102, 3, 102, 3, 103, 3, 103, 3, 
+	104, 3, 104, 3, 105, 3, 105, 3, 106, 3, 106, 3, 107, 3, 107, 3, 108, 3, 
+	108, 3, 109, 3, 109, 3, 110, 6, 110, 763, 10, 110, 13, 110, 14, 110, 764, 
+	3, 111, 6, 111, 768, 10, 111, 13, 111, 14, 111, 769, 3, 111, 3, 111, 3, 
+	111, 7, 111, 775, 10, 111, 12, 111, 14, 111, 778, 11, 111, 3, 111, 3, 111, 
+	6, 111, 782, 10, 111, 13, 111, 14, 111, 783, 5, 111, 786, 10, 111, 3, 112, 
+	6, 112, 789, 10, 112, 13, 112, 14, 112, 790, 3, 112, 3, 112, 3, 113, 3, 
+	113, 3, 114, 3, 114, 3, 115, 3, 115, 3, 115, 3, 115, 7, 115, 803, 10, 115, 
+	12, 115, 14, 115, 806, 11, 115, 3, 115, 3, 115, 3, 115, 7, 115, 811, 10, 
+	115, 12, 115, 14, 115, 814, 11, 115, 3, 115, 3, 115, 3, 115, 3, 115, 3, 
+	115, 6, 115, 821, 10, 115, 13, 115, 14, 115, 822, 3, 115, 3, 115, 7, 115, 
+	827, 10, 115, 12, 115, 14, 115, 830, 11, 115, 3, 115, 3, 115, 3, 115, 7, 
+	115, 835, 10, 115, 12, 115, 14, 115, 838, 11, 115, 3, 115, 3, 115, 3, 115, 
+	7, 115, 843, 10, 115, 12, 115, 14, 115, 846, 11, 115, 3, 115, 5, 115, 849, 
+	10, 115, 3, 116, 3, 116, 3, 117, 3, 117, 3, 118, 3, 118, 3, 119, 3, 119, 
+	3, 120, 3, 120, 3, 121, 3, 121, 3, 122, 3, 122, 3, 123, 3, 123, 3, 124, 
+	3, 124, 3, 125, 3, 125, 3, 126, 3, 126, 3, 127, 3, 127, 3, 128, 3, 128, 
+	3, 129, 3, 129, 3, 130, 3, 130, 3, 131, 3, 131, 3, 132, 3, 132, 3, 133, 
+	3, 133, 3, 134, 3, 134, 3, 135, 3, 135, 3, 136, 3, 136, 3, 137, 3, 137, 
+	3, 138, 3, 138, 3, 139, 3, 139, 3, 140, 3, 140, 3, 141, 3, 141, 4, 74, 
+	9, 74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 4, 75, 9, 75, 3, 75, 3, 75, 3, 
+	75, 3, 75, 3, 75, 3, 75, 4, 76, 9, 76, 3, 76, 3, 76, 3, 76, 3, 76, 3, 76, 
+	3, 76, 4, 77, 9, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 
+	77, 3, 77, 3, 77, 3, 77, 4, 78, 9, 78, 3, 78, 3, 78, 3, 78, 3, 78, 3, 78, 
+	3, 78, 3, 78, 3, 78, 3, 78, 4, 6, 9, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 
+	6, 3, 6, 4, 25, 9, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 
+	4, 26, 9, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 6, 812, 
+	828, 836, 844, 2, 142, 3, 3, 5, 4, 7, 5, 9, 6, 949, 7, 11, 8, 13, 9, 15, 
+	10, 17, 11, 19, 12, 21, 13, 23, 14, 25, 15, 27, 16, 29, 17, 31, 18, 33, 
+	19, 35, 20, 37, 21, 39, 22, 41, 23, 43, 24, 45, 25, 958, 26, 967, 27, 47, 
+	28, 49, 29, 51, 30, 53, 31, 55, 32, 57, 33, 59, 34, 61, 35, 63, 36, 65, 
+	37, 67, 38, 69, 39, 71, 40, 73, 41, 75, 42, 77, 43, 79, 44, 81, 45, 83, 
+	46, 85, 47, 87, 48, 89, 49, 91, 50, 93, 51, 95, 52, 97, 53, 99, 54, 101, 
+	55, 103, 56, 105, 57, 107, 58, 109, 59, 111, 60, 113, 61, 115, 62, 117, 
+	63, 119, 64, 121, 65, 123, 66, 125, 67, 127, 68, 129, 69, 131, 70, 133, 
+	71, 135, 72, 137, 73, 139, 74, 902, 75, 909, 76, 917, 77, 925, 78, 938, 
+	79, 141, 80, 143, 81, 145, 82, 147, 83, 149, 84, 151, 85, 153, 86, 155, 
+	87, 157, 88, 159, 89, 161, 90, 163, 91, 165, 92, 167, 93, 169, 94, 171, 
+	95, 173, 96, 175, 97, 177, 98, 179, 99, 181, 100, 183, 101, 185, 102, 187, 
+	103, 189, 104, 191, 105, 193, 106, 195, 107, 197, 108, 199, 109, 201, 110, 
+	203, 111, 205, 112, 207, 113, 209, 2, 211, 2, 213, 2, 215, 2, 217, 2, 219, 
+	2, 221, 2, 223, 2, 225, 2, 227, 2, 229, 2, 231, 2, 233, 2, 235, 2, 237, 
+	2, 239, 2, 241, 2, 243, 2, 245, 2, 247, 2, 249, 2, 251, 2, 253, 2, 255, 
+	2, 257, 2, 259, 2, 261, 2, 263, 2, 265, 2, 3, 2, 34, 3, 2, 48, 48, 5, 2, 
+	11, 12, 15, 15, 34, 34, 3, 2, 50, 59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 
+	48, 97, 97, 6, 2, 37, 38, 60, 60, 66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 
+	4, 2, 68, 68, 100, 100, 4, 2, 69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 
+	4, 2, 71, 71, 103, 103, 4, 2, 72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 
+	4, 2, 74, 74, 106, 106, 4, 2, 75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 
+	4, 2, 77, 77, 109, 109, 4, 2, 78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 
+	4, 2, 80, 80, 112, 112, 4, 2, 81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 
+	4, 2, 83, 83, 115, 115, 4, 2, 84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 
+	4, 2, 86, 86, 118, 118, 4, 2, 87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 
+	4, 2, 89, 89, 121, 121, 4, 2, 90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 
+	4, 2, 92, 92, 124, 124, 2, 967, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 
+	7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 949, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 
+	2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 
+	2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 
+	2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 
+	2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 
+	3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 958, 3, 2, 2, 2, 2, 967, 3, 2, 2, 2, 
+	2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 
+	2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 
+	2, 2, 2, 63, 3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 
+	2, 2, 2, 2, 71, 3, 2, 2, 2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 
+	3, 2, 2, 2, 2, 79, 3, 2, 2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 
+	85, 3, 2, 2, 2, 2, 87, 3, 2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 
+	2, 93, 3, 2, 2, 2, 2, 95, 3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 
+	2, 2, 101, 3, 2, 2, 2, 2, 103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107, 
+	3, 2, 2, 2, 2, 109, 3, 2, 2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 
+	2, 115, 3, 2, 2, 2, 2, 117, 3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3, 
+	2, 2, 2, 2, 123, 3, 2, 2, 2, 2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 
+	129, 3, 2, 2, 2, 2, 131, 3, 2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2, 
+	2, 2, 2, 137, 3, 2, 2, 2, 2, 139, 3, 2, 2, 2, 2, 902, 3, 2, 2, 2, 2, 909, 
+	3, 2, 2, 2, 2, 917, 3, 2, 2, 2, 2, 925, 3, 2, 2, 2, 2, 938, 3, 2, 2, 2, 
+	2, 141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 2, 2, 2, 147, 3, 
+	2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 3, 2, 2, 2, 2, 
+	155, 3, 2, 2, 2, 2, 157, 3, 2, 2, 2, 2, 159, 3, 2, 2, 2, 2, 161, 3, 2, 
+	2, 2, 2, 163, 3, 2, 2, 2, 2, 165, 3, 2, 2, 2, 2, 167, 3, 2, 2, 2, 2, 169, 
+	3, 2, 2, 2, 2, 171, 3, 2, 2, 2, 2, 173, 3, 2, 2, 2, 2, 175, 3, 2, 2, 2, 
+	2, 177, 3, 2, 2, 2, 2, 179, 3, 2, 2, 2, 2, 181, 3, 2, 2, 2, 2, 183, 3, 
+	2, 2, 2, 2, 185, 3, 2, 2, 2, 2, 187, 3, 2, 2, 2, 2, 189, 3, 2, 2, 2, 2, 
+	191, 3, 2, 2, 2, 2, 193, 3, 2, 2, 2, 2, 195, 3, 2, 2, 2, 2, 197, 3, 2, 
+	2, 2, 2, 199, 3, 2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 2, 2, 2, 2, 205, 
+	3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 274, 3, 2, 2, 2, 
+	7, 281, 3, 2, 2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 2, 2, 13, 299, 3, 
+	2, 2, 2, 15, 304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 322, 3, 2, 2, 2, 
+	21, 326, 3, 2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 2, 2, 2, 27, 352, 
+	3, 2, 2, 2, 29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 33, 365, 3, 2, 2, 
+	2, 35, 374, 3, 2, 2, 2, 37, 384, 3, 2, 2, 2, 39, 394, 3, 2, 2, 2, 41, 405, 
+	3, 2, 2, 2, 43, 410, 3, 2, 2, 2, 45, 423, 3, 2, 2, 2, 47, 435, 3, 2, 2, 
+	2, 49, 441, 3, 2, 2, 2, 51, 448, 3, 2, 2, 2, 53, 452, 3, 2, 2, 2, 55, 457, 
+	3, 2, 2, 2, 57, 462, 3, 2, 2, 2, 59, 466, 3, 2, 2, 2, 61, 471, 3, 2, 2, 
+	2, 63, 478, 3, 2, 2, 2, 65, 484, 3, 2, 2, 2, 67, 489, 3, 2, 2, 2, 69, 495, 
+	3, 2, 2, 2, 71, 501, 3, 2, 2, 2, 73, 509, 3, 2, 2, 2, 75, 515, 3, 2, 2, 
+	2, 77, 523, 3, 2, 2, 2, 79, 533, 3, 2, 2, 2, 81, 540, 3, 2, 2, 2, 83, 543, 
+	3, 2, 2, 2, 85, 547, 3, 2, 2, 2, 87, 550, 3, 2, 2, 2, 89, 555, 3, 2, 2, 
+	2, 91, 560, 3, 2, 2, 2, 93, 569, 3, 2, 2, 2, 95, 575, 3, 2, 2, 2, 97, 579, 
+	3, 2, 2, 2, 99, 584, 3, 2, 2, 2, 101, 589, 3, 2, 2, 2, 103, 593, 3, 2, 
+	2, 2, 105, 601, 3, 2, 2, 2, 107, 604, 3, 2, 2, 2, 109, 610, 3, 2, 2, 2, 
+	111, 617, 3, 2, 2, 2, 113, 620, 3, 2, 2, 2, 115, 624, 3, 2, 2, 2, 117, 
+	630, 3, 2, 2, 2, 119, 635, 3, 2, 2, 2, 121, 639, 3, 2, 2, 2, 123, 642, 
+	3, 2, 2, 2, 125, 646, 3, 2, 2, 2, 127, 654, 3, 2, 2, 2, 129, 658, 3, 2, 
+	2, 2, 131, 662, 3, 2, 2, 2, 133, 666, 3, 2, 2, 2, 135, 672, 3, 2, 2, 2, 
+	137, 676, 3, 2, 2, 2, 139, 683, 3, 2, 2, 2, 141, 693, 3, 2, 2, 2, 143, 
+	695, 3, 2, 2, 2, 145, 697, 3, 2, 2, 2, 147, 699, 3, 2, 2, 2, 149, 701, 
+	3, 2, 2, 2, 151, 703, 3, 2, 2, 2, 153, 705, 3, 2, 2, 2, 155, 707, 3, 2, 
+	2, 2, 157, 709, 3, 2, 2, 2, 159, 711, 3, 2, 2, 2, 161, 713, 3, 2, 2, 2, 
+	163, 716, 3, 2, 2, 2, 165, 719, 3, 2, 2, 2, 167, 721, 3, 2, 2, 2, 169, 
+	724, 3, 2, 2, 2, 171, 726, 3, 2, 2, 2, 173, 729, 3, 2, 2, 2, 175, 732, 
+	3, 2, 2, 2, 177, 735, 3, 2, 2, 2, 179, 737, 3, 2, 2, 2, 181, 739, 3, 2, 
+	2, 2, 183, 741, 3, 2, 2, 2, 185, 743, 3, 2, 2, 2, 187, 745, 3, 2, 2, 2, 
+	189, 747, 3, 2, 2, 2, 191, 749, 3, 2, 2, 2, 193, 751, 3, 2, 2, 2, 195, 
+	753, 3, 2, 2, 2, 197, 755, 3, 2, 2, 2, 199, 757, 3, 2, 2, 2, 201, 759, 
+	3, 2, 2, 2, 203, 762, 3, 2, 2, 2, 205, 785, 3, 2, 2, 2, 207, 788, 3, 2, 
+	2, 2, 209, 794, 3, 2, 2, 2, 211, 796, 3, 2, 2, 2, 213, 848, 3, 2, 2, 2, 
+	215, 850, 3, 2, 2, 2, 217, 852, 3, 2, 2, 2, 219, 854, 3, 2, 2, 2, 221, 
+	856, 3, 2, 2, 2, 223, 858, 3, 2, 2, 2, 225, 860, 3, 2, 2, 2, 227, 862, 
+	3, 2, 2, 2, 229, 864, 3, 2, 2, 2, 231, 866, 3, 2, 2, 2, 233, 868, 3, 2, 
+	2, 2, 235, 870, 3, 2, 2, 2, 237, 872, 3, 2, 2, 2, 239, 874, 3, 2, 2, 2, 
+	241, 876, 3, 2, 2, 2, 243, 878, 3, 2, 2, 2, 245, 880, 3, 2, 2, 2, 247, 
+	882, 3, 2, 2, 2, 249, 884, 3, 2, 2, 2, 251, 886, 3, 2, 2, 2, 253, 888, 
+	3, 2, 2, 2, 255, 890, 3, 2, 2, 2, 257, 892, 3, 2, 2, 2, 259, 894, 3, 2, 
+	2, 2, 261, 896, 3, 2, 2, 2, 263, 898, 3, 2, 2, 2, 265, 900, 3, 2, 2, 2, 
+	267, 268, 5, 219, 118, 2, 268, 269, 5, 249, 133, 2, 269, 270, 5, 223, 120, 
+	2, 270, 271, 5, 215, 116, 2, 271, 272, 5, 253, 135, 2, 272, 273, 5, 223, 
+	120, 2, 273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 136, 2, 275, 276, 5, 245, 
+	131, 2, 276, 277, 5, 221, 119, 2, 277, 278, 5, 215, 116, 2, 278, 279, 5, 
+	253, 135, 2, 279, 280, 5, 223, 120, 2, 280, 6, 3, 2, 2, 2, 281, 282, 5, 
+	251, 134, 2, 282, 283, 5, 223, 120, 2, 283, 284, 5, 253, 135, 2, 284, 8, 
+	3, 2, 2, 2, 285, 286, 5, 221, 119, 2, 286, 287, 5, 249, 133, 2, 287, 288, 
+	5, 243, 130, 2, 288, 289, 5, 245, 131, 2, 289, 10, 3, 2, 2, 2, 290, 291, 
+	5, 231, 124, 2, 291, 292, 5, 241, 129, 2, 292, 293, 5, 253, 135, 2, 293, 
+	294, 5, 223, 120, 2, 294, 295, 5, 249, 133, 2, 295, 296, 5, 257, 137, 2, 
+	296, 297, 5, 215, 116, 2, 297, 298, 5, 237, 127, 2, 298, 12, 3, 2, 2, 2, 
+	299, 300, 5, 241, 129, 2, 300, 301, 5, 215, 116, 2, 301, 302, 5, 239, 128, 
+	2, 302, 303, 5, 223, 120, 2, 303, 14, 3, 2, 2, 2, 304, 305, 5, 251, 134, 
+	2, 305, 306, 5, 229, 123, 2, 306, 307, 5, 215, 116, 2, 307, 308, 5, 249, 
+	133, 2, 308, 309, 5, 221, 119, 2, 309, 16, 3, 2, 2, 2, 310, 311, 5, 249, 
+	133, 2, 311, 312, 5, 223, 120, 2, 312, 313, 5, 245, 131, 2, 313, 314, 5, 
+	237, 127, 2, 314, 315, 5, 231, 124, 2, 315, 316, 5, 219, 118, 2, 316, 317, 
+	5, 215, 116, 2, 317, 318, 5, 253, 135, 2, 318, 319, 5, 231, 124, 2, 319, 
+	320, 5, 243, 130, 2, 320, 321, 5, 241, 129, 2, 321, 18, 3, 2, 2, 2, 322, 
+	323, 5, 253, 135, 2, 323, 324, 5, 253, 135, 2, 324, 325, 5, 237, 127, 2, 
+	325, 20, 3, 2, 2, 2, 326, 327, 5, 239, 128, 2, 327, 328, 5, 223, 120, 2, 
+	328, 329, 5, 253, 135, 2, 329, 330, 5, 215, 116, 2, 330, 331, 5, 253, 135, 
+	2, 331, 332, 5, 253, 135, 2, 332, 333, 5, 237, 127, 2, 333, 22, 3, 2, 2, 
+	2, 334, 335, 5, 245, 131, 2, 335, 336, 5, 215, 116, 2, 336, 337, 5, 251, 
+	134, 2, 337, 338, 5, 253, 135, 2, 338, 339, 5, 253, 135, 2, 339, 340, 5, 
+	253, 135, 2, 340, 341, 5, 237, 127, 2, 341, 24, 3, 2, 2, 2, 342, 343, 5, 
+	225, 121, 2, 343, 344, 5, 255, 136, 2, 344, 345, 5, 253, 135, 2, 345, 346, 
+	5, 255, 136, 2, 346, 347, 5, 249, 133, 2, 347, 348, 5, 223, 120, 2, 348, 
+	349, 5, 253, 135, 2, 349, 350, 5, 253, 135, 2, 350, 351, 5, 237, 127, 2, 
+	351, 26, 3, 2, 2, 2, 352, 353, 5, 235, 126, 2, 353, 354, 5, 231, 124, 2, 
+	354, 355, 5, 237, 127, 2, 355, 356, 5, 237, 127, 2, 356, 28, 3, 2, 2, 2, 
+	357, 358, 5, 243, 130, 2, 358, 359, 5, 241, 129, 2, 359, 30, 3, 2, 2, 2, 
+	360, 361, 5, 251, 134, 2, 361, 362, 5, 229, 123, 2, 362, 363, 5, 243, 130, 
+	2, 363, 364, 5, 259, 138, 2, 364, 32, 3, 2, 2, 2, 365, 366, 5, 221, 119, 
+	2, 366, 367, 5, 215, 116, 2, 367, 368, 5, 253, 135, 2, 368, 369, 5, 215, 
+	116, 2, 369, 370, 5, 217, 117, 2, 370, 371, 5, 215, 116, 2, 371, 372, 5, 
+	251, 134, 2, 372, 373, 5, 223, 120, 2, 373, 34, 3, 2, 2, 2, 374, 375, 5, 
+	221, 119, 2, 375, 376, 5, 215, 116, 2, 376, 377, 5, 253, 135, 2, 377, 378, 
+	5, 215, 116, 2, 378, 379, 5, 217, 117, 2, 379, 380, 5, 215, 116, 2, 380, 
+	381, 5, 251, 134, 2, 381, 382, 5, 223, 120, 2, 382, 383, 5, 251, 134, 2, 
+	383, 36, 3, 2, 2, 2, 384, 385, 5, 241, 129, 2, 385, 386, 5, 215, 116, 2, 
+	386, 387, 5, 239, 128, 2, 387, 388, 5, 223, 120, 2, 388, 389, 5, 251, 134, 
+	2, 389, 390, 5, 245, 131, 2, 390, 391, 5, 215, 116, 2, 391, 392, 5, 219, 
+	118, 2, 392, 393, 5, 223, 120, 2, 393, 38, 3, 2, 2, 2, 394, 395, 5, 241, 
+	129, 2, 395, 396, 5, 215, 116, 2, 396, 397, 5, 239, 128, 2, 397, 398, 5, 
+	223, 120, 2, 398, 399, 5, 251, 134, 2, 399, 400, 5, 245, 131, 2, 400, 401, 
+	5, 215, 116, 2, 401, 402, 5, 219, 118, 2, 402, 403, 5, 223, 120, 2, 403, 
+	404, 5, 251, 134, 2, 404, 40, 3, 2, 2, 2, 405, 406, 5, 241, 129, 2, 406, 
+	407, 5, 243, 130, 2, 407, 408, 5, 221, 119, 2, 408, 409, 5, 223, 120, 2, 
+	409, 42, 3, 2, 2, 2, 410, 411, 5, 239, 128, 2, 411, 412, 5, 223, 120, 2, 
+	412, 413, 5, 215, 116, 2, 413, 414, 5, 251, 134, 2, 414, 415, 5, 255, 136, 
+	2, 415, 416, 5, 249, 133, 2, 416, 417, 5, 223, 120, 2, 417, 418, 5, 239, 
+	128, 2, 418, 419, 5, 223, 120, 2, 419, 420, 5, 241, 129, 2, 420, 421, 5, 
+	253, 135, 2, 421, 422, 5, 251, 134, 2, 422, 44, 3, 2, 2, 2, 423, 424, 5, 
+	239, 128, 2, 424, 425, 5, 223, 120, 2, 425, 426, 5, 215, 116, 2, 426, 427, 
+	5, 251, 134, 2, 427, 428, 5, 255, 136, 2, 428, 429, 5, 249, 133, 2, 429, 
+	430, 5, 223, 120, 2, 430, 431, 5, 239, 128, 2, 431, 432, 5, 223, 120, 2, 
+	432, 433, 5, 241, 129, 2, 433, 434, 5, 253, 135, 2, 434, 46, 3, 2, 2, 2, 
+	435, 436, 5, 225, 121, 2, 436, 437, 5, 231, 124, 2, 437, 438, 5, 223, 120, 
+	2, 438, 439, 5, 237, 127, 2, 439, 440, 5, 221, 119, 2, 440, 48, 3, 2, 2, 
+	2, 441, 442, 5, 225, 121, 2, 442, 443, 5, 231, 124, 2, 443, 444, 5, 223, 
+	120, 2, 444, 445, 5, 237, 127, 2, 445, 446, 5, 221, 119, 2, 446, 447, 5, 
+	251, 134, 2, 447, 50, 3, 2, 2, 2, 448, 449, 5, 253, 135, 2, 449, 450, 5, 
+	215, 116, 2, 450, 451, 5, 227, 122, 2, 451, 52, 3, 2, 2, 2, 452, 453, 5, 
+	231, 124, 2, 453, 454, 5, 241, 129, 2, 454, 455, 5, 225, 121, 2, 455, 456, 
+	5, 243, 130, 2, 456, 54, 3, 2, 2, 2, 457, 458, 5, 235, 126, 2, 458, 459, 
+	5, 223, 120, 2, 459, 460, 5, 263, 140, 2, 460, 461, 5, 251, 134, 2, 461, 
+	56, 3, 2, 2, 2, 462, 463, 5, 235, 126, 2, 463, 464, 5, 223, 120, 2, 464, 
+	465, 5, 263, 140, 2, 465, 58, 3, 2, 2, 2, 466, 467, 5, 259, 138, 2, 467, 
+	468, 5, 231, 124, 2, 468, 469, 5, 253, 135, 2, 469, 470, 5, 229, 123, 2, 
+	470, 60, 3, 2, 2, 2, 471, 472, 5, 257, 137, 2, 472, 473, 5, 215, 116, 2, 
+	473, 474, 5, 237, 127, 2, 474, 475, 5, 255, 136, 2, 475, 476, 5, 223, 120, 
+	2, 476, 477, 5, 251, 134, 2, 477, 62, 3, 2, 2, 2, 478, 479, 5, 257, 137, 
+	2, 479, 480, 5, 215, 116, 2, 480, 481, 5, 237, 127, 2, 481, 482, 5, 255, 
+	136, 2, 482, 483, 5, 223, 120, 2, 483, 64, 3, 2, 2, 2, 484, 485, 5, 225, 
+	121, 2, 485, 486, 5, 249, 133, 2, 486, 487, 5, 243, 130, 2, 487, 488, 5, 
+	239, 128, 2, 488, 66, 3, 2, 2, 2, 489, 490, 5, 259, 138, 2, 490, 491, 5, 
+	229, 123, 2, 491, 492, 5, 223, 120, 2, 492, 493, 5, 249, 133, 2, 493, 494, 
+	5, 223, 120, 2, 494, 68, 3, 2, 2, 2, 495, 496, 5, 237, 127, 2, 496, 497, 
+	5, 231, 124, 2, 497, 498, 5, 239, 128, 2, 498, 499, 5, 231, 124, 2, 499, 
+	500, 5, 253, 135, 2, 500, 70, 3, 2, 2, 2, 501, 502, 5, 247, 132, 2, 502, 
+	503, 5, 255, 136, 2, 503, 504, 5, 223, 120, 2, 504, 505, 5, 249, 133, 2, 
+	505, 506, 5, 231, 124, 2, 506, 507, 5, 223, 120, 2, 507, 508, 5, 251, 134, 
+	2, 508, 72, 3, 2, 2, 2, 509, 510, 5, 247, 132, 2, 510, 511, 5, 255, 136, 
+	2, 511, 512, 5, 223, 120, 2, 512, 513, 5, 249, 133, 2, 513, 514, 5, 263, 
+	140, 2, 514, 74, 3, 2, 2, 2, 515, 516, 5, 223, 120, 2, 516, 517, 5, 261, 
+	139, 2, 517, 518, 5, 245, 131, 2, 518, 519, 5, 237, 127, 2, 519, 520, 5, 
+	215, 116, 2, 520, 521, 5, 231, 124, 2, 521, 522, 5, 241, 129, 2, 522, 76, 
+	3, 2, 2, 2, 523, 524, 5, 259, 138, 2, 524, 525, 5, 231, 124, 2, 525, 526, 
+	5, 253, 135, 2, 526, 527, 5, 229, 123, 2, 527, 528, 5, 257, 137, 2, 528, 
+	529, 5, 215, 116, 2, 529, 530, 5, 237, 127, 2, 530, 531, 5, 255, 136, 2, 
+	531, 532, 5, 223, 120, 2, 532, 78, 3, 2, 2, 2, 533, 534, 5, 251, 134, 2, 
+	534, 535, 5, 223, 120, 2, 535, 536, 5, 237, 127, 2, 536, 537, 5, 223, 120, 
+	2, 537, 538, 5, 219, 118, 2, 538, 539, 5, 253, 135, 2, 539, 80, 3, 2, 2, 
+	2, 540, 541, 5, 215, 116, 2, 541, 542, 5, 251, 134, 2, 542, 82, 3, 2, 2, 
+	2, 543, 544, 5, 215, 116, 2, 544, 545, 5, 241, 129, 2, 545, 546, 5, 221, 
+	119, 2, 546, 84, 3, 2, 2, 2, 547, 548, 5, 243, 130, 2, 548, 549, 5, 249, 
+	133, 2, 549, 86, 3, 2, 2, 2, 550, 551, 5, 225, 121, 2, 551, 552, 5, 231, 
+	124, 2, 552, 553, 5, 237, 127, 2, 553, 554, 5, 237, 127, 2, 554, 88, 3, 
+	2, 2, 2, 555, 556, 5, 241, 129, 2, 556, 557, 5, 255, 136, 2, 557, 558, 
+	5, 237, 127, 2, 558, 559, 5, 237, 127, 2, 559, 90, 3, 2, 2, 2, 560, 561, 
+	5, 245, 131, 2, 561, 562, 5, 249, 133, 2, 562, 563, 5, 223, 120, 2, 563, 
+	564, 5, 257, 137, 2, 564, 565, 5, 231, 124, 2, 565, 566, 5, 243, 130, 2, 
+	566, 567, 5, 255, 136, 2, 567, 568, 5, 251, 134, 2, 568, 92, 3, 2, 2, 2, 
+	569, 570, 5, 243, 130, 2, 570, 571, 5, 249, 133, 2, 571, 572, 5, 221, 119, 
+	2, 572, 573, 5, 223, 120, 2, 573, 574, 5, 249, 133, 2, 574, 94, 3, 2, 2, 
+	2, 575, 576, 5, 215, 116, 2, 576, 577, 5, 251, 134, 2, 577, 578, 5, 219, 
+	118, 2, 578, 96, 3, 2, 2, 2, 579, 580, 5, 221, 119, 2, 580, 581, 5, 223, 
+	120, 2, 581, 582, 5, 251, 134, 2, 582, 583, 5, 219, 118, 2, 583, 98, 3, 
+	2, 2, 2, 584, 585, 5, 237, 127, 2, 585, 586, 5, 231, 124, 2, 586, 587, 
+	5, 235, 126, 2, 587, 588, 5, 223, 120, 2, 588, 100, 3, 2, 2, 2, 589, 590, 
+	5, 241, 129, 2, 590, 591, 5, 243, 130, 2, 591, 592, 5, 253, 135, 2, 592, 
+	102, 3, 2, 2, 2, 593, 594, 5, 217, 117, 2, 594, 595, 5, 223, 120, 2, 595, 
+	596, 5, 253, 135, 2, 596, 597, 5, 259, 138, 2, 597, 598, 5, 223, 120, 2, 
+	598, 599, 5, 223, 120, 2, 599, 600, 5, 241, 129, 2, 600, 104, 3, 2, 2, 
+	2, 601, 602, 5, 231, 124, 2, 602, 603, 5, 251, 134, 2, 603, 106, 3, 2, 
+	2, 2, 604, 605, 5, 227, 122, 2, 605, 606, 5, 249, 133, 2, 606, 607, 5, 
+	243, 130, 2, 607, 608, 5, 255, 136, 2, 608, 609, 5, 245, 131, 2, 609, 108, 
+	3, 2, 2, 2, 610, 611, 5, 229, 123, 2, 611, 612, 5, 215, 116, 2, 612, 613, 
+	5, 257, 137, 2, 613, 614, 5, 231, 124, 2, 614, 615, 5, 241, 129, 2, 615, 
+	616, 5, 227, 122, 2, 616, 110, 3, 2, 2, 2, 617, 618, 5, 217, 117, 2, 618, 
+	619, 5, 263, 140, 2, 619, 112, 3, 2, 2, 2, 620, 621, 5, 225, 121, 2, 621, 
+	622, 5, 243, 130, 2, 622, 623, 5, 249, 133, 2, 623, 114, 3, 2, 2, 2, 624, 
+	625, 5, 251, 134, 2, 625, 626, 5, 253, 135, 2, 626, 627, 5, 215, 116, 2, 
+	627, 628, 5, 253, 135, 2, 628, 629, 5, 251, 134, 2, 629, 116, 3, 2, 2, 
+	2, 630, 631, 5, 253, 135, 2, 631, 632, 5, 231, 124, 2, 632, 633, 5, 239, 
+	128, 2, 633, 634, 5, 223, 120, 2, 634, 118, 3, 2, 2, 2, 635, 636, 5, 241, 
+	129, 2, 636, 637, 5, 243, 130, 2, 637, 638, 5, 259, 138, 2, 638, 120, 3, 
+	2, 2, 2, 639, 640, 5, 231, 124, 2, 640, 641, 5, 241, 129, 2, 641, 122, 
+	3, 2, 2, 2, 642, 643, 5, 237, 127, 2, 643, 644, 5, 243, 130, 2, 644, 645, 
+	5, 227, 122, 2, 645, 124, 3, 2, 2, 2, 646, 647, 5, 245, 131, 2, 647, 648, 
+	5, 249, 133, 2, 648, 649, 5, 243, 130, 2, 649, 650, 5, 225, 121, 2, 650, 
+	651, 5, 231, 124, 2, 651, 652, 5, 237, 127, 2, 652, 653, 5, 223, 120, 2, 
+	653, 126, 3, 2, 2, 2, 654, 655, 5, 251, 134, 2, 655, 656, 5, 255, 136, 
+	2, 656, 657, 5, 239, 128, 2, 657, 128, 3, 2, 2, 2, 658, 659, 5, 239, 128, 
+	2, 659, 660, 5, 231, 124, 2, 660, 661, 5, 241, 129, 2, 661, 130, 3, 2, 
+	2, 2, 662, 663, 5, 239, 128, 2, 663, 664, 5, 215, 116, 2, 664, 665, 5, 
+	261, 139, 2, 665, 132, 3, 2, 2, 2, 666, 667, 5, 219, 118, 2, 667, 668, 
+	5, 243, 130, 2, 668, 669, 5, 255, 136, 2, 669, 670, 5, 241, 129, 2, 670, 
+	671, 5, 253, 135, 2, 671, 134, 3, 2, 2, 2, 672, 673, 5, 215, 116, 2, 673, 
+	674, 5, 257, 137, 2, 674, 675, 5, 227, 122, 2, 675, 136, 3, 2, 2, 2, 676, 
+	677, 5, 251, 134, 2, 677, 678, 5, 253, 135, 2, 678, 679, 5, 221, 119, 2, 
+	679, 680, 5, 221, 119, 2, 680, 681, 5, 223, 120, 2, 681, 682, 5, 257, 137, 
+	2, 682, 138, 3, 2, 2, 2, 683, 684, 5, 229, 123, 2, 684, 685, 5, 231, 124, 
+	2, 685, 686, 5, 251, 134, 2, 686, 687, 5, 253, 135, 2, 687, 688, 5, 243, 
+	130, 2, 688, 689, 5, 227, 122, 2, 689, 690, 5, 249, 133, 2, 690, 691, 5, 
+	215, 116, 2, 691, 692, 5, 239, 128, 2, 692, 140, 3, 2, 2, 2, 693, 694, 
+	5, 251, 134, 2, 694, 142, 3, 2, 2, 2, 695, 696, 7, 111, 2, 2, 696, 144, 
+	3, 2, 2, 2, 697, 698, 5, 229, 123, 2, 698, 146, 3, 2, 2, 2, 699, 700, 5, 
+	221, 119, 2, 700, 148, 3, 2, 2, 2, 701, 702, 5, 259, 138, 2, 702, 150, 
+	3, 2, 2, 2, 703, 704, 7, 79, 2, 2, 704, 152, 3, 2, 2, 2, 705, 706, 5, 263, 
+	140, 2, 706, 154, 3, 2, 2, 2, 707, 708, 7, 48, 2, 2, 708, 156, 3, 2, 2, 
+	2, 709, 710, 7, 60, 2, 2, 710, 158, 3, 2, 2, 2, 711, 712, 7, 63, 2, 2, 
+	712, 160, 3, 2, 2, 2, 713, 714, 7, 62, 2, 2, 714, 715, 7, 64, 2, 2, 715, 
+	162, 3, 2, 2, 2, 716, 717, 7, 35, 2, 2, 717, 718, 7, 63, 2, 2, 718, 164, 
+	3, 2, 2, 2, 719, 720, 7, 64, 2, 2, 720, 166, 3, 2, 2, 2, 721, 722, 7, 64, 
+	2, 2, 722, 723, 7, 63, 2, 2, 723, 168, 3, 2, 2, 2, 724, 725, 7, 62, 2, 
+	2, 725, 170, 3, 2, 2, 2, 726, 727, 7, 62, 2, 2, 727, 728, 7, 63, 2, 2, 
+	728, 172, 3, 2, 2, 2, 729, 730, 7, 63, 2, 2, 730, 731, 7, 128, 2, 2, 731, 
+	174, 3, 2, 2, 2, 732, 733, 7, 35, 2, 2, 733, 734, 7, 128, 2, 2, 734, 176, 
+	3, 2, 2, 2, 735, 736, 7, 46, 2, 2, 736, 178, 3, 2, 2, 2, 737, 738, 7, 125, 
+	2, 2, 738, 180, 3, 2, 2, 2, 739, 740, 7, 127, 2, 2, 740, 182, 3, 2, 2, 
+	2, 741, 742, 7, 93, 2, 2, 742, 184, 3, 2, 2, 2, 743, 744, 7, 95, 2, 2, 
+	744, 186, 3, 2, 2, 2, 745, 746, 7, 42, 2, 2, 746, 188, 3, 2, 2, 2, 747, 
+	748, 7, 43, 2, 2, 748, 190, 3, 2, 2, 2, 749, 750, 7, 45, 2, 2, 750, 192, 
+	3, 2, 2, 2, 751, 752, 7, 47, 2, 2, 752, 194, 3, 2, 2, 2, 753, 754, 7, 49, 
+	2, 2, 754, 196, 3, 2, 2, 2, 755, 756, 7, 44, 2, 2, 756, 198, 3, 2, 2, 2, 
+	757, 758, 7, 39, 2, 2, 758, 200, 3, 2, 2, 2, 759, 760, 5, 213, 115, 2, 
+	760, 202, 3, 2, 2, 2, 761, 763, 5, 211, 114, 2, 762, 761, 3, 2, 2, 2, 763, 
+	764, 3, 2, 2, 2, 764, 762, 3, 2, 2, 2, 764, 765, 3, 2, 2, 2, 765, 204, 
+	3, 2, 2, 2, 766, 768, 5, 211, 114, 2, 767, 766, 3, 2, 2, 2, 768, 769, 3, 
+	2, 2, 2, 769, 767, 3, 2, 2, 2, 769, 770, 3, 2, 2, 2, 770, 771, 3, 2, 2, 
+	2, 771, 772, 7, 48, 2, 2, 772, 776, 10, 2, 2, 2, 773, 775, 5, 211, 114, 
+	2, 774, 773, 3, 2, 2, 2, 775, 778, 3, 2, 2, 2, 776, 774, 3, 2, 2, 2, 776, 
+	777, 3, 2, 2, 2, 777, 786, 3, 2, 2, 2, 778, 776, 3, 2, 2, 2, 779, 781, 
+	7, 48, 2, 2, 780, 782, 5, 211, 114, 2, 781, 780, 3, 2, 2, 2, 782, 783, 
+	3, 2, 2, 2, 783, 781, 3, 2, 2, 2, 783, 784, 3, 2, 2, 2, 784, 786, 3, 2, 
+	2, 2, 785, 767, 3, 2, 2, 2, 785, 779, 3, 2, 2, 2, 786, 206, 3, 2, 2, 2, 
+	787, 789, 5, 209, 113, 2, 788, 787, 3, 2, 2, 2, 789, 790, 3, 2, 2, 2, 790, 
+	788, 3, 2, 2, 2, 790, 791, 3, 2, 2, 2, 791, 792, 3, 2, 2, 2, 792, 793, 
+	8, 112, 2, 2, 793, 208, 3, 2, 2, 2, 794, 795, 9, 3, 2, 2, 795, 210, 3, 
+	2, 2, 2, 796, 797, 9, 4, 2, 2, 797, 212, 3, 2, 2, 2, 798, 804, 9, 5, 2, 
+	2, 799, 803, 9, 5, 2, 2, 800, 803, 5, 211, 114, 2, 801, 803, 9, 6, 2, 2, 
+	802, 799, 3, 2, 2, 2, 802, 800, 3, 2, 2, 2, 802, 801, 3, 2, 2, 2, 803, 
+	806, 3, 2, 2, 2, 804, 802, 3, 2, 2, 2, 804, 805, 3, 2, 2, 2, 805, 849, 
+	3, 2, 2, 2, 806, 804, 3, 2, 2, 2, 807, 808, 7, 38, 2, 2, 808, 812, 7, 125, 
+	2, 2, 809, 811, 11, 2, 2, 2, 810, 809, 3, 2, 2, 2, 811, 814, 3, 2, 2, 2, 
+	812, 813, 3, 2, 2, 2, 812, 810, 3, 2, 2, 2, 813, 815, 3, 2, 2, 2, 814, 
+	812, 3, 2, 2, 2, 815, 849, 7, 127, 2, 2, 816, 820, 9, 7, 2, 2, 817, 821, 
+	9, 5, 2, 2, 818, 821, 5, 211, 114, 2, 819, 821, 9, 7, 2, 2, 820, 817, 3, 
+	2, 2, 2, 820, 818, 3, 2, 2, 2, 820, 819, 3, 2, 2, 2, 821, 822, 3, 2, 2, 
+	2, 822, 820, 3, 2, 2, 2, 822, 823, 3, 2, 2, 2, 823, 849, 3, 2, 2, 2, 824, 
+	828, 7, 36, 2, 2, 825, 827, 11, 2, 2, 2, 826, 825, 3, 2, 2, 2, 827, 830, 
+	3, 2, 2, 2, 828, 829, 3, 2, 2, 2, 828, 826, 3, 2, 2, 2, 829, 831, 3, 2, 
+	2, 2, 830, 828, 3, 2, 2, 2, 831, 849, 7, 36, 2, 2, 832, 836, 7, 98, 2, 
+	2, 833, 835, 11, 2, 2, 2, 834, 833, 3, 2, 2, 2, 835, 838, 3, 2, 2, 2, 836, 
+	837, 3, 2, 2, 2, 836, 834, 3, 2, 2, 2, 837, 839, 3, 2, 2, 2, 838, 836, 
+	3, 2, 2, 2, 839, 849, 7, 98, 2, 2, 840, 844, 7, 41, 2, 2, 841, 843, 11, 
+	2, 2, 2, 842, 841, 3, 2, 2, 2, 843, 846, 3, 2, 2, 2, 844, 845, 3, 2, 2, 
+	2, 844, 842, 3, 2, 2, 2, 845, 847, 3, 2, 2, 2, 846, 844, 3, 2, 2, 2, 847, 
+	849, 7, 41, 2, 2, 848, 798, 3, 2, 2, 2, 848, 807, 3, 2, 2, 2, 848, 816, 
+	3, 2, 2, 2, 848, 824, 3, 2, 2, 2, 848, 832, 3, 2, 2, 2, 848, 840, 3, 2, 
+	2, 2, 849, 214, 3, 2, 2, 2, 850, 851, 9, 8, 2, 2, 851, 216, 3, 2, 2, 2, 
+	852, 853, 9, 9, 2, 2, 853, 218, 3, 2, 2, 2, 854, 855, 9, 10, 2, 2, 855, 
+	220, 3, 2, 2, 2, 856, 857, 9, 11, 2, 2, 857, 222, 3, 2, 2, 2, 858, 859, 
+	9, 12, 2, 2, 859, 224, 3, 2, 2, 2, 860, 861, 9, 13, 2, 2, 861, 226, 3, 
+	2, 2, 2, 862, 863, 9, 14, 2, 2, 863, 228, 3, 2, 2, 2, 864, 865, 9, 15, 
+	2, 2, 865, 230, 3, 2, 2, 2, 866, 867, 9, 16, 2, 2, 867, 232, 3, 2, 2, 2, 
+	868, 869, 9, 17, 2, 2, 869, 234, 3, 2, 2, 2, 870, 871, 9, 18, 2, 2, 871, 
+	236, 3, 2, 2, 2, 872, 873, 9, 19, 2, 2, 873, 238, 3, 2, 2, 2, 874, 875, 
+	9, 20, 2, 2, 875, 240, 3, 2, 2, 2, 876, 877, 9, 21, 2, 2, 877, 242, 3, 
+	2, 2, 2, 878, 879, 9, 22, 2, 2, 879, 244, 3, 2, 2, 2, 880, 881, 9, 23, 
+	2, 2, 881, 246, 3, 2, 2, 2, 882, 883, 9, 24, 2, 2, 883, 248, 3, 2, 2, 2, 
+	884, 885, 9, 25, 2, 2, 885, 250, 3, 2, 2, 2, 886, 887, 9, 26, 2, 2, 887, 
+	252, 3, 2, 2, 2, 888, 889, 9, 27, 2, 2, 889, 254, 3, 2, 2, 2, 890, 891, 
+	9, 28, 2, 2, 891, 256, 3, 2, 2, 2, 892, 893, 9, 29, 2, 2, 893, 258, 3, 
+	2, 2, 2, 894, 895, 9, 30, 2, 2, 895, 260, 3, 2, 2, 2, 896, 897, 9, 31, 
+	2, 2, 897, 262, 3, 2, 2, 2, 898, 899, 9, 32, 2, 2, 899, 264, 3, 2, 2, 2, 
+	900, 901, 9, 33, 2, 2, 901, 266, 3, 2, 2, 2, 902, 904, 3, 2, 2, 2, 904, 
+	905, 5, 249, 133, 2, 905, 906, 5, 215, 116, 2, 906, 907, 5, 253, 135, 2, 
+	907, 908, 5, 223, 120, 2, 908, 903, 3, 2, 2, 2, 909, 911, 3, 2, 2, 2, 911, 
+	912, 5, 231, 124, 2, 912, 913, 5, 249, 133, 2, 913, 914, 5, 215, 116, 2, 
+	914, 915, 5, 253, 135, 2, 915, 916, 5, 223, 120, 2, 916, 910, 3, 2, 2, 
+	2, 917, 919, 3, 2, 2, 2, 919, 920, 5, 221, 119, 2, 920, 921, 5, 223, 120, 
+	2, 921, 922, 5, 237, 127, 2, 922, 923, 5, 253, 135, 2, 923, 924, 5, 215, 
+	116, 2, 924, 918, 3, 2, 2, 2, 925, 927, 3, 2, 2, 2, 927, 928, 5, 221, 119, 
+	2, 928, 929, 5, 223, 120, 2, 929, 930, 5, 249, 133, 2, 930, 931, 5, 231, 
+	124, 2, 931, 932, 5, 257, 137, 2, 932, 933, 5, 215, 116, 2, 933, 934, 5, 
+	253, 135, 2, 934, 935, 5, 231, 124, 2, 935, 936, 5, 257, 137, 2, 936, 937, 
+	5, 223, 120, 2, 937, 926, 3, 2, 2, 2, 938, 940, 3, 2, 2, 2, 940, 941, 5, 
+	247, 132, 2, 941, 942, 5, 255, 136, 2, 942, 943, 5, 215, 116, 2, 943, 944, 
+	5, 241, 129, 2, 944, 945, 5, 253, 135, 2, 945, 946, 5, 231, 124, 2, 946, 
+	947, 5, 237, 127, 2, 947, 948, 5, 223, 120, 2, 948, 939, 3, 2, 2, 2, 949, 
+	951, 3, 2, 2, 2, 951, 952, 5, 221, 119, 2, 952, 953, 5, 223, 120, 2, 953, 
+	954, 5, 237, 127, 2, 954, 955, 5, 223, 120, 2, 955, 956, 5, 253, 135, 2, 
+	956, 957, 5, 223, 120, 2, 957, 950, 3, 2, 2, 2, 958, 960, 3, 2, 2, 2, 960, 
+	961, 5, 239, 128, 2, 961, 962, 5, 223, 120, 2, 962, 963, 5, 253, 135, 2, 
+	963, 964, 5, 249, 133, 2, 964, 965, 5, 231, 124, 2, 965, 966, 5, 219, 118, 
+	2, 966, 959, 3, 2, 2, 2, 967, 969, 3, 2, 2, 2, 969, 970, 5, 251, 134, 2, 
+	970, 971, 5, 223, 120, 2, 971, 972, 5, 249, 133, 2, 972, 973, 5, 231, 124, 
+	2, 973, 974, 5, 223, 120, 2, 974, 975, 5, 251, 134, 2, 975, 968, 3, 2, 
+	2, 2, 18, 2, 764, 769, 776, 783, 785, 790, 802, 804, 812, 820, 822, 828, 
+	836, 844, 848, 3, 8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -453,50 +486,52 @@ var lexerLiteralNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
-	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "'m'", 
-	"", "", "", "'M'", "", "'.'", "':'", "'='", "'<>'", "'!='", "'>'", "'>='", 
-	"'<'", "'<='", "'=~'", "'!~'", "','", "'{'", "'}'", "'['", "']'", "'('", 
-	"')'", "'+'", "'-'", "'/'", "'*'", "'%'",
+	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
+	"", "", "", "", "", "", "", "'m'", "", "", "", "'M'", "", "'.'", "':'", 
+	"'='", "'<>'", "'!='", "'>'", "'>='", "'<'", "'<='", "'=~'", "'!~'", "','", 
+	"'{'", "'}'", "'['", "']'", "'('", "')'", "'+'", "'-'", "'/'", "'*'", "'%'",
 }
 
 var lexerSymbolicNames = []string{
-	"", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_INTERVAL", "T_INTERVAL_NAME", 
-	"T_SHARD", "T_REPLICATION", "T_TTL", "T_META_TTL", "T_PAST_TTL", "T_FUTURE_TTL", 
-	"T_KILL", "T_ON", "T_SHOW", "T_DATASBAE", "T_DATASBAES", "T_NAMESPACE", 
-	"T_NAMESPACES", "T_NODE", "T_MEASUREMENTS", "T_MEASUREMENT", "T_FIELD", 
-	"T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", "T_KEY", "T_WITH", "T_VALUES", 
-	"T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", "T_QUERIES", "T_QUERY", "T_EXPLAIN", 
-	"T_WITH_VALUE", "T_SELECT", "T_AS", "T_AND", "T_OR", "T_FILL", "T_NULL", 
-	"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", 
-	"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", 
-	"T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", 
-	"T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", 
-	"T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", 
-	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
-	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
-	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS",
+	"", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_DELETE", "T_INTERVAL", 
+	"T_INTERVAL_NAME", "T_SHARD", "T_REPLICATION", "T_TTL", "T_META_TTL", "T_PAST_TTL", 
+	"T_FUTURE_TTL", "T_KILL", "T_ON", "T_SHOW", "T_DATASBAE", "T_DATASBAES", 
+	"T_NAMESPACE", "T_NAMESPACES", "T_NODE", "T_MEASUREMENTS", "T_MEASUREMENT", 
+	"T_METRIC", "T_SERIES", "T_FIELD", "T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", 
+	"T_KEY", "T_WITH", "T_VALUES", "T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", 
+	"T_QUERIES", "T_QUERY", "T_EXPLAIN", "T_WITH_VALUE", "T_SELECT", "T_AS", 
+	"T_AND", "T_OR", "T_FILL", "T_NULL", "T_PREVIOUS", "T_ORDER", "T_ASC", 
+	"T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", "T_IS", "T_GROUP", "T_HAVING", 
+	"T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", "T_IN", "T_LOG", "T_PROFILE", 
+	"T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", "T_STDDEV", "T_HISTOGRAM", 
+	"T_RATE", "T_IRATE", "T_DELTA", "T_DERIVATIVE", "T_QUANTILE", "T_SECOND", 
+	"T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", 
+	"T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", 
+	"T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", 
+	"T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", 
+	"T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC", "WS",
 }
 
 var lexerRuleNames = []string{
-	"T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_INTERVAL", "T_INTERVAL_NAME", 
+	"T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_DELETE", "T_INTERVAL", "T_INTERVAL_NAME", 
 	"T_SHARD", "T_REPLICATION", "T_TTL", "T_META_TTL", "T_PAST_TTL", "T_FUTURE_TTL", 
 	"T_KILL", "T_ON", "T_SHOW", "T_DATASBAE", "T_DATASBAES", "T_NAMESPACE", 
-	"T_NAMESPACES", "T_NODE", "T_MEASUREMENTS", "T_MEASUREMENT", "T_FIELD", 
-	"T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", "T_KEY", "T_WITH", "T_VALUES", 
-	"T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", "T_QUERIES", "T_QUERY", "T_EXPLAIN", 
-	"T_WITH_VALUE", "T_SELECT", "T_AS", "T_AND", "T_OR", "T_FILL", "T_NULL", 
-	"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", 
-	"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", 
-	"T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", 
-	"T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", 
-	"T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", 
-	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
-	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
-	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS", "BLANK", "L_DIGIT", "L_ID_PART", "A", "B", "C", "D", "E", 
-	"F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", 
-	"U", "V", "W", "X", "Y", "Z",
+	"T_NAMESPACES", "T_NODE", "T_MEASUREMENTS", "T_MEASUREMENT", "T_METRIC", 
+	"T_SERIES", "T_FIELD", "T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", "T_KEY", 
+	"T_WITH", "T_VALUES", "T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", "T_QUERIES", 
+	"T_QUERY", "T_EXPLAIN", "T_WITH_VALUE", "T_SELECT", "T_AS", "T_AND", "T_OR", 
+	"T_FILL", "T_NULL", "T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", 
+	"T_NOT", "T_BETWEEN", "T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", 
+	"T_TIME", "T_NOW", "T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", 
+	"T_COUNT", "T_AVG", "T_STDDEV", "T_HISTOGRAM", "T_RATE", "T_IRATE", "T_DELTA", 
+	"T_DERIVATIVE", "T_QUANTILE", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", 
+	"T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", 
+	"T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", 
+	"T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", 
+	"T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", 
+	"T_MOD", "L_ID", "L_INT", "L_DEC", "WS", "BLANK", "L_DIGIT", "L_ID_PART", 
+	"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", 
+	"P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
 }
 
 type SQLLexer struct {
@@ -538,104 +573,112 @@ const (
 	SQLLexerT_UPDATE = 2
 	SQLLexerT_SET = 3
 	SQLLexerT_DROP = 4
-	SQLLexerT_INTERVAL = 5
-	SQLLexerT_INTERVAL_NAME = 6
-	SQLLexerT_SHARD = 7
-	SQLLexerT_REPLICATION = 8
-	SQLLexerT_TTL = 9
-	SQLLexerT_META_TTL = 10
-	SQLLexerT_PAST_TTL = 11
-	SQLLexerT_FUTURE_TTL = 12
-	SQLLexerT_KILL = 13
-	SQLLexerT_ON = 14
-	SQLLexerT_SHOW = 15
-	SQLLexerT_DATASBAE = 16
-	SQLLexerT_DATASBAES = 17
-	SQLLexerT_NAMESPACE = 18
-	SQLLexerT_NAMESPACES = 19
-	SQLLexerT_NODE = 20
-	SQLLexerT_MEASUREMENTS = 21
-	SQLLexerT_MEASUREMENT = 22
-	SQLLexerT_FIELD = 23
-	SQLLexerT_FIELDS = 24
-	SQLLexerT_TAG = 25
-	SQLLexerT_INFO = 26
-	SQLLexerT_KEYS = 27
-	SQLLexerT_KEY = 28
-	SQLLexerT_WITH = 29
-	SQLLexerT_VALUES = 30
-	SQLLexerT_VALUE = 31
-	SQLLexerT_FROM = 32
-	SQLLexerT_WHERE = 33
-	SQLLexerT_LIMIT = 34
-	SQLLexerT_QUERIES = 35
-	SQLLexerT_QUERY = 36
-	SQLLexerT_EXPLAIN = 37
-	SQLLexerT_WITH_VALUE = 38
-	SQLLexerT_SELECT = 39
-	SQLLexerT_AS = 40
-	SQLLexerT_AND = 41
-	SQLLexerT_OR = 42
-	SQLLexerT_FILL = 43
-	SQLLexerT_NULL = 44
-	SQLLexerT_PREVIOUS = 45
-	SQLLexerT_ORDER = 46
-	SQLLexerT_ASC = 47
-	SQLLexerT_DESC = 48
-	SQLLexerT_LIKE = 49
-	SQLLexerT_NOT = 50
-	SQLLexerT_BETWEEN = 51
-	SQLLexerT_IS = 52
-	SQLLexerT_GROUP = 53
-	SQLLexerT_HAVING = 54
-	SQLLexerT_BY = 55
-	SQLLexerT_FOR = 56
-	SQLLexerT_STATS = 57
-	SQLLexerT_TIME = 58
-	SQLLexerT_NOW = 59
-	SQLLexerT_IN = 60
-	SQLLexerT_LOG = 61
-	SQLLexerT_PROFILE = 62
-	SQLLexerT_SUM = 63
-	SQLLexerT_MIN = 64
-	SQLLexerT_MAX = 65
-	SQLLexerT_COUNT = 66
-	SQLLexerT_AVG = 67
-	SQLLexerT_STDDEV = 68
-	SQLLexerT_HISTOGRAM = 69
-	SQLLexerT_SECOND = 70
-	SQLLexerT_MINUTE = 71
-	SQLLexerT_HOUR = 72
-	SQLLexerT_DAY = 73
-	SQLLexerT_WEEK = 74
-	SQLLexerT_MONTH = 75
-	SQLLexerT_YEAR = 76
-	SQLLexerT_DOT = 77
-	SQLLexerT_COLON = 78
-	SQLLexerT_EQUAL = 79
-	SQLLexerT_NOTEQUAL = 80
-	SQLLexerT_NOTEQUAL2 = 81
-	SQLLexerT_GREATER = 82
-	SQLLexerT_GREATEREQUAL = 83
-	SQLLexerT_LESS = 84
-	SQLLexerT_LESSEQUAL = 85
-	SQLLexerT_REGEXP = 86
-	SQLLexerT_NEQREGEXP = 87
-	SQLLexerT_COMMA = 88
-	SQLLexerT_OPEN_B = 89
-	SQLLexerT_CLOSE_B = 90
-	SQLLexerT_OPEN_SB = 91
-	SQLLexerT_CLOSE_SB = 92
-	SQLLexerT_OPEN_P = 93
-	SQLLexerT_CLOSE_P = 94
-	SQLLexerT_ADD = 95
-	SQLLexerT_SUB = 96
-	SQLLexerT_DIV = 97
-	SQLLexerT_MUL = 98
-	SQLLexerT_MOD = 99
-	SQLLexerL_ID = 100
-	SQLLexerL_INT = 101
-	SQLLexerL_DEC = 102
-	SQLLexerWS = 103
+	SQLLexerT_DELETE = 5
+	SQLLexerT_INTERVAL = 6
+	SQLLexerT_INTERVAL_NAME = 7
+	SQLLexerT_SHARD = 8
+	SQLLexerT_REPLICATION = 9
+	SQLLexerT_TTL = 10
+	SQLLexerT_META_TTL = 11
+	SQLLexerT_PAST_TTL = 12
+	SQLLexerT_FUTURE_TTL = 13
+	SQLLexerT_KILL = 14
+	SQLLexerT_ON = 15
+	SQLLexerT_SHOW = 16
+	SQLLexerT_DATASBAE = 17
+	SQLLexerT_DATASBAES = 18
+	SQLLexerT_NAMESPACE = 19
+	SQLLexerT_NAMESPACES = 20
+	SQLLexerT_NODE = 21
+	SQLLexerT_MEASUREMENTS = 22
+	SQLLexerT_MEASUREMENT = 23
+	SQLLexerT_METRIC = 24
+	SQLLexerT_SERIES = 25
+	SQLLexerT_FIELD = 26
+	SQLLexerT_FIELDS = 27
+	SQLLexerT_TAG = 28
+	SQLLexerT_INFO = 29
+	SQLLexerT_KEYS = 30
+	SQLLexerT_KEY = 31
+	SQLLexerT_WITH = 32
+	SQLLexerT_VALUES = 33
+	SQLLexerT_VALUE = 34
+	SQLLexerT_FROM = 35
+	SQLLexerT_WHERE = 36
+	SQLLexerT_LIMIT = 37
+	SQLLexerT_QUERIES = 38
+	SQLLexerT_QUERY = 39
+	SQLLexerT_EXPLAIN = 40
+	SQLLexerT_WITH_VALUE = 41
+	SQLLexerT_SELECT = 42
+	SQLLexerT_AS = 43
+	SQLLexerT_AND = 44
+	SQLLexerT_OR = 45
+	SQLLexerT_FILL = 46
+	SQLLexerT_NULL = 47
+	SQLLexerT_PREVIOUS = 48
+	SQLLexerT_ORDER = 49
+	SQLLexerT_ASC = 50
+	SQLLexerT_DESC = 51
+	SQLLexerT_LIKE = 52
+	SQLLexerT_NOT = 53
+	SQLLexerT_BETWEEN = 54
+	SQLLexerT_IS = 55
+	SQLLexerT_GROUP = 56
+	SQLLexerT_HAVING = 57
+	SQLLexerT_BY = 58
+	SQLLexerT_FOR = 59
+	SQLLexerT_STATS = 60
+	SQLLexerT_TIME = 61
+	SQLLexerT_NOW = 62
+	SQLLexerT_IN = 63
+	SQLLexerT_LOG = 64
+	SQLLexerT_PROFILE = 65
+	SQLLexerT_SUM = 66
+	SQLLexerT_MIN = 67
+	SQLLexerT_MAX = 68
+	SQLLexerT_COUNT = 69
+	SQLLexerT_AVG = 70
+	SQLLexerT_STDDEV = 71
+	SQLLexerT_HISTOGRAM = 72
+	SQLLexerT_RATE = 73
+	SQLLexerT_IRATE = 74
+	SQLLexerT_DELTA = 75
+	SQLLexerT_DERIVATIVE = 76
+	SQLLexerT_QUANTILE = 77
+	SQLLexerT_SECOND = 78
+	SQLLexerT_MINUTE = 79
+	SQLLexerT_HOUR = 80
+	SQLLexerT_DAY = 81
+	SQLLexerT_WEEK = 82
+	SQLLexerT_MONTH = 83
+	SQLLexerT_YEAR = 84
+	SQLLexerT_DOT = 85
+	SQLLexerT_COLON = 86
+	SQLLexerT_EQUAL = 87
+	SQLLexerT_NOTEQUAL = 88
+	SQLLexerT_NOTEQUAL2 = 89
+	SQLLexerT_GREATER = 90
+	SQLLexerT_GREATEREQUAL = 91
+	SQLLexerT_LESS = 92
+	SQLLexerT_LESSEQUAL = 93
+	SQLLexerT_REGEXP = 94
+	SQLLexerT_NEQREGEXP = 95
+	SQLLexerT_COMMA = 96
+	SQLLexerT_OPEN_B = 97
+	SQLLexerT_CLOSE_B = 98
+	SQLLexerT_OPEN_SB = 99
+	SQLLexerT_CLOSE_SB = 100
+	SQLLexerT_OPEN_P = 101
+	SQLLexerT_CLOSE_P = 102
+	SQLLexerT_ADD = 103
+	SQLLexerT_SUB = 104
+	SQLLexerT_DIV = 105
+	SQLLexerT_MUL = 106
+	SQLLexerT_MOD = 107
+	SQLLexerL_ID = 108
+	SQLLexerL_INT = 109
+	SQLLexerL_DEC = 110
+	SQLLexerWS = 111
 )
 
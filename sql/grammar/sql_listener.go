@@ -189,6 +189,24 @@ type SQLListener interface {
 	// EnterNonReservedWords is called when entering the nonReservedWords production.
 	EnterNonReservedWords(c *NonReservedWordsContext)
 
+	// EnterShowQueriesStmt is called when entering the showQueriesStmt production.
+	EnterShowQueriesStmt(c *ShowQueriesStmtContext)
+
+	// EnterKillQueryStmt is called when entering the killQueryStmt production.
+	EnterKillQueryStmt(c *KillQueryStmtContext)
+
+	// EnterDropDatabaseStmt is called when entering the dropDatabaseStmt production.
+	EnterDropDatabaseStmt(c *DropDatabaseStmtContext)
+
+	// EnterDropMetricStmt is called when entering the dropMetricStmt production.
+	EnterDropMetricStmt(c *DropMetricStmtContext)
+
+	// EnterDeleteSeriesStmt is called when entering the deleteSeriesStmt production.
+	EnterDeleteSeriesStmt(c *DeleteSeriesStmtContext)
+
+	// EnterDatabaseName is called when entering the databaseName production.
+	EnterDatabaseName(c *DatabaseNameContext)
+
 	// ExitStatement is called when exiting the statement production.
 	ExitStatement(c *StatementContext)
 
@@ -353,4 +371,22 @@ type SQLListener interface {
 
 	// ExitNonReservedWords is called when exiting the nonReservedWords production.
 	ExitNonReservedWords(c *NonReservedWordsContext)
+
+	// ExitDropDatabaseStmt is called when exiting the dropDatabaseStmt production.
+	ExitDropDatabaseStmt(c *DropDatabaseStmtContext)
+
+	// ExitDropMetricStmt is called when exiting the dropMetricStmt production.
+	ExitDropMetricStmt(c *DropMetricStmtContext)
+
+	// ExitDeleteSeriesStmt is called when exiting the deleteSeriesStmt production.
+	ExitDeleteSeriesStmt(c *DeleteSeriesStmtContext)
+
+	// ExitDatabaseName is called when exiting the databaseName production.
+	ExitDatabaseName(c *DatabaseNameContext)
+
+	// ExitShowQueriesStmt is called when exiting the showQueriesStmt production.
+	ExitShowQueriesStmt(c *ShowQueriesStmtContext)
+
+	// ExitKillQueryStmt is called when exiting the killQueryStmt production.
+	ExitKillQueryStmt(c *KillQueryStmtContext)
 }
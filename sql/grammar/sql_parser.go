@@ -33,7 +33,7 @@ var _ = strconv.Itoa
 
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 105, 511, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 113, 559, 
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9, 
@@ -87,180 +87,202 @@ var parserATN = []uint16{
 	10, 50, 3, 50, 3, 50, 3, 51, 3, 51, 3, 51, 3, 52, 3, 52, 3, 53, 3, 53, 
 	3, 54, 3, 54, 3, 55, 3, 55, 5, 55, 497, 10, 55, 3, 55, 3, 55, 3, 55, 5, 
 	55, 502, 10, 55, 7, 55, 504, 10, 55, 12, 55, 14, 55, 507, 11, 55, 3, 56, 
-	3, 56, 3, 56, 2, 5, 40, 68, 78, 57, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 
-	22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 
-	58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 
-	94, 96, 98, 100, 102, 104, 106, 108, 110, 2, 10, 3, 2, 43, 44, 4, 2, 46, 
-	47, 103, 104, 3, 2, 49, 50, 4, 2, 51, 51, 88, 88, 3, 2, 72, 78, 3, 2, 65, 
-	71, 3, 2, 97, 98, 11, 2, 3, 3, 7, 7, 9, 11, 15, 27, 29, 32, 34, 38, 41, 
-	55, 57, 60, 64, 78, 2, 531, 2, 112, 3, 2, 2, 2, 4, 122, 3, 2, 2, 2, 6, 
-	124, 3, 2, 2, 2, 8, 127, 3, 2, 2, 2, 10, 138, 3, 2, 2, 2, 12, 153, 3, 2, 
-	2, 2, 14, 161, 3, 2, 2, 2, 16, 170, 3, 2, 2, 2, 18, 188, 3, 2, 2, 2, 20, 
-	190, 3, 2, 2, 2, 22, 192, 3, 2, 2, 2, 24, 195, 3, 2, 2, 2, 26, 218, 3, 
-	2, 2, 2, 28, 221, 3, 2, 2, 2, 30, 229, 3, 2, 2, 2, 32, 233, 3, 2, 2, 2, 
-	34, 236, 3, 2, 2, 2, 36, 239, 3, 2, 2, 2, 38, 252, 3, 2, 2, 2, 40, 282, 
-	3, 2, 2, 2, 42, 292, 3, 2, 2, 2, 44, 300, 3, 2, 2, 2, 46, 305, 3, 2, 2, 
-	2, 48, 311, 3, 2, 2, 2, 50, 315, 3, 2, 2, 2, 52, 322, 3, 2, 2, 2, 54, 335, 
-	3, 2, 2, 2, 56, 349, 3, 2, 2, 2, 58, 351, 3, 2, 2, 2, 60, 353, 3, 2, 2, 
-	2, 62, 357, 3, 2, 2, 2, 64, 364, 3, 2, 2, 2, 66, 372, 3, 2, 2, 2, 68, 381, 
-	3, 2, 2, 2, 70, 392, 3, 2, 2, 2, 72, 394, 3, 2, 2, 2, 74, 396, 3, 2, 2, 
-	2, 76, 408, 3, 2, 2, 2, 78, 418, 3, 2, 2, 2, 80, 437, 3, 2, 2, 2, 82, 440, 
-	3, 2, 2, 2, 84, 442, 3, 2, 2, 2, 86, 449, 3, 2, 2, 2, 88, 451, 3, 2, 2, 
-	2, 90, 461, 3, 2, 2, 2, 92, 469, 3, 2, 2, 2, 94, 471, 3, 2, 2, 2, 96, 476, 
-	3, 2, 2, 2, 98, 481, 3, 2, 2, 2, 100, 485, 3, 2, 2, 2, 102, 488, 3, 2, 
-	2, 2, 104, 490, 3, 2, 2, 2, 106, 492, 3, 2, 2, 2, 108, 496, 3, 2, 2, 2, 
-	110, 508, 3, 2, 2, 2, 112, 113, 5, 4, 3, 2, 113, 114, 7, 2, 2, 3, 114, 
-	3, 3, 2, 2, 2, 115, 123, 5, 6, 4, 2, 116, 123, 5, 8, 5, 2, 117, 123, 5, 
-	10, 6, 2, 118, 123, 5, 12, 7, 2, 119, 123, 5, 14, 8, 2, 120, 123, 5, 16, 
-	9, 2, 121, 123, 5, 24, 13, 2, 122, 115, 3, 2, 2, 2, 122, 116, 3, 2, 2, 
-	2, 122, 117, 3, 2, 2, 2, 122, 118, 3, 2, 2, 2, 122, 119, 3, 2, 2, 2, 122, 
-	120, 3, 2, 2, 2, 122, 121, 3, 2, 2, 2, 123, 5, 3, 2, 2, 2, 124, 125, 7, 
-	17, 2, 2, 125, 126, 7, 19, 2, 2, 126, 7, 3, 2, 2, 2, 127, 128, 7, 17, 2, 
-	2, 128, 133, 7, 21, 2, 2, 129, 130, 7, 35, 2, 2, 130, 131, 7, 20, 2, 2, 
-	131, 132, 7, 81, 2, 2, 132, 134, 5, 18, 10, 2, 133, 129, 3, 2, 2, 2, 133, 
-	134, 3, 2, 2, 2, 134, 136, 3, 2, 2, 2, 135, 137, 5, 100, 51, 2, 136, 135, 
-	3, 2, 2, 2, 136, 137, 3, 2, 2, 2, 137, 9, 3, 2, 2, 2, 138, 139, 7, 17, 
-	2, 2, 139, 142, 7, 23, 2, 2, 140, 141, 7, 16, 2, 2, 141, 143, 5, 22, 12, 
-	2, 142, 140, 3, 2, 2, 2, 142, 143, 3, 2, 2, 2, 143, 148, 3, 2, 2, 2, 144, 
-	145, 7, 35, 2, 2, 145, 146, 7, 24, 2, 2, 146, 147, 7, 81, 2, 2, 147, 149, 
-	5, 18, 10, 2, 148, 144, 3, 2, 2, 2, 148, 149, 3, 2, 2, 2, 149, 151, 3, 
-	2, 2, 2, 150, 152, 5, 100, 51, 2, 151, 150, 3, 2, 2, 2, 151, 152, 3, 2, 
-	2, 2, 152, 11, 3, 2, 2, 2, 153, 154, 7, 17, 2, 2, 154, 157, 7, 26, 2, 2, 
-	155, 156, 7, 16, 2, 2, 156, 158, 5, 22, 12, 2, 157, 155, 3, 2, 2, 2, 157, 
-	158, 3, 2, 2, 2, 158, 159, 3, 2, 2, 2, 159, 160, 5, 34, 18, 2, 160, 13, 
-	3, 2, 2, 2, 161, 162, 7, 17, 2, 2, 162, 163, 7, 27, 2, 2, 163, 166, 7, 
-	29, 2, 2, 164, 165, 7, 16, 2, 2, 165, 167, 5, 22, 12, 2, 166, 164, 3, 2, 
-	2, 2, 166, 167, 3, 2, 2, 2, 167, 168, 3, 2, 2, 2, 168, 169, 5, 34, 18, 
-	2, 169, 15, 3, 2, 2, 2, 170, 171, 7, 17, 2, 2, 171, 172, 7, 27, 2, 2, 172, 
-	175, 7, 32, 2, 2, 173, 174, 7, 16, 2, 2, 174, 176, 5, 22, 12, 2, 175, 173, 
-	3, 2, 2, 2, 175, 176, 3, 2, 2, 2, 176, 177, 3, 2, 2, 2, 177, 178, 5, 34, 
-	18, 2, 178, 179, 7, 31, 2, 2, 179, 180, 7, 30, 2, 2, 180, 181, 7, 81, 2, 
-	2, 181, 183, 5, 20, 11, 2, 182, 184, 5, 36, 19, 2, 183, 182, 3, 2, 2, 2, 
-	183, 184, 3, 2, 2, 2, 184, 186, 3, 2, 2, 2, 185, 187, 5, 100, 51, 2, 186, 
-	185, 3, 2, 2, 2, 186, 187, 3, 2, 2, 2, 187, 17, 3, 2, 2, 2, 188, 189, 5, 
-	108, 55, 2, 189, 19, 3, 2, 2, 2, 190, 191, 5, 108, 55, 2, 191, 21, 3, 2, 
-	2, 2, 192, 193, 5, 108, 55, 2, 193, 23, 3, 2, 2, 2, 194, 196, 7, 39, 2, 
-	2, 195, 194, 3, 2, 2, 2, 195, 196, 3, 2, 2, 2, 196, 197, 3, 2, 2, 2, 197, 
-	200, 5, 26, 14, 2, 198, 199, 7, 16, 2, 2, 199, 201, 5, 22, 12, 2, 200, 
-	198, 3, 2, 2, 2, 200, 201, 3, 2, 2, 2, 201, 202, 3, 2, 2, 2, 202, 204, 
-	5, 34, 18, 2, 203, 205, 5, 36, 19, 2, 204, 203, 3, 2, 2, 2, 204, 205, 3, 
-	2, 2, 2, 205, 207, 3, 2, 2, 2, 206, 208, 5, 52, 27, 2, 207, 206, 3, 2, 
-	2, 2, 207, 208, 3, 2, 2, 2, 208, 210, 3, 2, 2, 2, 209, 211, 5, 60, 31, 
-	2, 210, 209, 3, 2, 2, 2, 210, 211, 3, 2, 2, 2, 211, 213, 3, 2, 2, 2, 212, 
-	214, 5, 100, 51, 2, 213, 212, 3, 2, 2, 2, 213, 214, 3, 2, 2, 2, 214, 216, 
-	3, 2, 2, 2, 215, 217, 7, 40, 2, 2, 216, 215, 3, 2, 2, 2, 216, 217, 3, 2, 
-	2, 2, 217, 25, 3, 2, 2, 2, 218, 219, 7, 41, 2, 2, 219, 220, 5, 28, 15, 
-	2, 220, 27, 3, 2, 2, 2, 221, 226, 5, 30, 16, 2, 222, 223, 7, 90, 2, 2, 
-	223, 225, 5, 30, 16, 2, 224, 222, 3, 2, 2, 2, 225, 228, 3, 2, 2, 2, 226, 
-	224, 3, 2, 2, 2, 226, 227, 3, 2, 2, 2, 227, 29, 3, 2, 2, 2, 228, 226, 3, 
-	2, 2, 2, 229, 231, 5, 78, 40, 2, 230, 232, 5, 32, 17, 2, 231, 230, 3, 2, 
-	2, 2, 231, 232, 3, 2, 2, 2, 232, 31, 3, 2, 2, 2, 233, 234, 7, 42, 2, 2, 
-	234, 235, 5, 108, 55, 2, 235, 33, 3, 2, 2, 2, 236, 237, 7, 34, 2, 2, 237, 
-	238, 5, 102, 52, 2, 238, 35, 3, 2, 2, 2, 239, 240, 7, 35, 2, 2, 240, 241, 
-	5, 38, 20, 2, 241, 37, 3, 2, 2, 2, 242, 253, 5, 40, 21, 2, 243, 244, 5, 
-	40, 21, 2, 244, 245, 7, 43, 2, 2, 245, 246, 5, 44, 23, 2, 246, 253, 3, 
-	2, 2, 2, 247, 250, 5, 44, 23, 2, 248, 249, 7, 43, 2, 2, 249, 251, 5, 40, 
-	21, 2, 250, 248, 3, 2, 2, 2, 250, 251, 3, 2, 2, 2, 251, 253, 3, 2, 2, 2, 
-	252, 242, 3, 2, 2, 2, 252, 243, 3, 2, 2, 2, 252, 247, 3, 2, 2, 2, 253, 
-	39, 3, 2, 2, 2, 254, 255, 8, 21, 1, 2, 255, 256, 7, 95, 2, 2, 256, 257, 
-	5, 40, 21, 2, 257, 258, 7, 96, 2, 2, 258, 283, 3, 2, 2, 2, 259, 268, 5, 
-	104, 53, 2, 260, 269, 7, 81, 2, 2, 261, 269, 7, 51, 2, 2, 262, 263, 7, 
-	52, 2, 2, 263, 269, 7, 51, 2, 2, 264, 269, 7, 88, 2, 2, 265, 269, 7, 89, 
-	2, 2, 266, 269, 7, 82, 2, 2, 267, 269, 7, 83, 2, 2, 268, 260, 3, 2, 2, 
-	2, 268, 261, 3, 2, 2, 2, 268, 262, 3, 2, 2, 2, 268, 264, 3, 2, 2, 2, 268, 
-	265, 3, 2, 2, 2, 268, 266, 3, 2, 2, 2, 268, 267, 3, 2, 2, 2, 269, 270, 
-	3, 2, 2, 2, 270, 271, 5, 106, 54, 2, 271, 283, 3, 2, 2, 2, 272, 276, 5, 
-	104, 53, 2, 273, 277, 7, 62, 2, 2, 274, 275, 7, 52, 2, 2, 275, 277, 7, 
-	62, 2, 2, 276, 273, 3, 2, 2, 2, 276, 274, 3, 2, 2, 2, 277, 278, 3, 2, 2, 
-	2, 278, 279, 7, 95, 2, 2, 279, 280, 5, 42, 22, 2, 280, 281, 7, 96, 2, 2, 
-	281, 283, 3, 2, 2, 2, 282, 254, 3, 2, 2, 2, 282, 259, 3, 2, 2, 2, 282, 
-	272, 3, 2, 2, 2, 283, 289, 3, 2, 2, 2, 284, 285, 12, 3, 2, 2, 285, 286, 
-	9, 2, 2, 2, 286, 288, 5, 40, 21, 4, 287, 284, 3, 2, 2, 2, 288, 291, 3, 
-	2, 2, 2, 289, 287, 3, 2, 2, 2, 289, 290, 3, 2, 2, 2, 290, 41, 3, 2, 2, 
-	2, 291, 289, 3, 2, 2, 2, 292, 297, 5, 106, 54, 2, 293, 294, 7, 90, 2, 2, 
-	294, 296, 5, 106, 54, 2, 295, 293, 3, 2, 2, 2, 296, 299, 3, 2, 2, 2, 297, 
-	295, 3, 2, 2, 2, 297, 298, 3, 2, 2, 2, 298, 43, 3, 2, 2, 2, 299, 297, 3, 
-	2, 2, 2, 300, 303, 5, 46, 24, 2, 301, 302, 7, 43, 2, 2, 302, 304, 5, 46, 
-	24, 2, 303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 45, 3, 2, 2, 2, 
-	305, 306, 7, 60, 2, 2, 306, 309, 5, 76, 39, 2, 307, 310, 5, 48, 25, 2, 
-	308, 310, 5, 108, 55, 2, 309, 307, 3, 2, 2, 2, 309, 308, 3, 2, 2, 2, 310, 
-	47, 3, 2, 2, 2, 311, 313, 5, 50, 26, 2, 312, 314, 5, 80, 41, 2, 313, 312, 
-	3, 2, 2, 2, 313, 314, 3, 2, 2, 2, 314, 49, 3, 2, 2, 2, 315, 316, 7, 61, 
-	2, 2, 316, 318, 7, 95, 2, 2, 317, 319, 5, 88, 45, 2, 318, 317, 3, 2, 2, 
-	2, 318, 319, 3, 2, 2, 2, 319, 320, 3, 2, 2, 2, 320, 321, 7, 96, 2, 2, 321, 
-	51, 3, 2, 2, 2, 322, 323, 7, 55, 2, 2, 323, 324, 7, 57, 2, 2, 324, 330, 
-	5, 54, 28, 2, 325, 326, 7, 45, 2, 2, 326, 327, 7, 95, 2, 2, 327, 328, 5, 
-	58, 30, 2, 328, 329, 7, 96, 2, 2, 329, 331, 3, 2, 2, 2, 330, 325, 3, 2, 
-	2, 2, 330, 331, 3, 2, 2, 2, 331, 333, 3, 2, 2, 2, 332, 334, 5, 66, 34, 
+	3, 56, 3, 56, 4, 57, 9, 57, 4, 58, 9, 58, 4, 59, 9, 59, 4, 60, 9, 60, 3, 
+	57, 3, 57, 3, 57, 3, 57, 3, 58, 3, 58, 3, 58, 3, 58, 5, 58, 528, 10, 58, 
+	3, 58, 3, 58, 3, 59, 3, 59, 3, 59, 3, 59, 5, 59, 536, 10, 59, 3, 59, 3, 
+	59, 3, 59, 3, 59, 3, 60, 3, 60, 3, 3, 3, 3, 3, 3, 4, 61, 9, 61, 4, 62, 
+	9, 62, 3, 61, 3, 61, 3, 61, 3, 62, 3, 62, 3, 62, 3, 62, 3, 3, 3, 3, 2, 
+	5, 40, 68, 78, 63, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 
+	30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 
+	66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 94, 96, 98, 100, 
+	102, 104, 106, 108, 110, 511, 513, 515, 517, 546, 548, 2, 11, 3, 2, 46, 
+	47, 4, 2, 49, 50, 111, 112, 3, 2, 52, 53, 4, 2, 54, 54, 96, 96, 3, 2, 80, 
+	86, 3, 2, 68, 79, 3, 2, 105, 106, 11, 2, 3, 3, 7, 8, 10, 12, 16, 30, 32, 
+	35, 37, 41, 44, 58, 60, 63, 67, 86, 3, 2, 25, 26, 2, 580, 2, 112, 3, 2, 
+	2, 2, 4, 122, 3, 2, 2, 2, 6, 124, 3, 2, 2, 2, 8, 127, 3, 2, 2, 2, 10, 138, 
+	3, 2, 2, 2, 12, 153, 3, 2, 2, 2, 14, 161, 3, 2, 2, 2, 16, 170, 3, 2, 2, 
+	2, 18, 188, 3, 2, 2, 2, 20, 190, 3, 2, 2, 2, 22, 192, 3, 2, 2, 2, 24, 195, 
+	3, 2, 2, 2, 26, 218, 3, 2, 2, 2, 28, 221, 3, 2, 2, 2, 30, 229, 3, 2, 2, 
+	2, 32, 233, 3, 2, 2, 2, 34, 236, 3, 2, 2, 2, 36, 239, 3, 2, 2, 2, 38, 252, 
+	3, 2, 2, 2, 40, 282, 3, 2, 2, 2, 42, 292, 3, 2, 2, 2, 44, 300, 3, 2, 2, 
+	2, 46, 305, 3, 2, 2, 2, 48, 311, 3, 2, 2, 2, 50, 315, 3, 2, 2, 2, 52, 322, 
+	3, 2, 2, 2, 54, 335, 3, 2, 2, 2, 56, 349, 3, 2, 2, 2, 58, 351, 3, 2, 2, 
+	2, 60, 353, 3, 2, 2, 2, 62, 357, 3, 2, 2, 2, 64, 364, 3, 2, 2, 2, 66, 372, 
+	3, 2, 2, 2, 68, 381, 3, 2, 2, 2, 70, 392, 3, 2, 2, 2, 72, 394, 3, 2, 2, 
+	2, 74, 396, 3, 2, 2, 2, 76, 408, 3, 2, 2, 2, 78, 418, 3, 2, 2, 2, 80, 437, 
+	3, 2, 2, 2, 82, 440, 3, 2, 2, 2, 84, 442, 3, 2, 2, 2, 86, 449, 3, 2, 2, 
+	2, 88, 451, 3, 2, 2, 2, 90, 461, 3, 2, 2, 2, 92, 469, 3, 2, 2, 2, 94, 471, 
+	3, 2, 2, 2, 96, 476, 3, 2, 2, 2, 98, 481, 3, 2, 2, 2, 100, 485, 3, 2, 2, 
+	2, 102, 488, 3, 2, 2, 2, 104, 490, 3, 2, 2, 2, 106, 492, 3, 2, 2, 2, 108, 
+	496, 3, 2, 2, 2, 110, 508, 3, 2, 2, 2, 112, 113, 5, 4, 3, 2, 113, 114, 
+	7, 2, 2, 3, 114, 3, 3, 2, 2, 2, 115, 123, 5, 6, 4, 2, 116, 123, 5, 8, 5, 
+	2, 117, 123, 5, 10, 6, 2, 118, 123, 5, 12, 7, 2, 119, 123, 5, 14, 8, 2, 
+	120, 123, 5, 16, 9, 2, 121, 123, 5, 24, 13, 2, 122, 115, 3, 2, 2, 2, 122, 
+	116, 3, 2, 2, 2, 122, 117, 3, 2, 2, 2, 122, 118, 3, 2, 2, 2, 122, 119, 
+	3, 2, 2, 2, 122, 120, 3, 2, 2, 2, 122, 121, 3, 2, 2, 2, 122, 543, 3, 2, 
+	2, 2, 122, 544, 3, 2, 2, 2, 122, 545, 3, 2, 2, 2, 122, 557, 3, 2, 2, 2, 
+	122, 558, 3, 2, 2, 2, 123, 5, 3, 2, 2, 2, 124, 125, 7, 18, 2, 2, 125, 126, 
+	7, 20, 2, 2, 126, 7, 3, 2, 2, 2, 127, 128, 7, 18, 2, 2, 128, 133, 7, 22, 
+	2, 2, 129, 130, 7, 38, 2, 2, 130, 131, 7, 21, 2, 2, 131, 132, 7, 89, 2, 
+	2, 132, 134, 5, 18, 10, 2, 133, 129, 3, 2, 2, 2, 133, 134, 3, 2, 2, 2, 
+	134, 136, 3, 2, 2, 2, 135, 137, 5, 100, 51, 2, 136, 135, 3, 2, 2, 2, 136, 
+	137, 3, 2, 2, 2, 137, 9, 3, 2, 2, 2, 138, 139, 7, 18, 2, 2, 139, 142, 7, 
+	24, 2, 2, 140, 141, 7, 17, 2, 2, 141, 143, 5, 22, 12, 2, 142, 140, 3, 2, 
+	2, 2, 142, 143, 3, 2, 2, 2, 143, 148, 3, 2, 2, 2, 144, 145, 7, 38, 2, 2, 
+	145, 146, 7, 25, 2, 2, 146, 147, 7, 89, 2, 2, 147, 149, 5, 18, 10, 2, 148, 
+	144, 3, 2, 2, 2, 148, 149, 3, 2, 2, 2, 149, 151, 3, 2, 2, 2, 150, 152, 
+	5, 100, 51, 2, 151, 150, 3, 2, 2, 2, 151, 152, 3, 2, 2, 2, 152, 11, 3, 
+	2, 2, 2, 153, 154, 7, 18, 2, 2, 154, 157, 7, 29, 2, 2, 155, 156, 7, 17, 
+	2, 2, 156, 158, 5, 22, 12, 2, 157, 155, 3, 2, 2, 2, 157, 158, 3, 2, 2, 
+	2, 158, 159, 3, 2, 2, 2, 159, 160, 5, 34, 18, 2, 160, 13, 3, 2, 2, 2, 161, 
+	162, 7, 18, 2, 2, 162, 163, 7, 30, 2, 2, 163, 166, 7, 32, 2, 2, 164, 165, 
+	7, 17, 2, 2, 165, 167, 5, 22, 12, 2, 166, 164, 3, 2, 2, 2, 166, 167, 3, 
+	2, 2, 2, 167, 168, 3, 2, 2, 2, 168, 169, 5, 34, 18, 2, 169, 15, 3, 2, 2, 
+	2, 170, 171, 7, 18, 2, 2, 171, 172, 7, 30, 2, 2, 172, 175, 7, 35, 2, 2, 
+	173, 174, 7, 17, 2, 2, 174, 176, 5, 22, 12, 2, 175, 173, 3, 2, 2, 2, 175, 
+	176, 3, 2, 2, 2, 176, 177, 3, 2, 2, 2, 177, 178, 5, 34, 18, 2, 178, 179, 
+	7, 34, 2, 2, 179, 180, 7, 33, 2, 2, 180, 181, 7, 89, 2, 2, 181, 183, 5, 
+	20, 11, 2, 182, 184, 5, 36, 19, 2, 183, 182, 3, 2, 2, 2, 183, 184, 3, 2, 
+	2, 2, 184, 186, 3, 2, 2, 2, 185, 187, 5, 100, 51, 2, 186, 185, 3, 2, 2, 
+	2, 186, 187, 3, 2, 2, 2, 187, 17, 3, 2, 2, 2, 188, 189, 5, 108, 55, 2, 
+	189, 19, 3, 2, 2, 2, 190, 191, 5, 108, 55, 2, 191, 21, 3, 2, 2, 2, 192, 
+	193, 5, 108, 55, 2, 193, 23, 3, 2, 2, 2, 194, 196, 7, 42, 2, 2, 195, 194, 
+	3, 2, 2, 2, 195, 196, 3, 2, 2, 2, 196, 197, 3, 2, 2, 2, 197, 200, 5, 26, 
+	14, 2, 198, 199, 7, 17, 2, 2, 199, 201, 5, 22, 12, 2, 200, 198, 3, 2, 2, 
+	2, 200, 201, 3, 2, 2, 2, 201, 202, 3, 2, 2, 2, 202, 204, 5, 34, 18, 2, 
+	203, 205, 5, 36, 19, 2, 204, 203, 3, 2, 2, 2, 204, 205, 3, 2, 2, 2, 205, 
+	207, 3, 2, 2, 2, 206, 208, 5, 52, 27, 2, 207, 206, 3, 2, 2, 2, 207, 208, 
+	3, 2, 2, 2, 208, 210, 3, 2, 2, 2, 209, 211, 5, 60, 31, 2, 210, 209, 3, 
+	2, 2, 2, 210, 211, 3, 2, 2, 2, 211, 213, 3, 2, 2, 2, 212, 214, 5, 100, 
+	51, 2, 213, 212, 3, 2, 2, 2, 213, 214, 3, 2, 2, 2, 214, 216, 3, 2, 2, 2, 
+	215, 217, 7, 43, 2, 2, 216, 215, 3, 2, 2, 2, 216, 217, 3, 2, 2, 2, 217, 
+	25, 3, 2, 2, 2, 218, 219, 7, 44, 2, 2, 219, 220, 5, 28, 15, 2, 220, 27, 
+	3, 2, 2, 2, 221, 226, 5, 30, 16, 2, 222, 223, 7, 98, 2, 2, 223, 225, 5, 
+	30, 16, 2, 224, 222, 3, 2, 2, 2, 225, 228, 3, 2, 2, 2, 226, 224, 3, 2, 
+	2, 2, 226, 227, 3, 2, 2, 2, 227, 29, 3, 2, 2, 2, 228, 226, 3, 2, 2, 2, 
+	229, 231, 5, 78, 40, 2, 230, 232, 5, 32, 17, 2, 231, 230, 3, 2, 2, 2, 231, 
+	232, 3, 2, 2, 2, 232, 31, 3, 2, 2, 2, 233, 234, 7, 45, 2, 2, 234, 235, 
+	5, 108, 55, 2, 235, 33, 3, 2, 2, 2, 236, 237, 7, 37, 2, 2, 237, 238, 5, 
+	102, 52, 2, 238, 35, 3, 2, 2, 2, 239, 240, 7, 38, 2, 2, 240, 241, 5, 38, 
+	20, 2, 241, 37, 3, 2, 2, 2, 242, 253, 5, 40, 21, 2, 243, 244, 5, 40, 21, 
+	2, 244, 245, 7, 46, 2, 2, 245, 246, 5, 44, 23, 2, 246, 253, 3, 2, 2, 2, 
+	247, 250, 5, 44, 23, 2, 248, 249, 7, 46, 2, 2, 249, 251, 5, 40, 21, 2, 
+	250, 248, 3, 2, 2, 2, 250, 251, 3, 2, 2, 2, 251, 253, 3, 2, 2, 2, 252, 
+	242, 3, 2, 2, 2, 252, 243, 3, 2, 2, 2, 252, 247, 3, 2, 2, 2, 253, 39, 3, 
+	2, 2, 2, 254, 255, 8, 21, 1, 2, 255, 256, 7, 103, 2, 2, 256, 257, 5, 40, 
+	21, 2, 257, 258, 7, 104, 2, 2, 258, 283, 3, 2, 2, 2, 259, 268, 5, 104, 
+	53, 2, 260, 269, 7, 89, 2, 2, 261, 269, 7, 54, 2, 2, 262, 263, 7, 55, 2, 
+	2, 263, 269, 7, 54, 2, 2, 264, 269, 7, 96, 2, 2, 265, 269, 7, 97, 2, 2, 
+	266, 269, 7, 90, 2, 2, 267, 269, 7, 91, 2, 2, 268, 260, 3, 2, 2, 2, 268, 
+	261, 3, 2, 2, 2, 268, 262, 3, 2, 2, 2, 268, 264, 3, 2, 2, 2, 268, 265, 
+	3, 2, 2, 2, 268, 266, 3, 2, 2, 2, 268, 267, 3, 2, 2, 2, 269, 270, 3, 2, 
+	2, 2, 270, 271, 5, 106, 54, 2, 271, 283, 3, 2, 2, 2, 272, 276, 5, 104, 
+	53, 2, 273, 277, 7, 65, 2, 2, 274, 275, 7, 55, 2, 2, 275, 277, 7, 65, 2, 
+	2, 276, 273, 3, 2, 2, 2, 276, 274, 3, 2, 2, 2, 277, 278, 3, 2, 2, 2, 278, 
+	279, 7, 103, 2, 2, 279, 280, 5, 42, 22, 2, 280, 281, 7, 104, 2, 2, 281, 
+	283, 3, 2, 2, 2, 282, 254, 3, 2, 2, 2, 282, 259, 3, 2, 2, 2, 282, 272, 
+	3, 2, 2, 2, 283, 289, 3, 2, 2, 2, 284, 285, 12, 3, 2, 2, 285, 286, 9, 2, 
+	2, 2, 286, 288, 5, 40, 21, 4, 287, 284, 3, 2, 2, 2, 288, 291, 3, 2, 2, 
+	2, 289, 287, 3, 2, 2, 2, 289, 290, 3, 2, 2, 2, 290, 41, 3, 2, 2, 2, 291, 
+	289, 3, 2, 2, 2, 292, 297, 5, 106, 54, 2, 293, 294, 7, 98, 2, 2, 294, 296, 
+	5, 106, 54, 2, 295, 293, 3, 2, 2, 2, 296, 299, 3, 2, 2, 2, 297, 295, 3, 
+	2, 2, 2, 297, 298, 3, 2, 2, 2, 298, 43, 3, 2, 2, 2, 299, 297, 3, 2, 2, 
+	2, 300, 303, 5, 46, 24, 2, 301, 302, 7, 46, 2, 2, 302, 304, 5, 46, 24, 
+	2, 303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 45, 3, 2, 2, 2, 305, 
+	306, 7, 63, 2, 2, 306, 309, 5, 76, 39, 2, 307, 310, 5, 48, 25, 2, 308, 
+	310, 5, 108, 55, 2, 309, 307, 3, 2, 2, 2, 309, 308, 3, 2, 2, 2, 310, 47, 
+	3, 2, 2, 2, 311, 313, 5, 50, 26, 2, 312, 314, 5, 80, 41, 2, 313, 312, 3, 
+	2, 2, 2, 313, 314, 3, 2, 2, 2, 314, 49, 3, 2, 2, 2, 315, 316, 7, 64, 2, 
+	2, 316, 318, 7, 103, 2, 2, 317, 319, 5, 88, 45, 2, 318, 317, 3, 2, 2, 2, 
+	318, 319, 3, 2, 2, 2, 319, 320, 3, 2, 2, 2, 320, 321, 7, 104, 2, 2, 321, 
+	51, 3, 2, 2, 2, 322, 323, 7, 58, 2, 2, 323, 324, 7, 60, 2, 2, 324, 330, 
+	5, 54, 28, 2, 325, 326, 7, 48, 2, 2, 326, 327, 7, 103, 2, 2, 327, 328, 
+	5, 58, 30, 2, 328, 329, 7, 104, 2, 2, 329, 331, 3, 2, 2, 2, 330, 325, 3, 
+	2, 2, 2, 330, 331, 3, 2, 2, 2, 331, 333, 3, 2, 2, 2, 332, 334, 5, 66, 34, 
 	2, 333, 332, 3, 2, 2, 2, 333, 334, 3, 2, 2, 2, 334, 53, 3, 2, 2, 2, 335, 
-	340, 5, 56, 29, 2, 336, 337, 7, 90, 2, 2, 337, 339, 5, 56, 29, 2, 338, 
+	340, 5, 56, 29, 2, 336, 337, 7, 98, 2, 2, 337, 339, 5, 56, 29, 2, 338, 
 	336, 3, 2, 2, 2, 339, 342, 3, 2, 2, 2, 340, 338, 3, 2, 2, 2, 340, 341, 
 	3, 2, 2, 2, 341, 55, 3, 2, 2, 2, 342, 340, 3, 2, 2, 2, 343, 350, 5, 108, 
-	55, 2, 344, 345, 7, 60, 2, 2, 345, 346, 7, 95, 2, 2, 346, 347, 5, 80, 41, 
-	2, 347, 348, 7, 96, 2, 2, 348, 350, 3, 2, 2, 2, 349, 343, 3, 2, 2, 2, 349, 
-	344, 3, 2, 2, 2, 350, 57, 3, 2, 2, 2, 351, 352, 9, 3, 2, 2, 352, 59, 3, 
-	2, 2, 2, 353, 354, 7, 48, 2, 2, 354, 355, 7, 57, 2, 2, 355, 356, 5, 64, 
-	33, 2, 356, 61, 3, 2, 2, 2, 357, 361, 5, 78, 40, 2, 358, 360, 9, 4, 2, 
-	2, 359, 358, 3, 2, 2, 2, 360, 363, 3, 2, 2, 2, 361, 359, 3, 2, 2, 2, 361, 
-	362, 3, 2, 2, 2, 362, 63, 3, 2, 2, 2, 363, 361, 3, 2, 2, 2, 364, 369, 5, 
-	62, 32, 2, 365, 366, 7, 90, 2, 2, 366, 368, 5, 62, 32, 2, 367, 365, 3, 
-	2, 2, 2, 368, 371, 3, 2, 2, 2, 369, 367, 3, 2, 2, 2, 369, 370, 3, 2, 2, 
-	2, 370, 65, 3, 2, 2, 2, 371, 369, 3, 2, 2, 2, 372, 373, 7, 56, 2, 2, 373, 
-	374, 5, 68, 35, 2, 374, 67, 3, 2, 2, 2, 375, 376, 8, 35, 1, 2, 376, 377, 
-	7, 95, 2, 2, 377, 378, 5, 68, 35, 2, 378, 379, 7, 96, 2, 2, 379, 382, 3, 
-	2, 2, 2, 380, 382, 5, 72, 37, 2, 381, 375, 3, 2, 2, 2, 381, 380, 3, 2, 
-	2, 2, 382, 389, 3, 2, 2, 2, 383, 384, 12, 4, 2, 2, 384, 385, 5, 70, 36, 
-	2, 385, 386, 5, 68, 35, 5, 386, 388, 3, 2, 2, 2, 387, 383, 3, 2, 2, 2, 
-	388, 391, 3, 2, 2, 2, 389, 387, 3, 2, 2, 2, 389, 390, 3, 2, 2, 2, 390, 
-	69, 3, 2, 2, 2, 391, 389, 3, 2, 2, 2, 392, 393, 9, 2, 2, 2, 393, 71, 3, 
-	2, 2, 2, 394, 395, 5, 74, 38, 2, 395, 73, 3, 2, 2, 2, 396, 397, 5, 78, 
-	40, 2, 397, 398, 5, 76, 39, 2, 398, 399, 5, 78, 40, 2, 399, 75, 3, 2, 2, 
-	2, 400, 409, 7, 81, 2, 2, 401, 409, 7, 82, 2, 2, 402, 409, 7, 83, 2, 2, 
-	403, 409, 7, 86, 2, 2, 404, 409, 7, 87, 2, 2, 405, 409, 7, 84, 2, 2, 406, 
-	409, 7, 85, 2, 2, 407, 409, 9, 5, 2, 2, 408, 400, 3, 2, 2, 2, 408, 401, 
-	3, 2, 2, 2, 408, 402, 3, 2, 2, 2, 408, 403, 3, 2, 2, 2, 408, 404, 3, 2, 
-	2, 2, 408, 405, 3, 2, 2, 2, 408, 406, 3, 2, 2, 2, 408, 407, 3, 2, 2, 2, 
-	409, 77, 3, 2, 2, 2, 410, 411, 8, 40, 1, 2, 411, 412, 7, 95, 2, 2, 412, 
-	413, 5, 78, 40, 2, 413, 414, 7, 96, 2, 2, 414, 419, 3, 2, 2, 2, 415, 419, 
-	5, 84, 43, 2, 416, 419, 5, 92, 47, 2, 417, 419, 5, 80, 41, 2, 418, 410, 
-	3, 2, 2, 2, 418, 415, 3, 2, 2, 2, 418, 416, 3, 2, 2, 2, 418, 417, 3, 2, 
-	2, 2, 419, 434, 3, 2, 2, 2, 420, 421, 12, 10, 2, 2, 421, 422, 7, 100, 2, 
-	2, 422, 433, 5, 78, 40, 11, 423, 424, 12, 9, 2, 2, 424, 425, 7, 99, 2, 
-	2, 425, 433, 5, 78, 40, 10, 426, 427, 12, 8, 2, 2, 427, 428, 7, 97, 2, 
-	2, 428, 433, 5, 78, 40, 9, 429, 430, 12, 7, 2, 2, 430, 431, 7, 98, 2, 2, 
-	431, 433, 5, 78, 40, 8, 432, 420, 3, 2, 2, 2, 432, 423, 3, 2, 2, 2, 432, 
-	426, 3, 2, 2, 2, 432, 429, 3, 2, 2, 2, 433, 436, 3, 2, 2, 2, 434, 432, 
-	3, 2, 2, 2, 434, 435, 3, 2, 2, 2, 435, 79, 3, 2, 2, 2, 436, 434, 3, 2, 
-	2, 2, 437, 438, 5, 96, 49, 2, 438, 439, 5, 82, 42, 2, 439, 81, 3, 2, 2, 
-	2, 440, 441, 9, 6, 2, 2, 441, 83, 3, 2, 2, 2, 442, 443, 5, 86, 44, 2, 443, 
-	445, 7, 95, 2, 2, 444, 446, 5, 88, 45, 2, 445, 444, 3, 2, 2, 2, 445, 446, 
-	3, 2, 2, 2, 446, 447, 3, 2, 2, 2, 447, 448, 7, 96, 2, 2, 448, 85, 3, 2, 
-	2, 2, 449, 450, 9, 7, 2, 2, 450, 87, 3, 2, 2, 2, 451, 456, 5, 90, 46, 2, 
-	452, 453, 7, 90, 2, 2, 453, 455, 5, 90, 46, 2, 454, 452, 3, 2, 2, 2, 455, 
-	458, 3, 2, 2, 2, 456, 454, 3, 2, 2, 2, 456, 457, 3, 2, 2, 2, 457, 89, 3, 
-	2, 2, 2, 458, 456, 3, 2, 2, 2, 459, 462, 5, 78, 40, 2, 460, 462, 5, 40, 
-	21, 2, 461, 459, 3, 2, 2, 2, 461, 460, 3, 2, 2, 2, 462, 91, 3, 2, 2, 2, 
-	463, 465, 5, 108, 55, 2, 464, 466, 5, 94, 48, 2, 465, 464, 3, 2, 2, 2, 
-	465, 466, 3, 2, 2, 2, 466, 470, 3, 2, 2, 2, 467, 470, 5, 98, 50, 2, 468, 
-	470, 5, 96, 49, 2, 469, 463, 3, 2, 2, 2, 469, 467, 3, 2, 2, 2, 469, 468, 
-	3, 2, 2, 2, 470, 93, 3, 2, 2, 2, 471, 472, 7, 93, 2, 2, 472, 473, 5, 40, 
-	21, 2, 473, 474, 7, 94, 2, 2, 474, 95, 3, 2, 2, 2, 475, 477, 9, 8, 2, 2, 
-	476, 475, 3, 2, 2, 2, 476, 477, 3, 2, 2, 2, 477, 478, 3, 2, 2, 2, 478, 
-	479, 7, 103, 2, 2, 479, 97, 3, 2, 2, 2, 480, 482, 9, 8, 2, 2, 481, 480, 
-	3, 2, 2, 2, 481, 482, 3, 2, 2, 2, 482, 483, 3, 2, 2, 2, 483, 484, 7, 104, 
-	2, 2, 484, 99, 3, 2, 2, 2, 485, 486, 7, 36, 2, 2, 486, 487, 7, 103, 2, 
-	2, 487, 101, 3, 2, 2, 2, 488, 489, 5, 108, 55, 2, 489, 103, 3, 2, 2, 2, 
-	490, 491, 5, 108, 55, 2, 491, 105, 3, 2, 2, 2, 492, 493, 5, 108, 55, 2, 
-	493, 107, 3, 2, 2, 2, 494, 497, 7, 102, 2, 2, 495, 497, 5, 110, 56, 2, 
-	496, 494, 3, 2, 2, 2, 496, 495, 3, 2, 2, 2, 497, 505, 3, 2, 2, 2, 498, 
-	501, 7, 79, 2, 2, 499, 502, 7, 102, 2, 2, 500, 502, 5, 110, 56, 2, 501, 
-	499, 3, 2, 2, 2, 501, 500, 3, 2, 2, 2, 502, 504, 3, 2, 2, 2, 503, 498, 
-	3, 2, 2, 2, 504, 507, 3, 2, 2, 2, 505, 503, 3, 2, 2, 2, 505, 506, 3, 2, 
-	2, 2, 506, 109, 3, 2, 2, 2, 507, 505, 3, 2, 2, 2, 508, 509, 9, 9, 2, 2, 
-	509, 111, 3, 2, 2, 2, 55, 122, 133, 136, 142, 148, 151, 157, 166, 175, 
-	183, 186, 195, 200, 204, 207, 210, 213, 216, 226, 231, 250, 252, 268, 276, 
-	282, 289, 297, 303, 309, 313, 318, 330, 333, 340, 349, 361, 369, 381, 389, 
-	408, 418, 432, 434, 445, 456, 461, 465, 469, 476, 481, 496, 501, 505,
+	55, 2, 344, 345, 7, 63, 2, 2, 345, 346, 7, 103, 2, 2, 346, 347, 5, 80, 
+	41, 2, 347, 348, 7, 104, 2, 2, 348, 350, 3, 2, 2, 2, 349, 343, 3, 2, 2, 
+	2, 349, 344, 3, 2, 2, 2, 350, 57, 3, 2, 2, 2, 351, 352, 9, 3, 2, 2, 352, 
+	59, 3, 2, 2, 2, 353, 354, 7, 51, 2, 2, 354, 355, 7, 60, 2, 2, 355, 356, 
+	5, 64, 33, 2, 356, 61, 3, 2, 2, 2, 357, 361, 5, 78, 40, 2, 358, 360, 9, 
+	4, 2, 2, 359, 358, 3, 2, 2, 2, 360, 363, 3, 2, 2, 2, 361, 359, 3, 2, 2, 
+	2, 361, 362, 3, 2, 2, 2, 362, 63, 3, 2, 2, 2, 363, 361, 3, 2, 2, 2, 364, 
+	369, 5, 62, 32, 2, 365, 366, 7, 98, 2, 2, 366, 368, 5, 62, 32, 2, 367, 
+	365, 3, 2, 2, 2, 368, 371, 3, 2, 2, 2, 369, 367, 3, 2, 2, 2, 369, 370, 
+	3, 2, 2, 2, 370, 65, 3, 2, 2, 2, 371, 369, 3, 2, 2, 2, 372, 373, 7, 59, 
+	2, 2, 373, 374, 5, 68, 35, 2, 374, 67, 3, 2, 2, 2, 375, 376, 8, 35, 1, 
+	2, 376, 377, 7, 103, 2, 2, 377, 378, 5, 68, 35, 2, 378, 379, 7, 104, 2, 
+	2, 379, 382, 3, 2, 2, 2, 380, 382, 5, 72, 37, 2, 381, 375, 3, 2, 2, 2, 
+	381, 380, 3, 2, 2, 2, 382, 389, 3, 2, 2, 2, 383, 384, 12, 4, 2, 2, 384, 
+	385, 5, 70, 36, 2, 385, 386, 5, 68, 35, 5, 386, 388, 3, 2, 2, 2, 387, 383, 
+	3, 2, 2, 2, 388, 391, 3, 2, 2, 2, 389, 387, 3, 2, 2, 2, 389, 390, 3, 2, 
+	2, 2, 390, 69, 3, 2, 2, 2, 391, 389, 3, 2, 2, 2, 392, 393, 9, 2, 2, 2, 
+	393, 71, 3, 2, 2, 2, 394, 395, 5, 74, 38, 2, 395, 73, 3, 2, 2, 2, 396, 
+	397, 5, 78, 40, 2, 397, 398, 5, 76, 39, 2, 398, 399, 5, 78, 40, 2, 399, 
+	75, 3, 2, 2, 2, 400, 409, 7, 89, 2, 2, 401, 409, 7, 90, 2, 2, 402, 409, 
+	7, 91, 2, 2, 403, 409, 7, 94, 2, 2, 404, 409, 7, 95, 2, 2, 405, 409, 7, 
+	92, 2, 2, 406, 409, 7, 93, 2, 2, 407, 409, 9, 5, 2, 2, 408, 400, 3, 2, 
+	2, 2, 408, 401, 3, 2, 2, 2, 408, 402, 3, 2, 2, 2, 408, 403, 3, 2, 2, 2, 
+	408, 404, 3, 2, 2, 2, 408, 405, 3, 2, 2, 2, 408, 406, 3, 2, 2, 2, 408, 
+	407, 3, 2, 2, 2, 409, 77, 3, 2, 2, 2, 410, 411, 8, 40, 1, 2, 411, 412, 
+	7, 103, 2, 2, 412, 413, 5, 78, 40, 2, 413, 414, 7, 104, 2, 2, 414, 419, 
+	3, 2, 2, 2, 415, 419, 5, 84, 43, 2, 416, 419, 5, 92, 47, 2, 417, 419, 5, 
+	80, 41, 2, 418, 410, 3, 2, 2, 2, 418, 415, 3, 2, 2, 2, 418, 416, 3, 2, 
+	2, 2, 418, 417, 3, 2, 2, 2, 419, 434, 3, 2, 2, 2, 420, 421, 12, 10, 2, 
+	2, 421, 422, 7, 108, 2, 2, 422, 433, 5, 78, 40, 11, 423, 424, 12, 9, 2, 
+	2, 424, 425, 7, 107, 2, 2, 425, 433, 5, 78, 40, 10, 426, 427, 12, 8, 2, 
+	2, 427, 428, 7, 105, 2, 2, 428, 433, 5, 78, 40, 9, 429, 430, 12, 7, 2, 
+	2, 430, 431, 7, 106, 2, 2, 431, 433, 5, 78, 40, 8, 432, 420, 3, 2, 2, 2, 
+	432, 423, 3, 2, 2, 2, 432, 426, 3, 2, 2, 2, 432, 429, 3, 2, 2, 2, 433, 
+	436, 3, 2, 2, 2, 434, 432, 3, 2, 2, 2, 434, 435, 3, 2, 2, 2, 435, 79, 3, 
+	2, 2, 2, 436, 434, 3, 2, 2, 2, 437, 438, 5, 96, 49, 2, 438, 439, 5, 82, 
+	42, 2, 439, 81, 3, 2, 2, 2, 440, 441, 9, 6, 2, 2, 441, 83, 3, 2, 2, 2, 
+	442, 443, 5, 86, 44, 2, 443, 445, 7, 103, 2, 2, 444, 446, 5, 88, 45, 2, 
+	445, 444, 3, 2, 2, 2, 445, 446, 3, 2, 2, 2, 446, 447, 3, 2, 2, 2, 447, 
+	448, 7, 104, 2, 2, 448, 85, 3, 2, 2, 2, 449, 450, 9, 7, 2, 2, 450, 87, 
+	3, 2, 2, 2, 451, 456, 5, 90, 46, 2, 452, 453, 7, 98, 2, 2, 453, 455, 5, 
+	90, 46, 2, 454, 452, 3, 2, 2, 2, 455, 458, 3, 2, 2, 2, 456, 454, 3, 2, 
+	2, 2, 456, 457, 3, 2, 2, 2, 457, 89, 3, 2, 2, 2, 458, 456, 3, 2, 2, 2, 
+	459, 462, 5, 78, 40, 2, 460, 462, 5, 40, 21, 2, 461, 459, 3, 2, 2, 2, 461, 
+	460, 3, 2, 2, 2, 462, 91, 3, 2, 2, 2, 463, 465, 5, 108, 55, 2, 464, 466, 
+	5, 94, 48, 2, 465, 464, 3, 2, 2, 2, 465, 466, 3, 2, 2, 2, 466, 470, 3, 
+	2, 2, 2, 467, 470, 5, 98, 50, 2, 468, 470, 5, 96, 49, 2, 469, 463, 3, 2, 
+	2, 2, 469, 467, 3, 2, 2, 2, 469, 468, 3, 2, 2, 2, 470, 93, 3, 2, 2, 2, 
+	471, 472, 7, 101, 2, 2, 472, 473, 5, 40, 21, 2, 473, 474, 7, 102, 2, 2, 
+	474, 95, 3, 2, 2, 2, 475, 477, 9, 8, 2, 2, 476, 475, 3, 2, 2, 2, 476, 477, 
+	3, 2, 2, 2, 477, 478, 3, 2, 2, 2, 478, 479, 7, 111, 2, 2, 479, 97, 3, 2, 
+	2, 2, 480, 482, 9, 8, 2, 2, 481, 480, 3, 2, 2, 2, 481, 482, 3, 2, 2, 2, 
+	482, 483, 3, 2, 2, 2, 483, 484, 7, 112, 2, 2, 484, 99, 3, 2, 2, 2, 485, 
+	486, 7, 39, 2, 2, 486, 487, 7, 111, 2, 2, 487, 101, 3, 2, 2, 2, 488, 489, 
+	5, 108, 55, 2, 489, 103, 3, 2, 2, 2, 490, 491, 5, 108, 55, 2, 491, 105, 
+	3, 2, 2, 2, 492, 493, 5, 108, 55, 2, 493, 107, 3, 2, 2, 2, 494, 497, 7, 
+	110, 2, 2, 495, 497, 5, 110, 56, 2, 496, 494, 3, 2, 2, 2, 496, 495, 3, 
+	2, 2, 2, 497, 505, 3, 2, 2, 2, 498, 501, 7, 87, 2, 2, 499, 502, 7, 110, 
+	2, 2, 500, 502, 5, 110, 56, 2, 501, 499, 3, 2, 2, 2, 501, 500, 3, 2, 2, 
+	2, 502, 504, 3, 2, 2, 2, 503, 498, 3, 2, 2, 2, 504, 507, 3, 2, 2, 2, 505, 
+	503, 3, 2, 2, 2, 505, 506, 3, 2, 2, 2, 506, 109, 3, 2, 2, 2, 507, 505, 
+	3, 2, 2, 2, 508, 509, 9, 9, 2, 2, 509, 111, 3, 2, 2, 2, 511, 519, 3, 2, 
+	2, 2, 513, 523, 3, 2, 2, 2, 515, 531, 3, 2, 2, 2, 517, 541, 3, 2, 2, 2, 
+	519, 520, 7, 6, 2, 2, 520, 521, 7, 19, 2, 2, 521, 522, 5, 517, 60, 2, 522, 
+	512, 3, 2, 2, 2, 523, 524, 7, 6, 2, 2, 524, 527, 9, 10, 2, 2, 525, 526, 
+	7, 17, 2, 2, 526, 528, 5, 22, 12, 2, 527, 525, 3, 2, 2, 2, 527, 528, 3, 
+	2, 2, 2, 528, 529, 3, 2, 2, 2, 529, 530, 5, 102, 52, 2, 530, 514, 3, 2, 
+	2, 2, 531, 532, 7, 7, 2, 2, 532, 535, 7, 27, 2, 2, 533, 534, 7, 17, 2, 
+	2, 534, 536, 5, 22, 12, 2, 535, 533, 3, 2, 2, 2, 535, 536, 3, 2, 2, 2, 
+	536, 537, 3, 2, 2, 2, 537, 538, 5, 34, 18, 2, 538, 539, 7, 38, 2, 2, 539, 
+	540, 5, 40, 21, 2, 540, 516, 3, 2, 2, 2, 541, 542, 5, 108, 55, 2, 542, 
+	518, 3, 2, 2, 2, 543, 123, 5, 511, 57, 2, 544, 123, 5, 513, 58, 2, 545, 
+	123, 5, 515, 59, 2, 546, 550, 3, 2, 2, 2, 548, 553, 3, 2, 2, 2, 550, 551, 
+	7, 18, 2, 2, 551, 552, 7, 40, 2, 2, 552, 547, 3, 2, 2, 2, 553, 554, 7, 
+	16, 2, 2, 554, 555, 7, 41, 2, 2, 555, 556, 7, 111, 2, 2, 556, 549, 3, 2, 
+	2, 2, 557, 123, 5, 546, 61, 2, 558, 123, 5, 548, 62, 2, 57, 122, 133, 136, 
+	142, 148, 151, 157, 166, 175, 183, 186, 195, 200, 204, 207, 210, 213, 216, 
+	226, 231, 250, 252, 268, 276, 282, 289, 297, 303, 309, 313, 318, 330, 333, 
+	340, 349, 361, 369, 381, 389, 408, 418, 432, 434, 445, 456, 461, 465, 469, 
+	476, 481, 496, 501, 505, 527, 535,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -269,28 +291,29 @@ var literalNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
-	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "'m'", 
-	"", "", "", "'M'", "", "'.'", "':'", "'='", "'<>'", "'!='", "'>'", "'>='", 
-	"'<'", "'<='", "'=~'", "'!~'", "','", "'{'", "'}'", "'['", "']'", "'('", 
-	"')'", "'+'", "'-'", "'/'", "'*'", "'%'",
+	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
+	"", "", "", "", "", "", "", "'m'", "", "", "", "'M'", "", "'.'", "':'", 
+	"'='", "'<>'", "'!='", "'>'", "'>='", "'<'", "'<='", "'=~'", "'!~'", "','", 
+	"'{'", "'}'", "'['", "']'", "'('", "')'", "'+'", "'-'", "'/'", "'*'", "'%'",
 }
 var symbolicNames = []string{
-	"", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_INTERVAL", "T_INTERVAL_NAME", 
-	"T_SHARD", "T_REPLICATION", "T_TTL", "T_META_TTL", "T_PAST_TTL", "T_FUTURE_TTL", 
-	"T_KILL", "T_ON", "T_SHOW", "T_DATASBAE", "T_DATASBAES", "T_NAMESPACE", 
-	"T_NAMESPACES", "T_NODE", "T_MEASUREMENTS", "T_MEASUREMENT", "T_FIELD", 
-	"T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", "T_KEY", "T_WITH", "T_VALUES", 
-	"T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", "T_QUERIES", "T_QUERY", "T_EXPLAIN", 
-	"T_WITH_VALUE", "T_SELECT", "T_AS", "T_AND", "T_OR", "T_FILL", "T_NULL", 
-	"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", 
-	"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", 
-	"T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", 
-	"T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", 
-	"T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", 
-	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
-	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
-	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS",
+	"", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_DELETE", "T_INTERVAL", 
+	"T_INTERVAL_NAME", "T_SHARD", "T_REPLICATION", "T_TTL", "T_META_TTL", "T_PAST_TTL", 
+	"T_FUTURE_TTL", "T_KILL", "T_ON", "T_SHOW", "T_DATASBAE", "T_DATASBAES", 
+	"T_NAMESPACE", "T_NAMESPACES", "T_NODE", "T_MEASUREMENTS", "T_MEASUREMENT", 
+	"T_METRIC", "T_SERIES", "T_FIELD", "T_FIELDS", "T_TAG", "T_INFO", "T_KEYS", 
+	"T_KEY", "T_WITH", "T_VALUES", "T_VALUE", "T_FROM", "T_WHERE", "T_LIMIT", 
+	"T_QUERIES", "T_QUERY", "T_EXPLAIN", "T_WITH_VALUE", "T_SELECT", "T_AS", 
+	"T_AND", "T_OR", "T_FILL", "T_NULL", "T_PREVIOUS", "T_ORDER", "T_ASC", 
+	"T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", "T_IS", "T_GROUP", "T_HAVING", 
+	"T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", "T_IN", "T_LOG", "T_PROFILE", 
+	"T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", "T_STDDEV", "T_HISTOGRAM", 
+	"T_RATE", "T_IRATE", "T_DELTA", "T_DERIVATIVE", "T_QUANTILE", "T_SECOND", 
+	"T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", 
+	"T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", 
+	"T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", 
+	"T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", 
+	"T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC", "WS",
 }
 
 var ruleNames = []string{
@@ -304,7 +327,8 @@ var ruleNames = []string{
 	"binaryExpr", "binaryOperator", "fieldExpr", "durationLit", "intervalItem", 
 	"exprFunc", "funcName", "exprFuncParams", "funcParam", "exprAtom", "identFilter", 
 	"intNumber", "decNumber", "limitClause", "metricName", "tagKey", "tagValue", 
-	"ident", "nonReservedWords",
+	"ident", "nonReservedWords", "dropDatabaseStmt", "dropMetricStmt", "deleteSeriesStmt", 
+	"databaseName", "showQueriesStmt", "killQueryStmt",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -339,105 +363,113 @@ const (
 	SQLParserT_UPDATE = 2
 	SQLParserT_SET = 3
 	SQLParserT_DROP = 4
-	SQLParserT_INTERVAL = 5
-	SQLParserT_INTERVAL_NAME = 6
-	SQLParserT_SHARD = 7
-	SQLParserT_REPLICATION = 8
-	SQLParserT_TTL = 9
-	SQLParserT_META_TTL = 10
-	SQLParserT_PAST_TTL = 11
-	SQLParserT_FUTURE_TTL = 12
-	SQLParserT_KILL = 13
-	SQLParserT_ON = 14
-	SQLParserT_SHOW = 15
-	SQLParserT_DATASBAE = 16
-	SQLParserT_DATASBAES = 17
-	SQLParserT_NAMESPACE = 18
-	SQLParserT_NAMESPACES = 19
-	SQLParserT_NODE = 20
-	SQLParserT_MEASUREMENTS = 21
-	SQLParserT_MEASUREMENT = 22
-	SQLParserT_FIELD = 23
-	SQLParserT_FIELDS = 24
-	SQLParserT_TAG = 25
-	SQLParserT_INFO = 26
-	SQLParserT_KEYS = 27
-	SQLParserT_KEY = 28
-	SQLParserT_WITH = 29
-	SQLParserT_VALUES = 30
-	SQLParserT_VALUE = 31
-	SQLParserT_FROM = 32
-	SQLParserT_WHERE = 33
-	SQLParserT_LIMIT = 34
-	SQLParserT_QUERIES = 35
-	SQLParserT_QUERY = 36
-	SQLParserT_EXPLAIN = 37
-	SQLParserT_WITH_VALUE = 38
-	SQLParserT_SELECT = 39
-	SQLParserT_AS = 40
-	SQLParserT_AND = 41
-	SQLParserT_OR = 42
-	SQLParserT_FILL = 43
-	SQLParserT_NULL = 44
-	SQLParserT_PREVIOUS = 45
-	SQLParserT_ORDER = 46
-	SQLParserT_ASC = 47
-	SQLParserT_DESC = 48
-	SQLParserT_LIKE = 49
-	SQLParserT_NOT = 50
-	SQLParserT_BETWEEN = 51
-	SQLParserT_IS = 52
-	SQLParserT_GROUP = 53
-	SQLParserT_HAVING = 54
-	SQLParserT_BY = 55
-	SQLParserT_FOR = 56
-	SQLParserT_STATS = 57
-	SQLParserT_TIME = 58
-	SQLParserT_NOW = 59
-	SQLParserT_IN = 60
-	SQLParserT_LOG = 61
-	SQLParserT_PROFILE = 62
-	SQLParserT_SUM = 63
-	SQLParserT_MIN = 64
-	SQLParserT_MAX = 65
-	SQLParserT_COUNT = 66
-	SQLParserT_AVG = 67
-	SQLParserT_STDDEV = 68
-	SQLParserT_HISTOGRAM = 69
-	SQLParserT_SECOND = 70
-	SQLParserT_MINUTE = 71
-	SQLParserT_HOUR = 72
-	SQLParserT_DAY = 73
-	SQLParserT_WEEK = 74
-	SQLParserT_MONTH = 75
-	SQLParserT_YEAR = 76
-	SQLParserT_DOT = 77
-	SQLParserT_COLON = 78
-	SQLParserT_EQUAL = 79
-	SQLParserT_NOTEQUAL = 80
-	SQLParserT_NOTEQUAL2 = 81
-	SQLParserT_GREATER = 82
-	SQLParserT_GREATEREQUAL = 83
-	SQLParserT_LESS = 84
-	SQLParserT_LESSEQUAL = 85
-	SQLParserT_REGEXP = 86
-	SQLParserT_NEQREGEXP = 87
-	SQLParserT_COMMA = 88
-	SQLParserT_OPEN_B = 89
-	SQLParserT_CLOSE_B = 90
-	SQLParserT_OPEN_SB = 91
-	SQLParserT_CLOSE_SB = 92
-	SQLParserT_OPEN_P = 93
-	SQLParserT_CLOSE_P = 94
-	SQLParserT_ADD = 95
-	SQLParserT_SUB = 96
-	SQLParserT_DIV = 97
-	SQLParserT_MUL = 98
-	SQLParserT_MOD = 99
-	SQLParserL_ID = 100
-	SQLParserL_INT = 101
-	SQLParserL_DEC = 102
-	SQLParserWS = 103
+	SQLParserT_DELETE = 5
+	SQLParserT_INTERVAL = 6
+	SQLParserT_INTERVAL_NAME = 7
+	SQLParserT_SHARD = 8
+	SQLParserT_REPLICATION = 9
+	SQLParserT_TTL = 10
+	SQLParserT_META_TTL = 11
+	SQLParserT_PAST_TTL = 12
+	SQLParserT_FUTURE_TTL = 13
+	SQLParserT_KILL = 14
+	SQLParserT_ON = 15
+	SQLParserT_SHOW = 16
+	SQLParserT_DATASBAE = 17
+	SQLParserT_DATASBAES = 18
+	SQLParserT_NAMESPACE = 19
+	SQLParserT_NAMESPACES = 20
+	SQLParserT_NODE = 21
+	SQLParserT_MEASUREMENTS = 22
+	SQLParserT_MEASUREMENT = 23
+	SQLParserT_METRIC = 24
+	SQLParserT_SERIES = 25
+	SQLParserT_FIELD = 26
+	SQLParserT_FIELDS = 27
+	SQLParserT_TAG = 28
+	SQLParserT_INFO = 29
+	SQLParserT_KEYS = 30
+	SQLParserT_KEY = 31
+	SQLParserT_WITH = 32
+	SQLParserT_VALUES = 33
+	SQLParserT_VALUE = 34
+	SQLParserT_FROM = 35
+	SQLParserT_WHERE = 36
+	SQLParserT_LIMIT = 37
+	SQLParserT_QUERIES = 38
+	SQLParserT_QUERY = 39
+	SQLParserT_EXPLAIN = 40
+	SQLParserT_WITH_VALUE = 41
+	SQLParserT_SELECT = 42
+	SQLParserT_AS = 43
+	SQLParserT_AND = 44
+	SQLParserT_OR = 45
+	SQLParserT_FILL = 46
+	SQLParserT_NULL = 47
+	SQLParserT_PREVIOUS = 48
+	SQLParserT_ORDER = 49
+	SQLParserT_ASC = 50
+	SQLParserT_DESC = 51
+	SQLParserT_LIKE = 52
+	SQLParserT_NOT = 53
+	SQLParserT_BETWEEN = 54
+	SQLParserT_IS = 55
+	SQLParserT_GROUP = 56
+	SQLParserT_HAVING = 57
+	SQLParserT_BY = 58
+	SQLParserT_FOR = 59
+	SQLParserT_STATS = 60
+	SQLParserT_TIME = 61
+	SQLParserT_NOW = 62
+	SQLParserT_IN = 63
+	SQLParserT_LOG = 64
+	SQLParserT_PROFILE = 65
+	SQLParserT_SUM = 66
+	SQLParserT_MIN = 67
+	SQLParserT_MAX = 68
+	SQLParserT_COUNT = 69
+	SQLParserT_AVG = 70
+	SQLParserT_STDDEV = 71
+	SQLParserT_HISTOGRAM = 72
+	SQLParserT_RATE = 73
+	SQLParserT_IRATE = 74
+	SQLParserT_DELTA = 75
+	SQLParserT_DERIVATIVE = 76
+	SQLParserT_QUANTILE = 77
+	SQLParserT_SECOND = 78
+	SQLParserT_MINUTE = 79
+	SQLParserT_HOUR = 80
+	SQLParserT_DAY = 81
+	SQLParserT_WEEK = 82
+	SQLParserT_MONTH = 83
+	SQLParserT_YEAR = 84
+	SQLParserT_DOT = 85
+	SQLParserT_COLON = 86
+	SQLParserT_EQUAL = 87
+	SQLParserT_NOTEQUAL = 88
+	SQLParserT_NOTEQUAL2 = 89
+	SQLParserT_GREATER = 90
+	SQLParserT_GREATEREQUAL = 91
+	SQLParserT_LESS = 92
+	SQLParserT_LESSEQUAL = 93
+	SQLParserT_REGEXP = 94
+	SQLParserT_NEQREGEXP = 95
+	SQLParserT_COMMA = 96
+	SQLParserT_OPEN_B = 97
+	SQLParserT_CLOSE_B = 98
+	SQLParserT_OPEN_SB = 99
+	SQLParserT_CLOSE_SB = 100
+	SQLParserT_OPEN_P = 101
+	SQLParserT_CLOSE_P = 102
+	SQLParserT_ADD = 103
+	SQLParserT_SUB = 104
+	SQLParserT_DIV = 105
+	SQLParserT_MUL = 106
+	SQLParserT_MOD = 107
+	SQLParserL_ID = 108
+	SQLParserL_INT = 109
+	SQLParserL_DEC = 110
+	SQLParserWS = 111
 )
 
 // SQLParser rules.
@@ -497,6 +529,12 @@ const (
 	SQLParserRULE_tagValue = 52
 	SQLParserRULE_ident = 53
 	SQLParserRULE_nonReservedWords = 54
+	SQLParserRULE_dropDatabaseStmt = 55
+	SQLParserRULE_dropMetricStmt = 56
+	SQLParserRULE_deleteSeriesStmt = 57
+	SQLParserRULE_databaseName = 58
+	SQLParserRULE_showQueriesStmt = 59
+	SQLParserRULE_killQueryStmt = 60
 )
 
 // IStatementContext is an interface to support dynamic dispatch.
@@ -719,6 +757,56 @@ func (s *StatementListContext) QueryStmt() IQueryStmtContext {
 	return t.(IQueryStmtContext)
 }
 
+func (s *StatementListContext) DropDatabaseStmt() IDropDatabaseStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDropDatabaseStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDropDatabaseStmtContext)
+}
+
+func (s *StatementListContext) DropMetricStmt() IDropMetricStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDropMetricStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDropMetricStmtContext)
+}
+
+func (s *StatementListContext) DeleteSeriesStmt() IDeleteSeriesStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDeleteSeriesStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDeleteSeriesStmtContext)
+}
+
+func (s *StatementListContext) ShowQueriesStmt() IShowQueriesStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IShowQueriesStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IShowQueriesStmtContext)
+}
+
+func (s *StatementListContext) KillQueryStmt() IKillQueryStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKillQueryStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKillQueryStmtContext)
+}
+
 func (s *StatementListContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -821,6 +909,46 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 			p.QueryStmt()
 		}
 
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(541)
+			p.DropDatabaseStmt()
+		}
+
+
+	case 9:
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(542)
+			p.DropMetricStmt()
+		}
+
+
+	case 10:
+		p.EnterOuterAlt(localctx, 10)
+		{
+			p.SetState(543)
+			p.DeleteSeriesStmt()
+		}
+
+
+	case 11:
+		p.EnterOuterAlt(localctx, 11)
+		{
+			p.SetState(555)
+			p.ShowQueriesStmt()
+		}
+
+
+	case 12:
+		p.EnterOuterAlt(localctx, 12)
+		{
+			p.SetState(556)
+			p.KillQueryStmt()
+		}
+
 	}
 
 
@@ -4140,7 +4268,7 @@ func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
+	case SQLParserT_CREATE, SQLParserT_DELETE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_METRIC, SQLParserT_SERIES, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_RATE, SQLParserT_IRATE, SQLParserT_DELTA, SQLParserT_DERIVATIVE, SQLParserT_QUANTILE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
 		{
 			p.SetState(306)
 			p.Ident()
@@ -4272,7 +4400,7 @@ func (p *SQLParser) NowExpr() (localctx INowExprContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if ((((_la - 95)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 95))) & ((1 << (SQLParserT_ADD - 95)) | (1 << (SQLParserT_SUB - 95)) | (1 << (SQLParserL_INT - 95)))) != 0) {
+	if ((((_la - 103)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 103))) & ((1 << (SQLParserT_ADD - 103)) | (1 << (SQLParserT_SUB - 103)) | (1 << (SQLParserL_INT - 103)))) != 0) {
 		{
 			p.SetState(310)
 			p.DurationLit()
@@ -4406,7 +4534,7 @@ func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_DELETE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_METRIC) | (1 << SQLParserT_SERIES) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_WITH - 32)) | (1 << (SQLParserT_VALUES - 32)) | (1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)))) != 0) || ((((_la - 65)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 65))) & ((1 << (SQLParserT_PROFILE - 65)) | (1 << (SQLParserT_SUM - 65)) | (1 << (SQLParserT_MIN - 65)) | (1 << (SQLParserT_MAX - 65)) | (1 << (SQLParserT_COUNT - 65)) | (1 << (SQLParserT_AVG - 65)) | (1 << (SQLParserT_STDDEV - 65)) | (1 << (SQLParserT_HISTOGRAM - 65)) | (1 << (SQLParserT_RATE - 65)) | (1 << (SQLParserT_IRATE - 65)) | (1 << (SQLParserT_DELTA - 65)) | (1 << (SQLParserT_DERIVATIVE - 65)) | (1 << (SQLParserT_QUANTILE - 65)) | (1 << (SQLParserT_SECOND - 65)) | (1 << (SQLParserT_MINUTE - 65)) | (1 << (SQLParserT_HOUR - 65)) | (1 << (SQLParserT_DAY - 65)) | (1 << (SQLParserT_WEEK - 65)) | (1 << (SQLParserT_MONTH - 65)) | (1 << (SQLParserT_YEAR - 65)))) != 0) || ((((_la - 101)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 101))) & ((1 << (SQLParserT_OPEN_P - 101)) | (1 << (SQLParserT_ADD - 101)) | (1 << (SQLParserT_SUB - 101)) | (1 << (SQLParserL_ID - 101)) | (1 << (SQLParserL_INT - 101)) | (1 << (SQLParserL_DEC - 101)))) != 0) {
 		{
 			p.SetState(315)
 			p.ExprFuncParams()
@@ -6866,7 +6994,7 @@ func (p *SQLParser) IntervalItem() (localctx IIntervalItemContext) {
 		p.SetState(438)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 70)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 70))) & ((1 << (SQLParserT_SECOND - 70)) | (1 << (SQLParserT_MINUTE - 70)) | (1 << (SQLParserT_HOUR - 70)) | (1 << (SQLParserT_DAY - 70)) | (1 << (SQLParserT_WEEK - 70)) | (1 << (SQLParserT_MONTH - 70)) | (1 << (SQLParserT_YEAR - 70)))) != 0)) {
+		if !(((((_la - 78)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 78))) & ((1 << (SQLParserT_SECOND - 78)) | (1 << (SQLParserT_MINUTE - 78)) | (1 << (SQLParserT_HOUR - 78)) | (1 << (SQLParserT_DAY - 78)) | (1 << (SQLParserT_WEEK - 78)) | (1 << (SQLParserT_MONTH - 78)) | (1 << (SQLParserT_YEAR - 78)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -7006,7 +7134,7 @@ func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_DELETE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_METRIC) | (1 << SQLParserT_SERIES) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_WITH - 32)) | (1 << (SQLParserT_VALUES - 32)) | (1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)))) != 0) || ((((_la - 65)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 65))) & ((1 << (SQLParserT_PROFILE - 65)) | (1 << (SQLParserT_SUM - 65)) | (1 << (SQLParserT_MIN - 65)) | (1 << (SQLParserT_MAX - 65)) | (1 << (SQLParserT_COUNT - 65)) | (1 << (SQLParserT_AVG - 65)) | (1 << (SQLParserT_STDDEV - 65)) | (1 << (SQLParserT_HISTOGRAM - 65)) | (1 << (SQLParserT_RATE - 65)) | (1 << (SQLParserT_IRATE - 65)) | (1 << (SQLParserT_DELTA - 65)) | (1 << (SQLParserT_DERIVATIVE - 65)) | (1 << (SQLParserT_QUANTILE - 65)) | (1 << (SQLParserT_SECOND - 65)) | (1 << (SQLParserT_MINUTE - 65)) | (1 << (SQLParserT_HOUR - 65)) | (1 << (SQLParserT_DAY - 65)) | (1 << (SQLParserT_WEEK - 65)) | (1 << (SQLParserT_MONTH - 65)) | (1 << (SQLParserT_YEAR - 65)))) != 0) || ((((_la - 101)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 101))) & ((1 << (SQLParserT_OPEN_P - 101)) | (1 << (SQLParserT_ADD - 101)) | (1 << (SQLParserT_SUB - 101)) | (1 << (SQLParserL_ID - 101)) | (1 << (SQLParserL_INT - 101)) | (1 << (SQLParserL_DEC - 101)))) != 0) {
 		{
 			p.SetState(442)
 			p.ExprFuncParams()
@@ -7090,6 +7218,26 @@ func (s *FuncNameContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *FuncNameContext) T_RATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_RATE, 0)
+}
+
+func (s *FuncNameContext) T_IRATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_IRATE, 0)
+}
+
+func (s *FuncNameContext) T_DELTA() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DELTA, 0)
+}
+
+func (s *FuncNameContext) T_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DERIVATIVE, 0)
+}
+
+func (s *FuncNameContext) T_QUANTILE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUANTILE, 0)
+}
+
 func (s *FuncNameContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -7141,7 +7289,7 @@ func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 		p.SetState(447)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 63)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 63))) & ((1 << (SQLParserT_SUM - 63)) | (1 << (SQLParserT_MIN - 63)) | (1 << (SQLParserT_MAX - 63)) | (1 << (SQLParserT_COUNT - 63)) | (1 << (SQLParserT_AVG - 63)) | (1 << (SQLParserT_STDDEV - 63)) | (1 << (SQLParserT_HISTOGRAM - 63)))) != 0)) {
+		if !(((((_la - 66)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 66))) & ((1 << (SQLParserT_SUM - 66)) | (1 << (SQLParserT_MIN - 66)) | (1 << (SQLParserT_MAX - 66)) | (1 << (SQLParserT_COUNT - 66)) | (1 << (SQLParserT_AVG - 66)) | (1 << (SQLParserT_STDDEV - 66)) | (1 << (SQLParserT_HISTOGRAM - 66)) | (1 << (SQLParserT_RATE - 66)) | (1 << (SQLParserT_IRATE - 66)) | (1 << (SQLParserT_DELTA - 66)) | (1 << (SQLParserT_DERIVATIVE - 66)) | (1 << (SQLParserT_QUANTILE - 66)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -8523,7 +8671,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+	case SQLParserT_CREATE, SQLParserT_DELETE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_METRIC, SQLParserT_SERIES, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_RATE, SQLParserT_IRATE, SQLParserT_DELTA, SQLParserT_DERIVATIVE, SQLParserT_QUANTILE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 		{
 			p.SetState(493)
 			p.NonReservedWords()
@@ -8555,7 +8703,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 				}
 
 
-			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+			case SQLParserT_CREATE, SQLParserT_DELETE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_METRIC, SQLParserT_SERIES, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_RATE, SQLParserT_IRATE, SQLParserT_DELTA, SQLParserT_DERIVATIVE, SQLParserT_QUANTILE, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 				{
 					p.SetState(498)
 					p.NonReservedWords()
@@ -8862,6 +9010,26 @@ func (s *NonReservedWordsContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *NonReservedWordsContext) T_RATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_RATE, 0)
+}
+
+func (s *NonReservedWordsContext) T_IRATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_IRATE, 0)
+}
+
+func (s *NonReservedWordsContext) T_DELTA() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DELTA, 0)
+}
+
+func (s *NonReservedWordsContext) T_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DERIVATIVE, 0)
+}
+
+func (s *NonReservedWordsContext) T_QUANTILE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUANTILE, 0)
+}
+
 func (s *NonReservedWordsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -8913,7 +9081,7 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 		p.SetState(506)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0)) {
+		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_DELETE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_METRIC) | (1 << SQLParserT_SERIES) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_WITH - 32)) | (1 << (SQLParserT_VALUES - 32)) | (1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)))) != 0) || ((((_la - 65)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 65))) & ((1 << (SQLParserT_PROFILE - 65)) | (1 << (SQLParserT_SUM - 65)) | (1 << (SQLParserT_MIN - 65)) | (1 << (SQLParserT_MAX - 65)) | (1 << (SQLParserT_COUNT - 65)) | (1 << (SQLParserT_AVG - 65)) | (1 << (SQLParserT_STDDEV - 65)) | (1 << (SQLParserT_HISTOGRAM - 65)) | (1 << (SQLParserT_RATE - 65)) | (1 << (SQLParserT_IRATE - 65)) | (1 << (SQLParserT_DELTA - 65)) | (1 << (SQLParserT_DERIVATIVE - 65)) | (1 << (SQLParserT_QUANTILE - 65)) | (1 << (SQLParserT_SECOND - 65)) | (1 << (SQLParserT_MINUTE - 65)) | (1 << (SQLParserT_HOUR - 65)) | (1 << (SQLParserT_DAY - 65)) | (1 << (SQLParserT_WEEK - 65)) | (1 << (SQLParserT_MONTH - 65)) | (1 << (SQLParserT_YEAR - 65)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -8927,6 +9095,787 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 }
 
 
+// IDropDatabaseStmtContext is an interface to support dynamic dispatch.
+type IDropDatabaseStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsDropDatabaseStmtContext differentiates from other interfaces.
+	IsDropDatabaseStmtContext()
+}
+
+type DropDatabaseStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDropDatabaseStmtContext() *DropDatabaseStmtContext {
+	var p = new(DropDatabaseStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_dropDatabaseStmt
+	return p
+}
+
+func (*DropDatabaseStmtContext) IsDropDatabaseStmtContext() {}
+
+func NewDropDatabaseStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DropDatabaseStmtContext {
+	var p = new(DropDatabaseStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_dropDatabaseStmt
+
+	return p
+}
+
+func (s *DropDatabaseStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DropDatabaseStmtContext) T_DROP() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DROP, 0)
+}
+
+func (s *DropDatabaseStmtContext) T_DATASBAE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DATASBAE, 0)
+}
+
+func (s *DropDatabaseStmtContext) DatabaseName() IDatabaseNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IDatabaseNameContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDatabaseNameContext)
+}
+
+func (s *DropDatabaseStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DropDatabaseStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *DropDatabaseStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterDropDatabaseStmt(s)
+	}
+}
+
+func (s *DropDatabaseStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitDropDatabaseStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) DropDatabaseStmt() (localctx IDropDatabaseStmtContext) {
+	localctx = NewDropDatabaseStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 509, SQLParserRULE_dropDatabaseStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(517)
+		p.Match(SQLParserT_DROP)
+	}
+	{
+		p.SetState(518)
+		p.Match(SQLParserT_DATASBAE)
+	}
+	{
+		p.SetState(519)
+		p.DatabaseName()
+	}
+
+
+
+	return localctx
+}
+
+
+// IDropMetricStmtContext is an interface to support dynamic dispatch.
+type IDropMetricStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsDropMetricStmtContext differentiates from other interfaces.
+	IsDropMetricStmtContext()
+}
+
+type DropMetricStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDropMetricStmtContext() *DropMetricStmtContext {
+	var p = new(DropMetricStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_dropMetricStmt
+	return p
+}
+
+func (*DropMetricStmtContext) IsDropMetricStmtContext() {}
+
+func NewDropMetricStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DropMetricStmtContext {
+	var p = new(DropMetricStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_dropMetricStmt
+
+	return p
+}
+
+func (s *DropMetricStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DropMetricStmtContext) T_DROP() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DROP, 0)
+}
+
+func (s *DropMetricStmtContext) T_MEASUREMENT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MEASUREMENT, 0)
+}
+
+func (s *DropMetricStmtContext) T_METRIC() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_METRIC, 0)
+}
+
+func (s *DropMetricStmtContext) MetricName() IMetricNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMetricNameContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMetricNameContext)
+}
+
+func (s *DropMetricStmtContext) T_ON() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ON, 0)
+}
+
+func (s *DropMetricStmtContext) Namespace() INamespaceContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*INamespaceContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamespaceContext)
+}
+
+func (s *DropMetricStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DropMetricStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *DropMetricStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterDropMetricStmt(s)
+	}
+}
+
+func (s *DropMetricStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitDropMetricStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) DropMetricStmt() (localctx IDropMetricStmtContext) {
+	localctx = NewDropMetricStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 511, SQLParserRULE_dropMetricStmt)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(521)
+		p.Match(SQLParserT_DROP)
+	}
+	{
+		p.SetState(522)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == SQLParserT_MEASUREMENT || _la == SQLParserT_METRIC) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+	p.SetState(525)
+	p.GetErrorHandler().Sync(p)
+
+
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 53, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(523)
+			p.Match(SQLParserT_ON)
+		}
+		{
+			p.SetState(524)
+			p.Namespace()
+		}
+
+
+	}
+	{
+		p.SetState(527)
+		p.MetricName()
+	}
+
+
+
+	return localctx
+}
+
+
+// IDeleteSeriesStmtContext is an interface to support dynamic dispatch.
+type IDeleteSeriesStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsDeleteSeriesStmtContext differentiates from other interfaces.
+	IsDeleteSeriesStmtContext()
+}
+
+type DeleteSeriesStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDeleteSeriesStmtContext() *DeleteSeriesStmtContext {
+	var p = new(DeleteSeriesStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_deleteSeriesStmt
+	return p
+}
+
+func (*DeleteSeriesStmtContext) IsDeleteSeriesStmtContext() {}
+
+func NewDeleteSeriesStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DeleteSeriesStmtContext {
+	var p = new(DeleteSeriesStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_deleteSeriesStmt
+
+	return p
+}
+
+func (s *DeleteSeriesStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DeleteSeriesStmtContext) T_DELETE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DELETE, 0)
+}
+
+func (s *DeleteSeriesStmtContext) T_SERIES() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SERIES, 0)
+}
+
+func (s *DeleteSeriesStmtContext) FromClause() IFromClauseContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFromClauseContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFromClauseContext)
+}
+
+func (s *DeleteSeriesStmtContext) T_WHERE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_WHERE, 0)
+}
+
+func (s *DeleteSeriesStmtContext) TagFilterExpr() ITagFilterExprContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITagFilterExprContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITagFilterExprContext)
+}
+
+func (s *DeleteSeriesStmtContext) T_ON() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ON, 0)
+}
+
+func (s *DeleteSeriesStmtContext) Namespace() INamespaceContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*INamespaceContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INamespaceContext)
+}
+
+func (s *DeleteSeriesStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DeleteSeriesStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *DeleteSeriesStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterDeleteSeriesStmt(s)
+	}
+}
+
+func (s *DeleteSeriesStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitDeleteSeriesStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) DeleteSeriesStmt() (localctx IDeleteSeriesStmtContext) {
+	localctx = NewDeleteSeriesStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 513, SQLParserRULE_deleteSeriesStmt)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(529)
+		p.Match(SQLParserT_DELETE)
+	}
+	{
+		p.SetState(530)
+		p.Match(SQLParserT_SERIES)
+	}
+	p.SetState(533)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	if _la == SQLParserT_ON {
+		{
+			p.SetState(531)
+			p.Match(SQLParserT_ON)
+		}
+		{
+			p.SetState(532)
+			p.Namespace()
+		}
+
+	}
+	{
+		p.SetState(535)
+		p.FromClause()
+	}
+	{
+		p.SetState(536)
+		p.Match(SQLParserT_WHERE)
+	}
+	{
+		p.SetState(537)
+		p.tagFilterExpr(0)
+	}
+
+
+
+	return localctx
+}
+
+
+// IDatabaseNameContext is an interface to support dynamic dispatch.
+type IDatabaseNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsDatabaseNameContext differentiates from other interfaces.
+	IsDatabaseNameContext()
+}
+
+type DatabaseNameContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDatabaseNameContext() *DatabaseNameContext {
+	var p = new(DatabaseNameContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_databaseName
+	return p
+}
+
+func (*DatabaseNameContext) IsDatabaseNameContext() {}
+
+func NewDatabaseNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DatabaseNameContext {
+	var p = new(DatabaseNameContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_databaseName
+
+	return p
+}
+
+func (s *DatabaseNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DatabaseNameContext) Ident() IIdentContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentContext)
+}
+
+func (s *DatabaseNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DatabaseNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *DatabaseNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterDatabaseName(s)
+	}
+}
+
+func (s *DatabaseNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitDatabaseName(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) DatabaseName() (localctx IDatabaseNameContext) {
+	localctx = NewDatabaseNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 515, SQLParserRULE_databaseName)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(539)
+		p.Ident()
+	}
+
+
+
+	return localctx
+}
+
+
+// IShowQueriesStmtContext is an interface to support dynamic dispatch.
+type IShowQueriesStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsShowQueriesStmtContext differentiates from other interfaces.
+	IsShowQueriesStmtContext()
+}
+
+type ShowQueriesStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyShowQueriesStmtContext() *ShowQueriesStmtContext {
+	var p = new(ShowQueriesStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_showQueriesStmt
+	return p
+}
+
+func (*ShowQueriesStmtContext) IsShowQueriesStmtContext() {}
+
+func NewShowQueriesStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowQueriesStmtContext {
+	var p = new(ShowQueriesStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_showQueriesStmt
+
+	return p
+}
+
+func (s *ShowQueriesStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ShowQueriesStmtContext) T_SHOW() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SHOW, 0)
+}
+
+func (s *ShowQueriesStmtContext) T_QUERIES() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUERIES, 0)
+}
+
+func (s *ShowQueriesStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ShowQueriesStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *ShowQueriesStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterShowQueriesStmt(s)
+	}
+}
+
+func (s *ShowQueriesStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitShowQueriesStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) ShowQueriesStmt() (localctx IShowQueriesStmtContext) {
+	localctx = NewShowQueriesStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 544, SQLParserRULE_showQueriesStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(548)
+		p.Match(SQLParserT_SHOW)
+	}
+	{
+		p.SetState(549)
+		p.Match(SQLParserT_QUERIES)
+	}
+
+
+
+	return localctx
+}
+
+
+// IKillQueryStmtContext is an interface to support dynamic dispatch.
+type IKillQueryStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsKillQueryStmtContext differentiates from other interfaces.
+	IsKillQueryStmtContext()
+}
+
+type KillQueryStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyKillQueryStmtContext() *KillQueryStmtContext {
+	var p = new(KillQueryStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_killQueryStmt
+	return p
+}
+
+func (*KillQueryStmtContext) IsKillQueryStmtContext() {}
+
+func NewKillQueryStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KillQueryStmtContext {
+	var p = new(KillQueryStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_killQueryStmt
+
+	return p
+}
+
+func (s *KillQueryStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *KillQueryStmtContext) T_KILL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_KILL, 0)
+}
+
+func (s *KillQueryStmtContext) T_QUERY() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUERY, 0)
+}
+
+func (s *KillQueryStmtContext) L_INT() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_INT, 0)
+}
+
+func (s *KillQueryStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *KillQueryStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *KillQueryStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterKillQueryStmt(s)
+	}
+}
+
+func (s *KillQueryStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitKillQueryStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) KillQueryStmt() (localctx IKillQueryStmtContext) {
+	localctx = NewKillQueryStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 546, SQLParserRULE_killQueryStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(551)
+		p.Match(SQLParserT_KILL)
+	}
+	{
+		p.SetState(552)
+		p.Match(SQLParserT_QUERY)
+	}
+	{
+		p.SetState(553)
+		p.Match(SQLParserL_INT)
+	}
+
+
+
+	return localctx
+}
+
+
 func (p *SQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
 	case 19:
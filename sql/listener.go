@@ -27,6 +27,10 @@ type listener struct {
 	stmt *queryStmtParse
 
 	metaStmt *metaStmtParser
+
+	deleteStmt *deleteStmtParser
+
+	queryManageStmt *queryManageStmtParser
 }
 
 // EnterQueryStmt is called when production queryStmt is entered.
@@ -64,6 +68,39 @@ func (l *listener) EnterShowTagValuesStmt(ctx *grammar.ShowTagValuesStmtContext)
 	l.metaStmt = newMetaStmtParser(stmt.TagValue)
 }
 
+// EnterDropDatabaseStmt is called when production dropDatabaseStmt is entered.
+func (l *listener) EnterDropDatabaseStmt(ctx *grammar.DropDatabaseStmtContext) {
+	l.deleteStmt = newDeleteStmtParser(dropDatabase)
+}
+
+// EnterDropMetricStmt is called when production dropMetricStmt is entered.
+func (l *listener) EnterDropMetricStmt(ctx *grammar.DropMetricStmtContext) {
+	l.deleteStmt = newDeleteStmtParser(dropMetric)
+}
+
+// EnterDeleteSeriesStmt is called when production deleteSeriesStmt is entered.
+func (l *listener) EnterDeleteSeriesStmt(ctx *grammar.DeleteSeriesStmtContext) {
+	l.deleteStmt = newDeleteStmtParser(deleteSeries)
+}
+
+// EnterDatabaseName is called when production databaseName is entered.
+func (l *listener) EnterDatabaseName(ctx *grammar.DatabaseNameContext) {
+	if l.deleteStmt != nil {
+		l.deleteStmt.visitDatabaseName(ctx)
+	}
+}
+
+// EnterShowQueriesStmt is called when production showQueriesStmt is entered.
+func (l *listener) EnterShowQueriesStmt(ctx *grammar.ShowQueriesStmtContext) {
+	l.queryManageStmt = newQueryManageStmtParser(false)
+}
+
+// EnterKillQueryStmt is called when production killQueryStmt is entered.
+func (l *listener) EnterKillQueryStmt(ctx *grammar.KillQueryStmtContext) {
+	l.queryManageStmt = newQueryManageStmtParser(true)
+	l.queryManageStmt.visitKillQuery(ctx)
+}
+
 // EnterNamespace is called when production namespace is entered.
 func (l *listener) EnterNamespace(ctx *grammar.NamespaceContext) {
 	switch {
@@ -71,6 +108,8 @@ func (l *listener) EnterNamespace(ctx *grammar.NamespaceContext) {
 		l.stmt.visitNamespace(ctx)
 	case l.metaStmt != nil:
 		l.metaStmt.visitNamespace(ctx)
+	case l.deleteStmt != nil:
+		l.deleteStmt.visitNamespace(ctx)
 	}
 }
 
@@ -95,6 +134,8 @@ func (l *listener) EnterMetricName(ctx *grammar.MetricNameContext) {
 		l.stmt.visitMetricName(ctx)
 	case l.metaStmt != nil:
 		l.metaStmt.visitMetricName(ctx)
+	case l.deleteStmt != nil:
+		l.deleteStmt.visitMetricName(ctx)
 	}
 }
 
@@ -171,6 +212,8 @@ func (l *listener) EnterTagFilterExpr(ctx *grammar.TagFilterExprContext) {
 		l.stmt.visitTagFilterExpr(ctx)
 	case l.metaStmt != nil:
 		l.metaStmt.visitTagFilterExpr(ctx)
+	case l.deleteStmt != nil:
+		l.deleteStmt.visitTagFilterExpr(ctx)
 	}
 }
 
@@ -181,6 +224,8 @@ func (l *listener) ExitTagFilterExpr(ctx *grammar.TagFilterExprContext) {
 		l.stmt.completeTagFilterExpr()
 	case l.metaStmt != nil:
 		l.metaStmt.completeTagFilterExpr()
+	case l.deleteStmt != nil:
+		l.deleteStmt.completeTagFilterExpr()
 	}
 }
 
@@ -191,6 +236,8 @@ func (l *listener) EnterTagValue(ctx *grammar.TagValueContext) {
 		l.stmt.visitTagValue(ctx)
 	case l.metaStmt != nil:
 		l.metaStmt.visitTagValue(ctx)
+	case l.deleteStmt != nil:
+		l.deleteStmt.visitTagValue(ctx)
 	}
 }
 
@@ -208,12 +255,72 @@ func (l *listener) EnterGroupByKey(ctx *grammar.GroupByKeyContext) {
 	}
 }
 
+// EnterFillOption is called when production fillOption is entered.
+func (l *listener) EnterFillOption(ctx *grammar.FillOptionContext) {
+	if l.stmt != nil {
+		l.stmt.visitFillOption(ctx)
+	}
+}
+
+// EnterHavingClause is called when production havingClause is entered.
+func (l *listener) EnterHavingClause(ctx *grammar.HavingClauseContext) {
+	if l.stmt != nil {
+		l.stmt.enterClause(havingClause)
+	}
+}
+
+// EnterBoolExpr is called when production boolExpr is entered.
+func (l *listener) EnterBoolExpr(ctx *grammar.BoolExprContext) {
+	if l.stmt != nil {
+		l.stmt.visitBoolExpr(ctx)
+	}
+}
+
+// ExitBoolExpr is called when production boolExpr is exited.
+func (l *listener) ExitBoolExpr(ctx *grammar.BoolExprContext) {
+	if l.stmt != nil {
+		l.stmt.completeBoolExpr(ctx)
+	}
+}
+
+// EnterBinaryExpr is called when production binaryExpr is entered.
+func (l *listener) EnterBinaryExpr(ctx *grammar.BinaryExprContext) {
+	if l.stmt != nil {
+		l.stmt.visitBinaryExpr(ctx)
+	}
+}
+
+// ExitBinaryExpr is called when production binaryExpr is exited.
+func (l *listener) ExitBinaryExpr(ctx *grammar.BinaryExprContext) {
+	if l.stmt != nil {
+		l.stmt.completeBinaryExpr()
+	}
+}
+
+// EnterOrderByClause is called when production orderByClause is entered.
+func (l *listener) EnterOrderByClause(ctx *grammar.OrderByClauseContext) {
+	if l.stmt != nil {
+		l.stmt.enterClause(orderByClause)
+	}
+}
+
+// ExitSortField is called when production sortField is exited.
+func (l *listener) ExitSortField(ctx *grammar.SortFieldContext) {
+	if l.stmt != nil {
+		l.stmt.completeSortField(ctx)
+	}
+}
+
 // statement returns query statement, if failure return error
 func (l *listener) statement() (stmt.Statement, error) {
 	if l.stmt != nil {
 		return l.stmt.build()
 	} else if l.metaStmt != nil {
 		return l.metaStmt.build()
+	} else if l.deleteStmt != nil {
+		return l.deleteStmt.build()
+	} else if l.queryManageStmt != nil {
+		return l.queryManageStmt.build()
 	}
 	return nil, nil
 }
@@ -0,0 +1,65 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/lindb/lindb/sql/grammar"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// queryManageStmtParser represents the running query manage statement parser(show queries/kill query)
+type queryManageStmtParser struct {
+	killQuery bool
+	jobID     int64
+
+	err error
+}
+
+// newQueryManageStmtParser creates a new query manage statement parser
+func newQueryManageStmtParser(killQuery bool) *queryManageStmtParser {
+	return &queryManageStmtParser{
+		killQuery: killQuery,
+	}
+}
+
+// build builds the show queries/kill query statement
+func (s *queryManageStmtParser) build() (stmt.Statement, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.killQuery {
+		return &stmt.KillQuery{JobID: s.jobID}, nil
+	}
+	return &stmt.ShowQueries{}, nil
+}
+
+// visitKillQuery visits when production kill query expression is entered
+func (s *queryManageStmtParser) visitKillQuery(ctx *grammar.KillQueryStmtContext) {
+	if ctx.L_INT() == nil {
+		return
+	}
+	jobID, err := strconv.ParseInt(ctx.L_INT().GetText(), 10, 64)
+	if err != nil {
+		s.err = fmt.Errorf("invalid job id '%s'", ctx.L_INT().GetText())
+		return
+	}
+	s.jobID = jobID
+}
@@ -0,0 +1,56 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestQueryManageStmt_ShowQueries(t *testing.T) {
+	q, err := Parse("show queries")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.ShowQueries{}, q)
+	q, err = Parse("SHOW QUERIES")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.ShowQueries{}, q)
+
+	_, err = Parse("show queries 1")
+	assert.Error(t, err)
+}
+
+func TestQueryManageStmt_KillQuery(t *testing.T) {
+	q, err := Parse("kill query 100")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.KillQuery{JobID: 100}, q)
+	q, err = Parse("KILL QUERY 12")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.KillQuery{JobID: 12}, q)
+
+	_, err = Parse("kill query")
+	assert.Error(t, err)
+	_, err = Parse("kill query abc")
+	assert.Error(t, err)
+	_, err = Parse("kill query 1 2")
+	assert.Error(t, err)
+	_, err = Parse("kill query 99999999999999999999")
+	assert.Error(t, err)
+}
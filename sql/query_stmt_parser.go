@@ -31,22 +31,35 @@ import (
 	"github.com/lindb/lindb/sql/stmt"
 )
 
+// exprClause represents the clause which the parsing field expression belongs to
+type exprClause int
+
+const (
+	selectClause exprClause = iota
+	havingClause
+	orderByClause
+)
+
 // queryStmtParse represents query statement parser using visitor
 type queryStmtParse struct {
 	baseStmtParser
 	explain bool
 
+	clause      exprClause
 	selectItems []stmt.Expr
 	fieldNames  map[string]struct{}
+	aliases     map[string]struct{}
 
 	startTime int64
 	endTime   int64
 
-	//orderByExpr stmt.Expr
-	//desc        bool
-	groupBy  []string
-	interval int64
-	fieldID  int
+	groupBy   []string
+	fill      stmt.FillPolicy
+	fillValue float64
+	having    stmt.Expr
+	orderBy   []stmt.Expr
+	interval  int64
+	fieldID   int
 }
 
 // newQueryStmtParse create a query statement parser
@@ -54,6 +67,7 @@ func newQueryStmtParse(explain bool) *queryStmtParse {
 	return &queryStmtParse{
 		explain:    explain,
 		fieldNames: make(map[string]struct{}),
+		aliases:    make(map[string]struct{}),
 		fieldID:    1,
 		baseStmtParser: baseStmtParser{
 			exprStack: collections.NewStack(),
@@ -101,6 +115,10 @@ func (q *queryStmtParse) build() (stmt.Statement, error) {
 
 	query.Interval = timeutil.Interval(q.interval)
 	query.GroupBy = q.groupBy
+	query.Fill = q.fill
+	query.FillValue = q.fillValue
+	query.Having = q.having
+	query.OrderBy = q.orderBy
 	query.Limit = q.limit
 	return query, nil
 }
@@ -124,6 +142,126 @@ func (q *queryStmtParse) resetExprStack() {
 	q.exprStack = collections.NewStack()
 }
 
+// enterClause marks the clause which the following field expressions belong to
+func (q *queryStmtParse) enterClause(clause exprClause) {
+	q.clause = clause
+	q.resetExprStack()
+}
+
+// visitFillOption visits when production fill option expression is entered
+func (q *queryStmtParse) visitFillOption(ctx *grammar.FillOptionContext) {
+	switch {
+	case ctx.T_NULL() != nil:
+		q.fill = stmt.FillNull
+	case ctx.T_PREVIOUS() != nil:
+		q.fill = stmt.FillPrevious
+	case ctx.L_INT() != nil || ctx.L_DEC() != nil:
+		val, err := strconv.ParseFloat(ctx.GetText(), 64)
+		if err != nil {
+			q.err = err
+			return
+		}
+		q.fill = stmt.FillValue
+		q.fillValue = val
+	}
+}
+
+// completeSortField completes a sort field of order by clause
+func (q *queryStmtParse) completeSortField(ctx *grammar.SortFieldContext) {
+	if len(q.orderBy) == 0 {
+		return
+	}
+	orderByExpr, ok := q.orderBy[len(q.orderBy)-1].(*stmt.OrderByExpr)
+	if ok {
+		orderByExpr.Desc = len(ctx.AllT_DESC()) > 0
+	}
+}
+
+// visitBoolExpr visits when production bool expression of having clause is entered
+func (q *queryStmtParse) visitBoolExpr(ctx *grammar.BoolExprContext) {
+	switch {
+	case ctx.T_OPEN_P() != nil:
+		q.exprStack.Push(&stmt.ParenExpr{})
+	case ctx.BoolExprLogicalOp() != nil:
+		logicalOp, ok := ctx.BoolExprLogicalOp().(*grammar.BoolExprLogicalOpContext)
+		if !ok {
+			return
+		}
+		if logicalOp.T_AND() != nil {
+			q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.AND})
+		} else {
+			q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.OR})
+		}
+	}
+}
+
+// completeBoolExpr completes a bool expression of having clause
+func (q *queryStmtParse) completeBoolExpr(ctx *grammar.BoolExprContext) {
+	if ctx.T_OPEN_P() == nil && ctx.BoolExprLogicalOp() == nil {
+		return
+	}
+	q.completeStackExpr()
+}
+
+// visitBinaryExpr visits when production compare expression of having clause is entered
+func (q *queryStmtParse) visitBinaryExpr(ctx *grammar.BinaryExprContext) {
+	binaryOpCtx, ok := ctx.BinaryOperator().(*grammar.BinaryOperatorContext)
+	if !ok {
+		return
+	}
+	operator := stmt.UNKNOWN
+	switch {
+	case binaryOpCtx.T_EQUAL() != nil:
+		operator = stmt.EQUAL
+	case binaryOpCtx.T_NOTEQUAL() != nil || binaryOpCtx.T_NOTEQUAL2() != nil:
+		operator = stmt.NOTEQUAL
+	case binaryOpCtx.T_LESS() != nil:
+		operator = stmt.LESS
+	case binaryOpCtx.T_LESSEQUAL() != nil:
+		operator = stmt.LESSEQUAL
+	case binaryOpCtx.T_GREATER() != nil:
+		operator = stmt.GREATER
+	case binaryOpCtx.T_GREATEREQUAL() != nil:
+		operator = stmt.GREATEREQUAL
+	}
+	if operator == stmt.UNKNOWN {
+		q.err = fmt.Errorf("not support operator:%s in having clause", binaryOpCtx.GetText())
+	}
+	q.exprStack.Push(&stmt.BinaryExpr{Operator: operator})
+}
+
+// completeBinaryExpr completes a compare expression of having clause
+func (q *queryStmtParse) completeBinaryExpr() {
+	q.completeStackExpr()
+}
+
+// completeStackExpr pops the expression from stack,
+// then sets it as parent's param or completes it for current clause
+func (q *queryStmtParse) completeStackExpr() {
+	cur := q.exprStack.Pop()
+	expr, ok := cur.(stmt.Expr)
+	if !ok {
+		return
+	}
+	if q.exprStack.Empty() {
+		q.completeExpr(expr)
+		return
+	}
+	q.setExprParam(expr)
+}
+
+// completeExpr completes a top level expression for current clause
+func (q *queryStmtParse) completeExpr(expr stmt.Expr) {
+	switch q.clause {
+	case havingClause:
+		q.having = expr
+	case orderByClause:
+		q.orderBy = append(q.orderBy, &stmt.OrderByExpr{Expr: expr})
+	default:
+		q.selectItems = append(q.selectItems, &stmt.SelectItem{Expr: expr})
+	}
+}
+
 // visitGroupByKey visits when production groupBy key expression is entered
 func (q *queryStmtParse) visitGroupByKey(ctx *grammar.GroupByKeyContext) {
 	switch {
@@ -242,9 +380,10 @@ func (q *queryStmtParse) visitAlias(ctx *grammar.AliasContext) {
 	if len(q.selectItems) == 0 {
 		return
 	}
-	selectItem, ok := (q.selectItems[0]).(*stmt.SelectItem)
+	selectItem, ok := (q.selectItems[len(q.selectItems)-1]).(*stmt.SelectItem)
 	if ok {
 		selectItem.Alias = strutil.GetStringValue(ctx.Ident().GetText())
+		q.aliases[selectItem.Alias] = struct{}{}
 	}
 }
 
@@ -272,6 +411,16 @@ func (q *queryStmtParse) visitFuncName(ctx *grammar.FuncNameContext) {
 		callExpr.FuncType = function.Stddev
 	case ctx.T_HISTOGRAM() != nil:
 		callExpr.FuncType = function.Histogram
+	case ctx.T_RATE() != nil:
+		callExpr.FuncType = function.Rate
+	case ctx.T_IRATE() != nil:
+		callExpr.FuncType = function.IRate
+	case ctx.T_DELTA() != nil:
+		callExpr.FuncType = function.Delta
+	case ctx.T_DERIVATIVE() != nil:
+		callExpr.FuncType = function.Derivative
+	case ctx.T_QUANTILE() != nil:
+		callExpr.FuncType = function.Quantile
 	}
 }
 
@@ -284,7 +433,7 @@ func (q *queryStmtParse) completeFuncExpr() {
 			q.setExprParam(expr)
 		}
 		if q.exprStack.Empty() {
-			q.selectItems = append(q.selectItems, &stmt.SelectItem{Expr: expr})
+			q.completeExpr(expr)
 		}
 	}
 }
@@ -295,10 +444,14 @@ func (q *queryStmtParse) visitExprAtom(ctx *grammar.ExprAtomContext) {
 	case ctx.Ident() != nil:
 		val := strutil.GetStringValue(ctx.Ident().GetText())
 		if q.exprStack.Empty() {
-			q.selectItems = append(q.selectItems, &stmt.SelectItem{Expr: &stmt.FieldExpr{Name: val}})
+			q.completeExpr(&stmt.FieldExpr{Name: val})
 		} else {
 			q.setExprParam(&stmt.FieldExpr{Name: val})
 		}
+		// having/order by clause can reference the alias of select item
+		if _, ok := q.aliases[val]; ok && q.clause != selectClause {
+			return
+		}
 		q.fieldNames[val] = struct{}{}
 	case ctx.DecNumber() != nil || ctx.IntNumber() != nil:
 		valStr := ""
@@ -339,7 +492,7 @@ func (q *queryStmtParse) completeFieldExpr(ctx *grammar.FieldExprContext) {
 			q.setExprParam(expr)
 		}
 		if q.exprStack.Empty() {
-			q.selectItems = append(q.selectItems, &stmt.SelectItem{Expr: expr})
+			q.completeExpr(expr)
 		}
 	}
 }
@@ -134,6 +134,52 @@ func TestSelectFuncItem(t *testing.T) {
 	}, *selectItem)
 }
 
+func TestQuantileFuncCall(t *testing.T) {
+	q, err := Parse("select quantile(0.99, f), histogram(0.5, f), Quantile (0.9,f) as p90 from memory where host='quantile('")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, 3, len(query.SelectItems))
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Quantile,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 0.99}, &stmt.FieldExpr{Name: "f"}}},
+	}, *(query.SelectItems[0]).(*stmt.SelectItem))
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Histogram,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 0.5}, &stmt.FieldExpr{Name: "f"}}},
+	}, *(query.SelectItems[1]).(*stmt.SelectItem))
+	assert.Equal(t, stmt.SelectItem{
+		Alias: "p90",
+		Expr: &stmt.CallExpr{FuncType: function.Quantile,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 0.9}, &stmt.FieldExpr{Name: "f"}}},
+	}, *(query.SelectItems[2]).(*stmt.SelectItem))
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "quantile("}, query.Condition)
+}
+
+func TestRateFuncCall(t *testing.T) {
+	q, err := Parse("select rate(f), IRate(f), delta (f), derivative(f), sum(f) from memory where host='rate('")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, 5, len(query.SelectItems))
+	funcTypes := []function.FuncType{function.Rate, function.IRate, function.Delta, function.Derivative, function.Sum}
+	for idx, funcType := range funcTypes {
+		assert.Equal(t, stmt.SelectItem{
+			Expr: &stmt.CallExpr{FuncType: funcType, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+		}, *(query.SelectItems[idx]).(*stmt.SelectItem))
+	}
+	assert.Equal(t, &stmt.EqualsExpr{Key: "host", Value: "rate("}, query.Condition)
+
+	// function names are non-reserved words
+	q, err = Parse("select rate, delta from quantile where irate='derivative'")
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, "quantile", query.MetricName)
+	assert.Equal(t, []stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "rate"}},
+		&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "delta"}},
+	}, query.SelectItems)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "irate", Value: "derivative"}, query.Condition)
+}
+
 func TestFieldExpression(t *testing.T) {
 	q, err := Parse("select f+100 from cpu")
 	query := q.(*stmt.Query)
@@ -379,6 +425,104 @@ func TestGroupBy(t *testing.T) {
 	assert.Equal(t, "/data", query.GroupBy[1])
 }
 
+func TestFill(t *testing.T) {
+	sql := "select f from cpu group by host"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, stmt.NoFill, q.(*stmt.Query).Fill)
+
+	sql = "select f from cpu group by host fill(null)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, stmt.FillNull, q.(*stmt.Query).Fill)
+
+	sql = "select f from cpu group by host fill(previous)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, stmt.FillPrevious, q.(*stmt.Query).Fill)
+
+	sql = "select f from cpu group by host,time(1m) fill(10)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, stmt.FillValue, query.Fill)
+	assert.Equal(t, 10.0, query.FillValue)
+
+	sql = "select f from cpu group by host fill(1.5)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.FillValue, query.Fill)
+	assert.Equal(t, 1.5, query.FillValue)
+}
+
+func TestHaving(t *testing.T) {
+	sql := "select avg(cpu) from cpu group by host having max(cpu) > 90"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"cpu"}, query.FieldNames)
+	assert.Len(t, query.SelectItems, 1)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "cpu"}}},
+		Operator: stmt.GREATER,
+		Right:    &stmt.NumberLiteral{Val: 90},
+	}, query.Having)
+
+	sql = "select avg(f) as a from cpu group by host having (a >= 1 and a<10) or max(load) != 0"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []string{"f", "load"}, query.FieldNames)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left: &stmt.ParenExpr{Expr: &stmt.BinaryExpr{
+			Left:     &stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "a"}, Operator: stmt.GREATEREQUAL, Right: &stmt.NumberLiteral{Val: 1}},
+			Operator: stmt.AND,
+			Right:    &stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "a"}, Operator: stmt.LESS, Right: &stmt.NumberLiteral{Val: 10}},
+		}},
+		Operator: stmt.OR,
+		Right: &stmt.BinaryExpr{
+			Left:     &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "load"}}},
+			Operator: stmt.NOTEQUAL,
+			Right:    &stmt.NumberLiteral{Val: 0},
+		},
+	}, query.Having)
+
+	sql = "select f from cpu group by host having f like 1"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+}
+
+func TestOrderBy(t *testing.T) {
+	sql := "select avg(cpu) as a,max(cpu) from cpu group by host fill(previous) " +
+		"having max(cpu) > 90 order by avg(load) desc, a limit 10"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"cpu", "load"}, query.FieldNames)
+	assert.Len(t, query.SelectItems, 2)
+	assert.Equal(t, "a", query.SelectItems[0].(*stmt.SelectItem).Alias)
+	assert.Equal(t, "", query.SelectItems[1].(*stmt.SelectItem).Alias)
+	assert.Equal(t, stmt.FillPrevious, query.Fill)
+	assert.NotNil(t, query.Having)
+	assert.True(t, query.HasOrderBy())
+	assert.Equal(t, []stmt.Expr{
+		&stmt.OrderByExpr{
+			Expr: &stmt.CallExpr{FuncType: function.Avg, Params: []stmt.Expr{&stmt.FieldExpr{Name: "load"}}},
+			Desc: true,
+		},
+		&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "a"}},
+	}, query.OrderBy)
+	assert.Equal(t, 10, query.Limit)
+
+	sql = "select f from cpu order by f asc"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Len(t, query.SelectItems, 1)
+	assert.Equal(t, []stmt.Expr{&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "f"}}}, query.OrderBy)
+}
+
 func TestEmptyCondition(t *testing.T) {
 	sql := "select f from cpu"
 	q, err := Parse(sql)
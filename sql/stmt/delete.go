@@ -0,0 +1,36 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stmt
+
+// DropDatabase represents drop database statement
+type DropDatabase struct {
+	Database string // database name
+}
+
+// DropMetric represents drop metric statement
+type DropMetric struct {
+	Namespace  string // namespace
+	MetricName string // metric name
+}
+
+// DeleteSeries represents delete series statement, deletes the series which match the tag filter condition
+type DeleteSeries struct {
+	Namespace  string // namespace
+	MetricName string // metric name
+	Condition  Expr   // tag filter condition expression
+}
@@ -125,11 +125,28 @@ type RegexExpr struct {
 	Regexp string `json:"regexp"`
 }
 
+// AbsentExpr represents a tag key absent expression, matches the series without the tag key
+type AbsentExpr struct {
+	Key string `json:"key"`
+}
+
 // NotExpr represents a not expression
 type NotExpr struct {
 	Expr Expr
 }
 
+// OrderByExpr represents a sort field of order by clause
+type OrderByExpr struct {
+	Expr Expr
+	Desc bool
+}
+
+// innerOrderByExpr represents inner wrapper of order by expr for json marshal
+type innerOrderByExpr struct {
+	exprData
+	Desc bool `json:"desc"`
+}
+
 // Rewrite rewrites the select item expr after parse
 func (e *SelectItem) Rewrite() string {
 	if len(e.Alias) == 0 {
@@ -172,6 +189,14 @@ func (e *NotExpr) Rewrite() string {
 	return fmt.Sprintf("not %s", e.Expr.Rewrite())
 }
 
+// Rewrite rewrites the order by expr after parse
+func (e *OrderByExpr) Rewrite() string {
+	if e.Desc {
+		return fmt.Sprintf("%s desc", e.Expr.Rewrite())
+	}
+	return fmt.Sprintf("%s asc", e.Expr.Rewrite())
+}
+
 // Rewrite rewrites the equals expr after parse
 func (e *EqualsExpr) Rewrite() string {
 	return fmt.Sprintf("%s=%s", e.Key, e.Value)
@@ -192,6 +217,11 @@ func (e *RegexExpr) Rewrite() string {
 	return fmt.Sprintf("%s=~%s", e.Key, e.Regexp)
 }
 
+// Rewrite rewrites the absent expr after parse
+func (e *AbsentExpr) Rewrite() string {
+	return fmt.Sprintf("%s absent", e.Key)
+}
+
 // Marshal returns json of expr using custom json marshal
 func Marshal(expr Expr) []byte {
 	switch e := expr.(type) {
@@ -203,6 +233,8 @@ func Marshal(expr Expr) []byte {
 		return encoding.JSONMarshal(&exprData{Type: "in", Expr: encoding.JSONMarshal(expr)})
 	case *EqualsExpr:
 		return encoding.JSONMarshal(&exprData{Type: "equals", Expr: encoding.JSONMarshal(expr)})
+	case *AbsentExpr:
+		return encoding.JSONMarshal(&exprData{Type: "absent", Expr: encoding.JSONMarshal(expr)})
 	case *NumberLiteral:
 		return encoding.JSONMarshal(&exprData{Type: "number", Expr: encoding.JSONMarshal(expr)})
 	case *FieldExpr:
@@ -220,6 +252,15 @@ func Marshal(expr Expr) []byte {
 			Alias: e.Alias,
 		}
 		return encoding.JSONMarshal(&inner)
+	case *OrderByExpr:
+		inner := innerOrderByExpr{
+			exprData: exprData{
+				Type: "orderBy",
+				Expr: Marshal(e.Expr),
+			},
+			Desc: e.Desc,
+		}
+		return encoding.JSONMarshal(&inner)
 	case *CallExpr:
 		inner := innerCallExpr{
 			Type:     "call",
@@ -258,6 +299,8 @@ func Unmarshal(value []byte) (Expr, error) {
 		return unmarshal(&exprData, &InExpr{})
 	case "equals":
 		return unmarshal(&exprData, &EqualsExpr{})
+	case "absent":
+		return unmarshal(&exprData, &AbsentExpr{})
 	case "number":
 		return unmarshal(&exprData, &NumberLiteral{})
 	case field:
@@ -272,6 +315,8 @@ func Unmarshal(value []byte) (Expr, error) {
 		return unmarshalBinary(value)
 	case "selectItem":
 		return unmarshalSelectItem(value)
+	case "orderBy":
+		return unmarshalOrderBy(value)
 	case "call":
 		return unmarshalCall(value)
 	case "not":
@@ -317,6 +362,20 @@ func unmarshalSelectItem(value []byte) (Expr, error) {
 	return &SelectItem{Alias: innerExpr.Alias, Expr: e}, nil
 }
 
+// unmarshalOrderBy parses value to order by expr
+func unmarshalOrderBy(value []byte) (Expr, error) {
+	innerExpr := innerOrderByExpr{}
+	err := encoding.JSONUnmarshal(value, &innerExpr)
+	if err != nil {
+		return nil, err
+	}
+	e, err := Unmarshal(innerExpr.Expr)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderByExpr{Expr: e, Desc: innerExpr.Desc}, nil
+}
+
 // unmarshalBinary parses value to binary expr
 func unmarshalBinary(value []byte) (Expr, error) {
 	innerExpr := innerBinaryExpr{}
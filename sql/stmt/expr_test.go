@@ -62,6 +62,12 @@ func TestExpr_Rewrite(t *testing.T) {
 	assert.Equal(t, "tagKey in ()", (&InExpr{Key: "tagKey"}).Rewrite())
 
 	assert.Equal(t, "tagKey=~Regexp", (&RegexExpr{Key: "tagKey", Regexp: "Regexp"}).Rewrite())
+
+	assert.Equal(t, "tagKey absent", (&AbsentExpr{Key: "tagKey"}).Rewrite())
+
+	assert.Equal(t, "sum(f) desc",
+		(&OrderByExpr{Expr: &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}, Desc: true}).Rewrite())
+	assert.Equal(t, "f asc", (&OrderByExpr{Expr: &FieldExpr{Name: "f"}}).Rewrite())
 }
 
 func TestTagFilter(t *testing.T) {
@@ -97,6 +103,10 @@ func TestExpr_Unmarshal_Fail(t *testing.T) {
 	assert.NotNil(t, err)
 	_, err = unmarshalSelectItem([]byte("{\"type\":\"selectItem\",\"expr\":[\"213\"]}"))
 	assert.NotNil(t, err)
+	_, err = unmarshalOrderBy([]byte("324"))
+	assert.NotNil(t, err)
+	_, err = unmarshalOrderBy([]byte("{\"type\":\"orderBy\",\"expr\":[\"213\"]}"))
+	assert.NotNil(t, err)
 	_, err = unmarshalBinary([]byte("123"))
 	assert.NotNil(t, err)
 	_, err = unmarshalBinary([]byte("{\"type\":\"binary\",\"left\":\"123\"}"))
@@ -114,6 +124,14 @@ func TestRegexExpr_Marshal(t *testing.T) {
 	assert.Equal(t, *expr, *e)
 }
 
+func TestAbsentExpr_Marshal(t *testing.T) {
+	expr := &AbsentExpr{Key: "tagKey"}
+	data := Marshal(expr)
+	exprData, _ := Unmarshal(data)
+	e := exprData.(*AbsentExpr)
+	assert.Equal(t, *expr, *e)
+}
+
 func TestLikeExpr_Marshal(t *testing.T) {
 	expr := &LikeExpr{Key: "tagKey", Value: "tagValue"}
 	data := Marshal(expr)
@@ -212,3 +230,15 @@ func TestBinaryExpr_Marshal(t *testing.T) {
 	e := exprData.(*BinaryExpr)
 	assert.Equal(t, *expr, *e)
 }
+
+func TestOrderByExpr_Marshal(t *testing.T) {
+	expr := &OrderByExpr{
+		Expr: &CallExpr{FuncType: function.Avg, Params: []Expr{&FieldExpr{Name: "f"}}},
+		Desc: true,
+	}
+	data := Marshal(expr)
+	exprData, err := Unmarshal(data)
+	assert.NoError(t, err)
+	e := exprData.(*OrderByExpr)
+	assert.Equal(t, *expr, *e)
+}
@@ -19,11 +19,26 @@ package stmt
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
+// FillPolicy represents the policy of filling the missing points of down sampling result
+type FillPolicy int
+
+const (
+	// NoFill keeps the missing points empty
+	NoFill FillPolicy = iota
+	// FillNull keeps the missing points as null(empty)
+	FillNull
+	// FillPrevious fills the missing points with the previous point's value
+	FillPrevious
+	// FillValue fills the missing points with a fixed value
+	FillValue
+)
+
 // Query represents search statement
 type Query struct {
 	Explain     bool     //  need explain query execute stat
@@ -36,8 +51,12 @@ type Query struct {
 	TimeRange timeutil.TimeRange // query time range
 	Interval  timeutil.Interval  // down sampling interval
 
-	GroupBy []string // group by tag keys
-	Limit   int      // num. of time series list for result
+	GroupBy   []string   // group by tag keys
+	Fill      FillPolicy // fill policy for missing points
+	FillValue float64    // fill value if fill policy is FillValue
+	Having    Expr       // filter condition of time series after group by
+	OrderBy   []Expr     // order by expressions for sorting time series
+	Limit     int        // num. of time series list for result
 }
 
 // HasGroupBy returns whether query has group by tag keys
@@ -45,6 +64,81 @@ func (q *Query) HasGroupBy() bool {
 	return len(q.GroupBy) > 0
 }
 
+// HasOrderBy returns whether query has order by expressions
+func (q *Query) HasOrderBy() bool {
+	return len(q.OrderBy) > 0
+}
+
+// HiddenItems returns the expressions which having/order by clause depends on but not in select list,
+// they need to be loaded and evaluated like select items, but not returned, sorted by expression name.
+func (q *Query) HiddenItems() []Expr {
+	selectNames := make(map[string]struct{})
+	for _, item := range q.SelectItems {
+		selectItem, ok := item.(*SelectItem)
+		if ok && len(selectItem.Alias) > 0 {
+			selectNames[selectItem.Alias] = struct{}{}
+		} else {
+			selectNames[item.Rewrite()] = struct{}{}
+		}
+	}
+	operands := collectHavingOperands(q.Having, nil)
+	for _, orderBy := range q.OrderBy {
+		if orderByExpr, ok := orderBy.(*OrderByExpr); ok {
+			operands = append(operands, orderByExpr.Expr)
+		}
+	}
+	hiddenItems := make(map[string]Expr)
+	for _, operand := range operands {
+		name := operand.Rewrite()
+		if _, ok := selectNames[name]; ok {
+			continue
+		}
+		hiddenItems[name] = operand
+	}
+	names := make([]string, 0, len(hiddenItems))
+	for name := range hiddenItems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]Expr, len(names))
+	for idx, name := range names {
+		result[idx] = hiddenItems[name]
+	}
+	return result
+}
+
+// AllSelectItems returns the select items with hidden items which need to be loaded and evaluated
+func (q *Query) AllSelectItems() []Expr {
+	hiddenItems := q.HiddenItems()
+	if len(hiddenItems) == 0 {
+		return q.SelectItems
+	}
+	selectItems := append([]Expr{}, q.SelectItems...)
+	for _, item := range hiddenItems {
+		selectItems = append(selectItems, &SelectItem{Expr: item})
+	}
+	return selectItems
+}
+
+// collectHavingOperands collects the operands of compare expressions in having clause
+func collectHavingOperands(expr Expr, operands []Expr) []Expr {
+	switch e := expr.(type) {
+	case *ParenExpr:
+		return collectHavingOperands(e.Expr, operands)
+	case *BinaryExpr:
+		if e.Operator == AND || e.Operator == OR {
+			operands = collectHavingOperands(e.Left, operands)
+			return collectHavingOperands(e.Right, operands)
+		}
+		for _, operand := range []Expr{e.Left, e.Right} {
+			if _, ok := operand.(*NumberLiteral); !ok {
+				operands = append(operands, operand)
+			}
+		}
+	}
+	return operands
+}
+
 // innerQuery represents a wrapper of query for json encoding
 type innerQuery struct {
 	Explain     bool              `json:"Explain,omitempty"`
@@ -57,8 +151,12 @@ type innerQuery struct {
 	TimeRange timeutil.TimeRange `json:"timeRange,omitempty"`
 	Interval  timeutil.Interval  `json:"interval,omitempty"`
 
-	GroupBy []string `json:"groupBy,omitempty"`
-	Limit   int      `json:"limit,omitempty"`
+	GroupBy   []string          `json:"groupBy,omitempty"`
+	Fill      FillPolicy        `json:"fill,omitempty"`
+	FillValue float64           `json:"fillValue,omitempty"`
+	Having    json.RawMessage   `json:"having,omitempty"`
+	OrderBy   []json.RawMessage `json:"orderBy,omitempty"`
+	Limit     int               `json:"limit,omitempty"`
 }
 
 // MarshalJSON returns json data of query
@@ -72,11 +170,17 @@ func (q *Query) MarshalJSON() ([]byte, error) {
 		TimeRange:  q.TimeRange,
 		Interval:   q.Interval,
 		GroupBy:    q.GroupBy,
+		Fill:       q.Fill,
+		FillValue:  q.FillValue,
+		Having:     Marshal(q.Having),
 		Limit:      q.Limit,
 	}
 	for _, item := range q.SelectItems {
 		inner.SelectItems = append(inner.SelectItems, Marshal(item))
 	}
+	for _, item := range q.OrderBy {
+		inner.OrderBy = append(inner.OrderBy, Marshal(item))
+	}
 	return encoding.JSONMarshal(&inner), nil
 }
 
@@ -101,6 +205,21 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 		}
 		selectItems = append(selectItems, selectItem)
 	}
+	if inner.Having != nil {
+		having, err := Unmarshal(inner.Having)
+		if err != nil {
+			return err
+		}
+		q.Having = having
+	}
+	var orderBy []Expr
+	for _, item := range inner.OrderBy {
+		orderByItem, err := Unmarshal(item)
+		if err != nil {
+			return err
+		}
+		orderBy = append(orderBy, orderByItem)
+	}
 	q.Explain = inner.Explain
 	q.MetricName = inner.MetricName
 	q.Namespace = inner.Namespace
@@ -109,6 +228,9 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 	q.TimeRange = inner.TimeRange
 	q.Interval = inner.Interval
 	q.GroupBy = inner.GroupBy
+	q.Fill = inner.Fill
+	q.FillValue = inner.FillValue
+	q.OrderBy = orderBy
 	q.Limit = inner.Limit
 	return nil
 }
@@ -66,7 +66,17 @@ func TestQuery_Marshal(t *testing.T) {
 		TimeRange: timeutil.TimeRange{Start: 10, End: 30},
 		Interval:  1000,
 		GroupBy:   []string{"a", "b", "c"},
-		Limit:     100,
+		Fill:      FillValue,
+		FillValue: 1.5,
+		Having: &BinaryExpr{
+			Left:     &CallExpr{FuncType: function.Max, Params: []Expr{&FieldExpr{Name: "a"}}},
+			Operator: GREATER,
+			Right:    &NumberLiteral{Val: 90},
+		},
+		OrderBy: []Expr{
+			&OrderByExpr{Expr: &CallExpr{FuncType: function.Avg, Params: []Expr{&FieldExpr{Name: "a"}}}, Desc: true},
+		},
+		Limit: 100,
 	}
 
 	data := encoding.JSONMarshal(&query)
@@ -75,6 +85,7 @@ func TestQuery_Marshal(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, query, query1)
 	assert.True(t, query.HasGroupBy())
+	assert.True(t, query.HasOrderBy())
 }
 
 func TestQuery_Marshal_Fail(t *testing.T) {
@@ -85,4 +96,40 @@ func TestQuery_Marshal_Fail(t *testing.T) {
 	assert.NotNil(t, err)
 	err = query.UnmarshalJSON([]byte("{\"selectItems\":[\"123\"]}"))
 	assert.NotNil(t, err)
+	err = query.UnmarshalJSON([]byte("{\"having\":\"123\"}"))
+	assert.NotNil(t, err)
+	err = query.UnmarshalJSON([]byte("{\"orderBy\":[\"123\"]}"))
+	assert.NotNil(t, err)
+}
+
+func TestQuery_HiddenItems(t *testing.T) {
+	maxF := &CallExpr{FuncType: function.Max, Params: []Expr{&FieldExpr{Name: "f"}}}
+	minF := &CallExpr{FuncType: function.Min, Params: []Expr{&FieldExpr{Name: "f"}}}
+	sumG := &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "g"}}}
+	query := &Query{
+		SelectItems: []Expr{
+			&SelectItem{Expr: &CallExpr{FuncType: function.Avg, Params: []Expr{&FieldExpr{Name: "f"}}}, Alias: "a"},
+			&SelectItem{Expr: maxF},
+		},
+		Having: &BinaryExpr{
+			Left:     &BinaryExpr{Left: maxF, Operator: GREATER, Right: &NumberLiteral{Val: 90}},
+			Operator: AND,
+			Right: &ParenExpr{Expr: &BinaryExpr{
+				Left: minF, Operator: GREATER, Right: &NumberLiteral{Val: 1}}},
+		},
+		OrderBy: []Expr{
+			&OrderByExpr{Expr: &FieldExpr{Name: "a"}, Desc: true},
+			&OrderByExpr{Expr: sumG},
+			&OrderByExpr{Expr: minF},
+		},
+	}
+	assert.Equal(t, []Expr{minF, sumG}, query.HiddenItems())
+	selectItems := query.AllSelectItems()
+	assert.Len(t, selectItems, 4)
+	assert.Equal(t, "min(f)", selectItems[2].Rewrite())
+	assert.Equal(t, "sum(g)", selectItems[3].Rewrite())
+
+	query = &Query{SelectItems: []Expr{&SelectItem{Expr: &FieldExpr{Name: "f"}}}}
+	assert.Empty(t, query.HiddenItems())
+	assert.Equal(t, query.SelectItems, query.AllSelectItems())
 }
@@ -0,0 +1,180 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/protocol"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/point"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/service"
+)
+
+// for testing
+var (
+	readAllFunc = ioutil.ReadAll
+)
+
+// protobufContentType is the content type of protobuf body which is sent by the backfill api of broker
+const protobufContentType = "application/x-protobuf"
+
+// backfillResult represents the result of backfill request
+type backfillResult struct {
+	Written int      `json:"written"`
+	Failed  int      `json:"failed,omitempty"` // number of invalid lines
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// BackfillAPI represents the api of backfilling historical data into the shard replica of current storage node
+type BackfillAPI struct {
+	storageService service.StorageService
+}
+
+// NewBackfillAPI creates the backfill api instance
+func NewBackfillAPI(storageService service.StorageService) *BackfillAPI {
+	return &BackfillAPI{
+		storageService: storageService,
+	}
+}
+
+// Backfill parses line protocol(default), json format(Content-Type: application/json) or protobuf
+// (Content-Type: application/x-protobuf), then writes all metrics into the replica of shard param on current
+// storage node by building sst files directly, bypasses the time window(behind/ahead), wal and replication,
+// the request body can be compressed by gzip.
+// NOTICE: only writes one replica of shard, the backfill api of broker routes metrics by the shard assignment
+// (num. of shard and routing epochs) of database and writes metrics into all replicas of shard by this api.
+func (b *BackfillAPI) Backfill(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	shardID, err := getBackfillShard(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	db, ok := b.storageService.GetDatabase(databaseName)
+	if !ok {
+		api.NotFound(w)
+		return
+	}
+	shard, ok := db.GetShard(shardID)
+	if !ok {
+		api.Error(w, fmt.Errorf("shard[%d] of database[%s] not exist in current node", shardID, databaseName))
+		return
+	}
+	metricList, result, err := parseBackfillMetrics(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	for _, metric := range metricList.Metrics {
+		if len(metric.Namespace) == 0 {
+			metric.Namespace = namespace
+		}
+		// set tags hash as broker does, storage side uses this hash for generating series id
+		metric.TagsHash = xxhash.Sum64String(tag.Concat(metric.Tags))
+	}
+	if len(metricList.Metrics) > 0 {
+		if err := shard.Backfill(metricList.Metrics); err != nil {
+			api.Error(w, fmt.Errorf("backfill shard[%d] error: %s", shardID, err))
+			return
+		}
+	}
+	result.Written = len(metricList.Metrics)
+	api.OK(w, result)
+}
+
+// getBackfillShard returns the shard id from request, shard param is required.
+func getBackfillShard(r *http.Request) (int32, error) {
+	shardParam, err := api.GetParamsFromRequest("shard", r, "", true)
+	if err != nil {
+		return -1, err
+	}
+	id, err := strconv.ParseInt(shardParam, 10, 32)
+	if err != nil || id < 0 {
+		return -1, fmt.Errorf("shard param is invalid: %s", shardParam)
+	}
+	return int32(id), nil
+}
+
+// parseBackfillMetrics reads the request body and parses the metrics,
+// returns the result with the parse errors of invalid lines.
+func parseBackfillMetrics(r *http.Request) (*pb.MetricList, *backfillResult, error) {
+	data, err := readBody(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, protobufContentType) {
+		metricList := &pb.MetricList{}
+		if err := metricList.Unmarshal(data); err != nil {
+			return nil, nil, err
+		}
+		return metricList, &backfillResult{}, nil
+	}
+	parse := protocol.LineParse
+	if strings.HasPrefix(contentType, "application/json") {
+		parse = protocol.JSONParse
+	}
+	metricList, err := parse(data)
+	result := &backfillResult{}
+	if err != nil {
+		parseErrors, ok := err.(point.ParseErrors)
+		if !ok || metricList == nil || len(metricList.Metrics) == 0 {
+			return nil, nil, err
+		}
+		result.Failed = len(parseErrors)
+		for _, parseErr := range parseErrors {
+			result.Errors = append(result.Errors, parseErr.Error())
+		}
+	}
+	return metricList, result, nil
+}
+
+// readBody reads the request body, decompresses the body if it is compressed by gzip
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Header.Get("Content-Encoding") != "gzip" {
+		return readAllFunc(r.Body)
+	}
+	reader, err := gzip.NewReader(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return readAllFunc(reader)
+}
@@ -0,0 +1,131 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestBackfillAPI_Backfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+		ctrl.Finish()
+	}()
+
+	storageService := service.NewMockStorageService(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	api := NewBackfillAPI(storageService)
+	lines := "cpu,host=1.1.1.1 load_SUM=1 1577000000000\ncpu,host=1.1.1.2 load_SUM=2 1577000000000"
+
+	// case 1: db param not exist
+	doBackfillRequest(t, api, "/backfill?shard=1", lines, nil, http.StatusInternalServerError)
+	// case 2: shard param not exist or invalid
+	doBackfillRequest(t, api, "/backfill?db=test", lines, nil, http.StatusInternalServerError)
+	doBackfillRequest(t, api, "/backfill?db=test&shard=a", lines, nil, http.StatusInternalServerError)
+	doBackfillRequest(t, api, "/backfill?db=test&shard=-1", lines, nil, http.StatusInternalServerError)
+	// case 3: db not exist
+	storageService.EXPECT().GetDatabase("test").Return(nil, false)
+	doBackfillRequest(t, api, "/backfill?db=test&shard=1", lines, nil, http.StatusNotFound)
+	storageService.EXPECT().GetDatabase("test").Return(db, true).AnyTimes()
+	// case 4: shard not exist in current node
+	db.EXPECT().GetShard(int32(2)).Return(nil, false)
+	doBackfillRequest(t, api, "/backfill?db=test&shard=2", lines, nil, http.StatusInternalServerError)
+	db.EXPECT().GetShard(int32(1)).Return(shard, true).AnyTimes()
+	// case 5: read body err
+	readAllFunc = func(r io.Reader) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	doBackfillRequest(t, api, "/backfill?db=test&shard=1", lines, nil, http.StatusInternalServerError)
+	readAllFunc = ioutil.ReadAll
+	// case 6: parse err
+	doBackfillRequest(t, api, "/backfill?db=test&shard=1", "cpu", nil, http.StatusInternalServerError)
+	doBackfillRequest(t, api, "/backfill?db=test&shard=1", "cpu",
+		map[string]string{"Content-Type": protobufContentType}, http.StatusInternalServerError)
+	// case 7: gzip body err
+	doBackfillRequest(t, api, "/backfill?db=test&shard=1", lines,
+		map[string]string{"Content-Encoding": "gzip"}, http.StatusInternalServerError)
+	// case 8: backfill err
+	shard.EXPECT().Backfill(gomock.Any()).Return(fmt.Errorf("err"))
+	doBackfillRequest(t, api, "/backfill?db=test&shard=1", lines, nil, http.StatusInternalServerError)
+	// case 9: backfill into given shard, with invalid line
+	shard.EXPECT().Backfill(gomock.Any()).DoAndReturn(func(metrics []*pb.Metric) error {
+		assert.Len(t, metrics, 2)
+		assert.Equal(t, "ns", metrics[0].Namespace)
+		assert.NotZero(t, metrics[0].TagsHash)
+		return nil
+	})
+	result := doBackfillRequest(t, api, "/backfill?db=test&ns=ns&shard=1", lines+"\ncpu",
+		nil, http.StatusOK)
+	assert.Equal(t, 2, result.Written)
+	assert.Equal(t, 1, result.Failed)
+	shard.EXPECT().Backfill(gomock.Any()).Return(nil).AnyTimes()
+	// case 10: protobuf which is sent by broker
+	data, _ := (&pb.MetricList{Metrics: []*pb.Metric{{
+		Name:      "cpu",
+		Timestamp: 1577000000000,
+		Tags:      map[string]string{"host": "1.1.1.1"},
+		Fields:    []*pb.Field{{Name: "load", Type: pb.FieldType_Sum, Value: 1}},
+	}}}).Marshal()
+	result = doBackfillRequest(t, api, "/backfill?db=test&shard=1", string(data),
+		map[string]string{"Content-Type": protobufContentType}, http.StatusOK)
+	assert.Equal(t, 1, result.Written)
+	// case 11: json with gzip
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, _ = gzipWriter.Write([]byte(`{"metrics":[{"name":"cpu","timestamp":1465839830100,` +
+		`"tags":{"host":"1.1.1.1"},"fields":[{"name":"load","type":"gauge","value":1}]}]}`))
+	_ = gzipWriter.Close()
+	result = doBackfillRequest(t, api, "/backfill?db=test&shard=1", buf.String(),
+		map[string]string{"Content-Encoding": "gzip", "Content-Type": "application/json"}, http.StatusOK)
+	assert.Equal(t, 1, result.Written)
+}
+
+func doBackfillRequest(t *testing.T, api *BackfillAPI, url, body string,
+	headers map[string]string, expectHTTPCode int,
+) *backfillResult {
+	req := httptest.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rr := httptest.NewRecorder()
+	api.Backfill(rr, req)
+	assert.Equal(t, expectHTTPCode, rr.Code)
+	result := &backfillResult{}
+	if rr.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), result))
+	}
+	return result
+}
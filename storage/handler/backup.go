@@ -0,0 +1,72 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/backup"
+)
+
+// backupNameLayout is the layout of default backup name
+const backupNameLayout = "20060102150405"
+
+// BackupAPI represents the api of backing up the database of storage node into backup target
+type BackupAPI struct {
+	node           string
+	storageService service.StorageService
+}
+
+// NewBackupAPI creates the backup api instance, node is the indicator of current storage node
+func NewBackupAPI(node string, storageService service.StorageService) *BackupAPI {
+	return &BackupAPI{
+		node:           node,
+		storageService: storageService,
+	}
+}
+
+// Backup backups the database of storage node into backup target(uri),
+// returns the prefix of backup in target, the backup name is current time if not set.
+func (ba *BackupAPI) Backup(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	target, err := api.GetParamsFromRequest("target", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	name, _ := api.GetParamsFromRequest("name", r,
+		timeutil.FormatTimestamp(timeutil.Now(), backupNameLayout), false)
+	if err := backup.ValidateName(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	prefix := tsdb.BackupPrefix(name, ba.node, databaseName)
+	if err := ba.storageService.BackupDatabase(databaseName, target, name, prefix); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, prefix)
+}
@@ -0,0 +1,78 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/service"
+)
+
+func TestBackupAPI_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	api := NewBackupAPI("127.0.0.1:2891", storageService)
+
+	// case 1: db param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/backup?target=/tmp/backup",
+		HandlerFunc:    api.Backup,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: target param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/backup?db=test",
+		HandlerFunc:    api.Backup,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: traversal backup name
+	for _, name := range []string{"..", "../../..", "a%2F..%2F..%2Fb", "%2Fetc"} {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodPut,
+			URL:            "/backup?db=test&target=/tmp/backup&name=" + name,
+			HandlerFunc:    api.Backup,
+			ExpectHTTPCode: 500,
+		})
+	}
+	// case 4: backup failure
+	storageService.EXPECT().BackupDatabase("test", "/tmp/backup", "b1", "b1/127.0.0.1_2891/test").
+		Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/backup?db=test&target=/tmp/backup&name=b1",
+		HandlerFunc:    api.Backup,
+		ExpectHTTPCode: 500,
+	})
+	// case 5: backup with default name
+	storageService.EXPECT().BackupDatabase("test", "/tmp/backup", gomock.Any(), gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/backup?db=test&target=/tmp/backup",
+		HandlerFunc:    api.Backup,
+		ExpectHTTPCode: 200,
+	})
+}
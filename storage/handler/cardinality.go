@@ -0,0 +1,73 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/service"
+)
+
+// CardinalityAPI represents the api of querying the cardinality of metric in current storage node
+type CardinalityAPI struct {
+	storageService service.StorageService
+}
+
+// NewCardinalityAPI creates the cardinality api instance
+func NewCardinalityAPI(storageService service.StorageService) *CardinalityAPI {
+	return &CardinalityAPI{
+		storageService: storageService,
+	}
+}
+
+// GetMetricCardinality returns the cardinality of metric, includes the number of series for each shard
+// and the number of tag values for each tag key
+func (c *CardinalityAPI) GetMetricCardinality(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, err := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	metricName, err := api.GetParamsFromRequest("metric", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	db, ok := c.storageService.GetDatabase(databaseName)
+	if !ok {
+		api.NotFound(w)
+		return
+	}
+	cardinality, err := db.GetCardinality(namespace, metricName)
+	if err == constants.ErrNotFound {
+		api.NotFound(w)
+		return
+	}
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, cardinality)
+}
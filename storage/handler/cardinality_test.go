@@ -0,0 +1,95 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestCardinalityAPI_GetMetricCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	api := NewCardinalityAPI(storageService)
+
+	// case 1: db param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/cardinality?metric=cpu",
+		HandlerFunc:    api.GetMetricCardinality,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: metric param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/cardinality?db=test",
+		HandlerFunc:    api.GetMetricCardinality,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: db not exist
+	storageService.EXPECT().GetDatabase("test").Return(nil, false)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/cardinality?db=test&metric=cpu",
+		HandlerFunc:    api.GetMetricCardinality,
+		ExpectHTTPCode: 404,
+	})
+	// case 4: metric not exist
+	storageService.EXPECT().GetDatabase("test").Return(db, true).AnyTimes()
+	db.EXPECT().GetCardinality(constants.DefaultNamespace, "cpu").Return(nil, constants.ErrNotFound)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/cardinality?db=test&metric=cpu",
+		HandlerFunc:    api.GetMetricCardinality,
+		ExpectHTTPCode: 404,
+	})
+	// case 5: get cardinality err
+	db.EXPECT().GetCardinality("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/cardinality?db=test&ns=ns&metric=cpu",
+		HandlerFunc:    api.GetMetricCardinality,
+		ExpectHTTPCode: 500,
+	})
+	// case 6: get cardinality
+	cardinality := &tsdb.MetricCardinality{
+		Namespace: "ns",
+		Metric:    "cpu",
+		Series:    map[int32]uint32{1: 10},
+		TagValues: map[string]uint64{"host": 10},
+	}
+	db.EXPECT().GetCardinality("ns", "cpu").Return(cardinality, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/cardinality?db=test&ns=ns&metric=cpu",
+		HandlerFunc:    api.GetMetricCardinality,
+		ExpectHTTPCode: 200,
+		ExpectResponse: cardinality,
+	})
+}
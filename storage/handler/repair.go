@@ -0,0 +1,125 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// RepairAPI represents the api of anti-entropy repair between the replicas of shard,
+// the lagging replica gets the digest of shard from peer, then exports the missing series from peer.
+type RepairAPI struct {
+	storageService service.StorageService
+}
+
+// NewRepairAPI creates the repair api instance
+func NewRepairAPI(storageService service.StorageService) *RepairAPI {
+	return &RepairAPI{
+		storageService: storageService,
+	}
+}
+
+// GetDigest returns the digest of index and persisted data families of shard
+func (ra *RepairAPI) GetDigest(w http.ResponseWriter, r *http.Request) {
+	shard, ok, err := ra.getShard(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !ok {
+		api.NotFound(w)
+		return
+	}
+	digest, err := shard.Digest()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, digest)
+}
+
+// ExportSeries exports the data points of series under metric in persisted data family of shard,
+// the request body is the time slots(json) of series which exist in the lagging replica.
+func (ra *RepairAPI) ExportSeries(w http.ResponseWriter, r *http.Request) {
+	shard, ok, err := ra.getShard(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !ok {
+		api.NotFound(w)
+		return
+	}
+	familyTimeParam, err := api.GetParamsFromRequest("familyTime", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	familyTime, err := strconv.ParseInt(familyTimeParam, 10, 64)
+	if err != nil {
+		api.Error(w, fmt.Errorf("familyTime param is invalid: %s", familyTimeParam))
+		return
+	}
+	namespace, err := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	metricName, err := api.GetParamsFromRequest("metric", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	var excludes []tsdb.SeriesSlots
+	if err := api.GetJSONBodyFromRequest(r, &excludes); err != nil {
+		api.Error(w, err)
+		return
+	}
+	metrics, err := shard.ExportSeries(familyTime, namespace, metricName, excludes)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, &pb.MetricList{Metrics: metrics})
+}
+
+// getShard returns the shard by db/shard param, returns false if not exist
+func (ra *RepairAPI) getShard(r *http.Request) (tsdb.Shard, bool, error) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		return nil, false, err
+	}
+	shardParam, err := api.GetParamsFromRequest("shard", r, "", true)
+	if err != nil {
+		return nil, false, err
+	}
+	shardID, err := strconv.ParseInt(shardParam, 10, 32)
+	if err != nil {
+		return nil, false, fmt.Errorf("shard param is invalid: %s", shardParam)
+	}
+	shard, ok := ra.storageService.GetShard(databaseName, int32(shardID))
+	return shard, ok, nil
+}
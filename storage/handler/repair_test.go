@@ -0,0 +1,157 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/mock"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestRepairAPI_GetDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	api := NewRepairAPI(storageService)
+
+	// case 1: db param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/repair/digest?shard=1",
+		HandlerFunc:    api.GetDigest,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: shard param invalid
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/repair/digest?db=test&shard=a",
+		HandlerFunc:    api.GetDigest,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: shard not exist
+	storageService.EXPECT().GetShard("test", int32(1)).Return(nil, false)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/repair/digest?db=test&shard=1",
+		HandlerFunc:    api.GetDigest,
+		ExpectHTTPCode: 404,
+	})
+	// case 4: digest err
+	storageService.EXPECT().GetShard("test", int32(1)).Return(shard, true).AnyTimes()
+	shard.EXPECT().Digest().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/repair/digest?db=test&shard=1",
+		HandlerFunc:    api.GetDigest,
+		ExpectHTTPCode: 500,
+	})
+	// case 5: get digest
+	digest := &tsdb.ShardDigest{
+		Index: []tsdb.MetricDigest{{Namespace: "ns", MetricName: "cpu", Series: tsdb.SeriesDigest{Count: 1, Checksum: 10}}},
+	}
+	shard.EXPECT().Digest().Return(digest, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/repair/digest?db=test&shard=1",
+		HandlerFunc:    api.GetDigest,
+		ExpectHTTPCode: 200,
+		ExpectResponse: digest,
+	})
+}
+
+func TestRepairAPI_ExportSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	api := NewRepairAPI(storageService)
+
+	// case 1: shard not exist
+	storageService.EXPECT().GetShard("test", int32(1)).Return(nil, false)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&familyTime=10&metric=cpu",
+		RequestBody:    []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}},
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 404,
+	})
+	storageService.EXPECT().GetShard("test", int32(1)).Return(shard, true).AnyTimes()
+	// case 2: familyTime param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&metric=cpu",
+		RequestBody:    []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}},
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: familyTime param invalid
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&familyTime=a&metric=cpu",
+		RequestBody:    []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}},
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: metric param not exist
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&familyTime=10",
+		RequestBody:    []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}},
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 500,
+	})
+	// case 5: body invalid
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&familyTime=10&metric=cpu",
+		RequestBody:    "abc",
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 500,
+	})
+	// case 6: export err
+	shard.EXPECT().ExportSeries(int64(10), constants.DefaultNamespace, "cpu", []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}}).Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&familyTime=10&metric=cpu",
+		RequestBody:    []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}},
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 500,
+	})
+	// case 7: export series
+	metrics := []*pb.Metric{{Namespace: "ns", Name: "cpu", Timestamp: 10, TagsHash: 2}}
+	shard.EXPECT().ExportSeries(int64(10), "ns", "cpu", []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}}).Return(metrics, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/repair/series?db=test&shard=1&familyTime=10&ns=ns&metric=cpu",
+		RequestBody:    []tsdb.SeriesSlots{{TagsHash: 1, Slots: []uint16{1}}},
+		HandlerFunc:    api.ExportSeries,
+		ExpectHTTPCode: 200,
+		ExpectResponse: &pb.MetricList{Metrics: metrics},
+	})
+}
@@ -157,10 +157,7 @@ func (w *Writer) handleReplica(shard tsdb.Shard, replica *storage.Replica) {
 	}
 
 	//TODO write metric, need handle panic
-	for _, metric := range metricList.Metrics {
-		err = shard.Write(metric)
-	}
-	if err != nil {
+	if err := shard.WriteMetrics(metricList.Metrics); err != nil {
 		w.logger.Error("write metric", logger.Error(err))
 		return
 	}
@@ -194,6 +194,7 @@ func TestWriter_Write_Fail(t *testing.T) {
 
 	writeServer.EXPECT().Recv().Return(&storage.WriteRequest{Replicas: []*storage.Replica{{Seq: int64(10)}}}, nil)
 	s.EXPECT().GetHeadSeq().Return(int64(9)).MaxTimes(2)
+	shard.EXPECT().WriteMetrics(gomock.Any()).Return(nil)
 	s.EXPECT().SetHeadSeq(gomock.Any())
 	s.EXPECT().GetAckSeq().Return(int64(8))
 	writeServer.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
@@ -226,7 +227,7 @@ func TestWriter_handle_replica(t *testing.T) {
 	compressBuf = snappy.NewBufferedWriter(buf)
 	_, _ = compressBuf.Write(data)
 	_ = compressBuf.Flush()
-	shard.EXPECT().Write(gomock.Any()).Return(fmt.Errorf("err"))
+	shard.EXPECT().WriteMetrics(gomock.Any()).Return(fmt.Errorf("err"))
 	writer.handleReplica(shard, &storage.Replica{Seq: int64(10), Data: buf.Bytes()})
 }
 
@@ -19,6 +19,7 @@ package storage
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
@@ -42,6 +43,7 @@ import (
 	"github.com/lindb/lindb/pkg/server"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/pkg/tlsutil"
 	"github.com/lindb/lindb/query"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/common"
@@ -123,6 +125,11 @@ func (r *runtime) Run() error {
 		r.state = server.Failed
 		return fmt.Errorf("cannot get server ip address, error:%s", err)
 	}
+	// set tls config of grpc client connections(replication) before creating any connection
+	if err := rpc.SetClientTLS(r.config.StorageBase.GRPC.TLS); err != nil {
+		r.state = server.Failed
+		return fmt.Errorf("set grpc client tls config error:%s", err)
+	}
 
 	// build service dependency for storage server
 	if err := r.buildServiceDependency(); err != nil {
@@ -144,9 +151,15 @@ func (r *runtime) Run() error {
 	r.factory = factory{taskServer: rpc.NewTaskServerFactory()}
 
 	// start tcp server
-	r.startTCPServer()
+	if err := r.startTCPServer(); err != nil {
+		r.state = server.Failed
+		return err
+	}
 	// start http server
-	r.startHTTPServer()
+	if err := r.startHTTPServer(); err != nil {
+		r.state = server.Failed
+		return err
+	}
 
 	// start state repo
 	if err := r.startStateRepo(); err != nil {
@@ -161,7 +174,11 @@ func (r *runtime) Run() error {
 		return fmt.Errorf("register storage node error:%s", err)
 	}
 
-	r.taskExecutor = task.NewTaskExecutor(r.ctx, &r.node, r.repo, r.srv.storageService)
+	taskExecutor, err := task.NewTaskExecutor(r.ctx, &r.node, r.repo, r.srv.storageService, r.config.StorageBase.HTTP)
+	if err != nil {
+		return fmt.Errorf("create storage task executor error:%s", err)
+	}
+	r.taskExecutor = taskExecutor
 	r.taskExecutor.Run()
 
 	// start stat monitoring
@@ -246,21 +263,43 @@ func (r *runtime) buildServiceDependency() error {
 		return err
 	}
 	srv := srv{
-		storageService: service.NewStorageService(engine),
+		storageService: service.NewStorageService(engine, query.NewSeriesFinder(), r.config.StorageBase.TSDB.BackupDir),
 	}
 	r.srv = srv
 	return nil
 }
 
-// startHTTPServer starts http server for api rpcHandler
-func (r *runtime) startHTTPServer() {
+// startHTTPServer starts http server for api rpcHandler, serves https if tls enable
+func (r *runtime) startHTTPServer() error {
 	port := r.node.Port + 1
+	tlsCfg := r.config.StorageBase.HTTP.TLS
+	var tlsConfig *tls.Config
+	if tlsCfg.Enable {
+		reloader, err := tlsutil.NewCertReloader(tlsCfg)
+		if err != nil {
+			return fmt.Errorf("load http server tls certificate error:%s", err)
+		}
+		tlsConfig = reloader.ServerConfig()
+	}
 	r.log.Info("starting http server", logger.Uint16("port", port))
 
 	// add prometheus metric report
 	reporter := promreporter.NewReporter(promreporter.Options{})
 	router := mux.NewRouter().StrictSlash(true)
 	router.Handle("/metrics", reporter.HTTPHandler())
+	// add metric cardinality api
+	cardinalityAPI := handler.NewCardinalityAPI(r.srv.storageService)
+	router.HandleFunc("/cardinality", cardinalityAPI.GetMetricCardinality).Methods(http.MethodGet)
+	// add backfill api for writing historical data
+	backfillAPI := handler.NewBackfillAPI(r.srv.storageService)
+	router.HandleFunc("/backfill", backfillAPI.Backfill).Methods(http.MethodPut)
+	// add repair api for anti-entropy repair between the replicas of shard
+	repairAPI := handler.NewRepairAPI(r.srv.storageService)
+	router.HandleFunc("/repair/digest", repairAPI.GetDigest).Methods(http.MethodGet)
+	router.HandleFunc("/repair/series", repairAPI.ExportSeries).Methods(http.MethodPost)
+	// add backup api for backing up database of this node
+	backupAPI := handler.NewBackupAPI(r.node.Indicator(), r.srv.storageService)
+	router.HandleFunc("/backup", backupAPI.Backup).Methods(http.MethodPut)
 
 	r.httpServer = &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -268,18 +307,31 @@ func (r *runtime) startHTTPServer() {
 		ReadTimeout:  time.Second * 15,
 		IdleTimeout:  time.Second * 60,
 		Handler:      router,
+		TLSConfig:    tlsConfig,
 	}
 	go func() {
-		if err := r.httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		var err error
+		if tlsConfig != nil {
+			// certificates are loaded by tls config
+			err = r.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = r.httpServer.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
 			panic(fmt.Sprintf("start http server with error: %s", err))
 		}
 		r.log.Info("http server stopped successfully")
 	}()
+	return nil
 }
 
-// startTCPServer starts tcp server
-func (r *runtime) startTCPServer() {
-	r.server = rpc.NewGRPCServer(fmt.Sprintf(":%d", r.node.Port))
+// startTCPServer starts tcp server, enables tls if tls config enable
+func (r *runtime) startTCPServer() error {
+	opts, err := rpc.NewServerTLSOptions(r.config.StorageBase.GRPC.TLS)
+	if err != nil {
+		return fmt.Errorf("load grpc server tls certificate error:%s", err)
+	}
+	r.server = rpc.NewGRPCServer(fmt.Sprintf(":%d", r.node.Port), opts...)
 
 	// bind rpc handlers
 	r.bindRPCHandlers()
@@ -289,13 +341,14 @@ func (r *runtime) startTCPServer() {
 			panic(err)
 		}
 	}()
+	return nil
 }
 
 // bindRPCHandlers binds rpc handlers, registers handler into grpc server
 func (r *runtime) bindRPCHandlers() {
 	//FIXME: (stone1100) need close
 	dispatcher := taskHandler.NewLeafTaskDispatcher(r.node, r.srv.storageService,
-		query.NewExecutorFactory(), r.factory.taskServer)
+		query.NewExecutorFactory(r.config.StorageBase.Query), r.factory.taskServer)
 
 	r.handler = &rpcHandler{
 		writer: handler.NewWriter(r.srv.storageService),
@@ -146,3 +146,16 @@ func (ts *testStorageRuntimeSuite) TestStorageRun_Err(c *check.C) {
 	err = s.Stop()
 	assert.NoError(ts.t, err)
 }
+
+func (ts *testStorageRuntimeSuite) TestStorageRun_TLS_Err(c *check.C) {
+	fmt.Println("run TestStorageRun_TLS_Err...")
+	tlsCfg := cfg
+	tlsCfg.StorageBase.GRPC.Port = 8885
+	tlsCfg.StorageBase.GRPC.TLS = config.TLS{Enable: true, CertFile: "not_exist", KeyFile: "not_exist"}
+
+	storage := NewStorageRuntime("test-version", tlsCfg)
+	err := storage.Run()
+	assert.Error(ts.t, err)
+	c.Assert(server.Failed, check.Equals, storage.State())
+	_ = storage.Stop()
+}
@@ -0,0 +1,167 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/logger"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/tsdb/memdb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+// for testing
+var (
+	newMetricsDataFlusherFunc = metricsdata.NewFlusher
+)
+
+var (
+	backfillCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_backfill_metrics",
+			Help: "The number of metrics written by backfill.",
+		},
+		[]string{"db", "shard"},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(backfillCounter)
+}
+
+// backfillPoint represents the metric point with its family time and slot
+type backfillPoint struct {
+	familyTime int64
+	slot       uint16
+	metric     *pb.Metric
+}
+
+// Backfill writes the historical metrics into data families directly, the metrics are grouped by family time,
+// each group is written into a temporary memory database, then flushed into sst files of the target family,
+// the new files are attached to the version set of family atomically by edit log.
+// The memory databases of live writes are not touched, so backfill doesn't disturb live writes.
+func (s *shard) Backfill(metrics []*pb.Metric) error {
+	points := make([]backfillPoint, 0, len(metrics))
+	intervalCalc := s.interval.Calculator()
+	for _, metric := range metrics {
+		if err := checkMetric(metric); err != nil {
+			return err
+		}
+		timestamp := metric.Timestamp
+		segmentTime := intervalCalc.CalcSegmentTime(timestamp)
+		family := intervalCalc.CalcFamily(timestamp, segmentTime)
+		familyTime := intervalCalc.CalcFamilyStartTime(segmentTime, family)
+		points = append(points, backfillPoint{
+			familyTime: familyTime,
+			slot:       uint16(intervalCalc.CalcSlot(timestamp, familyTime, s.interval.Int64())),
+			metric:     metric,
+		})
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].familyTime < points[j].familyTime
+	})
+
+	s.backfillMutex.Lock()
+	defer s.backfillMutex.Unlock()
+
+	start := 0
+	for idx := range points {
+		if idx == len(points)-1 || points[idx+1].familyTime != points[start].familyTime {
+			if err := s.backfillFamily(points[start].familyTime, points[start:idx+1]); err != nil {
+				return err
+			}
+			start = idx + 1
+		}
+	}
+	s.backfillCounter.Add(float64(len(points)))
+	return nil
+}
+
+// backfillFamily builds the metric data of one family in a temporary memory database,
+// then flushes it into the target data family.
+func (s *shard) backfillFamily(familyTime int64, points []backfillPoint) (err error) {
+	memDB, err := s.createMemoryDatabase()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := memDB.Close(); closeErr != nil {
+			engineLogger.Error("close temporary memory database error when backfill",
+				logger.String("shard", s.path), logger.Error(closeErr))
+		}
+	}()
+	if err := s.writeBackfillPoints(memDB, points); err != nil {
+		return err
+	}
+	segmentName := s.interval.Calculator().GetSegment(familyTime)
+	segment, err := s.segment.GetOrCreateSegment(segmentName)
+	if err != nil {
+		return err
+	}
+	dataFamily, err := segment.GetDataFamily(familyTime)
+	if err != nil {
+		return err
+	}
+	// retain data family, make sure it cannot be evicted by data retention when flushing
+	if !dataFamily.Retain() {
+		return fmt.Errorf("data family[%d] is evicted", familyTime)
+	}
+	defer dataFamily.Release()
+	return memDB.FlushFamilyTo(newMetricsDataFlusherFunc(dataFamily.Family().NewFlusher()))
+}
+
+// writeBackfillPoints writes the points into temporary memory database
+func (s *shard) writeBackfillPoints(memDB memdb.MemoryDatabase, points []backfillPoint) error {
+	memDB.AcquireWrite()
+	defer memDB.CompleteWrite()
+
+	for _, point := range points {
+		metric := point.metric
+		ns, metricID, seriesID, err := s.genSeriesID(metric)
+		if err != nil {
+			return err
+		}
+		if err := memDB.Write(ns, metric.Name, metricID, seriesID, point.slot, metric.Fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMetric checks if the metric is valid for writing
+func checkMetric(metric *pb.Metric) error {
+	if metric == nil {
+		return constants.ErrNilMetric
+	}
+	if len(metric.Name) == 0 {
+		return constants.ErrEmptyMetricName
+	}
+	if len(metric.Fields) == 0 {
+		return constants.ErrEmptyField
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/tsdb/memdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+func TestShard_Backfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMemoryDBFunc = memdb.NewMemoryDatabase
+		newMetricsDataFlusherFunc = metricsdata.NewFlusher
+		ctrl.Finish()
+	}()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GenMetricID(constants.DefaultNamespace, "test").Return(uint32(10), nil).AnyTimes()
+	intervalSegment := NewMockIntervalSegment(ctrl)
+	segment := NewMockSegment(ctrl)
+	dataFamily := NewMockDataFamily(ctrl)
+	family := kv.NewMockFamily(ctrl)
+	dataFamily.EXPECT().Family().Return(family).AnyTimes()
+	family.EXPECT().NewFlusher().Return(kv.NewMockFlusher(ctrl)).AnyTimes()
+	flusher := metricsdata.NewMockFlusher(ctrl)
+	newMetricsDataFlusherFunc = func(kvFlusher kv.Flusher) metricsdata.Flusher {
+		return flusher
+	}
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	memDB.EXPECT().AcquireWrite().AnyTimes()
+	memDB.EXPECT().CompleteWrite().AnyTimes()
+	memDB.EXPECT().Close().Return(nil).AnyTimes()
+	newMemoryDBFunc = func(cfg memdb.MemoryDatabaseCfg) (memdb.MemoryDatabase, error) {
+		return memDB, nil
+	}
+
+	s := &shard{
+		databaseName:    "db",
+		path:            _testShard1Path,
+		metadata:        metadata,
+		segment:         intervalSegment,
+		backfillCounter: backfillCounter.WithLabelValues("db", "1"),
+	}
+	_ = s.interval.ValueOf("10s")
+	// history data out of time window
+	timestamp := timeutil.Now() - 3*timeutil.OneDay
+	newMetric := func(timestamp int64) *pb.Metric {
+		return &pb.Metric{
+			Name:      "test",
+			Timestamp: timestamp,
+			Fields:    []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: 1.0}},
+		}
+	}
+	metrics := []*pb.Metric{newMetric(timestamp + timeutil.OneHour), newMetric(timestamp), newMetric(timestamp + 10)}
+
+	// case 1: invalid metric
+	assert.Equal(t, constants.ErrNilMetric, s.Backfill([]*pb.Metric{nil}))
+	assert.Equal(t, constants.ErrEmptyMetricName, s.Backfill([]*pb.Metric{{Timestamp: timestamp}}))
+	assert.Equal(t, constants.ErrEmptyField, s.Backfill([]*pb.Metric{{Name: "test", Timestamp: timestamp}}))
+	// case 2: empty metrics
+	assert.NoError(t, s.Backfill(nil))
+	// case 3: create memory database err
+	newMemoryDBFunc = func(cfg memdb.MemoryDatabaseCfg) (memdb.MemoryDatabase, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, s.Backfill(metrics))
+	newMemoryDBFunc = func(cfg memdb.MemoryDatabaseCfg) (memdb.MemoryDatabase, error) {
+		return memDB, nil
+	}
+	// case 4: write memory database err
+	memDB.EXPECT().Write(constants.DefaultNamespace, "test", uint32(10), uint32(0),
+		gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Backfill(metrics))
+	// case 5: get segment err
+	memDB.EXPECT().Write(constants.DefaultNamespace, "test", uint32(10), uint32(0),
+		gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	intervalSegment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, s.Backfill(metrics))
+	// case 6: get data family err
+	intervalSegment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(segment, nil).AnyTimes()
+	segment.EXPECT().GetDataFamily(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, s.Backfill(metrics))
+	// case 7: data family evicted
+	segment.EXPECT().GetDataFamily(gomock.Any()).Return(dataFamily, nil)
+	dataFamily.EXPECT().Retain().Return(false)
+	assert.Error(t, s.Backfill(metrics))
+	// case 8: flush family err
+	dataFamily.EXPECT().Retain().Return(true).AnyTimes()
+	dataFamily.EXPECT().Release().AnyTimes()
+	segment.EXPECT().GetDataFamily(gomock.Any()).Return(dataFamily, nil)
+	memDB.EXPECT().FlushFamilyTo(flusher).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Backfill(metrics))
+	// case 9: backfill two families, sorted by family time
+	intervalCalc := s.interval.Calculator()
+	familyTime := func(timestamp int64) int64 {
+		segmentTime := intervalCalc.CalcSegmentTime(timestamp)
+		return intervalCalc.CalcFamilyStartTime(segmentTime, intervalCalc.CalcFamily(timestamp, segmentTime))
+	}
+	gomock.InOrder(
+		segment.EXPECT().GetDataFamily(familyTime(timestamp)).Return(dataFamily, nil),
+		segment.EXPECT().GetDataFamily(familyTime(timestamp+timeutil.OneHour)).Return(dataFamily, nil),
+	)
+	memDB.EXPECT().FlushFamilyTo(flusher).Return(nil).Times(2)
+	assert.NoError(t, s.Backfill(metrics))
+}
+
+func TestShard_WriteMetrics_backfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMemoryDBFunc = memdb.NewMemoryDatabase
+		newMetricsDataFlusherFunc = metricsdata.NewFlusher
+		ctrl.Finish()
+	}()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().GenMetricID(constants.DefaultNamespace, "test").Return(uint32(10), nil).AnyTimes()
+	intervalSegment := NewMockIntervalSegment(ctrl)
+	segment := NewMockSegment(ctrl)
+	dataFamily := NewMockDataFamily(ctrl)
+	family := kv.NewMockFamily(ctrl)
+	intervalSegment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(segment, nil).AnyTimes()
+	segment.EXPECT().GetDataFamily(gomock.Any()).Return(dataFamily, nil).AnyTimes()
+	dataFamily.EXPECT().Retain().Return(true).AnyTimes()
+	dataFamily.EXPECT().Release().AnyTimes()
+	dataFamily.EXPECT().Family().Return(family).AnyTimes()
+	family.EXPECT().NewFlusher().Return(kv.NewMockFlusher(ctrl)).AnyTimes()
+	flusher := metricsdata.NewMockFlusher(ctrl)
+	newMetricsDataFlusherFunc = func(kvFlusher kv.Flusher) metricsdata.Flusher {
+		return flusher
+	}
+	backfillMemDB := memdb.NewMockMemoryDatabase(ctrl)
+	backfillMemDB.EXPECT().AcquireWrite().AnyTimes()
+	backfillMemDB.EXPECT().CompleteWrite().AnyTimes()
+	backfillMemDB.EXPECT().Close().Return(nil).AnyTimes()
+	newMemoryDBFunc = func(cfg memdb.MemoryDatabaseCfg) (memdb.MemoryDatabase, error) {
+		return backfillMemDB, nil
+	}
+	liveMemDB := memdb.NewMockMemoryDatabase(ctrl)
+	liveMemDB.EXPECT().AcquireWrite().AnyTimes()
+	liveMemDB.EXPECT().CompleteWrite().AnyTimes()
+
+	s := &shard{
+		databaseName:     "db",
+		path:             _testShard1Path,
+		metadata:         metadata,
+		segment:          intervalSegment,
+		families:         make(map[int64]memdb.MemoryDatabase),
+		option:           option.DatabaseOption{OutOfWindow: option.OutOfWindowBackfill},
+		buildIndexTimer:  buildIndexTimer.WithLabelValues("db", "1"),
+		writeMetricTimer: writeMetricTimer.WithLabelValues("db", "1"),
+		backfillCounter:  backfillCounter.WithLabelValues("db", "1"),
+	}
+	_ = s.interval.ValueOf("10s")
+	_ = s.behind.ValueOf("1h")
+	intervalCalc := s.interval.Calculator()
+	familyTime := func(timestamp int64) int64 {
+		segmentTime := intervalCalc.CalcSegmentTime(timestamp)
+		return intervalCalc.CalcFamilyStartTime(segmentTime, intervalCalc.CalcFamily(timestamp, segmentTime))
+	}
+	now := timeutil.Now()
+	s.families[familyTime(now)] = liveMemDB
+	newMetric := func(timestamp int64) *pb.Metric {
+		return &pb.Metric{
+			Name:      "test",
+			Timestamp: timestamp,
+			Fields:    []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: 1.0}},
+		}
+	}
+	late := now - 3*timeutil.OneDay
+
+	// case 1: live metric into memory database, late metrics into data family by backfill in batch
+	liveMemDB.EXPECT().Write(constants.DefaultNamespace, "test", uint32(10), uint32(0),
+		gomock.Any(), gomock.Any()).Return(nil)
+	backfillMemDB.EXPECT().Write(constants.DefaultNamespace, "test", uint32(10), uint32(0),
+		gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	backfillMemDB.EXPECT().FlushFamilyTo(flusher).Return(nil)
+	assert.NoError(t, s.WriteMetrics([]*pb.Metric{newMetric(late), newMetric(now), newMetric(late + 10)}))
+	_, ok := s.families[familyTime(late)]
+	assert.False(t, ok)
+	// case 2: returns first error, still backfills late metrics
+	backfillMemDB.EXPECT().Write(constants.DefaultNamespace, "test", uint32(10), uint32(0),
+		gomock.Any(), gomock.Any()).Return(nil)
+	backfillMemDB.EXPECT().FlushFamilyTo(flusher).Return(nil)
+	assert.Equal(t, constants.ErrNilMetric, s.WriteMetrics([]*pb.Metric{nil, newMetric(late)}))
+	// case 3: backfill err
+	backfillMemDB.EXPECT().Write(constants.DefaultNamespace, "test", uint32(10), uint32(0),
+		gomock.Any(), gomock.Any()).Return(nil)
+	backfillMemDB.EXPECT().FlushFamilyTo(flusher).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Write(newMetric(late)))
+}
@@ -0,0 +1,253 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb/backup"
+)
+
+// for testing
+var (
+	copyFile         = fileutil.CopyFile
+	uploadBackupFunc = backup.Upload
+)
+
+const (
+	backupDir       = "backup"
+	restoringSuffix = ".restoring"
+)
+
+// Backup creates the consistent checkpoint of shard under path, includes data segments, index store,
+// index database(series id mapping) and tombstones, the replica sequence is excluded.
+// NOTICE: the data in memory database is not included, database flushes shards before backup.
+func (s *shard) Backup(path string) error {
+	// checkpoint data before index, make sure that the index of backup contains all series of data
+	for intervalType, segment := range s.segments {
+		if err := segment.checkpoint(filepath.Join(path, segmentDir, intervalType.String())); err != nil {
+			return err
+		}
+	}
+	if err := s.indexStore.Checkpoint(filepath.Join(path, indexParentDir)); err != nil {
+		return err
+	}
+	if err := s.indexDB.Backup(filepath.Join(path, metaDir)); err != nil {
+		return err
+	}
+	if err := s.metricTombstone.backup(filepath.Join(path, tombstoneDir, metricTombstone)); err != nil {
+		return err
+	}
+	return s.indexTombstone.backup(filepath.Join(path, tombstoneDir, indexTombstone))
+}
+
+// Backup creates the consistent checkpoint of database under backup dir of database,
+// then uploads the checkpoint into target under prefix, the checkpoint is removed after uploaded.
+func (db *database) Backup(target backup.Target, name, prefix string) error {
+	if !db.isBackingUp.CAS(false, true) {
+		return fmt.Errorf("backup of database[%s] is running", db.name)
+	}
+	defer db.isBackingUp.Store(false)
+
+	if err := backup.ValidateName(name); err != nil {
+		return err
+	}
+	checkpointPath := filepath.Join(db.path, backupDir, name)
+	// double check, checkpoint path is removed before and after backup
+	if !fileutil.IsSubPath(filepath.Join(db.path, backupDir), checkpointPath) {
+		return fmt.Errorf("backup checkpoint path[%s] is out of backup dir of database[%s]", checkpointPath, db.name)
+	}
+	if err := removeDir(checkpointPath); err != nil {
+		return err
+	}
+	defer func() {
+		if err := removeDir(checkpointPath); err != nil {
+			engineLogger.Error("remove backup checkpoint error",
+				logger.String("db", db.name), logger.String("path", checkpointPath), logger.Error(err))
+		}
+	}()
+	shardIDs, err := db.checkpoint(checkpointPath)
+	if err != nil {
+		return err
+	}
+	return uploadBackupFunc(target, prefix, checkpointPath, &backup.Manifest{
+		Database:  db.name,
+		Name:      name,
+		Timestamp: timeutil.Now(),
+		ShardIDs:  shardIDs,
+	})
+}
+
+// checkpoint creates the consistent checkpoint of database under path, returns the shard ids of checkpoint.
+// shards(index and memory data) are flushed synchronously before checkpoint, because the checkpoint
+// only contains the data on disk. shards are checkpointed before metadata, make sure that the metadata
+// of checkpoint contains all metric/tag ids which are used by shards.
+func (db *database) checkpoint(checkpointPath string) (shardIDs []int32, err error) {
+	shards := db.getShards()
+	for _, shard := range shards {
+		if err := shard.Flush(); err != nil {
+			return nil, fmt.Errorf("flush shard[%d] of database[%s] error:%s", shard.ShardID(), db.name, err)
+		}
+	}
+	for _, shard := range shards {
+		shardID := shard.ShardID()
+		if err := shard.Backup(filepath.Join(checkpointPath, shardDir, strconv.Itoa(int(shardID)))); err != nil {
+			return nil, fmt.Errorf("backup shard[%d] of database[%s] error:%s", shardID, db.name, err)
+		}
+		shardIDs = append(shardIDs, shardID)
+	}
+	if err := db.metadata.Flush(); err != nil {
+		return nil, err
+	}
+	if err := db.metaStore.Checkpoint(filepath.Join(checkpointPath, metaDir, tagMetaDir)); err != nil {
+		return nil, err
+	}
+	if err := db.metadata.MetadataDatabase().Backup(filepath.Join(checkpointPath, metaDir, metricMetaDir)); err != nil {
+		return nil, err
+	}
+	if err := db.tombstone.backup(filepath.Join(checkpointPath, tombstoneDir, tagMetaTombstone)); err != nil {
+		return nil, err
+	}
+	cfgPath := optionsPath(checkpointPath)
+	if err := encodeToml(cfgPath, &databaseConfig{ShardIDs: shardIDs, Option: db.config.Option}); err != nil {
+		return nil, fmt.Errorf("write database options to file[%s] error:%s", cfgPath, err)
+	}
+	return shardIDs, nil
+}
+
+// RestoreDatabase restores the database backup of prefix from target into database path,
+// only restores the given shards if shard ids not empty.
+// If database path not exist, restores the whole database(metadata and shards).
+// If database path exists, only the given shards are restored and replace the existing ones,
+// the metadata of existing database is kept, because the metric/tag ids of backup cannot merge
+// with other database, the existing database must be the one which the backup is taken from,
+// its metadata is a superset of backup's since the ids are node-local and never reused.
+// NOTICE: must restore when storage node stopped, because the shard files are replaced.
+func RestoreDatabase(target backup.Target, prefix, databasePath string, shardIDs []int32) error {
+	databaseExist := fileutil.Exist(databasePath)
+	if databaseExist && len(shardIDs) == 0 {
+		return fmt.Errorf("database path[%s] already exist, only restores the given shards into it", databasePath)
+	}
+	manifest, err := backup.LoadManifest(target, prefix)
+	if err != nil {
+		return err
+	}
+	restoreShards := manifest.ShardIDs
+	if len(shardIDs) > 0 {
+		restoreShards = nil
+		for _, shardID := range shardIDs {
+			if !containsShard(manifest.ShardIDs, shardID) {
+				return fmt.Errorf("shard[%d] not exist in backup[%s]", shardID, prefix)
+			}
+			restoreShards = append(restoreShards, shardID)
+		}
+	}
+	restoringPath := databasePath + restoringSuffix
+	if err := removeDir(restoringPath); err != nil {
+		return err
+	}
+	err = backup.Download(target, prefix, manifest, restoringPath, func(file string) bool {
+		if !strings.HasPrefix(file, shardDir+"/") {
+			// keeps the metadata of existing database
+			return !databaseExist
+		}
+		shardID, err := strconv.Atoi(strings.Split(file, "/")[1])
+		return err == nil && containsShard(restoreShards, int32(shardID))
+	})
+	if err == nil {
+		if databaseExist {
+			err = replaceShards(restoringPath, databasePath, restoreShards)
+		} else {
+			err = restoreDatabaseConfig(restoringPath, restoreShards)
+		}
+	}
+	if err != nil {
+		_ = removeDir(restoringPath)
+		return err
+	}
+	if databaseExist {
+		return removeDir(restoringPath)
+	}
+	return renameFunc(restoringPath, databasePath)
+}
+
+// restoreDatabaseConfig rewrites the shard ids of restored database options
+func restoreDatabaseConfig(databasePath string, shardIDs []int32) error {
+	cfgPath := optionsPath(databasePath)
+	cfg := &databaseConfig{}
+	if err := decodeToml(cfgPath, cfg); err != nil {
+		return fmt.Errorf("read database options from file[%s] error:%s", cfgPath, err)
+	}
+	cfg.ShardIDs = shardIDs
+	return encodeToml(cfgPath, cfg)
+}
+
+// replaceShards replaces the shards of existing database with the restored ones,
+// then adds the shard ids into database options if not exist.
+func replaceShards(restoringPath, databasePath string, shardIDs []int32) error {
+	cfgPath := optionsPath(databasePath)
+	cfg := &databaseConfig{}
+	if err := decodeToml(cfgPath, cfg); err != nil {
+		return fmt.Errorf("read database options from file[%s] error:%s", cfgPath, err)
+	}
+	if err := fileutil.MkDirIfNotExist(filepath.Join(databasePath, shardDir)); err != nil {
+		return err
+	}
+	for _, shardID := range shardIDs {
+		restoredPath := filepath.Join(restoringPath, shardDir, strconv.Itoa(int(shardID)))
+		// shard without any file in backup
+		if err := fileutil.MkDirIfNotExist(restoredPath); err != nil {
+			return err
+		}
+		shardPath := filepath.Join(databasePath, shardDir, strconv.Itoa(int(shardID)))
+		if err := removeDir(shardPath); err != nil {
+			return err
+		}
+		if err := renameFunc(restoredPath, shardPath); err != nil {
+			return err
+		}
+		if !containsShard(cfg.ShardIDs, shardID) {
+			cfg.ShardIDs = append(cfg.ShardIDs, shardID)
+		}
+	}
+	return encodeToml(cfgPath, cfg)
+}
+
+// BackupPrefix returns the prefix of database backup in target, backups are separated by storage node,
+// because the metric/tag/series ids are node-local.
+func BackupPrefix(name, node, databaseName string) string {
+	return path.Join(name, strings.NewReplacer(":", "_", string(os.PathSeparator), "_").Replace(node), databaseName)
+}
+
+// containsShard checks if the shard ids contains the shard id
+func containsShard(shardIDs []int32, shardID int32) bool {
+	for _, id := range shardIDs {
+		if id == shardID {
+			return true
+		}
+	}
+	return false
+}
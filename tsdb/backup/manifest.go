@@ -0,0 +1,148 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+// for testing
+var (
+	readFileFunc  = ioutil.ReadFile
+	writeFileFunc = ioutil.WriteFile
+)
+
+// ManifestFile is the object name of backup manifest under the prefix of backup,
+// manifest is uploaded after all files uploaded, so the backup without manifest is incomplete.
+const ManifestFile = "MANIFEST.json"
+
+// File represents the file of backup
+type File struct {
+	Path string `json:"path"` // slash-separated relative path
+	Size int64  `json:"size"`
+}
+
+// Manifest represents the manifest of backup, includes all files of backup
+type Manifest struct {
+	Database  string  `json:"database"`
+	Name      string  `json:"name"`
+	Timestamp int64   `json:"timestamp"`
+	ShardIDs  []int32 `json:"shardIds"`
+	Files     []File  `json:"files"`
+}
+
+// Upload uploads all files under the local dir into target with prefix, then uploads the manifest,
+// returns error if the backup of prefix already exist.
+func Upload(target Target, prefix, dir string, manifest *Manifest) error {
+	manifestKey := path.Join(prefix, ManifestFile)
+	exist, err := target.Exist(manifestKey)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return fmt.Errorf("backup[%s] already exist", prefix)
+	}
+	var files []File
+	err = filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: filepath.ToSlash(rel), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	for _, f := range files {
+		if err := target.Put(path.Join(prefix, f.Path), filepath.Join(dir, filepath.FromSlash(f.Path))); err != nil {
+			return fmt.Errorf("upload backup file[%s] error:%s", f.Path, err)
+		}
+	}
+	manifest.Files = files
+	manifestFile := filepath.Join(filepath.Dir(dir), filepath.Base(dir)+"."+ManifestFile)
+	if err := writeFileFunc(manifestFile, encoding.JSONMarshal(manifest), 0644); err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(manifestFile)
+	}()
+	return target.Put(manifestKey, manifestFile)
+}
+
+// LoadManifest loads the manifest of backup with prefix from target
+func LoadManifest(target Target, prefix string) (*Manifest, error) {
+	f, err := ioutil.TempFile("", "backup-manifest")
+	if err != nil {
+		return nil, err
+	}
+	_ = f.Close()
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if err := target.Get(path.Join(prefix, ManifestFile), f.Name()); err != nil {
+		return nil, fmt.Errorf("load manifest of backup[%s] error:%s", prefix, err)
+	}
+	data, err := readFileFunc(f.Name())
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err := encoding.JSONUnmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unmarshal manifest of backup[%s] error:%s", prefix, err)
+	}
+	return manifest, nil
+}
+
+// Download downloads the files of manifest which accepted by filter into the local dir,
+// verifies the size of downloaded file, filter is optional.
+func Download(target Target, prefix string, manifest *Manifest, dir string, filter func(file string) bool) error {
+	for _, f := range manifest.Files {
+		if filter != nil && !filter(f.Path) {
+			continue
+		}
+		localFile := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := target.Get(path.Join(prefix, f.Path), localFile); err != nil {
+			return fmt.Errorf("download backup file[%s] error:%s", f.Path, err)
+		}
+		stat, err := os.Stat(localFile)
+		if err != nil {
+			return err
+		}
+		if stat.Size() != f.Size {
+			return fmt.Errorf("backup file[%s] size mismatch, expect:%d, actual:%d", f.Path, f.Size, stat.Size())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestUpload_Download(t *testing.T) {
+	dir := filepath.Join(testPath, "checkpoint")
+	_ = fileutil.MkDirIfNotExist(filepath.Join(dir, "shard", "1"))
+	defer func() {
+		writeFileFunc = ioutil.WriteFile
+		readFileFunc = ioutil.ReadFile
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "OPTIONS"), []byte("option"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "shard", "1", "000001.sst"), []byte("sst"), 0644))
+
+	store := newMemObjectStore()
+	target := NewObjectStoreTarget(store, "backup")
+	manifest := &Manifest{Database: "db", Name: "b1", ShardIDs: []int32{1}}
+	// write manifest failure
+	writeFileFunc = func(filename string, data []byte, perm os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, Upload(target, "b1/db", dir, manifest))
+	writeFileFunc = ioutil.WriteFile
+	assert.NoError(t, Upload(target, "b1/db", dir, manifest))
+	assert.Equal(t, []File{{Path: "OPTIONS", Size: 6}, {Path: "shard/1/000001.sst", Size: 3}}, manifest.Files)
+	// backup exist
+	assert.Error(t, Upload(target, "b1/db", dir, manifest))
+
+	m, err := LoadManifest(target, "b1/db")
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, m)
+	m, err = LoadManifest(target, "b2/db")
+	assert.Error(t, err)
+	assert.Nil(t, m)
+
+	restore := filepath.Join(testPath, "restore")
+	assert.NoError(t, Download(target, "b1/db", manifest, restore, func(file string) bool {
+		return file != "OPTIONS"
+	}))
+	assert.False(t, fileutil.Exist(filepath.Join(restore, "OPTIONS")))
+	data, err := ioutil.ReadFile(filepath.Join(restore, "shard", "1", "000001.sst"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("sst"), data)
+	// size mismatch
+	manifest.Files[0].Size = 10
+	assert.Error(t, Download(target, "b1/db", manifest, restore, nil))
+	// file not exist
+	assert.Error(t, Download(target, "b2/db", manifest, restore, nil))
+
+	// read manifest failure
+	readFileFunc = func(filename string) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = LoadManifest(target, "b1/db")
+	assert.Error(t, err)
+	// unmarshal failure
+	readFileFunc = func(filename string) ([]byte, error) {
+		return []byte("abc"), nil
+	}
+	_, err = LoadManifest(target, "b1/db")
+	assert.Error(t, err)
+}
+
+func TestUpload_err(t *testing.T) {
+	dir := filepath.Join(testPath, "checkpoint")
+	_ = fileutil.MkDirIfNotExist(dir)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "OPTIONS"), []byte("option"), 0644))
+	store := newMemObjectStore()
+	target := NewObjectStoreTarget(store, "backup")
+	// dir not exist
+	assert.Error(t, Upload(target, "b1/db", filepath.Join(testPath, "not_exist"), &Manifest{}))
+	// put failure
+	store.err = fmt.Errorf("err")
+	assert.Error(t, Upload(target, "b1/db", dir, &Manifest{}))
+}
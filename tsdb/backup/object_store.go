@@ -0,0 +1,118 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ObjectStore represents the object store(like s3/oss/hdfs), implements and registers it for backing up into object store.
+type ObjectStore interface {
+	// PutObject writes the object of key from reader
+	PutObject(key string, reader io.Reader, size int64) error
+	// GetObject returns the reader of the object of key
+	GetObject(key string) (io.ReadCloser, error)
+	// ExistObject checks if the object of key exist
+	ExistObject(key string) (bool, error)
+}
+
+// ObjectStoreFactory creates the object store by the uri of backup target
+type ObjectStoreFactory func(uri *url.URL) (ObjectStore, error)
+
+var objectStores = make(map[string]ObjectStoreFactory)
+
+// RegisterObjectStore registers the object store factory for the scheme of backup target uri
+// NOTICE: must register before create backup target
+func RegisterObjectStore(scheme string, factory ObjectStoreFactory) {
+	_, ok := objectStores[scheme]
+	if ok {
+		panic("object store already register")
+	}
+	objectStores[scheme] = factory
+}
+
+// objectStoreTarget implements Target interface based on object store, all keys are under the prefix.
+type objectStoreTarget struct {
+	store  ObjectStore
+	prefix string
+}
+
+// NewObjectStoreTarget creates the backup target based on object store, all keys are under the prefix
+func NewObjectStoreTarget(store ObjectStore, prefix string) Target {
+	return &objectStoreTarget{
+		store:  store,
+		prefix: prefix,
+	}
+}
+
+// Put uploads the local file as the object of key
+func (t *objectStoreTarget) Put(key, localFile string) error {
+	f, err := os.Open(localFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return t.store.PutObject(t.key(key), f, stat.Size())
+}
+
+// Get downloads the object of key into the local file
+func (t *objectStoreTarget) Get(key, localFile string) (err error) {
+	reader, err := t.store.GetObject(t.key(key))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	if err := mkDirFunc(filepath.Dir(localFile)); err != nil {
+		return err
+	}
+	f, err := os.Create(localFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if _, err = io.Copy(f, reader); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// Exist checks if the object of key exist
+func (t *objectStoreTarget) Exist(key string) (bool, error) {
+	return t.store.ExistObject(t.key(key))
+}
+
+// key returns the object key under prefix
+func (t *objectStoreTarget) key(key string) string {
+	return path.Join(t.prefix, key)
+}
@@ -0,0 +1,113 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+// memObjectStore implements ObjectStore in memory for testing
+type memObjectStore struct {
+	objects map[string][]byte
+	err     error
+	mutex   sync.Mutex
+}
+
+func newMemObjectStore() *memObjectStore {
+	return &memObjectStore{objects: make(map[string][]byte)}
+}
+
+func (s *memObjectStore) PutObject(key string, reader io.Reader, size int64) error {
+	if s.err != nil {
+		return s.err
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return fmt.Errorf("size mismatch")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memObjectStore) GetObject(key string) (io.ReadCloser, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("object not exist")
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memObjectStore) ExistObject(key string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.objects[key]
+	return ok, nil
+}
+
+func TestObjectStoreTarget(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		mkDirFunc = fileutil.MkDirIfNotExist
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	src := filepath.Join(testPath, "src")
+	assert.NoError(t, ioutil.WriteFile(src, []byte("hello"), 0644))
+
+	store := newMemObjectStore()
+	target := NewObjectStoreTarget(store, "prefix")
+	assert.Error(t, target.Put("a/b", filepath.Join(testPath, "not_exist")))
+	assert.NoError(t, target.Put("a/b", src))
+	assert.Equal(t, []byte("hello"), store.objects["prefix/a/b"])
+	exist, err := target.Exist("a/b")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	dst := filepath.Join(testPath, "dst", "b")
+	assert.NoError(t, target.Get("a/b", dst))
+	data, err := ioutil.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), data)
+	assert.Error(t, target.Get("a/c", dst))
+	// create file failure
+	assert.Error(t, target.Get("a/b", testPath))
+
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, target.Get("a/b", dst))
+}
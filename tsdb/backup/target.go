@@ -0,0 +1,151 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+// for testing
+var (
+	mkDirFunc      = fileutil.MkDirIfNotExist
+	linkOrCopyFunc = fileutil.LinkOrCopyFile
+	copyFileFunc   = fileutil.CopyFile
+)
+
+// namePattern is the pattern of backup name, the name is used as path element of checkpoint and backup
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ValidateName checks if the backup name is a single path element which cannot escape its parent dir
+func ValidateName(name string) error {
+	if name == "." || !namePattern.MatchString(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid backup name[%s], must match %s and not be '.' or contain '..'", name, namePattern)
+	}
+	return nil
+}
+
+// Target represents the storage of backups, like local directory or object store,
+// the files of backup are stored as objects, the key of object is slash-separated relative path.
+type Target interface {
+	// Put stores the local file as the object of key
+	Put(key, localFile string) error
+	// Get fetches the object of key into the local file
+	Get(key, localFile string) error
+	// Exist checks if the object of key exist
+	Exist(key string) (bool, error)
+}
+
+// NewTarget creates the backup target by uri,
+// 1) local directory: /data/backup or file:///data/backup,
+// 2) object store: scheme://bucket/path, the object store of scheme must be registered by RegisterObjectStore.
+func NewTarget(uri string) (Target, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("backup target is empty")
+	}
+	if !strings.Contains(uri, "://") {
+		return NewLocalTarget(uri), nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "file" {
+		return NewLocalTarget(u.Path), nil
+	}
+	factory, ok := objectStores[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("object store of scheme[%s] not register", u.Scheme)
+	}
+	store, err := factory(u)
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectStoreTarget(store, strings.TrimPrefix(u.Path, "/")), nil
+}
+
+// NewRestrictedTarget creates the backup target by uri like NewTarget,
+// but the local directory target must be under the backup root, local target is rejected if root is empty.
+func NewRestrictedTarget(uri, root string) (Target, error) {
+	target, err := NewTarget(uri)
+	if err != nil {
+		return nil, err
+	}
+	local, ok := target.(*localTarget)
+	if !ok {
+		return target, nil
+	}
+	if root == "" || !fileutil.IsSubPath(root, local.dir) {
+		return nil, fmt.Errorf("local backup target[%s] must be under backup root[%s]", uri, root)
+	}
+	return target, nil
+}
+
+// localTarget implements Target interface, stores backups under local directory(like mounted nfs),
+// the file is hard linked into target directory if possible, else copied.
+type localTarget struct {
+	dir string
+}
+
+// NewLocalTarget creates the backup target which stores backups under local directory
+func NewLocalTarget(dir string) Target {
+	return &localTarget{dir: dir}
+}
+
+// Put links or copies the local file into target directory
+func (t *localTarget) Put(key, localFile string) error {
+	file := t.path(key)
+	if err := mkDirFunc(filepath.Dir(file)); err != nil {
+		return err
+	}
+	if err := fileutil.RemoveFile(file); err != nil {
+		return err
+	}
+	return linkOrCopyFunc(localFile, file)
+}
+
+// Get copies the file of key into the local file
+func (t *localTarget) Get(key, localFile string) error {
+	if err := mkDirFunc(filepath.Dir(localFile)); err != nil {
+		return err
+	}
+	return copyFileFunc(t.path(key), localFile)
+}
+
+// Exist checks if the file of key exist under target directory
+func (t *localTarget) Exist(key string) (bool, error) {
+	_, err := os.Stat(t.path(key))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// path returns the file path of key
+func (t *localTarget) path(key string) string {
+	return filepath.Join(t.dir, filepath.FromSlash(key))
+}
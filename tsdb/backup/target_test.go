@@ -0,0 +1,143 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+var testPath = "./test_backup"
+
+func TestNewTarget(t *testing.T) {
+	defer func() {
+		delete(objectStores, "mem")
+	}()
+	target, err := NewTarget("")
+	assert.Error(t, err)
+	assert.Nil(t, target)
+	target, err = NewTarget("/tmp/backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/backup", target.(*localTarget).dir)
+	target, err = NewTarget("file:///tmp/backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/backup", target.(*localTarget).dir)
+	target, err = NewTarget("mem://bucket/a/b")
+	assert.Error(t, err)
+	assert.Nil(t, target)
+	target, err = NewTarget("mem://%zz/a")
+	assert.Error(t, err)
+	assert.Nil(t, target)
+
+	RegisterObjectStore("mem", func(uri *url.URL) (ObjectStore, error) {
+		if uri.Host == "err" {
+			return nil, fmt.Errorf("err")
+		}
+		return newMemObjectStore(), nil
+	})
+	assert.Panics(t, func() {
+		RegisterObjectStore("mem", nil)
+	})
+	target, err = NewTarget("mem://err/a/b")
+	assert.Error(t, err)
+	assert.Nil(t, target)
+	target, err = NewTarget("mem://bucket/a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, "a/b", target.(*objectStoreTarget).prefix)
+}
+
+func TestNewRestrictedTarget(t *testing.T) {
+	defer func() {
+		delete(objectStores, "mem")
+	}()
+	target, err := NewRestrictedTarget("", "/data/backup")
+	assert.Error(t, err)
+	assert.Nil(t, target)
+	target, err = NewRestrictedTarget("/data/backup/b1", "/data/backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "/data/backup/b1", target.(*localTarget).dir)
+	target, err = NewRestrictedTarget("file:///data/backup/b1", "/data/backup")
+	assert.NoError(t, err)
+	assert.NotNil(t, target)
+	// local target out of backup root
+	for _, uri := range []string{"/etc", "file:///data/backup/../../etc", "/data/backup", "/data/backup2"} {
+		target, err = NewRestrictedTarget(uri, "/data/backup")
+		assert.Error(t, err, uri)
+		assert.Nil(t, target)
+	}
+	// backup root not set
+	target, err = NewRestrictedTarget("/data/backup/b1", "")
+	assert.Error(t, err)
+	assert.Nil(t, target)
+	// object store target is not restricted
+	RegisterObjectStore("mem", func(uri *url.URL) (ObjectStore, error) {
+		return newMemObjectStore(), nil
+	})
+	target, err = NewRestrictedTarget("mem://bucket/a", "")
+	assert.NoError(t, err)
+	assert.NotNil(t, target)
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"b1", "20200101120000", "daily_backup-1.0"} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", ".", "..", "../../..", "a/b", "a/../b", "..b", "/etc", "a\\b", "b 1"} {
+		assert.Error(t, ValidateName(name), name)
+	}
+}
+
+func TestLocalTarget(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		mkDirFunc = fileutil.MkDirIfNotExist
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	src := filepath.Join(testPath, "src")
+	assert.NoError(t, ioutil.WriteFile(src, []byte("hello"), 0644))
+
+	target := NewLocalTarget(filepath.Join(testPath, "target"))
+	exist, err := target.Exist("a/b")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	assert.NoError(t, target.Put("a/b", src))
+	// put again
+	assert.NoError(t, target.Put("a/b", src))
+	exist, err = target.Exist("a/b")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	dst := filepath.Join(testPath, "dst", "b")
+	assert.NoError(t, target.Get("a/b", dst))
+	data, err := ioutil.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), data)
+	assert.Error(t, target.Get("a/c", dst))
+
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, target.Put("a/c", src))
+	assert.Error(t, target.Get("a/b", dst))
+}
@@ -0,0 +1,289 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cespare/xxhash"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/tsdb/backup"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestDatabase_Backup_Restore(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	timestamp := timeutil.Now() - 3*timeutil.OneDay
+	var metrics []*pb.Metric
+	for _, host := range []string{"1.1.1.1", "1.1.1.2"} {
+		tags := map[string]string{"host": host}
+		metrics = append(metrics, &pb.Metric{
+			Namespace: "ns",
+			Name:      "cpu",
+			Timestamp: timestamp,
+			Tags:      tags,
+			TagsHash:  xxhash.Sum64String(tag.Concat(tags)),
+			Fields:    []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: 1}},
+		})
+	}
+	openDatabase := func(cfg config.TSDB) (Engine, Database) {
+		e, err := NewEngine(cfg)
+		assert.NoError(t, err)
+		db, ok := e.GetDatabase("db")
+		if !ok {
+			db, err = e.CreateDatabase("db")
+			assert.NoError(t, err)
+			assert.NoError(t, db.CreateShards(option.DatabaseOption{Interval: "10s"}, []int32{1, 2}))
+		}
+		return e, db
+	}
+	closeDatabase := func(e Engine, db Database) {
+		e.Close()
+		// releases the kv stores of segments for reopening
+		for _, s := range db.(*database).getShards() {
+			for _, segment := range s.(*shard).segments {
+				segment.Close()
+			}
+		}
+	}
+	e, db := openDatabase(engineCfg)
+	for _, shardID := range []int32{1, 2} {
+		s, _ := db.GetShard(shardID)
+		assert.NoError(t, s.Backfill(metrics))
+	}
+	// reopen for persisting metadata
+	closeDatabase(e, db)
+	e, db = openDatabase(engineCfg)
+	s, _ := db.GetShard(1)
+	digest, err := s.Digest()
+	assert.NoError(t, err)
+	exported, err := s.ExportSeries(digest.Families[0].FamilyTime, "ns", "cpu", nil)
+	assert.NoError(t, err)
+
+	target := backup.NewLocalTarget(filepath.Join(testPath, "backup_target"))
+	prefix := BackupPrefix("b1", "127.0.0.1:2891", "db")
+	assert.Equal(t, "b1/127.0.0.1_2891/db", prefix)
+	assert.NoError(t, db.Backup(target, "b1", prefix))
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "db", backupDir, "b1")))
+	// backup exist
+	assert.Error(t, db.Backup(target, "b1", prefix))
+	closeDatabase(e, db)
+
+	restoreDir := filepath.Join(testPath, "restore")
+	// shard not exist in backup
+	assert.Error(t, RestoreDatabase(target, prefix, filepath.Join(restoreDir, "db"), []int32{3}))
+	// backup not exist
+	assert.Error(t, RestoreDatabase(target, "b2", filepath.Join(restoreDir, "db"), nil))
+	assert.NoError(t, RestoreDatabase(target, prefix, filepath.Join(restoreDir, "db"), []int32{1}))
+	// database exist, restores whole database
+	assert.Error(t, RestoreDatabase(target, prefix, filepath.Join(restoreDir, "db"), nil))
+	// database exist, restores the given shards into it
+	assert.NoError(t, RestoreDatabase(target, prefix, filepath.Join(restoreDir, "db"), []int32{1, 2}))
+	assert.False(t, fileutil.Exist(filepath.Join(restoreDir, "db"+restoringSuffix)))
+
+	e, db = openDatabase(config.TSDB{Dir: restoreDir})
+	defer closeDatabase(e, db)
+	assert.Equal(t, 2, db.NumOfShards())
+	for _, shardID := range []int32{1, 2} {
+		s, ok := db.GetShard(shardID)
+		assert.True(t, ok)
+		restored, err := s.ExportSeries(digest.Families[0].FamilyTime, "ns", "cpu", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, sortMetrics(exported), sortMetrics(restored))
+	}
+	s, _ = db.GetShard(1)
+	restoredDigest, err := s.Digest()
+	assert.NoError(t, err)
+	assert.Equal(t, digest, restoredDigest)
+}
+
+func TestDatabase_Restore_existing_err(t *testing.T) {
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		renameFunc = os.Rename
+		encodeToml = ltoml.EncodeToml
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	target := backup.NewLocalTarget(filepath.Join(testPath, "backup_target"))
+	backupPath := filepath.Join(testPath, "backup")
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(backupPath, shardDir, "1")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(backupPath, shardDir, "1", "data"), []byte("data"), 0644))
+	assert.NoError(t, ltoml.EncodeToml(optionsPath(backupPath), &databaseConfig{ShardIDs: []int32{1}}))
+	assert.NoError(t, backup.Upload(target, "b1", backupPath, &backup.Manifest{ShardIDs: []int32{1}}))
+	databasePath := filepath.Join(testPath, "db")
+	assert.NoError(t, fileutil.MkDirIfNotExist(databasePath))
+	// read options failure
+	assert.Error(t, RestoreDatabase(target, "b1", databasePath, []int32{1}))
+	assert.False(t, fileutil.Exist(databasePath+restoringSuffix))
+	assert.NoError(t, ltoml.EncodeToml(optionsPath(databasePath), &databaseConfig{ShardIDs: []int32{2}}))
+	// remove shard failure
+	removeDir = func(path string) error {
+		if strings.HasPrefix(path, databasePath+restoringSuffix) {
+			return fileutil.RemoveDir(path)
+		}
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, RestoreDatabase(target, "b1", databasePath, []int32{1}))
+	removeDir = fileutil.RemoveDir
+	// replace shard failure
+	renameFunc = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, RestoreDatabase(target, "b1", databasePath, []int32{1}))
+	renameFunc = os.Rename
+	// write options failure
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, RestoreDatabase(target, "b1", databasePath, []int32{1}))
+	encodeToml = ltoml.EncodeToml
+	assert.NoError(t, RestoreDatabase(target, "b1", databasePath, []int32{1}))
+	cfg := &databaseConfig{}
+	assert.NoError(t, ltoml.DecodeToml(optionsPath(databasePath), cfg))
+	assert.Equal(t, []int32{2, 1}, cfg.ShardIDs)
+	assert.True(t, fileutil.Exist(filepath.Join(databasePath, shardDir, "1", "data")))
+	assert.False(t, fileutil.Exist(databasePath+restoringSuffix))
+}
+
+func TestDatabase_Backup_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		encodeToml = ltoml.EncodeToml
+		uploadBackupFunc = backup.Upload
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	shard := NewMockShard(ctrl)
+	shard.EXPECT().ShardID().Return(int32(1)).AnyTimes()
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metaStore := kv.NewMockStore(ctrl)
+	db := &database{
+		name:      "db",
+		path:      filepath.Join(testPath, "db"),
+		config:    &databaseConfig{},
+		metadata:  metadata,
+		metaStore: metaStore,
+		tombstone: &tombstone{path: filepath.Join(testPath, "tombstone")},
+	}
+	db.shards.Store(int32(1), shard)
+	target := backup.NewLocalTarget(filepath.Join(testPath, "backup_target"))
+
+	// invalid backup name, checkpoint path out of backup dir
+	removeDir = func(path string) error {
+		panic("remove dir with invalid backup name")
+	}
+	for _, name := range []string{"", ".", "..", "../../..", "a/../../b", "/etc"} {
+		assert.Error(t, db.Backup(target, name, "b1"), name)
+	}
+	removeDir = fileutil.RemoveDir
+	// backup is running
+	db.isBackingUp.Store(true)
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	db.isBackingUp.Store(false)
+	// remove checkpoint failure
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	removeDir = fileutil.RemoveDir
+	// flush shard failure
+	shard.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	shard.EXPECT().Flush().Return(nil).AnyTimes()
+	// shard backup failure
+	shard.EXPECT().Backup(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	shard.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// flush metadata failure
+	metadata.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	metadata.EXPECT().Flush().Return(nil).AnyTimes()
+	// checkpoint meta store failure
+	metaStore.EXPECT().Checkpoint(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	metaStore.EXPECT().Checkpoint(gomock.Any()).Return(nil).AnyTimes()
+	// backup metadata database failure
+	metadataDB.EXPECT().Backup(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	metadataDB.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// write options failure
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+	encodeToml = ltoml.EncodeToml
+	// upload failure
+	uploadBackupFunc = func(target backup.Target, prefix, dir string, manifest *backup.Manifest) error {
+		assert.Equal(t, []int32{1}, manifest.ShardIDs)
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db.Backup(target, "b1", "b1"))
+}
+
+func TestShard_Backup_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	segment := NewMockIntervalSegment(ctrl)
+	indexStore := kv.NewMockStore(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	s := &shard{
+		segments:        map[timeutil.IntervalType]IntervalSegment{timeutil.Day: segment},
+		indexStore:      indexStore,
+		indexDB:         indexDB,
+		metricTombstone: &tombstone{path: filepath.Join(testPath, metricTombstone)},
+		indexTombstone:  &tombstone{path: filepath.Join(testPath, indexTombstone)},
+	}
+	path := filepath.Join(testPath, "checkpoint")
+	// checkpoint segment failure
+	segment.EXPECT().checkpoint(filepath.Join(path, segmentDir, timeutil.Day.String())).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Backup(path))
+	segment.EXPECT().checkpoint(gomock.Any()).Return(nil).AnyTimes()
+	// checkpoint index store failure
+	indexStore.EXPECT().Checkpoint(filepath.Join(path, indexParentDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Backup(path))
+	indexStore.EXPECT().Checkpoint(gomock.Any()).Return(nil).AnyTimes()
+	// backup index database failure
+	indexDB.EXPECT().Backup(filepath.Join(path, metaDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Backup(path))
+	indexDB.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	assert.NoError(t, s.Backup(path))
+}
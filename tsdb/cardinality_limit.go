@@ -0,0 +1,126 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/monitoring"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+// defines the limit types of cardinality
+const (
+	metricsPerNamespaceLimit = "metrics_per_namespace"
+	seriesPerMetricLimit     = "series_per_metric"
+	tagValuesPerTagKeyLimit  = "tag_values_per_tag_key"
+)
+
+var (
+	writeRejectedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_write_rejected_by_limit",
+			Help: "The number of rejected writes which exceed the cardinality limits.",
+		},
+		[]string{"db", "shard", "limit"},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(writeRejectedCounter)
+}
+
+// checkMetricLimit checks if the number of metrics under namespace exceeds the limit when writes a new metric
+func (s *shard) checkMetricLimit(namespace, metricName string) error {
+	limit := s.option.Limits.MaxMetricsPerNamespace
+	if limit <= 0 {
+		return nil
+	}
+	metadataDB := s.metadata.MetadataDatabase()
+	_, err := metadataDB.GetMetricID(namespace, metricName)
+	if err != constants.ErrNotFound {
+		// metric exist or get metric id err
+		return err
+	}
+	count, err := metadataDB.GetMetricCount(namespace)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return s.rejectWrite(metricsPerNamespaceLimit, constants.ErrTooManyMetrics)
+	}
+	return nil
+}
+
+// seriesLimitChecker returns the checker which checks if the number of series under metric
+// or the number of tag values under tag key exceeds the limit when writes a new series,
+// the checker is invoked by index database under the lock of series id generation, returns nil if no series limit.
+func (s *shard) seriesLimitChecker(namespace string, metric *pb.Metric) func(seriesCount uint32) error {
+	limits := s.option.Limits
+	if !limits.HasSeriesLimit() {
+		return nil
+	}
+	return func(seriesCount uint32) error {
+		if limits.MaxSeriesPerMetric > 0 && seriesCount >= uint32(limits.MaxSeriesPerMetric) {
+			return s.rejectWrite(seriesPerMetricLimit, constants.ErrTooManySeries)
+		}
+		if limits.MaxTagValuesPerTagKey > 0 {
+			for tagKey, tagValue := range metric.Tags {
+				if err := s.checkTagValueLimit(namespace, metric.Name, tagKey, tagValue); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// checkTagValueLimit checks if the number of tag values under tag key exceeds the limit when writes a new tag value
+func (s *shard) checkTagValueLimit(namespace, metricName, tagKey, tagValue string) error {
+	tagKeyID, err := s.metadata.MetadataDatabase().GetTagKeyID(namespace, metricName, tagKey)
+	if err == constants.ErrNotFound {
+		// new tag key, no tag values under it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tagMetadata := s.metadata.TagMetadata()
+	_, err = tagMetadata.GetTagValueID(tagKeyID, tagValue)
+	if err != constants.ErrNotFound {
+		// tag value exist or get tag value id err
+		return err
+	}
+	tagValueIDs, err := tagMetadata.GetTagValueIDsForTag(tagKeyID)
+	if err != nil && err != constants.ErrNotFound {
+		return err
+	}
+	if tagValueIDs != nil && tagValueIDs.GetCardinality() >= uint64(s.option.Limits.MaxTagValuesPerTagKey) {
+		return s.rejectWrite(tagValuesPerTagKeyLimit, constants.ErrTooManyTagValues)
+	}
+	return nil
+}
+
+// rejectWrite records the rejected write by limit type, then returns the err
+func (s *shard) rejectWrite(limit string, err error) error {
+	writeRejectedCounter.WithLabelValues(s.databaseName, strconv.Itoa(int(s.id)), limit).Inc()
+	return err
+}
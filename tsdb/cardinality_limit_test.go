@@ -0,0 +1,107 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/option"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestShard_checkMetricLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	s := &shard{databaseName: "db", id: 1, metadata: metadata}
+	// case 1: no limit
+	assert.NoError(t, s.checkMetricLimit("ns", "cpu"))
+
+	s.option = option.DatabaseOption{Limits: option.LimitOption{MaxMetricsPerNamespace: 2}}
+	// case 2: metric exist
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(1), nil)
+	assert.NoError(t, s.checkMetricLimit("ns", "cpu"))
+	// case 3: get metric id err
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), fmt.Errorf("err"))
+	assert.Error(t, s.checkMetricLimit("ns", "cpu"))
+	// case 4: get metric count err
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), constants.ErrNotFound).AnyTimes()
+	metadataDB.EXPECT().GetMetricCount("ns").Return(0, fmt.Errorf("err"))
+	assert.Error(t, s.checkMetricLimit("ns", "cpu"))
+	// case 5: under limit
+	metadataDB.EXPECT().GetMetricCount("ns").Return(1, nil)
+	assert.NoError(t, s.checkMetricLimit("ns", "cpu"))
+	// case 6: exceed limit
+	metadataDB.EXPECT().GetMetricCount("ns").Return(2, nil)
+	assert.Equal(t, constants.ErrTooManyMetrics, s.checkMetricLimit("ns", "cpu"))
+}
+
+func TestShard_seriesLimitChecker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	tagMetadata := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMetadata).AnyTimes()
+	s := &shard{databaseName: "db", id: 1, metadata: metadata}
+	metric := &pb.Metric{Name: "cpu", TagsHash: 100, Tags: map[string]string{"host": "1.1.1.1"}}
+	// case 1: no limit
+	assert.Nil(t, s.seriesLimitChecker("ns", metric))
+
+	s.option = option.DatabaseOption{Limits: option.LimitOption{MaxSeriesPerMetric: 2}}
+	checkLimit := s.seriesLimitChecker("ns", metric)
+	// case 2: under limit
+	assert.NoError(t, checkLimit(1))
+	// case 3: exceed limit
+	assert.Equal(t, constants.ErrTooManySeries, checkLimit(2))
+
+	s.option = option.DatabaseOption{Limits: option.LimitOption{MaxTagValuesPerTagKey: 2}}
+	checkLimit = s.seriesLimitChecker("ns", metric)
+	// case 4: new tag key
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(0), constants.ErrNotFound)
+	assert.NoError(t, checkLimit(100))
+	// case 5: get tag key id err
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(0), fmt.Errorf("err"))
+	assert.Error(t, checkLimit(100))
+	// case 6: tag value exist
+	metadataDB.EXPECT().GetTagKeyID("ns", "cpu", "host").Return(uint32(5), nil).AnyTimes()
+	tagMetadata.EXPECT().GetTagValueID(uint32(5), "1.1.1.1").Return(uint32(1), nil)
+	assert.NoError(t, checkLimit(100))
+	// case 7: get tag value ids err
+	tagMetadata.EXPECT().GetTagValueID(uint32(5), "1.1.1.1").Return(uint32(0), constants.ErrNotFound).AnyTimes()
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(5)).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, checkLimit(100))
+	// case 8: under limit
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(5)).Return(roaring.BitmapOf(1), nil)
+	assert.NoError(t, checkLimit(100))
+	// case 9: exceed limit
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(5)).Return(roaring.BitmapOf(1, 2), nil)
+	assert.Equal(t, constants.ErrTooManyTagValues, checkLimit(100))
+}
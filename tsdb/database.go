@@ -22,18 +22,23 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/lindb/roaring"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/concurrent"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/backup"
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/tblstore/tagkeymeta"
 )
@@ -77,6 +82,33 @@ type Database interface {
 	FlushMeta() error
 	// FLush flushes memory data of all shards to disk
 	Flush() error
+	// DropMetric drops the metadata/index/data of metric, the index/data will be purged by compaction job
+	DropMetric(namespace, metricName string) error
+	// DeleteSeries deletes the index/data of series which match the tag filter condition under metric,
+	// the index/data will be purged by compaction job
+	DeleteSeries(namespace, metricName string, condition stmt.Expr, finder SeriesFinder) error
+	// GetCardinality returns the cardinality of metric, includes the number of series for each shard
+	// and the number of tag values for each tag key, if metric not exist return constants.ErrNotFound
+	GetCardinality(namespace, metricName string) (*MetricCardinality, error)
+	// Backup creates the consistent checkpoint of database, then uploads it into target under prefix,
+	// the metric/tag/series ids are node-local, so the backup includes metadata and all shards of this node.
+	Backup(target backup.Target, name, prefix string) error
+}
+
+// MetricCardinality represents the cardinality of metric
+type MetricCardinality struct {
+	Namespace string            `json:"namespace"`
+	Metric    string            `json:"metric"`
+	Series    map[int32]uint32  `json:"series"`    // key: shard id, value: the number of series
+	TagValues map[string]uint64 `json:"tagValues"` // key: tag key, value: the number of tag values
+}
+
+// SeriesFinder finds the series ids of metric which match the tag filter condition
+type SeriesFinder interface {
+	// FindSeriesIDs returns the series ids of metric which match the tag filter condition for each shard,
+	// the key of result is shard id
+	FindSeriesIDs(metadata metadb.Metadata, shards []Shard,
+		namespace, metricName string, condition stmt.Expr) (map[int32]*roaring.Bitmap, error)
 }
 
 // databaseConfig represents a database configuration about config and shards
@@ -98,6 +130,8 @@ type database struct {
 	metadata     metadb.Metadata // underlying metric metadata
 	metaStore    kv.Store        // underlying meta kv store
 	isFlushing   atomic.Bool     // restrict flusher concurrency
+	isBackingUp  atomic.Bool     // restrict backup concurrency
+	tombstone    *tombstone      // deleted tag meta data(key: tag key id)
 
 	flushChecker DataFlushChecker
 }
@@ -126,7 +160,8 @@ func newDatabase(databaseName string, databasePath string, cfg *databaseConfig,
 				runtime.NumCPU(), /*nRoutines*/
 				time.Second*5),
 		},
-		isFlushing: *atomic.NewBool(false),
+		isFlushing:  *atomic.NewBool(false),
+		isBackingUp: *atomic.NewBool(false),
 	}
 	if err := db.dumpDatabaseConfig(cfg); err != nil {
 		return nil, err
@@ -197,6 +232,27 @@ func (db *database) CreateShards(
 			return err
 		}
 	}
+	// data retention maybe modified after shards created, shards refresh it when evicting expired data
+	return db.updateRetention(option)
+}
+
+// updateRetention updates the data retention(ttl/rollup ttl) of database option if modified
+func (db *database) updateRetention(option option.DatabaseOption) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	current := db.config.Option
+	if current.TTL == option.TTL && reflect.DeepEqual(current.RollupTTL, option.RollupTTL) {
+		return nil
+	}
+	newCfg := &databaseConfig{Option: current, ShardIDs: db.config.ShardIDs}
+	newCfg.Option.TTL = option.TTL
+	newCfg.Option.RollupTTL = option.RollupTTL
+	if err := db.dumpDatabaseConfig(newCfg); err != nil {
+		return err
+	}
+	engineLogger.Info("update data retention of database",
+		logger.String("db", db.name), logger.String("ttl", option.TTL), logger.Any("rollupTTL", option.RollupTTL))
 	return nil
 }
 
@@ -284,6 +340,11 @@ func (db *database) initMetadata() error {
 	if err != nil {
 		return err
 	}
+	db.tombstone, err = newTombstone(filepath.Join(db.path, tombstoneDir, tagMetaTombstone))
+	if err != nil {
+		return err
+	}
+	metaStore.RegisterTombstone(db.tombstone)
 	tagMetaFamily, err := metaStore.CreateFamily(
 		tagValueDir,
 		kv.FamilyOption{
@@ -322,6 +383,121 @@ func (db *database) Flush() error {
 	return nil
 }
 
+// DropMetric drops the metadata/index/data of metric, the index/data will be purged by compaction job
+func (db *database) DropMetric(namespace, metricName string) error {
+	metricID, tags, err := db.metadata.MetadataDatabase().DropMetric(namespace, metricName)
+	if err == constants.ErrNotFound {
+		// metric not exist in this database
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tagKeyIDs := make([]uint32, len(tags))
+	for idx, tagKey := range tags {
+		tagKeyIDs[idx] = tagKey.ID
+	}
+	if err := db.tombstone.deleteKeys(tagKeyIDs...); err != nil {
+		return err
+	}
+	for _, shard := range db.getShards() {
+		if err := shard.DropMetric(metricID, tagKeyIDs); err != nil {
+			return fmt.Errorf("drop metric[%s] for shard[%s] error:%s", metricName, shard.ShardInfo(), err)
+		}
+	}
+	engineLogger.Info("drop metric successfully",
+		logger.String("db", db.name), logger.String("namespace", namespace), logger.String("metric", metricName))
+	return nil
+}
+
+// DeleteSeries deletes the index/data of series which match the tag filter condition under metric,
+// the index/data will be purged by compaction job
+func (db *database) DeleteSeries(namespace, metricName string, condition stmt.Expr, finder SeriesFinder) error {
+	metadataDB := db.metadata.MetadataDatabase()
+	metricID, err := metadataDB.GetMetricID(namespace, metricName)
+	if err == constants.ErrNotFound {
+		// metric not exist in this database
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tags, err := metadataDB.GetAllTagKeys(namespace, metricName)
+	// metric maybe has no tag keys
+	if err != nil && err != constants.ErrNotFound {
+		return err
+	}
+	tagKeyIDs := make([]uint32, len(tags))
+	for idx, tagKey := range tags {
+		tagKeyIDs[idx] = tagKey.ID
+	}
+	shards := db.getShards()
+	result, err := finder.FindSeriesIDs(db.metadata, shards, namespace, metricName, condition)
+	if err != nil {
+		return err
+	}
+	for _, shard := range shards {
+		seriesIDs, ok := result[shard.ShardID()]
+		if !ok {
+			continue
+		}
+		if err := shard.DeleteSeries(metricID, tagKeyIDs, seriesIDs); err != nil {
+			return fmt.Errorf("delete series of metric[%s] for shard[%s] error:%s", metricName, shard.ShardInfo(), err)
+		}
+	}
+	engineLogger.Info("delete series successfully",
+		logger.String("db", db.name), logger.String("namespace", namespace), logger.String("metric", metricName))
+	return nil
+}
+
+// GetCardinality returns the cardinality of metric, includes the number of series for each shard
+// and the number of tag values for each tag key, if metric not exist return constants.ErrNotFound
+func (db *database) GetCardinality(namespace, metricName string) (*MetricCardinality, error) {
+	metadataDB := db.metadata.MetadataDatabase()
+	metricID, err := metadataDB.GetMetricID(namespace, metricName)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := metadataDB.GetAllTagKeys(namespace, metricName)
+	// metric maybe has no tag keys
+	if err != nil && err != constants.ErrNotFound {
+		return nil, err
+	}
+	result := &MetricCardinality{
+		Namespace: namespace,
+		Metric:    metricName,
+		Series:    make(map[int32]uint32),
+		TagValues: make(map[string]uint64),
+	}
+	tagMetadata := db.metadata.TagMetadata()
+	for _, tagKey := range tags {
+		tagValueIDs, err := tagMetadata.GetTagValueIDsForTag(tagKey.ID)
+		if err != nil && err != constants.ErrNotFound {
+			return nil, err
+		}
+		if tagValueIDs != nil {
+			result.TagValues[tagKey.Key] = tagValueIDs.GetCardinality()
+		}
+	}
+	for _, shard := range db.getShards() {
+		count, err := shard.IndexDatabase().GetSeriesCount(metricID)
+		if err != nil {
+			return nil, fmt.Errorf("get series count of metric[%s] for shard[%s] error:%s", metricName, shard.ShardInfo(), err)
+		}
+		result.Series[shard.ShardID()] = count
+	}
+	return result, nil
+}
+
+// getShards returns all shards of database
+func (db *database) getShards() (shards []Shard) {
+	db.shards.Range(func(key, value interface{}) bool {
+		shards = append(shards, value.(Shard))
+		return true
+	})
+	return
+}
+
 // optionsPath returns options file path
 func optionsPath(path string) string {
 	return filepath.Join(path, options)
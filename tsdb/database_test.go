@@ -20,16 +20,22 @@ package tsdb
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/metadb"
 )
 
@@ -68,6 +74,7 @@ func TestDatabase_New(t *testing.T) {
 	newKVStoreFunc = func(name string, option kv.StoreOption) (store kv.Store, err error) {
 		return kvStore, nil
 	}
+	kvStore.EXPECT().RegisterTombstone(gomock.Any()).AnyTimes()
 	kvStore.EXPECT().CreateFamily(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	db, err = newDatabase("db", testPath, &databaseConfig{
 		Option: option.DatabaseOption{},
@@ -155,13 +162,20 @@ func TestDatabase_CreateShards(t *testing.T) {
 	// case 3: create exist shard
 	err = db.CreateShards(option.DatabaseOption{}, []int32{1, 2, 3})
 	assert.NoError(t, err)
-	// case 4: create shard success
+	// case 4: data retention of exist shard modified
+	err = db.CreateShards(option.DatabaseOption{Interval: "1m", TTL: "7d", RollupTTL: []string{"30d"}}, []int32{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, option.DatabaseOption{Interval: "10s", TTL: "7d", RollupTTL: []string{"30d"}}, db.GetOption())
+	cfg := &databaseConfig{}
+	assert.NoError(t, ltoml.DecodeToml(optionsPath(testPath), cfg))
+	assert.Equal(t, "7d", cfg.Option.TTL)
+	// case 5: create shard success
 	newShardFunc = func(db Database, shardID int32, shardPath string, option option.DatabaseOption) (s Shard, err error) {
 		return nil, nil
 	}
 	err = db.CreateShards(option.DatabaseOption{}, []int32{4, 5, 6})
 	assert.NoError(t, err)
-	// case 5: dump option err
+	// case 6: dump option err
 	newShardFunc = func(db Database, shardID int32, shardPath string, option option.DatabaseOption) (s Shard, err error) {
 		return nil, nil
 	}
@@ -170,7 +184,9 @@ func TestDatabase_CreateShards(t *testing.T) {
 	}
 	err = db.CreateShards(option.DatabaseOption{}, []int32{9})
 	assert.Error(t, err)
-	// case 6: create exist shard
+	err = db.CreateShards(option.DatabaseOption{TTL: "1d"}, []int32{1})
+	assert.Error(t, err)
+	// case 7: create exist shard
 	db1 := db.(*database)
 	err = db1.createShard(1, option.DatabaseOption{})
 	assert.NoError(t, err)
@@ -250,3 +266,137 @@ func TestDatabase_Flush(t *testing.T) {
 	err = db.Flush()
 	assert.NoError(t, err)
 }
+
+func TestDatabase_DropMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	ts, err := newTombstone(filepath.Join(testPath, tombstoneDir, tagMetaTombstone))
+	assert.NoError(t, err)
+	db := &database{
+		metadata:  metadata,
+		tombstone: ts,
+	}
+	shard := NewMockShard(ctrl)
+	shard.EXPECT().ShardInfo().Return("shard-1").AnyTimes()
+	db.shards.Store(int32(1), shard)
+	// case 1: metric not exist
+	metadataDB.EXPECT().DropMetric("ns", "cpu").Return(uint32(0), nil, constants.ErrNotFound)
+	err = db.DropMetric("ns", "cpu")
+	assert.NoError(t, err)
+	// case 2: drop metric metadata err
+	metadataDB.EXPECT().DropMetric("ns", "cpu").Return(uint32(0), nil, fmt.Errorf("err"))
+	err = db.DropMetric("ns", "cpu")
+	assert.Error(t, err)
+	// case 3: drop shard's metric err
+	metadataDB.EXPECT().DropMetric("ns", "cpu").Return(uint32(10), []tag.Meta{{Key: "host", ID: 1}}, nil).AnyTimes()
+	shard.EXPECT().DropMetric(uint32(10), []uint32{1}).Return(fmt.Errorf("err"))
+	err = db.DropMetric("ns", "cpu")
+	assert.Error(t, err)
+	assert.True(t, ts.IsDeleted(1))
+	// case 4: drop metric
+	shard.EXPECT().DropMetric(uint32(10), []uint32{1}).Return(nil)
+	err = db.DropMetric("ns", "cpu")
+	assert.NoError(t, err)
+}
+
+func TestDatabase_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	finder := NewMockSeriesFinder(ctrl)
+	db := &database{metadata: metadata}
+	shard1 := NewMockShard(ctrl)
+	shard1.EXPECT().ShardID().Return(int32(1)).AnyTimes()
+	shard1.EXPECT().ShardInfo().Return("shard-1").AnyTimes()
+	shard2 := NewMockShard(ctrl)
+	shard2.EXPECT().ShardID().Return(int32(2)).AnyTimes()
+	db.shards.Store(int32(1), shard1)
+	db.shards.Store(int32(2), shard2)
+	condition := &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}
+	// case 1: metric not exist
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), constants.ErrNotFound)
+	err := db.DeleteSeries("ns", "cpu", condition, finder)
+	assert.NoError(t, err)
+	// case 2: get metric id err
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), fmt.Errorf("err"))
+	err = db.DeleteSeries("ns", "cpu", condition, finder)
+	assert.Error(t, err)
+	// case 3: get tag keys err
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAllTagKeys("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	err = db.DeleteSeries("ns", "cpu", condition, finder)
+	assert.Error(t, err)
+	// case 4: find series ids err
+	metadataDB.EXPECT().GetAllTagKeys("ns", "cpu").Return([]tag.Meta{{Key: "host", ID: 1}}, nil).AnyTimes()
+	finder.EXPECT().FindSeriesIDs(metadata, gomock.Any(), "ns", "cpu", condition).Return(nil, fmt.Errorf("err"))
+	err = db.DeleteSeries("ns", "cpu", condition, finder)
+	assert.Error(t, err)
+	// case 5: delete shard's series err
+	seriesIDs := roaring.BitmapOf(1, 2)
+	finder.EXPECT().FindSeriesIDs(metadata, gomock.Any(), "ns", "cpu", condition).
+		Return(map[int32]*roaring.Bitmap{1: seriesIDs}, nil).AnyTimes()
+	shard1.EXPECT().DeleteSeries(uint32(10), []uint32{1}, seriesIDs).Return(fmt.Errorf("err"))
+	err = db.DeleteSeries("ns", "cpu", condition, finder)
+	assert.Error(t, err)
+	// case 6: delete series
+	shard1.EXPECT().DeleteSeries(uint32(10), []uint32{1}, seriesIDs).Return(nil)
+	err = db.DeleteSeries("ns", "cpu", condition, finder)
+	assert.NoError(t, err)
+}
+
+func TestDatabase_GetCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	tagMetadata := metadb.NewMockTagMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadata.EXPECT().TagMetadata().Return(tagMetadata).AnyTimes()
+	db := &database{metadata: metadata}
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	shard1 := NewMockShard(ctrl)
+	shard1.EXPECT().ShardID().Return(int32(1)).AnyTimes()
+	shard1.EXPECT().ShardInfo().Return("shard-1").AnyTimes()
+	shard1.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	db.shards.Store(int32(1), shard1)
+	// case 1: metric not exist
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), constants.ErrNotFound)
+	_, err := db.GetCardinality("ns", "cpu")
+	assert.Equal(t, constants.ErrNotFound, err)
+	// case 2: get tag keys err
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAllTagKeys("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	_, err = db.GetCardinality("ns", "cpu")
+	assert.Error(t, err)
+	// case 3: get tag value ids err
+	metadataDB.EXPECT().GetAllTagKeys("ns", "cpu").Return([]tag.Meta{{Key: "host", ID: 1}}, nil).AnyTimes()
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(nil, fmt.Errorf("err"))
+	_, err = db.GetCardinality("ns", "cpu")
+	assert.Error(t, err)
+	// case 4: get series count err
+	tagMetadata.EXPECT().GetTagValueIDsForTag(uint32(1)).Return(roaring.BitmapOf(1, 2, 3), nil).AnyTimes()
+	indexDB.EXPECT().GetSeriesCount(uint32(10)).Return(uint32(0), fmt.Errorf("err"))
+	_, err = db.GetCardinality("ns", "cpu")
+	assert.Error(t, err)
+	// case 5: get cardinality
+	indexDB.EXPECT().GetSeriesCount(uint32(10)).Return(uint32(5), nil)
+	cardinality, err := db.GetCardinality("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, &MetricCardinality{
+		Namespace: "ns",
+		Metric:    "cpu",
+		Series:    map[int32]uint32{1: 5},
+		TagValues: map[string]uint64{"host": 3},
+	}, cardinality)
+}
@@ -21,9 +21,14 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
+
+	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
@@ -39,6 +44,11 @@ var (
 	newDatabaseFunc = newDatabase
 )
 
+var (
+	// can be modified in runtime
+	retentionCheckInterval = *atomic.NewDuration(time.Hour)
+)
+
 var engineLogger = logger.GetLogger("tsdb", "Engine")
 
 // Engine represents a time series engine
@@ -50,6 +60,8 @@ type Engine interface {
 	GetDatabase(databaseName string) (Database, bool)
 	// FLushDatabase produces a signal to workers for flushing memory database by name
 	FlushDatabase(ctx context.Context, databaseName string) bool
+	// DropDatabase closes the database, then removes all data of database, does nothing if database not exist
+	DropDatabase(databaseName string) error
 	// Close closes the cached time series databases
 	Close()
 
@@ -82,12 +94,18 @@ func newEngine(cfg config.TSDB) (*engine, error) {
 	if err := mkDirIfNotExist(cfg.Dir); err != nil {
 		return nil, fmt.Errorf("create time sereis storage path[%s] erorr: %s", cfg.Dir, err)
 	}
+	// set capacity of sst table reader cache shared by all kv stores
+	table.SetCacheOption(table.CacheOption{
+		MaxFiles:     cfg.MaxCachedTableFiles,
+		MaxIndexSize: cfg.MaxCachedTableIndexSize,
+	})
 	e := &engine{
 		cfg: cfg,
 	}
 	e.ctx, e.cancel = context.WithCancel(context.Background())
 	e.dataFlushChecker = newDataFlushChecker(e.ctx)
 	e.dataFlushChecker.Start()
+	go e.startRetentionCheck()
 
 	if err := e.load(); err != nil {
 		engineLogger.Error("load engine data error when create a new engine", logger.Error(err))
@@ -133,6 +151,9 @@ func (e *engine) Close() {
 	if e.dataFlushChecker != nil {
 		e.dataFlushChecker.Stop()
 	}
+	if e.cancel != nil {
+		e.cancel()
+	}
 
 	e.databases.Range(func(key, value interface{}) bool {
 		db := value.(Database)
@@ -157,6 +178,56 @@ func (e *engine) FlushDatabase(ctx context.Context, name string) bool {
 	return true
 }
 
+// DropDatabase closes the database, then removes all data of database, does nothing if database not exist
+func (e *engine) DropDatabase(databaseName string) error {
+	item, ok := e.databases.Load(databaseName)
+	if !ok {
+		return nil
+	}
+	e.databases.Delete(databaseName)
+	db := item.(Database)
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("close database[%s] error when drop database: %s", databaseName, err)
+	}
+	if err := removeDir(filepath.Join(e.cfg.Dir, databaseName)); err != nil {
+		return fmt.Errorf("remove database[%s]'s path error when drop database: %s", databaseName, err)
+	}
+	engineLogger.Info("drop database successfully", logger.String("db", databaseName))
+	return nil
+}
+
+// startRetentionCheck checks and evicts the expired data of each shard periodically
+func (e *engine) startRetentionCheck() {
+	timer := time.NewTimer(retentionCheckInterval.Load())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-timer.C:
+			e.evictExpiredData()
+			// reset check interval
+			timer.Reset(retentionCheckInterval.Load())
+		}
+	}
+}
+
+// evictExpiredData evicts the expired data of each shard based on data retention
+func (e *engine) evictExpiredData() {
+	GetShardManager().WalkEntry(func(shard Shard) {
+		reclaimed, err := shard.EvictExpiredData()
+		if err != nil {
+			engineLogger.Error("evict expired data error",
+				logger.String("shard", shard.ShardInfo()), logger.Error(err))
+		}
+		if reclaimed > 0 {
+			engineLogger.Info("evict expired data successfully",
+				logger.String("shard", shard.ShardInfo()), logger.Int64("reclaimed", reclaimed))
+		}
+	})
+}
+
 // load loads the time series engines if exist
 func (e *engine) load() error {
 	databaseNames, err := listDir(e.cfg.Dir)
@@ -164,6 +235,10 @@ func (e *engine) load() error {
 		return err
 	}
 	for _, databaseName := range databaseNames {
+		// skip the incomplete database which is restoring from backup
+		if strings.HasSuffix(databaseName, restoringSuffix) {
+			continue
+		}
 		_, err := e.CreateDatabase(databaseName)
 		if err != nil {
 			return err
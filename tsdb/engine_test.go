@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -183,3 +184,74 @@ func Test_Engine_Flush_Database(t *testing.T) {
 	ok = e.FlushDatabase(context.TODO(), "test_db_1")
 	assert.False(t, ok)
 }
+
+func TestEngine_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	e, _ := NewEngine(engineCfg)
+	engineImpl := e.(*engine)
+	defer engineImpl.cancel()
+	// case 1: database not exist
+	err := e.DropDatabase("test_db")
+	assert.NoError(t, err)
+
+	mockDatabase := NewMockDatabase(ctrl)
+	// case 2: close database err
+	mockDatabase.EXPECT().Close().Return(fmt.Errorf("err"))
+	engineImpl.databases.Store("test_db", mockDatabase)
+	err = e.DropDatabase("test_db")
+	assert.Error(t, err)
+	_, ok := e.GetDatabase("test_db")
+	assert.False(t, ok)
+	// case 3: remove database's path err
+	mockDatabase.EXPECT().Close().Return(nil).AnyTimes()
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	engineImpl.databases.Store("test_db", mockDatabase)
+	err = e.DropDatabase("test_db")
+	assert.Error(t, err)
+	// case 4: drop database
+	removeDir = fileutil.RemoveDir
+	engineImpl.databases.Store("test_db", mockDatabase)
+	err = e.DropDatabase("test_db")
+	assert.NoError(t, err)
+}
+
+func TestEngine_evictExpiredData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		retentionCheckInterval.Store(time.Hour)
+		ctrl.Finish()
+	}()
+	// use new shard manager, avoid walking the shards of other cases
+	shardManager := GetShardManager()
+	sManager = newShardManager()
+	defer func() {
+		sManager = shardManager
+	}()
+	retentionCheckInterval.Store(time.Millisecond * 10)
+	shard1 := NewMockShard(ctrl)
+	shard1.EXPECT().ShardInfo().Return("shard1").AnyTimes()
+	shard1.EXPECT().EvictExpiredData().Return(int64(100), nil).MinTimes(1)
+	shard2 := NewMockShard(ctrl)
+	shard2.EXPECT().ShardInfo().Return("shard2").AnyTimes()
+	shard2.EXPECT().EvictExpiredData().Return(int64(0), fmt.Errorf("err")).MinTimes(1)
+	GetShardManager().AddShard(shard1)
+	GetShardManager().AddShard(shard2)
+	e := &engine{}
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e.startRetentionCheck()
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	e.cancel()
+	<-done
+}
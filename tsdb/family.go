@@ -18,10 +18,13 @@
 package tsdb
 
 import (
+	"sync"
+
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/field"
@@ -44,6 +47,15 @@ type DataFamily interface {
 	TimeRange() timeutil.TimeRange
 	// Family returns the raw kv family
 	Family() kv.Family
+	// Retain retains the data family for reading/writing, the data family cannot be evicted before released,
+	// returns false if the data family is evicted.
+	Retain() bool
+	// Release releases the data family retained by Retain
+	Release()
+	// markEvicted marks the data family evicted if no one retains it, returns false if the data family is in use
+	markEvicted() bool
+	// unmarkEvicted cancels the eviction of data family
+	unmarkEvicted()
 
 	// flow.DataFilter filters data under data family based on query condition
 	flow.DataFilter
@@ -54,6 +66,10 @@ type dataFamily struct {
 	interval  timeutil.Interval
 	timeRange timeutil.TimeRange
 	family    kv.Family
+
+	refs    int  // number of in-flight users(query/flush)
+	evicted bool // evicted by data retention, cannot be retained
+	mutex   sync.Mutex
 }
 
 // newDataFamily creates a data family storage unit
@@ -84,6 +100,49 @@ func (f *dataFamily) Family() kv.Family {
 	return f.family
 }
 
+// Retain retains the data family for reading/writing, the data family cannot be evicted before released,
+// returns false if the data family is evicted.
+func (f *dataFamily) Retain() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.evicted {
+		return false
+	}
+	f.refs++
+	return true
+}
+
+// Release releases the data family retained by Retain
+func (f *dataFamily) Release() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.refs > 0 {
+		f.refs--
+	}
+}
+
+// markEvicted marks the data family evicted if no one retains it, returns false if the data family is in use
+func (f *dataFamily) markEvicted() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.refs > 0 {
+		return false
+	}
+	f.evicted = true
+	return true
+}
+
+// unmarkEvicted cancels the eviction of data family
+func (f *dataFamily) unmarkEvicted() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.evicted = false
+}
+
 // Filter filters the data based on metric/version/seriesIDs,
 // if finds data then returns the FilterResultSet, else returns nil
 func (f *dataFamily) Filter(metricID uint32,
@@ -104,11 +163,14 @@ func (f *dataFamily) Filter(metricID uint32,
 	}
 	var metricReaders []metricsdata.Reader
 	for _, reader := range readers {
-		value, ok := reader.Get(metricID)
+		value, err := reader.Get(metricID)
 		// metric data not found
-		if !ok {
+		if err == table.ErrKeyNotFound {
 			continue
 		}
+		if err != nil {
+			return nil, err
+		}
 		r, err := newReaderFunc(reader.Path(), value)
 		if err != nil {
 			return nil, err
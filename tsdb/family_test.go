@@ -46,6 +46,23 @@ func TestDataFamily_BaseTime(t *testing.T) {
 	assert.NotNil(t, dataFamily.Family())
 }
 
+func TestDataFamily_Retain(t *testing.T) {
+	dataFamily := newDataFamily(timeutil.Interval(timeutil.OneSecond*10), timeutil.TimeRange{}, nil)
+	assert.True(t, dataFamily.Retain())
+	assert.True(t, dataFamily.Retain())
+	// in use, cannot evict
+	assert.False(t, dataFamily.markEvicted())
+	dataFamily.Release()
+	assert.False(t, dataFamily.markEvicted())
+	dataFamily.Release()
+	dataFamily.Release()
+	// evicted, cannot retain
+	assert.True(t, dataFamily.markEvicted())
+	assert.False(t, dataFamily.Retain())
+	dataFamily.unmarkEvicted()
+	assert.True(t, dataFamily.Retain())
+}
+
 func TestDataFamily_Filter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -80,22 +97,28 @@ func TestDataFamily_Filter(t *testing.T) {
 	reader := table.NewMockReader(ctrl)
 	reader.EXPECT().Path().Return("test_path").AnyTimes()
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
-	reader.EXPECT().Get(gomock.Any()).Return(nil, false)
+	reader.EXPECT().Get(gomock.Any()).Return(nil, table.ErrKeyNotFound)
 	rs, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.NoError(t, err)
 	assert.Nil(t, rs)
+	// case 3: value of reader corrupted
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
+	reader.EXPECT().Get(gomock.Any()).Return(nil, fmt.Errorf("checksum mismatch"))
+	rs, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, rs)
 
-	// case 3: new metric reader err
+	// case 4: new metric reader err
 	newReaderFunc = func(file string, buf []byte) (reader metricsdata.Reader, err error) {
 		return nil, fmt.Errorf("err")
 	}
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
-	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, true)
+	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil)
 	rs, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.Error(t, err)
 	assert.Nil(t, rs)
 
-	// case 4: normal case
+	// case 5: normal case
 	newReaderFunc = func(file string, buf []byte) (reader metricsdata.Reader, err error) {
 		return nil, nil
 	}
@@ -104,7 +127,7 @@ func TestDataFamily_Filter(t *testing.T) {
 		return filter
 	}
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
-	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, true)
+	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil)
 	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, nil)
 	_, err = dataFamily.Filter(uint32(10), nil, timeutil.TimeRange{}, nil)
 	assert.NoError(t, err)
@@ -21,8 +21,10 @@ import (
 	"encoding/binary"
 	"io"
 	"path"
+	"path/filepath"
 	"time"
 
+	"github.com/lindb/roaring"
 	"go.etcd.io/bbolt"
 
 	"github.com/lindb/lindb/constants"
@@ -55,8 +57,16 @@ type IDMappingBackend interface {
 	loadMetricIDMapping(metricID uint32) (idMapping MetricIDMapping, err error)
 	// getSeriesID gets series id by metric id/tags hash, if not exist return constants.ErrNotFount
 	getSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, err error)
+	// loadSeriesHashes loads all series ids with tags hash under metric, key: series id, value: tags hash
+	loadSeriesHashes(metricID uint32) (map[uint32]uint64, error)
 	// saveMapping saves the id mapping event
 	saveMapping(event *mappingEvent) (err error)
+	// deleteMetric deletes the id mapping of metric
+	deleteMetric(metricID uint32) error
+	// deleteSeriesIDs deletes the mapping of series ids under metric, keeps the sequence for avoiding reusing series id
+	deleteSeriesIDs(metricID uint32, seriesIDs *roaring.Bitmap) error
+	// backup copies a consistent view of the backend storage into the path
+	backup(path string) error
 }
 
 // idMappingBackend implements IDMappingBackend interface
@@ -135,6 +145,30 @@ func (imb *idMappingBackend) getSeriesID(metricID uint32, tagsHash uint64) (seri
 	return
 }
 
+// loadSeriesHashes loads all series ids with tags hash under metric, key: series id, value: tags hash
+func (imb *idMappingBackend) loadSeriesHashes(metricID uint32) (map[uint32]uint64, error) {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	result := make(map[uint32]uint64)
+	err := imb.db.View(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(seriesBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return nil
+		}
+		cursor := metricBucket.Cursor()
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			if len(k) == 8 && len(v) == 4 {
+				result[binary.LittleEndian.Uint32(v)] = binary.LittleEndian.Uint64(k)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // saveMapping saves the id mapping event
 func (imb *idMappingBackend) saveMapping(event *mappingEvent) (err error) {
 	err = imb.db.Update(func(tx *bbolt.Tx) error {
@@ -171,6 +205,55 @@ func (imb *idMappingBackend) saveMapping(event *mappingEvent) (err error) {
 	return err
 }
 
+// deleteMetric deletes the id mapping of metric
+func (imb *idMappingBackend) deleteMetric(metricID uint32) error {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	return imb.db.Update(func(tx *bbolt.Tx) error {
+		root := tx.Bucket(seriesBucketName)
+		if root.Bucket(scratch[:]) == nil {
+			return nil
+		}
+		return root.DeleteBucket(scratch[:])
+	})
+}
+
+// deleteSeriesIDs deletes the mapping of series ids under metric, keeps the sequence for avoiding reusing series id
+func (imb *idMappingBackend) deleteSeriesIDs(metricID uint32, seriesIDs *roaring.Bitmap) error {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	return imb.db.Update(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(seriesBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return nil
+		}
+		// collect the tags hash of deleted series, because cannot delete key when iterating
+		var hashes [][]byte
+		cursor := metricBucket.Cursor()
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			if len(v) == 4 && seriesIDs.Contains(binary.LittleEndian.Uint32(v)) {
+				hashes = append(hashes, k)
+			}
+		}
+		for _, hash := range hashes {
+			if err := metricBucket.Delete(hash); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backup copies a consistent view of the backend storage into the path by read transaction
+func (imb *idMappingBackend) backup(path string) error {
+	if err := mkDir(path); err != nil {
+		return err
+	}
+	return imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(filepath.Join(path, MappingDB), 0600)
+	})
+}
+
 // Close closes the bbolt.DB
 func (imb *idMappingBackend) Close() error {
 	return imb.db.Close()
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 	"go.etcd.io/bbolt"
 
@@ -109,6 +110,13 @@ func TestIdMappingBackend_mapping(t *testing.T) {
 	assert.Equal(t, uint32(2), mapping.GetMetricID())
 	mapping1 := mapping.(*metricIDMapping)
 	assert.Equal(t, uint32(300), mapping1.idSequence.Load())
+	// case 6: load series hashes
+	hashes, err := backend.loadSeriesHashes(2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{100: 10, 300: 30, 50: 50}, hashes)
+	hashes, err = backend.loadSeriesHashes(30)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
 
 	err = backend.Close()
 	assert.NoError(t, err)
@@ -122,6 +130,43 @@ func TestIdMappingBackend_mapping(t *testing.T) {
 	assert.Equal(t, uint32(300), mapping1.idSequence.Load())
 }
 
+func TestIdMappingBackend_delete(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	backend, err := newIDMappingBackend(filepath.Join(testPath, "test"))
+	assert.NoError(t, err)
+	event := newMappingEvent()
+	event.addSeriesID(1, 20, 200)
+	event.addSeriesID(2, 10, 100)
+	event.addSeriesID(2, 30, 300)
+	err = backend.saveMapping(event)
+	assert.NoError(t, err)
+
+	// case 1: metric not exist
+	assert.NoError(t, backend.deleteSeriesIDs(10, roaring.BitmapOf(1)))
+	assert.NoError(t, backend.deleteMetric(10))
+	// case 2: delete series ids, keep sequence
+	assert.NoError(t, backend.deleteSeriesIDs(2, roaring.BitmapOf(300)))
+	_, err = backend.getSeriesID(2, 30)
+	assert.Equal(t, constants.ErrNotFound, err)
+	seriesID, err := backend.getSeriesID(2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(100), seriesID)
+	mapping, err := backend.loadMetricIDMapping(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(300), mapping.(*metricIDMapping).idSequence.Load())
+	hashes, err := backend.loadSeriesHashes(2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{100: 10}, hashes)
+	// case 3: delete metric
+	assert.NoError(t, backend.deleteMetric(1))
+	_, err = backend.getSeriesID(1, 20)
+	assert.Equal(t, constants.ErrNotFound, err)
+	err = backend.Close()
+	assert.NoError(t, err)
+}
+
 func TestIdMappingBackend_save_err(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
@@ -154,3 +199,32 @@ func TestIdMappingBackend_save_err(t *testing.T) {
 	err = backend.saveMapping(event)
 	assert.Error(t, err)
 }
+
+func TestIdMappingBackend_backup(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDir = fileutil.MkDirIfNotExist
+	}()
+	backend, err := newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	event := newMappingEvent()
+	event.addSeriesID(1, 10, 1)
+	assert.NoError(t, backend.saveMapping(event))
+	// case 1: create backup path err
+	mkDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	err = backend.backup(filepath.Join(testPath, "backup"))
+	assert.Error(t, err)
+	mkDir = fileutil.MkDirIfNotExist
+	// case 2: backup
+	err = backend.backup(filepath.Join(testPath, "backup"))
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Close())
+	backend, err = newIDMappingBackend(filepath.Join(testPath, "backup"))
+	assert.NoError(t, err)
+	seriesID, err := backend.getSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), seriesID)
+	assert.NoError(t, backend.Close())
+}
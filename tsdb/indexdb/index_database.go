@@ -30,6 +30,7 @@ import (
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
@@ -41,6 +42,7 @@ import (
 var (
 	createBackend   = newIDMappingBackend
 	createSeriesWAL = wal.NewSeriesWAL
+	copyDirFunc     = fileutil.CopyDir
 )
 
 var (
@@ -155,46 +157,27 @@ func (db *indexDatabase) GetGroupingContext(tagKeyIDs []uint32, seriesIDs *roari
 // GetOrCreateSeriesID gets series by tags hash, if not exist generate new series id in memory,
 // if generate a new series id returns isCreate is true
 // if generate fail return err
+// checkLimit(optional) is invoked with the number of series under metric before generating new series id,
+// so that check and generation are atomic under the lock, rejects the new series if it returns err.
 func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
+	checkLimit func(seriesCount uint32) error,
 ) (seriesID uint32, isCreated bool, err error) {
 	db.rwMutex.Lock()
 	defer db.rwMutex.Unlock()
 
-	metricIDMapping, ok := db.metricID2Mapping[metricID]
-	if ok {
-		// get series id from memory cache
-		seriesID, ok = metricIDMapping.GetSeriesID(tagsHash)
-		if ok {
-			return seriesID, false, nil
-		}
-	} else {
-		// metric mapping not exist, need load from backend storage
-		metricIDMapping, err = db.backend.loadMetricIDMapping(metricID)
-		if err != nil && err != constants.ErrNotFound {
-			return 0, false, err
-		}
-		// if metric id not exist in backend storage
-		if err == constants.ErrNotFound {
-			// create new metric id mapping with 0 sequence
-			metricIDMapping = newMetricIDMapping(metricID, 0)
-			// cache metric id mapping
-			db.metricID2Mapping[metricID] = metricIDMapping
-		} else {
-			// cache metric id mapping
-			db.metricID2Mapping[metricID] = metricIDMapping
-			// metric id mapping exist, try get series id from backend storage
-			seriesID, err = db.backend.getSeriesID(metricID, tagsHash)
-			if err == nil {
-				// cache load series id
-				metricIDMapping.AddSeriesID(tagsHash, seriesID)
-				return seriesID, false, nil
-			}
-		}
+	metricIDMapping, seriesID, err := db.getSeriesID(metricID, tagsHash)
+	if err == nil {
+		return seriesID, false, nil
 	}
 	// throw err in backend storage
-	if err != nil && err != constants.ErrNotFound {
+	if err != constants.ErrNotFound {
 		return 0, false, err
 	}
+	if checkLimit != nil {
+		if err = checkLimit(metricIDMapping.GetSeriesSequence()); err != nil {
+			return 0, false, err
+		}
+	}
 	// generate new series id
 	seriesID = metricIDMapping.GenSeriesID(tagsHash)
 
@@ -207,6 +190,81 @@ func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
 	return seriesID, true, nil
 }
 
+// GetSeriesCount returns the number of series ids generated under metric,
+// deleted series ids are also counted because series id will not be reused.
+func (db *indexDatabase) GetSeriesCount(metricID uint32) (uint32, error) {
+	db.rwMutex.RLock()
+	metricIDMapping, ok := db.metricID2Mapping[metricID]
+	db.rwMutex.RUnlock()
+	if ok {
+		return metricIDMapping.GetSeriesSequence(), nil
+	}
+	// metric mapping not exist in memory, load sequence from backend storage without caching
+	metricIDMapping, err := db.backend.loadMetricIDMapping(metricID)
+	if err == constants.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return metricIDMapping.GetSeriesSequence(), nil
+}
+
+// GetSeriesHashes returns the tags hash of series ids under metric, key: series id, value: tags hash
+func (db *indexDatabase) GetSeriesHashes(metricID uint32) (map[uint32]uint64, error) {
+	db.rwMutex.RLock()
+	defer db.rwMutex.RUnlock()
+
+	hashes, err := db.backend.loadSeriesHashes(metricID)
+	if err != nil {
+		return nil, err
+	}
+	// pending series in wal maybe not saved into backend storage, collects them from memory cache
+	if metricIDMapping, ok := db.metricID2Mapping[metricID]; ok {
+		metricIDMapping.CollectSeriesHashes(hashes)
+	}
+	return hashes, nil
+}
+
+// getSeriesID gets series id by tags hash from memory cache or backend storage,
+// returns the cached metric id mapping of metric, if series id not exist return constants.ErrNotFound.
+// NOTICE: must hold write lock, because metric id mapping maybe loaded and cached.
+func (db *indexDatabase) getSeriesID(metricID uint32, tagsHash uint64,
+) (metricIDMapping MetricIDMapping, seriesID uint32, err error) {
+	metricIDMapping, ok := db.metricID2Mapping[metricID]
+	if ok {
+		// get series id from memory cache
+		seriesID, ok = metricIDMapping.GetSeriesID(tagsHash)
+		if ok {
+			return metricIDMapping, seriesID, nil
+		}
+		return metricIDMapping, 0, constants.ErrNotFound
+	}
+	// metric mapping not exist, need load from backend storage
+	metricIDMapping, err = db.backend.loadMetricIDMapping(metricID)
+	if err != nil && err != constants.ErrNotFound {
+		return nil, 0, err
+	}
+	// if metric id not exist in backend storage
+	if err == constants.ErrNotFound {
+		// create new metric id mapping with 0 sequence
+		metricIDMapping = newMetricIDMapping(metricID, 0)
+		// cache metric id mapping
+		db.metricID2Mapping[metricID] = metricIDMapping
+		return metricIDMapping, 0, constants.ErrNotFound
+	}
+	// cache metric id mapping
+	db.metricID2Mapping[metricID] = metricIDMapping
+	// metric id mapping exist, try get series id from backend storage
+	seriesID, err = db.backend.getSeriesID(metricID, tagsHash)
+	if err != nil {
+		return metricIDMapping, 0, err
+	}
+	// cache load series id
+	metricIDMapping.AddSeriesID(tagsHash, seriesID)
+	return metricIDMapping, seriesID, nil
+}
+
 // GetSeriesIDsByTagValueIDs gets series ids by tag value ids for spec metric's tag key
 func (db *indexDatabase) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
 	return db.index.GetSeriesIDsByTagValueIDs(tagKeyID, tagValueIDs)
@@ -245,6 +303,42 @@ func (db *indexDatabase) BuildInvertIndex(namespace, metricName string, tags map
 	buildInvertedIndexCounter.WithLabelValues(db.metadata.DatabaseName()).Inc()
 }
 
+// DropMetric drops the series id mapping of metric
+func (db *indexDatabase) DropMetric(metricID uint32) error {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal saved into backend storage
+	db.seriesRecovery()
+	if db.seriesWAL.NeedRecovery() {
+		return ErrNeedRecoveryWAL
+	}
+	if err := db.backend.deleteMetric(metricID); err != nil {
+		return err
+	}
+	delete(db.metricID2Mapping, metricID)
+	return nil
+}
+
+// DeleteSeries deletes the series id mapping of given series ids under metric,
+// the deleted series ids will not be reused, new series with same tags will generate new series id.
+func (db *indexDatabase) DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) error {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal saved into backend storage, includes series id sequence
+	db.seriesRecovery()
+	if db.seriesWAL.NeedRecovery() {
+		return ErrNeedRecoveryWAL
+	}
+	if err := db.backend.deleteSeriesIDs(metricID, seriesIDs); err != nil {
+		return err
+	}
+	// remove memory cache, reloads metric id mapping from backend storage when write
+	delete(db.metricID2Mapping, metricID)
+	return nil
+}
+
 // Flush flushes index data to disk
 func (db *indexDatabase) Flush() error {
 	if err := db.seriesWAL.Sync(); err != nil {
@@ -271,6 +365,23 @@ func (db *indexDatabase) Close() error {
 	return db.index.Flush()
 }
 
+// Backup copies the series id mapping into the path, includes backend storage and pending series in wal,
+// the pending series in wal are recovered when opening the index database of backup.
+func (db *indexDatabase) Backup(path string) error {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	// make sure pending series in wal saved into backend storage as much as possible
+	db.seriesRecovery()
+	if err := db.seriesWAL.Sync(); err != nil {
+		return err
+	}
+	if err := db.backend.backup(path); err != nil {
+		return err
+	}
+	return copyDirFunc(filepath.Join(db.path, walPath), filepath.Join(path, walPath))
+}
+
 // checkSync checks if need sync pending series event in period
 func (db *indexDatabase) checkSync() {
 	ticker := time.NewTicker(time.Duration(db.syncInterval * 1000000))
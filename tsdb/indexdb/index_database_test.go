@@ -20,6 +20,7 @@ package indexdb
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series/tag"
@@ -153,7 +155,7 @@ func TestIndexDatabase_series_Recovery_err(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, db)
 	for i := 0; i < 11000; i++ {
-		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(i))
+		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(i), nil)
 		assert.NoError(t, err)
 		assert.True(t, isCreated)
 	}
@@ -177,7 +179,7 @@ func TestIndexDatabase_series_Recovery_err(t *testing.T) {
 	assert.NotNil(t, db)
 
 	for i := 0; i < 100; i++ {
-		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(1000000+i))
+		_, isCreated, err := db.GetOrCreateSeriesID(1, uint64(1000000+i), nil)
 		assert.NoError(t, err)
 		assert.True(t, isCreated)
 	}
@@ -206,17 +208,17 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
 	assert.NoError(t, err)
 	// case 1: generate new series id and create new metric id mapping
-	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 10)
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 10, nil)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(1), seriesID)
 	// case 2: get series id from memory
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 10)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 10, nil)
 	assert.NoError(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(1), seriesID)
 	// case 3: generate new series id from memory
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20, nil)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(2), seriesID)
@@ -228,12 +230,12 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
 	assert.NoError(t, err)
 	// case 4: get series id from backend
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20, nil)
 	assert.NoError(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(2), seriesID)
 	// case 5: gen series id, id sequence reset from backend
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30, nil)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(3), seriesID)
@@ -243,13 +245,13 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	oldWAL := db1.seriesWAL
 	db1.seriesWAL = mockSeriesWAl
 	mockSeriesWAl.EXPECT().Append(uint32(1), uint64(50), uint32(4)).Return(fmt.Errorf("err"))
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50, nil)
 	assert.Error(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(0), seriesID)
 	// add use series id => 4
 	db1.seriesWAL = oldWAL
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 50, nil)
 	assert.NoError(t, err)
 	assert.True(t, isCreated)
 	assert.Equal(t, uint32(4), seriesID)
@@ -259,6 +261,119 @@ func TestIndexDatabase_GetOrCreateSeriesID(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexDatabase_GetSeriesCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	// case 1: metric not exist
+	count, err := db.GetSeriesCount(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), count)
+	// case 2: get series count from memory
+	_, _, _ = db.GetOrCreateSeriesID(1, 10, nil)
+	_, _, _ = db.GetOrCreateSeriesID(1, 20, nil)
+	// pending series in memory cache
+	hashes, err := db.GetSeriesHashes(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{1: 10, 2: 20}, hashes)
+	count, err = db.GetSeriesCount(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), count)
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// reopen
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	// case 3: get series count from backend
+	count, err = db.GetSeriesCount(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), count)
+	// case 4: get series hashes from backend
+	hashes, err = db.GetSeriesHashes(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{1: 10, 2: 20}, hashes)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_GetOrCreateSeriesID_limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	var counts []uint32
+	checkLimit := func(seriesCount uint32) error {
+		counts = append(counts, seriesCount)
+		if seriesCount >= 2 {
+			return constants.ErrTooManySeries
+		}
+		return nil
+	}
+	// case 1: under limit
+	for _, tagsHash := range []uint64{10, 20} {
+		_, isCreated, err := db.GetOrCreateSeriesID(1, tagsHash, checkLimit)
+		assert.NoError(t, err)
+		assert.True(t, isCreated)
+	}
+	// case 2: existing series doesn't check limit
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 20, checkLimit)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+	assert.Equal(t, uint32(2), seriesID)
+	// case 3: exceed limit, not generate series id
+	_, isCreated, err = db.GetOrCreateSeriesID(1, 30, checkLimit)
+	assert.Equal(t, constants.ErrTooManySeries, err)
+	assert.False(t, isCreated)
+	assert.Equal(t, []uint32{0, 1, 2}, counts)
+	count, err := db.GetSeriesCount(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), count)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_GetSeriesCount_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		createBackend = newIDMappingBackend
+
+		ctrl.Finish()
+	}()
+
+	backend := NewMockIDMappingBackend(ctrl)
+	createBackend = func(parent string) (IDMappingBackend, error) {
+		return backend, nil
+	}
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	backend.EXPECT().loadMetricIDMapping(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	count, err := db.GetSeriesCount(1)
+	assert.Error(t, err)
+	assert.Equal(t, uint32(0), count)
+	backend.EXPECT().Close().Return(nil)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
 func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -281,7 +396,7 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	assert.NoError(t, err)
 	// case 1: load metric mapping err
 	backend.EXPECT().loadMetricIDMapping(uint32(1)).Return(nil, fmt.Errorf("err"))
-	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 30)
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 30, nil)
 	assert.Error(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(0), seriesID)
@@ -289,7 +404,7 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	// case 2: load series err
 	backend.EXPECT().loadMetricIDMapping(uint32(1)).Return(newMetricIDMapping(1, 0), nil)
 	backend.EXPECT().getSeriesID(uint32(1), uint64(30)).Return(uint32(0), fmt.Errorf("err"))
-	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30)
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 30, nil)
 	assert.Error(t, err)
 	assert.False(t, isCreated)
 	assert.Equal(t, uint32(0), seriesID)
@@ -401,6 +516,58 @@ func TestIndexDatabase_Close(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIndexDatabase_DeleteData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		createBackend = newIDMappingBackend
+		ctrl.Finish()
+	}()
+
+	backend := NewMockIDMappingBackend(ctrl)
+	createBackend = func(parent string) (IDMappingBackend, error) {
+		return backend, nil
+	}
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	db1 := db.(*indexDatabase)
+	mockSeriesWAL := wal.NewMockSeriesWAL(ctrl)
+	db1.seriesWAL = mockSeriesWAL
+	mockSeriesWAL.EXPECT().Recovery(gomock.Any(), gomock.Any()).AnyTimes()
+	// case 1: need recovery wal
+	mockSeriesWAL.EXPECT().NeedRecovery().Return(true).Times(2)
+	err = db.DropMetric(10)
+	assert.Equal(t, ErrNeedRecoveryWAL, err)
+	err = db.DeleteSeries(10, roaring.BitmapOf(1))
+	assert.Equal(t, ErrNeedRecoveryWAL, err)
+	// case 2: delete backend err
+	mockSeriesWAL.EXPECT().NeedRecovery().Return(false).AnyTimes()
+	backend.EXPECT().deleteMetric(uint32(10)).Return(fmt.Errorf("err"))
+	err = db.DropMetric(10)
+	assert.Error(t, err)
+	backend.EXPECT().deleteSeriesIDs(uint32(10), gomock.Any()).Return(fmt.Errorf("err"))
+	err = db.DeleteSeries(10, roaring.BitmapOf(1))
+	assert.Error(t, err)
+	backend.EXPECT().loadSeriesHashes(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, err = db.GetSeriesHashes(10)
+	assert.Error(t, err)
+	// case 3: delete data
+	db1.metricID2Mapping[10] = newMetricIDMapping(10, 10)
+	backend.EXPECT().deleteSeriesIDs(uint32(10), gomock.Any()).Return(nil)
+	err = db.DeleteSeries(10, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	_, ok := db1.metricID2Mapping[10]
+	assert.False(t, ok)
+	db1.metricID2Mapping[10] = newMetricIDMapping(10, 10)
+	backend.EXPECT().deleteMetric(uint32(10)).Return(nil)
+	err = db.DropMetric(10)
+	assert.NoError(t, err)
+	_, ok = db1.metricID2Mapping[10]
+	assert.False(t, ok)
+}
+
 func TestIndexDatabase_Flush(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -462,3 +629,81 @@ func TestIndexDatabase_checkSync(t *testing.T) {
 	err = db.Close()
 	assert.NoError(t, err)
 }
+
+func TestIndexDatabase_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backupPath := filepath.Join(testPath, "backup")
+	defer func() {
+		copyDirFunc = fileutil.CopyDir
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), filepath.Join(testPath, "source"), meta, nil, nil)
+	assert.NoError(t, err)
+	// pending series in wal
+	_, _, _ = db.GetOrCreateSeriesID(1, 10, nil)
+	_, _, _ = db.GetOrCreateSeriesID(1, 20, nil)
+	// case 1: copy wal err
+	copyDirFunc = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	err = db.Backup(backupPath)
+	assert.Error(t, err)
+	copyDirFunc = fileutil.CopyDir
+	_ = fileutil.RemoveDir(backupPath)
+	// case 2: backup
+	err = db.Backup(backupPath)
+	assert.NoError(t, err)
+	err = db.Close()
+	assert.NoError(t, err)
+	// case 3: open backup
+	db, err = NewIndexDatabase(context.TODO(), backupPath, meta, nil, nil)
+	assert.NoError(t, err)
+	hashes, err := db.GetSeriesHashes(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{1: 10, 2: 20}, hashes)
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 30, nil)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, uint32(3), seriesID)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_Backup_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		createBackend = newIDMappingBackend
+		ctrl.Finish()
+	}()
+
+	backend := NewMockIDMappingBackend(ctrl)
+	createBackend = func(parent string) (IDMappingBackend, error) {
+		return backend, nil
+	}
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	ctx, cancel := context.WithCancel(context.TODO())
+	// stop the wal check goroutine before finishing mock controller
+	defer cancel()
+	db, err := NewIndexDatabase(ctx, testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	db1 := db.(*indexDatabase)
+	mockSeriesWAL := wal.NewMockSeriesWAL(ctrl)
+	db1.seriesWAL = mockSeriesWAL
+	mockSeriesWAL.EXPECT().Recovery(gomock.Any(), gomock.Any()).AnyTimes()
+	mockSeriesWAL.EXPECT().NeedRecovery().Return(false).AnyTimes()
+	// case 1: sync wal err
+	mockSeriesWAL.EXPECT().Sync().Return(fmt.Errorf("err"))
+	err = db.Backup(testPath)
+	assert.Error(t, err)
+	// case 2: backup backend err
+	mockSeriesWAL.EXPECT().Sync().Return(nil)
+	backend.EXPECT().backup(testPath).Return(fmt.Errorf("err"))
+	err = db.Backup(testPath)
+	assert.Error(t, err)
+}
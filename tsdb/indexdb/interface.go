@@ -20,6 +20,8 @@ package indexdb
 import (
 	"io"
 
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/series"
 )
@@ -46,10 +48,25 @@ type IndexDatabase interface {
 	// GetOrCreateSeriesID gets series by tags hash, if not exist generate new series id in memory,
 	// if generate a new series id returns isCreate is true
 	// if generate fail return err
-	GetOrCreateSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, isCreated bool, err error)
+	// checkLimit(optional) is invoked with the number of series under metric before generating new series id,
+	// under the same lock of series id generation, rejects the new series if it returns err.
+	GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
+		checkLimit func(seriesCount uint32) error) (seriesID uint32, isCreated bool, err error)
+	// GetSeriesCount returns the number of series ids generated under metric,
+	// deleted series ids are also counted because series id will not be reused.
+	GetSeriesCount(metricID uint32) (uint32, error)
+	// GetSeriesHashes returns the tags hash of series ids under metric, key: series id, value: tags hash
+	GetSeriesHashes(metricID uint32) (map[uint32]uint64, error)
 	// BuildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as a empty key-value pair while tags is nil.
 	BuildInvertIndex(namespace, metricName string, tags map[string]string, seriesID uint32)
+	// DropMetric drops the series id mapping of metric
+	DropMetric(metricID uint32) error
+	// DeleteSeries deletes the series id mapping of given series ids under metric,
+	// the deleted series ids will not be reused, new series with same tags will generate new series id.
+	DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) error
 	// Flush flushes index data to disk
 	Flush() error
+	// Backup copies the series id mapping into the path, includes backend storage and pending series in wal
+	Backup(path string) error
 }
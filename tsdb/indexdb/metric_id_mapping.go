@@ -36,6 +36,10 @@ type MetricIDMapping interface {
 	RemoveSeriesID(tagsHash uint64)
 	// AddSeriesID adds the series id init cache
 	AddSeriesID(tagsHash uint64, seriesID uint32)
+	// GetSeriesSequence returns the current series id sequence, which is the number of generated series ids
+	GetSeriesSequence() uint32
+	// CollectSeriesHashes collects the cached series ids with tags hash into result, key: series id, value: tags hash
+	CollectSeriesHashes(result map[uint32]uint64)
 	// SetMaxSeriesIDsLimit sets the max series ids limit
 	SetMaxSeriesIDsLimit(limit uint32)
 	// GetMaxSeriesIDsLimit returns the max series ids limit
@@ -103,6 +107,18 @@ func (mim *metricIDMapping) RemoveSeriesID(tagsHash uint64) {
 	}
 }
 
+// GetSeriesSequence returns the current series id sequence, which is the number of generated series ids
+func (mim *metricIDMapping) GetSeriesSequence() uint32 {
+	return mim.idSequence.Load()
+}
+
+// CollectSeriesHashes collects the cached series ids with tags hash into result, key: series id, value: tags hash
+func (mim *metricIDMapping) CollectSeriesHashes(result map[uint32]uint64) {
+	for tagsHash, seriesID := range mim.hash2SeriesID {
+		result[seriesID] = tagsHash
+	}
+}
+
 // SetMaxSeriesIDsLimit sets the max series ids limit
 func (mim *metricIDMapping) SetMaxSeriesIDsLimit(limit uint32) {
 	mim.maxSeriesIDsLimit.Store(limit)
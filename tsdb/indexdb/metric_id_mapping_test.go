@@ -37,6 +37,7 @@ func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
 	assert.Equal(t, uint32(0), seriesID)
 	seriesID = idMapping.GenSeriesID(100)
 	assert.Equal(t, uint32(1), seriesID)
+	assert.Equal(t, uint32(1), idMapping.GetSeriesSequence())
 	// get exist series id
 	seriesID, ok = idMapping.GetSeriesID(100)
 	assert.Equal(t, uint32(1), seriesID)
@@ -47,6 +48,10 @@ func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
 	seriesID, ok = idMapping.GetSeriesID(300)
 	assert.Equal(t, uint32(4), seriesID)
 	assert.True(t, ok)
+
+	hashes := map[uint32]uint64{2: 200}
+	idMapping.CollectSeriesHashes(hashes)
+	assert.Equal(t, map[uint32]uint64{1: 100, 2: 200, 4: 300}, hashes)
 }
 
 func TestMetricIDMapping_SetMaxTagsLimit(t *testing.T) {
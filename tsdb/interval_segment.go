@@ -22,19 +22,34 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
+// for testing
+var (
+	removeDir = fileutil.RemoveDir
+)
+
 //go:generate mockgen -source=./interval_segment.go -destination=./interval_segment_mock.go -package=tsdb
 
 // IntervalSegment represents a interval segment, there are some segments in a shard.
 type IntervalSegment interface {
+	// Interval returns the interval of segment
+	Interval() timeutil.Interval
 	// GetOrCreateSegment creates new segment if not exist, if exist return it
 	GetOrCreateSegment(segmentName string) (Segment, error)
 	// getDataFamilies returns data family list by time range, return nil if not match
 	getDataFamilies(timeRange timeutil.TimeRange) []DataFamily
+	// EvictExpired removes the segments/data families which are before expire time,
+	// returns the reclaimed disk size
+	EvictExpired(expireTime int64) (reclaimed int64, err error)
 	// Close closes interval segment, release resource
 	Close()
+	// checkpoint creates the checkpoint of all segments under path(path/segment name)
+	checkpoint(path string) error
 }
 
 // intervalSegment implements IntervalSegment interface
@@ -42,14 +57,21 @@ type intervalSegment struct {
 	path     string
 	interval timeutil.Interval
 	segments sync.Map
+	// rollup target interval segment, nil if no rollup
+	rollupTarget IntervalSegment
+	// tombstone of metric data, compaction job purges the deleted data
+	tombstone kv.Tombstone
 
 	mutex sync.Mutex
 }
 
-// newIntervalSegment create interval segment based on interval/type/path etc.
+// newIntervalSegment create interval segment based on interval/type/path etc.,
+// if rollup target not nil, the data of this interval segment will be rolled up into target interval segment.
 func newIntervalSegment(
 	interval timeutil.Interval,
 	path string,
+	rollupTarget IntervalSegment,
+	tombstone kv.Tombstone,
 ) (
 	segment IntervalSegment,
 	err error,
@@ -58,8 +80,10 @@ func newIntervalSegment(
 		return segment, err
 	}
 	intervalSegment := &intervalSegment{
-		path:     path,
-		interval: interval,
+		path:         path,
+		interval:     interval,
+		rollupTarget: rollupTarget,
+		tombstone:    tombstone,
 	}
 
 	defer func() {
@@ -76,7 +100,7 @@ func newIntervalSegment(
 		return segment, err
 	}
 	for _, segmentName := range segmentNames {
-		seg, err := newSegment(segmentName, intervalSegment.interval, filepath.Join(path, segmentName))
+		seg, err := newSegment(segmentName, intervalSegment.interval, filepath.Join(path, segmentName), rollupTarget, tombstone)
 		if err != nil {
 			err = fmt.Errorf("create segmenet error: %s", err)
 			return segment, err
@@ -89,6 +113,11 @@ func newIntervalSegment(
 	return segment, err
 }
 
+// Interval returns the interval of segment
+func (s *intervalSegment) Interval() timeutil.Interval {
+	return s.interval
+}
+
 // GetOrCreateSegment creates new segment if not exist, if exist return it
 func (s *intervalSegment) GetOrCreateSegment(segmentName string) (Segment, error) {
 	segment, ok := s.getSegment(segmentName)
@@ -98,7 +127,7 @@ func (s *intervalSegment) GetOrCreateSegment(segmentName string) (Segment, error
 		defer s.mutex.Unlock()
 		segment, ok = s.getSegment(segmentName)
 		if !ok {
-			seg, err := newSegment(segmentName, s.interval, filepath.Join(s.path, segmentName))
+			seg, err := newSegment(segmentName, s.interval, filepath.Join(s.path, segmentName), s.rollupTarget, s.tombstone)
 			if err != nil {
 				return nil, fmt.Errorf("create segmenet error: %s", err)
 			}
@@ -134,6 +163,58 @@ func (s *intervalSegment) getDataFamilies(timeRange timeutil.TimeRange) []DataFa
 	return result
 }
 
+// EvictExpired removes the segments/data families which are before expire time,
+// returns the reclaimed disk size
+func (s *intervalSegment) EvictExpired(expireTime int64) (reclaimed int64, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expireSegmentTime := s.interval.Calculator().CalcSegmentTime(expireTime)
+	var segments []string
+	s.segments.Range(func(k, v interface{}) bool {
+		segments = append(segments, k.(string))
+		return true
+	})
+	for _, segmentName := range segments {
+		segment, ok := s.getSegment(segmentName)
+		if !ok {
+			continue
+		}
+		baseTime := segment.BaseTime()
+		switch {
+		case baseTime < expireSegmentTime:
+			// all data of segment expired, remove whole segment
+			segmentPath := filepath.Join(s.path, segmentName)
+			if !segment.markEvicted() {
+				// data family of segment is used by query/flush, evicts it next time
+				engineLogger.Info("skip evicting segment in use", logger.String("segment", segmentPath))
+				continue
+			}
+			size, err := dirSize(segmentPath)
+			if err != nil {
+				engineLogger.Warn("get segment disk size error",
+					logger.String("segment", segmentPath), logger.Error(err))
+			}
+			segment.Close()
+			s.segments.Delete(segmentName)
+			if err := removeDir(segmentPath); err != nil {
+				return reclaimed, fmt.Errorf("remove expired segment[%s] error: %s", segmentPath, err)
+			}
+			reclaimed += size
+			engineLogger.Info("evict expired segment",
+				logger.String("segment", segmentPath), logger.Int64("size", size))
+		case baseTime == expireSegmentTime:
+			// part of data families expired
+			size, err := segment.EvictFamilies(expireTime)
+			reclaimed += size
+			if err != nil {
+				return reclaimed, err
+			}
+		}
+	}
+	return reclaimed, nil
+}
+
 // Close closes interval segment, release resource
 func (s *intervalSegment) Close() {
 	s.segments.Range(func(k, v interface{}) bool {
@@ -145,6 +226,21 @@ func (s *intervalSegment) Close() {
 	})
 }
 
+// checkpoint creates the checkpoint of all segments under path(path/segment name)
+func (s *intervalSegment) checkpoint(path string) error {
+	var err error
+	s.segments.Range(func(k, v interface{}) bool {
+		seg, ok := v.(Segment)
+		if ok {
+			if err = seg.checkpoint(filepath.Join(path, k.(string))); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	return err
+}
+
 // getSegment returns segment by name
 func (s *intervalSegment) getSegment(segmentName string) (Segment, bool) {
 	segment, _ := s.segments.Load(segmentName)
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/fileutil"
@@ -38,7 +39,7 @@ func TestIntervalSegment_New(t *testing.T) {
 	mkDirIfNotExist = func(path string) error {
 		return fmt.Errorf("err")
 	}
-	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 	mkDirIfNotExist = fileutil.MkDirIfNotExist
@@ -47,13 +48,13 @@ func TestIntervalSegment_New(t *testing.T) {
 	listDir = func(path string) (strings []string, err error) {
 		return nil, fmt.Errorf("err")
 	}
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 	listDir = fileutil.ListDir
 
 	// case 3: create segment success
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	assert.True(t, fileutil.Exist(segPath))
@@ -63,11 +64,12 @@ func TestIntervalSegment_New(t *testing.T) {
 	s1, err := newSegment(
 		"20190903",
 		timeutil.Interval(timeutil.OneSecond*10),
-		filepath.Join(segPath, "20190903"))
+		filepath.Join(segPath, "20190903"),
+		nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s1)
 	// case 5: cannot re-open kv-store
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.Nil(t, s)
 	assert.Error(t, err)
 }
@@ -76,7 +78,7 @@ func TestIntervalSegment_GetOrCreateSegment(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	seg, err := s.GetOrCreateSegment("20190702")
 	assert.Nil(t, err)
 	assert.NotNil(t, seg)
@@ -95,7 +97,7 @@ func TestIntervalSegment_GetOrCreateSegment(t *testing.T) {
 
 	s.Close()
 
-	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 
 	s1, ok := s.(*intervalSegment)
 	if ok {
@@ -112,7 +114,7 @@ func TestIntervalSegment_getDataFamilies(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	segment1, _ := s.GetOrCreateSegment("20190902")
 	now, _ := timeutil.ParseTimestamp("20190902 19:10:48", "20060102 15:04:05")
 	_, _ = segment1.GetDataFamily(now)
@@ -156,3 +158,98 @@ func TestIntervalSegment_getDataFamilies(t *testing.T) {
 	segments = s.getDataFamilies(timeutil.TimeRange{Start: start, End: end})
 	assert.Equal(t, 1, len(segments))
 }
+
+func TestIntervalSegment_EvictExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		dirSize = fileutil.DirSize
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
+	for _, segmentName := range []string{"20190901", "20190902", "20190903"} {
+		seg, _ := s.GetOrCreateSegment(segmentName)
+		now, _ := timeutil.ParseTimestamp(segmentName+" 10:10:48", "20060102 15:04:05")
+		_, _ = seg.GetDataFamily(now)
+		now, _ = timeutil.ParseTimestamp(segmentName+" 20:10:48", "20060102 15:04:05")
+		_, _ = seg.GetDataFamily(now)
+	}
+	// case 1: evict expired segment and data families
+	expireTime, _ := timeutil.ParseTimestamp("20190902 12:00:00", "20060102 15:04:05")
+	reclaimed, err := s.EvictExpired(expireTime)
+	assert.NoError(t, err)
+	assert.True(t, reclaimed > 0)
+	assert.False(t, fileutil.Exist(filepath.Join(segPath, "20190901")))
+	assert.False(t, fileutil.Exist(filepath.Join(segPath, "20190902", "10")))
+	assert.True(t, fileutil.Exist(filepath.Join(segPath, "20190902", "20")))
+	assert.True(t, fileutil.Exist(filepath.Join(segPath, "20190903")))
+	start, _ := timeutil.ParseTimestamp("20190901 00:00:00", "20060102 15:04:05")
+	end, _ := timeutil.ParseTimestamp("20190903 23:00:00", "20060102 15:04:05")
+	assert.Len(t, s.getDataFamilies(timeutil.TimeRange{Start: start, End: end}), 3)
+	// case 2: remove segment dir err
+	dirSize = func(path string) (int64, error) {
+		return 0, fmt.Errorf("err")
+	}
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	expireTime, _ = timeutil.ParseTimestamp("20190903 12:00:00", "20060102 15:04:05")
+	_, err = s.EvictExpired(expireTime)
+	assert.Error(t, err)
+	removeDir = fileutil.RemoveDir
+	// case 3: evict data family err
+	seg := NewMockSegment(ctrl)
+	s1 := s.(*intervalSegment)
+	s1.segments.Store("20190903", seg)
+	seg.EXPECT().BaseTime().Return(expireTime - 12*timeutil.OneHour)
+	seg.EXPECT().EvictFamilies(expireTime).Return(int64(0), fmt.Errorf("err"))
+	_, err = s.EvictExpired(expireTime)
+	assert.Error(t, err)
+	seg.EXPECT().Close()
+	s.Close()
+}
+
+func TestIntervalSegment_EvictExpired_open_snapshot(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
+	defer s.Close()
+	seg, _ := s.GetOrCreateSegment("20190901")
+	now, _ := timeutil.ParseTimestamp("20190901 10:10:48", "20060102 15:04:05")
+	family, err := seg.GetDataFamily(now)
+	assert.NoError(t, err)
+	assert.True(t, family.Retain())
+	snapshot := family.Family().GetSnapshot()
+	// segment in use, skip it
+	expireTime, _ := timeutil.ParseTimestamp("20190902 12:00:00", "20060102 15:04:05")
+	reclaimed, err := s.EvictExpired(expireTime)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), reclaimed)
+	assert.True(t, fileutil.Exist(filepath.Join(segPath, "20190901")))
+	_, err = snapshot.FindReaders(10)
+	assert.NoError(t, err)
+	// evict segment after snapshot closed
+	snapshot.Close()
+	family.Release()
+	_, err = s.EvictExpired(expireTime)
+	assert.NoError(t, err)
+	assert.False(t, fileutil.Exist(filepath.Join(segPath, "20190901")))
+}
+
+func TestIntervalSegment_checkpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
+	_, _ = s.GetOrCreateSegment("20190901")
+	path := filepath.Join(testPath, "checkpoint")
+	assert.NoError(t, s.checkpoint(path))
+	assert.True(t, fileutil.Exist(filepath.Join(path, "20190901", "CURRENT")))
+	// checkpoint exist
+	assert.Error(t, s.checkpoint(path))
+	s.Close()
+}
@@ -33,6 +33,10 @@ func getFieldType(f *pb.Field) field.Type {
 		return field.MinField
 	case pb.FieldType_Gauge:
 		return field.GaugeField
+	case pb.FieldType_Histogram:
+		return field.HistogramField
+	case pb.FieldType_Summary:
+		return field.SummaryField
 	default:
 		return field.Unknown
 	}
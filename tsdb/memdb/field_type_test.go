@@ -32,4 +32,6 @@ func TestFieldType_getFieldType(t *testing.T) {
 	assert.Equal(t, field.MinField, getFieldType(&pb.Field{Type: pb.FieldType_Min}))
 	assert.Equal(t, field.MaxField, getFieldType(&pb.Field{Type: pb.FieldType_Max}))
 	assert.Equal(t, field.GaugeField, getFieldType(&pb.Field{Type: pb.FieldType_Gauge}))
+	assert.Equal(t, field.HistogramField, getFieldType(&pb.Field{Type: pb.FieldType_Histogram}))
+	assert.Equal(t, field.SummaryField, getFieldType(&pb.Field{Type: pb.FieldType_Summary}))
 }
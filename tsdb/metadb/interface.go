@@ -77,6 +77,13 @@ type MetadataDatabase interface {
 
 	// SuggestNamespace suggests the namespace by namespace's prefix
 	SuggestNamespace(prefix string, limit int) (namespaces []string, err error)
+	// GetMetricCount returns the number of metrics under namespace, includes the pending metrics in memory
+	GetMetricCount(namespace string) (count int, err error)
+	// DropMetric drops the metric metadata by namespace/metric name, returns the metric id and tag keys of dropped metric,
+	// if not exist return series.ErrNotFound
+	DropMetric(namespace, metricName string) (metricID uint32, tags []tag.Meta, err error)
 	// Sync syncs the pending metadata update event
 	Sync() error
+	// Backup copies the metadata into the path, includes backend storage and pending metadata in wal
+	Backup(path string) error
 }
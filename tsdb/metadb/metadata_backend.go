@@ -61,6 +61,8 @@ type MetadataBackend interface {
 	suggestNamespace(prefix string, limit int) (namespaces []string, err error)
 	// suggestMetricName suggests the metric name by name's prefix
 	suggestMetricName(namespace, prefix string, limit int) (metricNames []string, err error)
+	// countMetrics returns the number of metrics under namespace
+	countMetrics(namespace string) (count int, err error)
 
 	// genMetricID generates the metric id in the memory
 	genMetricID() uint32
@@ -90,9 +92,13 @@ type MetadataBackend interface {
 
 	// saveMetadata saves the pending metadata include namespace/metric metadata
 	saveMetadata(event *metadataUpdateEvent) error
+	// dropMetric drops the metric metadata include metric name/fields/tag keys
+	dropMetric(namespace, metricName string, metricID uint32) error
 
 	// sync syncs bbolt.DB file data
 	sync() error
+	// backup copies a consistent view of the backend storage into the path
+	backup(path string) error
 }
 
 // metadataBackend implements the MetadataBackend interface
@@ -185,6 +191,19 @@ func (mb *metadataBackend) suggestMetricName(namespace, prefix string, limit int
 	return
 }
 
+// countMetrics returns the number of metrics under namespace
+func (mb *metadataBackend) countMetrics(namespace string) (count int, err error) {
+	err = mb.db.View(func(tx *bbolt.Tx) error {
+		nsBucket := tx.Bucket(nsBucketName).Bucket([]byte(namespace))
+		if nsBucket == nil {
+			return nil
+		}
+		count = nsBucket.Stats().KeyN
+		return nil
+	})
+	return
+}
+
 // genMetricID generates the metric id in the memory
 func (mb *metadataBackend) genMetricID() uint32 {
 	return mb.metricIDSequence.Inc()
@@ -348,11 +367,41 @@ func (mb *metadataBackend) saveMetadata(event *metadataUpdateEvent) (err error)
 	return
 }
 
+// dropMetric drops the metric metadata include metric name/fields/tag keys,
+// metric id sequence is kept, so dropped metric id will not be reused.
+func (mb *metadataBackend) dropMetric(namespace, metricName string, metricID uint32) error {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	return mb.db.Update(func(tx *bbolt.Tx) error {
+		nsBucket := tx.Bucket(nsBucketName).Bucket([]byte(namespace))
+		if nsBucket != nil {
+			if err := nsBucket.Delete([]byte(metricName)); err != nil {
+				return err
+			}
+		}
+		metricRootBucket := tx.Bucket(metricBucketName)
+		if metricRootBucket.Bucket(scratch[:]) == nil {
+			return nil
+		}
+		return metricRootBucket.DeleteBucket(scratch[:])
+	})
+}
+
 // sync syncs the bbolt.DB file data
 func (mb *metadataBackend) sync() error {
 	return mb.db.Sync()
 }
 
+// backup copies a consistent view of the backend storage into the path by read transaction
+func (mb *metadataBackend) backup(parent string) error {
+	if err := mkDir(parent); err != nil {
+		return err
+	}
+	return mb.db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(path.Join(parent, MetaDB), 0600)
+	})
+}
+
 // Close closes the bbolt.DB
 func (mb *metadataBackend) Close() error {
 	return mb.db.Close()
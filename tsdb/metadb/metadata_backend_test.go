@@ -221,6 +221,29 @@ func TestMetadataBackend_getAllTagKeys(t *testing.T) {
 	assert.Equal(t, []tag.Meta{{Key: "tagKey-2", ID: 4}, {Key: "tagKey-3", ID: 3}}, values)
 }
 
+func TestMetadataBackend_dropMetric(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	db := mockMetadataBackend(t)
+	// case 1: metric not exist
+	err := db.dropMetric("ns-10", "name10", 88)
+	assert.NoError(t, err)
+	// case 2: drop metric
+	err = db.dropMetric("ns-1", "name2", 2)
+	assert.NoError(t, err)
+	_, err = db.getMetricID("ns-1", "name2")
+	assert.Equal(t, constants.ErrNotFound, err)
+	_, err = db.getAllTagKeys(2)
+	assert.Equal(t, constants.ErrNotFound, err)
+	// other metric not changed
+	metricID, err := db.getMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), metricID)
+	// metric id not reused
+	assert.Equal(t, uint32(5), db.genMetricID())
+}
+
 func TestMetadataBackend_getField(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
@@ -409,3 +432,32 @@ func mockMetadataEvent() *metadataUpdateEvent {
 
 	return e
 }
+
+func TestMetadataBackend_backup(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDir = fileutil.MkDirIfNotExist
+	}()
+	backend, err := newMetadataBackend(testPath)
+	assert.NoError(t, err)
+	e := newMetadataUpdateEvent()
+	e.addMetric("ns", "cpu", 1)
+	assert.NoError(t, backend.saveMetadata(e))
+	// case 1: create backup path err
+	mkDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	err = backend.backup(filepath.Join(testPath, "backup"))
+	assert.Error(t, err)
+	mkDir = fileutil.MkDirIfNotExist
+	// case 2: backup
+	err = backend.backup(filepath.Join(testPath, "backup"))
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Close())
+	backend, err = newMetadataBackend(filepath.Join(testPath, "backup"))
+	assert.NoError(t, err)
+	metricID, err := backend.getMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), metricID)
+	assert.NoError(t, backend.Close())
+}
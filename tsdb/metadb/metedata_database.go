@@ -29,6 +29,7 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
@@ -41,6 +42,7 @@ import (
 var (
 	createMetadataBackend = newMetadataBackend
 	createMetaWAL         = wal.NewMetricMetaWAL
+	copyDirFunc           = fileutil.CopyDir
 )
 
 var (
@@ -100,6 +102,7 @@ type metadataDatabase struct {
 	cancel       context.CancelFunc
 	backend      MetadataBackend
 	metrics      map[string]MetricMetadata // metadata cache(key: namespace + metric-name, value: metric metadata)
+	metricCounts map[string]int            // number of metrics cache(key: namespace, value: number of metrics)
 
 	metaWAL wal.MetricMetaWAL
 
@@ -137,6 +140,7 @@ func NewMetadataDatabase(ctx context.Context, databaseName, parent string) (Meta
 		cancel:       cancel,
 		backend:      backend,
 		metrics:      make(map[string]MetricMetadata),
+		metricCounts: make(map[string]int),
 		metaWAL:      metaWAL,
 		syncInterval: syncInterval,
 	}
@@ -162,6 +166,35 @@ func (mdb *metadataDatabase) SuggestMetricName(namespace, prefix string, limit i
 	return mdb.backend.suggestMetricName(namespace, prefix, limit)
 }
 
+// GetMetricCount returns the number of metrics under namespace, includes the pending metrics in memory
+func (mdb *metadataDatabase) GetMetricCount(namespace string) (count int, err error) {
+	mdb.rwMux.RLock()
+	count, ok := mdb.metricCounts[namespace]
+	mdb.rwMux.RUnlock()
+	if ok {
+		return count, nil
+	}
+
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+	return mdb.loadMetricCount(namespace)
+}
+
+// loadMetricCount loads the number of metrics under namespace from backend storage if not exist in memory,
+// NOTICE: must add write lock
+func (mdb *metadataDatabase) loadMetricCount(namespace string) (count int, err error) {
+	count, ok := mdb.metricCounts[namespace]
+	if ok {
+		return count, nil
+	}
+	count, err = mdb.backend.countMetrics(namespace)
+	if err != nil {
+		return 0, err
+	}
+	mdb.metricCounts[namespace] = count
+	return count, nil
+}
+
 // GetMetricID gets the metric id by namespace and metric name, if not exist return constants.ErrNotFound
 func (mdb *metadataDatabase) GetMetricID(namespace, metricName string) (metricID uint32, err error) {
 	mdb.rwMux.RLock()
@@ -294,6 +327,12 @@ func (mdb *metadataDatabase) GenMetricID(namespace, metricName string) (metricID
 	if err != constants.ErrNotFound {
 		return
 	}
+	// load the number of metrics under namespace before assigning new metric id,
+	// because new metric only saves into wal, backend storage doesn't include it.
+	metricCount, err := mdb.loadMetricCount(namespace)
+	if err != nil {
+		return 0, err
+	}
 	// assign new metric id
 	metricID = mdb.backend.genMetricID()
 
@@ -305,6 +344,7 @@ func (mdb *metadataDatabase) GenMetricID(namespace, metricName string) (metricID
 	}
 
 	mdb.metrics[key] = newMetricMetadata(metricID, 0)
+	mdb.metricCounts[namespace] = metricCount + 1
 
 	genMetricIDCounter.WithLabelValues(mdb.databaseName).Inc()
 
@@ -386,6 +426,37 @@ func (mdb *metadataDatabase) GenTagKeyID(namespace, metricName, tagKey string) (
 	return
 }
 
+// DropMetric drops the metric metadata by namespace/metric name, returns the metric id and tag keys of dropped metric,
+// if not exist return constants.ErrNotFound
+func (mdb *metadataDatabase) DropMetric(namespace, metricName string) (metricID uint32, tags []tag.Meta, err error) {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	// make sure pending metadata in wal saved into backend storage
+	mdb.metaRecovery()
+	if mdb.metaWAL.NeedRecovery() {
+		return 0, nil, ErrNeedRecoveryWAL
+	}
+	metricID, err = mdb.backend.getMetricID(namespace, metricName)
+	if err != nil {
+		return 0, nil, err
+	}
+	tags, err = mdb.backend.getAllTagKeys(metricID)
+	// metric maybe has no tag keys
+	if err != nil && err != constants.ErrNotFound {
+		return 0, nil, err
+	}
+	if err = mdb.backend.dropMetric(namespace, metricName, metricID); err != nil {
+		return 0, nil, err
+	}
+	// remove metric metadata from memory cache
+	delete(mdb.metrics, namespace+metricName)
+	if count, ok := mdb.metricCounts[namespace]; ok && count > 0 {
+		mdb.metricCounts[namespace] = count - 1
+	}
+	return metricID, tags, nil
+}
+
 // Sync syncs the bbolt.DB's data file and metadata write ahead log
 func (mdb *metadataDatabase) Sync() error {
 	if err := mdb.metaWAL.Sync(); err != nil {
@@ -395,6 +466,23 @@ func (mdb *metadataDatabase) Sync() error {
 	return nil
 }
 
+// Backup copies the metadata into the path, includes backend storage and pending metadata in wal,
+// the pending metadata in wal are recovered when opening the metadata database of backup.
+func (mdb *metadataDatabase) Backup(path string) error {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	// make sure pending metadata in wal saved into backend storage as much as possible
+	mdb.metaRecovery()
+	if err := mdb.metaWAL.Sync(); err != nil {
+		return err
+	}
+	if err := mdb.backend.backup(path); err != nil {
+		return err
+	}
+	return copyDirFunc(filepath.Join(mdb.path, walPath), filepath.Join(path, walPath))
+}
+
 // Close closes the resources
 func (mdb *metadataDatabase) Close() error {
 	mdb.cancel()
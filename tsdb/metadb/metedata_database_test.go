@@ -20,6 +20,7 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -131,6 +132,70 @@ func TestMetadataDatabase_SuggestMetricName(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_GetMetricCount(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	// case 1: namespace not exist
+	count, err := db.GetMetricCount("ns-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	// case 2: count pending metrics in memory
+	_, _ = db.GenMetricID("ns-1", "name1")
+	_, _ = db.GenMetricID("ns-1", "name2")
+	_, _ = db.GenMetricID("ns-1", "name2")
+	_, _ = db.GenMetricID("ns-2", "name1")
+	count, err = db.GetMetricCount("ns-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	err = db.Close()
+	assert.NoError(t, err)
+	// case 3: load count from backend
+	db, err = NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	count, err = db.GetMetricCount("ns-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = db.GetMetricCount("ns-2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	// case 4: drop metric
+	_, _, err = db.DropMetric("ns-1", "name2")
+	assert.NoError(t, err)
+	count, err = db.GetMetricCount("ns-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestMetadataDatabase_GetMetricCount_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	mockBackend.EXPECT().countMetrics("ns-1").Return(0, fmt.Errorf("err")).Times(2)
+	_, err = db.GetMetricCount("ns-1")
+	assert.Error(t, err)
+	mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(nil, constants.ErrNotFound)
+	_, err = db.GenMetricID("ns-1", "name1")
+	assert.Error(t, err)
+
+	mockBackend.EXPECT().Close().Return(nil)
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_GetMetricID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -147,6 +212,7 @@ func TestMetadataDatabase_GetMetricID(t *testing.T) {
 	assert.NoError(t, err)
 	gomock.InOrder(
 		mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(nil, constants.ErrNotFound),
+		mockBackend.EXPECT().countMetrics("ns-1").Return(0, nil),
 		mockBackend.EXPECT().genMetricID().Return(uint32(1)),
 	)
 	metricID, err := db.GenMetricID("ns-1", "name1")
@@ -167,6 +233,45 @@ func TestMetadataDatabase_GetMetricID(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_DropMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	// case 1: metric not exist
+	mockBackend.EXPECT().getMetricID("ns-1", "name1").Return(uint32(0), constants.ErrNotFound)
+	_, _, err = db.DropMetric("ns-1", "name1")
+	assert.Equal(t, constants.ErrNotFound, err)
+	// case 2: get tag keys err
+	mockBackend.EXPECT().getMetricID("ns-1", "name1").Return(uint32(10), nil).AnyTimes()
+	mockBackend.EXPECT().getAllTagKeys(uint32(10)).Return(nil, fmt.Errorf("err"))
+	_, _, err = db.DropMetric("ns-1", "name1")
+	assert.Error(t, err)
+	// case 3: drop metric err
+	mockBackend.EXPECT().getAllTagKeys(uint32(10)).Return([]tag.Meta{{Key: "host", ID: 1}}, nil).AnyTimes()
+	mockBackend.EXPECT().dropMetric("ns-1", "name1", uint32(10)).Return(fmt.Errorf("err"))
+	_, _, err = db.DropMetric("ns-1", "name1")
+	assert.Error(t, err)
+	// case 4: drop metric
+	mockBackend.EXPECT().dropMetric("ns-1", "name1", uint32(10)).Return(nil)
+	metricID, tags, err := db.DropMetric("ns-1", "name1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), metricID)
+	assert.Equal(t, []tag.Meta{{Key: "host", ID: 1}}, tags)
+
+	mockBackend.EXPECT().Close().Return(nil)
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_GetTagKey(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -355,6 +460,7 @@ func TestMetadataDatabase_GenMetricID(t *testing.T) {
 	assert.NoError(t, err)
 	gomock.InOrder(
 		mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(nil, constants.ErrNotFound),
+		mockBackend.EXPECT().countMetrics("ns-1").Return(0, nil),
 		mockBackend.EXPECT().genMetricID().Return(uint32(1)),
 	)
 	// case 1: gen new metric id
@@ -835,3 +941,75 @@ func newMockMetadataDatabase(t *testing.T) MetadataDatabase {
 
 	return db
 }
+
+func TestMetadataDatabase_Backup(t *testing.T) {
+	backupPath := filepath.Join(testPath, "backup")
+	defer func() {
+		copyDirFunc = fileutil.CopyDir
+		_ = fileutil.RemoveDir(testPath)
+	}()
+
+	db, err := NewMetadataDatabase(context.TODO(), "test", filepath.Join(testPath, "source"))
+	assert.NoError(t, err)
+	// pending metadata in wal
+	metricID, err := db.GenMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	fieldID, err := db.GenFieldID("ns", "cpu", "f", field.SumField)
+	assert.NoError(t, err)
+	// case 1: copy wal err
+	copyDirFunc = func(src, dst string) error {
+		return fmt.Errorf("err")
+	}
+	err = db.Backup(backupPath)
+	assert.Error(t, err)
+	copyDirFunc = fileutil.CopyDir
+	_ = fileutil.RemoveDir(backupPath)
+	// case 2: backup
+	err = db.Backup(backupPath)
+	assert.NoError(t, err)
+	err = db.Close()
+	assert.NoError(t, err)
+	// case 3: open backup
+	db, err = NewMetadataDatabase(context.TODO(), "test", backupPath)
+	assert.NoError(t, err)
+	metricID2, err := db.GetMetricID("ns", "cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, metricID, metricID2)
+	f, err := db.GetField("ns", "cpu", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, fieldID, f.ID)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestMetadataDatabase_Backup_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	db1 := db.(*metadataDatabase)
+	mockWAL := wal.NewMockMetricMetaWAL(ctrl)
+	mockBackend := NewMockMetadataBackend(ctrl)
+	oldWAL := db1.metaWAL
+	oldBackend := db1.backend
+	db1.metaWAL = mockWAL
+	db1.backend = mockBackend
+	mockWAL.EXPECT().Recovery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	// case 1: sync wal err
+	mockWAL.EXPECT().Sync().Return(fmt.Errorf("err"))
+	err = db.Backup(testPath)
+	assert.Error(t, err)
+	// case 2: backup backend err
+	mockWAL.EXPECT().Sync().Return(nil)
+	mockBackend.EXPECT().backup(testPath).Return(fmt.Errorf("err"))
+	err = db.Backup(testPath)
+	assert.Error(t, err)
+	db1.metaWAL = oldWAL
+	db1.backend = oldBackend
+	err = db.Close()
+	assert.NoError(t, err)
+}
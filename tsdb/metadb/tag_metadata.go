@@ -58,6 +58,8 @@ func init() {
 type TagMetadata interface {
 	// GenTagValueID generates the tag value id for spec tag key
 	GenTagValueID(tagKeyID uint32, tagValue string) (uint32, error)
+	// GetTagValueID gets the tag value id for spec tag key, if not exist return constants.ErrNotFound
+	GetTagValueID(tagKeyID uint32, tagValue string) (uint32, error)
 	// SuggestTagValues returns suggestions from given tag key id and prefix of tag value
 	SuggestTagValues(tagKeyID uint32, tagValuePrefix string, limit int) []string
 	// FindTagValueDsByExpr finds tag value ids by tag filter expr for spec tag key,
@@ -167,6 +169,33 @@ func (m *tagMetadata) GenTagValueID(tagKeyID uint32, tagValue string) (tagValueI
 	return tagValueID, nil
 }
 
+// GetTagValueID gets the tag value id for spec tag key, if not exist return constants.ErrNotFound
+func (m *tagMetadata) GetTagValueID(tagKeyID uint32, tagValue string) (tagValueID uint32, err error) {
+	// get tag value id from memory with read lock
+	m.rwMutex.RLock()
+	tagValueID, ok := m.getTagValueIDInMem(tagKeyID, tagValue)
+	m.rwMutex.RUnlock()
+	if ok {
+		return tagValueID, nil
+	}
+	// try load tag value id from kv store
+	found := false
+	err = m.loadTagValueIDsInKV(tagKeyID, func(reader tagkeymeta.Reader) error {
+		tagValueID, err = reader.GetTagValueID(tagKeyID, tagValue)
+		if err == nil {
+			found = true
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, constants.ErrNotFound
+	}
+	return tagValueID, nil
+}
+
 // SuggestTagValues returns suggestions from given tag key id and prefix of tag value
 func (m *tagMetadata) SuggestTagValues(tagKeyID uint32, tagValuePrefix string, limit int) []string {
 	result := make([]string, 0)
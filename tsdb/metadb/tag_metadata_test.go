@@ -102,6 +102,45 @@ func TestTagMetadata_GenTagValueID(t *testing.T) {
 	assert.Equal(t, uint32(22), tagValueID)
 }
 
+func TestTagMetadata_GetTagValueID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagReaderFunc = tagkeymeta.NewReader
+		ctrl.Finish()
+	}()
+
+	meta, _, snapshot := mockTagMetadata(ctrl)
+
+	tagReader := tagkeymeta.NewMockReader(ctrl)
+	newTagReaderFunc = func(readers []table.Reader) tagkeymeta.Reader {
+		return tagReader
+	}
+
+	// case 1: tag value not exist
+	snapshot.EXPECT().FindReaders(uint32(1)).Return(nil, nil).Times(2)
+	_, err := meta.GetTagValueID(1, "tag-value-1")
+	assert.Equal(t, constants.ErrNotFound, err)
+	// case 2: get tag value id from mem
+	_, _ = meta.GenTagValueID(1, "tag-value-1")
+	tagValueID, err := meta.GetTagValueID(1, "tag-value-1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), tagValueID)
+	// case 3: get kv readers err
+	snapshot.EXPECT().FindReaders(uint32(1)).Return(nil, fmt.Errorf("err"))
+	_, err = meta.GetTagValueID(1, "tag-value-err")
+	assert.Error(t, err)
+	// case 4: get tag value from kv store
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{table.NewMockReader(ctrl)}, nil).AnyTimes()
+	tagReader.EXPECT().GetTagValueID(uint32(1), "tag-value-2").Return(uint32(2), nil)
+	tagValueID, err = meta.GetTagValueID(1, "tag-value-2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), tagValueID)
+	// case 5: tag value not exist in kv store
+	tagReader.EXPECT().GetTagValueID(uint32(1), "tag-value-3").Return(uint32(0), constants.ErrNotFound)
+	_, err = meta.GetTagValueID(1, "tag-value-3")
+	assert.Equal(t, constants.ErrNotFound, err)
+}
+
 func TestTagMetadata_SuggestTagValues(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
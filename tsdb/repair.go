@@ -0,0 +1,703 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/cespare/xxhash"
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+// emptyTagsHash represents the tags hash of series without tags
+var emptyTagsHash = xxhash.Sum64String(tag.Concat(nil))
+
+// SeriesDigest represents the order-independent digest of series set, series is identified by tags hash,
+// because the series id of same series is different between replicas.
+type SeriesDigest struct {
+	Count        int    `json:"count"`
+	Checksum     uint64 `json:"checksum"`               // sum of tags hash
+	DataChecksum uint64 `json:"dataChecksum,omitempty"` // sum of series data checksum, only for data family
+}
+
+// add adds the series into digest
+func (d *SeriesDigest) add(tagsHash uint64) {
+	d.Count++
+	d.Checksum += tagsHash
+}
+
+// addData adds the series with the data points into digest
+func (d *SeriesDigest) addData(tagsHash uint64, points map[uint16]*pb.Metric) {
+	d.add(tagsHash)
+	var buf [16]byte
+	binary.LittleEndian.PutUint64(buf[:8], tagsHash)
+	binary.LittleEndian.PutUint64(buf[8:], pointsChecksum(points))
+	d.DataChecksum += xxhash.Sum64(buf[:])
+}
+
+// SeriesSlots represents the time slots which have data of series in data family
+type SeriesSlots struct {
+	TagsHash uint64   `json:"tagsHash"`
+	Slots    []uint16 `json:"slots"`
+}
+
+// MetricDigest represents the series digest of metric
+type MetricDigest struct {
+	Namespace  string       `json:"namespace"`
+	MetricName string       `json:"metricName"`
+	Series     SeriesDigest `json:"series"`
+}
+
+// FamilyDigest represents the series digest of each metric in data family
+type FamilyDigest struct {
+	FamilyTime int64          `json:"familyTime"`
+	Metrics    []MetricDigest `json:"metrics"`
+}
+
+// ShardDigest represents the digest of index(series id mapping) and persisted data families of shard,
+// it's used for comparing the replicas of shard.
+type ShardDigest struct {
+	Index    []MetricDigest `json:"index"`
+	Families []FamilyDigest `json:"families"`
+}
+
+// metricKey represents the unique key of metric between replicas
+type metricKey struct {
+	namespace  string
+	metricName string
+}
+
+// Diff returns the metric digests of peer which are different from current digest(includes not exist),
+// the metrics which only exist in current digest are ignored, because the lagging peer repairs itself.
+func (d *ShardDigest) Diff(peer *ShardDigest) (index []MetricDigest, families []FamilyDigest) {
+	index = diffMetrics(d.Index, peer.Index)
+	localFamilies := make(map[int64][]MetricDigest)
+	for _, family := range d.Families {
+		localFamilies[family.FamilyTime] = family.Metrics
+	}
+	for _, family := range peer.Families {
+		metrics := diffMetrics(localFamilies[family.FamilyTime], family.Metrics)
+		if len(metrics) > 0 {
+			families = append(families, FamilyDigest{FamilyTime: family.FamilyTime, Metrics: metrics})
+		}
+	}
+	return
+}
+
+// diffMetrics returns the metric digests of peer which are different from local
+func diffMetrics(local, peer []MetricDigest) (result []MetricDigest) {
+	localMetrics := make(map[metricKey]SeriesDigest)
+	for _, metric := range local {
+		localMetrics[metricKey{namespace: metric.Namespace, metricName: metric.MetricName}] = metric.Series
+	}
+	for _, metric := range peer {
+		digest, ok := localMetrics[metricKey{namespace: metric.Namespace, metricName: metric.MetricName}]
+		if !ok || digest != metric.Series {
+			result = append(result, metric)
+		}
+	}
+	return
+}
+
+// Digest returns the digest of index and persisted data families,
+// the data families which have memory database are skipped, because the data in memory isn't persisted.
+func (s *shard) Digest() (*ShardDigest, error) {
+	metrics, err := s.loadMetricKeys()
+	if err != nil {
+		return nil, err
+	}
+	metricIDs := make([]uint32, 0, len(metrics))
+	for metricID := range metrics {
+		metricIDs = append(metricIDs, metricID)
+	}
+	sort.Slice(metricIDs, func(i, j int) bool {
+		return metricIDs[i] < metricIDs[j]
+	})
+	seriesHashes := make(map[uint32]map[uint32]uint64)
+	getSeriesHashes := func(metricID uint32) (map[uint32]uint64, error) {
+		hashes, ok := seriesHashes[metricID]
+		if ok {
+			return hashes, nil
+		}
+		hashes, err := s.indexDB.GetSeriesHashes(metricID)
+		if err != nil {
+			return nil, err
+		}
+		seriesHashes[metricID] = hashes
+		return hashes, nil
+	}
+
+	digest := &ShardDigest{}
+	for _, metricID := range metricIDs {
+		hashes, err := getSeriesHashes(metricID)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 {
+			continue
+		}
+		metricDigest := newMetricDigest(metrics[metricID])
+		for _, tagsHash := range hashes {
+			metricDigest.Series.add(tagsHash)
+		}
+		digest.Index = append(digest.Index, metricDigest)
+	}
+	for _, family := range s.persistedFamilies() {
+		familyDigest, err := s.familyDigest(family, metricIDs, metrics, getSeriesHashes)
+		if err != nil {
+			return nil, err
+		}
+		if len(familyDigest.Metrics) > 0 {
+			digest.Families = append(digest.Families, familyDigest)
+		}
+	}
+	return digest, nil
+}
+
+// familyDigest returns the series digest of each metric in data family
+func (s *shard) familyDigest(family DataFamily,
+	metricIDs []uint32, metrics map[uint32]metricKey,
+	getSeriesHashes func(metricID uint32) (map[uint32]uint64, error),
+) (FamilyDigest, error) {
+	familyDigest := FamilyDigest{FamilyTime: family.TimeRange().Start}
+	snapshot := family.Family().GetSnapshot()
+	defer snapshot.Close()
+
+	metricReaders, err := loadMetricReaders(snapshot)
+	if err != nil {
+		return familyDigest, err
+	}
+	for _, metricID := range metricIDs {
+		readers, ok := metricReaders[metricID]
+		if !ok {
+			continue
+		}
+		hashes, err := getSeriesHashes(metricID)
+		if err != nil {
+			return familyDigest, err
+		}
+		key := metrics[metricID]
+		fields, err := s.getFields(key.namespace, key.metricName)
+		if err != nil {
+			return familyDigest, err
+		}
+		seriesIDs := s.seriesIDsOfReaders(metricID, readers)
+		points := loadSeriesPoints(readers, seriesIDs, fields)
+		metricDigest := newMetricDigest(key)
+		it := seriesIDs.Iterator()
+		for it.HasNext() {
+			seriesID := it.Next()
+			if tagsHash, ok := getTagsHash(hashes, seriesID); ok {
+				metricDigest.Series.addData(tagsHash, points[seriesID])
+			}
+		}
+		if metricDigest.Series.Count > 0 {
+			familyDigest.Metrics = append(familyDigest.Metrics, metricDigest)
+		}
+	}
+	return familyDigest, nil
+}
+
+// SeriesSlots returns the time slots which have data of series under metric in persisted data family
+func (s *shard) SeriesSlots(familyTime int64, namespace, metricName string) ([]SeriesSlots, error) {
+	var result []SeriesSlots
+	err := s.walkFamilySeries(familyTime, namespace, metricName,
+		func(metricID uint32, readers []metricsdata.Reader, seriesIDs *roaring.Bitmap, hashes map[uint32]uint64) error {
+			fields, err := s.getFields(namespace, metricName)
+			if err != nil {
+				return err
+			}
+			points := loadSeriesPoints(readers, seriesIDs, fields)
+			it := seriesIDs.Iterator()
+			for it.HasNext() {
+				seriesID := it.Next()
+				tagsHash, ok := getTagsHash(hashes, seriesID)
+				if !ok {
+					continue
+				}
+				seriesSlots := SeriesSlots{TagsHash: tagsHash}
+				for slot := range points[seriesID] {
+					seriesSlots.Slots = append(seriesSlots.Slots, slot)
+				}
+				sort.Slice(seriesSlots.Slots, func(i, j int) bool {
+					return seriesSlots.Slots[i] < seriesSlots.Slots[j]
+				})
+				result = append(result, seriesSlots)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportSeries exports the data points of series under metric in persisted data family,
+// excludes the data points which time slot of series exists in excludes, so exports the points of series
+// which are missing or divergent in the lagging replica, the tags of series are rebuilt from inverted index.
+// NOTE: the points which exist in both replicas with different values cannot be repaired by backfill,
+// because backfill aggregates the values with existing data.
+func (s *shard) ExportSeries(familyTime int64, namespace, metricName string, excludes []SeriesSlots) ([]*pb.Metric, error) {
+	excluded := make(map[uint64]map[uint16]struct{}, len(excludes))
+	for _, seriesSlots := range excludes {
+		slots := make(map[uint16]struct{}, len(seriesSlots.Slots))
+		for _, slot := range seriesSlots.Slots {
+			slots[slot] = struct{}{}
+		}
+		excluded[seriesSlots.TagsHash] = slots
+	}
+	var result []*pb.Metric
+	err := s.walkFamilySeries(familyTime, namespace, metricName,
+		func(metricID uint32, readers []metricsdata.Reader, seriesIDs *roaring.Bitmap, hashes map[uint32]uint64) error {
+			fields, err := s.getFields(namespace, metricName)
+			if err != nil {
+				return err
+			}
+			points := loadSeriesPoints(readers, seriesIDs, fields)
+			exportPoints := make(map[uint32]map[uint16]*pb.Metric)
+			exportSeriesIDs := roaring.New()
+			it := seriesIDs.Iterator()
+			for it.HasNext() {
+				seriesID := it.Next()
+				tagsHash, ok := getTagsHash(hashes, seriesID)
+				if !ok {
+					continue
+				}
+				slots := excluded[tagsHash]
+				for slot, point := range points[seriesID] {
+					if _, ok := slots[slot]; ok {
+						continue
+					}
+					seriesPoints, ok := exportPoints[seriesID]
+					if !ok {
+						seriesPoints = make(map[uint16]*pb.Metric)
+						exportPoints[seriesID] = seriesPoints
+						exportSeriesIDs.Add(seriesID)
+					}
+					seriesPoints[slot] = point
+				}
+			}
+			if exportSeriesIDs.IsEmpty() {
+				return nil
+			}
+			seriesTags, err := s.seriesTags(namespace, metricName, exportSeriesIDs)
+			if err != nil {
+				return err
+			}
+			exporter := &seriesExporter{
+				namespace:  namespace,
+				metricName: metricName,
+				familyTime: familyTime,
+				interval:   s.interval.Int64(),
+				hashes:     hashes,
+				tags:       seriesTags,
+			}
+			result = exporter.export(exportSeriesIDs, exportPoints)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getFields returns the field metas of metric, key: field id
+func (s *shard) getFields(namespace, metricName string) (map[field.ID]field.Meta, error) {
+	fields, err := s.metadata.MetadataDatabase().GetAllFields(namespace, metricName)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[field.ID]field.Meta, len(fields))
+	for _, f := range fields {
+		result[f.ID] = f
+	}
+	return result, nil
+}
+
+// walkFamilySeries finds the series of metric in persisted data family, then invokes fn with the series ids
+// which are not deleted and the series id mapping, does nothing if family/metric not exist.
+func (s *shard) walkFamilySeries(familyTime int64, namespace, metricName string,
+	fn func(metricID uint32, readers []metricsdata.Reader, seriesIDs *roaring.Bitmap, hashes map[uint32]uint64) error,
+) error {
+	var family DataFamily
+	for _, f := range s.persistedFamilies() {
+		if f.TimeRange().Start == familyTime {
+			family = f
+			break
+		}
+	}
+	if family == nil {
+		return nil
+	}
+	metricID, err := s.metadata.MetadataDatabase().GetMetricID(namespace, metricName)
+	if err == constants.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	snapshot := family.Family().GetSnapshot()
+	defer snapshot.Close()
+
+	tableReaders, err := snapshot.FindReaders(metricID)
+	if err != nil {
+		return err
+	}
+	var readers []metricsdata.Reader
+	for _, reader := range tableReaders {
+		value, err := reader.Get(metricID)
+		if err == table.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		r, err := newReaderFunc(reader.Path(), value)
+		if err != nil {
+			return err
+		}
+		readers = append(readers, r)
+	}
+	if len(readers) == 0 {
+		return nil
+	}
+	hashes, err := s.indexDB.GetSeriesHashes(metricID)
+	if err != nil {
+		return err
+	}
+	return fn(metricID, readers, s.seriesIDsOfReaders(metricID, readers), hashes)
+}
+
+// persistedFamilies returns the data families of writing interval which have no memory database
+func (s *shard) persistedFamilies() []DataFamily {
+	families := s.segment.getDataFamilies(timeutil.TimeRange{Start: 0, End: timeutil.Now()})
+	s.rwMutex.RLock()
+	result := make([]DataFamily, 0, len(families))
+	for _, family := range families {
+		if _, ok := s.families[family.TimeRange().Start]; !ok {
+			result = append(result, family)
+		}
+	}
+	s.rwMutex.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TimeRange().Start < result[j].TimeRange().Start
+	})
+	return result
+}
+
+// loadMetricKeys returns the namespace/name of each metric id in metadata
+func (s *shard) loadMetricKeys() (map[uint32]metricKey, error) {
+	metadataDB := s.metadata.MetadataDatabase()
+	namespaces, err := metadataDB.SuggestNamespace("", math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint32]metricKey)
+	for _, namespace := range namespaces {
+		metricNames, err := metadataDB.SuggestMetrics(namespace, "", math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+		for _, metricName := range metricNames {
+			metricID, err := metadataDB.GetMetricID(namespace, metricName)
+			if err == constants.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			result[metricID] = metricKey{namespace: namespace, metricName: metricName}
+		}
+	}
+	return result, nil
+}
+
+// seriesIDsOfReaders returns the series ids of metric in sst files, excludes the deleted series ids
+func (s *shard) seriesIDsOfReaders(metricID uint32, readers []metricsdata.Reader) *roaring.Bitmap {
+	seriesIDs := roaring.New()
+	for _, reader := range readers {
+		seriesIDs.Or(reader.GetSeriesIDs())
+	}
+	if deleted := s.DeletedSeriesIDs(metricID); deleted != nil {
+		seriesIDs.AndNot(deleted)
+	}
+	return seriesIDs
+}
+
+// seriesTags rebuilds the tags of series from inverted index, key: series id, value: tags
+func (s *shard) seriesTags(namespace, metricName string, seriesIDs *roaring.Bitmap) (map[uint32]map[string]string, error) {
+	tagKeys, err := s.metadata.MetadataDatabase().GetAllTagKeys(namespace, metricName)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint32]map[string]string)
+	highKeys := seriesIDs.GetHighKeys()
+	for _, tagKey := range tagKeys {
+		// groups series ids by tag key one by one, because series maybe hasn't all tag keys of metric
+		grouping, err := s.indexDB.GetGroupingContext([]uint32{tagKey.ID}, seriesIDs)
+		if err != nil {
+			return nil, err
+		}
+		tagValueIDs := roaring.New()
+		seriesOfTagValue := make(map[uint32][]uint32)
+		for idx, highKey := range highKeys {
+			groups := grouping.BuildGroup(highKey, seriesIDs.GetContainerAtIndex(idx))
+			for key, lowSeriesIDs := range groups {
+				tagValueID := binary.LittleEndian.Uint32([]byte(key))
+				tagValueIDs.Add(tagValueID)
+				for _, lowSeriesID := range lowSeriesIDs {
+					seriesOfTagValue[tagValueID] = append(seriesOfTagValue[tagValueID],
+						encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID))
+				}
+			}
+		}
+		tagValues := make(map[uint32]string)
+		if err := s.metadata.TagMetadata().CollectTagValues(tagKey.ID, tagValueIDs, tagValues); err != nil {
+			return nil, err
+		}
+		for tagValueID, ids := range seriesOfTagValue {
+			tagValue, ok := tagValues[tagValueID]
+			if !ok {
+				continue
+			}
+			for _, seriesID := range ids {
+				tags, ok := result[seriesID]
+				if !ok {
+					tags = make(map[string]string)
+					result[seriesID] = tags
+				}
+				tags[tagKey.Key] = tagValue
+			}
+		}
+	}
+	return result, nil
+}
+
+// seriesExporter exports the data points of series with the tags rebuilt from inverted index
+type seriesExporter struct {
+	namespace  string
+	metricName string
+	familyTime int64
+	interval   int64
+	hashes     map[uint32]uint64
+	tags       map[uint32]map[string]string
+}
+
+// export fills the series info(tags, timestamp etc.) of data points, returns the points of series which tags rebuilt
+func (e *seriesExporter) export(seriesIDs *roaring.Bitmap, points map[uint32]map[uint16]*pb.Metric) []*pb.Metric {
+	var result []*pb.Metric
+	it := seriesIDs.Iterator()
+	for it.HasNext() {
+		seriesID := it.Next()
+		tags, ok := e.rebuildTags(seriesID)
+		if !ok {
+			continue
+		}
+		tagsHash := e.hashes[seriesID]
+		if seriesID == constants.SeriesIDWithoutTags {
+			tagsHash = emptyTagsHash
+		}
+		for slot, point := range points[seriesID] {
+			point.Namespace = e.namespace
+			point.Name = e.metricName
+			point.Timestamp = e.familyTime + int64(slot)*e.interval
+			point.Tags = tags
+			point.TagsHash = tagsHash
+			result = append(result, point)
+		}
+	}
+	return result
+}
+
+// rebuildTags returns the tags of series, returns false if the tags cannot be rebuilt completely
+func (e *seriesExporter) rebuildTags(seriesID uint32) (map[string]string, bool) {
+	if seriesID == constants.SeriesIDWithoutTags {
+		return nil, true
+	}
+	tags := e.tags[seriesID]
+	// checks the rebuilt tags by tags hash, because the tag value maybe lost(e.g. tag value not flushed)
+	return tags, len(tags) > 0 && xxhash.Sum64String(tag.Concat(tags)) == e.hashes[seriesID]
+}
+
+// loadSeriesPoints loads the data points of series ids from sst files, key: series id => time slot,
+// the data point only has fields, the values of same series/slot/field in multi sst files are aggregated.
+func loadSeriesPoints(readers []metricsdata.Reader, seriesIDs *roaring.Bitmap,
+	fields map[field.ID]field.Meta,
+) map[uint32]map[uint16]*pb.Metric {
+	result := make(map[uint32]map[uint16]*pb.Metric)
+	decoder := encoding.GetTSDDecoder()
+	defer encoding.ReleaseTSDDecoder(decoder)
+
+	for _, reader := range readers {
+		fieldMetas := reader.GetFields()
+		slotRange := reader.GetTimeRange()
+		highKeys := seriesIDs.GetHighKeys()
+		for idx, highKey := range highKeys {
+			container := seriesIDs.GetContainerAtIndex(idx)
+			loader := reader.Load(highKey, container, fieldMetas)
+			if loader == nil {
+				continue
+			}
+			it := container.PeekableIterator()
+			for it.HasNext() {
+				lowSeriesID := it.Next()
+				seriesID := encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID)
+				for fieldIdx, fieldData := range loader.Load(lowSeriesID) {
+					if len(fieldData) == 0 {
+						continue
+					}
+					fieldMeta, ok := fields[fieldMetas[fieldIdx].ID]
+					if !ok {
+						continue
+					}
+					fieldType := getPBFieldType(fieldMeta.Type)
+					if fieldType == pb.FieldType_UNKNOWN {
+						continue
+					}
+					decoder.ResetWithTimeRange(fieldData, slotRange.Start, slotRange.End)
+					for slot := int(slotRange.Start); slot <= int(slotRange.End); slot++ {
+						if !decoder.HasValueWithSlot(uint16(slot)) {
+							continue
+						}
+						point := getOrCreatePoint(result, seriesID, uint16(slot))
+						addField(point, fieldMeta, fieldType, math.Float64frombits(decoder.Value()))
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// getOrCreatePoint returns the data point of series at time slot
+func getOrCreatePoint(points map[uint32]map[uint16]*pb.Metric, seriesID uint32, slot uint16) *pb.Metric {
+	seriesPoints, ok := points[seriesID]
+	if !ok {
+		seriesPoints = make(map[uint16]*pb.Metric)
+		points[seriesID] = seriesPoints
+	}
+	point, ok := seriesPoints[slot]
+	if !ok {
+		point = &pb.Metric{}
+		seriesPoints[slot] = point
+	}
+	return point
+}
+
+// pointsChecksum returns the order-independent checksum of data points(time slot, field name and value)
+func pointsChecksum(points map[uint16]*pb.Metric) uint64 {
+	var checksum uint64
+	var head [10]byte
+	var buf []byte
+	for slot, point := range points {
+		binary.LittleEndian.PutUint16(head[:2], slot)
+		for _, f := range point.Fields {
+			binary.LittleEndian.PutUint64(head[2:], math.Float64bits(f.Value))
+			buf = append(append(buf[:0], head[:]...), f.Name...)
+			checksum += xxhash.Sum64(buf)
+		}
+	}
+	return checksum
+}
+
+// addField adds the field value into data point, aggregates the value if field exists,
+// because the same series maybe exist in multi sst files before compaction.
+func addField(point *pb.Metric, fieldMeta field.Meta, fieldType pb.FieldType, value float64) {
+	for _, f := range point.Fields {
+		if f.Name == string(fieldMeta.Name) {
+			if aggFunc := fieldMeta.Type.GetAggFunc(); aggFunc != nil {
+				f.Value = aggFunc.Aggregate(f.Value, value)
+			} else {
+				f.Value = value
+			}
+			return
+		}
+	}
+	point.Fields = append(point.Fields, &pb.Field{
+		Name:  string(fieldMeta.Name),
+		Type:  fieldType,
+		Value: value,
+	})
+}
+
+// newMetricDigest creates the metric digest by metric key
+func newMetricDigest(key metricKey) MetricDigest {
+	return MetricDigest{Namespace: key.namespace, MetricName: key.metricName}
+}
+
+// loadMetricReaders loads the metric readers of each metric id in data family snapshot
+func loadMetricReaders(snapshot version.Snapshot) (map[uint32][]metricsdata.Reader, error) {
+	result := make(map[uint32][]metricsdata.Reader)
+	for _, file := range snapshot.GetCurrent().GetAllFiles() {
+		reader, err := snapshot.GetReader(file.GetFileNumber())
+		if err != nil {
+			return nil, err
+		}
+		it := reader.Iterator()
+		for it.HasNext() {
+			metricID := it.Key()
+			r, err := newReaderFunc(reader.Path(), it.Value())
+			if err != nil {
+				return nil, err
+			}
+			result[metricID] = append(result[metricID], r)
+		}
+	}
+	return result, nil
+}
+
+// getTagsHash returns the tags hash of series id, the series without tags has no id mapping
+func getTagsHash(hashes map[uint32]uint64, seriesID uint32) (uint64, bool) {
+	if seriesID == constants.SeriesIDWithoutTags {
+		return emptyTagsHash, true
+	}
+	tagsHash, ok := hashes[seriesID]
+	return tagsHash, ok
+}
+
+// getPBFieldType returns the field type of write protocol by given storage field type
+func getPBFieldType(fieldType field.Type) pb.FieldType {
+	switch fieldType {
+	case field.SumField:
+		return pb.FieldType_Sum
+	case field.MaxField:
+		return pb.FieldType_Max
+	case field.MinField:
+		return pb.FieldType_Min
+	case field.GaugeField:
+		return pb.FieldType_Gauge
+	case field.HistogramField:
+		return pb.FieldType_Histogram
+	case field.SummaryField:
+		return pb.FieldType_Summary
+	default:
+		return pb.FieldType_UNKNOWN
+	}
+}
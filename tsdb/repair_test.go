@@ -0,0 +1,271 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cespare/xxhash"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/tag"
+)
+
+func TestShardDigest_Diff(t *testing.T) {
+	local := &ShardDigest{
+		Index: []MetricDigest{
+			{Namespace: "ns", MetricName: "cpu", Series: SeriesDigest{Count: 2, Checksum: 30}},
+			{Namespace: "ns", MetricName: "disk", Series: SeriesDigest{Count: 1, Checksum: 10}},
+		},
+		Families: []FamilyDigest{
+			{FamilyTime: 10, Metrics: []MetricDigest{
+				{Namespace: "ns", MetricName: "cpu", Series: SeriesDigest{Count: 1, Checksum: 10}},
+			}},
+		},
+	}
+	peer := &ShardDigest{
+		Index: []MetricDigest{
+			{Namespace: "ns", MetricName: "cpu", Series: SeriesDigest{Count: 2, Checksum: 30}},
+			{Namespace: "ns", MetricName: "mem", Series: SeriesDigest{Count: 1, Checksum: 10}},
+		},
+		Families: []FamilyDigest{
+			{FamilyTime: 10, Metrics: []MetricDigest{
+				{Namespace: "ns", MetricName: "cpu", Series: SeriesDigest{Count: 2, Checksum: 30}},
+			}},
+			{FamilyTime: 20, Metrics: []MetricDigest{
+				{Namespace: "ns", MetricName: "cpu", Series: SeriesDigest{Count: 1, Checksum: 10}},
+			}},
+		},
+	}
+	index, families := local.Diff(peer)
+	assert.Equal(t, []MetricDigest{peer.Index[1]}, index)
+	assert.Equal(t, peer.Families, families)
+	// same digest
+	index, families = peer.Diff(peer)
+	assert.Empty(t, index)
+	assert.Empty(t, families)
+}
+
+func TestShard_Repair(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	timestamp := timeutil.Now() - 3*timeutil.OneDay
+	newMetric := func(name string, tags map[string]string, timestamp int64, value float64) *pb.Metric {
+		return &pb.Metric{
+			Namespace: "ns",
+			Name:      name,
+			Timestamp: timestamp,
+			Tags:      tags,
+			TagsHash:  xxhash.Sum64String(tag.Concat(tags)),
+			Fields: []*pb.Field{
+				{Name: "f1", Type: pb.FieldType_Sum, Value: value},
+				{Name: "f2", Type: pb.FieldType_Gauge, Value: value * 2},
+			},
+		}
+	}
+	host1 := map[string]string{"host": "1.1.1.1", "zone": "sh"}
+	host2 := map[string]string{"host": "1.1.1.2"}
+	// replica 1 has all data, replica 2 lost the data of host2/mem and one point of host1
+	replica1 := []*pb.Metric{
+		newMetric("cpu", host1, timestamp, 1),
+		newMetric("cpu", host1, timestamp+10*timeutil.OneSecond, 2),
+		newMetric("cpu", host2, timestamp, 3),
+		newMetric("cpu", host2, timestamp+timeutil.OneHour, 4),
+		newMetric("mem", nil, timestamp, 5),
+	}
+	replica2 := []*pb.Metric{
+		newMetric("cpu", host1, timestamp, 1),
+	}
+	openShards := func() (Engine, Shard, Shard) {
+		e, err := NewEngine(engineCfg)
+		assert.NoError(t, err)
+		var shards []Shard
+		for _, name := range []string{"db1", "db2"} {
+			db, ok := e.GetDatabase(name)
+			if !ok {
+				db, err = e.CreateDatabase(name)
+				assert.NoError(t, err)
+				assert.NoError(t, db.CreateShards(option.DatabaseOption{Interval: "10s"}, []int32{1}))
+			}
+			shard, ok := db.GetShard(1)
+			assert.True(t, ok)
+			shards = append(shards, shard)
+		}
+		return e, shards[0], shards[1]
+	}
+	closeShards := func(e Engine, shards ...Shard) {
+		e.Close()
+		// releases the kv stores of segments for reopening
+		for _, s := range shards {
+			for _, segment := range s.(*shard).segments {
+				segment.Close()
+			}
+		}
+	}
+	e, shard1, shard2 := openShards()
+	assert.NoError(t, shard1.Backfill(replica1))
+	assert.NoError(t, shard2.Backfill(replica2))
+	// reopen for persisting metadata
+	closeShards(e, shard1, shard2)
+	e, shard1, shard2 = openShards()
+
+	digest1, err := shard1.Digest()
+	assert.NoError(t, err)
+	digest2, err := shard2.Digest()
+	assert.NoError(t, err)
+	// series without tags has no id mapping in index
+	assert.Len(t, digest1.Index, 1)
+	assert.Len(t, digest1.Families, 2)
+	assert.Len(t, digest2.Index, 1)
+	assert.Len(t, digest2.Families, 1)
+	// replica 1 is divergent, but no series missing
+	index, families := digest1.Diff(digest2)
+	assert.Len(t, index, 1)
+	assert.Len(t, families, 1)
+	slots, err := shard1.SeriesSlots(families[0].FamilyTime, "ns", "cpu")
+	assert.NoError(t, err)
+	assert.Len(t, slots, 2)
+	metrics, err := shard2.ExportSeries(families[0].FamilyTime, "ns", "cpu", slots)
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	// replica 2 is lagging
+	index, families = digest2.Diff(digest1)
+	assert.Len(t, index, 1)
+	assert.Len(t, families, 2)
+	exported := 0
+	for _, family := range families {
+		for _, metric := range family.Metrics {
+			slots, err := shard2.SeriesSlots(family.FamilyTime, metric.Namespace, metric.MetricName)
+			assert.NoError(t, err)
+			metrics, err := shard1.ExportSeries(family.FamilyTime, metric.Namespace, metric.MetricName, slots)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, metrics)
+			for _, m := range metrics {
+				// only exports the missing point of divergent series
+				if m.Tags["host"] == "1.1.1.1" {
+					interval := 10 * timeutil.OneSecond
+					assert.Equal(t, (timestamp+interval)/interval*interval, m.Timestamp)
+				}
+				assert.Len(t, m.Fields, 2)
+			}
+			exported += len(metrics)
+			assert.NoError(t, shard2.Backfill(metrics))
+		}
+	}
+	assert.Equal(t, 4, exported)
+	// family/metric not exist
+	slots, err = shard2.SeriesSlots(timestamp-timeutil.OneDay, "ns", "cpu")
+	assert.NoError(t, err)
+	assert.Empty(t, slots)
+	metrics, err = shard1.ExportSeries(families[0].FamilyTime, "ns", "not_exist", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	closeShards(e, shard1, shard2)
+
+	// digests are same after repair
+	e, shard1, shard2 = openShards()
+	defer closeShards(e, shard1, shard2)
+	digest1, err = shard1.Digest()
+	assert.NoError(t, err)
+	digest2, err = shard2.Digest()
+	assert.NoError(t, err)
+	index, families = digest2.Diff(digest1)
+	assert.Empty(t, index)
+	assert.Empty(t, families)
+	// exported data points are same
+	for _, family := range digest1.Families {
+		for _, metric := range family.Metrics {
+			metrics1, err := shard1.ExportSeries(family.FamilyTime, metric.Namespace, metric.MetricName, nil)
+			assert.NoError(t, err)
+			metrics2, err := shard2.ExportSeries(family.FamilyTime, metric.Namespace, metric.MetricName, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, sortMetrics(metrics1), sortMetrics(metrics2))
+		}
+	}
+}
+
+func sortMetrics(metrics []*pb.Metric) []*pb.Metric {
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].TagsHash != metrics[j].TagsHash {
+			return metrics[i].TagsHash < metrics[j].TagsHash
+		}
+		return metrics[i].Timestamp < metrics[j].Timestamp
+	})
+	for _, metric := range metrics {
+		sort.Slice(metric.Fields, func(i, j int) bool {
+			return metric.Fields[i].Name < metric.Fields[j].Name
+		})
+	}
+	return metrics
+}
+
+func TestSeriesDigest_addData(t *testing.T) {
+	newPoints := func(slot uint16, value float64) map[uint16]*pb.Metric {
+		return map[uint16]*pb.Metric{slot: {Fields: []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: value}}}}
+	}
+	digest1 := SeriesDigest{}
+	digest1.addData(1, newPoints(1, 1))
+	digest1.addData(2, newPoints(2, 2))
+	// order-independent
+	digest2 := SeriesDigest{}
+	digest2.addData(2, newPoints(2, 2))
+	digest2.addData(1, newPoints(1, 1))
+	assert.Equal(t, digest1, digest2)
+	// same series, different value
+	digest2 = SeriesDigest{}
+	digest2.addData(1, newPoints(1, 1))
+	digest2.addData(2, newPoints(2, 3))
+	assert.Equal(t, digest1.Checksum, digest2.Checksum)
+	assert.NotEqual(t, digest1.DataChecksum, digest2.DataChecksum)
+	// same series, different slot
+	digest2 = SeriesDigest{}
+	digest2.addData(1, newPoints(1, 1))
+	digest2.addData(2, newPoints(3, 2))
+	assert.NotEqual(t, digest1.DataChecksum, digest2.DataChecksum)
+}
+
+func TestAddField(t *testing.T) {
+	point := &pb.Metric{}
+	sum := field.Meta{Name: "f1", Type: field.SumField}
+	gauge := field.Meta{Name: "f2", Type: field.GaugeField}
+	addField(point, sum, getPBFieldType(sum.Type), 1)
+	addField(point, gauge, getPBFieldType(gauge.Type), 1)
+	addField(point, sum, getPBFieldType(sum.Type), 2)
+	addField(point, gauge, getPBFieldType(gauge.Type), 3)
+	assert.Equal(t, []*pb.Field{
+		{Name: "f1", Type: pb.FieldType_Sum, Value: 3},
+		{Name: "f2", Type: pb.FieldType_Gauge, Value: 3},
+	}, point.Fields)
+}
+
+func TestGetPBFieldType(t *testing.T) {
+	assert.Equal(t, pb.FieldType_Sum, getPBFieldType(field.SumField))
+	assert.Equal(t, pb.FieldType_Min, getPBFieldType(field.MinField))
+	assert.Equal(t, pb.FieldType_Max, getPBFieldType(field.MaxField))
+	assert.Equal(t, pb.FieldType_Gauge, getPBFieldType(field.GaugeField))
+	assert.Equal(t, pb.FieldType_Histogram, getPBFieldType(field.HistogramField))
+	assert.Equal(t, pb.FieldType_Summary, getPBFieldType(field.SummaryField))
+	assert.Equal(t, pb.FieldType_UNKNOWN, getPBFieldType(field.IncreaseField))
+}
@@ -0,0 +1,98 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// rollupRelation implements kv.RollupRelation interface,
+// represents the rollup relation from source segment(small interval) to target interval segment(large interval).
+type rollupRelation struct {
+	sourceInterval timeutil.Interval
+	sourceBaseTime int64
+	target         IntervalSegment
+}
+
+// newRollupRelation creates the rollup relation for source segment
+func newRollupRelation(sourceInterval timeutil.Interval, sourceBaseTime int64, target IntervalSegment) kv.RollupRelation {
+	return &rollupRelation{
+		sourceInterval: sourceInterval,
+		sourceBaseTime: sourceBaseTime,
+		target:         target,
+	}
+}
+
+// NewRollup creates the rollup context based on source family name,
+// gets or creates the target data family which contains the source family's time range.
+func (r *rollupRelation) NewRollup(sourceFamilyName string) (kv.Rollup, error) {
+	familyTime, err := strconv.Atoi(sourceFamilyName)
+	if err != nil {
+		return nil, fmt.Errorf("parse source family[%s] time error: %s", sourceFamilyName, err)
+	}
+	sourceFamilyStartTime := r.sourceInterval.Calculator().CalcFamilyStartTime(r.sourceBaseTime, familyTime)
+	targetInterval := r.target.Interval()
+	targetSegment, err := r.target.GetOrCreateSegment(targetInterval.Calculator().GetSegment(sourceFamilyStartTime))
+	if err != nil {
+		return nil, err
+	}
+	targetFamily, err := targetSegment.GetDataFamily(sourceFamilyStartTime)
+	if err != nil {
+		return nil, err
+	}
+	return &rollup{
+		sourceInterval:        r.sourceInterval,
+		sourceFamilyStartTime: sourceFamilyStartTime,
+		targetInterval:        targetInterval,
+		targetFamilyStartTime: targetFamily.TimeRange().Start,
+		targetFamily:          targetFamily.Family(),
+	}, nil
+}
+
+// rollup implements kv.Rollup interface, represents the rollup context from source family to target family.
+type rollup struct {
+	sourceInterval        timeutil.Interval
+	sourceFamilyStartTime int64
+	targetInterval        timeutil.Interval
+	targetFamilyStartTime int64
+	targetFamily          kv.Family
+}
+
+// GetTimestamp returns the timestamp based on source family and source slot
+func (r *rollup) GetTimestamp(slot uint16) int64 {
+	return r.sourceFamilyStartTime + int64(slot)*r.sourceInterval.Int64()
+}
+
+// IntervalRatio return interval ratio = target interval/source interval
+func (r *rollup) IntervalRatio() uint16 {
+	return uint16(r.targetInterval.Int64() / r.sourceInterval.Int64())
+}
+
+// CalcSlot calculates the target slot based on source timestamp
+func (r *rollup) CalcSlot(timestamp int64) uint16 {
+	return uint16(r.targetInterval.Calculator().CalcSlot(timestamp, r.targetFamilyStartTime, r.targetInterval.Int64()))
+}
+
+// GetTargetFamily returns the target family based on source family name
+func (r *rollup) GetTargetFamily(sourceFamilyName string) kv.Family {
+	return r.targetFamily
+}
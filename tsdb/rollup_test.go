@@ -0,0 +1,98 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestRollupRelation_NewRollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	sourceInterval := timeutil.Interval(10 * timeutil.OneSecond)
+	targetInterval := timeutil.Interval(5 * timeutil.OneMinute)
+	sourceBaseTime, _ := timeutil.ParseTimestamp("20190904", "20060102")
+	target := NewMockIntervalSegment(ctrl)
+	target.EXPECT().Interval().Return(targetInterval).AnyTimes()
+	relation := newRollupRelation(sourceInterval, sourceBaseTime, target)
+	// case 1: parse family time err
+	r, err := relation.NewRollup("abc")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+	// case 2: get target segment err
+	target.EXPECT().GetOrCreateSegment("201909").Return(nil, fmt.Errorf("err"))
+	r, err = relation.NewRollup("19")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+	// case 3: get target family err
+	segment := NewMockSegment(ctrl)
+	target.EXPECT().GetOrCreateSegment("201909").Return(segment, nil).AnyTimes()
+	segment.EXPECT().GetDataFamily(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	r, err = relation.NewRollup("19")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+	// case 4: new rollup context
+	familyStartTime, _ := timeutil.ParseTimestamp("20190904 19:00:00", "20060102 15:04:05")
+	family := kv.NewMockFamily(ctrl)
+	segment.EXPECT().GetDataFamily(familyStartTime).Return(newDataFamily(targetInterval, timeutil.TimeRange{
+		Start: sourceBaseTime,
+		End:   sourceBaseTime + timeutil.OneDay - 1,
+	}, family), nil)
+	r, err = relation.NewRollup("19")
+	assert.NoError(t, err)
+	assert.Equal(t, family, r.GetTargetFamily("19"))
+	assert.Equal(t, uint16(30), r.IntervalRatio())
+	// 19:00:00 + 6*10s
+	assert.Equal(t, familyStartTime+60*timeutil.OneSecond, r.GetTimestamp(6))
+	// 19:05:10 => (19*60+5)/5
+	assert.Equal(t, uint16(229), r.CalcSlot(familyStartTime+310*timeutil.OneSecond))
+}
+
+func TestRollupRelation_rollupSegment(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	path := filepath.Join(testPath, shardDir, "3", segmentDir)
+	target, err := newIntervalSegment(timeutil.Interval(5*timeutil.OneMinute),
+		filepath.Join(path, timeutil.Month.String()), nil, nil)
+	assert.NoError(t, err)
+	source, err := newIntervalSegment(timeutil.Interval(10*timeutil.OneSecond),
+		filepath.Join(path, timeutil.Day.String()), target, nil)
+	assert.NoError(t, err)
+	seg, err := source.GetOrCreateSegment("20190904")
+	assert.NoError(t, err)
+	r, err := newRollupRelation(timeutil.Interval(10*timeutil.OneSecond), seg.BaseTime(), target).NewRollup("10")
+	assert.NoError(t, err)
+	assert.NotNil(t, r.GetTargetFamily("10"))
+	// target segment/family created
+	assert.True(t, fileutil.Exist(filepath.Join(path, timeutil.Month.String(), "201909", "4")))
+	source.Close()
+	target.Close()
+}
@@ -19,11 +19,13 @@ package tsdb
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"sync"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
@@ -34,6 +36,7 @@ import (
 // for testing
 var (
 	newStore = kv.NewStore
+	dirSize  = fileutil.DirSize
 )
 
 // Segment represents a time based segment, there are some segments in a interval segment.
@@ -43,10 +46,17 @@ type Segment interface {
 	BaseTime() int64
 	// GetDataFamily returns the data family based on timestamp
 	GetDataFamily(timestamp int64) (DataFamily, error)
+	// EvictFamilies drops the data families which are before expire time, returns the reclaimed disk size
+	EvictFamilies(expireTime int64) (reclaimed int64, err error)
 	// Close closes segment, include kv store
 	Close()
 	// getDataFamilies returns data family list by time range, return nil if not match
 	getDataFamilies(timeRange timeutil.TimeRange) []DataFamily
+	// markEvicted marks the segment and all data families evicted if no data family is in use,
+	// returns false if any data family is in use.
+	markEvicted() bool
+	// checkpoint creates the consistent checkpoint of segment's kv store under path
+	checkpoint(path string) error
 }
 
 // segment implements Segment interface
@@ -55,17 +65,22 @@ type segment struct {
 	kvStore  kv.Store
 	interval timeutil.Interval
 	families sync.Map
+	evicted  bool // evicted by data retention, cannot create data family
 
 	mutex sync.Mutex
 
 	logger *logger.Logger
 }
 
-// newSegment returns segment, segment is wrapper of kv store
+// newSegment returns segment, segment is wrapper of kv store,
+// registers rollup relation into kv store if rollup target interval segment not nil,
+// registers tombstone into kv store if tombstone not nil.
 func newSegment(
 	segmentName string,
 	interval timeutil.Interval,
 	path string,
+	rollupTarget IntervalSegment,
+	tombstone kv.Tombstone,
 ) (
 	Segment,
 	error,
@@ -80,6 +95,12 @@ func newSegment(
 	if err != nil {
 		return nil, fmt.Errorf("create kv store for segment error:%s", err)
 	}
+	if rollupTarget != nil {
+		kvStore.RegisterRollup(rollupTarget.Interval(), newRollupRelation(interval, baseTime, rollupTarget))
+	}
+	if tombstone != nil {
+		kvStore.RegisterTombstone(tombstone)
+	}
 	familyNames := kvStore.ListFamilyNames()
 	s := &segment{
 		baseTime: baseTime,
@@ -141,6 +162,9 @@ func (s *segment) GetDataFamily(timestamp int64) (DataFamily, error) {
 		defer s.mutex.Unlock()
 		family, ok = s.families.Load(familyTime)
 		if !ok {
+			if s.evicted {
+				return nil, fmt.Errorf("segment evicted")
+			}
 			familyOption := kv.FamilyOption{
 				CompactThreshold: 0,
 				Merger:           string(metricsdata.MetricDataMerger),
@@ -161,6 +185,74 @@ func (s *segment) GetDataFamily(timestamp int64) (DataFamily, error) {
 	return f, nil
 }
 
+// EvictFamilies drops the data families which are before expire time, returns the reclaimed disk size
+func (s *segment) EvictFamilies(expireTime int64) (reclaimed int64, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var expiredFamilies []int
+	s.families.Range(func(k, v interface{}) bool {
+		family, ok := v.(DataFamily)
+		if ok && family.TimeRange().End < expireTime {
+			// data family is used by query/flush, evicts it next time
+			if !family.markEvicted() {
+				s.logger.Info("skip evicting data family in use",
+					logger.String("path", s.kvStore.Option().Path), logger.Any("family", k))
+				return true
+			}
+			expiredFamilies = append(expiredFamilies, k.(int))
+		}
+		return true
+	})
+	for _, familyTime := range expiredFamilies {
+		familyName := strconv.Itoa(familyTime)
+		size, err := dirSize(filepath.Join(s.kvStore.Option().Path, familyName))
+		if err != nil {
+			s.logger.Warn("get data family disk size error",
+				logger.String("family", familyName), logger.Error(err))
+		}
+		if err := s.kvStore.DropFamily(familyName); err != nil {
+			return reclaimed, fmt.Errorf("drop data family[%s] error: %s", familyName, err)
+		}
+		s.families.Delete(familyTime)
+		reclaimed += size
+		s.logger.Info("evict expired data family",
+			logger.String("path", s.kvStore.Option().Path),
+			logger.String("family", familyName), logger.Int64("size", size))
+	}
+	return reclaimed, nil
+}
+
+// markEvicted marks the segment and all data families evicted if no data family is in use,
+// returns false if any data family is in use.
+func (s *segment) markEvicted() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	inUse := false
+	var marked []DataFamily
+	s.families.Range(func(k, v interface{}) bool {
+		family, ok := v.(DataFamily)
+		if !ok {
+			return true
+		}
+		if !family.markEvicted() {
+			inUse = true
+			return false
+		}
+		marked = append(marked, family)
+		return true
+	})
+	if inUse {
+		for _, family := range marked {
+			family.unmarkEvicted()
+		}
+		return false
+	}
+	s.evicted = true
+	return true
+}
+
 // Close closes segment, include kv store
 func (s *segment) Close() {
 	if err := s.kvStore.Close(); err != nil {
@@ -168,6 +260,11 @@ func (s *segment) Close() {
 	}
 }
 
+// checkpoint creates the consistent checkpoint of segment's kv store under path
+func (s *segment) checkpoint(path string) error {
+	return s.kvStore.Checkpoint(path)
+}
+
 func (s *segment) initDataFamily(familyTime int, family kv.Family) DataFamily {
 	calc := s.interval.Calculator()
 	// create data family
@@ -37,7 +37,7 @@ func TestSegment_Close(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	seg, _ := s.GetOrCreateSegment("20190702")
 	seg1 := seg.(*segment)
 
@@ -54,7 +54,7 @@ func TestSegment_GetDataFamily(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	seg, _ := s.GetOrCreateSegment("20190904")
 	now, _ := timeutil.ParseTimestamp("20190904 19:10:48", "20060102 15:04:05")
 	familyBaseTime, _ := timeutil.ParseTimestamp("20190904 19:00:00", "20060102 15:04:05")
@@ -103,7 +103,7 @@ func TestSegment_New(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	now, _ := timeutil.ParseTimestamp("20190904 19:10:40", "20060102 15:04:05")
@@ -113,7 +113,7 @@ func TestSegment_New(t *testing.T) {
 	s.Close()
 
 	// reopen
-	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	f, err = s.GetDataFamily(now)
@@ -121,7 +121,7 @@ func TestSegment_New(t *testing.T) {
 	assert.NotNil(t, f)
 
 	// cannot reopen
-	s2, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s2, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s2)
 
@@ -142,7 +142,110 @@ func TestSegment_loadFamily_err(t *testing.T) {
 		return kvStore, nil
 	}
 	kvStore.EXPECT().ListFamilyNames().Return([]string{"abc"})
-	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 }
+
+func TestSegment_New_rollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		newStore = kv.NewStore
+		ctrl.Finish()
+	}()
+	kvStore := kv.NewMockStore(ctrl)
+	newStore = func(name string, option kv.StoreOption) (store kv.Store, e error) {
+		return kvStore, nil
+	}
+	target := NewMockIntervalSegment(ctrl)
+	target.EXPECT().Interval().Return(timeutil.Interval(5 * timeutil.OneMinute))
+	kvStore.EXPECT().RegisterRollup(timeutil.Interval(5*timeutil.OneMinute), gomock.Any())
+	kvStore.EXPECT().ListFamilyNames().Return(nil)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, target, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+}
+
+func TestSegment_EvictFamilies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		dirSize = fileutil.DirSize
+		ctrl.Finish()
+	}()
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
+	assert.NoError(t, err)
+	for _, hour := range []string{"10", "11", "12"} {
+		now, _ := timeutil.ParseTimestamp("20190904 "+hour+":10:40", "20060102 15:04:05")
+		_, err = s.GetDataFamily(now)
+		assert.NoError(t, err)
+	}
+	// case 1: evict expired families
+	expireTime, _ := timeutil.ParseTimestamp("20190904 12:00:00", "20060102 15:04:05")
+	reclaimed, err := s.EvictFamilies(expireTime)
+	assert.NoError(t, err)
+	assert.True(t, reclaimed >= 0)
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "10")))
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "11")))
+	assert.True(t, fileutil.Exist(filepath.Join(testPath, "12")))
+	seg1 := s.(*segment)
+	assert.Len(t, seg1.kvStore.ListFamilyNames(), 1)
+	// case 2: nothing expired
+	reclaimed, err = s.EvictFamilies(expireTime)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), reclaimed)
+	s.Close()
+
+	// case 3: drop family err
+	store := kv.NewMockStore(ctrl)
+	store.EXPECT().Option().Return(kv.DefaultStoreOption(testPath)).AnyTimes()
+	seg1.kvStore = store
+	dirSize = func(path string) (int64, error) {
+		return 0, fmt.Errorf("err")
+	}
+	store.EXPECT().DropFamily("12").Return(fmt.Errorf("err"))
+	expireTime, _ = timeutil.ParseTimestamp("20190904 13:00:00", "20060102 15:04:05")
+	_, err = s.EvictFamilies(expireTime)
+	assert.Error(t, err)
+}
+
+func TestSegment_EvictFamilies_open_snapshot(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
+	assert.NoError(t, err)
+	defer s.Close()
+	now, _ := timeutil.ParseTimestamp("20190904 10:10:40", "20060102 15:04:05")
+	family, err := s.GetDataFamily(now)
+	assert.NoError(t, err)
+	// query retains the data family, then opens the snapshot
+	assert.True(t, family.Retain())
+	snapshot := family.Family().GetSnapshot()
+	// evict the data family in use, skip it
+	expireTime, _ := timeutil.ParseTimestamp("20190904 12:00:00", "20060102 15:04:05")
+	reclaimed, err := s.EvictFamilies(expireTime)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), reclaimed)
+	assert.True(t, fileutil.Exist(filepath.Join(testPath, "10")))
+	_, err = snapshot.FindReaders(10)
+	assert.NoError(t, err)
+	// segment cannot be evicted when data family in use
+	other, err := s.GetDataFamily(now + timeutil.OneHour)
+	assert.NoError(t, err)
+	assert.False(t, s.markEvicted())
+	assert.True(t, other.Retain())
+	other.Release()
+	// evict the data family after query completed
+	snapshot.Close()
+	family.Release()
+	_, err = s.EvictFamilies(expireTime)
+	assert.NoError(t, err)
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "10")))
+	assert.False(t, family.Retain())
+	// segment evicted, cannot create data family
+	assert.True(t, s.markEvicted())
+	_, err = s.GetDataFamily(now)
+	assert.Error(t, err)
+}
@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/lindb/roaring"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/atomic"
 
@@ -78,12 +79,28 @@ var (
 		},
 		[]string{"db", "shard"},
 	)
+	retentionReclaimedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_retention_reclaimed_bytes",
+			Help: "Reclaimed disk size(bytes) by evicting expired data.",
+		},
+		[]string{"db", "shard"},
+	)
+	retentionEvictFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_retention_evict_failures",
+			Help: "The number of failures when evicting expired data.",
+		},
+		[]string{"db", "shard"},
+	)
 )
 
 func init() {
 	monitoring.StorageRegistry.MustRegister(buildIndexTimer)
 	monitoring.StorageRegistry.MustRegister(writeMetricTimer)
 	monitoring.StorageRegistry.MustRegister(memFlushTimer)
+	monitoring.StorageRegistry.MustRegister(retentionReclaimedCounter)
+	monitoring.StorageRegistry.MustRegister(retentionEvictFailures)
 }
 
 const (
@@ -106,12 +123,22 @@ type Shard interface {
 	ShardInfo() string
 	// GetDataFamilies returns data family list by interval type and time range, return nil if not match
 	GetDataFamilies(intervalType timeutil.IntervalType, timeRange timeutil.TimeRange) []DataFamily
+	// GetQueryDataFamilies returns data family list which query reads data from by interval type and time range,
+	// if the data of rollup interval family is not rolled up completely, reads the source families instead.
+	GetQueryDataFamilies(intervalType timeutil.IntervalType, timeRange timeutil.TimeRange) []DataFamily
 	// MemoryDatabase returns memory database by given family time.
 	MemoryDatabase(familyTime int64) (memdb.MemoryDatabase, error)
 	// IndexDatabase returns the index-database
 	IndexDatabase() indexdb.IndexDatabase
-	// Write writes the metric-point into memory-database.
+	// Write writes the metric-point into memory-database,
+	// the late metric(older than behind) is written by backfill path if the out of window policy is backfill.
 	Write(metric *pb.Metric) error
+	// WriteMetrics writes the metric-points into memory-database, the late metrics are written by backfill path
+	// in batch if the out of window policy is backfill, returns the first error if some metrics fail to write.
+	WriteMetrics(metrics []*pb.Metric) error
+	// Backfill writes the historical metrics into data families directly by building sst files,
+	// bypasses the time window(behind/ahead) and the memory databases of live writes.
+	Backfill(metrics []*pb.Metric) error
 	// GetOrCreateSequence gets the replica sequence by given remote peer if exist, else creates a new sequence
 	GetOrCreateSequence(replicaPeer string) (replication.Sequence, error)
 	// Close releases shard's resource, such as flush data, spawned goroutines etc.
@@ -122,6 +149,24 @@ type Shard interface {
 	NeedFlush() bool
 	// IsFlushing checks if this shard is in flushing
 	IsFlushing() bool
+	// EvictExpiredData removes the data which is out of retention(ttl), returns the reclaimed disk size
+	EvictExpiredData() (reclaimed int64, err error)
+	// DropMetric drops the index/data of metric by tombstone, compaction job purges the dropped data
+	DropMetric(metricID uint32, tagKeyIDs []uint32) error
+	// DeleteSeries deletes the index/data of series under metric by tombstone, compaction job purges the deleted data
+	DeleteSeries(metricID uint32, tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) error
+	// DeletedSeriesIDs returns the deleted series ids of metric, returns nil if not exist
+	DeletedSeriesIDs(metricID uint32) *roaring.Bitmap
+	// Digest returns the digest of index and persisted data families for comparing replicas,
+	// the data families which have memory database are skipped.
+	Digest() (*ShardDigest, error)
+	// SeriesSlots returns the time slots which have data of series under metric in persisted data family
+	SeriesSlots(familyTime int64, namespace, metricName string) ([]SeriesSlots, error)
+	// ExportSeries exports the data points of series under metric in persisted data family,
+	// excludes the data points which time slot of series exists in excludes.
+	ExportSeries(familyTime int64, namespace, metricName string, excludes []SeriesSlots) ([]*pb.Metric, error)
+	// Backup creates the consistent checkpoint of shard under path, excludes the replica sequence
+	Backup(path string) error
 	// initIndexDatabase initializes index database
 	initIndexDatabase() error
 }
@@ -133,9 +178,11 @@ type Shard interface {
 //    xx/shard/1/temp/123213123131 // time of ns
 //    xx/shard/1/meta/
 //    xx/shard/1/index/inverted/
+//    xx/shard/1/tombstone/
 //    xx/shard/1/data/20191012/
 //    xx/shard/1/data/20191013/
 type shard struct {
+	db           Database
 	databaseName string
 	id           int32
 	path         string
@@ -156,16 +203,26 @@ type shard struct {
 	segment        IntervalSegment // smallest interval for writing data
 	isFlushing     atomic.Bool     // restrict flusher concurrency
 	flushCondition sync.WaitGroup  // flush condition
+	// data retention for each storage interval, keeps data forever if not set
+	ttls map[timeutil.Interval]timeutil.Interval
 
 	indexStore     kv.Store  // kv stores
 	forwardFamily  kv.Family // forward store
 	invertedFamily kv.Family // inverted store
 
-	rwMutex sync.RWMutex
+	metricTombstone *tombstone // deleted metric data(key: metric id, value: series ids)
+	indexTombstone  *tombstone // deleted index data(key: tag key id, value: series ids)
+
+	rwMutex       sync.RWMutex
+	backfillMutex sync.Mutex // restrict backfill concurrency
 
 	buildIndexTimer  prometheus.Observer
 	writeMetricTimer prometheus.Observer
 	memFlushTimer    prometheus.Observer
+
+	retentionReclaimedCounter prometheus.Counter
+	retentionEvictFailures    prometheus.Counter
+	backfillCounter           prometheus.Counter
 }
 
 // newShard creates shard instance, if shard path exist then load shard data for init.
@@ -192,6 +249,7 @@ func newShard(
 	}
 	shardIDStr := strconv.Itoa(int(shardID))
 	createdShard := &shard{
+		db:               db,
 		databaseName:     db.Name(),
 		id:               shardID,
 		path:             shardPath,
@@ -205,19 +263,49 @@ func newShard(
 		buildIndexTimer:  buildIndexTimer.WithLabelValues(db.Name(), shardIDStr),
 		writeMetricTimer: writeMetricTimer.WithLabelValues(db.Name(), shardIDStr),
 		memFlushTimer:    memFlushTimer.WithLabelValues(db.Name(), shardIDStr),
-	}
-	// new segment for writing
-	createdShard.segment, err = newIntervalSegmentFunc(
-		interval,
-		filepath.Join(shardPath, segmentDir, interval.Type().String()))
 
-	if err != nil {
+		retentionReclaimedCounter: retentionReclaimedCounter.WithLabelValues(db.Name(), shardIDStr),
+		retentionEvictFailures:    retentionEvictFailures.WithLabelValues(db.Name(), shardIDStr),
+		backfillCounter:           backfillCounter.WithLabelValues(db.Name(), shardIDStr),
+	}
+	createdShard.initTTLs(option)
+	if createdShard.metricTombstone, err = newTombstone(
+		filepath.Join(shardPath, tombstoneDir, metricTombstone)); err != nil {
 		return nil, err
 	}
+	if createdShard.indexTombstone, err = newTombstone(
+		filepath.Join(shardPath, tombstoneDir, indexTombstone)); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			for _, segment := range createdShard.segments {
+				segment.Close()
+			}
+		}
+	}()
+	// new interval segments from the largest rollup interval to the smallest writing interval,
+	// because the data of small interval segment need rollup into the next large interval segment.
+	intervals := option.GetStorageIntervals()
+	var rollupTarget IntervalSegment
+	for idx := len(intervals) - 1; idx >= 0; idx-- {
+		storageInterval := intervals[idx]
+		var segment IntervalSegment
+		segment, err = newIntervalSegmentFunc(
+			storageInterval,
+			filepath.Join(shardPath, segmentDir, storageInterval.Type().String()),
+			rollupTarget,
+			createdShard.metricTombstone)
+		if err != nil {
+			return nil, err
+		}
+		createdShard.segments[storageInterval.Type()] = segment
+		rollupTarget = segment
+	}
+	// smallest interval segment for writing
+	createdShard.segment = rollupTarget
 	_ = createdShard.ahead.ValueOf(option.Ahead)
 	_ = createdShard.behind.ValueOf(option.Behind)
-	// add writing segment into segment list
-	createdShard.segments[interval.Type()] = createdShard.segment
 
 	defer func() {
 		if err != nil {
@@ -266,6 +354,63 @@ func (s *shard) GetDataFamilies(intervalType timeutil.IntervalType, timeRange ti
 	return nil
 }
 
+// GetQueryDataFamilies returns data family list which query reads data from by interval type and time range,
+// if the data of rollup interval family is not rolled up completely, reads the source families instead.
+func (s *shard) GetQueryDataFamilies(intervalType timeutil.IntervalType, timeRange timeutil.TimeRange) []DataFamily {
+	intervals := s.option.GetStorageIntervals()
+	for idx, interval := range intervals {
+		if interval.Type() == intervalType {
+			return s.getQueryDataFamilies(intervals[:idx+1], timeRange)
+		}
+	}
+	return nil
+}
+
+// getQueryDataFamilies returns the data families of the last interval of intervals,
+// replaces the family by the families of previous(source) interval under its time range,
+// if some of source families are not rolled up into it yet, or it isn't created because no data rolled up.
+func (s *shard) getQueryDataFamilies(intervals []timeutil.Interval, timeRange timeutil.TimeRange) []DataFamily {
+	last := len(intervals) - 1
+	families := s.GetDataFamilies(intervals[last].Type(), timeRange)
+	if last == 0 {
+		return families
+	}
+	sourceInterval := intervals[last-1].Int64()
+	sourceFamilies := s.getQueryDataFamilies(intervals[:last], timeRange)
+	if len(sourceFamilies) == 0 {
+		return families
+	}
+	var result []DataFamily
+	covered := make([]bool, len(sourceFamilies))
+	for _, family := range families {
+		familyTimeRange := family.TimeRange()
+		var familySources []DataFamily
+		rolledUp := true
+		for idx, sourceFamily := range sourceFamilies {
+			if !familyTimeRange.Contains(sourceFamily.TimeRange().Start) {
+				continue
+			}
+			covered[idx] = true
+			familySources = append(familySources, sourceFamily)
+			if sourceFamily.Interval() != sourceInterval || sourceFamily.Family().HasRollupFiles() {
+				rolledUp = false
+			}
+		}
+		if rolledUp {
+			result = append(result, family)
+		} else {
+			result = append(result, familySources...)
+		}
+	}
+	// the source families which are not rolled up into any family
+	for idx, sourceFamily := range sourceFamilies {
+		if !covered[idx] {
+			result = append(result, sourceFamily)
+		}
+	}
+	return result
+}
+
 // MemoryDatabase returns memory database by given family time.
 func (s *shard) MemoryDatabase(familyTime int64) (memdb.MemoryDatabase, error) {
 	var memDB memdb.MemoryDatabase
@@ -282,48 +427,52 @@ func (s *shard) MemoryDatabase(familyTime int64) (memdb.MemoryDatabase, error) {
 	return memDB, nil
 }
 
-// Write writes the metric-point into memory-database.
-func (s *shard) Write(metric *pb.Metric) (err error) {
-	if metric == nil {
-		return constants.ErrNilMetric
+// Write writes the metric-point into memory-database,
+// the late metric(older than behind) is written by backfill path if the out of window policy is backfill.
+func (s *shard) Write(metric *pb.Metric) error {
+	return s.WriteMetrics([]*pb.Metric{metric})
+}
+
+// WriteMetrics writes the metric-points into memory-database, the late metrics are written by backfill path
+// in batch if the out of window policy is backfill, returns the first error if some metrics fail to write.
+func (s *shard) WriteMetrics(metrics []*pb.Metric) (err error) {
+	var lateMetrics []*pb.Metric
+	for _, metric := range metrics {
+		backfill, writeErr := s.write(metric)
+		if backfill {
+			lateMetrics = append(lateMetrics, metric)
+			continue
+		}
+		if writeErr != nil && err == nil {
+			err = writeErr
+		}
 	}
-	if len(metric.Name) == 0 {
-		return constants.ErrEmptyMetricName
+	if len(lateMetrics) > 0 {
+		// late metrics are written into data families directly, don't pollute the memory databases of live writes
+		if backfillErr := s.Backfill(lateMetrics); backfillErr != nil && err == nil {
+			err = backfillErr
+		}
 	}
-	if len(metric.Fields) == 0 {
-		return constants.ErrEmptyField
+	return err
+}
+
+// write writes the metric-point into memory-database, returns backfill=true if the metric is late data
+// which need to be written by backfill path.
+func (s *shard) write(metric *pb.Metric) (backfill bool, err error) {
+	if err := checkMetric(metric); err != nil {
+		return false, err
 	}
 	timestamp := metric.Timestamp
 	now := timeutil.Now()
 
 	// check metric timestamp if in acceptable time range
-	if (s.behind.Int64() > 0 && timestamp < now-s.behind.Int64()) ||
-		(s.ahead.Int64() > 0 && timestamp > now+s.ahead.Int64()) {
-		return nil
-	}
-	ns := metric.Namespace
-	if len(ns) == 0 {
-		ns = constants.DefaultNamespace
+	accept, backfill, err := s.checkTimeWindow(metric, now)
+	if !accept {
+		return backfill, err
 	}
-	metricID, err := s.metadata.MetadataDatabase().GenMetricID(ns, metric.Name)
+	ns, metricID, seriesID, err := s.genSeriesID(metric)
 	if err != nil {
-		return err
-	}
-	var seriesID uint32
-	isCreated := false
-	if len(metric.Tags) == 0 {
-		// if metric without tags, uses default series id(0)
-		seriesID = constants.SeriesIDWithoutTags
-	} else {
-		seriesID, isCreated, err = s.indexDB.GetOrCreateSeriesID(metricID, metric.TagsHash)
-		if err != nil {
-			return err
-		}
-	}
-
-	if isCreated {
-		// if series id is new, need build inverted index
-		s.indexDB.BuildInvertIndex(ns, metric.Name, metric.Tags, seriesID)
+		return false, err
 	}
 	buildIndexEnd := timeutil.Now()
 	s.buildIndexTimer.Observe(float64(buildIndexEnd - now))
@@ -335,7 +484,7 @@ func (s *shard) Write(metric *pb.Metric) (err error) {
 	familyTime := intervalCalc.CalcFamilyStartTime(segmentTime, family) // family timestamp
 	db, err := s.MemoryDatabase(familyTime)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// mark writing data
@@ -349,7 +498,40 @@ func (s *shard) Write(metric *pb.Metric) (err error) {
 
 	slotIndex := uint16(intervalCalc.CalcSlot(timestamp, familyTime, s.interval.Int64())) // slot offset of family
 	// write metric point into memory db
-	return db.Write(ns, metric.Name, metricID, seriesID, slotIndex, metric.Fields)
+	return false, db.Write(ns, metric.Name, metricID, seriesID, slotIndex, metric.Fields)
+}
+
+// genSeriesID generates the metric id and series id of metric, builds the index if series is new,
+// returns the namespace of metric(default namespace if not set).
+func (s *shard) genSeriesID(metric *pb.Metric) (ns string, metricID, seriesID uint32, err error) {
+	ns = metric.Namespace
+	if len(ns) == 0 {
+		ns = constants.DefaultNamespace
+	}
+	// check cardinality limit of metrics before generating metric id
+	if err = s.checkMetricLimit(ns, metric.Name); err != nil {
+		return
+	}
+	metricID, err = s.metadata.MetadataDatabase().GenMetricID(ns, metric.Name)
+	if err != nil {
+		return
+	}
+	if len(metric.Tags) == 0 {
+		// if metric without tags, uses default series id(0)
+		seriesID = constants.SeriesIDWithoutTags
+		return
+	}
+	var isCreated bool
+	// check cardinality limit of series/tag values when generating new series id
+	seriesID, isCreated, err = s.indexDB.GetOrCreateSeriesID(metricID, metric.TagsHash, s.seriesLimitChecker(ns, metric))
+	if err != nil {
+		return
+	}
+	if isCreated {
+		// if series id is new, need build inverted index
+		s.indexDB.BuildInvertIndex(ns, metric.Name, metric.Tags, seriesID)
+	}
+	return
 }
 
 func (s *shard) Close() error {
@@ -376,6 +558,48 @@ func (s *shard) Close() error {
 	return s.sequence.Close()
 }
 
+// EvictExpiredData removes the data which is out of retention(ttl), returns the reclaimed disk size,
+// refreshes the data retention from current database option before evicting, because it maybe modified.
+func (s *shard) EvictExpiredData() (reclaimed int64, err error) {
+	s.initTTLs(s.db.GetOption())
+	now := timeutil.Now()
+	for _, segment := range s.segments {
+		ttl, ok := s.ttls[segment.Interval()]
+		if !ok {
+			continue
+		}
+		size, err := segment.EvictExpired(now - ttl.Int64())
+		reclaimed += size
+		if err != nil {
+			s.retentionEvictFailures.Inc()
+			s.retentionReclaimedCounter.Add(float64(reclaimed))
+			return reclaimed, err
+		}
+	}
+	s.retentionReclaimedCounter.Add(float64(reclaimed))
+	return reclaimed, nil
+}
+
+// initTTLs initializes the data retention of write interval and rollup intervals by database option
+func (s *shard) initTTLs(option option.DatabaseOption) {
+	ttls := make(map[timeutil.Interval]timeutil.Interval)
+	var ttl timeutil.Interval
+	if err := ttl.ValueOf(option.TTL); err == nil && ttl > 0 {
+		ttls[s.interval] = ttl
+	}
+	for idx, rollupTTL := range option.RollupTTL {
+		if idx >= len(option.Rollup) {
+			break
+		}
+		var interval, ttl timeutil.Interval
+		_ = interval.ValueOf(option.Rollup[idx])
+		if err := ttl.ValueOf(rollupTTL); err == nil && ttl > 0 {
+			ttls[interval] = ttl
+		}
+	}
+	s.ttls = ttls
+}
+
 // IsFlushing checks if this shard is in flushing
 func (s *shard) IsFlushing() bool { return s.isFlushing.Load() }
 
@@ -440,6 +664,7 @@ func (s *shard) initIndexDatabase() error {
 	if err != nil {
 		return err
 	}
+	s.indexStore.RegisterTombstone(s.indexTombstone)
 	s.forwardFamily, err = s.indexStore.CreateFamily(
 		forwardIndexDir,
 		kv.FamilyOption{
@@ -467,6 +692,39 @@ func (s *shard) initIndexDatabase() error {
 	return nil
 }
 
+// DropMetric drops the index/data of metric by tombstone, compaction job purges the dropped data
+func (s *shard) DropMetric(metricID uint32, tagKeyIDs []uint32) error {
+	if err := s.metricTombstone.deleteKeys(metricID); err != nil {
+		return err
+	}
+	if err := s.indexTombstone.deleteKeys(tagKeyIDs...); err != nil {
+		return err
+	}
+	return s.indexDB.DropMetric(metricID)
+}
+
+// DeleteSeries deletes the index/data of series under metric by tombstone, compaction job purges the deleted data
+func (s *shard) DeleteSeries(metricID uint32, tagKeyIDs []uint32, seriesIDs *roaring.Bitmap) error {
+	if seriesIDs == nil || seriesIDs.IsEmpty() {
+		return nil
+	}
+	if err := s.metricTombstone.deleteValues(metricID, seriesIDs); err != nil {
+		return err
+	}
+	for _, tagKeyID := range tagKeyIDs {
+		if err := s.indexTombstone.deleteValues(tagKeyID, seriesIDs); err != nil {
+			return err
+		}
+	}
+	// remove series id mapping, new series with same tags will generate new series id
+	return s.indexDB.DeleteSeries(metricID, seriesIDs)
+}
+
+// DeletedSeriesIDs returns the deleted series ids of metric, returns nil if not exist
+func (s *shard) DeletedSeriesIDs(metricID uint32) *roaring.Bitmap {
+	return s.metricTombstone.DeletedValues(metricID)
+}
+
 // createMemoryDatabase creates a new memory database for writing data points
 func (s *shard) createMemoryDatabase() (memdb.MemoryDatabase, error) {
 	return newMemoryDBFunc(memdb.MemoryDatabaseCfg{
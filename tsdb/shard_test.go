@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
@@ -83,7 +84,8 @@ func TestShard_New(t *testing.T) {
 	assert.Nil(t, thisShard)
 	// case 5: new interval segment err
 	newReplicaSequenceFunc = newReplicaSequence
-	newIntervalSegmentFunc = func(interval timeutil.Interval, path string) (segment IntervalSegment, err error) {
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string,
+		rollupTarget IntervalSegment, tombstone kv.Tombstone) (segment IntervalSegment, err error) {
 		return nil, fmt.Errorf("err")
 	}
 	thisShard, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
@@ -100,6 +102,7 @@ func TestShard_New(t *testing.T) {
 	// case 7: create forward family err
 	kvStore := kv.NewMockStore(ctrl)
 	kvStore.EXPECT().Close().Return(fmt.Errorf("err")).AnyTimes()
+	kvStore.EXPECT().RegisterTombstone(gomock.Any()).AnyTimes()
 	newKVStoreFunc = func(name string, option kv.StoreOption) (store kv.Store, err error) {
 		return kvStore, nil
 	}
@@ -141,6 +144,53 @@ func TestShard_New(t *testing.T) {
 	assert.False(t, thisShard.IsFlushing())
 }
 
+func TestShard_New_rollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		newIntervalSegmentFunc = newIntervalSegment
+		ctrl.Finish()
+	}()
+	db := NewMockDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	dbOption := option.DatabaseOption{Interval: "10s", Rollup: []string{"1h", "5m"}}
+	// case 1: create rollup interval segment err, close created segments
+	segment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().Close()
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string,
+		rollupTarget IntervalSegment, tombstone kv.Tombstone) (IntervalSegment, error) {
+		if interval.Type() == timeutil.Year {
+			return segment, nil
+		}
+		return nil, fmt.Errorf("err")
+	}
+	thisShard, err := newShard(db, 1, _testShard1Path, dbOption)
+	assert.Error(t, err)
+	assert.Nil(t, thisShard)
+	// case 2: create rollup interval segments, from large interval to small interval
+	var targets []IntervalSegment
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string,
+		rollupTarget IntervalSegment, tombstone kv.Tombstone) (IntervalSegment, error) {
+		assert.Equal(t, filepath.Join(_testShard1Path, segmentDir, interval.Type().String()), path)
+		targets = append(targets, rollupTarget)
+		return newIntervalSegment(interval, path, rollupTarget, tombstone)
+	}
+	thisShard, err = newShard(db, 1, _testShard1Path, dbOption)
+	assert.NoError(t, err)
+	s := thisShard.(*shard)
+	assert.Len(t, s.segments, 3)
+	assert.Equal(t, s.segments[timeutil.Day], s.segment)
+	assert.Equal(t, []IntervalSegment{nil, s.segments[timeutil.Year], s.segments[timeutil.Month]}, targets)
+	assert.Equal(t, timeutil.Interval(5*timeutil.OneMinute), s.segments[timeutil.Month].Interval())
+	assert.NoError(t, thisShard.Close())
+	for _, segment := range s.segments {
+		segment.Close()
+	}
+}
+
 func TestShard_GetDataFamilies(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
@@ -159,6 +209,146 @@ func TestShard_GetDataFamilies(t *testing.T) {
 	assert.Equal(t, 0, len(s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{})))
 }
 
+func TestShard_GetQueryDataFamilies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newFamily := func(interval timeutil.Interval, start, end int64, hasRollupFiles bool) DataFamily {
+		kvFamily := kv.NewMockFamily(ctrl)
+		kvFamily.EXPECT().HasRollupFiles().Return(hasRollupFiles).AnyTimes()
+		family := NewMockDataFamily(ctrl)
+		family.EXPECT().Interval().Return(interval.Int64()).AnyTimes()
+		family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: start, End: end}).AnyTimes()
+		family.EXPECT().Family().Return(kvFamily).AnyTimes()
+		return family
+	}
+	writeInterval := timeutil.Interval(10 * timeutil.OneSecond)
+	rollupInterval := timeutil.Interval(5 * timeutil.OneMinute)
+	// day1 rolled up, day2 has files not rolled up, day3 is recent data not rolled up(no rollup family)
+	rolledUp := newFamily(writeInterval, 0, 9, false)
+	notRolledUp := newFamily(writeInterval, 10, 19, true)
+	recent := newFamily(writeInterval, 20, 29, true)
+	rollupFamily1 := newFamily(rollupInterval, 0, 9, false)
+	rollupFamily2 := newFamily(rollupInterval, 10, 19, false)
+
+	segment := NewMockIntervalSegment(ctrl)
+	rollupSegment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{rolledUp, notRolledUp, recent}).AnyTimes()
+	rollupSegment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{rollupFamily1, rollupFamily2}).AnyTimes()
+	s := &shard{
+		option: option.DatabaseOption{Interval: "10s", Rollup: []string{"5m"}},
+		segments: map[timeutil.IntervalType]IntervalSegment{
+			writeInterval.Type():  segment,
+			rollupInterval.Type(): rollupSegment,
+		},
+	}
+	timeRange := timeutil.TimeRange{Start: 0, End: 29}
+	assert.Nil(t, s.GetQueryDataFamilies(timeutil.Year, timeRange))
+	assert.Equal(t, []DataFamily{rolledUp, notRolledUp, recent}, s.GetQueryDataFamilies(writeInterval.Type(), timeRange))
+	assert.Equal(t, []DataFamily{rollupFamily1, notRolledUp, recent}, s.GetQueryDataFamilies(rollupInterval.Type(), timeRange))
+}
+
+func TestShard_EvictExpiredData(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	opt := option.DatabaseOption{
+		Interval:  "10s",
+		TTL:       "7d",
+		Rollup:    []string{"5m", "1h"},
+		RollupTTL: []string{"30d", ""},
+	}
+	db.EXPECT().GetOption().Return(opt).Times(2)
+	thisShard, err := newShard(db, 1, _testShard1Path, opt)
+	assert.NoError(t, err)
+	s := thisShard.(*shard)
+	assert.Len(t, s.ttls, 2)
+	assert.Equal(t, timeutil.Interval(7*timeutil.OneDay), s.ttls[timeutil.Interval(10*timeutil.OneSecond)])
+	assert.Equal(t, timeutil.Interval(30*timeutil.OneDay), s.ttls[timeutil.Interval(5*timeutil.OneMinute)])
+
+	segment := NewMockIntervalSegment(ctrl)
+	rollupSegment := NewMockIntervalSegment(ctrl)
+	noTTLSegment := NewMockIntervalSegment(ctrl)
+	segment.EXPECT().Interval().Return(timeutil.Interval(10 * timeutil.OneSecond)).AnyTimes()
+	rollupSegment.EXPECT().Interval().Return(timeutil.Interval(5 * timeutil.OneMinute)).AnyTimes()
+	noTTLSegment.EXPECT().Interval().Return(timeutil.Interval(timeutil.OneHour)).AnyTimes()
+	s.segments[timeutil.Day] = segment
+	s.segments[timeutil.Month] = rollupSegment
+	s.segments[timeutil.Year] = noTTLSegment
+	// case 1: evict expired data
+	segment.EXPECT().EvictExpired(gomock.Any()).Return(int64(10), nil)
+	rollupSegment.EXPECT().EvictExpired(gomock.Any()).Return(int64(20), nil)
+	reclaimed, err := s.EvictExpiredData()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), reclaimed)
+	// case 2: evict expired data err
+	segment.EXPECT().EvictExpired(gomock.Any()).Return(int64(0), fmt.Errorf("err"))
+	rollupSegment.EXPECT().EvictExpired(gomock.Any()).Return(int64(0), nil).AnyTimes()
+	_, err = s.EvictExpiredData()
+	assert.Error(t, err)
+	// case 3: refresh data retention from modified database option
+	modified := opt
+	modified.TTL = "14d"
+	modified.RollupTTL = []string{"", "90d"}
+	db.EXPECT().GetOption().Return(modified)
+	now := timeutil.Now()
+	segment.EXPECT().EvictExpired(gomock.Any()).DoAndReturn(func(expireTime int64) (int64, error) {
+		assert.True(t, expireTime <= now-14*timeutil.OneDay+timeutil.OneMinute)
+		return 0, nil
+	})
+	noTTLSegment.EXPECT().EvictExpired(gomock.Any()).Return(int64(5), nil)
+	reclaimed, err = s.EvictExpiredData()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), reclaimed)
+	assert.Len(t, s.ttls, 2)
+	assert.Equal(t, timeutil.Interval(14*timeutil.OneDay), s.ttls[timeutil.Interval(10*timeutil.OneSecond)])
+	assert.Equal(t, timeutil.Interval(90*timeutil.OneDay), s.ttls[timeutil.Interval(timeutil.OneHour)])
+}
+
+func TestShard_DeleteData(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	thisShard, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	assert.NoError(t, err)
+	s := thisShard.(*shard)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	s.indexDB = indexDB
+	// case 1: drop metric
+	indexDB.EXPECT().DropMetric(uint32(10)).Return(nil)
+	err = s.DropMetric(10, []uint32{1, 2})
+	assert.NoError(t, err)
+	assert.True(t, s.metricTombstone.IsDeleted(10))
+	assert.True(t, s.indexTombstone.IsDeleted(1))
+	assert.True(t, s.indexTombstone.IsDeleted(2))
+	// case 2: delete empty series
+	err = s.DeleteSeries(20, []uint32{3}, roaring.New())
+	assert.NoError(t, err)
+	assert.Nil(t, s.DeletedSeriesIDs(20))
+	// case 3: delete series
+	indexDB.EXPECT().DeleteSeries(uint32(20), gomock.Any()).Return(nil)
+	err = s.DeleteSeries(20, []uint32{3}, roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, s.DeletedSeriesIDs(20).ToArray())
+	assert.Equal(t, []uint32{1, 2}, s.indexTombstone.DeletedValues(3).ToArray())
+}
+
 func TestShard_Write(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
@@ -236,7 +426,7 @@ func TestShard_Write(t *testing.T) {
 	}))
 	// case 7: gen series id err
 	metadataDB.EXPECT().GenMetricID(constants.DefaultNamespace, "test").Return(uint32(10), nil).AnyTimes()
-	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10)).Return(uint32(0), false, fmt.Errorf("err"))
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), gomock.Any()).Return(uint32(0), false, fmt.Errorf("err"))
 	assert.Error(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
 		Timestamp: timestamp,
@@ -248,7 +438,7 @@ func TestShard_Write(t *testing.T) {
 		}},
 	}))
 	// case 7: get old series id
-	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10)).Return(uint32(10), false, nil)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), gomock.Any()).Return(uint32(10), false, nil)
 	assert.NoError(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
 		Timestamp: timestamp,
@@ -260,7 +450,7 @@ func TestShard_Write(t *testing.T) {
 		}},
 	}))
 	// case 8: create new series id
-	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10)).Return(uint32(10), true, nil)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(10), gomock.Any()).Return(uint32(10), true, nil)
 	indexDB.EXPECT().BuildInvertIndex(constants.DefaultNamespace, "test", map[string]string{"ip": "1.1.1.1"}, uint32(10))
 	assert.NoError(t, shardINTF.Write(&pb.Metric{
 		Name:      "test",
@@ -282,6 +472,34 @@ func TestShard_Write(t *testing.T) {
 			Value: 1.0,
 		}},
 	}))
+	// case 10: reject new metric by limit
+	shardIns.option.Limits = option.LimitOption{MaxMetricsPerNamespace: 1, MaxSeriesPerMetric: 1}
+	metadataDB.EXPECT().GetMetricID(constants.DefaultNamespace, "test2").Return(uint32(0), constants.ErrNotFound)
+	metadataDB.EXPECT().GetMetricCount(constants.DefaultNamespace).Return(1, nil)
+	assert.Equal(t, constants.ErrTooManyMetrics, shardINTF.Write(&pb.Metric{
+		Name:      "test2",
+		Timestamp: timestamp,
+		Fields: []*pb.Field{{
+			Name:  "f1",
+			Value: 1.0,
+		}},
+	}))
+	// case 11: reject new series by limit
+	metadataDB.EXPECT().GetMetricID(constants.DefaultNamespace, "test").Return(uint32(10), nil)
+	indexDB.EXPECT().GetOrCreateSeriesID(uint32(10), uint64(20), gomock.Any()).
+		DoAndReturn(func(metricID uint32, tagsHash uint64, checkLimit func(seriesCount uint32) error) (uint32, bool, error) {
+			return 0, false, checkLimit(1)
+		})
+	assert.Equal(t, constants.ErrTooManySeries, shardINTF.Write(&pb.Metric{
+		Name:      "test",
+		Timestamp: timestamp,
+		TagsHash:  20,
+		Tags:      map[string]string{"ip": "1.1.1.2"},
+		Fields: []*pb.Field{{
+			Name:  "f1",
+			Value: 1.0,
+		}},
+	}))
 }
 
 func TestShard_Close(t *testing.T) {
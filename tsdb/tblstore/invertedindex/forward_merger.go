@@ -34,10 +34,15 @@ func init() {
 type forwardMerger struct {
 	forwardFlusher ForwardFlusher
 	flusher        *kv.NopFlusher
+	tombstone      kv.Tombstone
 }
 
+// Init initializes forward merger, if tombstone context exist purges the deleted series
 func (m *forwardMerger) Init(params map[string]interface{}) {
-	// do nothing
+	tombstoneCtx, ok := params[kv.TombstoneContext]
+	if ok {
+		m.tombstone = tombstoneCtx.(kv.Tombstone)
+	}
 }
 
 // NewForwardMerger creates a forward merger
@@ -63,6 +68,19 @@ func (m *forwardMerger) Merge(key uint32, values [][]byte) ([]byte, error) {
 		scanners = append(scanners, newTagForwardScanner(reader))
 	}
 
+	targetSeriesIDs := seriesIDs
+	var deletedSeriesIDs *roaring.Bitmap
+	if m.tombstone != nil {
+		deletedSeriesIDs = m.tombstone.DeletedValues(key)
+		if deletedSeriesIDs != nil {
+			targetSeriesIDs = roaring.AndNot(seriesIDs, deletedSeriesIDs)
+			if targetSeriesIDs.IsEmpty() {
+				// all series under tag key are deleted, purge tag key data
+				return nil, nil
+			}
+		}
+	}
+
 	// 2. merge forward index by roaring container
 	highKeys := seriesIDs.GetHighKeys()
 	for idx, highKey := range highKeys {
@@ -71,16 +89,25 @@ func (m *forwardMerger) Merge(key uint32, values [][]byte) ([]byte, error) {
 		var tagValueIDs []uint32
 		for it.HasNext() {
 			lowSeriesID := it.Next()
+			pos := len(tagValueIDs)
 			// scan index data then merge tag value ids, sort by series id
 			for _, scanner := range scanners {
 				tagValueIDs = scanner.scan(highKey, lowSeriesID, tagValueIDs)
 			}
+			if deletedSeriesIDs != nil && deletedSeriesIDs.Contains(uint32(highKey)<<16|uint32(lowSeriesID)) {
+				// series is deleted, purge tag value id of series
+				tagValueIDs = tagValueIDs[:pos]
+			}
+		}
+		if deletedSeriesIDs != nil && len(tagValueIDs) == 0 {
+			// all series in container are deleted
+			continue
 		}
 		// flush tag value ids by one container
 		m.forwardFlusher.FlushForwardIndex(tagValueIDs)
 	}
 	// flush all series ids under this tag key
-	if err := m.forwardFlusher.FlushTagKeyID(key, seriesIDs); err != nil {
+	if err := m.forwardFlusher.FlushTagKeyID(key, targetSeriesIDs); err != nil {
 		return nil, err
 	}
 	return m.flusher.Bytes(), nil
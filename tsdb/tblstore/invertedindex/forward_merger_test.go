@@ -60,6 +60,28 @@ func TestForwardMerger_Merge(t *testing.T) {
 	assert.Nil(t, data)
 }
 
+func TestForwardMerger_Merge_tombstone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tombstone := kv.NewMockTombstone(ctrl)
+	merge := NewForwardMerger()
+	merge.Init(map[string]interface{}{kv.TombstoneContext: tombstone})
+	// case 1: purge deleted series
+	tombstone.EXPECT().DeletedValues(uint32(1)).Return(roaring.BitmapOf(2, 65535+10, 65535+20, 65535+30, 65535+40))
+	data, err := merge.Merge(1, mockMergeForwardBlock())
+	assert.NoError(t, err)
+	reader, err := NewTagForwardReader(data)
+	assert.NoError(t, err)
+	assert.EqualValues(t, roaring.BitmapOf(1, 3, 4).ToArray(), reader.getSeriesIDs().ToArray())
+	_, tagValueIDs := reader.GetSeriesAndTagValue(0)
+	assert.Equal(t, []uint32{1, 3, 4}, tagValueIDs)
+	// case 2: all series deleted
+	tombstone.EXPECT().DeletedValues(uint32(1)).Return(roaring.BitmapOf(1, 2, 3, 4, 65535+10, 65535+20, 65535+30, 65535+40))
+	data, err = merge.Merge(1, mockMergeForwardBlock())
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
 func mockMergeForwardBlock() (block [][]byte) {
 	nopKVFlusher := kv.NewNopFlusher()
 	forwardFlusher := NewForwardFlusher(nopKVFlusher)
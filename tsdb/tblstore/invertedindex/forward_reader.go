@@ -78,10 +78,13 @@ func (r *forwardReader) GetGroupingScanner(tagKeyID uint32, seriesIDs *roaring.B
 // findReader finds the tag forward reader by tag key id, if reader exist, will invoke callback function
 func (r *forwardReader) findReader(tagKeyID uint32, callback func(reader TagForwardReader)) error {
 	for _, reader := range r.readers {
-		value, ok := reader.Get(tagKeyID)
-		if !ok {
+		value, err := reader.Get(tagKeyID)
+		if err == table.ErrKeyNotFound {
 			continue
 		}
+		if err != nil {
+			return err
+		}
 		indexReader, err := NewTagForwardReader(value)
 		if err != nil {
 			return err
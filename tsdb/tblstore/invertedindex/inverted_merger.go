@@ -35,6 +35,7 @@ func init() {
 type invertedMerger struct {
 	invertedFlusher InvertedFlusher
 	flusher         *kv.NopFlusher
+	tombstone       kv.Tombstone
 }
 
 // NewInvertedMerger creates a inverted merger
@@ -46,8 +47,12 @@ func NewInvertedMerger() kv.Merger {
 	}
 }
 
+// Init initializes inverted merger, if tombstone context exist purges the deleted series ids
 func (m *invertedMerger) Init(params map[string]interface{}) {
-	// do nothing
+	tombstoneCtx, ok := params[kv.TombstoneContext]
+	if ok {
+		m.tombstone = tombstoneCtx.(kv.Tombstone)
+	}
 }
 
 // Merge merges the multi inverted index data into a inverted index for same tag key id
@@ -64,9 +69,14 @@ func (m *invertedMerger) Merge(key uint32, values [][]byte) ([]byte, error) {
 		scanners = append(scanners, newTagInvertedScanner(reader))
 	}
 
+	var deletedSeriesIDs *roaring.Bitmap
+	if m.tombstone != nil {
+		deletedSeriesIDs = m.tombstone.DeletedValues(key)
+	}
 	// 2. merge inverted index by roaring container
 	highKeys := targetTagValueIDs.GetHighKeys()
 	seriesIDs := roaring.New()
+	flushed := 0
 	for idx, highKey := range highKeys {
 		container := targetTagValueIDs.GetContainerAtIndex(idx)
 		it := container.PeekableIterator()
@@ -79,15 +89,28 @@ func (m *invertedMerger) Merge(key uint32, values [][]byte) ([]byte, error) {
 				}
 			}
 
+			if deletedSeriesIDs != nil {
+				// purge deleted series ids
+				seriesIDs.AndNot(deletedSeriesIDs)
+				if seriesIDs.IsEmpty() {
+					continue
+				}
+			}
+
 			hk := uint32(highKey) << 16
 			// flush tag value id=>series ids mapping
 			if err := m.invertedFlusher.
 				FlushInvertedIndex(encoding.ValueWithHighLowBits(hk, lowTagValueID), seriesIDs); err != nil {
 				return nil, err
 			}
+			flushed++
 			seriesIDs.Clear() // clear target series ids
 		}
 	}
+	if flushed == 0 {
+		// all series under tag key are deleted, purge tag key data
+		return nil, nil
+	}
 	if err := m.invertedFlusher.FlushTagKeyID(key); err != nil {
 		return nil, err
 	}
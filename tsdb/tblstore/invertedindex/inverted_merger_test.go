@@ -84,6 +84,28 @@ func TestInvertedMerger_Merge(t *testing.T) {
 	assert.Nil(t, data)
 }
 
+func TestInvertedMerger_Merge_tombstone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tombstone := kv.NewMockTombstone(ctrl)
+	merge := NewInvertedMerger()
+	merge.Init(map[string]interface{}{kv.TombstoneContext: tombstone})
+	// case 1: purge deleted series
+	tombstone.EXPECT().DeletedValues(uint32(1)).Return(roaring.BitmapOf(2, 10, 8000000))
+	data, err := merge.Merge(1, mockInvertedMergeData())
+	assert.NoError(t, err)
+	reader, err := newTagInvertedReader(data)
+	assert.NoError(t, err)
+	assert.EqualValues(t, roaring.BitmapOf(1, 3, 4, 5, 6, 7, 9000000).ToArray(), reader.keys.ToArray())
+	seriesIDs, _ := reader.getSeriesIDsByTagValueIDs(roaring.BitmapOf(1))
+	assert.EqualValues(t, roaring.BitmapOf(1).ToArray(), seriesIDs.ToArray())
+	// case 2: all series deleted
+	tombstone.EXPECT().DeletedValues(uint32(1)).Return(roaring.BitmapOf(1, 2, 3, 4, 5, 6, 7, 10, 30, 8000000, 9000000))
+	data, err = merge.Merge(1, mockInvertedMergeData())
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
 func mockInvertedMergeData() (data [][]byte) {
 	nopKVFlusher := kv.NewNopFlusher()
 	seriesFlusher := NewInvertedFlusher(nopKVFlusher)
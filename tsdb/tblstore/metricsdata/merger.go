@@ -50,6 +50,7 @@ type merger struct {
 	flusher      *kv.NopFlusher
 	seriesMerger SeriesMerger
 	rollup       kv.Rollup
+	tombstone    kv.Tombstone
 }
 
 // NewMerger creates a metric data merger
@@ -63,12 +64,17 @@ func NewMerger() kv.Merger {
 	}
 }
 
-// Init initializes metric data merger, if rollup context exist do rollup job, else do compact job
+// Init initializes metric data merger, if rollup context exist do rollup job, else do compact job,
+// if tombstone context exist purges the deleted series data.
 func (m *merger) Init(params map[string]interface{}) {
 	rollupCtx, ok := params[kv.RollupContext]
 	if ok {
 		m.rollup = rollupCtx.(kv.Rollup)
 	}
+	tombstoneCtx, ok := params[kv.TombstoneContext]
+	if ok {
+		m.tombstone = tombstoneCtx.(kv.Tombstone)
+	}
 }
 
 // Merge merges the multi metric data into one target metric data for same metric id
@@ -79,6 +85,14 @@ func (m *merger) Merge(key uint32, values [][]byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	var deletedSeriesIDs *roaring.Bitmap
+	if m.tombstone != nil {
+		deletedSeriesIDs = m.tombstone.DeletedValues(key)
+		if deletedSeriesIDs != nil && roaring.AndNot(mergeCtx.seriesIDs, deletedSeriesIDs).IsEmpty() {
+			// all series of metric are deleted, purge metric data
+			return nil, nil
+		}
+	}
 	// 2. flush fields
 	m.dataFlusher.FlushFieldMetas(mergeCtx.targetFields)
 	// 3. merge series data by roaring container
@@ -109,11 +123,16 @@ func (m *merger) Merge(key uint32, values [][]byte) ([]byte, error) {
 					}
 				}
 			}
+			seriesID := encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID)
+			if deletedSeriesIDs != nil && deletedSeriesIDs.Contains(seriesID) {
+				// series is deleted, purge series data
+				continue
+			}
 			if err := m.seriesMerger.merge(mergeCtx, decodeStreams, encodeStream, fieldReaders); err != nil {
 				return nil, err
 			}
 			// flush series id
-			m.dataFlusher.FlushSeries(encoding.ValueWithHighLowBits(uint32(highKey)<<16, lowSeriesID))
+			m.dataFlusher.FlushSeries(seriesID)
 		}
 	}
 	// flush metric data
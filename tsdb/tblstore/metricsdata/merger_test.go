@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv"
@@ -126,6 +127,41 @@ func TestMerger_Rollup_Merge(t *testing.T) {
 	assert.False(t, len(data) > 0) // data flush is mock
 }
 
+func TestMerger_Tombstone_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tombstone := kv.NewMockTombstone(ctrl)
+	flusher := NewMockFlusher(ctrl)
+	seriesMerger := NewMockSeriesMerger(ctrl)
+	merge := NewMerger()
+	merge.Init(map[string]interface{}{kv.TombstoneContext: tombstone})
+
+	m := merge.(*merger)
+	m.dataFlusher = flusher
+	m.seriesMerger = seriesMerger
+	blocks := [][]byte{
+		mockMetricMergeBlock([]uint32{1, 2, 4}, 10, 10),
+		mockMetricMergeBlock([]uint32{2, 20}, 15, 15),
+	}
+	// case 1: purge deleted series
+	tombstone.EXPECT().DeletedValues(uint32(1)).Return(roaring.BitmapOf(2, 20))
+	flusher.EXPECT().FlushFieldMetas(gomock.Any()).AnyTimes()
+	seriesMerger.EXPECT().merge(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).Times(2)
+	gomock.InOrder(
+		flusher.EXPECT().FlushSeries(uint32(1)),
+		flusher.EXPECT().FlushSeries(uint32(4)),
+		flusher.EXPECT().FlushMetric(uint32(1), uint16(10), uint16(15)).Return(nil),
+	)
+	_, err := merge.Merge(1, blocks)
+	assert.NoError(t, err)
+	// case 2: all series deleted
+	tombstone.EXPECT().DeletedValues(uint32(1)).Return(roaring.BitmapOf(1, 2, 4, 20))
+	data, err := merge.Merge(1, blocks)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
 func mockMetricMergeBlock(seriesIDs []uint32, start, end uint16) []byte {
 	nopKVFlusher := kv.NewNopFlusher()
 	flusher := NewFlusher(nopKVFlusher)
@@ -0,0 +1,62 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/option"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+var (
+	outOfWindowCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_write_out_of_window",
+			Help: "The number of dropped/rejected writes which timestamp is out of time window(behind/ahead).",
+		},
+		[]string{"db", "metric", "reason"},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(outOfWindowCounter)
+}
+
+// checkTimeWindow checks if the timestamp of metric is in acceptable time range(behind/ahead),
+// returns accept=false if the metric need to be dropped, returns ErrOutOfTimeWindow if rejected by policy.
+// The late data need to be written by backfill path(backfill=true) if the out of window policy is backfill.
+func (s *shard) checkTimeWindow(metric *pb.Metric, now int64) (accept, backfill bool, err error) {
+	reason := option.CheckTimeWindow(metric.Timestamp, now, s.behind.Int64(), s.ahead.Int64())
+	if reason == "" {
+		return true, false, nil
+	}
+	if s.option.IsBackfill(reason) {
+		return false, true, nil
+	}
+	outOfWindowCounter.WithLabelValues(s.databaseName, metric.Name, reason).Inc()
+	if s.option.OutOfWindow == option.OutOfWindowReject {
+		return false, false, fmt.Errorf("%s(%s), metric: %s, timestamp: %d",
+			constants.ErrOutOfTimeWindow, reason, metric.Name, metric.Timestamp)
+	}
+	return false, false, nil
+}
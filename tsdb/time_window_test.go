@@ -0,0 +1,71 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func TestShard_checkTimeWindow(t *testing.T) {
+	s := &shard{databaseName: "db", id: 1}
+	now := timeutil.Now()
+	late := &pb.Metric{Name: "cpu", Timestamp: now - 2*timeutil.OneMinute}
+	early := &pb.Metric{Name: "cpu", Timestamp: now + 2*timeutil.OneMinute}
+	// case 1: no time window
+	accept, backfill, err := s.checkTimeWindow(late, now)
+	assert.True(t, accept)
+	assert.False(t, backfill)
+	assert.NoError(t, err)
+
+	_ = s.behind.ValueOf("1m")
+	_ = s.ahead.ValueOf("1m")
+	// case 2: in time window
+	accept, _, err = s.checkTimeWindow(&pb.Metric{Name: "cpu", Timestamp: now}, now)
+	assert.True(t, accept)
+	assert.NoError(t, err)
+	// case 3: drop
+	accept, backfill, err = s.checkTimeWindow(late, now)
+	assert.False(t, accept)
+	assert.False(t, backfill)
+	assert.NoError(t, err)
+	accept, backfill, err = s.checkTimeWindow(early, now)
+	assert.False(t, accept)
+	assert.False(t, backfill)
+	assert.NoError(t, err)
+	// case 4: reject
+	s.option = option.DatabaseOption{OutOfWindow: option.OutOfWindowReject}
+	accept, _, err = s.checkTimeWindow(late, now)
+	assert.False(t, accept)
+	assert.Error(t, err)
+	// case 5: backfill late data, drop early data
+	s.option = option.DatabaseOption{OutOfWindow: option.OutOfWindowBackfill}
+	accept, backfill, err = s.checkTimeWindow(late, now)
+	assert.False(t, accept)
+	assert.True(t, backfill)
+	assert.NoError(t, err)
+	accept, backfill, err = s.checkTimeWindow(early, now)
+	assert.False(t, accept)
+	assert.False(t, backfill)
+	assert.NoError(t, err)
+}
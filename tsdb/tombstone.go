@@ -0,0 +1,213 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/stream"
+)
+
+// for testing
+var (
+	readFileFunc  = ioutil.ReadFile
+	writeFileFunc = ioutil.WriteFile
+	renameFunc    = os.Rename
+)
+
+const (
+	tombstoneDir       = "tombstone"
+	metricTombstone    = "metric"
+	indexTombstone     = "index"
+	tagMetaTombstone   = "tag_meta"
+	tombstoneTmpSuffix = ".tmp"
+)
+
+// tombstone implements kv.Tombstone, records the deleted keys(metric id/tag key id)
+// and the deleted values(series ids) under key, the compaction job of kv store purges the deleted data.
+// tombstone is persisted into file, so that the deleted data cannot be read again after restart.
+type tombstone struct {
+	path   string
+	keys   *roaring.Bitmap            // deleted keys
+	values map[uint32]*roaring.Bitmap // key => deleted value ids
+
+	rwMutex sync.RWMutex
+}
+
+// newTombstone creates the tombstone, loads the deleted data if tombstone file exist
+func newTombstone(path string) (*tombstone, error) {
+	t := &tombstone{
+		path:   path,
+		keys:   roaring.New(),
+		values: make(map[uint32]*roaring.Bitmap),
+	}
+	if !fileutil.Exist(path) {
+		return t, nil
+	}
+	data, err := readFileFunc(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.unmarshal(data); err != nil {
+		return nil, fmt.Errorf("load tombstone file[%s] error:%s", path, err)
+	}
+	return t, nil
+}
+
+// IsDeleted returns if all values of the key are deleted
+func (t *tombstone) IsDeleted(key uint32) bool {
+	t.rwMutex.RLock()
+	defer t.rwMutex.RUnlock()
+
+	return t.keys.Contains(key)
+}
+
+// DeletedValues returns the deleted value ids(like series ids) under the key, returns nil if not exist,
+// NOTICE: the returned bitmap is read only.
+func (t *tombstone) DeletedValues(key uint32) *roaring.Bitmap {
+	t.rwMutex.RLock()
+	defer t.rwMutex.RUnlock()
+
+	return t.values[key]
+}
+
+// deleteKeys marks all values of the keys are deleted, then persists tombstone
+func (t *tombstone) deleteKeys(keys ...uint32) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	t.rwMutex.Lock()
+	defer t.rwMutex.Unlock()
+
+	// copy on write, because bitmap maybe read by query/compaction
+	deletedKeys := t.keys.Clone()
+	deletedKeys.AddMany(keys)
+	return t.persist(deletedKeys, t.values)
+}
+
+// deleteValues marks the values under the key are deleted, then persists tombstone
+func (t *tombstone) deleteValues(key uint32, values *roaring.Bitmap) error {
+	if values == nil || values.IsEmpty() {
+		return nil
+	}
+	t.rwMutex.Lock()
+	defer t.rwMutex.Unlock()
+
+	// copy on write, because bitmap maybe read by query/compaction
+	deletedValues := make(map[uint32]*roaring.Bitmap, len(t.values)+1)
+	for k, v := range t.values {
+		deletedValues[k] = v
+	}
+	if exist, ok := t.values[key]; ok {
+		deletedValues[key] = roaring.Or(exist, values)
+	} else {
+		deletedValues[key] = values.Clone()
+	}
+	return t.persist(t.keys, deletedValues)
+}
+
+// backup copies the tombstone file into the target file if tombstone file exist
+func (t *tombstone) backup(target string) error {
+	t.rwMutex.RLock()
+	defer t.rwMutex.RUnlock()
+
+	if !fileutil.Exist(t.path) {
+		return nil
+	}
+	if err := mkDirIfNotExist(filepath.Dir(target)); err != nil {
+		return err
+	}
+	return copyFile(t.path, target)
+}
+
+// persist writes the tombstone data into temp file, then renames it to tombstone file,
+// sets the new deleted data if persist successfully.
+func (t *tombstone) persist(keys *roaring.Bitmap, values map[uint32]*roaring.Bitmap) error {
+	data, err := marshalTombstone(keys, values)
+	if err != nil {
+		return err
+	}
+	if err := fileutil.MkDirIfNotExist(filepath.Dir(t.path)); err != nil {
+		return err
+	}
+	tmp := t.path + tombstoneTmpSuffix
+	if err := writeFileFunc(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := renameFunc(tmp, t.path); err != nil {
+		return err
+	}
+	t.keys = keys
+	t.values = values
+	return nil
+}
+
+// unmarshal parses the tombstone data from binary
+func (t *tombstone) unmarshal(data []byte) error {
+	reader := stream.NewReader(data)
+	keys := roaring.New()
+	if err := keys.UnmarshalBinary(reader.ReadSlice(int(reader.ReadUvarint32()))); err != nil {
+		return err
+	}
+	count := int(reader.ReadUvarint32())
+	values := make(map[uint32]*roaring.Bitmap, count)
+	for i := 0; i < count; i++ {
+		key := reader.ReadUint32()
+		value := roaring.New()
+		if err := value.UnmarshalBinary(reader.ReadSlice(int(reader.ReadUvarint32()))); err != nil {
+			return err
+		}
+		values[key] = value
+	}
+	if err := reader.Error(); err != nil {
+		return err
+	}
+	t.keys = keys
+	t.values = values
+	return nil
+}
+
+// marshalTombstone marshals the tombstone data into binary, format:
+// keys bitmap length(uvarint) + keys bitmap + count of values(uvarint) + [key(uint32) + values bitmap length(uvarint) + values bitmap]
+func marshalTombstone(keys *roaring.Bitmap, values map[uint32]*roaring.Bitmap) ([]byte, error) {
+	writer := stream.NewBufferWriter(nil)
+	keysData, err := keys.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	writer.PutUvarint32(uint32(len(keysData)))
+	writer.PutBytes(keysData)
+	writer.PutUvarint32(uint32(len(values)))
+	for key, value := range values {
+		valueData, err := value.ToBytes()
+		if err != nil {
+			return nil, err
+		}
+		writer.PutUint32(key)
+		writer.PutUvarint32(uint32(len(valueData)))
+		writer.PutBytes(valueData)
+	}
+	return writer.Bytes()
+}
@@ -0,0 +1,138 @@
+// Licensed to LinDB under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. LinDB licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tsdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestTombstone_Delete(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	path := filepath.Join(testPath, tombstoneDir, metricTombstone)
+	ts, err := newTombstone(path)
+	assert.NoError(t, err)
+	assert.False(t, ts.IsDeleted(1))
+	assert.Nil(t, ts.DeletedValues(1))
+	// empty delete
+	assert.NoError(t, ts.deleteKeys())
+	assert.NoError(t, ts.deleteValues(1, nil))
+	assert.NoError(t, ts.deleteValues(1, roaring.New()))
+	assert.False(t, fileutil.Exist(path))
+
+	assert.NoError(t, ts.deleteKeys(1, 2))
+	assert.NoError(t, ts.deleteValues(3, roaring.BitmapOf(1, 2)))
+	assert.NoError(t, ts.deleteValues(3, roaring.BitmapOf(5)))
+	assert.NoError(t, ts.deleteValues(4, roaring.BitmapOf(10)))
+	assert.True(t, ts.IsDeleted(1))
+	assert.True(t, ts.IsDeleted(2))
+	assert.False(t, ts.IsDeleted(3))
+	assert.Equal(t, []uint32{1, 2, 5}, ts.DeletedValues(3).ToArray())
+
+	// reload tombstone
+	ts, err = newTombstone(path)
+	assert.NoError(t, err)
+	assert.True(t, ts.IsDeleted(1))
+	assert.True(t, ts.IsDeleted(2))
+	assert.Equal(t, []uint32{1, 2, 5}, ts.DeletedValues(3).ToArray())
+	assert.Equal(t, []uint32{10}, ts.DeletedValues(4).ToArray())
+}
+
+func TestTombstone_persist_fail(t *testing.T) {
+	defer func() {
+		writeFileFunc = ioutil.WriteFile
+		renameFunc = os.Rename
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	path := filepath.Join(testPath, tombstoneDir, indexTombstone)
+	ts, err := newTombstone(path)
+	assert.NoError(t, err)
+	// case 1: write file err
+	writeFileFunc = func(filename string, data []byte, perm os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, ts.deleteKeys(1))
+	assert.False(t, ts.IsDeleted(1))
+	writeFileFunc = ioutil.WriteFile
+	// case 2: rename err
+	renameFunc = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, ts.deleteValues(1, roaring.BitmapOf(1)))
+	assert.Nil(t, ts.DeletedValues(1))
+}
+
+func TestTombstone_load_fail(t *testing.T) {
+	defer func() {
+		readFileFunc = ioutil.ReadFile
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	path := filepath.Join(testPath, tombstoneDir, tagMetaTombstone)
+	ts, err := newTombstone(path)
+	assert.NoError(t, err)
+	assert.NoError(t, ts.deleteKeys(1))
+	// case 1: read file err
+	readFileFunc = func(filename string) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	ts, err = newTombstone(path)
+	assert.Error(t, err)
+	assert.Nil(t, ts)
+	// case 2: corrupted file
+	readFileFunc = func(filename string) ([]byte, error) {
+		return []byte{1, 2, 3}, nil
+	}
+	ts, err = newTombstone(path)
+	assert.Error(t, err)
+	assert.Nil(t, ts)
+}
+
+func TestTombstone_backup(t *testing.T) {
+	defer func() {
+		mkDirIfNotExist = fileutil.MkDirIfNotExist
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	path := filepath.Join(testPath, tombstoneDir, metricTombstone)
+	target := filepath.Join(testPath, "backup", metricTombstone)
+	ts, err := newTombstone(path)
+	assert.NoError(t, err)
+	// tombstone file not exist
+	assert.NoError(t, ts.backup(target))
+	assert.False(t, fileutil.Exist(target))
+
+	assert.NoError(t, ts.deleteKeys(1))
+	assert.NoError(t, ts.backup(target))
+	ts, err = newTombstone(target)
+	assert.NoError(t, err)
+	assert.True(t, ts.IsDeleted(1))
+
+	mkDirIfNotExist = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, ts.backup(target))
+}
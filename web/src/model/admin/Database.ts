@@ -5,10 +5,16 @@ export interface DatabaseConfig {
     replicaFactor?: number
     option?: {
         interval?: string
+        rollup?: string[]
+        ttl?: string
+        rollupTTL?: string[]
         timeWindow?: number
         autoCreateNS?: boolean
+        namespaces?: string[]
         behind?: string
         ahead?: string
+        outOfWindow?: string
+        writeConsistency?: string
         index: {
             timeThreshold?: number
             sizeThreshold?: number